@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/leaanthony/clir"
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/cmd/wails/flags"
+	"github.com/wailsapp/wails/v2/internal/colour"
+	"github.com/wailsapp/wails/v2/internal/nativedeps"
+)
+
+func addAnalyzeCommands(app *clir.Cli) {
+	analyze := app.NewSubCommand("analyze", "Analyses built applications")
+
+	depsFlags := &flags.AnalyzeDeps{}
+	deps := analyze.NewSubCommand("deps", "Reports the native libraries and minimum OS versions that a binary requires")
+	deps.AddFlags(depsFlags)
+	deps.Action(func() error {
+		return analyzeDeps(depsFlags, deps.OtherArgs())
+	})
+}
+
+func analyzeDeps(f *flags.AnalyzeDeps, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: wails analyze deps <binary>")
+	}
+	if f.NoColour {
+		pterm.DisableColor()
+		colour.ColourEnabled = false
+	}
+
+	report, err := nativedeps.Analyze(args[0])
+	if err != nil {
+		return err
+	}
+
+	if f.JSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		err = printDepsReport(args[0], report)
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.Baseline == "" {
+		return nil
+	}
+	data, err := os.ReadFile(f.Baseline)
+	if err != nil {
+		return err
+	}
+	var baseline nativedeps.Report
+	err = json.Unmarshal(data, &baseline)
+	if err != nil {
+		return fmt.Errorf("unable to read the baseline %s: %w", f.Baseline, err)
+	}
+	changes := nativedeps.Compare(&baseline, report)
+	for _, change := range changes {
+		pterm.Error.Println(change)
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("the requirements of %s are higher than in %s", args[0], f.Baseline)
+	}
+	if !f.JSON {
+		pterm.Success.Printfln("The requirements match %s", f.Baseline)
+	}
+	return nil
+}
+
+func printDepsReport(path string, report *nativedeps.Report) error {
+	pterm.DefaultSection.Println(path)
+	tableData := pterm.TableData{
+		{"Format", report.Format},
+		{"Architecture", report.Arch},
+	}
+	if report.GoVersion != "" {
+		tableData = append(tableData, []string{"Go Version", report.GoVersion})
+	}
+	if report.WailsVersion != "" {
+		tableData = append(tableData, []string{"Wails Version", report.WailsVersion})
+	}
+	if report.BuildTags != "" {
+		tableData = append(tableData, []string{"Build Tags", report.BuildTags})
+	}
+	err := pterm.DefaultTable.WithData(tableData).Render()
+	if err != nil {
+		return err
+	}
+
+	pterm.DefaultSection.Println("Requirements")
+	requirementsData := pterm.TableData{{"Name", "Minimum Version", "Source"}}
+	for _, requirement := range report.Requirements {
+		version := requirement.Version
+		if version == "" {
+			version = "any"
+		}
+		requirementsData = append(requirementsData, []string{requirement.Name, version, requirement.Source})
+	}
+	err = pterm.DefaultTable.WithHasHeader(true).WithData(requirementsData).Render()
+	if err != nil {
+		return err
+	}
+
+	pterm.DefaultSection.Println("Libraries")
+	for _, library := range report.Libraries {
+		printBulletPoint("%s", library)
+	}
+	return nil
+}
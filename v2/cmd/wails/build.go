@@ -18,6 +18,12 @@ import (
 )
 
 func buildApplication(f *flags.Build) error {
+	_, err := buildTargets(f)
+	return err
+}
+
+// buildTargets builds the application for all platforms and returns the compiled binaries by platform
+func buildTargets(f *flags.Build) (map[string]string, error) {
 	if f.NoColour {
 		pterm.DisableColor()
 		colour.ColourEnabled = false
@@ -35,18 +41,26 @@ func buildApplication(f *flags.Build) error {
 		app.PrintBanner()
 	}
 
-	err := f.Process()
+	cwd, err := os.Getwd()
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	cwd, err := os.Getwd()
+	projectOptions, err := project.LoadEnvironment(cwd, "prod")
 	if err != nil {
-		return err
+		return nil, err
 	}
-	projectOptions, err := project.Load(cwd)
+
+	if f.Profile != "" {
+		profile, err := projectOptions.GetBuildProfile(f.Profile)
+		if err != nil {
+			return nil, err
+		}
+		f.ApplyProfile(profile)
+	}
+
+	err = f.Process()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Set obfuscation from project file
@@ -85,6 +99,11 @@ func buildApplication(f *flags.Build) error {
 		GarbleArgs:        f.GarbleArgs,
 		SkipBindings:      f.SkipBindings,
 		ProjectData:       projectOptions,
+		BuildProfile:      f.Profile,
+		BuildFlags:        f.GetBuildFlags(),
+		AssetPolicies:     f.GetAssetPolicies(),
+		Offline:           f.Offline,
+		OfflineStore:      f.OfflineStore,
 	}
 
 	tableData := pterm.TableData{
@@ -92,6 +111,7 @@ func buildApplication(f *flags.Build) error {
 		{"Compiler", f.GetCompilerPath()},
 		{"Skip Bindings", bool2Str(f.SkipBindings)},
 		{"Build Mode", f.GetBuildModeAsString()},
+		{"Build Profile", f.Profile},
 		{"Devtools", bool2Str(buildOptions.Devtools)},
 		{"Frontend Directory", projectOptions.GetFrontendDir()},
 		{"Obfuscated", bool2Str(f.Obfuscated)},
@@ -101,6 +121,7 @@ func buildApplication(f *flags.Build) error {
 	}
 	tableData = append(tableData, pterm.TableData{
 		{"Skip Frontend", bool2Str(f.SkipFrontend)},
+		{"Offline", bool2Str(f.Offline)},
 		{"Compress", bool2Str(f.Upx)},
 		{"Package", bool2Str(!f.NoPackage)},
 		{"Clean Bin Dir", bool2Str(f.Clean)},
@@ -115,13 +136,13 @@ func buildApplication(f *flags.Build) error {
 
 	err = pterm.DefaultTable.WithData(tableData).Render()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !f.NoSyncGoMod {
 		err = gomod.SyncGoMod(logger, f.UpdateWailsVersionGoMod)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -244,24 +265,24 @@ func buildApplication(f *flags.Build) error {
 	})
 
 	if targetErr != nil {
-		return targetErr
+		return nil, targetErr
 	}
 
 	if f.DryRun {
-		return nil
+		return outputBinaries, nil
 	}
 
 	if f.NSIS {
 		amd64Binary := outputBinaries["windows/amd64"]
 		arm64Binary := outputBinaries["windows/arm64"]
 		if amd64Binary == "" && arm64Binary == "" {
-			return fmt.Errorf("cannot build nsis installer - no windows targets")
+			return nil, fmt.Errorf("cannot build nsis installer - no windows targets")
 		}
 
 		if err := build.GenerateNSISInstaller(buildOptions, amd64Binary, arm64Binary); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return outputBinaries, nil
 }
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/leaanthony/clir"
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/cmd/wails/flags"
+	"github.com/wailsapp/wails/v2/internal/colour"
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+func addConfigCommands(app *clir.Cli) {
+	config := app.NewSubCommand("config", "Validates, reads and writes the project config")
+	config.NewSubCommandFunction("validate", "Validates wails.json and the overlays of the environments", validateConfig)
+
+	getFlags := &flags.Config{}
+	get := config.NewSubCommand("get", "Prints the value of a key, EG: wails config get bindings.ts_generation.outputType")
+	get.AddFlags(getFlags)
+	get.Action(func() error {
+		return getConfig(getFlags, get.OtherArgs())
+	})
+
+	setFlags := &flags.Config{}
+	set := config.NewSubCommand("set", "Sets the value of a key, EG: wails config set bindings.ts_generation.outputType interfaces")
+	set.AddFlags(setFlags)
+	set.Action(func() error {
+		return setConfig(setFlags, set.OtherArgs())
+	})
+}
+
+func configFilename(f *flags.Config) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if f.Env != "" {
+		return project.OverlayFilename(cwd, f.Env), nil
+	}
+	return filepath.Join(cwd, "wails.json"), nil
+}
+
+func validateConfig(f *flags.Config) error {
+	if f.NoColour {
+		pterm.DisableColor()
+		colour.ColourEnabled = false
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	filenames := []string{filepath.Join(cwd, "wails.json")}
+	if f.Env != "" {
+		filenames = append(filenames, project.OverlayFilename(cwd, f.Env))
+	} else {
+		overlays, _ := filepath.Glob(filepath.Join(cwd, "wails.*.json"))
+		sort.Strings(overlays)
+		filenames = append(filenames, overlays...)
+	}
+
+	problems := 0
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		configErrors := project.Validate(data)
+		for _, configError := range configErrors {
+			pterm.Error.Printfln("%s:%s", filepath.Base(filename), configError)
+		}
+		if len(configErrors) == 0 {
+			pterm.Success.Printfln("%s is valid", filepath.Base(filename))
+		}
+		problems += len(configErrors)
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problems in the project config", problems)
+	}
+	return nil
+}
+
+func getConfig(f *flags.Config, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: wails config get <key>")
+	}
+	filename, err := configFilename(f)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	value, err := project.GetConfigValue(data, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Strings are printed without quotes for the use in scripts
+	var text string
+	if json.Unmarshal(value, &text) == nil {
+		fmt.Println(text)
+		return nil
+	}
+	fmt.Println(string(value))
+	return nil
+}
+
+func setConfig(f *flags.Config, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: wails config set <key> <value>")
+	}
+	filename, err := configFilename(f)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) && f.Env != "" {
+		data = []byte("{}\n")
+	} else if err != nil {
+		return err
+	}
+
+	// Values that aren't valid JSON are set as strings
+	value := json.RawMessage(args[1])
+	if !json.Valid(value) {
+		value, _ = json.Marshal(args[1])
+	}
+	data, err = project.SetConfigValue(data, args[0], value)
+	if err != nil {
+		return err
+	}
+	if configErrors := project.Validate(data); len(configErrors) > 0 {
+		return fmt.Errorf("%s:%s", filepath.Base(filename), configErrors[0])
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/leaanthony/clir"
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/cmd/wails/flags"
+	"github.com/wailsapp/wails/v2/internal/colour"
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+func addDebugCommands(app *clir.Cli) {
+	debug := app.NewSubCommand("debug", "Profiles an application that is running with wails dev")
+	debug.NewSubCommandFunction("heap", "Takes a heap snapshot of the running application", debugHeap)
+	debug.NewSubCommandFunction("goroutines", "Prints the stacks of the goroutines of the running application", debugGoroutines)
+	debug.NewSubCommandFunction("pprof", "Prints the URLs of the profiles of the running application", debugPprof)
+}
+
+// debugURL returns the URL of the debug tools of the dev server
+func debugURL(f *flags.Debug) string {
+	if f.NoColour {
+		pterm.DisableColor()
+		colour.ColourEnabled = false
+	}
+	devServer := f.DevServer
+	if devServer == "" {
+		devServer = "localhost:34115"
+		if cwd, err := os.Getwd(); err == nil {
+			if proj, err := project.Load(cwd); err == nil {
+				devServer = proj.DevServer
+			}
+		}
+	}
+	return "http://" + devServer + "/wails/debug"
+}
+
+var debugClient = &http.Client{Timeout: time.Minute}
+
+func debugRequest(method string, url string) ([]byte, error) {
+	request, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := debugClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach the application, is it running with wails dev? %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s is not served, the application has to be updated to the current version of Wails", url)
+	}
+	if response.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%s is only served to the local machine", url)
+	}
+	return body, nil
+}
+
+func debugHeap(f *flags.Debug) error {
+	data, err := debugRequest(http.MethodPost, debugURL(f)+"/heapsnapshot")
+	if err != nil {
+		return err
+	}
+	var snapshot struct {
+		Path  string `json:"path"`
+		Error string `json:"error"`
+	}
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return err
+	}
+	if snapshot.Error != "" {
+		return fmt.Errorf("unable to take the heap snapshot: %s", snapshot.Error)
+	}
+	pterm.Success.Printfln("Heap snapshot saved to %s", snapshot.Path)
+	pterm.Println("Analyse it with: go tool pprof " + snapshot.Path)
+	return nil
+}
+
+func debugGoroutines(f *flags.Debug) error {
+	data, err := debugRequest(http.MethodGet, debugURL(f)+"/pprof/goroutine?debug=2")
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+func debugPprof(f *flags.Debug) error {
+	url := debugURL(f)
+	_, err := debugRequest(http.MethodGet, url+"/pprof/")
+	if err != nil {
+		return err
+	}
+	pterm.Println("Debug tools:     " + url + "/")
+	pterm.Println("CPU profile:     go tool pprof " + url + "/pprof/profile?seconds=30")
+	pterm.Println("Heap profile:    go tool pprof " + url + "/pprof/heap")
+	pterm.Println("Goroutines:      go tool pprof " + url + "/pprof/goroutine")
+	pterm.Println("Execution trace: curl -o trace.out " + url + "/pprof/trace?seconds=5")
+	return nil
+}
@@ -0,0 +1,7 @@
+package flags
+
+type AnalyzeDeps struct {
+	Common
+	JSON     bool   `name:"json" description:"Print the report as JSON, EG: to store it as a baseline"`
+	Baseline string `description:"Fail if the requirements are higher than in the JSON report"`
+}
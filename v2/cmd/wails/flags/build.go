@@ -8,9 +8,12 @@ import (
 	"strings"
 
 	"github.com/leaanthony/slicer"
+	"github.com/samber/lo"
+	"github.com/wailsapp/wails/v2/internal/project"
 	"github.com/wailsapp/wails/v2/internal/system"
 	"github.com/wailsapp/wails/v2/pkg/commands/build"
 	"github.com/wailsapp/wails/v2/pkg/commands/buildtags"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 )
 
 const (
@@ -39,16 +42,24 @@ type Build struct {
 	TrimPath                bool   `description:"Remove all file system paths from the resulting executable"`
 	WindowsConsole          bool   `description:"Keep the console when building for Windows"`
 	Obfuscated              bool   `description:"Code obfuscation of bound Wails methods"`
+	Obfuscate               bool   `description:"Alias of -obfuscated"`
 	GarbleArgs              string `description:"Arguments to pass to garble"`
 	DryRun                  bool   `description:"Prints the build command without executing it"`
+	Profile                 string `description:"Build profile of the project config to use, EG: store"`
+	Offline                 bool   `description:"Install the frontend dependencies from the lockfile without network access, verifying its integrity hashes"`
+	OfflineStore            string `description:"The npm cache, pnpm store or Yarn cache to install the frontend dependencies from when offline"`
 
 	// Build Specific
 
 	// Internal state
-	compilerPath  string
-	userTags      []string
-	wv2rtstrategy string // WebView2 runtime strategy
-	defaultArch   string // Default architecture
+	compilerPath    string
+	userTags        []string
+	wv2rtstrategy   string // WebView2 runtime strategy
+	defaultArch     string // Default architecture
+	defaultPlatform string // Default platform/architecture
+	profileTags     string
+	buildFlags      map[string]string
+	assetPolicies   []assetserver.Policy
 }
 
 func (b *Build) Default() *Build {
@@ -70,7 +81,8 @@ func (b *Build) Default() *Build {
 		WebView2:   "download",
 		GarbleArgs: "-literals -tiny -seed=random",
 
-		defaultArch: defaultArch,
+		defaultArch:     defaultArch,
+		defaultPlatform: defaultPlatform + "/" + defaultArch,
 	}
 	result.BuildCommon = result.BuildCommon.Default()
 	return result
@@ -102,7 +114,45 @@ func (b *Build) GetTags() []string {
 	return b.userTags
 }
 
+// ApplyProfile sets the options of the build profile that haven't been given on the command line
+func (b *Build) ApplyProfile(profile *project.BuildProfile) {
+	if profile.Platform != "" && b.Platform == b.defaultPlatform {
+		b.Platform = profile.Platform
+	}
+	if profile.OutputFilename != "" && b.OutputFilename == "" {
+		b.OutputFilename = profile.OutputFilename
+	}
+	if profile.WebView2 != "" && b.WebView2 == "download" {
+		b.WebView2 = profile.WebView2
+	}
+	b.profileTags = profile.Tags
+	b.LdFlags = strings.TrimSpace(profile.LdFlags + " " + b.LdFlags)
+
+	b.Debug = b.Debug || profile.Debug
+	b.Devtools = b.Devtools || profile.Devtools
+	b.Obfuscated = b.Obfuscated || profile.Obfuscated
+	b.TrimPath = b.TrimPath || profile.TrimPath
+	b.Upx = b.Upx || profile.Upx
+	b.NSIS = b.NSIS || profile.NSIS
+	b.WindowsConsole = b.WindowsConsole || profile.WindowsConsole
+	b.SkipBindings = b.SkipBindings || profile.SkipBindings
+	b.buildFlags = profile.Flags
+	b.assetPolicies = profile.Assets
+}
+
+// GetBuildFlags returns the flags of the build profile
+func (b *Build) GetBuildFlags() map[string]string {
+	return b.buildFlags
+}
+
+// GetAssetPolicies returns the asset policies of the build profile
+func (b *Build) GetAssetPolicies() []assetserver.Policy {
+	return b.assetPolicies
+}
+
 func (b *Build) Process() error {
+	b.Obfuscated = b.Obfuscated || b.Obfuscate
+
 	// Lookup compiler path
 	var err error
 	b.compilerPath, err = exec.LookPath(b.Compiler)
@@ -115,6 +165,15 @@ func (b *Build) Process() error {
 	if err != nil {
 		return err
 	}
+	profileTags, err := buildtags.Parse(b.profileTags)
+	if err != nil {
+		return fmt.Errorf("invalid tags of the build profile: %w", err)
+	}
+	b.userTags = lo.Uniq(append(profileTags, b.userTags...))
+
+	if b.OfflineStore != "" && !b.Offline {
+		return fmt.Errorf("-offlinestore needs -offline")
+	}
 
 	// WebView2 installer strategy (download by default)
 	b.WebView2 = strings.ToLower(b.WebView2)
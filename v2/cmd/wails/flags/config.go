@@ -0,0 +1,6 @@
+package flags
+
+type Config struct {
+	Common
+	Env string `description:"Use the overlay of the environment, EG: dev for wails.dev.json"`
+}
@@ -0,0 +1,6 @@
+package flags
+
+type Debug struct {
+	Common
+	DevServer string `flag:"devserver" description:"The address of the dev server of the running app. Defaults to devServer of wails.json"`
+}
@@ -31,6 +31,8 @@ type Dev struct {
 	AppArgs              string `flag:"appargs" description:"arguments to pass to the underlying app (quoted and space separated)"`
 	Save                 bool   `flag:"save" description:"Save the given flags as defaults"`
 	FrontendDevServerURL string `flag:"frontenddevserverurl" description:"The url of the external frontend dev server to use"`
+	Delve                bool   `flag:"delve" description:"Run the application under Delve in headless mode so a debugger can attach"`
+	DelveAddr            string `flag:"delveaddr" description:"The address Delve listens on"`
 
 	// Internal state
 	devServerURL  *url.URL
@@ -41,6 +43,7 @@ func (*Dev) Default() *Dev {
 	result := &Dev{
 		Extensions: "go",
 		Debounce:   100,
+		DelveAddr:  "127.0.0.1:2345",
 	}
 	result.BuildCommon = result.BuildCommon.Default()
 	return result
@@ -62,6 +65,12 @@ func (d *Dev) Process() error {
 		return err
 	}
 
+	if d.Delve {
+		if _, _, err := net.SplitHostPort(d.DelveAddr); err != nil {
+			return fmt.Errorf("DelveAddr is not of the form 'host:port'")
+		}
+	}
+
 	return nil
 }
 
@@ -71,7 +80,7 @@ func (d *Dev) loadAndMergeProjectConfig() error {
 	if err != nil {
 		return err
 	}
-	d.projectConfig, err = project.Load(cwd)
+	d.projectConfig, err = project.LoadEnvironment(cwd, "dev")
 	if err != nil {
 		return err
 	}
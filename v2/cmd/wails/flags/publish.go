@@ -0,0 +1,9 @@
+package flags
+
+type Publish struct {
+	Common
+	Target  string `description:"Store to publish to: mas, msstore or snap"`
+	Upload  bool   `description:"Uploads the artifact with the CLI of the store"`
+	NoBuild bool   `description:"Packages the output of the last build instead of building"`
+	Report  bool   `description:"Only prints the requirements and the sandbox-compatibility report"`
+}
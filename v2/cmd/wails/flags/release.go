@@ -0,0 +1,10 @@
+package flags
+
+type Release struct {
+	Common
+	Bump    string `description:"Part of the version to raise: major, minor or patch. Default: derived from the commits"`
+	Version string `description:"Version of the release, overrides -bump"`
+	DryRun  bool   `description:"Prints the version and the release notes without changing anything"`
+	NoBuild bool   `description:"Skips building and packaging the platforms"`
+	NoTag   bool   `description:"Skips committing the version and creating the tag"`
+}
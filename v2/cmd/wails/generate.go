@@ -48,11 +48,13 @@ func generateModule(f *flags.GenerateModule) error {
 	}
 
 	_, err = bindings.GenerateBindings(bindings.Options{
-		Compiler:     f.Compiler,
-		Tags:         buildTags,
-		TsPrefix:     projectConfig.Bindings.TsGeneration.Prefix,
-		TsSuffix:     projectConfig.Bindings.TsGeneration.Suffix,
-		TsOutputType: projectConfig.Bindings.TsGeneration.OutputType,
+		Compiler:      f.Compiler,
+		Tags:          buildTags,
+		TsPrefix:      projectConfig.Bindings.TsGeneration.Prefix,
+		TsSuffix:      projectConfig.Bindings.TsGeneration.Suffix,
+		TsOutputType:  projectConfig.Bindings.TsGeneration.OutputType,
+		TsNullability: projectConfig.Bindings.TsGeneration.Nullability,
+		JSONSchema:    projectConfig.Bindings.JSONSchema,
 	})
 	if err != nil {
 		return err
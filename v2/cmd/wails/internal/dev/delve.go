@@ -0,0 +1,75 @@
+package dev
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os/exec"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/process"
+)
+
+// delveTimeout limits the time to connect to Delve and to detach from the application
+const delveTimeout = 2 * time.Second
+
+// detachIn and detachOut are the arguments of the Detach method of the JSON-RPC API of Delve
+type detachIn struct {
+	Kill bool
+}
+
+type detachOut struct{}
+
+// findDelve returns the path of dlv
+func findDelve() (string, error) {
+	dlv, err := exec.LookPath("dlv")
+	if err != nil {
+		return "", errors.New("unable to find Delve, install it with 'go install github.com/go-delve/delve/cmd/dlv@latest'")
+	}
+	return dlv, nil
+}
+
+// delveArgs returns the arguments of dlv that run the binary headless. The application runs without waiting for a
+// debugger, which can attach and detach any time with the JSON-RPC API or DAP.
+func delveArgs(addr string, binary string, args []string) []string {
+	result := []string{"exec", binary, "--headless", "--listen=" + addr, "--api-version=2", "--accept-multiclient", "--continue"}
+	if len(args) > 0 {
+		result = append(result, "--")
+		result = append(result, args...)
+	}
+	return result
+}
+
+// newDelveProcess returns the process of Delve running the binary. Killing it lets Delve kill the application, which
+// would keep running when Delve is killed on its own, and frees the address for the next session.
+func newDelveProcess(dlv string, addr string, binary string, args []string) *process.Process {
+	result := process.NewProcess(dlv, delveArgs(addr, binary, args)...)
+	result.Stop = func() error {
+		return detachDelve(addr)
+	}
+	return result
+}
+
+// detachDelve asks the Delve server to kill the application and to exit
+func detachDelve(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, delveTimeout)
+	if err != nil {
+		return err
+	}
+	client := jsonrpc.NewClient(conn)
+	defer client.Close()
+	call := client.Go("RPCServer.Detach", detachIn{Kill: true}, &detachOut{}, nil)
+	select {
+	case <-call.Done:
+		// Delve can exit before it responds
+		if errors.Is(call.Error, rpc.ErrShutdown) || errors.Is(call.Error, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		return call.Error
+	case <-time.After(delveTimeout):
+		return fmt.Errorf("timed out detaching Delve at %s", addr)
+	}
+}
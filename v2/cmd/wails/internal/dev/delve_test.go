@@ -0,0 +1,62 @@
+package dev
+
+import (
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"reflect"
+	"testing"
+)
+
+func Test_delveArgs(t *testing.T) {
+	got := delveArgs("127.0.0.1:2345", "build/bin/app", []string{"-port", "8080"})
+	want := []string{"exec", "build/bin/app", "--headless", "--listen=127.0.0.1:2345", "--api-version=2", "--accept-multiclient", "--continue", "--", "-port", "8080"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("delveArgs() = %v, want %v", got, want)
+	}
+	if got := delveArgs("127.0.0.1:2345", "app", nil); got[len(got)-1] != "--continue" {
+		t.Errorf("delveArgs() = %v, want no application arguments", got)
+	}
+}
+
+// fakeDelve is the RPCServer of the JSON-RPC API of Delve. net/rpc requires exported argument types.
+type fakeDelve struct {
+	detached chan DetachIn
+}
+
+type DetachIn struct {
+	Kill bool
+}
+
+type DetachOut struct{}
+
+func (d *fakeDelve) Detach(in DetachIn, out *DetachOut) error {
+	d.detached <- in
+	return nil
+}
+
+func Test_detachDelve(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	delve := &fakeDelve{detached: make(chan DetachIn, 1)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("RPCServer", delve); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			server.ServeCodec(jsonrpc.NewServerCodec(conn))
+		}
+	}()
+
+	if err := detachDelve(listener.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+	if in := <-delve.detached; !in.Kill {
+		t.Error("Delve was detached without killing the application")
+	}
+}
@@ -68,6 +68,12 @@ func Application(f *flags.Dev, logger *clilogger.CLILogger) error {
 		}
 	}
 
+	if f.Delve {
+		if _, err := findDelve(); err != nil {
+			return err
+		}
+	}
+
 	buildOptions := f.GenerateBuildOptions()
 	buildOptions.Logger = logger
 
@@ -118,6 +124,16 @@ func Application(f *flags.Dev, logger *clilogger.CLILogger) error {
 		return fmt.Errorf("unable to auto discover frontend:dev:serverUrl without a frontend:dev:watcher command, please either set frontend:dev:watcher or remove the auto discovery from frontend:dev:serverUrl")
 	}
 
+	// frontend:dev:servers, the application serves their paths from them
+	if len(projectConfig.FrontendDevServers) > 0 {
+		closer, routes, err := runFrontendDevServers(projectConfig.Path, projectConfig.FrontendDevServers)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		os.Setenv("frontenddevservers", encodeDevServerRoutes(routes))
+	}
+
 	// Do initial build but only for the application.
 	logger.Println("Building application for development...")
 	buildOptions.IgnoreFrontend = true
@@ -310,6 +326,13 @@ func restartApp(buildOptions *build.Options, debugBinaryProcess *process.Process
 
 	// Start up new binary with correct args
 	newProcess := process.NewProcess(appBinary, args...)
+	if f.Delve {
+		dlv, err := findDelve()
+		if err != nil {
+			buildOptions.Logger.Fatal("%s", err.Error())
+		}
+		newProcess = newDelveProcess(dlv, f.DelveAddr, appBinary, args)
+	}
 	err = newProcess.Start(exitCodeChannel)
 	if err != nil {
 		// Remove binary
@@ -321,6 +344,9 @@ func restartApp(buildOptions *build.Options, debugBinaryProcess *process.Process
 		}
 		buildOptions.Logger.Fatal("Unable to start application: %s", err.Error())
 	}
+	if f.Delve {
+		logutils.LogGreen("Delve is listening on %s. Attach with 'dlv connect %s' or a DAP client, EG: a remote attach configuration of VS Code", f.DelveAddr, f.DelveAddr)
+	}
 
 	return newProcess, appBinary, nil
 }
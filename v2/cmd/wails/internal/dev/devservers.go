@@ -0,0 +1,178 @@
+package dev
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/cmd/wails/internal/logutils"
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+// devServerReadyTimeout is the time a dev server has to listen on its port
+const devServerReadyTimeout = time.Minute
+
+// runFrontendDevServers starts the additional frontend dev servers of `frontend:dev:servers` and waits until they
+// listen on their ports. It returns the URLs of the dev servers by path, which are passed to the application.
+func runFrontendDevServers(projectDir string, devServers []project.DevServer) (func(), map[string]string, error) {
+	var closers []func()
+	closeAll := func() {
+		for _, closer := range closers {
+			closer()
+		}
+	}
+
+	output := &prefixedOutput{writer: os.Stdout}
+	routes := map[string]string{}
+	for _, devServer := range devServers {
+		if devServer.Command == "" || devServer.Path == "" {
+			closeAll()
+			return nil, nil, fmt.Errorf("the frontend dev server %q needs a command and a path", devServer.Name)
+		}
+		if _, exists := routes[devServer.Path]; exists {
+			closeAll()
+			return nil, nil, fmt.Errorf("the path %s of the frontend dev server %q is already used", devServer.Path, devServer.Name)
+		}
+		port := devServer.Port
+		if port == 0 {
+			var err error
+			port, err = allocatePort()
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("unable to allocate a port for the frontend dev server %q: %w", devServer.Name, err)
+			}
+		}
+
+		closer, err := runFrontendDevServer(projectDir, devServer, port, output)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		closers = append(closers, closer)
+
+		address := net.JoinHostPort("localhost", strconv.Itoa(port))
+		if !waitForPort(address, devServerReadyTimeout) {
+			closeAll()
+			return nil, nil, fmt.Errorf("timeout waiting for the frontend dev server %q on port %d", devServer.Name, port)
+		}
+		routes[devServer.Path] = "http://" + address
+		logutils.LogGreen("Serving %s from frontend dev server %q: http://%s", devServer.Path, devServer.Name, address)
+	}
+	return closeAll, routes, nil
+}
+
+// runFrontendDevServer starts the command of the dev server with the output prefixed with its name
+func runFrontendDevServer(projectDir string, devServer project.DevServer, port int, output *prefixedOutput) (func(), error) {
+	command := strings.ReplaceAll(devServer.Command, "$PORT", strconv.Itoa(port))
+	ctx, cancel := context.WithCancel(context.Background())
+	cmdSlice := strings.Split(command, " ")
+	cmd := exec.CommandContext(ctx, cmdSlice[0], cmdSlice[1:]...)
+	cmd.Dir = devServer.GetDir(projectDir)
+	cmd.Env = append(os.Environ(), "PORT="+strconv.Itoa(port))
+	prefix := devServer.Name
+	if prefix == "" {
+		prefix = devServer.Path
+	}
+	cmd.Stdout = output.prefixed(prefix)
+	cmd.Stderr = output.prefixed(prefix)
+	setParentGID(cmd)
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("unable to start the frontend dev server %q: %w", devServer.Name, err)
+	}
+	logutils.LogGreen("Running frontend dev server %q: '%s'", devServer.Name, command)
+
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+
+	return func() {
+		select {
+		case <-done:
+		default:
+			killProc(cmd, command)
+		}
+		cancel()
+		<-done
+	}, nil
+}
+
+// allocatePort returns a free port of localhost
+func allocatePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func waitForPort(address string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		conn, _ := net.DialTimeout("tcp", address, 2*time.Second)
+		if conn != nil {
+			conn.Close()
+			return true
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	return false
+}
+
+// encodeDevServerRoutes encodes the routes for the frontenddevservers environment variable of the application
+func encodeDevServerRoutes(routes map[string]string) string {
+	if len(routes) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(routes)
+	return string(data)
+}
+
+// prefixedOutput multiplexes the output of the dev servers line by line, so that the lines of different dev servers
+// aren't mixed up
+type prefixedOutput struct {
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+func (o *prefixedOutput) prefixed(prefix string) io.Writer {
+	return &prefixWriter{output: o, prefix: []byte("[" + prefix + "] ")}
+}
+
+type prefixWriter struct {
+	output  *prefixedOutput
+	prefix  []byte
+	pending []byte
+}
+
+// Write writes the complete lines and keeps the rest until the line is complete
+func (w *prefixWriter) Write(data []byte) (int, error) {
+	w.pending = append(w.pending, data...)
+	for {
+		index := bytes.IndexByte(w.pending, '\n')
+		if index < 0 {
+			return len(data), nil
+		}
+		line := w.pending[:index+1]
+		w.output.lock.Lock()
+		_, err := w.output.writer.Write(append(append([]byte{}, w.prefix...), line...))
+		w.output.lock.Unlock()
+		w.pending = w.pending[index+1:]
+		if err != nil {
+			return len(data), err
+		}
+	}
+}
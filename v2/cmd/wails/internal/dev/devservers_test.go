@@ -0,0 +1,33 @@
+package dev
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrefixedOutput(t *testing.T) {
+	var buffer bytes.Buffer
+	output := &prefixedOutput{writer: &buffer}
+	main := output.prefixed("main")
+	plugin := output.prefixed("plugin")
+
+	_, _ = main.Write([]byte("ready in "))
+	_, _ = plugin.Write([]byte("listening\nbuilt"))
+	_, _ = main.Write([]byte("300ms\n"))
+	_, _ = plugin.Write([]byte(" index.html\n"))
+
+	expected := "[plugin] listening\n[main] ready in 300ms\n[plugin] built index.html\n"
+	if buffer.String() != expected {
+		t.Errorf("unexpected output:\n%s", buffer.String())
+	}
+}
+
+func TestAllocatePort(t *testing.T) {
+	port, err := allocatePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port == 0 {
+		t.Error("no port has been allocated")
+	}
+}
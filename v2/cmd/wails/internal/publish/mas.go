@@ -0,0 +1,169 @@
+package publish
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+const (
+	appStoreConnectKey    = "APP_STORE_CONNECT_API_KEY"
+	appStoreConnectIssuer = "APP_STORE_CONNECT_API_ISSUER"
+)
+
+var bundleID = regexp.MustCompile(`^[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)+$`)
+
+// mas submits the application bundle to the Mac App Store. The bundle is signed with the entitlements of the App
+// Sandbox and the provisioning profile, and packaged into an installer package for App Store Connect.
+type mas struct {
+	options             *project.Project
+	outputDir           string
+	config              project.PublishMAS
+	entitlements        string
+	provisioningProfile string
+}
+
+func newMAS(options *project.Project, outputDir string, config *project.PublishMAS) *mas {
+	if config == nil {
+		config = &project.PublishMAS{}
+	}
+	return &mas{
+		options:             options,
+		outputDir:           outputDir,
+		config:              *config,
+		entitlements:        projectPath(options, config.Entitlements, "darwin", "entitlements.plist"),
+		provisioningProfile: projectPath(options, config.ProvisioningProfile, "darwin", "embedded.provisionprofile"),
+	}
+}
+
+func (m *mas) Check(upload bool) []Check {
+	checks := []Check{
+		checkIf("Platform", runtime.GOOS == "darwin", "Mac App Store builds can only be signed on macOS"),
+		checkIf("Bundle identifier", bundleID.MatchString(m.config.BundleID), "publish.mas.bundleId '%s' has to be a reverse-DNS identifier, EG: com.example.myapp", m.config.BundleID),
+		checkVersion(m.options.Info.ProductVersion),
+		m.checkEntitlements(),
+		m.checkProvisioningProfile(),
+		checkIf("Application identity", m.config.ApplicationIdentity != "", "publish.mas.applicationIdentity is not set, EG: 3rd Party Mac Developer Application: Example Ltd (TEAMID)"),
+		checkIf("Installer identity", m.config.InstallerIdentity != "", "publish.mas.installerIdentity is not set, EG: 3rd Party Mac Developer Installer: Example Ltd (TEAMID)"),
+		checkIf("codesign", shell.CommandExists("codesign"), "codesign is not installed, install the Xcode command line tools"),
+		checkIf("productbuild", shell.CommandExists("productbuild"), "productbuild is not installed, install the Xcode command line tools"),
+	}
+	if upload {
+		checks = append(checks,
+			checkIf("xcrun", shell.CommandExists("xcrun"), "xcrun is not installed, install Xcode"),
+			checkIf("App Store Connect API key", os.Getenv(appStoreConnectKey) != "" && os.Getenv(appStoreConnectIssuer) != "",
+				"%s and %s have to be set to the key ID and the issuer ID of an App Store Connect API key", appStoreConnectKey, appStoreConnectIssuer),
+		)
+	}
+	return checks
+}
+
+func (m *mas) checkEntitlements() Check {
+	entitlements, err := m.readEntitlements()
+	if err != nil {
+		return check("App Sandbox", "%s: %s", filepath.ToSlash(m.entitlements), err.Error())
+	}
+	return checkIf("App Sandbox", entitlements["com.apple.security.app-sandbox"] == true, "%s has to enable com.apple.security.app-sandbox", filepath.ToSlash(m.entitlements))
+}
+
+func (m *mas) checkProvisioningProfile() Check {
+	data, err := os.ReadFile(m.provisioningProfile)
+	if err != nil {
+		return check("Provisioning profile", "%s does not exist, download the Mac App Store profile of the bundle identifier", filepath.ToSlash(m.provisioningProfile))
+	}
+	profile, err := embeddedPlist(data)
+	if err != nil {
+		return check("Provisioning profile", "%s: %s", filepath.ToSlash(m.provisioningProfile), err.Error())
+	}
+	entitlements, _ := profile["Entitlements"].(map[string]interface{})
+	identifier, _ := entitlements["com.apple.application-identifier"].(string)
+	return checkIf("Provisioning profile", m.config.BundleID != "" && strings.HasSuffix(identifier, "."+m.config.BundleID),
+		"%s is the profile of %s, not of %s", filepath.ToSlash(m.provisioningProfile), identifier, m.config.BundleID)
+}
+
+func (m *mas) readEntitlements() (map[string]interface{}, error) {
+	data, err := os.ReadFile(m.entitlements)
+	if err != nil {
+		return nil, errors.New("the entitlements do not exist")
+	}
+	return parsePlist(data)
+}
+
+// Granted returns the entitlements that are enabled
+func (m *mas) Granted() ([]string, error) {
+	entitlements, err := m.readEntitlements()
+	if err != nil {
+		return nil, err
+	}
+	var granted []string
+	for name, value := range entitlements {
+		if value == true {
+			granted = append(granted, name)
+		}
+	}
+	sort.Strings(granted)
+	return granted, nil
+}
+
+func (m *mas) Package(output string) (string, error) {
+	if !strings.HasSuffix(output, ".app") {
+		return "", fmt.Errorf("%s is not an application bundle", output)
+	}
+	data, err := os.ReadFile(filepath.Join(output, "Contents", "Info.plist"))
+	if err != nil {
+		return "", err
+	}
+	info, err := parsePlist(data)
+	if err != nil {
+		return "", fmt.Errorf("the Info.plist of the bundle: %w", err)
+	}
+	if identifier, _ := info["CFBundleIdentifier"].(string); identifier != m.config.BundleID {
+		return "", fmt.Errorf("the CFBundleIdentifier of build/darwin/Info.plist is %s, it has to be the bundle identifier %s", identifier, m.config.BundleID)
+	}
+	if category, _ := info["LSApplicationCategoryType"].(string); !strings.HasPrefix(category, "public.app-category.") {
+		return "", errors.New("build/darwin/Info.plist has to set LSApplicationCategoryType, EG: public.app-category.productivity")
+	}
+
+	err = copyFile(m.provisioningProfile, filepath.Join(output, "Contents", "embedded.provisionprofile"))
+	if err != nil {
+		return "", err
+	}
+	_, stderr, err := shell.RunCommand(m.options.Path, "codesign", "--force", "--timestamp", "--sign", m.config.ApplicationIdentity, "--entitlements", m.entitlements, output)
+	if err != nil {
+		return "", fmt.Errorf("codesign failed: %s", strings.TrimSpace(stderr))
+	}
+
+	err = os.MkdirAll(m.outputDir, 0o755)
+	if err != nil {
+		return "", err
+	}
+	artifact := filepath.Join(m.outputDir, strings.TrimSuffix(filepath.Base(output), ".app")+".pkg")
+	_, stderr, err = shell.RunCommand(m.options.Path, "productbuild", "--component", output, "/Applications", "--sign", m.config.InstallerIdentity, artifact)
+	if err != nil {
+		return "", fmt.Errorf("productbuild failed: %s", strings.TrimSpace(stderr))
+	}
+	return artifact, nil
+}
+
+// Upload uploads the package to App Store Connect. altool looks up the private key AuthKey_<key>.p8 in
+// ./private_keys or ~/.appstoreconnect/private_keys.
+func (m *mas) Upload(artifact string) error {
+	return shell.RunCommandVerbose(m.options.Path, "xcrun", "altool", "--upload-app", "--type", "macos", "--file", artifact,
+		"--apiKey", os.Getenv(appStoreConnectKey), "--apiIssuer", os.Getenv(appStoreConnectIssuer))
+}
+
+func copyFile(source string, target string) error {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0o644)
+}
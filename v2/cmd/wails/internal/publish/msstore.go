@@ -0,0 +1,259 @@
+package publish
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+	"github.com/wailsapp/wails/v2/internal/shell"
+	"golang.org/x/image/draw"
+)
+
+// restrictedCapabilities are declared in the rescap namespace of the manifest
+var restrictedCapabilities = map[string]bool{"runFullTrust": true, "allowElevation": true}
+
+var identityName = regexp.MustCompile(`^[A-Za-z0-9.-]{3,50}$`)
+
+// msstoreLogos are the logos of the manifest by their size, they are scaled from build/appicon.png
+var msstoreLogos = []struct {
+	name string
+	size int
+}{
+	{"StoreLogo.png", 50},
+	{"Square44x44Logo.png", 44},
+	{"Square150x150Logo.png", 150},
+}
+
+const appxManifest = `<?xml version="1.0" encoding="utf-8"?>
+<Package xmlns="http://schemas.microsoft.com/appx/manifest/foundation/windows10"
+         xmlns:uap="http://schemas.microsoft.com/appx/manifest/uap/windows10"
+         xmlns:rescap="http://schemas.microsoft.com/appx/manifest/foundation/windows10/restrictedcapabilities"
+         IgnorableNamespaces="uap rescap">
+  <Identity Name="{{xml .IdentityName}}" Publisher="{{xml .Publisher}}" Version="{{.Version}}" ProcessorArchitecture="x64"/>
+  <Properties>
+    <DisplayName>{{xml .DisplayName}}</DisplayName>
+    <PublisherDisplayName>{{xml .PublisherDisplayName}}</PublisherDisplayName>
+    <Logo>Assets\StoreLogo.png</Logo>
+  </Properties>
+  <Dependencies>
+    <TargetDeviceFamily Name="Windows.Desktop" MinVersion="10.0.17763.0" MaxVersionTested="10.0.22621.0"/>
+  </Dependencies>
+  <Resources>
+    <Resource Language="en-us"/>
+  </Resources>
+  <Applications>
+    <Application Id="App" Executable="{{xml .Executable}}" EntryPoint="Windows.FullTrustApplication">
+      <uap:VisualElements DisplayName="{{xml .DisplayName}}" Description="{{xml .Description}}" BackgroundColor="transparent"
+                          Square150x150Logo="Assets\Square150x150Logo.png" Square44x44Logo="Assets\Square44x44Logo.png"/>
+{{- if or .Protocols .FileAssociations}}
+      <Extensions>
+{{- range .Protocols}}
+        <uap:Extension Category="windows.protocol">
+          <uap:Protocol Name="{{xml .Scheme}}">
+            <uap:DisplayName>{{xml .Description}}</uap:DisplayName>
+          </uap:Protocol>
+        </uap:Extension>
+{{- end}}
+{{- range .FileAssociations}}
+        <uap:Extension Category="windows.fileTypeAssociation">
+          <uap:FileTypeAssociation Name="{{xml .Name}}">
+            <uap:DisplayName>{{xml .Description}}</uap:DisplayName>
+            <uap:SupportedFileTypes>
+              <uap:FileType>{{xml .Ext}}</uap:FileType>
+            </uap:SupportedFileTypes>
+          </uap:FileTypeAssociation>
+        </uap:Extension>
+{{- end}}
+      </Extensions>
+{{- end}}
+    </Application>
+  </Applications>
+  <Capabilities>
+{{- range .Capabilities}}
+    <Capability Name="{{xml .}}"/>
+{{- end}}
+{{- range .RestrictedCapabilities}}
+    <rescap:Capability Name="{{xml .}}"/>
+{{- end}}
+  </Capabilities>
+</Package>
+`
+
+var appxManifestTemplate = template.Must(template.New("AppxManifest.xml").Funcs(template.FuncMap{
+	"xml": func(value string) string {
+		var escaped bytes.Buffer
+		_ = xml.EscapeText(&escaped, []byte(value))
+		return escaped.String()
+	},
+}).Parse(appxManifest))
+
+// msstore submits the application to the Microsoft Store as an MSIX package. The package is not signed, the store
+// signs it when it is submitted.
+type msstore struct {
+	options   *project.Project
+	outputDir string
+	config    project.PublishMSStore
+	services  []string
+}
+
+func newMSStore(options *project.Project, outputDir string, config *project.PublishMSStore, services []string) *msstore {
+	if config == nil {
+		config = &project.PublishMSStore{}
+	}
+	return &msstore{options: options, outputDir: outputDir, config: *config, services: services}
+}
+
+func (m *msstore) Check(upload bool) []Check {
+	checks := []Check{
+		checkIf("Platform", runtime.GOOS == "windows", "MSIX packages can only be made on Windows"),
+		checkIf("Identity name", identityName.MatchString(m.config.IdentityName), "publish.msstore.identityName '%s' has to be the Package/Identity/Name of Partner Center", m.config.IdentityName),
+		checkIf("Publisher", strings.HasPrefix(m.config.Publisher, "CN="), "publish.msstore.publisher '%s' has to be the Package/Identity/Publisher of Partner Center, EG: CN=00000000-0000-0000-0000-000000000000", m.config.Publisher),
+		checkIf("Publisher display name", m.config.PublisherDisplayName != "", "publish.msstore.publisherDisplayName is not set"),
+		checkVersion(m.options.Info.ProductVersion),
+		checkFile("Application icon", filepath.Join(m.options.GetBuildDir(), "appicon.png")),
+		checkIf("makeappx", shell.CommandExists("makeappx"), "makeappx is not installed, install the Windows SDK and add its bin directory to PATH"),
+	}
+	if upload {
+		checks = append(checks,
+			checkIf("Product ID", m.config.ProductID != "", "publish.msstore.productId is not set, it is the Store ID of Partner Center"),
+			checkIf("msstore", shell.CommandExists("msstore"), "msstore is not installed, install the Microsoft Store Developer CLI and run msstore reconfigure"),
+		)
+	}
+	return checks
+}
+
+// Granted returns the capabilities of the generated manifest, which declares the capabilities the services require
+func (m *msstore) Granted() ([]string, error) {
+	return m.capabilities(), nil
+}
+
+func (m *msstore) capabilities() []string {
+	return append(RequiredPermissions(TargetMSStore, m.services), "runFullTrust")
+}
+
+// AppxManifest returns the manifest of the package
+func (m *msstore) AppxManifest() ([]byte, error) {
+	data := struct {
+		project.PublishMSStore
+		Version                string
+		DisplayName            string
+		Description            string
+		Executable             string
+		Protocols              []project.Protocol
+		FileAssociations       []project.FileAssociation
+		Capabilities           []string
+		RestrictedCapabilities []string
+	}{
+		PublishMSStore: m.config,
+		Version:        MSIXVersion(m.options.Info.ProductVersion),
+		DisplayName:    m.options.Info.ProductName,
+		Description:    m.options.Info.ProductName,
+		Executable:     m.options.OutputFilename,
+		Protocols:      m.options.Info.Protocols,
+	}
+	if m.options.Info.Comments != nil && *m.options.Info.Comments != "" {
+		data.Description = *m.options.Info.Comments
+	}
+	for _, association := range m.options.Info.FileAssociations {
+		// The names of the associations have to be lower case and the extensions start with a dot
+		association.Name = strings.ToLower(association.Name)
+		association.Ext = "." + strings.TrimPrefix(association.Ext, ".")
+		data.FileAssociations = append(data.FileAssociations, association)
+	}
+	for _, capability := range m.capabilities() {
+		if restrictedCapabilities[capability] {
+			data.RestrictedCapabilities = append(data.RestrictedCapabilities, capability)
+		} else {
+			data.Capabilities = append(data.Capabilities, capability)
+		}
+	}
+	var manifest bytes.Buffer
+	err := appxManifestTemplate.Execute(&manifest, data)
+	return manifest.Bytes(), err
+}
+
+func (m *msstore) Package(output string) (string, error) {
+	packageDir := filepath.Join(m.outputDir, "package")
+	err := os.RemoveAll(packageDir)
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(filepath.Join(packageDir, "Assets"), 0o755)
+	if err != nil {
+		return "", err
+	}
+	err = copyFile(output, filepath.Join(packageDir, m.options.OutputFilename))
+	if err != nil {
+		return "", err
+	}
+	manifest, err := m.AppxManifest()
+	if err != nil {
+		return "", err
+	}
+	err = os.WriteFile(filepath.Join(packageDir, "AppxManifest.xml"), manifest, 0o644)
+	if err != nil {
+		return "", err
+	}
+	err = writeLogos(filepath.Join(m.options.GetBuildDir(), "appicon.png"), filepath.Join(packageDir, "Assets"))
+	if err != nil {
+		return "", err
+	}
+
+	artifact := filepath.Join(m.outputDir, strings.TrimSuffix(m.options.OutputFilename, ".exe")+".msix")
+	_, stderr, err := shell.RunCommand(m.outputDir, "makeappx", "pack", "/d", packageDir, "/p", artifact, "/o")
+	if err != nil {
+		return "", fmt.Errorf("makeappx failed: %s", strings.TrimSpace(stderr))
+	}
+	return artifact, nil
+}
+
+// Upload submits the package with the Microsoft Store Developer CLI, which has to be configured with the credentials
+// of Partner Center by `msstore reconfigure`
+func (m *msstore) Upload(artifact string) error {
+	return shell.RunCommandVerbose(m.options.Path, "msstore", "publish", artifact, "-id", m.config.ProductID)
+}
+
+// MSIXVersion returns the four part version of MSIX packages, of which the last part is reserved for the store
+func MSIXVersion(version string) string {
+	parts := strings.Split(version, ".")
+	for len(parts) < 4 {
+		parts = append(parts, "0")
+	}
+	parts[3] = "0"
+	return strings.Join(parts[:4], ".")
+}
+
+func writeLogos(icon string, assetsDir string) error {
+	file, err := os.Open(icon)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	source, err := png.Decode(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", icon, err)
+	}
+	for _, logo := range msstoreLogos {
+		scaled := image.NewRGBA(image.Rect(0, 0, logo.size, logo.size))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), source, source.Bounds(), draw.Src, nil)
+		var encoded bytes.Buffer
+		err = png.Encode(&encoded, scaled)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(filepath.Join(assetsDir, logo.name), encoded.Bytes(), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package publish
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// parsePlist returns the top-level dictionary of an XML property list. Booleans are bool, dictionaries are maps,
+// arrays are slices and every other value is its text.
+func parsePlist(data []byte) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, errors.New("the property list has no dictionary")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "dict" {
+			return decodePlistDict(decoder)
+		}
+	}
+}
+
+func decodePlistDict(decoder *xml.Decoder) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	key := ""
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Local == "key" {
+				if err := decoder.DecodeElement(&key, &token); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			value, err := decodePlistValue(decoder, token)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		case xml.EndElement:
+			return result, nil
+		}
+	}
+}
+
+func decodePlistValue(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "true", "false":
+		return start.Name.Local == "true", decoder.Skip()
+	case "dict":
+		return decodePlistDict(decoder)
+	case "array":
+		var result []interface{}
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch token := token.(type) {
+			case xml.StartElement:
+				value, err := decodePlistValue(decoder, token)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, value)
+			case xml.EndElement:
+				return result, nil
+			}
+		}
+	default:
+		var text string
+		err := decoder.DecodeElement(&text, &start)
+		return text, err
+	}
+}
+
+// embeddedPlist returns the property list of a provisioning profile, which is signed but not encrypted
+func embeddedPlist(data []byte) (map[string]interface{}, error) {
+	start := bytes.Index(data, []byte("<?xml"))
+	end := bytes.Index(data, []byte("</plist>"))
+	if start < 0 || end < start {
+		return nil, errors.New("the provisioning profile has no property list")
+	}
+	return parsePlist(data[start : end+len("</plist>")])
+}
@@ -0,0 +1,144 @@
+package publish
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+// Target is a store the application can be submitted to
+type Target string
+
+const (
+	TargetMAS     Target = "mas"
+	TargetMSStore Target = "msstore"
+	TargetSnap    Target = "snap"
+)
+
+// Targets are the supported stores
+var Targets = []Target{TargetMAS, TargetMSStore, TargetSnap}
+
+// ParseTarget parses the target of `wails publish -target`
+func ParseTarget(target string) (Target, error) {
+	for _, t := range Targets {
+		if string(t) == strings.ToLower(target) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown target '%s', expected mas, msstore or snap", target)
+}
+
+// Name returns the name of the store
+func (t Target) Name() string {
+	switch t {
+	case TargetMAS:
+		return "Mac App Store"
+	case TargetMSStore:
+		return "Microsoft Store"
+	default:
+		return "Snap Store"
+	}
+}
+
+// Platform returns the platform the store builds are made for. The stores can only be packaged on that platform, as
+// the tools of the stores are only available there.
+func (t Target) Platform() string {
+	switch t {
+	case TargetMAS:
+		return "darwin/universal"
+	case TargetMSStore:
+		return "windows/amd64"
+	default:
+		return "linux/amd64"
+	}
+}
+
+// Store checks, packages and uploads the store builds of a project
+type Store interface {
+	// Check returns the requirements of the store, including the ones of uploading if upload is set
+	Check(upload bool) []Check
+	// Granted returns the entitlements, the plugs or the capabilities of the store build
+	Granted() ([]string, error)
+	// Package makes the artifact of the store from the output of the build and returns its path
+	Package(output string) (string, error)
+	// Upload submits the artifact to the store
+	Upload(artifact string) error
+}
+
+// New returns the store of the target for the project. Services are the services the project uses.
+func New(target Target, options *project.Project, services []string) Store {
+	config := options.Publish
+	if config == nil {
+		config = &project.Publish{}
+	}
+	outputDir := filepath.Join(options.GetBuildDir(), "publish", string(target))
+	switch target {
+	case TargetMAS:
+		return newMAS(options, outputDir, config.MAS)
+	case TargetMSStore:
+		return newMSStore(options, outputDir, config.MSStore, services)
+	default:
+		return newSnap(options, outputDir, config.Snap)
+	}
+}
+
+// Check is a requirement of a store, Problem is empty if the requirement is met
+type Check struct {
+	Requirement string
+	Problem     string
+}
+
+// Failed returns the checks that have a problem
+func Failed(checks []Check) []Check {
+	var result []Check
+	for _, check := range checks {
+		if check.Problem != "" {
+			result = append(result, check)
+		}
+	}
+	return result
+}
+
+func check(requirement string, problem string, args ...interface{}) Check {
+	if problem != "" && len(args) > 0 {
+		problem = fmt.Sprintf(problem, args...)
+	}
+	return Check{Requirement: requirement, Problem: problem}
+}
+
+// checkIf returns a check that fails with the problem if the condition is false
+func checkIf(requirement string, condition bool, problem string, args ...interface{}) Check {
+	if condition {
+		return check(requirement, "")
+	}
+	return check(requirement, problem, args...)
+}
+
+// checkFile checks that the file of the project exists
+func checkFile(requirement string, path string) Check {
+	_, err := os.Stat(path)
+	return checkIf(requirement, err == nil, "%s does not exist", filepath.ToSlash(path))
+}
+
+var storeVersion = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// checkVersion checks that the version only has up to three numbers, which every store accepts
+func checkVersion(version string) Check {
+	return checkIf("Version", storeVersion.MatchString(version), "info.productVersion '%s' has to be up to three numbers, EG: 1.2.3", version)
+}
+
+// projectPath returns the path of the project config relative to the project directory, or the fallback of the
+// build directory if it is not set
+func projectPath(options *project.Project, path string, fallback ...string) string {
+	if path == "" {
+		return filepath.Join(append([]string{options.GetBuildDir()}, fallback...)...)
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(options.Path, path)
+}
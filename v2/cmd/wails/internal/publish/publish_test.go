@@ -0,0 +1,225 @@
+package publish
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testProject(t *testing.T) *project.Project {
+	dir := t.TempDir()
+	return &project.Project{Path: dir, BuildDir: "build", Name: "myapp", OutputFilename: "myapp.exe", Info: project.Info{ProductName: "My App & Co", ProductVersion: "1.2"}}
+}
+
+func TestScanServices(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.go"), `package main
+
+import (
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/updater"
+)
+`)
+	writeFile(t, filepath.Join(dir, "internal", "devices.go"), `package internal
+
+import bt "github.com/wailsapp/wails/v2/pkg/bluetooth"
+`)
+	writeFile(t, filepath.Join(dir, "frontend", "node_modules", "x", "x.go"), `package x
+
+import "github.com/wailsapp/wails/v2/pkg/elevate"
+`)
+
+	services, err := ScanServices(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"bluetooth", "options", "updater"}; !reflect.DeepEqual(services, want) {
+		t.Errorf("ScanServices() = %v, want %v", services, want)
+	}
+}
+
+func TestSandboxReport(t *testing.T) {
+	findings := SandboxReport(TargetSnap, []string{"bluetooth", "options", "updater", "zeroconf"}, []string{"network", "bluez"})
+	want := []Finding{
+		{Service: "bluetooth", Permission: "bluez", Granted: true},
+		{Service: "updater", Incompatible: true, Note: sandboxRules[TargetSnap]["updater"].note},
+		{Service: "zeroconf", Permission: "network", Granted: true},
+		{Service: "zeroconf", Permission: "network-bind"},
+	}
+	if !reflect.DeepEqual(findings, want) {
+		t.Fatalf("SandboxReport() = %+v, want %+v", findings, want)
+	}
+	if findings[3].Problem() != "network-bind is not granted" || findings[0].Problem() != "" || findings[1].Problem() == "" {
+		t.Errorf("unexpected problems of %+v", findings)
+	}
+	if got := RequiredPermissions(TargetMAS, []string{"telemetry", "zeroconf"}); !reflect.DeepEqual(got, []string{"com.apple.security.network.client", "com.apple.security.network.server"}) {
+		t.Errorf("RequiredPermissions() = %v", got)
+	}
+}
+
+func TestParsePlist(t *testing.T) {
+	entitlements, err := parsePlist([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>com.apple.security.app-sandbox</key>
+	<true/>
+	<key>com.apple.security.network.server</key>
+	<false/>
+	<key>com.apple.security.application-groups</key>
+	<array>
+		<string>TEAMID.group</string>
+	</array>
+	<key>nested</key>
+	<dict>
+		<key>name</key>
+		<string>value</string>
+	</dict>
+</dict>
+</plist>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"com.apple.security.app-sandbox":        true,
+		"com.apple.security.network.server":     false,
+		"com.apple.security.application-groups": []interface{}{"TEAMID.group"},
+		"nested":                                map[string]interface{}{"name": "value"},
+	}
+	if !reflect.DeepEqual(entitlements, want) {
+		t.Errorf("parsePlist() = %v, want %v", entitlements, want)
+	}
+}
+
+func TestMASChecks(t *testing.T) {
+	options := testProject(t)
+	options.Publish = &project.Publish{MAS: &project.PublishMAS{BundleID: "com.example.myapp"}}
+	writeFile(t, filepath.Join(options.Path, "build", "darwin", "entitlements.plist"), `<plist><dict>
+<key>com.apple.security.app-sandbox</key><true/>
+<key>com.apple.security.network.client</key><true/>
+</dict></plist>`)
+	// Provisioning profiles are CMS messages with the plist in plain text
+	writeFile(t, filepath.Join(options.Path, "build", "darwin", "embedded.provisionprofile"), "0\x80\x06\t*<?xml version=\"1.0\"?><plist><dict>"+
+		"<key>Entitlements</key><dict><key>com.apple.application-identifier</key><string>TEAMID.com.example.other</string></dict>"+
+		"</dict></plist>\x00\x00")
+
+	store := New(TargetMAS, options, []string{"telemetry"})
+	problems := map[string]string{}
+	for _, check := range store.Check(false) {
+		problems[check.Requirement] = check.Problem
+	}
+	for _, requirement := range []string{"Bundle identifier", "Version", "App Sandbox"} {
+		if problems[requirement] != "" {
+			t.Errorf("%s failed: %s", requirement, problems[requirement])
+		}
+	}
+	if !strings.Contains(problems["Provisioning profile"], "TEAMID.com.example.other") {
+		t.Errorf("expected the provisioning profile of another bundle to fail, got %q", problems["Provisioning profile"])
+	}
+	if problems["Application identity"] == "" {
+		t.Error("expected the missing application identity to fail")
+	}
+
+	granted, err := store.Granted()
+	if err != nil || !reflect.DeepEqual(granted, []string{"com.apple.security.app-sandbox", "com.apple.security.network.client"}) {
+		t.Errorf("Granted() = %v, %v", granted, err)
+	}
+}
+
+func TestAppxManifest(t *testing.T) {
+	options := testProject(t)
+	options.Info.Protocols = []project.Protocol{{Scheme: "myapp", Description: "My App"}}
+	options.Info.FileAssociations = []project.FileAssociation{{Ext: "mdx", Name: "Markdown", Description: "Markdown file"}}
+	options.Publish = &project.Publish{MSStore: &project.PublishMSStore{IdentityName: "Example.MyApp", Publisher: "CN=1234", PublisherDisplayName: "Example"}}
+
+	store := New(TargetMSStore, options, []string{"elevate", "telemetry", "zeroconf"}).(*msstore)
+	manifest, err := store.AppxManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<Identity Name="Example.MyApp" Publisher="CN=1234" Version="1.2.0.0" ProcessorArchitecture="x64"/>`,
+		`<DisplayName>My App &amp; Co</DisplayName>`,
+		`Executable="myapp.exe" EntryPoint="Windows.FullTrustApplication"`,
+		`<uap:Protocol Name="myapp">`,
+		`<uap:FileTypeAssociation Name="markdown">`,
+		`<uap:FileType>.mdx</uap:FileType>`,
+		`<Capability Name="internetClient"/>`,
+		`<Capability Name="privateNetworkClientServer"/>`,
+		`<rescap:Capability Name="allowElevation"/>`,
+		`<rescap:Capability Name="runFullTrust"/>`,
+	} {
+		if !strings.Contains(string(manifest), want) {
+			t.Errorf("the manifest has no %s:\n%s", want, manifest)
+		}
+	}
+	if problems := Failed(store.Check(false)); len(problems) == 0 {
+		t.Error("expected the missing application icon to fail")
+	}
+}
+
+func TestMSIXVersion(t *testing.T) {
+	for version, want := range map[string]string{"1": "1.0.0.0", "1.2.3": "1.2.3.0", "1.2.3.4": "1.2.3.0"} {
+		if got := MSIXVersion(version); got != want {
+			t.Errorf("MSIXVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestSnapChecks(t *testing.T) {
+	options := testProject(t)
+	options.Publish = &project.Publish{Snap: &project.PublishSnap{Channel: "stable"}}
+	writeFile(t, filepath.Join(options.Path, "build", "linux", "snap", "snapcraft.yaml"), `name: my-app
+version: "1.2"
+grade: devel
+confinement: strict
+plugs:
+  dot-config:
+    interface: personal-files
+apps:
+  my-app:
+    command: bin/myapp
+    plugs: [network, desktop]
+`)
+
+	store := New(TargetSnap, options, nil)
+	problems := map[string]string{}
+	for _, check := range store.Check(false) {
+		problems[check.Requirement] = check.Problem
+	}
+	for _, requirement := range []string{"Name", "Version", "Confinement", "Apps"} {
+		if problems[requirement] != "" {
+			t.Errorf("%s failed: %s", requirement, problems[requirement])
+		}
+	}
+	if problems["Grade"] == "" {
+		t.Error("expected a devel snap to fail for the stable channel")
+	}
+	granted, err := store.Granted()
+	if err != nil || !reflect.DeepEqual(granted, []string{"desktop", "dot-config", "network"}) {
+		t.Errorf("Granted() = %v, %v", granted, err)
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	if target, err := ParseTarget("MAS"); err != nil || target != TargetMAS {
+		t.Errorf("ParseTarget(MAS) = %v, %v", target, err)
+	}
+	if _, err := ParseTarget("play"); err == nil {
+		t.Error("expected an error for an unknown store")
+	}
+}
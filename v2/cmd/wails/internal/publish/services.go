@@ -0,0 +1,169 @@
+package publish
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const servicesPrefix = "github.com/wailsapp/wails/v2/pkg/"
+
+// ScanServices returns the packages of github.com/wailsapp/wails/v2/pkg that the Go files of the project import, EG:
+// "bluetooth" or "updater"
+func ScanServices(projectDir string) ([]string, error) {
+	seen := map[string]bool{}
+	err := filepath.WalkDir(projectDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != projectDir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor" || name == "build") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil || !strings.HasPrefix(importPath, servicesPrefix) {
+				continue
+			}
+			service, _, _ := strings.Cut(strings.TrimPrefix(importPath, servicesPrefix), "/")
+			seen[service] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	services := make([]string, 0, len(seen))
+	for service := range seen {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// Finding is a service of the sandbox-compatibility report
+type Finding struct {
+	Service string
+	// Permission is the entitlement, plug or capability the service requires, empty if it requires none
+	Permission string
+	// Granted reports if the entitlements, the plugs or the manifest of the store build have the permission
+	Granted bool
+	// Incompatible services don't work in the sandbox of the store or are not allowed by its policies
+	Incompatible bool
+	Note         string
+}
+
+// Problem returns the problem of the finding, empty if the service works in the store build
+func (f Finding) Problem() string {
+	if f.Incompatible {
+		return f.Note
+	}
+	if f.Permission != "" && !f.Granted {
+		return f.Permission + " is not granted"
+	}
+	return ""
+}
+
+type sandboxRule struct {
+	permissions  []string
+	incompatible bool
+	note         string
+}
+
+var sandboxRules = map[Target]map[string]sandboxRule{
+	TargetMAS: {
+		"autostart":    {incompatible: true, note: "Login items have to be registered with SMAppService by the sandboxed application"},
+		"bluetooth":    {permissions: []string{"com.apple.security.device.bluetooth"}},
+		"calendars":    {permissions: []string{"com.apple.security.personal-information.calendars"}},
+		"elevate":      {incompatible: true, note: "Sandboxed applications cannot run privileged processes"},
+		"featureflags": {permissions: []string{"com.apple.security.network.client"}},
+		"fileexplorer": {permissions: []string{"com.apple.security.files.bookmarks.app-scope"}, note: "The bookmarks keep the access to the files chosen in the dialogs"},
+		"permissions":  {note: "Camera and microphone access need com.apple.security.device.camera and com.apple.security.device.audio-input"},
+		"printers":     {permissions: []string{"com.apple.security.print"}},
+		"sidecar":      {note: "The sidecar binaries have to be signed with com.apple.security.inherit"},
+		"subprocess":   {note: "Child processes inherit the sandbox, bundled executables have to be signed with com.apple.security.inherit"},
+		"telemetry":    {permissions: []string{"com.apple.security.network.client"}},
+		"updater":      {incompatible: true, note: "The Mac App Store updates the application, the updater has to be disabled in store builds"},
+		"zeroconf":     {permissions: []string{"com.apple.security.network.client", "com.apple.security.network.server"}},
+	},
+	TargetMSStore: {
+		"autostart":    {incompatible: true, note: "Packaged applications have to declare a windows.startupTask extension instead of the Run key"},
+		"elevate":      {permissions: []string{"allowElevation"}, note: "allowElevation is a restricted capability that needs the approval of the store"},
+		"featureflags": {permissions: []string{"internetClient"}},
+		"deeplink":     {note: "The protocols are registered by the manifest from info.protocols"},
+		"telemetry":    {permissions: []string{"internetClient"}},
+		"toast":        {note: "The toast activator is registered by the manifest, the COM server of the registry is ignored"},
+		"updater":      {incompatible: true, note: "The Microsoft Store updates the application, the updater has to be disabled in store builds"},
+		"zeroconf":     {permissions: []string{"privateNetworkClientServer"}},
+	},
+	TargetSnap: {
+		"autostart":    {incompatible: true, note: "Strictly confined snaps cannot write ~/.config/autostart, use the autostart key of the app"},
+		"bluetooth":    {permissions: []string{"bluez"}},
+		"calendars":    {permissions: []string{"calendar-service"}},
+		"elevate":      {incompatible: true, note: "Strictly confined snaps cannot run pkexec"},
+		"featureflags": {permissions: []string{"network"}},
+		"power":        {permissions: []string{"upower-observe"}},
+		"printers":     {permissions: []string{"cups"}},
+		"session":      {permissions: []string{"login-session-observe"}},
+		"telemetry":    {permissions: []string{"network"}},
+		"updater":      {incompatible: true, note: "The Snap Store updates the application, the updater has to be disabled in store builds"},
+		"zeroconf":     {permissions: []string{"network", "network-bind"}},
+	},
+}
+
+// SandboxReport returns the findings of the services in the sandbox of the store. Granted are the entitlements, the
+// plugs or the capabilities of the store build.
+func SandboxReport(target Target, services []string, granted []string) []Finding {
+	grantedSet := map[string]bool{}
+	for _, permission := range granted {
+		grantedSet[permission] = true
+	}
+	var findings []Finding
+	for _, service := range services {
+		rule, ok := sandboxRules[target][service]
+		if !ok {
+			continue
+		}
+		if len(rule.permissions) == 0 {
+			findings = append(findings, Finding{Service: service, Incompatible: rule.incompatible, Note: rule.note})
+			continue
+		}
+		for _, permission := range rule.permissions {
+			findings = append(findings, Finding{
+				Service:      service,
+				Permission:   permission,
+				Granted:      grantedSet[permission],
+				Incompatible: rule.incompatible,
+				Note:         rule.note,
+			})
+		}
+	}
+	return findings
+}
+
+// RequiredPermissions returns the permissions the services require in the sandbox of the store
+func RequiredPermissions(target Target, services []string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, finding := range SandboxReport(target, services, nil) {
+		if finding.Permission != "" && !seen[finding.Permission] {
+			seen[finding.Permission] = true
+			result = append(result, finding.Permission)
+		}
+	}
+	return result
+}
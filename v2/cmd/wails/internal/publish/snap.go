@@ -0,0 +1,140 @@
+package publish
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+	"github.com/wailsapp/wails/v2/internal/shell"
+	"gopkg.in/yaml.v3"
+)
+
+var snapName = regexp.MustCompile(`^[a-z0-9](-?[a-z0-9])*$`)
+
+// snapcraft are the keys of snapcraft.yaml the store requirements are checked with
+type snapcraft struct {
+	Name        string                 `yaml:"name"`
+	Version     string                 `yaml:"version"`
+	AdoptInfo   string                 `yaml:"adopt-info"`
+	Grade       string                 `yaml:"grade"`
+	Confinement string                 `yaml:"confinement"`
+	Plugs       map[string]interface{} `yaml:"plugs"`
+	Apps        map[string]struct {
+		Plugs []string `yaml:"plugs"`
+	} `yaml:"apps"`
+}
+
+// snap submits the application to the Snap Store. The snap is made by snapcraft from the snapcraft.yaml of the
+// project, which is expected to stage the binary of the build.
+type snap struct {
+	options   *project.Project
+	outputDir string
+	config    project.PublishSnap
+	snapcraft string
+}
+
+func newSnap(options *project.Project, outputDir string, config *project.PublishSnap) *snap {
+	if config == nil {
+		config = &project.PublishSnap{}
+	}
+	return &snap{
+		options:   options,
+		outputDir: outputDir,
+		config:    *config,
+		snapcraft: projectPath(options, config.Snapcraft, "linux", "snap", "snapcraft.yaml"),
+	}
+}
+
+func (s *snap) read() (*snapcraft, error) {
+	data, err := os.ReadFile(s.snapcraft)
+	if err != nil {
+		return nil, fmt.Errorf("%s does not exist", filepath.ToSlash(s.snapcraft))
+	}
+	result := &snapcraft{}
+	err = yaml.Unmarshal(data, result)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.ToSlash(s.snapcraft), err)
+	}
+	return result, nil
+}
+
+func (s *snap) Check(upload bool) []Check {
+	checks := []Check{
+		checkIf("Platform", runtime.GOOS == "linux", "snaps can only be made on Linux"),
+	}
+	config, err := s.read()
+	if err != nil {
+		checks = append(checks, check("snapcraft.yaml", err.Error()))
+	} else {
+		stable := s.config.GetChannel() == "stable" || s.config.GetChannel() == "candidate"
+		checks = append(checks,
+			checkIf("Name", snapName.MatchString(config.Name) && len(config.Name) <= 40, "the name '%s' has to be up to 40 lower case letters, digits and dashes", config.Name),
+			checkIf("Version", config.Version != "" || config.AdoptInfo != "", "snapcraft.yaml has to set version or adopt-info"),
+			checkIf("Confinement", config.Confinement == "strict", "the confinement is '%s', classic snaps need a review of the Snap Store and devmode snaps cannot be released to stable", config.Confinement),
+			checkIf("Grade", !stable || config.Grade == "stable", "snaps of the grade '%s' cannot be released to the %s channel", config.Grade, s.config.GetChannel()),
+			checkIf("Apps", len(config.Apps) > 0, "snapcraft.yaml has no apps"),
+		)
+	}
+	checks = append(checks, checkIf("snapcraft", shell.CommandExists("snapcraft"), "snapcraft is not installed, install it with: sudo snap install snapcraft --classic"))
+	if upload && shell.CommandExists("snapcraft") {
+		_, _, err := shell.RunCommand(s.options.Path, "snapcraft", "whoami")
+		checks = append(checks, checkIf("Snap Store login", err == nil, "snapcraft is not logged in, run snapcraft login or set SNAPCRAFT_STORE_CREDENTIALS"))
+	}
+	return checks
+}
+
+// Granted returns the plugs of the snap and its apps
+func (s *snap) Granted() ([]string, error) {
+	config, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for name := range config.Plugs {
+		seen[name] = true
+	}
+	for _, app := range config.Apps {
+		for _, plug := range app.Plugs {
+			seen[plug] = true
+		}
+	}
+	granted := make([]string, 0, len(seen))
+	for plug := range seen {
+		granted = append(granted, plug)
+	}
+	sort.Strings(granted)
+	return granted, nil
+}
+
+// Package runs snapcraft in the directory of the snap, which is the parent of snap/snapcraft.yaml
+func (s *snap) Package(output string) (string, error) {
+	dir := filepath.Dir(s.snapcraft)
+	if filepath.Base(dir) == "snap" {
+		dir = filepath.Dir(dir)
+	}
+	err := os.MkdirAll(s.outputDir, 0o755)
+	if err != nil {
+		return "", err
+	}
+	before, _ := filepath.Glob(filepath.Join(s.outputDir, "*.snap"))
+	for _, old := range before {
+		_ = os.Remove(old)
+	}
+	err = shell.RunCommandVerbose(dir, "snapcraft", "pack", "--output", s.outputDir+string(filepath.Separator))
+	if err != nil {
+		return "", fmt.Errorf("snapcraft failed: %w", err)
+	}
+	snaps, _ := filepath.Glob(filepath.Join(s.outputDir, "*.snap"))
+	if len(snaps) == 0 {
+		return "", fmt.Errorf("snapcraft has not written a snap to %s", s.outputDir)
+	}
+	return snaps[0], nil
+}
+
+func (s *snap) Upload(artifact string) error {
+	return shell.RunCommandVerbose(s.options.Path, "snapcraft", "upload", "--release="+s.config.GetChannel(), artifact)
+}
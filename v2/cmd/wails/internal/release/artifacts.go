@@ -0,0 +1,180 @@
+package release
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Artifact is a file of a release
+type Artifact struct {
+	Platform string `json:"platform"`
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// Feed describes the latest release for the updater of an application
+type Feed struct {
+	Version   string     `json:"version"`
+	Date      string     `json:"date"`
+	Notes     string     `json:"notes"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// NewFeed creates the feed of the release
+func NewFeed(version string, date time.Time, notes string, artifacts []Artifact) *Feed {
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Platform < artifacts[j].Platform
+	})
+	return &Feed{
+		Version:   version,
+		Date:      date.UTC().Format(time.RFC3339),
+		Notes:     notes,
+		Artifacts: artifacts,
+	}
+}
+
+// AddArtifact copies the build output of the platform to the release directory. Directories, EG: macOS application
+// bundles, are added as zip archive. The URL of the artifact is resolved against the baseURL if it is not empty.
+func AddArtifact(releaseDir string, platform string, path string, baseURL string) (Artifact, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	name := filepath.Base(path)
+	if info.IsDir() {
+		name += ".zip"
+		err = zipDirectory(path, filepath.Join(releaseDir, name))
+	} else {
+		err = copyFile(path, filepath.Join(releaseDir, name), info.Mode())
+	}
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	result := Artifact{Platform: platform, Name: name}
+	result.Size, result.SHA256, err = hashFile(filepath.Join(releaseDir, name))
+	if err != nil {
+		return Artifact{}, err
+	}
+	if baseURL != "" {
+		base, err := url.Parse(strings.TrimSuffix(baseURL, "/") + "/")
+		if err != nil {
+			return Artifact{}, fmt.Errorf("invalid feed url '%s': %w", baseURL, err)
+		}
+		result.URL = base.ResolveReference(&url.URL{Path: name}).String()
+	}
+	return result, nil
+}
+
+// Write writes update.json, SHA256SUMS and RELEASE_NOTES.md to the release directory
+func (f *Feed) Write(releaseDir string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(releaseDir, "update.json"), append(data, '\n'), 0o644)
+	if err != nil {
+		return err
+	}
+
+	var checksums strings.Builder
+	for _, artifact := range f.Artifacts {
+		checksums.WriteString(artifact.SHA256 + "  " + artifact.Name + "\n")
+	}
+	err = os.WriteFile(filepath.Join(releaseDir, "SHA256SUMS"), []byte(checksums.String()), 0o644)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(releaseDir, "RELEASE_NOTES.md"), []byte(f.Notes), 0o644)
+}
+
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func copyFile(source string, target string, mode os.FileMode) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// zipDirectory archives the directory, including the directory itself, and keeps the permissions of the files
+func zipDirectory(dir string, target string) error {
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	archive := zip.NewWriter(out)
+	parent := filepath.Dir(dir)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(parent, path)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relative)
+		if info.IsDir() {
+			header.Name += "/"
+			_, err = archive.CreateHeader(header)
+			return err
+		}
+		header.Method = zip.Deflate
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(writer, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return archive.Close()
+}
@@ -0,0 +1,188 @@
+// Package release generates the version, the release notes and the update feed of a release from the conventional
+// commits of the project, see https://www.conventionalcommits.org
+package release
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+// Bump is the part of the version that is raised
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// ParseBump parses major, minor or patch
+func ParseBump(bump string) (Bump, error) {
+	switch bump {
+	case "major":
+		return BumpMajor, nil
+	case "minor":
+		return BumpMinor, nil
+	case "patch":
+		return BumpPatch, nil
+	}
+	return BumpNone, fmt.Errorf("invalid bump '%s': expected major, minor or patch", bump)
+}
+
+// Commit is a conventional commit, EG: "feat(window)!: remove SetTitle"
+type Commit struct {
+	Hash     string
+	Type     string
+	Scope    string
+	Subject  string
+	Breaking bool
+}
+
+var commitPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+
+// ParseCommit parses the message of a commit. It returns false if the message isn't a conventional commit.
+func ParseCommit(hash string, message string) (Commit, bool) {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	match := commitPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return Commit{}, false
+	}
+	return Commit{
+		Hash:     hash,
+		Type:     strings.ToLower(match[1]),
+		Scope:    match[2],
+		Subject:  match[4],
+		Breaking: match[3] == "!" || strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:"),
+	}, true
+}
+
+// BumpOf returns the part of the version that the commits require to raise
+func BumpOf(commits []Commit) Bump {
+	result := BumpNone
+	for _, commit := range commits {
+		bump := BumpPatch
+		switch {
+		case commit.Breaking:
+			bump = BumpMajor
+		case commit.Type == "feat":
+			bump = BumpMinor
+		case commit.Type != "fix" && commit.Type != "perf":
+			continue
+		}
+		if bump > result {
+			result = bump
+		}
+	}
+	return result
+}
+
+// NextVersion raises the semantic version, EG: 1.2.3 with BumpMinor is 1.3.0. Before 1.0.0 breaking changes raise the
+// minor version.
+func NextVersion(version string, bump Bump) (string, error) {
+	parts := strings.Split(strings.SplitN(strings.TrimPrefix(version, "v"), "-", 2)[0], ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("'%s' is not a semantic version", version)
+	}
+	var numbers [3]int
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return "", fmt.Errorf("'%s' is not a semantic version", version)
+		}
+		numbers[index] = number
+	}
+
+	if bump == BumpMajor && numbers[0] == 0 {
+		bump = BumpMinor
+	}
+	switch bump {
+	case BumpMajor:
+		numbers = [3]int{numbers[0] + 1, 0, 0}
+	case BumpMinor:
+		numbers = [3]int{numbers[0], numbers[1] + 1, 0}
+	case BumpPatch:
+		numbers[2]++
+	}
+	return fmt.Sprintf("%d.%d.%d", numbers[0], numbers[1], numbers[2]), nil
+}
+
+var noteSections = []struct {
+	title   string
+	include func(Commit) bool
+}{
+	{"Breaking Changes", func(c Commit) bool { return c.Breaking }},
+	{"Features", func(c Commit) bool { return !c.Breaking && c.Type == "feat" }},
+	{"Bug Fixes", func(c Commit) bool { return !c.Breaking && c.Type == "fix" }},
+	{"Performance", func(c Commit) bool { return !c.Breaking && c.Type == "perf" }},
+}
+
+// Notes generates the release notes in markdown. Commits of other types than feat, fix and perf are only included if
+// they are breaking changes.
+func Notes(version string, date time.Time, commits []Commit) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## %s - %s\n", version, date.Format("2006-01-02")))
+	for _, section := range noteSections {
+		var lines []string
+		for _, commit := range commits {
+			if !section.include(commit) {
+				continue
+			}
+			line := "- "
+			if commit.Scope != "" {
+				line += "**" + commit.Scope + ":** "
+			}
+			line += commit.Subject
+			if len(commit.Hash) >= 7 {
+				line += " (" + commit.Hash[:7] + ")"
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			result.WriteString("\n### " + section.title + "\n\n" + strings.Join(lines, "\n") + "\n")
+		}
+	}
+	return result.String()
+}
+
+// LastTag returns the latest tag with the prefix that is reachable from HEAD, or "" if there is none
+func LastTag(dir string, prefix string) (string, error) {
+	stdout, stderr, err := shell.RunCommand(dir, "git", "describe", "--tags", "--abbrev=0", "--match", prefix+"*")
+	if err != nil {
+		if strings.Contains(stderr, "No names found") || strings.Contains(stderr, "No tags can describe") {
+			return "", nil
+		}
+		return "", errors.New(strings.TrimSpace(stderr))
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// Commits returns the conventional commits since the tag, or all commits if the tag is ""
+func Commits(dir string, since string) ([]Commit, error) {
+	args := []string{"log", "--format=%H%x1f%B%x1e"}
+	if since != "" {
+		args = append(args, since+"..HEAD")
+	}
+	stdout, stderr, err := shell.RunCommand(dir, "git", args...)
+	if err != nil {
+		return nil, errors.New(strings.TrimSpace(stderr))
+	}
+
+	var result []Commit
+	for _, entry := range strings.Split(stdout, "\x1e") {
+		hash, message, found := strings.Cut(strings.TrimSpace(entry), "\x1f")
+		if !found {
+			continue
+		}
+		if commit, ok := ParseCommit(hash, message); ok {
+			result = append(result, commit)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package release
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseCommit(t *testing.T) {
+	tests := []struct {
+		message string
+		want    Commit
+		ok      bool
+	}{
+		{"feat(window): add SetOpacity", Commit{Type: "feat", Scope: "window", Subject: "add SetOpacity"}, true},
+		{"fix: crash on exit\n\nCloses #1", Commit{Type: "fix", Subject: "crash on exit"}, true},
+		{"refactor(api)!: rename Open", Commit{Type: "refactor", Scope: "api", Subject: "rename Open", Breaking: true}, true},
+		{"feat: new events\n\nBREAKING CHANGE: events are async", Commit{Type: "feat", Subject: "new events", Breaking: true}, true},
+		{"Merge branch 'main'", Commit{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseCommit("", tt.message)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseCommit(%q) = %+v, %v, want %+v, %v", tt.message, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		commits []Commit
+		want    string
+	}{
+		{"1.2.3", []Commit{{Type: "fix"}, {Type: "docs"}}, "1.2.4"},
+		{"1.2.3", []Commit{{Type: "fix"}, {Type: "feat"}}, "1.3.0"},
+		{"v1.2.3", []Commit{{Type: "chore", Breaking: true}}, "2.0.0"},
+		{"0.4.1", []Commit{{Type: "feat", Breaking: true}}, "0.5.0"},
+		{"1.0.0-beta.1", []Commit{{Type: "fix"}}, "1.0.1"},
+	}
+	for _, tt := range tests {
+		got, err := NextVersion(tt.version, BumpOf(tt.commits))
+		if err != nil || got != tt.want {
+			t.Errorf("NextVersion(%q) = %q, %v, want %q", tt.version, got, err, tt.want)
+		}
+	}
+	if BumpOf([]Commit{{Type: "docs"}, {Type: "test"}}) != BumpNone {
+		t.Error("expected no bump for docs and tests")
+	}
+	if _, err := NextVersion("1.2", BumpPatch); err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
+
+func TestNotes(t *testing.T) {
+	commits := []Commit{
+		{Hash: "0123456789", Type: "feat", Scope: "window", Subject: "add SetOpacity"},
+		{Hash: "abcdef0123", Type: "fix", Subject: "crash on exit"},
+		{Hash: "fedcba9876", Type: "refactor", Subject: "rename Open", Breaking: true},
+		{Hash: "1111111111", Type: "docs", Subject: "typo"},
+	}
+	got := Notes("1.3.0", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), commits)
+	want := `## 1.3.0 - 2024-05-01
+
+### Breaking Changes
+
+- rename Open (fedcba9)
+
+### Features
+
+- **window:** add SetOpacity (0123456)
+
+### Bug Fixes
+
+- crash on exit (abcdef0)
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFeed(t *testing.T) {
+	binDir := t.TempDir()
+	releaseDir := t.TempDir()
+	binary := filepath.Join(binDir, "app.exe")
+	bundle := filepath.Join(binDir, "app.app")
+	if err := os.WriteFile(binary, []byte("binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(bundle, "Contents", "MacOS"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "Contents", "MacOS", "app"), []byte("binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	windows, err := AddArtifact(releaseDir, "windows/amd64", binary, "https://example.com/releases/v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if windows.URL != "https://example.com/releases/v1.0.0/app.exe" || windows.Size != 6 ||
+		windows.SHA256 != "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd" {
+		t.Errorf("unexpected artifact %+v", windows)
+	}
+	darwin, err := AddArtifact(releaseDir, "darwin/universal", bundle, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if darwin.Name != "app.app.zip" || darwin.URL != "" {
+		t.Errorf("unexpected artifact %+v", darwin)
+	}
+
+	err = NewFeed("1.0.0", time.Now(), "notes", []Artifact{windows, darwin}).Write(releaseDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(releaseDir, "update.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var feed Feed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		t.Fatal(err)
+	}
+	if feed.Version != "1.0.0" || len(feed.Artifacts) != 2 || feed.Artifacts[0].Platform != "darwin/universal" {
+		t.Errorf("unexpected feed %+v", feed)
+	}
+	for _, name := range []string{"SHA256SUMS", "RELEASE_NOTES.md", "app.exe", "app.app.zip"} {
+		if _, err := os.Stat(filepath.Join(releaseDir, name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/wailsapp/wails/v2/cmd/wails/internal"
 
 	"github.com/wailsapp/wails/v2/internal/colour"
+	"github.com/wailsapp/wails/v2/internal/project"
 
 	"github.com/leaanthony/clir"
+	"github.com/samber/lo"
 )
 
 func banner(_ *clir.Cli) string {
@@ -78,6 +80,8 @@ func main() {
 	app.NewSubCommandFunction("doctor", "Diagnose your environment", diagnoseEnvironment)
 	app.NewSubCommandFunction("init", "Initialises a new Wails project", initProject)
 	app.NewSubCommandFunction("update", "Update the Wails CLI", update)
+	app.NewSubCommandFunction("release", "Bumps the version, tags and packages a release from the conventional commits", releaseApplication)
+	app.NewSubCommandFunction("publish", "Checks, packages and uploads the application to the Mac App Store, the Microsoft Store or the Snap Store", publishApplication)
 
 	show := app.NewSubCommand("show", "Shows various information")
 	show.NewSubCommandFunction("releasenotes", "Shows the release notes for the current version", showReleaseNotes)
@@ -86,12 +90,30 @@ func main() {
 	generate.NewSubCommandFunction("module", "Generates a new Wails module", generateModule)
 	generate.NewSubCommandFunction("template", "Generates a new Wails template", generateTemplate)
 
+	addConfigCommands(app)
+	addAnalyzeCommands(app)
+	addDebugCommands(app)
+
 	command := app.NewSubCommand("version", "The Wails CLI version")
 	command.Action(func() error {
 		pterm.Println(internal.Version)
 		return nil
 	})
 
+	// Custom commands are run before the CLI parses the arguments, so the plugins can have their own flags
+	proj, _ := project.Load(lo.Must(os.Getwd()))
+	plugins := findPlugins(proj, []string{"build", "dev", "doctor", "init", "update", "release", "publish", "show", "generate", "config", "analyze", "debug", "version"})
+	if len(os.Args) > 1 {
+		if path, exists := plugins[os.Args[1]]; exists {
+			exitCode, err := runPlugin(path, os.Args[2:], proj)
+			if err != nil {
+				pterm.Error.Println(err.Error())
+			}
+			os.Exit(exitCode)
+		}
+	}
+	registerPlugins(app, plugins)
+
 	err = app.Run()
 	if err != nil {
 		pterm.Println()
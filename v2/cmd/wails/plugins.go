@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/leaanthony/clir"
+	"github.com/wailsapp/wails/v2/cmd/wails/internal"
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+// pluginPrefix is the prefix of the executables on the PATH that provide custom commands, EG: wails-deploy
+const pluginPrefix = "wails-"
+
+// findPlugins returns the executables of the custom commands by their name. The plugins of the project config take
+// precedence over the executables on the PATH. Commands that are built into the CLI can't be replaced.
+func findPlugins(proj *project.Project, builtins []string) map[string]string {
+	result := map[string]string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, pluginPrefix) || entry.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			} else if info, err := entry.Info(); err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			command := strings.TrimPrefix(name, pluginPrefix)
+			if _, exists := result[command]; command != "" && !exists {
+				// The first match on the PATH wins, the same as for exec.LookPath
+				result[command] = filepath.Join(dir, entry.Name())
+			}
+		}
+	}
+
+	if proj != nil {
+		for command, path := range proj.Plugins {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(proj.Path, path)
+			}
+			result[command] = path
+		}
+	}
+
+	for _, builtin := range builtins {
+		delete(result, builtin)
+	}
+	return result
+}
+
+// registerPlugins adds the plugins to the help of the CLI
+func registerPlugins(app *clir.Cli, plugins map[string]string) {
+	commands := make([]string, 0, len(plugins))
+	for command := range plugins {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	for _, command := range commands {
+		app.NewSubCommand(command, "Plugin: "+plugins[command])
+	}
+}
+
+// runPlugin runs the executable of the plugin with the arguments and returns its exit code. The version of the CLI
+// and the project config, with all defaults applied, are passed as environment variables.
+func runPlugin(path string, args []string, proj *project.Project) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "WAILS_VERSION="+internal.Version)
+	if proj != nil {
+		config, err := json.Marshal(proj)
+		if err != nil {
+			return 1, err
+		}
+		cmd.Env = append(cmd.Env, "WAILS_PROJECT_DIR="+proj.Path, "WAILS_PROJECT="+string(config))
+	}
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/cmd/wails/flags"
+	"github.com/wailsapp/wails/v2/cmd/wails/internal/publish"
+	"github.com/wailsapp/wails/v2/internal/colour"
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+func publishApplication(f *flags.Publish) error {
+	if f.NoColour {
+		pterm.DisableColor()
+		colour.ColourEnabled = false
+	}
+	if f.Target == "" {
+		return errors.New("no target given, use -target mas, msstore or snap")
+	}
+	target, err := publish.ParseTarget(f.Target)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	projectOptions, err := project.LoadEnvironment(cwd, "prod")
+	if err != nil {
+		return err
+	}
+	services, err := publish.ScanServices(cwd)
+	if err != nil {
+		return err
+	}
+	store := publish.New(target, projectOptions, services)
+
+	pterm.DefaultSection.Println("Requirements of the " + target.Name())
+	checks := store.Check(f.Upload)
+	table := pterm.TableData{{"Requirement", "Status"}}
+	for _, check := range checks {
+		table = append(table, []string{check.Requirement, orDefault(check.Problem, "OK")})
+	}
+	err = pterm.DefaultTable.WithHasHeader(true).WithData(table).Render()
+	if err != nil {
+		return err
+	}
+
+	// The report is made without the permissions of the store build if they can't be read, that is a failed check
+	granted, _ := store.Granted()
+	findings := publish.SandboxReport(target, services, granted)
+	pterm.DefaultSection.Println("Sandbox Compatibility")
+	missing := 0
+	if len(findings) == 0 {
+		pterm.Info.Println("The application uses no services that need permissions in the sandbox of the store.")
+	} else {
+		table = pterm.TableData{{"Service", "Permission", "Status", "Note"}}
+		for _, finding := range findings {
+			status := "OK"
+			switch {
+			case finding.Incompatible:
+				status = "Incompatible"
+			case finding.Permission != "" && !finding.Granted:
+				status = "Missing"
+				missing++
+			}
+			table = append(table, []string{finding.Service, orDefault(finding.Permission, "-"), status, finding.Note})
+		}
+		err = pterm.DefaultTable.WithHasHeader(true).WithData(table).Render()
+		if err != nil {
+			return err
+		}
+	}
+	for _, finding := range findings {
+		if finding.Incompatible {
+			pterm.Warning.Printfln("%s: %s", finding.Service, finding.Note)
+		}
+	}
+
+	if f.Report {
+		return nil
+	}
+	if failed := publish.Failed(checks); len(failed) > 0 {
+		return fmt.Errorf("the project doesn't meet %d requirement(s) of the %s", len(failed), target.Name())
+	}
+	if missing > 0 {
+		return fmt.Errorf("the store build doesn't grant %d permission(s) that the services need", missing)
+	}
+
+	platform := target.Platform()
+	binary := filepath.Join(projectOptions.GetBuildDir(), "bin", projectOptions.OutputFilename)
+	if !f.NoBuild {
+		buildFlags := (&flags.Build{}).Default()
+		buildFlags.NoColour = f.NoColour
+		buildFlags.Clean = true
+		buildFlags.Platform = platform
+		if projectOptions.Publish != nil {
+			buildFlags.Profile = projectOptions.Publish.Profile
+		}
+		binaries, err := buildTargets(buildFlags)
+		if err != nil {
+			return err
+		}
+		if binaries[platform] == "" {
+			return fmt.Errorf("%s has not been built", platform)
+		}
+		binary = binaries[platform]
+	}
+
+	pterm.DefaultSection.Println("Packaging for the " + target.Name())
+	artifact, err := store.Package(releaseOutput(projectOptions, platform, binary))
+	if err != nil {
+		return err
+	}
+	pterm.Success.Printfln("Wrote %s", artifact)
+
+	if !f.Upload {
+		return nil
+	}
+	err = store.Upload(artifact)
+	if err != nil {
+		return fmt.Errorf("the upload to the %s failed: %w", target.Name(), err)
+	}
+	pterm.Success.Printfln("Uploaded %s to the %s", filepath.Base(artifact), target.Name())
+	return nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/cmd/wails/flags"
+	"github.com/wailsapp/wails/v2/cmd/wails/internal/release"
+	"github.com/wailsapp/wails/v2/internal/colour"
+	"github.com/wailsapp/wails/v2/internal/project"
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+func releaseApplication(f *flags.Release) error {
+	if f.NoColour {
+		pterm.DisableColor()
+		colour.ColourEnabled = false
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	projectOptions, err := project.LoadEnvironment(cwd, "prod")
+	if err != nil {
+		return err
+	}
+	config := projectOptions.Release
+	if config == nil {
+		config = &project.Release{}
+	}
+
+	if !f.DryRun && !f.NoTag {
+		stdout, stderr, err := shell.RunCommand(cwd, "git", "status", "--porcelain")
+		if err != nil {
+			return errors.New(strings.TrimSpace(stderr))
+		}
+		if strings.TrimSpace(stdout) != "" {
+			return errors.New("the working tree has uncommitted changes")
+		}
+	}
+
+	lastTag, err := release.LastTag(cwd, config.GetTagPrefix())
+	if err != nil {
+		return err
+	}
+	commits, err := release.Commits(cwd, lastTag)
+	if err != nil {
+		return err
+	}
+
+	version := strings.TrimPrefix(f.Version, config.GetTagPrefix())
+	if version == "" {
+		bump := release.BumpOf(commits)
+		if f.Bump != "" {
+			bump, err = release.ParseBump(f.Bump)
+			if err != nil {
+				return err
+			}
+		}
+		if bump == release.BumpNone {
+			return fmt.Errorf("no feat, fix or perf commits since %s, use -bump to release anyway", orDefault(lastTag, "the first commit"))
+		}
+		version, err = release.NextVersion(projectOptions.Info.ProductVersion, bump)
+		if err != nil {
+			return err
+		}
+	}
+	tag := config.GetTagPrefix() + version
+	now := time.Now()
+	notes := release.Notes(version, now, commits)
+
+	platforms := strings.Join(config.Platforms, ",")
+	pterm.DefaultSection.Println("Release")
+	err = pterm.DefaultTable.WithData(pterm.TableData{
+		{"Previous Version", projectOptions.Info.ProductVersion},
+		{"Version", version},
+		{"Previous Tag", orDefault(lastTag, "none")},
+		{"Tag", tag},
+		{"Commits", fmt.Sprintf("%d", len(commits))},
+		{"Platform(s)", orDefault(platforms, "current platform")},
+		{"Build Profile", config.Profile},
+	}).Render()
+	if err != nil {
+		return err
+	}
+	pterm.DefaultSection.Println("Release Notes")
+	pterm.Println(notes)
+
+	if f.DryRun {
+		pterm.Info.Println("Dry run: the version, the tag and the artifacts have not been created.")
+		return nil
+	}
+
+	// The version has to be set before building, it is compiled into the application
+	projectFile := filepath.Join(cwd, "wails.json")
+	originalConfig, err := os.ReadFile(projectFile)
+	if err != nil {
+		return err
+	}
+	versionValue, _ := json.Marshal(version)
+	bumpedConfig, err := project.SetConfigValue(originalConfig, "info.productVersion", versionValue)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(projectFile, bumpedConfig, 0o644)
+	if err != nil {
+		return err
+	}
+	changedFiles := []string{"wails.json"}
+
+	if !f.NoBuild {
+		err = buildRelease(projectOptions, config, version, tag, now, notes, f.NoColour)
+		if err != nil {
+			// Don't leave a version behind that hasn't been released
+			_ = os.WriteFile(projectFile, originalConfig, 0o644)
+			return err
+		}
+	}
+
+	if config.Changelog != "" {
+		changelogFile := filepath.Join(cwd, config.Changelog)
+		changelog, err := os.ReadFile(changelogFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		err = os.WriteFile(changelogFile, []byte(notes+"\n"+string(changelog)), 0o644)
+		if err != nil {
+			return err
+		}
+		changedFiles = append(changedFiles, config.Changelog)
+	}
+
+	if f.NoTag {
+		pterm.Success.Printfln("Released %s without tag", version)
+		return nil
+	}
+	for _, args := range [][]string{
+		append([]string{"add", "--"}, changedFiles...),
+		{"commit", "-m", "chore(release): " + tag},
+		{"tag", "-a", tag, "--cleanup=verbatim", "-m", notes},
+	} {
+		_, stderr, err := shell.RunCommand(cwd, "git", args...)
+		if err != nil {
+			return fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr))
+		}
+	}
+	pterm.Success.Printfln("Released %s, push the tag with: git push --follow-tags", tag)
+	return nil
+}
+
+// buildRelease builds the platforms of the release and writes the artifacts and the update feed to build/release/<tag>
+func buildRelease(projectOptions *project.Project, config *project.Release, version string, tag string, date time.Time, notes string, noColour bool) error {
+	buildFlags := (&flags.Build{}).Default()
+	buildFlags.NoColour = noColour
+	buildFlags.Clean = true
+	buildFlags.Profile = config.Profile
+	if len(config.Platforms) > 0 {
+		buildFlags.Platform = strings.Join(config.Platforms, ",")
+	}
+	binaries, err := buildTargets(buildFlags)
+	if err != nil {
+		return err
+	}
+
+	releaseDir := filepath.Join(projectOptions.GetBuildDir(), "release", tag)
+	err = os.MkdirAll(releaseDir, 0o755)
+	if err != nil {
+		return err
+	}
+	var artifacts []release.Artifact
+	for platform, binary := range binaries {
+		artifact, err := release.AddArtifact(releaseDir, platform, releaseOutput(projectOptions, platform, binary), config.FeedURL)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	err = release.NewFeed(version, date, notes, artifacts).Write(releaseDir)
+	if err != nil {
+		return err
+	}
+	pterm.Success.Printfln("Wrote the artifacts and the update feed to %s", releaseDir)
+	return nil
+}
+
+// releaseOutput returns the application bundle of macOS builds, as the binary has been moved into the bundle
+func releaseOutput(projectOptions *project.Project, platform string, binary string) string {
+	if _, err := os.Stat(binary); err == nil || !strings.HasPrefix(platform, "darwin") {
+		return binary
+	}
+	binDir := filepath.Join(projectOptions.GetBuildDir(), "bin")
+	_, arch, _ := strings.Cut(platform, "/")
+	bundle := filepath.Join(binDir, strings.TrimSuffix(projectOptions.OutputFilename, ".exe")+"-"+arch+".app")
+	if _, err := os.Stat(bundle); err == nil {
+		return bundle
+	}
+	return filepath.Join(binDir, projectOptions.Name+".app")
+}
+
+// orDefault returns the fallback if the value is empty
+func orDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
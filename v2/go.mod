@@ -39,10 +39,13 @@ require (
 	github.com/wailsapp/go-webview2 v1.0.18
 	github.com/wailsapp/mimetype v1.4.1
 	github.com/wzshiming/ctc v1.2.3
+	golang.org/x/crypto v0.23.0
+	golang.org/x/image v0.12.0
 	golang.org/x/mod v0.14.0
 	golang.org/x/net v0.25.0
 	golang.org/x/sys v0.27.0
 	golang.org/x/tools v0.17.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -97,13 +100,10 @@ require (
 	github.com/xo/terminfo v0.0.0-20210125001918-ca9a967f8778 // indirect
 	github.com/yuin/goldmark v1.4.13 // indirect
 	github.com/yuin/goldmark-emoji v1.0.1 // indirect
-	golang.org/x/crypto v0.23.0 // indirect
 	golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1 // indirect
-	golang.org/x/image v0.12.0 // indirect
 	golang.org/x/term v0.20.0 // indirect
 	golang.org/x/text v0.15.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	howett.net/plist v1.0.0 // indirect
 )
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/frontend/runtime"
+	"github.com/wailsapp/wails/v2/internal/instanceevents"
 	"github.com/wailsapp/wails/v2/internal/logger"
 	"github.com/wailsapp/wails/v2/internal/menumanager"
 	"github.com/wailsapp/wails/v2/pkg/menu"
@@ -28,6 +30,17 @@ type App struct {
 	startupCallback  func(ctx context.Context)
 	shutdownCallback func(ctx context.Context)
 	ctx              context.Context
+
+	// Relaunch the application with relaunchArgs after the shutdown, with elevated privileges if relaunchElevated is set
+	relaunch         bool
+	relaunchArgs     []string
+	relaunchElevated bool
+
+	// Exchanges events with the other running instances
+	instanceEvents *instanceevents.Bus
+
+	// Delivers the events, the queued events are drained on shutdown
+	events *runtime.Events
 }
 
 // Shutdown the application
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/windows"
+)
+
+// portableMarkerFilename is the name of the file next to the executable that enables the portable mode
+const portableMarkerFilename = "portable"
+
+// processAppDataDirectory determines the directory the application data is stored in and attaches it to the context.
+// In portable mode all data is stored in a `data` directory next to the executable, or next to the application bundle
+// on macOS. The directory is empty if it can't be determined, only the options that need it fail then.
+func processAppDataDirectory(ctx context.Context, appoptions *options.App, myLogger *logger.Logger) (context.Context, string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		if appoptions.Portable || IsPortable() {
+			return ctx, "", err
+		}
+		myLogger.Warning("Unable to determine the application data directory: %s", err)
+		return ctx, "", nil
+	}
+	baseDir := portableBaseDir(goruntime.GOOS, exe)
+
+	portable := appoptions.Portable || IsPortable()
+	if !portable {
+		if _, err := os.Stat(filepath.Join(baseDir, portableMarkerFilename)); err == nil {
+			portable = true
+		}
+	}
+
+	var appDataDir string
+	if portable {
+		appDataDir = filepath.Join(baseDir, "data")
+		myLogger.Info("Running in portable mode, storing data in %s", appDataDir)
+		if err := applyPortableMode(appoptions, appDataDir); err != nil {
+			return ctx, "", err
+		}
+	} else if configDir, err := os.UserConfigDir(); err != nil {
+		myLogger.Warning("Unable to determine the application data directory: %s", err)
+	} else {
+		appDataDir = filepath.Join(configDir, strings.TrimSuffix(filepath.Base(exe), filepath.Ext(exe)))
+	}
+	if err := processWebviewProfile(appoptions, appDataDir); err != nil {
+		return ctx, "", err
+	}
+
+	ctx = context.WithValue(ctx, "portable", portable)
+	ctx = context.WithValue(ctx, "appdatadir", appDataDir)
+	return ctx, appDataDir, nil
+}
+
+// portableBaseDir returns the directory of the portable data and marker file. On macOS it is the directory of the
+// application bundle, as writing inside the signed bundle would break its signature.
+func portableBaseDir(goos string, exe string) string {
+	if goos == "darwin" {
+		if index := strings.LastIndex(exe, ".app/Contents/MacOS/"); index != -1 {
+			return filepath.Dir(exe[:index+len(".app")])
+		}
+	}
+	return filepath.Dir(exe)
+}
+
+// applyPortableMode redirects the data of the webview into the appDataDir
+func applyPortableMode(appoptions *options.App, appDataDir string) error {
+	err := os.MkdirAll(appDataDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	switch goruntime.GOOS {
+	case "windows":
+		if appoptions.Windows == nil {
+			appoptions.Windows = &windows.Options{}
+		}
+		if appoptions.Windows.WebviewUserDataPath == "" {
+			appoptions.Windows.WebviewUserDataPath = filepath.Join(appDataDir, "webview")
+		}
+	case "darwin":
+		// WebKit keeps the data stores in its own directory, a profile of the data directory separates the data of
+		// the portable application from an installed copy on macOS 14+
+		if appoptions.WebviewProfile == nil {
+			appoptions.WebviewProfile = &options.WebviewProfile{Directory: filepath.Join(appDataDir, "webview")}
+		}
+	case "linux":
+		// WebKitGTK and GTK store their data in the XDG base directories
+		for env, dir := range map[string]string{
+			"XDG_CONFIG_HOME": "config",
+			"XDG_DATA_HOME":   "share",
+			"XDG_CACHE_HOME":  "cache",
+		} {
+			if err := os.Setenv(env, filepath.Join(appDataDir, dir)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// processWebviewProfile resolves the directory of a named webview profile in the appDataDir
+func processWebviewProfile(appoptions *options.App, appDataDir string) error {
+	profile := appoptions.WebviewProfile
+	if profile == nil || profile.InMemory {
+		return nil
+	}
+	if profile.Directory == "" {
+		if appDataDir == "" {
+			return fmt.Errorf("unable to store the webview profile %q without an application data directory", profile.Name)
+		}
+		if !validProfileName(profile.Name) {
+			return fmt.Errorf("invalid webview profile name %q", profile.Name)
+		}
+		profile.Directory = filepath.Join(appDataDir, "profiles", profile.Name)
+	}
+	return os.MkdirAll(profile.Directory, 0o755)
+}
+
+// validProfileName reports if the name can be used as the name of a directory on all platforms
+func validProfileName(name string) bool {
+	if name == "" || strings.HasPrefix(name, ".") {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
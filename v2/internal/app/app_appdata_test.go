@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestProcessWebviewProfile(t *testing.T) {
+	directory := t.TempDir()
+
+	named := &options.App{WebviewProfile: &options.WebviewProfile{Name: "work-account"}}
+	if err := processWebviewProfile(named, directory); err != nil {
+		t.Fatal(err)
+	}
+	expected := filepath.Join(directory, "profiles", "work-account")
+	if named.WebviewProfile.Directory != expected {
+		t.Errorf("got the directory %q, want %q", named.WebviewProfile.Directory, expected)
+	}
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("expected the directory of the profile to be created: %v", err)
+	}
+
+	custom := filepath.Join(directory, "custom")
+	explicit := &options.App{WebviewProfile: &options.WebviewProfile{Name: "ignored", Directory: custom}}
+	if err := processWebviewProfile(explicit, directory); err != nil || explicit.WebviewProfile.Directory != custom {
+		t.Errorf("expected the directory to be kept, got %q, %v", explicit.WebviewProfile.Directory, err)
+	}
+
+	inMemory := &options.App{WebviewProfile: &options.WebviewProfile{Name: "guest", InMemory: true}}
+	if err := processWebviewProfile(inMemory, directory); err != nil || inMemory.WebviewProfile.Directory != "" {
+		t.Errorf("expected no directory for an in-memory profile, got %q, %v", inMemory.WebviewProfile.Directory, err)
+	}
+
+	for _, name := range []string{"", "../escape", ".hidden", "a/b", `a\b`} {
+		invalid := &options.App{WebviewProfile: &options.WebviewProfile{Name: name}}
+		if err := processWebviewProfile(invalid, directory); err == nil {
+			t.Errorf("expected an error for the name %q", name)
+		}
+	}
+}
+
+func TestProcessAppDataDirectoryWithoutConfigDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv("AppData", "")
+	if _, err := os.UserConfigDir(); err == nil {
+		t.Skip("the user config directory is always available")
+	}
+
+	ctx, appDataDir, err := processAppDataDirectory(context.Background(), &options.App{}, logger.New(nil))
+	if err != nil || appDataDir != "" {
+		t.Fatalf("expected no directory and no error, got %q, %v", appDataDir, err)
+	}
+	if dir, _ := ctx.Value("appdatadir").(string); dir != "" {
+		t.Errorf("expected no directory in the context, got %q", dir)
+	}
+
+	named := &options.App{WebviewProfile: &options.WebviewProfile{Name: "work-account"}}
+	if _, _, err := processAppDataDirectory(context.Background(), named, logger.New(nil)); err == nil {
+		t.Error("expected an error for a named profile without a directory")
+	}
+}
+
+func TestPortableBaseDir(t *testing.T) {
+	bundle := "/Applications/Portable/My App.app/Contents/MacOS/myapp"
+	if dir := portableBaseDir("darwin", bundle); dir != filepath.FromSlash("/Applications/Portable") {
+		t.Errorf("expected the directory of the bundle, got %q", dir)
+	}
+	if dir := portableBaseDir("darwin", "/usr/local/bin/myapp"); dir != filepath.FromSlash("/usr/local/bin") {
+		t.Errorf("expected the directory of the executable, got %q", dir)
+	}
+	if dir := portableBaseDir("linux", "/opt/myapp/myapp"); dir != filepath.FromSlash("/opt/myapp") {
+		t.Errorf("expected the directory of the executable, got %q", dir)
+	}
+}
@@ -24,6 +24,7 @@ func (a *App) Run() error {
 		a.options.OnShutdown,
 		a.options.OnDomReady,
 		a.options.OnBeforeClose,
+		a.options.OnLaunchArguments,
 	}
 
 	// Check for CLI Flags
@@ -32,6 +33,8 @@ func (a *App) Run() error {
 	var tsPrefixFlag *string
 	var tsPostfixFlag *string
 	var tsOutputTypeFlag *string
+	var tsNullabilityFlag *string
+	var jsonSchemaFlag *bool
 
 	tsPrefix := os.Getenv("tsprefix")
 	if tsPrefix == "" {
@@ -48,6 +51,16 @@ func (a *App) Run() error {
 		tsOutputTypeFlag = bindingFlags.String("tsoutputtype", "", "Output type for generated typescript entities (classes|interfaces)")
 	}
 
+	tsNullability := os.Getenv("tsnullability")
+	if tsNullability == "" {
+		tsNullabilityFlag = bindingFlags.String("tsnullability", "", "Nullability of generated typescript fields (optional|nullable|strict)")
+	}
+
+	jsonSchema := os.Getenv("jsonschema") == "true"
+	if !jsonSchema {
+		jsonSchemaFlag = bindingFlags.Bool("jsonschema", false, "Generate a JSON Schema document of the bound methods")
+	}
+
 	_ = bindingFlags.Parse(os.Args[1:])
 	if tsPrefixFlag != nil {
 		tsPrefix = *tsPrefixFlag
@@ -58,14 +71,33 @@ func (a *App) Run() error {
 	if tsOutputTypeFlag != nil {
 		tsOutputType = *tsOutputTypeFlag
 	}
+	if tsNullabilityFlag != nil {
+		tsNullability = *tsNullabilityFlag
+	}
+	if jsonSchemaFlag != nil {
+		jsonSchema = *jsonSchemaFlag
+	}
 
 	appBindings := binding.NewBindings(a.logger, a.options.Bind, bindingExemptions, IsObfuscated(), a.options.EnumBind)
 
 	appBindings.SetTsPrefix(tsPrefix)
 	appBindings.SetTsSuffix(tsSuffix)
 	appBindings.SetOutputType(tsOutputType)
+	appBindings.SetTsNullability(tsNullability)
+
+	err := processDeprecations(appBindings, a.options)
+	if err != nil {
+		return err
+	}
+
+	// Generate enums for the constants of the types used by the bound methods
+	if cwd, err := os.Getwd(); err == nil {
+		if err := appBindings.DetectEnums(cwd); err != nil {
+			a.logger.Warning("Unable to detect enums: %s", err)
+		}
+	}
 
-	err := generateBindings(appBindings)
+	err = generateBindings(appBindings, jsonSchema)
 	if err != nil {
 		return err
 	}
@@ -87,7 +119,7 @@ func CreateApp(appoptions *options.App) (*App, error) {
 
 }
 
-func generateBindings(bindings *binding.Bindings) error {
+func generateBindings(bindings *binding.Bindings, jsonSchema bool) error {
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -120,5 +152,20 @@ func generateBindings(bindings *binding.Bindings) error {
 		return err
 	}
 
+	if IsObfuscated() {
+		// The main package is built in the project directory
+		err = bindings.GenerateObfuscatedRegistration(filepath.Join(cwd, binding.ObfuscatedRegistrationFile))
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonSchema {
+		err = bindings.GenerateJSONSchema(filepath.Join(goBindingsDir, "bindings.schema.json"))
+		if err != nil {
+			return err
+		}
+	}
+
 	return fs.SetPermissions(wailsjsbasedir, 0755)
 }
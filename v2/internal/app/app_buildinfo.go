@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// The build profile is set with -ldflags by `wails build -profile`
+var (
+	buildProfile  string
+	buildFlags    string
+	assetPolicies string
+)
+
+// processBuildProfile adds the build profile and its flags to the context
+func processBuildProfile(ctx context.Context) context.Context {
+	profile, _ := url.QueryUnescape(buildProfile)
+	flags := map[string]string{}
+	values, _ := url.ParseQuery(buildFlags)
+	for name := range values {
+		flags[name] = values.Get(name)
+	}
+	ctx = context.WithValue(ctx, "buildprofile", profile)
+	return context.WithValue(ctx, "buildflags", flags)
+}
+
+// processAssetPolicies replaces the asset policies with the ones of the build profile. Debug builds serve the assets
+// as they are.
+func processAssetPolicies(appoptions *options.App, debug bool) error {
+	if !debug && assetPolicies == "" {
+		return nil
+	}
+	var policies []assetserver.Policy
+	if !debug {
+		data, err := url.QueryUnescape(assetPolicies)
+		if err == nil {
+			err = json.Unmarshal([]byte(data), &policies)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid asset policies of the build profile: %w", err)
+		}
+	}
+
+	if appoptions.AssetServer == nil {
+		if len(policies) == 0 {
+			return nil
+		}
+		// Migrate the deprecated options to set the policies
+		appoptions.AssetServer = &assetserver.Options{Assets: appoptions.Assets, Handler: appoptions.AssetsHandler}
+		appoptions.Assets = nil
+		appoptions.AssetsHandler = nil
+	} else {
+		// Copy the options instead of changing the ones of the application
+		assetServer := *appoptions.AssetServer
+		appoptions.AssetServer = &assetServer
+	}
+	appoptions.AssetServer.Policies = policies
+	return nil
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"net/url"
+	"os"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/deeplink"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
+)
+
+// processDeepLinks registers the schemes of DeepLinks and delivers their URLs to OnURL and to the frontend. The URLs
+// that arrive before the DOM is ready are emitted once it is ready, so that the frontend receives the URLs the
+// application has been started with.
+func processDeepLinks(appoptions *options.App, events frontend.Events, myLogger *logger.Logger) {
+	deepLinks := appoptions.DeepLinks
+	if deepLinks == nil || len(deepLinks.Schemes) == 0 {
+		return
+	}
+
+	if deepLinks.Register {
+		registerDeepLinks(deepLinks.Schemes, myLogger)
+	}
+
+	dispatcher := &openDispatcher{
+		onOpen:    deepLinks.OnURL,
+		eventName: options.DeepLinkEvent,
+		events:    events,
+	}
+	deliver := func(args []string) {
+		for _, arg := range args {
+			if isDeepLink(arg, deepLinks.Schemes) {
+				dispatcher.deliver(arg)
+			}
+		}
+	}
+
+	onStartup := appoptions.OnStartup
+	appoptions.OnStartup = func(ctx context.Context) {
+		if onStartup != nil {
+			onStartup(ctx)
+		}
+		deliver(os.Args[1:])
+	}
+
+	onDomReady := appoptions.OnDomReady
+	appoptions.OnDomReady = func(ctx context.Context) {
+		if onDomReady != nil {
+			onDomReady(ctx)
+		}
+		dispatcher.ready()
+	}
+
+	if lock := appoptions.SingleInstanceLock; lock != nil {
+		onSecondInstanceLaunch := lock.OnSecondInstanceLaunch
+		lock.OnSecondInstanceLaunch = func(secondInstanceData options.SecondInstanceData) {
+			if onSecondInstanceLaunch != nil {
+				onSecondInstanceLaunch(secondInstanceData)
+			}
+			deliver(secondInstanceData.Args)
+		}
+	}
+
+	if goruntime.GOOS == "darwin" {
+		if appoptions.Mac == nil {
+			appoptions.Mac = &mac.Options{}
+		}
+		onUrlOpen := appoptions.Mac.OnUrlOpen
+		appoptions.Mac.OnUrlOpen = func(url string) {
+			if onUrlOpen != nil {
+				onUrlOpen(url)
+			}
+			deliver([]string{url})
+		}
+	}
+}
+
+// registerDeepLinks registers the application as the handler of the schemes that it doesn't handle yet
+func registerDeepLinks(schemes []string, myLogger *logger.Logger) {
+	for _, scheme := range schemes {
+		registered, err := deeplink.IsRegistered(scheme)
+		if err == nil && !registered {
+			err = deeplink.Register(scheme)
+			if err == nil {
+				myLogger.Info("Registered the application as the handler of %s:// URLs", scheme)
+			}
+		}
+		if err != nil {
+			myLogger.Warning("Unable to register the application as the handler of %s:// URLs: %s", scheme, err)
+		}
+	}
+}
+
+// isDeepLink reports whether the argument is a URL of one of the schemes
+func isDeepLink(arg string, schemes []string) bool {
+	parsed, err := url.Parse(arg)
+	if err != nil {
+		return false
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(parsed.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
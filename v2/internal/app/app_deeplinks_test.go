@@ -0,0 +1,18 @@
+package app
+
+import "testing"
+
+func TestIsDeepLink(t *testing.T) {
+	schemes := []string{"myapp"}
+	for arg, expected := range map[string]bool{
+		"myapp://callback?code=1": true,
+		"MyApp://open":            true,
+		"other://callback":        false,
+		"--verbose":               false,
+		"document.txt":            false,
+	} {
+		if isDeepLink(arg, schemes) != expected {
+			t.Errorf("%s: expected %v", arg, expected)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// processDeprecations sets the API version and marks the deprecated methods of the bindings
+func processDeprecations(appBindings *binding.Bindings, appoptions *options.App) error {
+	appBindings.SetAPIVersion(appoptions.APIVersion)
+	for _, deprecation := range appoptions.Deprecations {
+		err := appBindings.Deprecate(deprecation.Method, deprecation.Replacement, deprecation.Message, deprecation.Since)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
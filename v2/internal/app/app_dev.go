@@ -35,13 +35,21 @@ func (a *App) Run() error {
 	if a.shutdownCallback != nil {
 		a.shutdownCallback(a.ctx)
 	}
-	return err
+	a.events.Close()
+	a.closeInstanceEvents()
+	if err != nil {
+		return err
+	}
+	return a.processRelaunch()
 }
 
 // CreateApp creates the app!
 func CreateApp(appoptions *options.App) (*App, error) {
 	var err error
 
+	// Wait for the instance that has relaunched the application
+	processRelaunchParent(appoptions)
+
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "debug", true)
 	ctx = context.WithValue(ctx, "devtoolsEnabled", true)
@@ -57,6 +65,7 @@ func CreateApp(appoptions *options.App) (*App, error) {
 	var devServerFlag *string
 	var frontendDevServerURLFlag *string
 	var loglevelFlag *string
+	var frontendDevServersFlag *string
 
 	assetdir := os.Getenv("assetdir")
 	if assetdir == "" {
@@ -73,6 +82,11 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		frontendDevServerURLFlag = devFlags.String("frontenddevserverurl", "", "URL of the external frontend dev server")
 	}
 
+	frontendDevServers := os.Getenv("frontenddevservers")
+	if frontendDevServers == "" {
+		frontendDevServersFlag = devFlags.String("frontenddevservers", "", "Paths and URLs of the additional frontend dev servers as JSON")
+	}
+
 	loglevel := os.Getenv("loglevel")
 	if loglevel == "" {
 		loglevelFlag = devFlags.String("loglevel", "debug", "Loglevel to use - Trace, Debug, Info, Warning, Error")
@@ -94,12 +108,17 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		if loglevelFlag != nil {
 			loglevel = *loglevelFlag
 		}
+		if frontendDevServersFlag != nil {
+			frontendDevServers = *frontendDevServersFlag
+		}
 	}
 
 	assetConfig, err := assetserver.BuildAssetServerConfig(appoptions)
 	if err != nil {
 		return nil, err
 	}
+	// The policies only apply to production builds
+	assetConfig.Policies = nil
 
 	if assetConfig.Assets == nil && frontendDevServerURL != "" {
 		myLogger.Warning("No AssetServer.Assets has been defined but a frontend DevServer, the frontend DevServer will not be used.")
@@ -160,6 +179,13 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		}
 	}
 
+	if frontendDevServers != "" {
+		assetConfig.Middleware, err = devServersMiddleware(myLogger, frontendDevServers, assetConfig.Middleware)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Migrate deprecated options to the new AssetServer option
 	appoptions.Assets = nil
 	appoptions.AssetsHandler = nil
@@ -180,6 +206,7 @@ func CreateApp(appoptions *options.App) (*App, error) {
 	// Attach logger to context
 	ctx = context.WithValue(ctx, "logger", myLogger)
 	ctx = context.WithValue(ctx, "buildtype", "dev")
+	ctx = processBuildProfile(ctx)
 
 	// Preflight checks
 	err = PreflightChecks(appoptions, myLogger)
@@ -187,6 +214,23 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		return nil, err
 	}
 
+	err = processProxy(appoptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine the application data directory
+	ctx, appDataDir, err := processAppDataDirectory(ctx, appoptions, myLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Process the version stamp and migrations
+	ctx, err = processMigration(ctx, appoptions.Migration, appDataDir, myLogger)
+	if err != nil {
+		return nil, err
+	}
+
 	// Merge default options
 	options.MergeDefaults(appoptions)
 
@@ -208,18 +252,39 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		appoptions.OnShutdown,
 		appoptions.OnDomReady,
 		appoptions.OnBeforeClose,
+		appoptions.OnLaunchArguments,
 	}
 	appBindings := binding.NewBindings(myLogger, appoptions.Bind, bindingExemptions, false, appoptions.EnumBind)
 
+	err = processDeprecations(appBindings, appoptions)
+	if err != nil {
+		return nil, err
+	}
+	err = processMocks(appBindings, appoptions, myLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Route the launch arguments to OnLaunchArguments
+	processLaunchArguments(appoptions)
+
 	eventHandler := runtime.NewEvents(myLogger)
+	eventHandler.EnableSchemaValidation(myLogger.Warning)
 	ctx = context.WithValue(ctx, "events", eventHandler)
-	messageDispatcher := dispatcher.NewDispatcher(ctx, myLogger, appBindings, eventHandler, appoptions.ErrorFormatter)
+	ctx, instanceEvents, err := processInstanceEvents(ctx, appoptions, eventHandler, myLogger)
+	if err != nil {
+		return nil, err
+	}
+	processDeepLinks(appoptions, eventHandler, myLogger)
+	processFileAssociations(appoptions, eventHandler)
+	messageDispatcher := dispatcher.NewDispatcher(ctx, myLogger, appBindings, eventHandler, appoptions.ErrorFormatter, appoptions.PanicRecovery)
 
 	// Create the frontends and register to event handler
 	desktopFrontend := desktop.NewFrontend(ctx, appoptions, myLogger, appBindings, messageDispatcher)
 	appFrontend := devserver.NewFrontend(ctx, appoptions, myLogger, appBindings, messageDispatcher, menuManager, desktopFrontend)
 	eventHandler.AddFrontend(appFrontend)
 	eventHandler.AddFrontend(desktopFrontend)
+	processSingleInstanceLock(appoptions, appFrontend)
 
 	ctx = context.WithValue(ctx, "frontend", appFrontend)
 	result := &App{
@@ -231,9 +296,13 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		shutdownCallback: appoptions.OnShutdown,
 		debug:            true,
 		devtoolsEnabled:  true,
+		instanceEvents:   instanceEvents,
+		events:           eventHandler,
 	}
 
 	result.options = appoptions
+	result.ctx = context.WithValue(result.ctx, "relaunch", result.Relaunch)
+	result.ctx = context.WithValue(result.ctx, "relaunchelevated", result.RelaunchElevated)
 
 	return result, nil
 
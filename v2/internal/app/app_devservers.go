@@ -0,0 +1,55 @@
+//go:build dev
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/assetserver"
+	optionsassetserver "github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// devServersMiddleware serves the paths of the additional frontend dev servers of `frontend:dev:servers` from them.
+// The routes are the URLs of the dev servers by path, as JSON. The other requests are passed to the middleware.
+func devServersMiddleware(myLogger *logger.Logger, routes string, middleware optionsassetserver.Middleware) (optionsassetserver.Middleware, error) {
+	var urls map[string]string
+	if err := json.Unmarshal([]byte(routes), &urls); err != nil {
+		return nil, fmt.Errorf("invalid frontend dev servers: %w", err)
+	}
+
+	paths := make([]string, 0, len(urls))
+	proxies := map[string]http.Handler{}
+	for path, devServerURL := range urls {
+		parsed, err := url.Parse(devServerURL)
+		if err != nil || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid URL of the frontend dev server of %s: %s", path, devServerURL)
+		}
+		paths = append(paths, path)
+		proxies[path] = assetserver.NewProxyServer(devServerURL)
+		myLogger.Info("Serving %s from frontend DevServer URL: %s", path, devServerURL)
+	}
+	// The longest path wins if the paths are nested
+	sort.Slice(paths, func(i, j int) bool { return len(paths[i]) > len(paths[j]) })
+
+	devServers := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			for _, path := range paths {
+				if strings.HasPrefix(r.URL.Path, path) {
+					proxies[path].ServeHTTP(rw, r)
+					return
+				}
+			}
+			next.ServeHTTP(rw, r)
+		})
+	}
+	if middleware == nil {
+		return devServers, nil
+	}
+	return optionsassetserver.ChainMiddleware(devServers, middleware), nil
+}
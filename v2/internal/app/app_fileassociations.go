@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
+)
+
+// processFileAssociations delivers the files the application is opened with to OnFileOpened and to the frontend.
+// The files that arrive before the DOM is ready are emitted once it is ready, so that the frontend receives the files
+// the application has been started with.
+func processFileAssociations(appoptions *options.App, events frontend.Events) {
+	fileAssociations := appoptions.FileAssociations
+	if fileAssociations == nil {
+		return
+	}
+
+	dispatcher := &openDispatcher{
+		onOpen:    fileAssociations.OnFileOpened,
+		eventName: options.FileOpenedEvent,
+		events:    events,
+	}
+	deliver := func(files []string) {
+		for _, file := range files {
+			if isAssociatedFile(file, fileAssociations.Extensions) {
+				dispatcher.deliver(file)
+			}
+		}
+	}
+
+	onStartup := appoptions.OnStartup
+	appoptions.OnStartup = func(ctx context.Context) {
+		if onStartup != nil {
+			onStartup(ctx)
+		}
+		workingDirectory, _ := os.Getwd()
+		deliver(parseLaunchArguments(os.Args[1:], workingDirectory, false).Files)
+	}
+
+	onDomReady := appoptions.OnDomReady
+	appoptions.OnDomReady = func(ctx context.Context) {
+		if onDomReady != nil {
+			onDomReady(ctx)
+		}
+		dispatcher.ready()
+	}
+
+	if lock := appoptions.SingleInstanceLock; lock != nil {
+		onSecondInstanceLaunch := lock.OnSecondInstanceLaunch
+		lock.OnSecondInstanceLaunch = func(secondInstanceData options.SecondInstanceData) {
+			if onSecondInstanceLaunch != nil {
+				onSecondInstanceLaunch(secondInstanceData)
+			}
+			deliver(parseLaunchArguments(secondInstanceData.Args, secondInstanceData.WorkingDirectory, true).Files)
+		}
+	}
+
+	if goruntime.GOOS == "darwin" {
+		if appoptions.Mac == nil {
+			appoptions.Mac = &mac.Options{}
+		}
+		onFileOpen := appoptions.Mac.OnFileOpen
+		appoptions.Mac.OnFileOpen = func(filePath string) {
+			if onFileOpen != nil {
+				onFileOpen(filePath)
+			}
+			deliver([]string{filePath})
+		}
+	}
+}
+
+// isAssociatedFile reports whether the file has one of the extensions, all files are associated without extensions
+func isAssociatedFile(file string, extensions []string) bool {
+	if len(extensions) == 0 {
+		return true
+	}
+	extension := strings.TrimPrefix(filepath.Ext(file), ".")
+	for _, associated := range extensions {
+		if strings.EqualFold(extension, strings.TrimPrefix(associated, ".")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package app
+
+import "testing"
+
+func TestIsAssociatedFile(t *testing.T) {
+	extensions := []string{"md", ".TXT"}
+	for file, expected := range map[string]bool{
+		"/docs/readme.md":      true,
+		"/docs/NOTES.txt":      true,
+		"/docs/image.png":      false,
+		"/docs/markdown":       false,
+		"/docs/archive.md.zip": false,
+	} {
+		if isAssociatedFile(file, extensions) != expected {
+			t.Errorf("%s: expected %v", file, expected)
+		}
+	}
+	if !isAssociatedFile("/docs/image.png", nil) {
+		t.Error("files aren't associated without extensions")
+	}
+}
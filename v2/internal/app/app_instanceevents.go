@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/instanceevents"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// processInstanceEvents starts exchanging events with the other running instances of the application. The received
+// events are emitted as regular events with the unwrapped options.InstanceEvent as data.
+func processInstanceEvents(ctx context.Context, appoptions *options.App, events frontend.Events, myLogger *logger.Logger) (context.Context, *instanceevents.Bus, error) {
+	if appoptions.InstanceEvents == nil {
+		return ctx, nil, nil
+	}
+	if appoptions.SingleInstanceLock != nil {
+		myLogger.Warning("InstanceEvents is ignored as SingleInstanceLock is set")
+		return ctx, nil, nil
+	}
+
+	bus, err := instanceevents.New(appoptions.InstanceEvents.UniqueId, func(event options.InstanceEvent) {
+		events.Emit(event.Name, event)
+	}, myLogger)
+	if err != nil {
+		return ctx, nil, err
+	}
+	myLogger.Debug("Instance events enabled for instance %s", bus.ID())
+	return context.WithValue(ctx, "instanceevents", bus), bus, nil
+}
+
+// closeInstanceEvents stops exchanging events with the other instances
+func (a *App) closeInstanceEvents() {
+	if a.instanceEvents == nil {
+		return
+	}
+	err := a.instanceEvents.Close()
+	if err != nil {
+		a.logger.Error("Unable to close instance events: %s", err)
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
+)
+
+// processLaunchArguments routes the arguments, files and URLs of all launch sources to OnLaunchArguments
+func processLaunchArguments(appoptions *options.App) {
+	callback := appoptions.OnLaunchArguments
+	if callback == nil {
+		return
+	}
+
+	onStartup := appoptions.OnStartup
+	appoptions.OnStartup = func(ctx context.Context) {
+		if onStartup != nil {
+			onStartup(ctx)
+		}
+		workingDirectory, _ := os.Getwd()
+		callback(parseLaunchArguments(os.Args[1:], workingDirectory, false))
+	}
+
+	if lock := appoptions.SingleInstanceLock; lock != nil {
+		onSecondInstanceLaunch := lock.OnSecondInstanceLaunch
+		lock.OnSecondInstanceLaunch = func(secondInstanceData options.SecondInstanceData) {
+			if onSecondInstanceLaunch != nil {
+				onSecondInstanceLaunch(secondInstanceData)
+			}
+			callback(parseLaunchArguments(secondInstanceData.Args, secondInstanceData.WorkingDirectory, true))
+		}
+	}
+
+	if goruntime.GOOS == "darwin" {
+		if appoptions.Mac == nil {
+			appoptions.Mac = &mac.Options{}
+		}
+		onFileOpen := appoptions.Mac.OnFileOpen
+		appoptions.Mac.OnFileOpen = func(filePath string) {
+			if onFileOpen != nil {
+				onFileOpen(filePath)
+			}
+			callback(options.LaunchArguments{Files: []string{filePath}})
+		}
+		onUrlOpen := appoptions.Mac.OnUrlOpen
+		appoptions.Mac.OnUrlOpen = func(url string) {
+			if onUrlOpen != nil {
+				onUrlOpen(url)
+			}
+			callback(options.LaunchArguments{URLs: []string{url}})
+		}
+	}
+}
+
+// parseLaunchArguments sorts the arguments into URLs, existing files and other arguments
+func parseLaunchArguments(args []string, workingDirectory string, secondInstance bool) options.LaunchArguments {
+	result := options.LaunchArguments{
+		WorkingDirectory: workingDirectory,
+		SecondInstance:   secondInstance,
+	}
+	for _, arg := range args {
+		// macOS adds the process serial number when the app has been launched by the Finder
+		if strings.HasPrefix(arg, "-psn_") {
+			continue
+		}
+		if isLaunchURL(arg) {
+			result.URLs = append(result.URLs, arg)
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			path := arg
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(workingDirectory, path)
+			}
+			if _, err := os.Stat(path); err == nil {
+				result.Files = append(result.Files, path)
+				continue
+			}
+		}
+		result.Args = append(result.Args, arg)
+	}
+	return result
+}
+
+func isLaunchURL(arg string) bool {
+	if !strings.Contains(arg, ":") {
+		return false
+	}
+	parsed, err := url.Parse(arg)
+	// A single letter scheme is a drive letter on Windows
+	return err == nil && len(parsed.Scheme) > 1
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLaunchArguments(t *testing.T) {
+	workingDirectory := t.TempDir()
+	err := os.WriteFile(filepath.Join(workingDirectory, "document.txt"), []byte("test"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"-psn_0_12345", "--verbose", "document.txt", "missing.txt", "myapp://open?id=1"}
+	result := parseLaunchArguments(args, workingDirectory, true)
+
+	if !reflect.DeepEqual(result.Args, []string{"--verbose", "missing.txt"}) {
+		t.Errorf("unexpected args: %v", result.Args)
+	}
+	if !reflect.DeepEqual(result.Files, []string{filepath.Join(workingDirectory, "document.txt")}) {
+		t.Errorf("unexpected files: %v", result.Files)
+	}
+	if !reflect.DeepEqual(result.URLs, []string{"myapp://open?id=1"}) {
+		t.Errorf("unexpected urls: %v", result.URLs)
+	}
+	if !result.SecondInstance || result.WorkingDirectory != workingDirectory {
+		t.Errorf("unexpected launch details: %+v", result)
+	}
+}
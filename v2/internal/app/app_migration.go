@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+const versionStampFilename = ".wails-version"
+
+// processMigration compares the version stamp of the previous run with the current version of the application and
+// calls the configured migration hooks. The result is attached to the context for the runtime.
+func processMigration(ctx context.Context, migration *options.Migration, appDataDir string, myLogger *logger.Logger) (context.Context, error) {
+	if migration == nil {
+		return ctx, nil
+	}
+
+	current, err := semver.NewVersion(migration.Version)
+	if err != nil {
+		return ctx, errors.Wrap(err, "invalid Migration.Version")
+	}
+
+	directory := migration.Directory
+	if directory == "" {
+		directory = appDataDir
+	}
+	if directory == "" {
+		return ctx, errors.New("Migration.Directory is required without an application data directory")
+	}
+	stampFile := filepath.Join(directory, versionStampFilename)
+
+	var previous *semver.Version
+	stamp, err := os.ReadFile(stampFile)
+	switch {
+	case err == nil:
+		previous, err = semver.NewVersion(strings.TrimSpace(string(stamp)))
+		if err != nil {
+			return ctx, errors.Wrapf(err, "invalid version stamp in %s", stampFile)
+		}
+	case !os.IsNotExist(err):
+		return ctx, err
+	}
+
+	if previous == nil {
+		myLogger.Debug("No version stamp found, first run of version %s", current)
+		ctx = context.WithValue(ctx, "firstrun", true)
+		if migration.OnFirstRun != nil {
+			if err := migration.OnFirstRun(ctx); err != nil {
+				return ctx, errors.Wrap(err, "OnFirstRun failed")
+			}
+		}
+	} else {
+		ctx = context.WithValue(ctx, "previousversion", previous.String())
+		if previous.LessThan(current) {
+			myLogger.Info("Upgrading from version %s to %s", previous, current)
+			if migration.OnUpgrade != nil {
+				if err := migration.OnUpgrade(ctx, previous, current); err != nil {
+					return ctx, errors.Wrap(err, "OnUpgrade failed")
+				}
+			}
+		}
+	}
+
+	if previous != nil && previous.Equal(current) {
+		return ctx, nil
+	}
+
+	err = os.MkdirAll(directory, 0o755)
+	if err != nil {
+		return ctx, err
+	}
+	return ctx, os.WriteFile(stampFile, []byte(current.String()), 0o644)
+}
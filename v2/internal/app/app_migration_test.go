@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestProcessMigration(t *testing.T) {
+	myLogger := logger.New(nil)
+	directory := t.TempDir()
+
+	var firstRuns int
+	var upgrades []string
+	migration := func(version string) *options.Migration {
+		return &options.Migration{
+			Version: version,
+			OnFirstRun: func(ctx context.Context) error {
+				firstRuns++
+				return nil
+			},
+			OnUpgrade: func(ctx context.Context, from *semver.Version, to *semver.Version) error {
+				upgrades = append(upgrades, from.String()+"->"+to.String())
+				return nil
+			},
+		}
+	}
+
+	ctx, err := processMigration(context.Background(), migration("1.0.0"), directory, myLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstRuns != 1 || ctx.Value("firstrun") != true {
+		t.Errorf("expected a first run, got %d", firstRuns)
+	}
+
+	ctx, err = processMigration(context.Background(), migration("1.0.0"), directory, myLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstRuns != 1 || len(upgrades) != 0 || ctx.Value("previousversion") != "1.0.0" {
+		t.Errorf("expected no hooks for the same version, got %d first runs and upgrades %v", firstRuns, upgrades)
+	}
+
+	_, err = processMigration(context.Background(), migration("1.1.0"), directory, myLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(upgrades) != 1 || upgrades[0] != "1.0.0->1.1.0" {
+		t.Errorf("expected an upgrade from 1.0.0 to 1.1.0, got %v", upgrades)
+	}
+
+	_, err = processMigration(context.Background(), migration("invalid"), directory, myLogger)
+	if err == nil {
+		t.Error("expected an error for an invalid version")
+	}
+}
@@ -0,0 +1,29 @@
+//go:build dev
+
+package app
+
+import (
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// processMocks mocks the bound structs with the mocks of the options, which are only used in dev mode
+func processMocks(appBindings *binding.Bindings, appoptions *options.App, myLogger *logger.Logger) error {
+	for _, mock := range appoptions.Mocks {
+		added, err := appBindings.AddMock(mock.Name, mock.Service, mock.Implementation, binding.MockSettings{
+			Enabled:        !mock.Disabled,
+			Latency:        mock.Latency,
+			Jitter:         mock.Jitter,
+			FailureRate:    mock.FailureRate,
+			FailureMessage: mock.FailureMessage,
+		}, mock.Seed)
+		if err != nil {
+			return err
+		}
+		if !mock.Disabled {
+			myLogger.Info("Mock %s is enabled", added.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"sync"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// openDispatcher delivers what the application is opened with, EG: URLs or files, to a callback and to the frontend
+// with an event. The values that arrive before the DOM is ready are emitted once it is ready.
+type openDispatcher struct {
+	onOpen    func(value string)
+	eventName string
+	events    frontend.Events
+
+	lock    sync.Mutex
+	isReady bool
+	pending []string
+}
+
+// deliver passes the value to the callback and emits it, or queues it until the DOM is ready
+func (d *openDispatcher) deliver(value string) {
+	if d.onOpen != nil {
+		d.onOpen(value)
+	}
+	d.lock.Lock()
+	if !d.isReady {
+		d.pending = append(d.pending, value)
+		d.lock.Unlock()
+		return
+	}
+	d.lock.Unlock()
+	d.events.Emit(d.eventName, value)
+}
+
+// ready emits the queued values
+func (d *openDispatcher) ready() {
+	d.lock.Lock()
+	d.isReady = true
+	pending := d.pending
+	d.pending = nil
+	d.lock.Unlock()
+	for _, value := range pending {
+		d.events.Emit(d.eventName, value)
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+type emitEvents struct {
+	frontend.Events
+	emitted []string
+}
+
+func (e *emitEvents) Emit(eventName string, data ...interface{}) {
+	e.emitted = append(e.emitted, eventName+" "+data[0].(string))
+}
+
+func TestOpenDispatcher(t *testing.T) {
+	var opened []string
+	events := &emitEvents{}
+	dispatcher := &openDispatcher{
+		onOpen:    func(value string) { opened = append(opened, value) },
+		eventName: "open",
+		events:    events,
+	}
+
+	dispatcher.deliver("first")
+	if len(events.emitted) != 0 {
+		t.Errorf("values have been emitted before the DOM is ready: %v", events.emitted)
+	}
+	dispatcher.ready()
+	dispatcher.deliver("second")
+
+	if !reflect.DeepEqual(opened, []string{"first", "second"}) {
+		t.Errorf("unexpected opened values: %v", opened)
+	}
+	if !reflect.DeepEqual(events.emitted, []string{"open first", "open second"}) {
+		t.Errorf("unexpected emitted values: %v", events.emitted)
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"os"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/linux"
+	"github.com/wailsapp/wails/v2/pkg/options/windows"
+)
+
+// The runtime overrides can be set with an environment variable or with a command-line flag of the
+// form `--wails-<name>=<value>`. Flags take precedence over the environment variables.
+const (
+	overrideLogLevel   = "loglevel"
+	overrideDevtools   = "devtools"
+	overrideDisableGPU = "disablegpu"
+	overrideAssetDir   = "assetdir"
+)
+
+var overrideNames = []string{overrideLogLevel, overrideDevtools, overrideDisableGPU, overrideAssetDir}
+
+type runtimeOverrides map[string]string
+
+// parseRuntimeOverrides collects the overrides from the environment and the given command-line arguments. It returns
+// the arguments without the consumed override flags, so they don't reach the flag parsing of the application.
+func parseRuntimeOverrides(lookupEnv func(string) (string, bool), args []string) (runtimeOverrides, []string) {
+	result := runtimeOverrides{}
+	remaining := make([]string, 0, len(args))
+	for _, name := range overrideNames {
+		if value, ok := lookupEnv("WAILS_" + strings.ToUpper(name)); ok {
+			result[name] = value
+		}
+	}
+
+	for index, arg := range args {
+		if arg == "--" {
+			remaining = append(remaining, args[index:]...)
+			break
+		}
+		flag := strings.TrimLeft(arg, "-")
+		name, value, found := strings.Cut(strings.TrimPrefix(flag, "wails-"), "=")
+		if flag == arg || !strings.HasPrefix(flag, "wails-") || !isOverrideName(name) {
+			remaining = append(remaining, arg)
+			continue
+		}
+		if !found {
+			// Boolean flags may be used without a value
+			value = "true"
+		}
+		result[name] = value
+	}
+	return result, remaining
+}
+
+func isOverrideName(name string) bool {
+	for _, known := range overrideNames {
+		if name == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (o runtimeOverrides) bool(name string) bool {
+	switch strings.ToLower(o[name]) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// apply updates the application options with the overrides and returns the names of the applied overrides
+func (o runtimeOverrides) apply(appoptions *options.App, devtoolsEnabled *bool) ([]string, error) {
+	var applied []string
+
+	if value, ok := o[overrideLogLevel]; ok {
+		level, err := logger.StringToLogLevel(value)
+		if err != nil {
+			return nil, err
+		}
+		appoptions.LogLevel = level
+		appoptions.LogLevelProduction = level
+		applied = append(applied, overrideLogLevel)
+	}
+
+	if o.bool(overrideDevtools) {
+		*devtoolsEnabled = true
+		applied = append(applied, overrideDevtools)
+	}
+
+	if o.bool(overrideDisableGPU) {
+		if appoptions.Windows == nil {
+			appoptions.Windows = &windows.Options{}
+		}
+		appoptions.Windows.WebviewGpuIsDisabled = true
+		if appoptions.Linux == nil {
+			appoptions.Linux = &linux.Options{}
+		}
+		appoptions.Linux.WebviewGpuPolicy = linux.WebviewGpuPolicyNever
+		applied = append(applied, overrideDisableGPU)
+	}
+
+	if dir := o[overrideAssetDir]; dir != "" {
+		if appoptions.AssetServer != nil {
+			appoptions.AssetServer.Assets = os.DirFS(dir)
+		} else {
+			appoptions.Assets = os.DirFS(dir)
+		}
+		applied = append(applied, overrideAssetDir)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/linux"
+)
+
+func TestRuntimeOverrides(t *testing.T) {
+	env := map[string]string{
+		"WAILS_LOGLEVEL": "info",
+		"WAILS_DEVTOOLS": "1",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	args := []string{"--wails-loglevel=debug", "-wails-disablegpu", "--other", "--wails-unknown", "--", "--wails-assetdir=/tmp"}
+
+	overrides, remaining := parseRuntimeOverrides(lookupEnv, args)
+	if strings.Join(remaining, " ") != "--other --wails-unknown -- --wails-assetdir=/tmp" {
+		t.Errorf("expected the consumed flags to be removed, got %v", remaining)
+	}
+
+	appoptions := &options.App{}
+	devtoolsEnabled := false
+	applied, err := overrides.apply(appoptions, &devtoolsEnabled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 3 {
+		t.Errorf("expected 3 applied overrides, got %v", applied)
+	}
+	if appoptions.LogLevelProduction != logger.DEBUG {
+		t.Errorf("expected the flag to take precedence, got log level %v", appoptions.LogLevelProduction)
+	}
+	if !devtoolsEnabled {
+		t.Error("expected devtools to be enabled")
+	}
+	if !appoptions.Windows.WebviewGpuIsDisabled || appoptions.Linux.WebviewGpuPolicy != linux.WebviewGpuPolicyNever {
+		t.Error("expected GPU acceleration to be disabled")
+	}
+	if appoptions.Assets != nil {
+		t.Error("expected arguments after -- to be ignored")
+	}
+}
+
+func TestRuntimeOverridesInvalidLogLevel(t *testing.T) {
+	overrides, _ := parseRuntimeOverrides(func(string) (string, bool) { return "", false }, []string{"--wails-loglevel=verbose"})
+
+	devtoolsEnabled := false
+	if _, err := overrides.apply(&options.App{}, &devtoolsEnabled); err == nil {
+		t.Error("expected an error for an invalid log level")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build portable
+
+package app
+
+// IsPortable returns true if the portable build tag is set
+func IsPortable() bool {
+	return true
+}
@@ -4,6 +4,7 @@ package app
 
 import (
 	"context"
+	"os"
 
 	"github.com/wailsapp/wails/v2/internal/binding"
 	"github.com/wailsapp/wails/v2/internal/frontend/desktop"
@@ -21,7 +22,12 @@ func (a *App) Run() error {
 	if a.shutdownCallback != nil {
 		a.shutdownCallback(a.ctx)
 	}
-	return err
+	a.events.Close()
+	a.closeInstanceEvents()
+	if err != nil {
+		return err
+	}
+	return a.processRelaunch()
 }
 
 // CreateApp creates the app!
@@ -33,8 +39,23 @@ func CreateApp(appoptions *options.App) (*App, error) {
 	// Merge default options
 	options.MergeDefaults(appoptions)
 
+	// Wait for the instance that has relaunched the application
+	processRelaunchParent(appoptions)
+
 	debug := IsDebug()
 	devtoolsEnabled := IsDevtoolsEnabled()
+
+	// Apply the runtime overrides
+	var appliedOverrides []string
+	if appoptions.EnableRuntimeOverrides {
+		overrides, args := parseRuntimeOverrides(os.LookupEnv, os.Args[1:])
+		os.Args = append(os.Args[:1:1], args...)
+		appliedOverrides, err = overrides.apply(appoptions, &devtoolsEnabled)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ctx = context.WithValue(ctx, "debug", debug)
 	ctx = context.WithValue(ctx, "devtoolsEnabled", devtoolsEnabled)
 
@@ -48,12 +69,33 @@ func CreateApp(appoptions *options.App) (*App, error) {
 	ctx = context.WithValue(ctx, "logger", myLogger)
 	ctx = context.WithValue(ctx, "obfuscated", IsObfuscated())
 
+	for _, override := range appliedOverrides {
+		myLogger.Info("Runtime override applied: %s", override)
+	}
+
 	// Preflight Checks
 	err = PreflightChecks(appoptions, myLogger)
 	if err != nil {
 		return nil, err
 	}
 
+	err = processProxy(appoptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine the application data directory
+	ctx, appDataDir, err := processAppDataDirectory(ctx, appoptions, myLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Process the version stamp and migrations
+	ctx, err = processMigration(ctx, appoptions.Migration, appDataDir, myLogger)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the menu manager
 	menuManager := menumanager.NewManager()
 
@@ -71,20 +113,42 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		appoptions.OnShutdown,
 		appoptions.OnDomReady,
 		appoptions.OnBeforeClose,
+		appoptions.OnLaunchArguments,
 	}
 	appBindings := binding.NewBindings(myLogger, appoptions.Bind, bindingExemptions, IsObfuscated(), appoptions.EnumBind)
+
+	err = processDeprecations(appBindings, appoptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Route the launch arguments to OnLaunchArguments
+	processLaunchArguments(appoptions)
+
 	eventHandler := runtime.NewEvents(myLogger)
 	ctx = context.WithValue(ctx, "events", eventHandler)
+	ctx, instanceEvents, err := processInstanceEvents(ctx, appoptions, eventHandler, myLogger)
+	if err != nil {
+		return nil, err
+	}
+	processDeepLinks(appoptions, eventHandler, myLogger)
+	processFileAssociations(appoptions, eventHandler)
 	// Attach logger to context
 	if debug {
 		ctx = context.WithValue(ctx, "buildtype", "debug")
 	} else {
 		ctx = context.WithValue(ctx, "buildtype", "production")
 	}
+	ctx = processBuildProfile(ctx)
+	err = processAssetPolicies(appoptions, debug)
+	if err != nil {
+		return nil, err
+	}
 
-	messageDispatcher := dispatcher.NewDispatcher(ctx, myLogger, appBindings, eventHandler, appoptions.ErrorFormatter)
+	messageDispatcher := dispatcher.NewDispatcher(ctx, myLogger, appBindings, eventHandler, appoptions.ErrorFormatter, appoptions.PanicRecovery)
 	appFrontend := desktop.NewFrontend(ctx, appoptions, myLogger, appBindings, messageDispatcher)
 	eventHandler.AddFrontend(appFrontend)
+	processSingleInstanceLock(appoptions, appFrontend)
 
 	ctx = context.WithValue(ctx, "frontend", appFrontend)
 	result := &App{
@@ -97,7 +161,11 @@ func CreateApp(appoptions *options.App) (*App, error) {
 		debug:            debug,
 		devtoolsEnabled:  devtoolsEnabled,
 		options:          appoptions,
+		instanceEvents:   instanceEvents,
+		events:           eventHandler,
 	}
+	result.ctx = context.WithValue(result.ctx, "relaunch", result.Relaunch)
+	result.ctx = context.WithValue(result.ctx, "relaunchelevated", result.RelaunchElevated)
 
 	return result, nil
 
@@ -0,0 +1,12 @@
+package app
+
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// processProxy checks the proxy of the options before the webview is created
+func processProxy(appoptions *options.App) error {
+	_, err := frontend.ParseProxy(appoptions.Proxy)
+	return err
+}
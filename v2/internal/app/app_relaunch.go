@@ -0,0 +1,99 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wailsapp/wails/v2/pkg/elevate"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// Relaunch shuts down the application and starts a new instance of it with the given arguments once the
+// shutdown has completed
+func (a *App) Relaunch(args []string) {
+	a.relaunchArgs = args
+	a.relaunch = true
+	a.Shutdown()
+}
+
+// RelaunchElevated shuts down the application and starts a new instance of it with elevated privileges and the given
+// arguments once the shutdown has completed
+func (a *App) RelaunchElevated(args []string) {
+	a.relaunchElevated = true
+	a.Relaunch(args)
+}
+
+// relaunchParentFlag passes the process ID of the parent to the new instance. The new instance waits for the parent to
+// exit before it takes the single instance lock, else it would hand its arguments to the parent and exit.
+const relaunchParentFlag = "--wails-relaunch-parent="
+
+// relaunchParentTimeout limits the wait for the parent to exit
+const relaunchParentTimeout = 10 * time.Second
+
+// processRelaunchParent removes the flag of the parent from the arguments and waits for the parent to exit if the
+// single instance lock is used
+func processRelaunchParent(appoptions *options.App) {
+	var parent int
+	args := make([]string, 0, len(os.Args))
+	for index, arg := range os.Args {
+		if index > 0 && strings.HasPrefix(arg, relaunchParentFlag) {
+			parent, _ = strconv.Atoi(strings.TrimPrefix(arg, relaunchParentFlag))
+			continue
+		}
+		args = append(args, arg)
+	}
+	os.Args = args
+
+	if parent > 0 && appoptions.SingleInstanceLock != nil {
+		waitForProcessExit(parent, relaunchParentTimeout)
+	}
+}
+
+// relaunchArguments returns the arguments of the new instance
+func (a *App) relaunchArguments() []string {
+	if a.options == nil || a.options.SingleInstanceLock == nil {
+		return a.relaunchArgs
+	}
+	return append([]string{relaunchParentFlag + strconv.Itoa(os.Getpid())}, a.relaunchArgs...)
+}
+
+// processRelaunch starts the new instance of the application if a relaunch has been requested
+func (a *App) processRelaunch() error {
+	if !a.relaunch {
+		return nil
+	}
+
+	if a.relaunchElevated {
+		// The user is asked for permission after the shutdown, so the instances don't run at the same time
+		err := elevate.Relaunch(a.relaunchArguments()...)
+		if err != nil {
+			return errors.Wrap(err, "unable to relaunch the application elevated")
+		}
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "unable to relaunch the application")
+	}
+
+	cmd := relaunchCommand(exe, a.relaunchArguments())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Start()
+	if err != nil {
+		return errors.Wrap(err, "unable to relaunch the application")
+	}
+	return cmd.Process.Release()
+}
+
+func relaunchCommandForExecutable(exe string, args []string) *exec.Cmd {
+	// The new process inherits the privileges of this process, so an elevated
+	// application stays elevated after the relaunch.
+	return exec.Command(exe, args...)
+}
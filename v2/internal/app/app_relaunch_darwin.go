@@ -0,0 +1,24 @@
+//go:build darwin
+
+package app
+
+import (
+	"os/exec"
+	"strings"
+)
+
+func relaunchCommand(exe string, args []string) *exec.Cmd {
+	// Relaunch the application bundle through LaunchServices, so it is registered as a new application
+	index := strings.LastIndex(exe, ".app/Contents/MacOS/")
+	if index == -1 {
+		return relaunchCommandForExecutable(exe, args)
+	}
+	bundle := exe[:index+len(".app")]
+
+	openArgs := []string{"-n", bundle}
+	if len(args) > 0 {
+		openArgs = append(openArgs, "--args")
+		openArgs = append(openArgs, args...)
+	}
+	return exec.Command("open", openArgs...)
+}
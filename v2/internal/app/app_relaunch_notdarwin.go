@@ -0,0 +1,9 @@
+//go:build !darwin
+
+package app
+
+import "os/exec"
+
+func relaunchCommand(exe string, args []string) *exec.Cmd {
+	return relaunchCommandForExecutable(exe, args)
+}
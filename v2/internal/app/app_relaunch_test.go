@@ -0,0 +1,55 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestRelaunchWithSingleInstanceLock(t *testing.T) {
+	appoptions := &options.App{SingleInstanceLock: &options.SingleInstanceLock{UniqueId: "test"}}
+	a := &App{options: appoptions, relaunchArgs: []string{"--open", "file.txt"}}
+	args := a.relaunchArguments()
+	want := []string{relaunchParentFlag + strconv.Itoa(os.Getpid()), "--open", "file.txt"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("got arguments %v, want %v", args, want)
+	}
+
+	// The new instance waits for the parent to release the lock
+	parent := exec.Command(os.Args[0], "-test.run=^$")
+	if err := parent.Start(); err != nil {
+		t.Fatal(err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = parent.Wait()
+		close(exited)
+	}()
+
+	osArgs := os.Args
+	defer func() { os.Args = osArgs }()
+	os.Args = []string{"app", relaunchParentFlag + strconv.Itoa(parent.Process.Pid), "--open", "file.txt"}
+	processRelaunchParent(appoptions)
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Error("expected the new instance to wait for the parent to exit")
+	}
+	if !reflect.DeepEqual(os.Args, []string{"app", "--open", "file.txt"}) {
+		t.Errorf("expected the flag of the parent to be removed, got %v", os.Args)
+	}
+}
+
+func TestRelaunchWithoutSingleInstanceLock(t *testing.T) {
+	a := &App{options: &options.App{}, relaunchArgs: []string{"--open"}}
+	if args := a.relaunchArguments(); !reflect.DeepEqual(args, []string{"--open"}) {
+		t.Errorf("got arguments %v, want [--open]", args)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package app
+
+import (
+	"errors"
+	"syscall"
+	"time"
+)
+
+// waitForProcessExit waits until the process has exited or the timeout has passed
+func waitForProcessExit(pid int, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
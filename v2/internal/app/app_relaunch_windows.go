@@ -0,0 +1,20 @@
+//go:build windows
+
+package app
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// waitForProcessExit waits until the process has exited or the timeout has passed
+func waitForProcessExit(pid int, timeout time.Duration) {
+	process, err := windows.OpenProcess(windows.SYNCHRONIZE, false, uint32(pid))
+	if err != nil {
+		// The process has already exited
+		return
+	}
+	defer windows.CloseHandle(process)
+	_, _ = windows.WaitForSingleObject(process, uint32(timeout.Milliseconds()))
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// processSingleInstanceLock brings the window to the front when a second instance is launched if
+// SingleInstanceLock.FocusWindow is set
+func processSingleInstanceLock(appoptions *options.App, appFrontend frontend.Frontend) {
+	lock := appoptions.SingleInstanceLock
+	if lock == nil || !lock.FocusWindow {
+		return
+	}
+
+	onSecondInstanceLaunch := lock.OnSecondInstanceLaunch
+	lock.OnSecondInstanceLaunch = func(secondInstanceData options.SecondInstanceData) {
+		appFrontend.WindowUnminimise()
+		appFrontend.WindowShow()
+		if onSecondInstanceLaunch != nil {
+			onSecondInstanceLaunch(secondInstanceData)
+		}
+	}
+}
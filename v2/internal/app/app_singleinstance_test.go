@@ -0,0 +1,36 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+type focusFrontend struct {
+	frontend.Frontend
+	calls *[]string
+}
+
+func (f focusFrontend) WindowUnminimise() { *f.calls = append(*f.calls, "unminimise") }
+func (f focusFrontend) WindowShow()       { *f.calls = append(*f.calls, "show") }
+
+func TestProcessSingleInstanceLock(t *testing.T) {
+	var calls []string
+	appoptions := &options.App{
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:    "test",
+			FocusWindow: true,
+			OnSecondInstanceLaunch: func(secondInstanceData options.SecondInstanceData) {
+				calls = append(calls, "callback "+secondInstanceData.WorkingDirectory)
+			},
+		},
+	}
+	processSingleInstanceLock(appoptions, focusFrontend{calls: &calls})
+	appoptions.SingleInstanceLock.OnSecondInstanceLaunch(options.SecondInstanceData{WorkingDirectory: "/tmp"})
+
+	if !reflect.DeepEqual(calls, []string{"unminimise", "show", "callback /tmp"}) {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+}
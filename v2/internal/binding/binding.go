@@ -15,6 +15,7 @@ import (
 
 	"github.com/leaanthony/slicer"
 	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/obfuscation"
 )
 
 type Bindings struct {
@@ -27,7 +28,10 @@ type Bindings struct {
 	tsPrefix            string
 	tsSuffix            string
 	tsInterface         bool
+	tsNullability       typescriptify.Nullability
 	obfuscate           bool
+	apiVersion          string
+	mocks               []*Mock
 }
 
 // NewBindings returns a new Bindings object
@@ -62,6 +66,10 @@ func NewBindings(logger *logger.Logger, structPointersToBind []interface{}, exem
 		}
 	}
 
+	if obfuscate {
+		result.db.orderObfuscatedMethods(obfuscation.Methods())
+	}
+
 	return result
 }
 
@@ -106,6 +114,7 @@ func (b *Bindings) GenerateModels() ([]byte, error) {
 		w.WithPrefix(b.tsPrefix)
 		w.WithSuffix(b.tsSuffix)
 		w.WithInterface(b.tsInterface)
+		w.WithNullability(b.tsNullability)
 		w.Namespace = packageName
 		w.WithBackupDir("")
 		w.KnownStructs = allStructNames
@@ -128,12 +137,12 @@ func (b *Bindings) GenerateModels() ([]byte, error) {
 		// if we have enums for this package, add them as well
 		var enums, enumsExist = b.enumsToGenerateTS[packageName]
 		if enumsExist {
-			for enumName, enum := range enums {
+			for _, enumName := range sortedKeys(enums) {
 				fqemumname := packageName + "." + enumName
 				if seen.Contains(fqemumname) {
 					continue
 				}
-				w.AddEnum(enum)
+				w.AddEnum(enums[enumName])
 			}
 			seenEnumsPackages.Add(packageName)
 		}
@@ -161,12 +170,12 @@ func (b *Bindings) GenerateModels() ([]byte, error) {
 		w.Namespace = packageName
 		w.WithBackupDir("")
 
-		for enumName, enum := range enumsToGenerate {
+		for _, enumName := range sortedKeys(enumsToGenerate) {
 			fqemumname := packageName + "." + enumName
 			if seen.Contains(fqemumname) {
 				continue
 			}
-			w.AddEnum(enum)
+			w.AddEnum(enumsToGenerate[enumName])
 		}
 		str, err := w.Convert(nil)
 		if err != nil {
@@ -326,6 +335,11 @@ func (b *Bindings) SetOutputType(outputType string) *Bindings {
 	return b
 }
 
+func (b *Bindings) SetTsNullability(nullability string) *Bindings {
+	b.tsNullability = typescriptify.Nullability(nullability)
+	return b
+}
+
 func (b *Bindings) getAllStructNames() *slicer.StringSlicer {
 	var result slicer.StringSlicer
 	for packageName, structsToGenerate := range b.structsToGenerateTS {
@@ -336,6 +350,15 @@ func (b *Bindings) getAllStructNames() *slicer.StringSlicer {
 	return &result
 }
 
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (b *Bindings) getAllEnumNames() *slicer.StringSlicer {
 	var result slicer.StringSlicer
 	for packageName, enumsToGenerate := range b.enumsToGenerateTS {
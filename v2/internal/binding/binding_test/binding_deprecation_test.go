@@ -0,0 +1,76 @@
+package binding_test
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+const expectedDeprecationBindings = `// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Greet(arg1:string):Promise<string>;
+
+/**
+ * @deprecated Deprecated since 1.2.0. Greetings are localised. Use binding_test.DeprecationTest.Greet instead.
+ */
+export function GreetUser(arg1:string):Promise<string>;
+`
+
+const expectedAPIVersion = `// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export const APIVersion = "1.2.0";
+`
+
+type DeprecationTest struct{}
+
+func (d *DeprecationTest) Greet(name string) string     { return "Hello " + name }
+func (d *DeprecationTest) GreetUser(name string) string { return "Hello " + name }
+
+func TestDeprecation(t *testing.T) {
+	// given
+	generationDir := t.TempDir()
+	deprecationTest := &DeprecationTest{}
+
+	// setup
+	testLogger := &logger.Logger{}
+	b := binding.NewBindings(testLogger, []interface{}{deprecationTest}, []interface{}{}, false, []interface{}{})
+	b.SetAPIVersion("1.2.0")
+	err := b.Deprecate(deprecationTest.GreetUser, deprecationTest.Greet, "Greetings are localised.", "1.2.0")
+	if err != nil {
+		t.Fatalf("could not deprecate the method: %v", err)
+	}
+
+	// then
+	if err := b.Deprecate(func() {}, nil, "", ""); err == nil {
+		t.Fatal("expected an error when deprecating a function that is not bound")
+	}
+
+	// then
+	err = b.GenerateGoBindings(generationDir)
+	if err != nil {
+		t.Fatalf("could not generate the Go bindings: %v", err)
+	}
+
+	// then
+	rawGeneratedBindings, err := fs.ReadFile(os.DirFS(generationDir), "binding_test/DeprecationTest.d.ts")
+	if err != nil {
+		t.Fatalf("could not read the generated bindings: %v", err)
+	}
+	if generatedBindings := string(rawGeneratedBindings); generatedBindings != expectedDeprecationBindings {
+		t.Fatalf("the generated bindings does not match the expected ones.\nWanted:\n%s\n\nGot:\n%s", expectedDeprecationBindings, generatedBindings)
+	}
+
+	// then
+	rawAPIVersion, err := fs.ReadFile(os.DirFS(generationDir), "version.js")
+	if err != nil {
+		t.Fatalf("could not read the generated API version: %v", err)
+	}
+	if apiVersion := string(rawAPIVersion); apiVersion != expectedAPIVersion {
+		t.Fatalf("the generated API version does not match the expected one.\nWanted:\n%s\n\nGot:\n%s", expectedAPIVersion, apiVersion)
+	}
+}
@@ -0,0 +1,71 @@
+package binding_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/binding/binding_test/binding_test_import/enum_package"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+type DetectedEnumStruct struct {
+	Level enum_package.Level  `json:"level"`
+	Modes []enum_package.Mode `json:"modes"`
+	// The types of the standard library aren't detected
+	Timeout time.Duration `json:"timeout"`
+}
+
+func (s DetectedEnumStruct) Get(level enum_package.Level) DetectedEnumStruct {
+	return s
+}
+
+func TestBindings_DetectEnums(t *testing.T) {
+	b := binding.NewBindings(&logger.Logger{}, []interface{}{&DetectedEnumStruct{}}, nil, false, nil)
+	require.NoError(t, b.DetectEnums("."))
+
+	got, err := b.GenerateModels()
+	require.NoError(t, err)
+
+	want := `export namespace binding_test {
+	
+	export class DetectedEnumStruct {
+	    level: enum_package.Level;
+	    modes: string[];
+	    timeout: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DetectedEnumStruct(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.level = source["level"];
+	        this.modes = source["modes"];
+	        this.timeout = source["timeout"];
+	    }
+	}
+
+}
+
+export namespace enum_package {
+	
+	export enum Level {
+	    Low = 0,
+	    Medium = 1,
+	    High = 2,
+	}
+	export enum Mode {
+	    ModeRead = "read",
+	    ModeWrite = "write",
+	}
+
+}
+`
+	if !reflect.DeepEqual(strings.Fields(string(got)), strings.Fields(want)) {
+		t.Errorf("GenerateModels() got = %v, want %v", string(got), want)
+	}
+}
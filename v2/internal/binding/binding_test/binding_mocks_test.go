@@ -0,0 +1,108 @@
+package binding_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+type MockTest struct{}
+
+func (m *MockTest) Greet(name string) (string, error) { return "Hello " + name, nil }
+func (m *MockTest) Count() int                        { return 1 }
+
+type MockTestFixtures struct{}
+
+func (m *MockTestFixtures) Greet(name string) (string, error) { return "Mocked " + name, nil }
+
+type MockTestInvalid struct{}
+
+func (m *MockTestInvalid) Greet(name string) string { return name }
+
+func TestMocks(t *testing.T) {
+	// setup
+	mockTest := &MockTest{}
+	testLogger := &logger.Logger{}
+	b := binding.NewBindings(testLogger, []interface{}{mockTest}, []interface{}{}, false, []interface{}{})
+	greet := b.DB().GetMethod("binding_test.MockTest.Greet")
+	count := b.DB().GetMethod("binding_test.MockTest.Count")
+
+	// then
+	if _, err := b.AddMock("", &struct{}{}, nil, binding.MockSettings{}, 0); err == nil {
+		t.Fatal("expected an error when mocking a struct that is not bound")
+	}
+	if _, err := b.AddMock("", mockTest, &MockTestInvalid{}, binding.MockSettings{}, 0); err == nil {
+		t.Fatal("expected an error when mocking a method with another signature")
+	}
+
+	// given
+	mock, err := b.AddMock("", mockTest, &MockTestFixtures{}, binding.MockSettings{Enabled: true}, 0)
+	if err != nil {
+		t.Fatalf("could not add the mock: %v", err)
+	}
+	if b.Mock("binding_test.MockTestFixtures") != mock {
+		t.Fatal("expected the mock to be named after the implementation")
+	}
+	if info := mock.Info(); info.Service != "binding_test.MockTest" || len(info.Methods) != 1 || info.Methods[0] != "Greet" {
+		t.Fatalf("unexpected info %+v", info)
+	}
+
+	// then
+	if result, err := greet.Call([]interface{}{"Wails"}); result != "Mocked Wails" || err != nil {
+		t.Fatalf("Greet() = %v, %v, want the mocked result", result, err)
+	}
+	if result, _ := count.Call([]interface{}{}); result != 1 {
+		t.Fatalf("Count() = %v, want the result of the service", result)
+	}
+
+	// when
+	mock.SetSettings(binding.MockSettings{Enabled: true, Latency: 20 * time.Millisecond, FailureRate: 1, FailureMessage: "offline"})
+	start := time.Now()
+	result, err := greet.Call([]interface{}{"Wails"})
+
+	// then
+	if time.Since(start) < 20*time.Millisecond {
+		t.Fatal("expected the call to be delayed")
+	}
+	if result != "" || err == nil || err.Error() != "offline" {
+		t.Fatalf("Greet() = %v, %v, want the injected failure", result, err)
+	}
+	// Methods without an error are only delayed
+	if result, _ := count.Call([]interface{}{}); result != 1 {
+		t.Fatalf("Count() = %v, want the result of the service", result)
+	}
+
+	// when
+	mock.SetSettings(binding.MockSettings{})
+
+	// then
+	if result, _ := greet.Call([]interface{}{"Wails"}); result != "Hello Wails" {
+		t.Fatalf("Greet() = %v, want the result of the service when the mock is disabled", result)
+	}
+}
+
+func TestMockFailuresAreReproducible(t *testing.T) {
+	failures := func() []bool {
+		mockTest := &MockTest{}
+		b := binding.NewBindings(&logger.Logger{}, []interface{}{mockTest}, []interface{}{}, false, []interface{}{})
+		if _, err := b.AddMock("flaky", mockTest, nil, binding.MockSettings{Enabled: true, FailureRate: 0.5}, 42); err != nil {
+			t.Fatalf("could not add the mock: %v", err)
+		}
+		greet := b.DB().GetMethod("binding_test.MockTest.Greet")
+		var result []bool
+		for i := 0; i < 20; i++ {
+			_, err := greet.Call([]interface{}{"Wails"})
+			result = append(result, err != nil)
+		}
+		return result
+	}
+
+	first, second := failures(), failures()
+	for index := range first {
+		if first[index] != second[index] {
+			t.Fatalf("the failures differ with the same seed: %v and %v", first, second)
+		}
+	}
+}
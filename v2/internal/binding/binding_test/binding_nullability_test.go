@@ -0,0 +1,89 @@
+package binding_test
+
+type NullableFields struct {
+	Name     string            `json:"name"`
+	Nickname string            `json:"nickname,omitempty"`
+	Parent   *NullableFields   `json:"parent"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Age      int               `json:"age" ts_optional:"true"`
+}
+
+func (n NullableFields) Get() NullableFields {
+	return n
+}
+
+var NullableFieldsTest = BindingTest{
+	name: "NullableFields",
+	structs: []interface{}{
+		&NullableFields{},
+	},
+	exemptions:  nil,
+	shouldError: false,
+	TsGenerationOptionsTest: TsGenerationOptionsTest{
+		TsOutputType:  "interfaces",
+		TsNullability: "nullable",
+	},
+	want: `export namespace binding_test {
+	export interface NullableFields {
+		name: string;
+		nickname?: string;
+		parent: NullableFields | null;
+		tags: string[] | null;
+		labels?: {[key: string]: string};
+		age?: number;
+	}
+}
+`,
+}
+
+type StrictNullability struct {
+	Count int `json:"count,omitempty"`
+}
+
+func (s StrictNullability) Get() StrictNullability {
+	return s
+}
+
+var StrictNullabilityTest = BindingTest{
+	name: "StrictNullability",
+	structs: []interface{}{
+		&StrictNullability{},
+	},
+	exemptions:  nil,
+	shouldError: true,
+	TsGenerationOptionsTest: TsGenerationOptionsTest{
+		TsOutputType:  "interfaces",
+		TsNullability: "strict",
+	},
+	want: ``,
+}
+
+type StrictNullabilityWithTags struct {
+	Count int      `json:"count,omitempty" ts_optional:"false"`
+	Items []string `json:"items" ts_nullable:"false"`
+}
+
+func (s StrictNullabilityWithTags) Get() StrictNullabilityWithTags {
+	return s
+}
+
+var StrictNullabilityWithTagsTest = BindingTest{
+	name: "StrictNullabilityWithTags",
+	structs: []interface{}{
+		&StrictNullabilityWithTags{},
+	},
+	exemptions:  nil,
+	shouldError: false,
+	TsGenerationOptionsTest: TsGenerationOptionsTest{
+		TsOutputType:  "interfaces",
+		TsNullability: "strict",
+	},
+	want: `export namespace binding_test {
+	export interface StrictNullabilityWithTags {
+		count: number;
+		items: string[];
+	}
+}
+`,
+}
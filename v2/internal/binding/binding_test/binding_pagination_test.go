@@ -0,0 +1,78 @@
+package binding_test
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/pagination"
+)
+
+const expectedPaginationBindings = `// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {binding_test} from '../models';
+import {Page, PageData} from '../../runtime/runtime';
+
+export function Customers(arg1:string,arg2?:string):Promise<Page<binding_test.PaginationTestCustomer>>;
+
+export function Names(arg1?:string):Promise<Page<string>>;
+
+export function NotPaged(arg1:number):Promise<PageData<number>>;
+`
+
+const expectedPaginationJS = `export function Customers(arg1, arg2) {
+  return window['wails']['Paginate']((cursor) => window['go']['binding_test']['PaginationTest']['Customers'](arg1, cursor), arg2);
+}`
+
+type PaginationTest struct{}
+type PaginationTestCustomer struct {
+	Name string `json:"name"`
+}
+
+func (h *PaginationTest) Customers(_ string, _ string) (pagination.Page[PaginationTestCustomer], error) {
+	return pagination.Page[PaginationTestCustomer]{}, nil
+}
+func (h *PaginationTest) Names(_ string) pagination.Page[string] { return pagination.Page[string]{} }
+
+// NotPaged doesn't take a cursor, so the page can't fetch the following pages
+func (h *PaginationTest) NotPaged(_ int) pagination.Page[int] { return pagination.Page[int]{} }
+
+func TestPagination(t *testing.T) {
+	generationDir := t.TempDir()
+
+	testLogger := &logger.Logger{}
+	b := binding.NewBindings(testLogger, []interface{}{&PaginationTest{}}, []interface{}{}, false, []interface{}{})
+
+	err := b.GenerateGoBindings(generationDir)
+	if err != nil {
+		t.Fatalf("could not generate the Go bindings: %v", err)
+	}
+
+	generatedBindings, err := fs.ReadFile(os.DirFS(generationDir), "binding_test/PaginationTest.d.ts")
+	if err != nil {
+		t.Fatalf("could not read the generated bindings: %v", err)
+	}
+	if string(generatedBindings) != expectedPaginationBindings {
+		t.Fatalf("the generated bindings does not match the expected ones.\nWanted:\n%s\n\nGot:\n%s", expectedPaginationBindings, generatedBindings)
+	}
+
+	generatedJS, err := fs.ReadFile(os.DirFS(generationDir), "binding_test/PaginationTest.js")
+	if err != nil {
+		t.Fatalf("could not read the generated bindings: %v", err)
+	}
+	if !strings.Contains(string(generatedJS), expectedPaginationJS) {
+		t.Fatalf("the generated JS does not call Paginate.\nWanted:\n%s\n\nGot:\n%s", expectedPaginationJS, generatedJS)
+	}
+
+	// Only the items have models
+	models, err := fs.ReadFile(os.DirFS(generationDir), "models.ts")
+	if err != nil {
+		t.Fatalf("could not read the generated models: %v", err)
+	}
+	if !strings.Contains(string(models), "export class PaginationTestCustomer") || strings.Contains(string(models), "Page[") {
+		t.Fatalf("unexpected models:\n%s", models)
+	}
+}
@@ -21,9 +21,10 @@ type BindingTest struct {
 }
 
 type TsGenerationOptionsTest struct {
-	TsPrefix     string
-	TsSuffix     string
-	TsOutputType string
+	TsPrefix      string
+	TsSuffix      string
+	TsOutputType  string
+	TsNullability string
 }
 
 func TestBindings_GenerateModels(t *testing.T) {
@@ -51,6 +52,9 @@ func TestBindings_GenerateModels(t *testing.T) {
 		SpecialCharacterFieldTest,
 		WithoutFieldsTest,
 		NoFieldTagsTest,
+		NullableFieldsTest,
+		StrictNullabilityTest,
+		StrictNullabilityWithTagsTest,
 	}
 
 	testLogger := &logger.Logger{}
@@ -64,6 +68,7 @@ func TestBindings_GenerateModels(t *testing.T) {
 			b.SetTsPrefix(tt.TsPrefix)
 			b.SetTsSuffix(tt.TsSuffix)
 			b.SetOutputType(tt.TsOutputType)
+			b.SetTsNullability(tt.TsNullability)
 			got, err := b.GenerateModels()
 			if (err != nil) != tt.shouldError {
 				t.Errorf("GenerateModels() error = %v, shouldError %v", err, tt.shouldError)
@@ -0,0 +1,16 @@
+package enum_package
+
+type Level int
+
+const (
+	Low Level = iota
+	Medium
+	High
+)
+
+type Mode string
+
+const (
+	ModeRead  Mode = "read"
+	ModeWrite Mode = "write"
+)
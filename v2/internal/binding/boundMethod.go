@@ -14,6 +14,20 @@ type BoundMethod struct {
 	Outputs  []*Parameter  `json:"outputs,omitempty"`
 	Comments string        `json:"comments,omitempty"`
 	Method   reflect.Value `json:"-"`
+
+	// Deprecation is set when the method has been deprecated
+	Deprecation *Deprecation `json:"deprecation,omitempty"`
+
+	// reflectName is the name of the method as reported by the runtime, EG: "main.(*App).Greet"
+	reflectName string
+
+	// receiver is the pointer type of the bound struct and pointer the code pointer of the method, which identify the
+	// method in obfuscated builds
+	receiver reflect.Type
+	pointer  uintptr
+
+	// mock is set when the bound struct has been mocked in dev mode
+	mock *Mock
 }
 
 // InputCount returns the number of inputs this bound method has
@@ -68,8 +82,11 @@ func (b *BoundMethod) Call(args []interface{}) (interface{}, error) {
 		callArgs[index] = reflect.ValueOf(arg)
 	}
 
-	// Do the call
-	callResults := b.Method.Call(callArgs)
+	// Do the call, with the mock if it is enabled
+	callResults, mocked := b.mock.call(b, callArgs)
+	if !mocked {
+		callResults = b.Method.Call(callArgs)
+	}
 
 	//** Check results **//
 	var returnValue interface{}
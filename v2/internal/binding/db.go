@@ -2,6 +2,7 @@ package binding
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
 	"unsafe"
 )
@@ -69,7 +70,7 @@ func (d *DB) GetObfuscatedMethod(id int) *BoundMethod {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
 
-	if len(d.obfuscatedMethodArray) <= id {
+	if id < 0 || len(d.obfuscatedMethodArray) <= id || d.obfuscatedMethodArray[id] == nil {
 		return nil
 	}
 
@@ -107,6 +108,22 @@ func (d *DB) AddMethod(packageName string, structName string, methodName string,
 	d.obfuscatedMethodArray = append(d.obfuscatedMethodArray, &ObfuscatedMethod{method: methodDefinition, methodName: key})
 }
 
+// StructNames returns the sorted names of the bound structs, EG: "main.App"
+func (d *DB) StructNames() []string {
+	// Lock the db whilst processing and unlock on return
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	var result []string
+	for packageName, structMap := range d.store {
+		for structName := range structMap {
+			result = append(result, packageName+"."+structName)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 // ToJSON converts the method map to JSON
 func (d *DB) ToJSON() (string, error) {
 	// Lock the db whilst processing and unlock on return
@@ -127,6 +144,9 @@ func (d *DB) UpdateObfuscatedCallMap() map[string]int {
 	mappings := make(map[string]int)
 
 	for id, k := range d.obfuscatedMethodArray {
+		if k == nil {
+			continue
+		}
 		mappings[k.methodName] = id
 	}
 
@@ -0,0 +1,90 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Deprecation contains the details of a deprecated bound method
+type Deprecation struct {
+	// Replacement is the name of the bound method that should be used instead, EG: "main.App.Greet"
+	Replacement string `json:"replacement,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Since       string `json:"since,omitempty"`
+}
+
+// String returns the text of the deprecation notice
+func (d *Deprecation) String() string {
+	var result []string
+	if d.Since != "" {
+		result = append(result, "Deprecated since "+d.Since+".")
+	}
+	if d.Message != "" {
+		result = append(result, d.Message)
+	}
+	if d.Replacement != "" {
+		result = append(result, "Use "+d.Replacement+" instead.")
+	}
+	return strings.Join(result, " ")
+}
+
+// SetAPIVersion sets the version of the bound API
+func (b *Bindings) SetAPIVersion(version string) *Bindings {
+	b.apiVersion = version
+	return b
+}
+
+// APIVersion returns the version of the bound API
+func (b *Bindings) APIVersion() string {
+	return b.apiVersion
+}
+
+// Deprecate marks the given bound method as deprecated. The replacement is optional and must be a bound method.
+func (b *Bindings) Deprecate(method interface{}, replacement interface{}, message string, since string) error {
+	boundMethod := b.findMethod(method)
+	if boundMethod == nil {
+		return fmt.Errorf("cannot deprecate %s: not a bound method", funcName(method))
+	}
+
+	deprecation := &Deprecation{
+		Message: message,
+		Since:   since,
+	}
+	if replacement != nil {
+		replacementMethod := b.findMethod(replacement)
+		if replacementMethod == nil {
+			return fmt.Errorf("cannot deprecate %s: replacement %s is not a bound method", boundMethod.Name, funcName(replacement))
+		}
+		deprecation.Replacement = replacementMethod.Name
+	}
+	boundMethod.Deprecation = deprecation
+	return nil
+}
+
+// findMethod returns the bound method of the given method value, EG: app.Greet
+func (b *Bindings) findMethod(method interface{}) *BoundMethod {
+	if !isFunction(method) {
+		return nil
+	}
+	name := funcName(method)
+	for _, structs := range b.db.store {
+		for _, methods := range structs {
+			for _, boundMethod := range methods {
+				if boundMethod.reflectName == name {
+					return boundMethod
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func funcName(fn interface{}) string {
+	if !isFunction(fn) {
+		return fmt.Sprintf("%v", fn)
+	}
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	return strings.TrimSuffix(name, "-fm")
+}
@@ -0,0 +1,229 @@
+package binding
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+// DetectEnums looks up the constants of the named basic types used by the bound methods and their structs, and
+// adds them as enums to the generated Typescript. Types that have been added with EnumBind are left untouched.
+// The source of the packages is located with `go list` in the given directory, the main package is the package of
+// the directory. Only the packages of the main module are used, so the types of the standard library and
+// dependencies, EG: time.Duration, stay numbers and strings. Packages that can't be loaded have no enums.
+func (b *Bindings) DetectEnums(projectDir string) error {
+	return b.detectEnums(projectDir, b.enumCandidates())
+}
+
+func (b *Bindings) detectEnums(projectDir string, candidates map[string]map[string]reflect.Type) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	packagePaths := make([]string, 0, len(candidates))
+	for packagePath := range candidates {
+		if packagePath == "main" {
+			// The types of the main package have the path main, which go list only resolves from its directory
+			packagePath = "."
+		}
+		packagePaths = append(packagePaths, packagePath)
+	}
+	sort.Strings(packagePaths)
+
+	args := append([]string{"list", "-e", "-f", `{{.ImportPath}}|{{.Name}}|{{with .Module}}{{.Main}}{{end}}|{{.Dir}}|{{join .GoFiles ","}}|{{with .Error}}error{{end}}`}, packagePaths...)
+	stdout, stderr, err := shell.RunCommand(projectDir, "go", args...)
+	if err != nil {
+		return fmt.Errorf("unable to locate the packages of the enums: %s", strings.TrimSpace(stderr))
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		parts := strings.SplitN(line, "|", 6)
+		if len(parts) != 6 || parts[2] != "true" || parts[4] == "" || parts[5] != "" {
+			continue
+		}
+		packagePath := parts[0]
+		if parts[1] == "main" {
+			packagePath = "main"
+		}
+		enumTypes, exists := candidates[packagePath]
+		if !exists {
+			continue
+		}
+		var files []string
+		for _, file := range strings.Split(parts[4], ",") {
+			files = append(files, filepath.Join(parts[3], file))
+		}
+		err := b.addEnumsFromPackage(packagePath, files, enumTypes)
+		if err != nil {
+			b.logger.Debug("Unable to detect the enums of %s: %s", packagePath, err)
+		}
+	}
+	return nil
+}
+
+// enumCandidates returns the named basic types of all bound methods and structs, grouped by their package path
+func (b *Bindings) enumCandidates() map[string]map[string]reflect.Type {
+	result := map[string]map[string]reflect.Type{}
+	seen := map[reflect.Type]bool{}
+
+	var visit func(typ reflect.Type)
+	visit = func(typ reflect.Type) {
+		if typ == nil || seen[typ] {
+			return
+		}
+		seen[typ] = true
+
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			visit(typ.Elem())
+		case reflect.Map:
+			visit(typ.Key())
+			visit(typ.Elem())
+		case reflect.Struct:
+			for i := 0; i < typ.NumField(); i++ {
+				visit(typ.Field(i).Type)
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.String:
+			if typ.PkgPath() == "" || typ.Name() == "" {
+				return
+			}
+			if enums := b.enumsToGenerateTS[getPackageName(typ.String())]; enums != nil && enums[typ.Name()] != nil {
+				return
+			}
+			if result[typ.PkgPath()] == nil {
+				result[typ.PkgPath()] = map[string]reflect.Type{}
+			}
+			result[typ.PkgPath()][typ.Name()] = typ
+		}
+	}
+
+	for _, structs := range b.db.store {
+		for _, methods := range structs {
+			for _, method := range methods {
+				for _, input := range method.Inputs {
+					visit(input.reflectType)
+				}
+				for _, output := range method.Outputs {
+					visit(output.reflectType)
+				}
+			}
+		}
+	}
+	for _, structs := range b.structsToGenerateTS {
+		for _, s := range structs {
+			visit(reflect.TypeOf(s))
+		}
+	}
+	return result
+}
+
+// failingImporter is used to type check a single package. Only the constants of the package itself are needed.
+type failingImporter struct{}
+
+func (failingImporter) Import(path string) (*types.Package, error) {
+	return nil, fmt.Errorf("not importing %s", path)
+}
+
+func (b *Bindings) addEnumsFromPackage(packagePath string, filenames []string, enumTypes map[string]reflect.Type) error {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, filename := range filenames {
+		file, err := parser.ParseFile(fset, filename, nil, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+
+	config := types.Config{
+		Importer: failingImporter{},
+		// Errors of unresolved imports are expected, the constants of the package are still evaluated
+		Error: func(error) {},
+	}
+	pkg, _ := config.Check(packagePath, fset, files, nil)
+	if pkg == nil {
+		return nil
+	}
+
+	constants := map[string][]*types.Const{}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !c.Exported() {
+			continue
+		}
+		named, ok := c.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg {
+			continue
+		}
+		constants[named.Obj().Name()] = append(constants[named.Obj().Name()], c)
+	}
+
+	for typeName, typ := range enumTypes {
+		consts := constants[typeName]
+		if len(consts) == 0 {
+			continue
+		}
+		// Keep the declaration order of the constants
+		sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+		elementType := reflect.StructOf([]reflect.StructField{
+			{Name: "Value", Type: typ},
+			{Name: "TSName", Type: reflect.TypeOf("")},
+		})
+		values := reflect.MakeSlice(reflect.SliceOf(elementType), 0, len(consts))
+		for _, c := range consts {
+			value := reflect.New(typ).Elem()
+			if !setConstantValue(value, c.Val()) {
+				continue
+			}
+			element := reflect.New(elementType).Elem()
+			element.Field(0).Set(value)
+			element.Field(1).SetString(c.Name())
+			values = reflect.Append(values, element)
+		}
+		if values.Len() > 0 {
+			b.AddEnumToGenerateTS(values.Interface())
+		}
+	}
+	return nil
+}
+
+func setConstantValue(value reflect.Value, val constant.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, exact := constant.Int64Val(constant.ToInt(val))
+		if !exact {
+			return false
+		}
+		value.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, exact := constant.Uint64Val(constant.ToInt(val))
+		if !exact {
+			return false
+		}
+		value.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, _ := constant.Float64Val(constant.ToFloat(val))
+		value.SetFloat(v)
+	case reflect.String:
+		if val.Kind() != constant.String {
+			return false
+		}
+		value.SetString(constant.StringVal(val))
+	default:
+		return false
+	}
+	return true
+}
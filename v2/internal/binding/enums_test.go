@@ -0,0 +1,39 @@
+package binding
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+// mainLevel stands in for a type of the main package of an application, which has the package path main
+type mainLevel int
+
+func TestDetectEnumsMainPackage(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/app\n\ngo 1.18\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "main.go"), []byte(`package main
+
+type mainLevel int
+
+const (
+	LevelLow mainLevel = iota
+	LevelHigh
+)
+
+func main() {}
+`), 0o644))
+
+	b := NewBindings(logger.New(nil), []interface{}{}, []interface{}{}, false, []interface{}{})
+	candidates := map[string]map[string]reflect.Type{
+		"main": {"mainLevel": reflect.TypeOf(mainLevel(0))},
+		// Packages that can't be loaded have no enums
+		"example.com/app/missing": {"Missing": reflect.TypeOf(mainLevel(0))},
+	}
+	require.NoError(t, b.detectEnums(projectDir, candidates))
+	require.NotNil(t, b.enumsToGenerateTS["binding"]["mainLevel"])
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/wailsapp/wails/v2/internal/fs"
@@ -64,6 +66,7 @@ func (b *Bindings) GenerateGoBindings(baseDir string) error {
 			sort.Strings(methodNames)
 
 			var importNamespaces slicer.StringSlicer
+			var pageTypes slicer.StringSlicer
 			for _, methodName := range methodNames {
 				// Get the method details
 				methodDetails := methods[methodName]
@@ -75,22 +78,45 @@ func (b *Bindings) GenerateGoBindings(baseDir string) error {
 					args.Add(arg)
 				}
 				argsString := args.Join(", ")
-				jsoutput.WriteString(fmt.Sprintf("\nexport function %s(%s) {", methodName, argsString))
+				// Paged methods are called with the cursor of each page by the runtime
+				pageItems := methodDetails.pageItems()
+				callArgs := argsString
+				if pageItems != nil {
+					callArgs = "cursor"
+					if args.Length() > 1 {
+						callArgs = strings.Join(args.AsSlice()[:args.Length()-1], ", ") + ", cursor"
+					}
+				}
+				deprecationComment := deprecationJSDoc(methodDetails.Deprecation)
+				jsoutput.WriteString("\n" + deprecationComment)
+				jsoutput.WriteString(fmt.Sprintf("export function %s(%s) {", methodName, argsString))
 				jsoutput.WriteString("\n")
+				var call string
 				if b.obfuscate {
 					id := obfuscatedBindings[strings.Join([]string{packageName, structName, methodName}, ".")]
-					jsoutput.WriteString(fmt.Sprintf("  return ObfuscatedCall(%d, [%s]);", id, argsString))
+					call = fmt.Sprintf("ObfuscatedCall(%d, [%s])", id, callArgs)
 				} else {
-					jsoutput.WriteString(fmt.Sprintf("  return window['go']['%s']['%s']['%s'](%s);", packageName, structName, methodName, argsString))
+					call = fmt.Sprintf("window['go']['%s']['%s']['%s'](%s)", packageName, structName, methodName, callArgs)
+				}
+				if pageItems != nil {
+					jsoutput.WriteString(fmt.Sprintf("  return window['wails']['Paginate']((cursor) => %s, arg%d);", call, args.Length()))
+				} else {
+					jsoutput.WriteString("  return " + call + ";")
 				}
 				jsoutput.WriteString("\n}\n")
 
 				// Generate TS
-				tsBody.WriteString(fmt.Sprintf("\nexport function %s(", methodName))
+				tsBody.WriteString("\n" + deprecationComment)
+				tsBody.WriteString(fmt.Sprintf("export function %s(", methodName))
 
 				args.Clear()
 				for count, input := range methodDetails.Inputs {
 					arg := fmt.Sprintf("arg%d", count+1)
+					if pageItems != nil && count == len(methodDetails.Inputs)-1 {
+						// The cursor is optional for the first page
+						args.Add(arg + "?:string")
+						continue
+					}
 					entityName := entityFullReturnType(input.TypeName, b.tsPrefix, b.tsSuffix, &importNamespaces)
 					args.Add(arg + ":" + goTypeToTypescriptType(entityName, &importNamespaces))
 				}
@@ -102,7 +128,14 @@ func (b *Bindings) GenerateGoBindings(baseDir string) error {
 				// If returning two values, TS returns Promise<type1|type2>
 				// Otherwise, TS returns Promise<type1> (instead of throwing Go error?)
 				var returnType string
-				if methodDetails.OutputCount() == 0 {
+				if pageItems != nil {
+					returnType = "Promise<Page<" + b.pageItemsTypescriptType(pageItems, &importNamespaces) + ">>"
+					pageTypes.Add("Page")
+				} else if methodDetails.OutputCount() > 0 && pageItemType(methodDetails.Outputs[0].reflectType) != nil {
+					// Without a cursor the page can't fetch the following pages
+					returnType = "Promise<PageData<" + b.pageItemsTypescriptType(pageItemType(methodDetails.Outputs[0].reflectType), &importNamespaces) + ">>"
+					pageTypes.Add("PageData")
+				} else if methodDetails.OutputCount() == 0 {
 					returnType = "Promise<void>"
 				} else if methodDetails.OutputCount() == 1 && methodDetails.Outputs[0].TypeName == "error" {
 					returnType = "Promise<void>"
@@ -124,6 +157,10 @@ func (b *Bindings) GenerateGoBindings(baseDir string) error {
 			importNamespaces.Each(func(namespace string) {
 				tsContent.WriteString("import {" + namespace + "} from '../models';\n")
 			})
+			if pageTypes.Length() > 0 {
+				pageTypes.Deduplicate()
+				tsContent.WriteString("import {" + pageTypes.Join(", ") + "} from '../../runtime/runtime';\n")
+			}
 			tsContent.WriteString(tsBody.String())
 
 			jsfilename := filepath.Join(packageDir, structName+".js")
@@ -142,7 +179,37 @@ func (b *Bindings) GenerateGoBindings(baseDir string) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return b.writeAPIVersion(baseDir)
+}
+
+// pageItemsTypescriptType returns the Typescript type of the items of a page
+func (b *Bindings) pageItemsTypescriptType(itemType reflect.Type, importNamespaces *slicer.StringSlicer) string {
+	entityName := entityFullReturnType(itemType.String(), b.tsPrefix, b.tsSuffix, importNamespaces)
+	return goTypeToTypescriptType(entityName, importNamespaces)
+}
+
+// deprecationJSDoc returns the JSDoc comment of a deprecated method
+func deprecationJSDoc(deprecation *Deprecation) string {
+	if deprecation == nil {
+		return ""
+	}
+	return "/**\n * @deprecated " + deprecation.String() + "\n */\n"
+}
+
+// writeAPIVersion writes the API version to version.js so it can be checked by the frontend
+func (b *Bindings) writeAPIVersion(baseDir string) error {
+	if b.apiVersion == "" {
+		return nil
+	}
+	header := `// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+`
+	version := strconv.Quote(b.apiVersion)
+	err := os.WriteFile(filepath.Join(baseDir, "version.js"), []byte(header+"\nexport const APIVersion = "+version+";\n"), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(baseDir, "version.d.ts"), []byte(header+"\nexport const APIVersion: string;\n"), 0o755)
 }
 
 func fullyQualifiedName(packageName string, typeName string) string {
@@ -0,0 +1,212 @@
+package binding
+
+import (
+	"encoding"
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// jsonSchema is a JSON Schema document or sub-schema
+type jsonSchema map[string]interface{}
+
+// methodContract describes the parameters and the result of a bound method
+type methodContract struct {
+	Description string                `json:"description,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Deprecation *Deprecation          `json:"deprecation,omitempty"`
+	Params      []methodContractParam `json:"params"`
+	Result      jsonSchema            `json:"result,omitempty"`
+}
+
+type methodContractParam struct {
+	Name   string     `json:"name"`
+	Schema jsonSchema `json:"schema"`
+}
+
+type jsonSchemaGenerator struct {
+	defs jsonSchema
+}
+
+// GenerateJSONSchema writes a JSON Schema document that describes the contracts of all bound methods and the types
+// used by them to the given file. The methods are listed under the `methods` key by their fully qualified name.
+func (b *Bindings) GenerateJSONSchema(filename string) error {
+	data, err := b.JSONSchema()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
+
+// JSONSchema returns the JSON Schema document of the bound methods
+func (b *Bindings) JSONSchema() ([]byte, error) {
+	generator := &jsonSchemaGenerator{
+		defs: jsonSchema{},
+	}
+
+	methods := map[string]methodContract{}
+	for packageName, structs := range b.db.store {
+		for structName, boundMethods := range structs {
+			for methodName, method := range boundMethods {
+				contract := methodContract{
+					Description: strings.TrimSpace(method.Comments),
+					Deprecated:  method.Deprecation != nil,
+					Deprecation: method.Deprecation,
+					Params:      []methodContractParam{},
+				}
+				for index, input := range method.Inputs {
+					contract.Params = append(contract.Params, methodContractParam{
+						Name:   "arg" + strconv.Itoa(index+1),
+						Schema: generator.schemaFor(input.reflectType),
+					})
+				}
+				// The result is the first output that is not an error, the same as for the generated Typescript
+				for _, output := range method.Outputs {
+					if !output.IsError() {
+						contract.Result = generator.schemaFor(output.reflectType)
+						break
+					}
+				}
+				methods[packageName+"."+structName+"."+methodName] = contract
+			}
+		}
+	}
+
+	document := jsonSchema{
+		"$schema": jsonSchemaDraft,
+		"title":   "Wails bindings",
+		"$defs":   generator.defs,
+		"methods": methods,
+	}
+	if b.apiVersion != "" {
+		document["version"] = b.apiVersion
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func (g *jsonSchemaGenerator) schemaFor(typ reflect.Type) jsonSchema {
+	if typ == nil {
+		return jsonSchema{}
+	}
+
+	switch {
+	case typ == timeType:
+		return jsonSchema{"type": "string", "format": "date-time"}
+	case typ.Implements(jsonMarshalerType):
+		// Custom marshalling, the shape is unknown
+		return jsonSchema{}
+	case typ.Implements(textMarshalerType):
+		return jsonSchema{"type": "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return jsonSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Ptr:
+		return g.schemaFor(typ.Elem())
+	case reflect.Slice, reflect.Array:
+		// byte arrays are marshalled as base64 strings
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return jsonSchema{"type": "string", "contentEncoding": "base64"}
+		}
+		return jsonSchema{"type": "array", "items": g.schemaFor(typ.Elem())}
+	case reflect.Map:
+		return jsonSchema{"type": "object", "additionalProperties": g.schemaFor(typ.Elem())}
+	case reflect.Struct:
+		return g.structRef(typ)
+	default:
+		// Interfaces, functions and channels can hold anything
+		return jsonSchema{}
+	}
+}
+
+// structRef adds the definition of the struct to the $defs and returns a reference to it
+func (g *jsonSchemaGenerator) structRef(typ reflect.Type) jsonSchema {
+	name := typ.String()
+	if name == "" || typ.Name() == "" {
+		// Anonymous structs are inlined
+		return g.structSchema(typ)
+	}
+	name = strings.NewReplacer("[", "_", "]", "_", "*", "", " ", "").Replace(name)
+	ref := jsonSchema{"$ref": "#/$defs/" + name}
+	if _, exists := g.defs[name]; exists {
+		return ref
+	}
+	// Add a placeholder first to support recursive types
+	g.defs[name] = jsonSchema{}
+	g.defs[name] = g.structSchema(typ)
+	return ref
+}
+
+func (g *jsonSchemaGenerator) structSchema(typ reflect.Type) jsonSchema {
+	properties := jsonSchema{}
+	var required []string
+	g.addFields(typ, properties, &required)
+
+	result := jsonSchema{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		result["required"] = required
+	}
+	return result
+}
+
+func (g *jsonSchemaGenerator) addFields(typ reflect.Type, properties jsonSchema, required *[]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// Promote the fields of embedded structs without a json name, the same as encoding/json
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := g.schemaFor(field.Type)
+		if strings.Contains(opts, "string") {
+			schema = jsonSchema{"type": "string"}
+		}
+		properties[name] = schema
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+type SchemaPerson struct {
+	Name     string         `json:"name"`
+	Nickname string         `json:"nickname,omitempty"`
+	Tags     []string       `json:"tags"`
+	Avatar   []byte         `json:"avatar"`
+	Parent   *SchemaPerson  `json:"parent,omitempty"`
+	Scores   map[string]int `json:"scores"`
+	Ignored  string         `json:"-"`
+	private  string
+	Friends  []*SchemaPerson `json:"friends"`
+}
+
+type SchemaForTest struct{}
+
+func (s *SchemaForTest) Greet(person SchemaPerson, times int) (string, error) {
+	return "", nil
+}
+
+func TestJSONSchema(t *testing.T) {
+	testBindings := NewBindings(logger.New(nil), []interface{}{&SchemaForTest{}}, []interface{}{}, false, []interface{}{})
+
+	data, err := testBindings.JSONSchema()
+	require.NoError(t, err)
+
+	var document struct {
+		Defs    map[string]map[string]interface{} `json:"$defs"`
+		Methods map[string]struct {
+			Params []struct {
+				Name   string                 `json:"name"`
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"params"`
+			Result map[string]interface{} `json:"result"`
+		} `json:"methods"`
+	}
+	require.NoError(t, json.Unmarshal(data, &document))
+
+	method, ok := document.Methods["binding.SchemaForTest.Greet"]
+	require.True(t, ok)
+	require.Len(t, method.Params, 2)
+	assert.Equal(t, "#/$defs/binding.SchemaPerson", method.Params[0].Schema["$ref"])
+	assert.Equal(t, "integer", method.Params[1].Schema["type"])
+	assert.Equal(t, "string", method.Result["type"])
+
+	person := document.Defs["binding.SchemaPerson"]
+	properties := person["properties"].(map[string]interface{})
+	assert.NotContains(t, properties, "Ignored")
+	assert.NotContains(t, properties, "private")
+	assert.Equal(t, "base64", properties["avatar"].(map[string]interface{})["contentEncoding"])
+	assert.Equal(t, "#/$defs/binding.SchemaPerson", properties["parent"].(map[string]interface{})["$ref"])
+	assert.ElementsMatch(t, []interface{}{"avatar", "friends", "name", "scores", "tags"}, person["required"])
+}
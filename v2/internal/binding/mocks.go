@@ -0,0 +1,198 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// MockSettings are the settings of a mock that can be changed at runtime
+type MockSettings struct {
+	Enabled bool `json:"enabled"`
+	// Latency delays every call, Jitter adds a random delay of up to its duration
+	Latency time.Duration `json:"latency"`
+	Jitter  time.Duration `json:"jitter"`
+	// FailureRate is the fraction of the calls that return an error with FailureMessage
+	FailureRate    float64 `json:"failureRate"`
+	FailureMessage string  `json:"failureMessage"`
+}
+
+// MockInfo describes a mock for the debug page of the dev server
+type MockInfo struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+	// Methods are the methods of the service that are replaced by the mock
+	Methods []string `json:"methods"`
+	MockSettings
+}
+
+// Mock replaces the methods of a bound struct with the methods of an implementation and delays or fails the calls
+// of the struct while it is enabled
+type Mock struct {
+	name         string
+	service      string
+	methods      []string
+	replacements map[*BoundMethod]reflect.Value
+
+	lock     sync.Mutex
+	settings MockSettings
+	random   *rand.Rand
+}
+
+// AddMock mocks the bound struct of the service with the methods of the implementation, which may be nil to only
+// delay or fail the calls. The implementation must only have methods of the service with the same signatures. The
+// random delays and failures are reproducible with the seed.
+func (b *Bindings) AddMock(name string, service interface{}, implementation interface{}, settings MockSettings, seed int64) (*Mock, error) {
+	if !isStructPtr(service) {
+		return nil, fmt.Errorf("cannot mock %T: not a pointer to a struct", service)
+	}
+	serviceType := reflect.TypeOf(service)
+	methods := map[string]*BoundMethod{}
+	for _, structs := range b.db.store {
+		for _, boundMethods := range structs {
+			for methodName, boundMethod := range boundMethods {
+				if boundMethod.receiver == serviceType {
+					methods[methodName] = boundMethod
+				}
+			}
+		}
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("cannot mock %s: not a bound struct", serviceType.String()[1:])
+	}
+	if implementation != nil && !isStructPtr(implementation) {
+		return nil, fmt.Errorf("cannot mock %s with %T: not a pointer to a struct", serviceType.String()[1:], implementation)
+	}
+	if name == "" {
+		name = serviceType.String()[1:]
+		if implementation != nil {
+			name = reflect.TypeOf(implementation).String()[1:]
+		}
+	}
+	for _, mock := range b.mocks {
+		if mock.name == name {
+			return nil, fmt.Errorf("a mock with the name %s already exists", name)
+		}
+	}
+
+	mock := &Mock{
+		name:         name,
+		service:      serviceType.String()[1:],
+		replacements: map[*BoundMethod]reflect.Value{},
+		settings:     settings,
+		random:       rand.New(rand.NewSource(seed)),
+	}
+	if implementation != nil {
+		implementationType := reflect.TypeOf(implementation)
+		implementationValue := reflect.ValueOf(implementation)
+		for i := 0; i < implementationType.NumMethod(); i++ {
+			methodName := implementationType.Method(i).Name
+			boundMethod := methods[methodName]
+			if boundMethod == nil {
+				return nil, fmt.Errorf("cannot mock %s with %s: %s is not a method of %s", mock.service, name, methodName, mock.service)
+			}
+			replacement := implementationValue.Method(i)
+			if replacement.Type() != boundMethod.Method.Type() {
+				return nil, fmt.Errorf("cannot mock %s with %s: %s is %s, want %s", mock.service, name, methodName, replacement.Type(), boundMethod.Method.Type())
+			}
+			mock.methods = append(mock.methods, methodName)
+			mock.replacements[boundMethod] = replacement
+		}
+	}
+	for _, boundMethod := range methods {
+		if boundMethod.mock != nil {
+			return nil, fmt.Errorf("cannot mock %s with %s: it is already mocked by %s", mock.service, name, boundMethod.mock.name)
+		}
+	}
+	for _, boundMethod := range methods {
+		boundMethod.mock = mock
+	}
+	b.mocks = append(b.mocks, mock)
+	return mock, nil
+}
+
+// Mocks returns the mocks of the bound structs
+func (b *Bindings) Mocks() []*Mock {
+	return b.mocks
+}
+
+// Mock returns the mock with the name, nil if it doesn't exist
+func (b *Bindings) Mock(name string) *Mock {
+	for _, mock := range b.mocks {
+		if mock.name == name {
+			return mock
+		}
+	}
+	return nil
+}
+
+// Name returns the name of the mock
+func (m *Mock) Name() string {
+	return m.name
+}
+
+// Info returns the description and the current settings of the mock
+func (m *Mock) Info() MockInfo {
+	return MockInfo{Name: m.name, Service: m.service, Methods: append([]string{}, m.methods...), MockSettings: m.Settings()}
+}
+
+// Settings returns the current settings of the mock
+func (m *Mock) Settings() MockSettings {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.settings
+}
+
+// SetSettings changes the settings of the mock, which apply to the next calls
+func (m *Mock) SetSettings(settings MockSettings) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.settings = settings
+}
+
+// call calls the method while the mock is enabled. It returns false if the method of the service should be called.
+func (m *Mock) call(method *BoundMethod, args []reflect.Value) ([]reflect.Value, bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.lock.Lock()
+	settings := m.settings
+	if !settings.Enabled {
+		m.lock.Unlock()
+		return nil, false
+	}
+	delay := settings.Latency
+	if settings.Jitter > 0 {
+		delay += time.Duration(m.random.Int63n(int64(settings.Jitter)))
+	}
+	failed := settings.FailureRate > 0 && m.random.Float64() < settings.FailureRate
+	m.lock.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	methodType := method.Method.Type()
+	if failed && methodType.NumOut() > 0 && methodType.Out(methodType.NumOut()-1) == errorType {
+		message := settings.FailureMessage
+		if message == "" {
+			message = "mock failure"
+		}
+		results := make([]reflect.Value, methodType.NumOut())
+		for index := range results {
+			results[index] = reflect.Zero(methodType.Out(index))
+		}
+		failure := reflect.New(errorType).Elem()
+		failure.Set(reflect.ValueOf(errors.New(message)))
+		results[len(results)-1] = failure
+		return results, true
+	}
+	if replacement, ok := m.replacements[method]; ok {
+		return replacement.Call(args), true
+	}
+	return nil, false
+}
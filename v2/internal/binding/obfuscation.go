@@ -0,0 +1,133 @@
+package binding
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ObfuscatedRegistrationFile is the file of the main package the registration of the bound methods of obfuscated
+// builds is generated to
+const ObfuscatedRegistrationFile = "wailsbindings_obfuscated.go"
+
+// isRegistrable returns true if the method can be referenced by a method expression in the main package, which isn't
+// possible for the methods of unexported types of other packages and of generic types
+func (b *BoundMethod) isRegistrable() bool {
+	if b.receiver == nil {
+		return false
+	}
+	structType := b.receiver.Elem()
+	name := structType.Name()
+	if name == "" || strings.Contains(name, "[") {
+		return false
+	}
+	return structType.PkgPath() == "main" || token.IsExported(name)
+}
+
+// orderObfuscatedMethods assigns the IDs of the obfuscated calls. The registered methods get the IDs of the
+// registration as the names of garbled builds don't match the names of the generated bindings. The methods that
+// can't be registered follow in the order they have been bound. Without a registration, EG: when the bindings are
+// generated, the methods that can be registered come first.
+func (d *DB) orderObfuscatedMethods(registered []uintptr) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var ordered []*ObfuscatedMethod
+	var rest []*ObfuscatedMethod
+	if len(registered) > 0 {
+		byPointer := map[uintptr][]*ObfuscatedMethod{}
+		for _, method := range d.obfuscatedMethodArray {
+			byPointer[method.method.pointer] = append(byPointer[method.method.pointer], method)
+		}
+		for _, pointer := range registered {
+			methods := byPointer[pointer]
+			if len(methods) == 0 {
+				// The struct of the method isn't bound, the ID stays unused
+				ordered = append(ordered, nil)
+				continue
+			}
+			ordered = append(ordered, methods[0])
+			byPointer[pointer] = methods[1:]
+		}
+		for _, method := range d.obfuscatedMethodArray {
+			if methods := byPointer[method.method.pointer]; len(methods) > 0 && methods[0] == method {
+				rest = append(rest, method)
+				byPointer[method.method.pointer] = methods[1:]
+			}
+		}
+	} else {
+		for _, method := range d.obfuscatedMethodArray {
+			if method.method.isRegistrable() {
+				ordered = append(ordered, method)
+			} else {
+				rest = append(rest, method)
+			}
+		}
+	}
+	d.obfuscatedMethodArray = append(ordered, rest...)
+}
+
+// GenerateObfuscatedRegistration generates the registration of the bound methods by ID of obfuscated builds. The
+// file is excluded from the build of the bindings, so a stale registration doesn't break their generation.
+func (b *Bindings) GenerateObfuscatedRegistration(filename string) error {
+	b.db.lock.RLock()
+	var methods []*BoundMethod
+	for _, method := range b.db.obfuscatedMethodArray {
+		if method == nil || !method.method.isRegistrable() {
+			break
+		}
+		methods = append(methods, method.method)
+	}
+	b.db.lock.RUnlock()
+
+	aliases := map[string]string{}
+	var imports []string
+	for _, method := range methods {
+		pkgPath := method.receiver.Elem().PkgPath()
+		if _, exists := aliases[pkgPath]; pkgPath == "main" || exists {
+			continue
+		}
+		aliases[pkgPath] = ""
+		imports = append(imports, pkgPath)
+	}
+	sort.Strings(imports)
+	for index, pkgPath := range imports {
+		aliases[pkgPath] = fmt.Sprintf("p%d", index)
+	}
+
+	var output bytes.Buffer
+	output.WriteString(`//go:build obfuscated && !bindings
+
+// Code generated by Wails. DO NOT EDIT.
+// The bound methods by ID of obfuscated builds, which are regenerated by ` + "`wails build -obfuscated`" + `
+
+package main
+
+import (
+	"github.com/wailsapp/wails/v2/pkg/obfuscation"
+`)
+	for _, pkgPath := range imports {
+		output.WriteString(fmt.Sprintf("\t%s %q\n", aliases[pkgPath], pkgPath))
+	}
+	output.WriteString(")\n\nfunc init() {\n\tobfuscation.RegisterMethods(\n")
+	for _, method := range methods {
+		structType := method.receiver.Elem()
+		typeName := structType.Name()
+		if alias := aliases[structType.PkgPath()]; alias != "" {
+			typeName = alias + "." + typeName
+		}
+		methodName := method.Name[strings.LastIndex(method.Name, ".")+1:]
+		output.WriteString(fmt.Sprintf("\t\t(*%s).%s,\n", typeName, methodName))
+	}
+	output.WriteString("\t)\n}\n")
+
+	source, err := format.Source(output.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, source, 0o644)
+}
@@ -0,0 +1,85 @@
+package binding
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+type ObfuscatedService struct{}
+
+func (s *ObfuscatedService) Add(a int, b int) int { return a + b }
+func (s *ObfuscatedService) Hello() string        { return "hello" }
+
+type obfuscatedHidden struct{}
+
+func (h *obfuscatedHidden) Secret() string { return "secret" }
+
+func TestObfuscatedRegistration(t *testing.T) {
+	logger := logger.New(nil)
+	structs := []interface{}{&obfuscatedHidden{}, &ObfuscatedService{}}
+
+	// The bindings are generated without a registration
+	generated := NewBindings(logger, structs, []interface{}{}, true, nil)
+	ids := generated.DB().UpdateObfuscatedCallMap()
+	expected := map[string]int{
+		"binding.ObfuscatedService.Add":   0,
+		"binding.ObfuscatedService.Hello": 1,
+		"binding.obfuscatedHidden.Secret": 2,
+	}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("unexpected IDs: %v", ids)
+	}
+
+	filename := filepath.Join(t.TempDir(), ObfuscatedRegistrationFile)
+	if err := generated.GenerateObfuscatedRegistration(filename); err != nil {
+		t.Fatal(err)
+	}
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"//go:build obfuscated && !bindings",
+		`p0 "github.com/wailsapp/wails/v2/internal/binding"`,
+		"(*p0.ObfuscatedService).Add,\n\t\t(*p0.ObfuscatedService).Hello,\n\t)",
+	} {
+		if !strings.Contains(string(source), line) {
+			t.Errorf("%q is missing from the registration:\n%s", line, source)
+		}
+	}
+
+	// The obfuscated build resolves the IDs with the registered method expressions
+	runtime := NewBindings(logger, structs, []interface{}{}, true, nil)
+	runtime.DB().orderObfuscatedMethods([]uintptr{
+		reflect.ValueOf((*ObfuscatedService).Add).Pointer(),
+		reflect.ValueOf((*ObfuscatedService).Hello).Pointer(),
+	})
+	for name, id := range ids {
+		method := runtime.DB().GetObfuscatedMethod(id)
+		if method == nil || method.Name != name {
+			t.Errorf("ID %d of %s resolves to %v", id, name, method)
+		}
+	}
+}
+
+func TestObfuscatedRegistrationOfUnboundStruct(t *testing.T) {
+	bindings := NewBindings(logger.New(nil), []interface{}{&ObfuscatedService{}}, []interface{}{}, true, nil)
+	bindings.DB().orderObfuscatedMethods([]uintptr{
+		reflect.ValueOf((*obfuscatedHidden).Secret).Pointer(),
+		reflect.ValueOf((*ObfuscatedService).Add).Pointer(),
+	})
+	if method := bindings.DB().GetObfuscatedMethod(0); method != nil {
+		t.Errorf("the ID of the unbound method resolves to %s", method.Name)
+	}
+	if method := bindings.DB().GetObfuscatedMethod(1); method == nil || method.Name != "binding.ObfuscatedService.Add" {
+		t.Errorf("ID 1 resolves to %v", method)
+	}
+	if method := bindings.DB().GetObfuscatedMethod(2); method == nil || method.Name != "binding.ObfuscatedService.Hello" {
+		t.Errorf("ID 2 resolves to %v", method)
+	}
+}
@@ -0,0 +1,32 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+)
+
+const paginationPackage = "github.com/wailsapp/wails/v2/pkg/pagination"
+
+// pageItemType returns the type of the items of a pagination.Page, or nil if the type isn't a page
+func pageItemType(typ reflect.Type) reflect.Type {
+	if typ == nil || typ.Kind() != reflect.Struct || typ.PkgPath() != paginationPackage || !strings.HasPrefix(typ.Name(), "Page[") {
+		return nil
+	}
+	field, ok := typ.FieldByName("Items")
+	if !ok {
+		return nil
+	}
+	return field.Type.Elem()
+}
+
+// pageItems returns the type of the items if the method returns a pagination.Page and takes the cursor as last
+// argument, otherwise nil
+func (b *BoundMethod) pageItems() reflect.Type {
+	if len(b.Inputs) == 0 || b.Inputs[len(b.Inputs)-1].reflectType.Kind() != reflect.String {
+		return nil
+	}
+	if len(b.Outputs) == 0 || len(b.Outputs) > 2 || (len(b.Outputs) == 2 && !b.Outputs[1].IsError()) {
+		return nil
+	}
+	return pageItemType(b.Outputs[0].reflectType)
+}
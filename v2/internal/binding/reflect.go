@@ -64,11 +64,14 @@ func (b *Bindings) getMethods(value interface{}) ([]*BoundMethod, error) {
 
 		// Create new method
 		boundMethod := &BoundMethod{
-			Name:     fullMethodName,
-			Inputs:   nil,
-			Outputs:  nil,
-			Comments: "",
-			Method:   method,
+			Name:        fullMethodName,
+			Inputs:      nil,
+			Outputs:     nil,
+			Comments:    "",
+			Method:      method,
+			reflectName: methodReflectName,
+			receiver:    structType,
+			pointer:     methodDef.Func.Pointer(),
 		}
 
 		// Iterate inputs
@@ -122,6 +125,11 @@ func (b *Bindings) getMethods(value interface{}) ([]*BoundMethod, error) {
 
 			thisOutput := output
 
+			// Pages are generated as Page<T> of the runtime, so only the items need models
+			if itemType := pageItemType(thisOutput); itemType != nil {
+				thisOutput = itemType
+			}
+
 			if thisOutput.Kind() == reflect.Slice {
 				thisOutput = thisOutput.Elem()
 			}
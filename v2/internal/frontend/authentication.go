@@ -0,0 +1,87 @@
+package frontend
+
+import (
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// AuthenticationPolicy answers the HTTP authentication challenges of the webview
+type AuthenticationPolicy struct {
+	options *options.Authentication
+	proxy   *ProxyServer
+}
+
+// NewAuthenticationPolicy creates the policy of the application, which answers the challenges of the proxy with its
+// credentials
+func NewAuthenticationPolicy(authOptions *options.Authentication, proxy *ProxyServer) *AuthenticationPolicy {
+	return &AuthenticationPolicy{options: authOptions, proxy: proxy}
+}
+
+// Enabled reports if the authentication challenges are answered by the application
+func (p *AuthenticationPolicy) Enabled() bool {
+	return (p.options != nil && p.options.OnChallenge != nil) || p.proxy.HasCredentials()
+}
+
+// IntegratedServers returns the hosts that NTLM and Negotiate use the account of the signed in user for
+func (p *AuthenticationPolicy) IntegratedServers() []string {
+	if p.options == nil {
+		return nil
+	}
+	return p.options.IntegratedServers
+}
+
+// Integrated reports if the challenge is answered with the account of the signed in user
+func (p *AuthenticationPolicy) Integrated(challenge options.AuthenticationChallenge) bool {
+	if challenge.Scheme != options.AuthenticationSchemeNTLM && challenge.Scheme != options.AuthenticationSchemeNegotiate {
+		return false
+	}
+	host := strings.ToLower(challenge.Host)
+	for _, server := range p.IntegratedServers() {
+		server = strings.ToLower(server)
+		if server == host {
+			return true
+		}
+		if strings.HasPrefix(server, "*.") && strings.HasSuffix(host, server[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Credentials returns the credentials for the challenge or nil for the default handling
+func (p *AuthenticationPolicy) Credentials(challenge options.AuthenticationChallenge) *options.Credentials {
+	// The stored credentials of the proxy are only tried once, so wrong credentials continue with OnChallenge
+	if challenge.IsProxy && challenge.PreviousFailures == 0 && p.proxy.HasCredentials() && strings.EqualFold(challenge.Host, p.proxy.Host) {
+		return &options.Credentials{Username: p.proxy.Username, Password: p.proxy.Password}
+	}
+	if p.options == nil || p.options.OnChallenge == nil || challenge.Scheme == "" || p.Integrated(challenge) {
+		return nil
+	}
+	return p.options.OnChallenge(challenge)
+}
+
+// ParseAuthenticationScheme returns the scheme of the name, EG: "Basic" or "NTLM", or "" if it isn't supported
+func ParseAuthenticationScheme(name string) options.AuthenticationScheme {
+	switch scheme := options.AuthenticationScheme(strings.ToLower(name)); scheme {
+	case options.AuthenticationSchemeBasic, options.AuthenticationSchemeDigest, options.AuthenticationSchemeNTLM, options.AuthenticationSchemeNegotiate:
+		return scheme
+	}
+	return ""
+}
+
+// ParseAuthenticationHeader returns the scheme and the realm of a WWW-Authenticate header, EG:
+// `Basic realm="intranet"`
+func ParseAuthenticationHeader(header string) (options.AuthenticationScheme, string) {
+	header = strings.TrimSpace(header)
+	name, params, _ := strings.Cut(header, " ")
+	realm := ""
+	for _, param := range strings.Split(params, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if found && strings.EqualFold(key, "realm") {
+			realm = strings.Trim(value, `"`)
+			break
+		}
+	}
+	return ParseAuthenticationScheme(name), realm
+}
@@ -0,0 +1,87 @@
+package frontend
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Cookie is a cookie of the webview profile
+type Cookie struct {
+	Name   string
+	Value  string
+	Domain string
+	Path   string
+	// Expires is zero for session cookies
+	Expires  time.Time
+	Secure   bool
+	HTTPOnly bool
+	SameSite http.SameSite
+}
+
+// SiteData are the kinds of data that SiteDataClear removes from the webview profile
+type SiteData int
+
+const (
+	SiteDataCookies SiteData = 1 << iota
+	// SiteDataStorage is the local and session storage, IndexedDB, WebSQL and the service workers
+	SiteDataStorage
+	// SiteDataCache is the HTTP cache and the cache storage
+	SiteDataCache
+
+	SiteDataAll = SiteDataCookies | SiteDataStorage | SiteDataCache
+)
+
+// PrepareCookie checks that the cookie can be set and defaults its path to "/"
+func PrepareCookie(cookie Cookie) (Cookie, error) {
+	if cookie.Name == "" {
+		return cookie, errors.New("the cookie has no name")
+	}
+	if cookie.Domain == "" {
+		return cookie, errors.New("the cookie has no domain")
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	return cookie, nil
+}
+
+// CookieURL returns a URL the cookie is sent to, which the webviews use to look up the cookies of a domain
+func CookieURL(cookie Cookie) string {
+	scheme := "http"
+	if cookie.Secure {
+		scheme = "https"
+	}
+	path := cookie.Path
+	if path == "" {
+		path = "/"
+	}
+	return scheme + "://" + strings.TrimPrefix(cookie.Domain, ".") + path
+}
+
+// CookieMatchesURL reports if the cookie is sent to the URL
+func CookieMatchesURL(cookie Cookie, rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return false
+	}
+	if cookie.Secure && parsed.Scheme != "https" && parsed.Scheme != "wss" {
+		return false
+	}
+	// Cookies of a domain start with a dot and are also sent to its subdomains, the others only to their host
+	host := strings.ToLower(parsed.Hostname())
+	domain := strings.ToLower(cookie.Domain)
+	if host != strings.TrimPrefix(domain, ".") && !(strings.HasPrefix(domain, ".") && strings.HasSuffix(host, domain)) {
+		return false
+	}
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if cookie.Path == "" || cookie.Path == "/" || path == cookie.Path {
+		return true
+	}
+	return strings.HasPrefix(path, cookie.Path) && (strings.HasSuffix(cookie.Path, "/") || path[len(cookie.Path)] == '/')
+}
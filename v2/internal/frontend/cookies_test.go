@@ -0,0 +1,44 @@
+package frontend
+
+import "testing"
+
+func TestCookieMatchesURL(t *testing.T) {
+	tests := []struct {
+		cookie Cookie
+		url    string
+		want   bool
+	}{
+		{Cookie{Domain: "example.com", Path: "/"}, "https://example.com/account", true},
+		{Cookie{Domain: "example.com", Path: "/"}, "https://www.example.com/", false},
+		{Cookie{Domain: ".example.com", Path: "/"}, "https://www.example.com/", true},
+		{Cookie{Domain: ".example.com", Path: "/"}, "https://example.com", true},
+		{Cookie{Domain: ".example.com", Path: "/"}, "https://badexample.com/", false},
+		{Cookie{Domain: "example.com", Path: "/account"}, "https://example.com/account/settings", true},
+		{Cookie{Domain: "example.com", Path: "/account"}, "https://example.com/accounts", false},
+		{Cookie{Domain: "example.com", Path: "/account/"}, "https://example.com/account/settings", true},
+		{Cookie{Domain: "example.com", Path: "/", Secure: true}, "http://example.com/", false},
+		{Cookie{Domain: "example.com", Path: "/", Secure: true}, "https://example.com/", true},
+		{Cookie{Domain: "example.com", Path: "/"}, "not a url", false},
+	}
+	for _, test := range tests {
+		if got := CookieMatchesURL(test.cookie, test.url); got != test.want {
+			t.Errorf("CookieMatchesURL(%+v, %q) = %v, want %v", test.cookie, test.url, got, test.want)
+		}
+	}
+}
+
+func TestPrepareCookie(t *testing.T) {
+	if _, err := PrepareCookie(Cookie{Domain: "example.com"}); err == nil {
+		t.Error("expected an error for a cookie without a name")
+	}
+	if _, err := PrepareCookie(Cookie{Name: "session"}); err == nil {
+		t.Error("expected an error for a cookie without a domain")
+	}
+	cookie, err := PrepareCookie(Cookie{Name: "session", Domain: "example.com", Secure: true})
+	if err != nil || cookie.Path != "/" {
+		t.Errorf("PrepareCookie() = %+v, %v, want the default path", cookie, err)
+	}
+	if url := CookieURL(cookie); url != "https://example.com/" {
+		t.Errorf("CookieURL() = %q", url)
+	}
+}
@@ -14,6 +14,7 @@
 @interface AppDelegate : NSResponder <NSApplicationDelegate, NSTouchBarProvider>
 
 @property bool alwaysOnTop;
+@property bool skipTaskbar;
 @property bool startHidden;
 @property (retain) NSString* singleInstanceUniqueId;
 @property bool singleInstanceLockEnabled;
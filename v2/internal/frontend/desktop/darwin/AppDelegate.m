@@ -29,7 +29,12 @@
 }
 
 - (void)applicationWillFinishLaunching:(NSNotification *)aNotification {
-    [NSApp setActivationPolicy:NSApplicationActivationPolicyRegular];
+    if (self.skipTaskbar) {
+        [NSApp setActivationPolicy:NSApplicationActivationPolicyAccessory];
+        self.mainWindow.collectionBehavior |= NSWindowCollectionBehaviorTransient | NSWindowCollectionBehaviorIgnoresCycle;
+    } else {
+        [NSApp setActivationPolicy:NSApplicationActivationPolicyRegular];
+    }
     if (self.alwaysOnTop) {
         [self.mainWindow setLevel:NSFloatingWindowLevel];
     }
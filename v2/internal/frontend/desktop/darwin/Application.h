@@ -17,13 +17,15 @@
 #define WindowStartsMinimised 2
 #define WindowStartsFullscreen 3
 
-WailsContext* Create(const char* title, int width, int height, int frameless, int resizable, int zoomable, int fullscreen, int fullSizeContent, int hideTitleBar, int titlebarAppearsTransparent, int hideTitle, int useToolbar, int hideToolbarSeparator, int webviewIsTransparent, int alwaysOnTop, int hideWindowOnClose, const char *appearance, int windowIsTranslucent, int devtoolsEnabled, int defaultContextMenuEnabled, int windowStartState, int startsHidden, int minWidth, int minHeight, int maxWidth, int maxHeight, bool fraudulentWebsiteWarningEnabled, struct Preferences preferences, int singleInstanceEnabled, const char* singleInstanceUniqueId, bool enableDragAndDrop, bool disableWebViewDragAndDrop);
+WailsContext* Create(const char* title, int width, int height, int frameless, int resizable, int zoomable, int fullscreen, int fullSizeContent, int hideTitleBar, int titlebarAppearsTransparent, int hideTitle, int useToolbar, int hideToolbarSeparator, int webviewIsTransparent, int alwaysOnTop, int skipTaskbar, int hideWindowOnClose, const char *appearance, int windowIsTranslucent, int devtoolsEnabled, int defaultContextMenuEnabled, int windowStartState, int startsHidden, int minWidth, int minHeight, int maxWidth, int maxHeight, bool fraudulentWebsiteWarningEnabled, struct Preferences preferences, int singleInstanceEnabled, const char* singleInstanceUniqueId, bool enableDragAndDrop, bool disableWebViewDragAndDrop, const char* webviewProfileIdentifier, int webviewProfileInMemory);
 void Run(void*, const char* url);
 
 void SetTitle(void* ctx, const char *title);
 void Center(void* ctx);
 void SetSize(void* ctx, int width, int height);
 void SetAlwaysOnTop(void* ctx, int onTop);
+void SetSkipTaskbar(void* ctx, int skip);
+void SetAppearance(void* ctx, const char* appearance);
 void SetMinSize(void* ctx, int width, int height);
 void SetMaxSize(void* ctx, int width, int height);
 void SetPosition(void* ctx, int x, int y);
@@ -40,8 +42,26 @@ void HideApplication(void* ctx);
 void ShowApplication(void* ctx);
 void SetBackgroundColour(void* ctx, int r, int g, int b, int a);
 void ExecJS(void* ctx, const char*);
+void LoadURL(void* ctx, const char* url);
+void SetZoom(void* ctx, double factor);
+void SetAudioMuted(void* ctx, bool muted);
 void Quit(void*);
 void WindowPrint(void* ctx);
+void WindowPrintToPDF(void *inctx, const char* path, double width, double height, double top, double bottom, double left, double right, int landscape, double scale, int printBackground, int headerAndFooter);
+void WindowCaptureSnapshot(void *inctx, const char* path);
+int SetProxy(void *inctx, const char* scheme, const char* host, int port, const char* username, const char* password, const char* bypass);
+void GetCookies(void *inctx);
+void SetCookie(void *inctx, const char* name, const char* value, const char* domain, const char* path, long long expires, int secure, int httpOnly, int sameSite);
+void DeleteCookie(void *inctx, const char* name, const char* domain, const char* path);
+void ClearSiteData(void *inctx, int cookies, int storage, int cache);
+void CancelDownload(const char* downloadId);
+void ExecuteEditCommand(void* ctx, const char* selector);
+void AddUserScript(void* ctx, const char* script);
+void AddUserContent(void* ctx, const char* script, bool allFrames, bool atDocumentEnd);
+void ClearUserContent(void* ctx);
+void EmbedNativeView(void* ctx, uintptr_t view);
+void SetNativeViewBounds(void* ctx, uintptr_t view, int x, int y, int width, int height, bool visible);
+void RemoveNativeView(uintptr_t view);
 
 const char* GetSize(void *ctx);
 const char* GetPosition(void *ctx);
@@ -53,8 +73,12 @@ const bool IsMaximised(void *ctx);
 
 void MessageDialog(void *inctx, const char* dialogType, const char* title, const char* message, const char* button1, const char* button2, const char* button3, const char* button4, const char* defaultButton, const char* cancelButton, void* iconData, int iconDataLength);
 void OpenFileDialog(void *inctx, const char* title, const char* defaultFilename, const char* defaultDirectory, int allowDirectories, int allowFiles, int canCreateDirectories, int treatPackagesAsDirectories, int resolveAliases, int showHiddenFiles, int allowMultipleSelection, const char* filters);
+void OpenMediaDialog(void *inctx, const char* title, int mediaType, int allowMultipleSelection, const char* filters);
 void SaveFileDialog(void *inctx, const char* title, const char* defaultFilename, const char* defaultDirectory, int canCreateDirectories, int treatPackagesAsDirectories, int showHiddenFiles, const char* filters);
 
+/* Share */
+void Share(void *inctx, const char* text, const char* url, const char* files, int hasAnchor, int x, int y, int width, int height);
+
 /* Application Menu */
 void* NewMenu(const char* name);
 void AppendSubmenu(void* parent, void* child);
@@ -64,6 +88,7 @@ void UpdateApplicationMenu(void *inctx);
 
 void SetAbout(void *inctx, const char* title, const char* description, void* imagedata, int datalen);
 void* AppendMenuItem(void* inctx, void* nsmenu, const char* label, const char* shortcutKey, int modifiers, int disabled, int checked, int menuItemID);
+void AppendEditRoleItem(void* nsmenu, const char* label, const char* selector, const char* shortcutKey, int modifiers, int disabled);
 void AppendSeparator(void* inMenu);
 void UpdateMenuItem(void* nsmenuitem, int checked);
 void RunMainLoop(void);
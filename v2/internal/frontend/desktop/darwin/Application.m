@@ -13,8 +13,9 @@
 #import "WindowDelegate.h"
 #import "WailsMenu.h"
 #import "WailsMenuItem.h"
+#import "WailsDownload.h"
 
-WailsContext* Create(const char* title, int width, int height, int frameless, int resizable, int zoomable, int fullscreen, int fullSizeContent, int hideTitleBar, int titlebarAppearsTransparent, int hideTitle, int useToolbar, int hideToolbarSeparator, int webviewIsTransparent, int alwaysOnTop, int hideWindowOnClose, const char *appearance, int windowIsTranslucent, int devtoolsEnabled, int defaultContextMenuEnabled, int windowStartState, int startsHidden, int minWidth, int minHeight, int maxWidth, int maxHeight, bool fraudulentWebsiteWarningEnabled, struct Preferences preferences, int singleInstanceLockEnabled, const char* singleInstanceUniqueId, bool enableDragAndDrop, bool disableWebViewDragAndDrop) {
+WailsContext* Create(const char* title, int width, int height, int frameless, int resizable, int zoomable, int fullscreen, int fullSizeContent, int hideTitleBar, int titlebarAppearsTransparent, int hideTitle, int useToolbar, int hideToolbarSeparator, int webviewIsTransparent, int alwaysOnTop, int skipTaskbar, int hideWindowOnClose, const char *appearance, int windowIsTranslucent, int devtoolsEnabled, int defaultContextMenuEnabled, int windowStartState, int startsHidden, int minWidth, int minHeight, int maxWidth, int maxHeight, bool fraudulentWebsiteWarningEnabled, struct Preferences preferences, int singleInstanceLockEnabled, const char* singleInstanceUniqueId, bool enableDragAndDrop, bool disableWebViewDragAndDrop, const char* webviewProfileIdentifier, int webviewProfileInMemory) {
 
     [NSApplication sharedApplication];
 
@@ -22,6 +23,8 @@ WailsContext* Create(const char* title, int width, int height, int frameless, in
 
     result.devtoolsEnabled = devtoolsEnabled;
     result.defaultContextMenuEnabled = defaultContextMenuEnabled;
+    result.webviewProfileIdentifier = safeInit(webviewProfileIdentifier);
+    result.webviewProfileInMemory = webviewProfileInMemory;
 
     if ( windowStartState == WindowStartsFullscreen ) {
         fullscreen = 1;
@@ -54,6 +57,7 @@ WailsContext* Create(const char* title, int width, int height, int frameless, in
     }
 
     result.alwaysOnTop = alwaysOnTop;
+    result.skipTaskbar = skipTaskbar;
     result.hideOnClose = hideWindowOnClose;
 
     return result;
@@ -68,6 +72,116 @@ void ExecJS(void* inctx, const char *script) {
     );
 }
 
+void ExecuteEditCommand(void* inctx, const char *selector) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_selector = safeInit(selector);
+    ON_MAIN_THREAD(
+       // Send the action through the responder chain, so it reaches the focused element of the webview
+       [NSApp sendAction:NSSelectorFromString(_selector) to:nil from:ctx.webview];
+       [_selector release];
+    );
+}
+
+void AddUserScript(void* inctx, const char *script) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *source = safeInit(script);
+    WKUserScript *userScript = [[WKUserScript alloc] initWithSource:source
+                                                       injectionTime:WKUserScriptInjectionTimeAtDocumentStart
+                                                    forMainFrameOnly:true];
+    [ctx.userContentController addUserScript:userScript];
+    [userScript release];
+    [source release];
+}
+
+void AddUserContent(void* inctx, const char *script, bool allFrames, bool atDocumentEnd) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *source = safeInit(script);
+    ON_MAIN_THREAD(
+       if (ctx.baseUserScripts == nil) {
+           // Keep the scripts of wails and the options when the user content is cleared
+           ctx.baseUserScripts = ctx.userContentController.userScripts;
+       }
+       WKUserScript *userScript = [[WKUserScript alloc] initWithSource:source
+                                                          injectionTime:(atDocumentEnd ? WKUserScriptInjectionTimeAtDocumentEnd : WKUserScriptInjectionTimeAtDocumentStart)
+                                                       forMainFrameOnly:!allFrames];
+       [ctx.userContentController addUserScript:userScript];
+       [userScript release];
+       [source release];
+    );
+}
+
+void ClearUserContent(void* inctx) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    ON_MAIN_THREAD(
+       if (ctx.baseUserScripts != nil) {
+           [ctx.userContentController removeAllUserScripts];
+           for (WKUserScript *userScript in ctx.baseUserScripts) {
+               [ctx.userContentController addUserScript:userScript];
+           }
+       }
+    );
+}
+
+void EmbedNativeView(void* inctx, uintptr_t view) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSView *nativeView = (__bridge NSView*) (void*) view;
+    ON_MAIN_THREAD(
+       [nativeView setHidden:YES];
+       [ctx.webview.superview addSubview:nativeView positioned:NSWindowAbove relativeTo:ctx.webview];
+    );
+}
+
+void SetNativeViewBounds(void* inctx, uintptr_t view, int x, int y, int width, int height, bool visible) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSView *nativeView = (__bridge NSView*) (void*) view;
+    ON_MAIN_THREAD(
+       if (nativeView.superview == nil) {
+           return;
+       }
+       // The webview is flipped, so the bounds of the placeholder can be converted as they are
+       NSRect frame = [ctx.webview convertRect:NSMakeRect(x, y, width, height) toView:nativeView.superview];
+       [nativeView setFrame:frame];
+       [nativeView setHidden:(!visible || width <= 0 || height <= 0)];
+    );
+}
+
+void RemoveNativeView(uintptr_t view) {
+    NSView *nativeView = (__bridge NSView*) (void*) view;
+    ON_MAIN_THREAD(
+       [nativeView setHidden:YES];
+       [nativeView removeFromSuperview];
+    );
+}
+
+void LoadURL(void* inctx, const char *url) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_url = safeInit(url);
+    ON_MAIN_THREAD(
+       [ctx loadRequest:_url];
+       [_url release];
+    );
+}
+
+void SetZoom(void* inctx, double factor) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    ON_MAIN_THREAD(
+       if (@available(macOS 11.0, *)) {
+           ctx.webview.pageZoom = factor;
+       }
+    );
+}
+
+// SetAudioMuted mutes the audio of the webview with its private _setPageMuted:, WKWebView has no public API for it
+void SetAudioMuted(void* inctx, bool muted) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    ON_MAIN_THREAD(
+       if ([ctx.webview respondsToSelector:NSSelectorFromString(@"_setPageMuted:")]) {
+           // The audio is muted by the first bit of _WKMediaMutedState
+           [ctx.webview setValue:@(muted ? 1 : 0) forKey:@"pageMuted"];
+       }
+    );
+}
+
 void SetTitle(void* inctx, const char *title) {
     WailsContext *ctx = (__bridge WailsContext*) inctx;
     NSString *_title = safeInit(title);
@@ -98,6 +212,21 @@ void SetAlwaysOnTop(void* inctx, int onTop) {
     );
 }
 
+void SetSkipTaskbar(void* inctx, int skip) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    ON_MAIN_THREAD(
+       [ctx SetSkipTaskbar:skip];
+    );
+}
+
+void SetAppearance(void* inctx, const char* appearance) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_appearance = safeInit(appearance);
+    ON_MAIN_THREAD(
+       [ctx SetAppearance:_appearance];
+    );
+}
+
 void SetMinSize(void* inctx, int width, int height) {
     WailsContext *ctx = (__bridge WailsContext*) inctx;
     ON_MAIN_THREAD(
@@ -283,6 +412,17 @@ void OpenFileDialog(void *inctx, const char* title, const char* defaultFilename,
     )
 }
 
+void OpenMediaDialog(void *inctx, const char* title, int mediaType, int allowMultipleSelection, const char* filters) {
+
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_title = safeInit(title);
+    NSString *_filters = safeInit(filters);
+
+    ON_MAIN_THREAD(
+                   [ctx OpenMediaDialog:_title :mediaType :allowMultipleSelection :_filters];
+    )
+}
+
 void SaveFileDialog(void *inctx, const char* title, const char* defaultFilename, const char* defaultDirectory, int canCreateDirectories, int treatPackagesAsDirectories, int showHiddenFiles, const char* filters) {
 
     WailsContext *ctx = (__bridge WailsContext*) inctx;
@@ -331,6 +471,17 @@ void UpdateApplicationMenu(void *inctx) {
     )
 }
 
+void Share(void *inctx, const char* text, const char* url, const char* files, int hasAnchor, int x, int y, int width, int height) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_text = safeInit(text);
+    NSString *_url = safeInit(url);
+    NSString *_files = safeInit(files);
+
+    ON_MAIN_THREAD(
+                   [ctx Share:_text :_url :_files :hasAnchor :x :y :width :height];
+    )
+}
+
 void SetAbout(void *inctx, const char* title, const char* description, void* imagedata, int datalen) {
     WailsContext *ctx = (__bridge WailsContext*) inctx;
     NSString *_title = safeInit(title);
@@ -348,6 +499,15 @@ void* AppendMenuItem(void* inctx, void* inMenu, const char* label, const char* s
     return [menu AppendMenuItem:ctx :_label :_shortcutKey :modifiers :disabled :checked :menuItemID];
 }
 
+void AppendEditRoleItem(void* inMenu, const char* label, const char* selector, const char* shortcutKey, int modifiers, int disabled) {
+    WailsMenu *menu = (__bridge WailsMenu*) inMenu;
+    NSString *_label = safeInit(label);
+    NSString *_selector = safeInit(selector);
+    NSString *_shortcutKey = safeInit(shortcutKey);
+
+    [menu AppendEditRoleItem:_label :_selector :_shortcutKey :modifiers :disabled];
+}
+
 void UpdateMenuItem(void* nsmenuitem, int checked) {
     ON_MAIN_THREAD(
         WailsMenuItem *menuItem = (__bridge WailsMenuItem*) nsmenuitem;
@@ -371,6 +531,7 @@ void Run(void *inctx, const char* url) {
     ctx.appdelegate = delegate;
     delegate.mainWindow = ctx.mainWindow;
     delegate.alwaysOnTop = ctx.alwaysOnTop;
+    delegate.skipTaskbar = ctx.skipTaskbar;
     delegate.startHidden = ctx.startHidden;
     delegate.singleInstanceLockEnabled = ctx.singleInstanceLockEnabled;
     delegate.singleInstanceUniqueId = ctx.singleInstanceUniqueId;
@@ -393,6 +554,86 @@ void ReleaseContext(void *inctx) {
     [ctx release];
 }
 
+// The sizes are in inches, NSPrintInfo uses points
+void WindowPrintToPDF(void *inctx, const char* path, double width, double height, double top, double bottom, double left, double right, int landscape, double scale, int printBackground, int headerAndFooter) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_path = safeInit(path);
+    NSSize paperSize = NSMakeSize(width * 72, height * 72);
+    NSEdgeInsets margins = NSEdgeInsetsMake(top * 72, left * 72, bottom * 72, right * 72);
+
+    ON_MAIN_THREAD(
+                   [ctx PrintToPDF:_path :paperSize :margins :landscape :scale :printBackground :headerAndFooter];
+    )
+}
+
+void WindowCaptureSnapshot(void *inctx, const char* path) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_path = safeInit(path);
+
+    ON_MAIN_THREAD(
+                   [ctx CaptureSnapshot:_path];
+                   [_path release];
+    )
+}
+
+// SetProxy is called while the window is created, the bypassed hosts are separated by newlines
+int SetProxy(void *inctx, const char* scheme, const char* host, int port, const char* username, const char* password, const char* bypass) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSArray<NSString*> *_bypass = [safeInit(bypass) componentsSeparatedByString:@"\n"];
+    return [ctx SetProxy:safeInit(scheme) :safeInit(host) :port :safeInit(username) :safeInit(password) :_bypass];
+}
+
+void GetCookies(void *inctx) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+
+    ON_MAIN_THREAD(
+                   [ctx GetCookies];
+    )
+}
+
+void SetCookie(void *inctx, const char* name, const char* value, const char* domain, const char* path, long long expires, int secure, int httpOnly, int sameSite) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_name = safeInit(name);
+    NSString *_value = safeInit(value);
+    NSString *_domain = safeInit(domain);
+    NSString *_path = safeInit(path);
+
+    ON_MAIN_THREAD(
+                   [ctx SetCookie:_name :_value :_domain :_path :expires :secure :httpOnly :sameSite];
+    )
+}
+
+void DeleteCookie(void *inctx, const char* name, const char* domain, const char* path) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+    NSString *_name = safeInit(name);
+    NSString *_domain = safeInit(domain);
+    NSString *_path = safeInit(path);
+
+    ON_MAIN_THREAD(
+                   [ctx DeleteCookie:_name :_domain :_path];
+    )
+}
+
+void ClearSiteData(void *inctx, int cookies, int storage, int cache) {
+    WailsContext *ctx = (__bridge WailsContext*) inctx;
+
+    ON_MAIN_THREAD(
+                   [ctx ClearSiteData:cookies :storage :cache];
+    )
+}
+
+void CancelDownload(const char* downloadId) {
+#ifdef USE_WKDOWNLOAD
+    if (@available(macOS 11.3, *)) {
+        NSString *_downloadId = safeInit(downloadId);
+        ON_MAIN_THREAD(
+                       [WailsDownload cancel:_downloadId];
+                       [_downloadId release];
+        )
+    }
+#endif
+}
+
 // Credit: https://stackoverflow.com/q/33319295
 void WindowPrint(void *inctx) {
 
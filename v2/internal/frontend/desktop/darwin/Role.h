@@ -13,5 +13,14 @@ typedef int Role;
 static const Role AppMenu = 1;
 static const Role EditMenu = 2;
 static const Role WindowMenu = 3;
+// The text editing roles are handled by the Go side of the menus
+static const Role UndoRole = 4;
+static const Role RedoRole = 5;
+static const Role CutRole = 6;
+static const Role CopyRole = 7;
+static const Role PasteRole = 8;
+static const Role PasteAndMatchStyleRole = 9;
+static const Role SelectAllRole = 10;
+static const Role FindRole = 11;
 
 #endif /* Role_h */
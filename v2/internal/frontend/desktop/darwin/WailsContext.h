@@ -47,11 +47,16 @@
 @property (retain) NSEvent* mouseEvent;
 
 @property bool alwaysOnTop;
+@property bool skipTaskbar;
 
 @property bool devtoolsEnabled;
 @property bool defaultContextMenuEnabled;
 
+@property (retain) NSString* webviewProfileIdentifier;
+@property bool webviewProfileInMemory;
+
 @property (retain) WKUserContentController* userContentController;
+@property (retain) NSArray* baseUserScripts;
 
 @property (retain) NSMenu* applicationMenu;
 
@@ -59,10 +64,14 @@
 @property (retain) NSString* aboutTitle;
 @property (retain) NSString* aboutDescription;
 
+@property (retain) NSSharingServicePicker* sharePicker;
+
 struct Preferences {
   bool *tabFocusesLinks;
   bool *textInteractionEnabled;
   bool *fullscreenEnabled;
+  bool autoplayAllowed;
+  bool backgroundThrottlingDisabled;
 };
 
 - (void) CreateWindow:(int)width :(int)height :(bool)frameless :(bool)resizable :(bool)zoomable :(bool)fullscreen :(bool)fullSizeContent :(bool)hideTitleBar :(bool)titlebarAppearsTransparent  :(bool)hideTitle :(bool)useToolbar :(bool)hideToolbarSeparator :(bool)webviewIsTransparent :(bool)hideWindowOnClose :(NSString *)appearance :(bool)windowIsTranslucent :(int)minWidth :(int)minHeight :(int)maxWidth :(int)maxHeight :(bool)fraudulentWebsiteWarningEnabled :(struct Preferences)preferences :(bool)enableDragAndDrop :(bool)disableWebViewDragAndDrop;
@@ -72,6 +81,8 @@ struct Preferences {
 - (void) SetMaxSize:(int)maxWidth :(int)maxHeight;
 - (void) SetTitle:(NSString*)title;
 - (void) SetAlwaysOnTop:(int)onTop;
+- (void) SetSkipTaskbar:(int)skip;
+- (void) SetAppearance:(NSString*)appearance;
 - (void) Center;
 - (void) Fullscreen;
 - (void) UnFullscreen;
@@ -94,6 +105,7 @@ struct Preferences {
 
 -(void) MessageDialog :(NSString*)dialogType :(NSString*)title :(NSString*)message :(NSString*)button1 :(NSString*)button2 :(NSString*)button3 :(NSString*)button4 :(NSString*)defaultButton :(NSString*)cancelButton :(void*)iconData :(int)iconDataLength;
 - (void) OpenFileDialog :(NSString*)title :(NSString*)defaultFilename :(NSString*)defaultDirectory :(bool)allowDirectories :(bool)allowFiles :(bool)canCreateDirectories :(bool)treatPackagesAsDirectories :(bool)resolveAliases :(bool)showHiddenFiles :(bool)allowMultipleSelection :(NSString*)filters;
+- (void) OpenMediaDialog :(NSString*)title :(int)mediaType :(bool)allowMultipleSelection :(NSString*)filters;
 - (void) SaveFileDialog :(NSString*)title :(NSString*)defaultFilename :(NSString*)defaultDirectory :(bool)canCreateDirectories :(bool)treatPackagesAsDirectories :(bool)showHiddenFiles :(NSString*)filters;
 
 - (void) loadRequest:(NSString*)url;
@@ -101,6 +113,14 @@ struct Preferences {
 - (NSScreen*) getCurrentScreen;
 
 - (void) SetAbout :(NSString*)title :(NSString*)description :(void*)imagedata :(int)datalen;
+- (void) Share :(NSString*)text :(NSString*)url :(NSString*)files :(bool)hasAnchor :(int)x :(int)y :(int)width :(int)height;
+- (void) PrintToPDF :(NSString*)path :(NSSize)paperSize :(NSEdgeInsets)margins :(bool)landscape :(double)scale :(bool)printBackground :(bool)headerAndFooter;
+- (void) CaptureSnapshot :(NSString*)path;
+- (bool) SetProxy :(NSString*)scheme :(NSString*)host :(int)port :(NSString*)username :(NSString*)password :(NSArray<NSString*>*)bypass;
+- (void) GetCookies;
+- (void) SetCookie :(NSString*)name :(NSString*)value :(NSString*)domain :(NSString*)path :(long long)expires :(int)secure :(int)httpOnly :(int)sameSite;
+- (void) DeleteCookie :(NSString*)name :(NSString*)domain :(NSString*)path;
+- (void) ClearSiteData :(int)cookies :(int)storage :(int)cache;
 - (void) dealloc;
 
 @end
@@ -7,8 +7,12 @@
 
 #import <Foundation/Foundation.h>
 #import <WebKit/WebKit.h>
+#import <Security/Security.h>
+#import <Network/Network.h>
 #import "WailsContext.h"
 #import "WailsAlert.h"
+#import "WailsMediaPicker.h"
+#import "WailsDownload.h"
 #import "WailsMenu.h"
 #import "WailsWebView.h"
 #import "WindowDelegate.h"
@@ -108,7 +112,9 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
     [self.mainWindow release];
     [self.mouseEvent release];
     [self.userContentController release];
+    [self.baseUserScripts release];
     [self.applicationMenu release];
+    [self.sharePicker release];
     [super dealloc];
 }
 
@@ -245,11 +251,38 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
     }
 #endif
 
+    if (preferences.autoplayAllowed) {
+        config.mediaTypesRequiringUserActionForPlayback = WKAudiovisualMediaTypeNone;
+    }
+
+    if (preferences.backgroundThrottlingDisabled) {
+        // WKWebView has no public preferences for it, the private ones are set if the version of WebKit has them.
+        // Key-value coding finds their setters, which start with an underscore.
+        if ([config.preferences respondsToSelector:NSSelectorFromString(@"_setHiddenPageDOMTimerThrottlingEnabled:")]) {
+            [config.preferences setValue:@NO forKey:@"hiddenPageDOMTimerThrottlingEnabled"];
+        }
+        if ([config.preferences respondsToSelector:NSSelectorFromString(@"_setPageVisibilityBasedProcessSuppressionEnabled:")]) {
+            [config.preferences setValue:@NO forKey:@"pageVisibilityBasedProcessSuppressionEnabled"];
+        }
+    }
+
     WKUserContentController* userContentController = [WKUserContentController new];
     [userContentController addScriptMessageHandler:self name:@"external"];
     config.userContentController = userContentController;
     self.userContentController = userContentController;
 
+    if (self.webviewProfileInMemory) {
+        config.websiteDataStore = [WKWebsiteDataStore nonPersistentDataStore];
+    } else if (self.webviewProfileIdentifier != nil) {
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 140000
+        // Before macOS 14 all webviews share the default data store
+        if (@available(macOS 14.0, *)) {
+            NSUUID *identifier = [[[NSUUID alloc] initWithUUIDString:self.webviewProfileIdentifier] autorelease];
+            config.websiteDataStore = [WKWebsiteDataStore dataStoreForIdentifier:identifier];
+        }
+#endif
+    }
+
     if (self.devtoolsEnabled) {
         [config.preferences setValue:@YES forKey:@"developerExtrasEnabled"];
     }
@@ -278,6 +311,11 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
         [self.webview setValue:[NSNumber numberWithBool:!webviewIsTransparent] forKey:@"drawsBackground"];
     }
 
+    // The page is treated as hidden while the window is occluded unless the occlusion detection is disabled
+    if (preferences.backgroundThrottlingDisabled && [self.webview respondsToSelector:NSSelectorFromString(@"_setWindowOcclusionDetectionEnabled:")]) {
+        [self.webview setValue:@NO forKey:@"windowOcclusionDetectionEnabled"];
+    }
+
     [self.webview setNavigationDelegate:self];
     self.webview.UIDelegate = self;
 
@@ -429,6 +467,26 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
     }
 }
 
+// SetSkipTaskbar hides the application from the Dock and the application switcher and the window from Mission Control
+// and the window cycling. Accessory applications don't show their menu bar.
+- (void) SetSkipTaskbar:(int)skip {
+    self.skipTaskbar = skip;
+    NSWindowCollectionBehavior skipped = NSWindowCollectionBehaviorTransient | NSWindowCollectionBehaviorIgnoresCycle;
+    NSWindowCollectionBehavior behaviour = [self.mainWindow collectionBehavior];
+    [self.mainWindow setCollectionBehavior:skip ? behaviour | skipped : behaviour & ~skipped];
+    [NSApp setActivationPolicy:skip ? NSApplicationActivationPolicyAccessory : NSApplicationActivationPolicyRegular];
+}
+
+// SetAppearance sets the appearance of the window, which the webview reports as prefers-color-scheme. The window
+// follows the system if appearance is nil.
+- (void) SetAppearance:(NSString*)appearance {
+    NSAppearance *nsAppearance = nil;
+    if (appearance != nil) {
+        nsAppearance = [NSAppearance appearanceNamed:appearance];
+    }
+    [self.mainWindow setAppearance:nsAppearance];
+}
+
 - (bool) IsMaximised {
     return [self.mainWindow isZoomed];
 }
@@ -457,6 +515,34 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
         }];
 }
 
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 120000
+// The application decides the camera and microphone requests, the others aren't reported by WKWebView
+- (void)webView:(WKWebView *)webView requestMediaCapturePermissionForOrigin:(WKSecurityOrigin *)origin
+    initiatedByFrame:(WKFrameInfo *)frame type:(WKMediaCaptureType)type decisionHandler:(void (^)(WKPermissionDecision decision))decisionHandler API_AVAILABLE(macos(12.0)) {
+    const char *kind = "camera";
+    const char *otherKind = NULL;
+    if (type == WKMediaCaptureTypeMicrophone) {
+        kind = "microphone";
+    } else if (type == WKMediaCaptureTypeCameraAndMicrophone) {
+        otherKind = "microphone";
+    }
+    NSString *originURL = [NSString stringWithFormat:@"%@://%@", origin.protocol, origin.host];
+    if (origin.port != 0) {
+        originURL = [NSString stringWithFormat:@"%@:%ld", originURL, (long)origin.port];
+    }
+    switch (decidePermission([originURL UTF8String], kind, otherKind)) {
+    case 1:
+        decisionHandler(WKPermissionDecisionGrant);
+        break;
+    case 2:
+        decisionHandler(WKPermissionDecisionDeny);
+        break;
+    default:
+        decisionHandler(WKPermissionDecisionPrompt);
+    }
+}
+#endif
+
 - (void)webView:(nonnull WKWebView *)webView startURLSchemeTask:(nonnull id<WKURLSchemeTask>)urlSchemeTask {
     // This callback is run with an autorelease pool
     processURLRequest(self, urlSchemeTask);
@@ -476,7 +562,208 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
     processMessage("DomReady");
 }
 
+- (void)webViewWebContentProcessDidTerminate:(WKWebView *)webView {
+    processMessage("wails:contentcrashed");
+}
+
+- (void)webView:(WKWebView *)webView decidePolicyForNavigationAction:(WKNavigationAction *)navigationAction decisionHandler:(void (^)(WKNavigationActionPolicy))decisionHandler {
+    const char *url = [navigationAction.request.URL.absoluteString UTF8String];
+    if (!allowNavigation(url)) {
+        decisionHandler(WKNavigationActionPolicyCancel);
+        return;
+    }
+    // The navigations of new windows are decided by createWebViewWithConfiguration
+    char *load = NULL;
+    if (navigationAction.targetFrame.isMainFrame && !decideNavigation(url, [self isUserInitiated:navigationAction], &load)) {
+        decisionHandler(WKNavigationActionPolicyCancel);
+        [self loadDecidedURL:load];
+        return;
+    }
+#ifdef USE_WKDOWNLOAD
+    if (@available(macOS 11.3, *)) {
+        // Links with the download attribute
+        if (navigationAction.shouldPerformDownload && downloadsEnabled()) {
+            decisionHandler(WKNavigationActionPolicyDownload);
+            return;
+        }
+    }
+#endif
+    decisionHandler(WKNavigationActionPolicyAllow);
+}
+
+- (void)webView:(WKWebView *)webView decidePolicyForNavigationResponse:(WKNavigationResponse *)navigationResponse decisionHandler:(void (^)(WKNavigationResponsePolicy))decisionHandler {
+#ifdef USE_WKDOWNLOAD
+    if (@available(macOS 11.3, *)) {
+        if (navigationResponse.isForMainFrame && downloadsEnabled() && (!navigationResponse.canShowMIMEType || [self isAttachment:navigationResponse.response])) {
+            decisionHandler(WKNavigationResponsePolicyDownload);
+            return;
+        }
+    }
+#endif
+    decisionHandler(WKNavigationResponsePolicyAllow);
+}
+
+- (BOOL)isAttachment:(NSURLResponse *)response {
+    if (![response isKindOfClass:[NSHTTPURLResponse class]]) {
+        return NO;
+    }
+    NSString *disposition = [((NSHTTPURLResponse *)response) valueForHTTPHeaderField:@"Content-Disposition"];
+    return disposition != nil && [[disposition lowercaseString] hasPrefix:@"attachment"];
+}
+
+#ifdef USE_WKDOWNLOAD
+- (void)webView:(WKWebView *)webView navigationAction:(WKNavigationAction *)navigationAction didBecomeDownload:(WKDownload *)download API_AVAILABLE(macos(11.3)) {
+    [WailsDownload start:download :self.mainWindow];
+}
+
+- (void)webView:(WKWebView *)webView navigationResponse:(WKNavigationResponse *)navigationResponse didBecomeDownload:(WKDownload *)download API_AVAILABLE(macos(11.3)) {
+    [WailsDownload start:download :self.mainWindow];
+}
+#endif
+
+- (BOOL)isUserInitiated:(WKNavigationAction *)navigationAction {
+    return navigationAction.navigationType == WKNavigationTypeLinkActivated || navigationAction.navigationType == WKNavigationTypeFormSubmitted;
+}
+
+// loadDecidedURL loads the URL of a decision of the application, which is freed, once the delegate has returned
+- (void)loadDecidedURL:(char *)url {
+    if (url == NULL) {
+        return;
+    }
+    NSURL *decided = [NSURL URLWithString:[NSString stringWithUTF8String:url]];
+    free(url);
+    if (decided == nil) {
+        return;
+    }
+    dispatch_async(dispatch_get_main_queue(), ^{
+        [self.webview loadRequest:[NSURLRequest requestWithURL:decided]];
+    });
+}
+
+// The page opens a new window, which is never created
+- (WKWebView *)webView:(WKWebView *)webView createWebViewWithConfiguration:(WKWebViewConfiguration *)configuration forNavigationAction:(WKNavigationAction *)navigationAction windowFeatures:(WKWindowFeatures *)windowFeatures {
+    char *load = NULL;
+    if (decideNewWindow([navigationAction.request.URL.absoluteString UTF8String], [self isUserInitiated:navigationAction], &load)) {
+        [self loadDecidedURL:load];
+    }
+    return nil;
+}
+
+- (void)webView:(WKWebView *)webView didReceiveAuthenticationChallenge:(NSURLAuthenticationChallenge *)challenge completionHandler:(void (^)(NSURLSessionAuthChallengeDisposition, NSURLCredential *))completionHandler {
+    NSURLProtectionSpace *space = challenge.protectionSpace;
+    if ([space.authenticationMethod isEqualToString:NSURLAuthenticationMethodServerTrust] && space.serverTrust != nil) {
+        SecTrustRef trust = space.serverTrust;
+        if (![self isTrustedBySystem:trust] && [self acceptServerTrust:trust host:space.host]) {
+            completionHandler(NSURLSessionAuthChallengeUseCredential, [NSURLCredential credentialForTrust:trust]);
+            return;
+        }
+    }
+    if ([space.authenticationMethod isEqualToString:NSURLAuthenticationMethodClientCertificate]) {
+        NSURLCredential *credential = [self clientCertificateCredential:space];
+        if (credential != nil) {
+            completionHandler(NSURLSessionAuthChallengeUseCredential, credential);
+            return;
+        }
+    }
+    NSURLCredential *credential = [self passwordCredential:challenge];
+    if (credential != nil) {
+        completionHandler(NSURLSessionAuthChallengeUseCredential, credential);
+        return;
+    }
+    completionHandler(NSURLSessionAuthChallengePerformDefaultHandling, nil);
+}
+
+// passwordCredential asks the application for the credentials of basic, digest, NTLM and Negotiate challenges
+- (NSURLCredential *)passwordCredential:(NSURLAuthenticationChallenge *)challenge {
+    NSURLProtectionSpace *space = challenge.protectionSpace;
+    NSString *method = space.authenticationMethod;
+    const char *scheme = NULL;
+    if ([method isEqualToString:NSURLAuthenticationMethodHTTPBasic]) {
+        scheme = "basic";
+    } else if ([method isEqualToString:NSURLAuthenticationMethodHTTPDigest]) {
+        scheme = "digest";
+    } else if ([method isEqualToString:NSURLAuthenticationMethodNTLM]) {
+        scheme = "ntlm";
+    } else if ([method isEqualToString:NSURLAuthenticationMethodNegotiate]) {
+        scheme = "negotiate";
+    } else {
+        return nil;
+    }
+    const char *realm = space.realm != nil ? [space.realm UTF8String] : "";
+    char *username = NULL;
+    char *password = NULL;
+    if (!authenticationCredentials([space.host UTF8String], (int)space.port, realm, scheme, space.isProxy, (int)challenge.previousFailureCount, &username, &password)) {
+        return nil;
+    }
+    NSURLCredential *credential = [NSURLCredential credentialWithUser:[NSString stringWithUTF8String:username] password:[NSString stringWithUTF8String:password] persistence:NSURLCredentialPersistenceForSession];
+    free(username);
+    free(password);
+    return credential;
+}
+
+- (BOOL)isTrustedBySystem:(SecTrustRef)trust {
+    if (@available(macOS 10.14, *)) {
+        return SecTrustEvaluateWithError(trust, NULL);
+    }
+    SecTrustResultType result;
+    return SecTrustEvaluate(trust, &result) == errSecSuccess && (result == kSecTrustResultProceed || result == kSecTrustResultUnspecified);
+}
+
+// acceptServerTrust asks the application to trust the certificates of the server, which are passed as DER
+- (BOOL)acceptServerTrust:(SecTrustRef)trust host:(NSString *)host {
+    CFIndex count = SecTrustGetCertificateCount(trust);
+    NSMutableData *data = [NSMutableData new];
+    int *lengths = malloc(sizeof(int) * (count > 0 ? count : 1));
+    for (CFIndex i = 0; i < count; i++) {
+        CFDataRef der = SecCertificateCopyData(SecTrustGetCertificateAtIndex(trust, i));
+        [data appendData:(NSData *)der];
+        lengths[i] = (int)CFDataGetLength(der);
+        CFRelease(der);
+    }
+    int accepted = acceptServerCertificate([host UTF8String], data.bytes, lengths, (int)count);
+    free(lengths);
+    [data release];
+    return accepted != 0;
+}
+
+// clientCertificateCredential imports the PKCS12 that is provided by the application
+- (NSURLCredential *)clientCertificateCredential:(NSURLProtectionSpace *)space {
+    void *pkcs12 = NULL;
+    int length = 0;
+    char *password = NULL;
+    if (!clientCertificate([space.host UTF8String], (int)space.port, &pkcs12, &length, &password)) {
+        return nil;
+    }
+    NSData *data = [NSData dataWithBytesNoCopy:pkcs12 length:length freeWhenDone:YES];
+    NSDictionary *importOptions = @{(id)kSecImportExportPassphrase: [NSString stringWithUTF8String:password]};
+    free(password);
+    CFArrayRef items = NULL;
+    OSStatus status = SecPKCS12Import((CFDataRef)data, (CFDictionaryRef)importOptions, &items);
+    if (status != errSecSuccess || items == NULL || CFArrayGetCount(items) == 0) {
+        NSLog(@"Unable to import the client certificate: %d", (int)status);
+        if (items != NULL) {
+            CFRelease(items);
+        }
+        return nil;
+    }
+    NSDictionary *item = [(NSArray *)items objectAtIndex:0];
+    SecIdentityRef identity = (SecIdentityRef)[item objectForKey:(id)kSecImportItemIdentity];
+    NSArray *chain = [item objectForKey:(id)kSecImportItemCertChain];
+    NSArray *certificates = chain.count > 1 ? [chain subarrayWithRange:NSMakeRange(1, chain.count - 1)] : nil;
+    NSURLCredential *credential = [NSURLCredential credentialWithIdentity:identity certificates:certificates persistence:NSURLCredentialPersistenceForSession];
+    CFRelease(items);
+    return credential;
+}
+
 - (void)userContentController:(nonnull WKUserContentController *)userContentController didReceiveScriptMessage:(nonnull WKScriptMessage *)message {
+    WKSecurityOrigin *origin = message.frameInfo.securityOrigin;
+    NSString *_origin = origin.port == 0
+        ? [NSString stringWithFormat:@"%@://%@", origin.protocol, origin.host]
+        : [NSString stringWithFormat:@"%@://%@:%ld", origin.protocol, origin.host, (long)origin.port];
+    if (!acceptScriptMessage([_origin UTF8String])) {
+        return;
+    }
+
     NSString *m = message.body;
 
     // Check for drag
@@ -632,6 +919,20 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
 }
 
 
+-(void) OpenMediaDialog :(NSString*)title :(int)mediaType :(bool)allowMultipleSelection :(NSString*)filters {
+#ifdef USE_PHPICKER
+    if (@available(macOS 13.0, *)) {
+        [WailsMediaPicker show :self.mainWindow :title :mediaType :allowMultipleSelection];
+        return;
+    }
+#endif
+    // The open panel starts in the Pictures or Movies folder
+    NSSearchPathDirectory searchPath = mediaType == 2 ? NSMoviesDirectory : NSPicturesDirectory;
+    NSString *defaultDirectory = [NSSearchPathForDirectoriesInDomains(searchPath, NSUserDomainMask, YES) firstObject];
+    [self OpenFileDialog:title :nil :defaultDirectory :false :true :false :false :true :false :allowMultipleSelection :filters];
+}
+
+
 -(void) SaveFileDialog :(NSString*)title :(NSString*)defaultFilename :(NSString*)defaultDirectory :(bool)canCreateDirectories :(bool)treatPackagesAsDirectories :(bool)showHiddenFiles :(NSString*)filters; {
 
 
@@ -709,6 +1010,220 @@ typedef void (^schemeTaskCaller)(id<WKURLSchemeTask>);
 
 }
 
+- (void) Share :(NSString*)text :(NSString*)url :(NSString*)files :(bool)hasAnchor :(int)x :(int)y :(int)width :(int)height {
+    NSMutableArray *items = [[NSMutableArray new] autorelease];
+    if( text != nil && [text length] > 0 ) {
+        [items addObject:text];
+    }
+    if( url != nil && [url length] > 0 ) {
+        NSURL *webURL = [NSURL URLWithString:url];
+        if( webURL != nil ) {
+            [items addObject:webURL];
+        }
+    }
+    // The files are passed as a JSON array
+    if( files != nil && [files length] > 0 ) {
+        NSArray *paths = [NSJSONSerialization JSONObjectWithData:[files dataUsingEncoding:NSUTF8StringEncoding] options:0 error:nil];
+        for (NSString *path in paths) {
+            [items addObject:[NSURL fileURLWithPath:path]];
+        }
+    }
+
+    // The anchor is in CSS pixels relative to the viewport, which are points of the webview
+    NSRect bounds = [self.webview bounds];
+    NSRect rect = NSMakeRect(NSMidX(bounds), NSMidY(bounds), 1, 1);
+    if( hasAnchor ) {
+        rect = NSMakeRect(x, y, MAX(width, 1), MAX(height, 1));
+        if( ![self.webview isFlipped] ) {
+            rect.origin.y = bounds.size.height - y - rect.size.height;
+        }
+    }
+
+    // The picker is retained until the next share so it outlives the call
+    self.sharePicker = [[[NSSharingServicePicker alloc] initWithItems:items] autorelease];
+    [self.sharePicker showRelativeToRect:rect ofView:self.webview preferredEdge:NSRectEdgeMinY];
+}
+
+// PrintToPDF saves the paginated print of the webview to the path without showing the print panel, as createPDF only
+// renders a single page without paper size and margins
+- (void) PrintToPDF :(NSString*)path :(NSSize)paperSize :(NSEdgeInsets)margins :(bool)landscape :(double)scale :(bool)printBackground :(bool)headerAndFooter {
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 110000
+    if (@available(macOS 11.0, *)) {
+        NSMutableDictionary *dictionary = [NSMutableDictionary dictionaryWithDictionary:[[NSPrintInfo sharedPrintInfo] dictionary]];
+        dictionary[NSPrintJobDisposition] = NSPrintSaveJob;
+        dictionary[NSPrintJobSavingURL] = [NSURL fileURLWithPath:path];
+        dictionary[NSPrintHeaderAndFooter] = @(headerAndFooter);
+        NSPrintInfo *printInfo = [[[NSPrintInfo alloc] initWithDictionary:dictionary] autorelease];
+        printInfo.paperSize = paperSize;
+        printInfo.orientation = landscape ? NSPaperOrientationLandscape : NSPaperOrientationPortrait;
+        printInfo.topMargin = margins.top;
+        printInfo.bottomMargin = margins.bottom;
+        printInfo.leftMargin = margins.left;
+        printInfo.rightMargin = margins.right;
+        printInfo.scalingFactor = scale;
+        printInfo.horizontalPagination = NSPrintingPaginationModeFit;
+        printInfo.verticalPagination = NSPrintingPaginationModeAutomatic;
+
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 130300
+        if (@available(macOS 13.3, *)) {
+            self.webview.configuration.preferences.shouldPrintBackgrounds = printBackground;
+        }
+#endif
+
+        NSPrintOperation *operation = [self.webview printOperationWithPrintInfo:printInfo];
+        operation.showsPrintPanel = NO;
+        operation.showsProgressPanel = NO;
+        operation.view.frame = self.webview.bounds;
+        [operation runOperationModalForWindow:self.mainWindow delegate:self didRunSelector:@selector(printToPDFDidRun:success:contextInfo:) contextInfo:nil];
+        return;
+    }
+#endif
+    processPrintToPDFResponse(0);
+}
+
+- (void) printToPDFDidRun:(NSPrintOperation *)operation success:(BOOL)success contextInfo:(void *)contextInfo {
+    processPrintToPDFResponse(success ? 1 : 0);
+}
+
+// CaptureSnapshot writes the visible content of the webview to the path as a PNG
+- (void) CaptureSnapshot :(NSString*)path {
+    WKSnapshotConfiguration *configuration = [[WKSnapshotConfiguration new] autorelease];
+    [self.webview takeSnapshotWithConfiguration:configuration completionHandler:^(NSImage *image, NSError *error) {
+        CGImageRef cgImage = [image CGImageForProposedRect:nil context:nil hints:nil];
+        if (cgImage == nil) {
+            processSnapshotResponse(0);
+            return;
+        }
+        NSBitmapImageRep *bitmap = [[[NSBitmapImageRep alloc] initWithCGImage:cgImage] autorelease];
+        NSData *data = [bitmap representationUsingType:NSBitmapImageFileTypePNG properties:@{}];
+        processSnapshotResponse([data writeToFile:path atomically:NO] ? 1 : 0);
+    }];
+}
+
+// SetProxy routes the requests of the data store of the webview through the proxy. It returns false before macOS 14.
+- (bool) SetProxy :(NSString*)scheme :(NSString*)host :(int)port :(NSString*)username :(NSString*)password :(NSArray<NSString*>*)bypass {
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 140000
+    if (@available(macOS 14.0, *)) {
+        nw_endpoint_t endpoint = nw_endpoint_create_host(host.UTF8String, [NSString stringWithFormat:@"%d", port].UTF8String);
+        nw_proxy_config_t proxy;
+        if ([scheme isEqualToString:@"socks5"]) {
+            proxy = nw_proxy_config_create_socksv5(endpoint);
+        } else {
+            nw_protocol_options_t tls = [scheme isEqualToString:@"https"] ? nw_tls_create_options() : NULL;
+            proxy = nw_proxy_config_create_http_connect(endpoint, tls);
+            if (tls != NULL) {
+                nw_release(tls);
+            }
+        }
+        nw_release(endpoint);
+        if (username.length > 0) {
+            nw_proxy_config_set_username_and_password(proxy, username.UTF8String, password.UTF8String);
+        }
+        for (NSString *domain in bypass) {
+            nw_proxy_config_add_excluded_domain(proxy, domain.UTF8String);
+        }
+        self.webview.configuration.websiteDataStore.proxyConfigurations = @[proxy];
+        nw_release(proxy);
+        return true;
+    }
+#endif
+    return false;
+}
+
+// GetCookies sends all cookies of the data store of the webview to processCookie
+- (void) GetCookies {
+    [self.webview.configuration.websiteDataStore.httpCookieStore getAllCookies:^(NSArray<NSHTTPCookie *> *cookies) {
+        for (NSHTTPCookie *cookie in cookies) {
+            // The values of http.SameSite
+            int sameSite = 1;
+            if (@available(macOS 10.15, *)) {
+                if ([cookie.sameSitePolicy isEqualToString:NSHTTPCookieSameSiteLax]) {
+                    sameSite = 3;
+                } else if ([cookie.sameSitePolicy isEqualToString:NSHTTPCookieSameSiteStrict]) {
+                    sameSite = 4;
+                }
+            }
+            long long expires = cookie.expiresDate == nil ? 0 : (long long)[cookie.expiresDate timeIntervalSince1970];
+            processCookie(cookie.name.UTF8String, cookie.value.UTF8String, cookie.domain.UTF8String, cookie.path.UTF8String, expires, cookie.isSecure, cookie.isHTTPOnly, sameSite);
+        }
+        processCookiesResult(1);
+    }];
+}
+
+- (void) SetCookie :(NSString*)name :(NSString*)value :(NSString*)domain :(NSString*)path :(long long)expires :(int)secure :(int)httpOnly :(int)sameSite {
+    NSMutableDictionary *properties = [NSMutableDictionary dictionary];
+    properties[NSHTTPCookieName] = name;
+    properties[NSHTTPCookieValue] = value;
+    properties[NSHTTPCookieDomain] = domain;
+    properties[NSHTTPCookiePath] = path;
+    if (expires != 0) {
+        properties[NSHTTPCookieExpires] = [NSDate dateWithTimeIntervalSince1970:expires];
+    }
+    if (secure) {
+        properties[NSHTTPCookieSecure] = @"TRUE";
+    }
+    if (httpOnly) {
+        properties[@"HttpOnly"] = @"TRUE";
+    }
+    if (@available(macOS 10.15, *)) {
+        if (sameSite == 3) {
+            properties[NSHTTPCookieSameSitePolicy] = NSHTTPCookieSameSiteLax;
+        } else if (sameSite == 4) {
+            properties[NSHTTPCookieSameSitePolicy] = NSHTTPCookieSameSiteStrict;
+        }
+    }
+    NSHTTPCookie *cookie = [NSHTTPCookie cookieWithProperties:properties];
+    if (cookie == nil) {
+        processCookiesResult(0);
+        return;
+    }
+    [self.webview.configuration.websiteDataStore.httpCookieStore setCookie:cookie completionHandler:^{
+        processCookiesResult(1);
+    }];
+}
+
+- (void) DeleteCookie :(NSString*)name :(NSString*)domain :(NSString*)path {
+    WKHTTPCookieStore *cookieStore = self.webview.configuration.websiteDataStore.httpCookieStore;
+    [cookieStore getAllCookies:^(NSArray<NSHTTPCookie *> *cookies) {
+        NSHTTPCookie *match = nil;
+        for (NSHTTPCookie *cookie in cookies) {
+            if ([cookie.name isEqualToString:name] && [cookie.domain caseInsensitiveCompare:domain] == NSOrderedSame && [cookie.path isEqualToString:path]) {
+                match = cookie;
+                break;
+            }
+        }
+        if (match == nil) {
+            processCookiesResult(1);
+            return;
+        }
+        [cookieStore deleteCookie:match completionHandler:^{
+            processCookiesResult(1);
+        }];
+    }];
+}
+
+- (void) ClearSiteData :(int)cookies :(int)storage :(int)cache {
+    NSMutableSet *types = [NSMutableSet set];
+    if (cookies) {
+        [types addObject:WKWebsiteDataTypeCookies];
+    }
+    if (storage) {
+        [types addObjectsFromArray:@[WKWebsiteDataTypeLocalStorage, WKWebsiteDataTypeSessionStorage, WKWebsiteDataTypeIndexedDBDatabases, WKWebsiteDataTypeWebSQLDatabases]];
+        if (@available(macOS 10.13.4, *)) {
+            [types addObject:WKWebsiteDataTypeServiceWorkerRegistrations];
+        }
+    }
+    if (cache) {
+        [types addObjectsFromArray:@[WKWebsiteDataTypeDiskCache, WKWebsiteDataTypeMemoryCache]];
+        if (@available(macOS 10.13.4, *)) {
+            [types addObject:WKWebsiteDataTypeFetchCache];
+        }
+    }
+    [self.webview.configuration.websiteDataStore removeDataOfTypes:types modifiedSince:[NSDate distantPast] completionHandler:^{
+        processCookiesResult(1);
+    }];
+}
+
 - (void) SetAbout :(NSString*)title :(NSString*)description :(void*)imagedata :(int)datalen {
     self.aboutTitle = title;
     self.aboutDescription = description;
@@ -0,0 +1,30 @@
+//
+//  WailsDownload.h
+//
+
+#ifndef WailsDownload_h
+#define WailsDownload_h
+
+#import <Cocoa/Cocoa.h>
+#import <WebKit/WebKit.h>
+
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 110300
+#define USE_WKDOWNLOAD
+
+// WailsDownload is the delegate of a download of the webview. It decides the destination with the application,
+// reports the progress and keeps itself alive until the download has finished.
+API_AVAILABLE(macos(11.3))
+@interface WailsDownload : NSObject <WKDownloadDelegate>
+
+@property (retain) WKDownload *download;
+@property (retain) NSString *downloadId;
+@property (assign) NSWindow *window;
+@property bool observing;
+
++ (void) start :(WKDownload*)download :(NSWindow*)window;
++ (void) cancel :(NSString*)downloadId;
+
+@end
+#endif
+
+#endif /* WailsDownload_h */
@@ -0,0 +1,114 @@
+//go:build darwin
+//
+//  WailsDownload.m
+//
+
+#import <Foundation/Foundation.h>
+#import "WailsDownload.h"
+#import "message.h"
+
+#ifdef USE_WKDOWNLOAD
+
+// The downloads in flight, they are only used on the main thread
+static NSMutableSet *downloads = nil;
+
+@implementation WailsDownload
+
++ (void) start :(WKDownload*)download :(NSWindow*)window {
+    if (downloads == nil) {
+        downloads = [NSMutableSet new];
+    }
+    WailsDownload *delegate = [[WailsDownload new] autorelease];
+    delegate.download = download;
+    delegate.window = window;
+    download.delegate = delegate;
+    [downloads addObject:delegate];
+}
+
++ (void) cancel :(NSString*)downloadId {
+    for (WailsDownload *delegate in downloads) {
+        if ([delegate.downloadId isEqualToString:downloadId]) {
+            [delegate.download cancel:nil];
+            return;
+        }
+    }
+}
+
+- (void)download:(WKDownload *)download decideDestinationUsingResponse:(NSURLResponse *)response suggestedFilename:(NSString *)suggestedFilename completionHandler:(void (^)(NSURL *))completionHandler {
+    const char *url = [download.originalRequest.URL.absoluteString UTF8String];
+    const char *mimeType = response.MIMEType != nil ? [response.MIMEType UTF8String] : "";
+    char *downloadId = NULL;
+    int prompt = 0;
+    char *path = decideDownload(url, [suggestedFilename UTF8String], mimeType, &downloadId, &prompt);
+    if (path == NULL) {
+        completionHandler(nil);
+        return;
+    }
+    self.downloadId = [NSString stringWithUTF8String:downloadId];
+    free(downloadId);
+    NSString *_path = [NSString stringWithUTF8String:path];
+    free(path);
+
+    if (!prompt) {
+        [self begin:_path :completionHandler];
+        return;
+    }
+    NSSavePanel *panel = [NSSavePanel savePanel];
+    panel.directoryURL = [NSURL fileURLWithPath:[_path stringByDeletingLastPathComponent]];
+    panel.nameFieldStringValue = [_path lastPathComponent];
+    panel.canCreateDirectories = YES;
+    [panel beginSheetModalForWindow:self.window completionHandler:^(NSModalResponse result) {
+        if (result != NSModalResponseOK || panel.URL == nil) {
+            completionHandler(nil);
+            return;
+        }
+        [self begin:panel.URL.path :completionHandler];
+    }];
+}
+
+// begin downloads to the path, WKDownload fails if the file exists
+- (void) begin :(NSString*)path :(void (^)(NSURL *))completionHandler {
+    [[NSFileManager defaultManager] removeItemAtPath:path error:nil];
+    beginDownload([self.downloadId UTF8String], [path UTF8String]);
+    [self.download.progress addObserver:self forKeyPath:@"completedUnitCount" options:NSKeyValueObservingOptionNew context:nil];
+    self.observing = true;
+    completionHandler([NSURL fileURLWithPath:path]);
+}
+
+- (void)observeValueForKeyPath:(NSString *)keyPath ofObject:(id)object change:(NSDictionary *)change context:(void *)context {
+    NSProgress *progress = (NSProgress *)object;
+    downloadProgress([self.downloadId UTF8String], progress.completedUnitCount, progress.totalUnitCount);
+}
+
+- (void)downloadDidFinish:(WKDownload *)download {
+    [self finish:NULL :0];
+}
+
+- (void)download:(WKDownload *)download didFailWithError:(NSError *)error resumeData:(NSData *)resumeData {
+    bool cancelled = [error.domain isEqualToString:NSURLErrorDomain] && error.code == NSURLErrorCancelled;
+    [self finish:[error.localizedDescription UTF8String] :cancelled];
+}
+
+- (void) finish :(const char*)error :(bool)cancelled {
+    if (self.observing) {
+        [self.download.progress removeObserver:self forKeyPath:@"completedUnitCount"];
+        self.observing = false;
+    }
+    if (self.downloadId != nil) {
+        NSProgress *progress = self.download.progress;
+        downloadProgress([self.downloadId UTF8String], progress.completedUnitCount, progress.totalUnitCount);
+        downloadFinished([self.downloadId UTF8String], cancelled ? NULL : error, cancelled ? 1 : 0);
+    }
+    self.download.delegate = nil;
+    [[self retain] autorelease];
+    [downloads removeObject:self];
+}
+
+- (void) dealloc {
+    [_download release];
+    [_downloadId release];
+    [super dealloc];
+}
+
+@end
+#endif
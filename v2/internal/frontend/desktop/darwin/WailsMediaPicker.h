@@ -0,0 +1,27 @@
+//
+//  WailsMediaPicker.h
+//
+
+#ifndef WailsMediaPicker_h
+#define WailsMediaPicker_h
+
+#import <Cocoa/Cocoa.h>
+
+#if MAC_OS_X_VERSION_MAX_ALLOWED >= 130000
+#define USE_PHPICKER
+#import <PhotosUI/PhotosUI.h>
+
+// WailsMediaPicker shows the PHPickerViewController as sheet and copies the selected photos and videos to temporary
+// files, as the files of the item providers are deleted after loading them
+API_AVAILABLE(macos(13.0))
+@interface WailsMediaPicker : NSObject <PHPickerViewControllerDelegate>
+
+@property (retain) NSWindow *sheet;
+@property (assign) NSWindow *parentWindow;
+
++ (void) show :(NSWindow*)window :(NSString*)title :(int)mediaType :(bool)allowMultipleSelection;
+
+@end
+#endif
+
+#endif /* WailsMediaPicker_h */
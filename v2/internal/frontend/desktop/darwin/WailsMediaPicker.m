@@ -0,0 +1,94 @@
+//go:build darwin
+//
+//  WailsMediaPicker.m
+//
+
+#import <Foundation/Foundation.h>
+#import "WailsMediaPicker.h"
+#import "message.h"
+
+#ifdef USE_PHPICKER
+
+@implementation WailsMediaPicker
+
++ (void) show :(NSWindow*)window :(NSString*)title :(int)mediaType :(bool)allowMultipleSelection {
+    PHPickerConfiguration *configuration = [[PHPickerConfiguration new] autorelease];
+    configuration.selectionLimit = allowMultipleSelection ? 0 : 1;
+    configuration.preferredAssetRepresentationMode = PHPickerConfigurationAssetRepresentationModeCurrent;
+    switch (mediaType) {
+        case 1:
+            configuration.filter = [PHPickerFilter imagesFilter];
+            break;
+        case 2:
+            configuration.filter = [PHPickerFilter videosFilter];
+            break;
+        default:
+            configuration.filter = [PHPickerFilter anyFilterMatchingSubfilters:@[[PHPickerFilter imagesFilter], [PHPickerFilter videosFilter]]];
+    }
+
+    PHPickerViewController *picker = [[[PHPickerViewController alloc] initWithConfiguration:configuration] autorelease];
+    // The delegate releases itself when the picker has finished
+    WailsMediaPicker *delegate = [WailsMediaPicker new];
+    picker.delegate = delegate;
+    delegate.sheet = [NSWindow windowWithContentViewController:picker];
+    delegate.parentWindow = window;
+    if( title != nil ) {
+        [delegate.sheet setTitle:title];
+    }
+    [window beginSheet:delegate.sheet completionHandler:nil];
+}
+
+- (void)picker:(PHPickerViewController *)picker didFinishPicking:(NSArray<PHPickerResult *> *)results {
+    [self.parentWindow endSheet:self.sheet];
+
+    NSString *directory = [NSTemporaryDirectory() stringByAppendingPathComponent:[[NSUUID UUID] UUIDString]];
+    NSMutableArray *paths = [NSMutableArray new];
+    dispatch_group_t group = dispatch_group_create();
+    for (NSUInteger index = 0; index < results.count; index++) {
+        [paths addObject:[NSNull null]];
+        NSItemProvider *provider = results[index].itemProvider;
+        NSString *typeIdentifier = [provider hasItemConformingToTypeIdentifier:@"public.movie"] ? @"public.movie" : @"public.image";
+        dispatch_group_enter(group);
+        [provider loadFileRepresentationForTypeIdentifier:typeIdentifier completionHandler:^(NSURL *url, NSError *error) {
+            if (url != nil) {
+                // Every file gets its own directory to keep the original filename
+                NSString *fileDirectory = [directory stringByAppendingPathComponent:[NSString stringWithFormat:@"%lu", (unsigned long)index]];
+                NSString *path = [fileDirectory stringByAppendingPathComponent:url.lastPathComponent];
+                NSFileManager *fileManager = [NSFileManager defaultManager];
+                if ([fileManager createDirectoryAtPath:fileDirectory withIntermediateDirectories:YES attributes:nil error:nil] &&
+                    [fileManager copyItemAtPath:url.path toPath:path error:nil]) {
+                    @synchronized (paths) {
+                        paths[index] = path;
+                    }
+                }
+            }
+            dispatch_group_leave(group);
+        }];
+    }
+
+    dispatch_group_notify(group, dispatch_get_main_queue(), ^{
+        NSMutableArray *selected = [NSMutableArray new];
+        for (id path in paths) {
+            if (path != [NSNull null]) {
+                [selected addObject:path];
+            }
+        }
+        NSData *jsonData = [NSJSONSerialization dataWithJSONObject:selected options:0 error:nil];
+        NSString *nsjson = [[NSString alloc] initWithData:jsonData encoding:NSUTF8StringEncoding];
+        processOpenFileDialogResponse([nsjson UTF8String]);
+        [nsjson release];
+        [selected release];
+        [paths release];
+        [self release];
+    });
+    dispatch_release(group);
+}
+
+- (void)dealloc {
+    [_sheet release];
+    [super dealloc];
+}
+
+@end
+
+#endif
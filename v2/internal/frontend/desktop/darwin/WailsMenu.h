@@ -22,6 +22,7 @@
 
 - (NSMenuItem*) newMenuItemWithContext :(WailsContext*)ctx :(NSString*)title :(SEL)selector :(NSString*)key :(NSEventModifierFlags)flags;
 - (void*) AppendMenuItem :(WailsContext*)ctx :(NSString*)label :(NSString *)shortcutKey :(int)modifiers :(bool)disabled :(bool)checked :(int)menuItemID;
+- (void) AppendEditRoleItem :(NSString*)label :(NSString*)selector :(NSString*)shortcutKey :(int)modifiers :(bool)disabled;
 - (void) AppendSeparator;
 
 @end
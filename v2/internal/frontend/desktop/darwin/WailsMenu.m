@@ -95,7 +95,7 @@
             [editMenu addItem:[self newMenuItem:@"Cut" :@selector(cut:) :@"x" :NSEventModifierFlagCommand]];
             [editMenu addItem:[self newMenuItem:@"Copy" :@selector(copy:) :@"c" :NSEventModifierFlagCommand]];
             [editMenu addItem:[self newMenuItem:@"Paste" :@selector(paste:) :@"v" :NSEventModifierFlagCommand]];
-            [editMenu addItem:[self newMenuItem:@"Paste and Match Style" :@selector(pasteAsRichText:) :@"v" :(NSEventModifierFlagOption | NSEventModifierFlagShift | NSEventModifierFlagCommand)]];
+            [editMenu addItem:[self newMenuItem:@"Paste and Match Style" :@selector(pasteAsPlainText:) :@"v" :(NSEventModifierFlagOption | NSEventModifierFlagShift | NSEventModifierFlagCommand)]];
             [editMenu addItem:[self newMenuItem:@"Delete" :@selector(delete:) :[self accel:@"backspace"] :0]];
             [editMenu addItem:[self newMenuItem:@"Select All" :@selector(selectAll:) :@"a" :NSEventModifierFlagCommand]];
             [editMenu addItem:[NSMenuItem separatorItem]];
@@ -156,6 +156,16 @@
     return menuItem;
 }
 
+- (void) AppendEditRoleItem :(NSString*)label :(NSString*)selector :(NSString*)shortcutKey :(int)modifiers :(bool)disabled {
+    // Without a target the action goes to the first responder, which enables the item. A disabled item has no action.
+    NSMenuItem *menuItem = [self newMenuItem:label :(disabled ? nil : NSSelectorFromString(selector)) :@"" :0];
+    if (shortcutKey != nil) {
+        [menuItem setKeyEquivalent:[self accel:shortcutKey]];
+        [menuItem setKeyEquivalentModifierMask:modifiers];
+    }
+    [self addItem:menuItem];
+}
+
 - (void) AppendSeparator {
     [self addItem:[NSMenuItem separatorItem]];
 }
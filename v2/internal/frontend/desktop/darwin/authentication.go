@@ -0,0 +1,38 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// authenticationPolicy is used by the navigation delegate of the webview to answer authentication challenges
+var authenticationPolicy = frontend.NewAuthenticationPolicy(nil, nil)
+
+// authenticationCredentials returns the credentials for the challenge, which are freed by the caller, or 0 for the
+// default handling
+//
+//export authenticationCredentials
+func authenticationCredentials(host *C.char, port C.int, realm *C.char, scheme *C.char, isProxy C.int, previousFailures C.int, username **C.char, password **C.char) C.int {
+	credentials := authenticationPolicy.Credentials(options.AuthenticationChallenge{
+		Host:             C.GoString(host),
+		Port:             int(port),
+		Realm:            C.GoString(realm),
+		Scheme:           frontend.ParseAuthenticationScheme(C.GoString(scheme)),
+		IsProxy:          isProxy != 0,
+		PreviousFailures: int(previousFailures),
+	})
+	if credentials == nil {
+		return 0
+	}
+	*username = C.CString(credentials.Username)
+	*password = C.CString(credentials.Password)
+	return 1
+}
@@ -4,6 +4,8 @@ package darwin
 
 import (
 	"os/exec"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
 )
 
 func (f *Frontend) ClipboardGetText() (string, error) {
@@ -33,3 +35,18 @@ func (f *Frontend) ClipboardSetText(text string) error {
 	}
 	return copyCmd.Wait()
 }
+
+// editCommands maps the commands to the selectors of the standard edit actions
+var editCommands = map[frontend.EditCommand]string{
+	frontend.EditCommandCopy:               "copy:",
+	frontend.EditCommandCut:                "cut:",
+	frontend.EditCommandPaste:              "paste:",
+	frontend.EditCommandSelectAll:          "selectAll:",
+	frontend.EditCommandUndo:               "undo:",
+	frontend.EditCommandRedo:               "redo:",
+	frontend.EditCommandPasteAndMatchStyle: "pasteAsPlainText:",
+}
+
+func (f *Frontend) ClipboardEditCommand(command frontend.EditCommand) {
+	executeEditCommand(f.mainWindow.context, command)
+}
@@ -0,0 +1,136 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit
+#import <Foundation/Foundation.h>
+#import "Application.h"
+#import "WailsContext.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// The cookie operations send their result to this channel, after the cookies that have been received
+var (
+	cookiesResult   = make(chan int)
+	cookiesReceived []frontend.Cookie
+	cookiesLock     sync.Mutex
+)
+
+// webviewProfileIdentifier returns the identifier of the data store of the profile, which WebKit derives from a
+// UUID. It is empty for the default data store.
+func webviewProfileIdentifier(profile *options.WebviewProfile) string {
+	if profile == nil || profile.InMemory {
+		return ""
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("wails-profile:"+profile.Directory)).String()
+}
+
+// cookiesOperation runs the operation and waits for its result
+func (f *Frontend) cookiesOperation(operation func()) ([]frontend.Cookie, error) {
+	cookiesLock.Lock()
+	defer cookiesLock.Unlock()
+	cookiesReceived = nil
+	operation()
+	if <-cookiesResult == 0 {
+		return nil, errors.New("the operation on the cookies failed")
+	}
+	return cookiesReceived, nil
+}
+
+// CookiesGet returns the cookies that are sent to the URL, all cookies if it is empty
+func (f *Frontend) CookiesGet(url string) ([]frontend.Cookie, error) {
+	cookies, err := f.cookiesOperation(func() {
+		C.GetCookies(f.mainWindow.context)
+	})
+	if err != nil || url == "" {
+		return cookies, err
+	}
+	// WKHTTPCookieStore can only return all cookies
+	var result []frontend.Cookie
+	for _, cookie := range cookies {
+		if frontend.CookieMatchesURL(cookie, url) {
+			result = append(result, cookie)
+		}
+	}
+	return result, nil
+}
+
+// CookieSet adds the cookie or replaces the cookie with the same name, domain and path
+func (f *Frontend) CookieSet(cookie frontend.Cookie) error {
+	cookie, err := frontend.PrepareCookie(cookie)
+	if err != nil {
+		return err
+	}
+	var expires int64
+	if !cookie.Expires.IsZero() {
+		expires = cookie.Expires.Unix()
+	}
+	c := NewCalloc()
+	defer c.Free()
+	_, err = f.cookiesOperation(func() {
+		C.SetCookie(f.mainWindow.context, c.String(cookie.Name), c.String(cookie.Value), c.String(cookie.Domain), c.String(cookie.Path),
+			C.longlong(expires), bool2Cint(cookie.Secure), bool2Cint(cookie.HTTPOnly), C.int(cookie.SameSite))
+	})
+	return err
+}
+
+// CookieDelete deletes the cookie with the name, domain and path of the cookie
+func (f *Frontend) CookieDelete(cookie frontend.Cookie) error {
+	cookie, err := frontend.PrepareCookie(cookie)
+	if err != nil {
+		return err
+	}
+	c := NewCalloc()
+	defer c.Free()
+	_, err = f.cookiesOperation(func() {
+		C.DeleteCookie(f.mainWindow.context, c.String(cookie.Name), c.String(cookie.Domain), c.String(cookie.Path))
+	})
+	return err
+}
+
+// SiteDataClear removes the data of all sites with removeDataOfTypes
+func (f *Frontend) SiteDataClear(data frontend.SiteData) error {
+	if data&frontend.SiteDataAll == 0 {
+		return nil
+	}
+	_, err := f.cookiesOperation(func() {
+		C.ClearSiteData(f.mainWindow.context, bool2Cint(data&frontend.SiteDataCookies != 0), bool2Cint(data&frontend.SiteDataStorage != 0), bool2Cint(data&frontend.SiteDataCache != 0))
+	})
+	return err
+}
+
+//export processCookie
+func processCookie(name *C.char, value *C.char, domain *C.char, path *C.char, expires C.longlong, secure C.int, httpOnly C.int, sameSite C.int) {
+	cookie := frontend.Cookie{
+		Name:     C.GoString(name),
+		Value:    C.GoString(value),
+		Domain:   C.GoString(domain),
+		Path:     C.GoString(path),
+		Secure:   secure != 0,
+		HTTPOnly: httpOnly != 0,
+		SameSite: http.SameSite(sameSite),
+	}
+	if expires != 0 {
+		cookie.Expires = time.Unix(int64(expires), 0)
+	}
+	cookiesReceived = append(cookiesReceived, cookie)
+}
+
+//export processCookiesResult
+func processCookiesResult(result C.int) {
+	cookiesResult <- int(result)
+}
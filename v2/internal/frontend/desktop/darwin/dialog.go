@@ -5,7 +5,7 @@ package darwin
 
 /*
 #cgo CFLAGS: -x objective-c
-#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit -weak_framework PhotosUI
 #import <Foundation/Foundation.h>
 #import "Application.h"
 #import "WailsContext.h"
@@ -21,6 +21,7 @@ import (
 
 	"github.com/leaanthony/slicer"
 	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/fileexplorer"
 )
 
 // Obj-C dialog methods send the response to this channel
@@ -81,10 +82,26 @@ func (f *Frontend) openDialog(options *frontend.OpenDialogOptions, multiple bool
 
 	var parsedResults []string
 	err := json.Unmarshal([]byte(result), &parsedResults)
+	if err == nil {
+		f.rememberBookmarks(parsedResults)
+	}
 
 	return parsedResults, err
 }
 
+// rememberBookmarks remembers the security-scoped bookmarks of the chosen items of sandboxed applications, which are
+// restored when the application starts, so the items stay accessible after it has been launched again
+func (f *Frontend) rememberBookmarks(paths []string) {
+	if !fileexplorer.IsSandboxed() {
+		return
+	}
+	for _, path := range paths {
+		if err := fileexplorer.RememberBookmark(path); err != nil {
+			f.logger.Warning("Unable to remember the bookmark of %s: %s", path, err)
+		}
+	}
+}
+
 // OpenFileDialog prompts the user to select a file
 func (f *Frontend) OpenFileDialog(options frontend.OpenDialogOptions) (string, error) {
 	results, err := f.openDialog(&options, false, true, false)
@@ -103,6 +120,33 @@ func (f *Frontend) OpenMultipleFilesDialog(options frontend.OpenDialogOptions) (
 	return f.openDialog(&options, true, true, false)
 }
 
+// OpenMediaDialog prompts the user to select photos and videos with PHPicker on macOS 13+. Older versions show the
+// open panel with media filters.
+func (f *Frontend) OpenMediaDialog(options frontend.MediaDialogOptions) ([]string, error) {
+	dialogLock.Lock()
+	defer dialogLock.Unlock()
+
+	mediaType := 0
+	switch options.Type {
+	case frontend.MediaImages:
+		mediaType = 1
+	case frontend.MediaVideos:
+		mediaType = 2
+	}
+
+	c := NewCalloc()
+	defer c.Free()
+	filters := strings.Join(frontend.MediaExtensions(options.Type), ";")
+	C.OpenMediaDialog(f.mainWindow.context, c.String(options.Title), C.int(mediaType), bool2Cint(options.Multiple), c.String(filters))
+
+	result := <-openFileDialogResponse
+
+	var parsedResults []string
+	err := json.Unmarshal([]byte(result), &parsedResults)
+
+	return parsedResults, err
+}
+
 // SaveFileDialog prompts the user to select a file
 func (f *Frontend) SaveFileDialog(options frontend.SaveDialogOptions) (string, error) {
 	dialogLock.Lock()
@@ -0,0 +1,77 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit
+#import <Foundation/Foundation.h>
+#import "Application.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// downloads is used by the delegates of the downloads to decide their paths and report their progress. WKDownload
+// requires macOS 11.3.
+var downloads = frontend.NewDownloadManager(nil, nil)
+
+//export downloadsEnabled
+func downloadsEnabled() C.int {
+	return bool2Cint(downloads.Enabled())
+}
+
+// decideDownload returns the path of the download and sets its id, which are freed by the caller. It returns nil if
+// the download is cancelled.
+//
+//export decideDownload
+func decideDownload(url *C.char, suggestedFilename *C.char, mimeType *C.char, id **C.char, prompt *C.int) *C.char {
+	destination, ok := downloads.Start(C.GoString(url), C.GoString(suggestedFilename), C.GoString(mimeType))
+	if !ok {
+		return nil
+	}
+	*id = C.CString(destination.ID)
+	if destination.Prompt {
+		*prompt = 1
+	}
+	return C.CString(destination.Path)
+}
+
+//export beginDownload
+func beginDownload(id *C.char, path *C.char) {
+	goID := C.GoString(id)
+	downloads.Begin(goID, C.GoString(path), func() {
+		cID := C.CString(goID)
+		defer C.free(unsafe.Pointer(cID))
+		C.CancelDownload(cID)
+	})
+}
+
+//export downloadProgress
+func downloadProgress(id *C.char, received C.longlong, total C.longlong) {
+	downloads.Progress(C.GoString(id), int64(received), int64(total))
+}
+
+//export downloadFinished
+func downloadFinished(id *C.char, message *C.char, cancelled C.int) {
+	var err error
+	switch {
+	case cancelled != 0:
+		err = frontend.ErrDownloadCancelled
+	case message != nil:
+		err = errors.New(C.GoString(message))
+	}
+	downloads.Finish(C.GoString(id), err)
+}
+
+// DownloadCancel cancels the download in flight
+func (f *Frontend) DownloadCancel(id string) {
+	downloads.Cancel(id)
+}
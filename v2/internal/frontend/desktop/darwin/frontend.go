@@ -24,16 +24,19 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/wailsapp/wails/v2/pkg/assetserver"
 	"github.com/wailsapp/wails/v2/pkg/assetserver/webview"
+	"github.com/wailsapp/wails/v2/pkg/fileexplorer"
 
 	"github.com/wailsapp/wails/v2/internal/binding"
 	"github.com/wailsapp/wails/v2/internal/frontend"
 	"github.com/wailsapp/wails/v2/internal/frontend/runtime"
 	"github.com/wailsapp/wails/v2/internal/logger"
 	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
 )
 
 const startURL = "wails://wails/"
@@ -67,6 +70,12 @@ type Frontend struct {
 	mainWindow *Window
 	bindings   *binding.Bindings
 	dispatcher frontend.Dispatcher
+
+	contentRecovery *frontend.ContentRecovery
+	userContent     *frontend.UserContentManager
+	nativeViews     *frontend.NativeViewManager
+	zoom            *frontend.Zoom
+	audioMuted      atomic.Bool
 }
 
 func (f *Frontend) RunMainLoop() {
@@ -84,6 +93,14 @@ func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.
 		bindings:        appBindings,
 		dispatcher:      dispatcher,
 		ctx:             ctx,
+		contentRecovery: frontend.NewContentRecovery(appoptions.WebviewRecovery),
+		userContent:     frontend.NewUserContentManager(),
+		nativeViews:     frontend.NewNativeViewManager(),
+	}
+	appDataDir, _ := ctx.Value("appdatadir").(string)
+	result.zoom = frontend.NewZoom(appoptions.Zoom, appDataDir, 1)
+	if appoptions.Media != nil {
+		result.audioMuted.Store(appoptions.Media.Muted)
 	}
 	result.startURL, _ = url.Parse(startURL)
 
@@ -117,6 +134,15 @@ func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.
 
 		go result.startRequestProcessor()
 	}
+	originPolicy = frontend.NewOriginPolicy(appoptions.ExternalContent, result.startURL)
+	tlsPolicy = frontend.NewTLSPolicy(appoptions.TLS)
+	tlsLogger = result.logger
+	// The proxy has been checked when the application was created
+	proxy, _ := frontend.ParseProxy(appoptions.Proxy)
+	authenticationPolicy = frontend.NewAuthenticationPolicy(appoptions.Authentication, proxy)
+	navigationPolicy = frontend.NewNavigationPolicy(appoptions.Navigation, result.startURL, result.BrowserOpenURL)
+	permissionPolicy = frontend.NewPermissionPolicy(appoptions.Permissions)
+	downloads = frontend.NewDownloadManager(appoptions.Downloads, frontend.EmitDownloads(ctx))
 
 	go result.startMessageProcessor()
 	go result.startCallbackProcessor()
@@ -178,12 +204,15 @@ func (f *Frontend) WindowReloadApp() {
 }
 
 func (f *Frontend) WindowSetSystemDefaultTheme() {
+	f.mainWindow.SetAppearance("")
 }
 
 func (f *Frontend) WindowSetLightTheme() {
+	f.mainWindow.SetAppearance(string(mac.NSAppearanceNameAqua))
 }
 
 func (f *Frontend) WindowSetDarkTheme() {
+	f.mainWindow.SetAppearance(string(mac.NSAppearanceNameDarkAqua))
 }
 
 func (f *Frontend) Run(ctx context.Context) error {
@@ -203,9 +232,23 @@ func (f *Frontend) Run(ctx context.Context) error {
 		f.devtoolsEnabled = _devtoolsEnabled.(bool)
 	}
 
+	// The items chosen in the dialogs of earlier launches are accessible from the start
+	if fileexplorer.IsSandboxed() {
+		if _, err := fileexplorer.RestoreBookmarks(); err != nil {
+			f.logger.Error("Unable to restore the bookmarks: %s", err)
+		}
+	}
+
 	mainWindow := NewWindow(f.frontendOptions, f.debug, f.devtoolsEnabled)
 	f.mainWindow = mainWindow
 	f.mainWindow.Center()
+	if factor := f.zoom.Factor(); factor != 1 {
+		f.mainWindow.SetZoom(factor)
+	}
+	if f.audioMuted.Load() {
+		f.mainWindow.SetAudioMuted(true)
+	}
+	f.setupProxy()
 
 	go func() {
 		if f.frontendOptions.OnStartup != nil {
@@ -224,6 +267,10 @@ func (f *Frontend) WindowSetAlwaysOnTop(onTop bool) {
 	f.mainWindow.SetAlwaysOnTop(onTop)
 }
 
+func (f *Frontend) WindowSetSkipTaskbar(skip bool) {
+	f.mainWindow.SetSkipTaskbar(skip)
+}
+
 func (f *Frontend) WindowSetPosition(x, y int) {
 	f.mainWindow.SetPosition(x, y)
 }
@@ -339,6 +386,74 @@ func (f *Frontend) WindowPrint() {
 	f.mainWindow.Print()
 }
 
+func (f *Frontend) WindowAddUserContent(content options.UserContent) (string, error) {
+	item, err := f.userContent.Add(content)
+	if err != nil {
+		return "", err
+	}
+	f.mainWindow.SetUserContent(f.userContent.Items())
+	return item.ID, nil
+}
+
+func (f *Frontend) WindowRemoveUserContent(id string) {
+	if f.userContent.Remove(id) {
+		f.mainWindow.SetUserContent(f.userContent.Items())
+	}
+}
+
+// WindowEmbedNativeView adds the view to the content view of the window, above the webview. The handle is a
+// pointer to a NSView, which is retained by the content view until the view is removed.
+func (f *Frontend) WindowEmbedNativeView(id string, handle uintptr) error {
+	bounds, err := f.nativeViews.Add(id, handle)
+	if err != nil {
+		return err
+	}
+	f.mainWindow.EmbedNativeView(handle)
+	f.mainWindow.SetNativeViewBounds(handle, bounds)
+	return nil
+}
+
+func (f *Frontend) WindowRemoveNativeView(id string) {
+	if handle, removed := f.nativeViews.Remove(id); removed {
+		f.mainWindow.RemoveNativeView(handle)
+	}
+}
+
+func (f *Frontend) WindowSetNativeViewBounds(bounds frontend.NativeViewBounds) {
+	if handle, embedded := f.nativeViews.SetBounds(bounds); embedded {
+		f.mainWindow.SetNativeViewBounds(handle, bounds)
+	}
+}
+
+func (f *Frontend) WindowSetZoom(factor float64) {
+	factor, err := f.zoom.Set(factor)
+	if err != nil {
+		f.logger.Error("Unable to persist the zoom factor: %s", err)
+	}
+	f.mainWindow.SetZoom(factor)
+}
+
+func (f *Frontend) WindowGetZoom() float64 {
+	return f.zoom.Factor()
+}
+
+func (f *Frontend) WindowSetAudioMuted(muted bool) {
+	f.audioMuted.Store(muted)
+	f.mainWindow.SetAudioMuted(muted)
+}
+
+func (f *Frontend) WindowIsAudioMuted() bool {
+	return f.audioMuted.Load()
+}
+
+func (f *Frontend) WindowRecycle() {
+	f.recycleContent(options.WebviewCrashReasonRecycled)
+}
+
+func (f *Frontend) recycleContent(reason options.WebviewCrashReason) {
+	f.contentRecovery.Recover(f.ctx, reason, f.startURL.String(), f.MessageDialog, f.mainWindow.LoadURL)
+}
+
 type EventNotify struct {
 	Name string        `json:"name"`
 	Data []interface{} `json:"data"`
@@ -359,6 +474,7 @@ func (f *Frontend) Notify(name string, data ...interface{}) {
 
 func (f *Frontend) processMessage(message string) {
 	if message == "DomReady" {
+		f.contentRecovery.Navigated()
 		if f.frontendOptions.OnDomReady != nil {
 			f.frontendOptions.OnDomReady(f.ctx)
 		}
@@ -373,6 +489,21 @@ func (f *Frontend) processMessage(message string) {
 			f.ExecJS("window.wails.flags.enableWailsDragAndDrop = true;")
 		}
 
+		if f.zoom.Shortcuts() {
+			f.ExecJS("window.wails.flags.enableZoomShortcuts = true;")
+		}
+
+		// WKWebView doesn't report unresponsive pages
+		f.contentRecovery.Watchdog(f.ctx, func() {
+			f.ExecJS("window.WailsInvoke('wails:pong');")
+		}, func() {
+			go f.recycleContent(options.WebviewCrashReasonUnresponsive)
+		})
+		return
+	}
+
+	if message == "wails:pong" {
+		f.contentRecovery.Pong()
 		return
 	}
 
@@ -381,6 +512,13 @@ func (f *Frontend) processMessage(message string) {
 		return
 	}
 
+	if message == "wails:contentcrashed" {
+		if f.frontendOptions.WebviewRecovery != nil {
+			go f.recycleContent(options.WebviewCrashReasonCrashed)
+		}
+		return
+	}
+
 	//if strings.HasPrefix(message, "systemevent:") {
 	//	f.processSystemEvent(message)
 	//	return
@@ -0,0 +1,32 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// originPolicy is used by the delegates of the webview to isolate third-party origins
+var originPolicy = frontend.NewOriginPolicy(nil, nil)
+
+//export acceptScriptMessage
+func acceptScriptMessage(origin *C.char) C.int {
+	if !originPolicy.Trusted(frontend.OriginOf(C.GoString(origin))) {
+		return 0
+	}
+	return 1
+}
+
+//export allowNavigation
+func allowNavigation(url *C.char) C.int {
+	if !originPolicy.CanNavigate(C.GoString(url)) {
+		return 0
+	}
+	return 1
+}
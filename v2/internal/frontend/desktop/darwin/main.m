@@ -213,6 +213,7 @@ int main(int argc, const char * argv[]) {
     int hideToolbarSeparator = 0;
     int webviewIsTransparent = 1;
     int alwaysOnTop = 0;
+    int skipTaskbar = 0;
     int hideWindowOnClose = 0;
     const char* appearance = "NSAppearanceNameDarkAqua";
     int windowIsTranslucent = 1;
@@ -220,7 +221,7 @@ int main(int argc, const char * argv[]) {
     int defaultContextMenuEnabled = 1;
     int windowStartState = 0;
     int startsHidden = 0;
-    WailsContext *result = Create("OI OI!",400,400, frameless, resizable, zoomable, fullscreen, fullSizeContent, hideTitleBar, titlebarAppearsTransparent, hideTitle, useToolbar, hideToolbarSeparator, webviewIsTransparent, alwaysOnTop, hideWindowOnClose, appearance, windowIsTranslucent, devtoolsEnabled, defaultContextMenuEnabled, windowStartState,
+    WailsContext *result = Create("OI OI!",400,400, frameless, resizable, zoomable, fullscreen, fullSizeContent, hideTitleBar, titlebarAppearsTransparent, hideTitle, useToolbar, hideToolbarSeparator, webviewIsTransparent, alwaysOnTop, skipTaskbar, hideWindowOnClose, appearance, windowIsTranslucent, devtoolsEnabled, defaultContextMenuEnabled, windowStartState,
                                   startsHidden, 400, 400, 600, 600, false);
     SetBackgroundColour(result, 255, 0, 0, 255);
     void *m = NewMenu("");
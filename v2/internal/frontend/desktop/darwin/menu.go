@@ -17,6 +17,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/wailsapp/wails/v2/internal/frontend"
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/menu/keys"
 )
@@ -73,6 +74,20 @@ func (m *NSMenu) AddMenuItem(menuItem *menu.MenuItem) *MenuItem {
 	return result
 }
 
+// AddEditRoleItem adds an item that sends the selector to the first responder, so it works in native text fields and
+// is enabled by the responder like the items of the EditMenuRole
+func (m *NSMenu) AddEditRoleItem(menuItem *menu.MenuItem, selector string) {
+	c := NewCalloc()
+	defer c.Free()
+	var modifier C.int
+	var key *C.char
+	if menuItem.Accelerator != nil {
+		modifier = C.int(keys.ToMacModifier(menuItem.Accelerator))
+		key = c.String(menuItem.Accelerator.Key)
+	}
+	C.AppendEditRoleItem(m.nsmenu, c.String(menuItem.Label), c.String(selector), key, modifier, bool2Cint(menuItem.Disabled))
+}
+
 //func (w *Window) SetApplicationMenu(menu *menu.Menu) {
 //w.applicationMenu = menu
 //processMenu(w, menu)
@@ -113,6 +128,16 @@ func processMenuItem(parent *NSMenu, menuItem *menu.MenuItem) *MenuItem {
 	if menuItem.Hidden {
 		return nil
 	}
+	if item, ok := frontend.EditRoleItem(menuItem, func(command frontend.EditCommand) {
+		executeEditCommand(parent.context, command)
+	}); ok {
+		command, _ := frontend.EditRoleCommand(menuItem.Role)
+		if selector, ok := editCommands[command]; ok {
+			parent.AddEditRoleItem(item, selector)
+			return nil
+		}
+		return parent.AddMenuItem(item)
+	}
 	if menuItem.Role != 0 {
 		parent.AppendRole(menuItem.Role)
 		return nil
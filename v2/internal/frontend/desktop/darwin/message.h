@@ -20,6 +20,23 @@ void processMessageDialogResponse(int);
 void processOpenFileDialogResponse(const char*);
 void processSaveFileDialogResponse(const char*);
 void processCallback(int);
+void processPrintToPDFResponse(int);
+void processSnapshotResponse(int);
+void processCookie(const char *, const char *, const char *, const char *, long long, int, int, int);
+void processCookiesResult(int);
+int acceptScriptMessage(const char *);
+int allowNavigation(const char *);
+int decideNavigation(const char *, int, char **);
+int decideNewWindow(const char *, int, char **);
+int downloadsEnabled(void);
+char *decideDownload(const char *, const char *, const char *, char **, int *);
+void beginDownload(const char *, const char *);
+void downloadProgress(const char *, long long, long long);
+void downloadFinished(const char *, const char *, int);
+int acceptServerCertificate(const char *, const void *, int *, int);
+int clientCertificate(const char *, int, void **, int *, char **);
+int authenticationCredentials(const char *, int, const char *, const char *, int, int, char **, char **);
+int decidePermission(const char *, const char *, const char *);
 
 #ifdef __cplusplus
 }
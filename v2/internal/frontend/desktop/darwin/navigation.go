@@ -0,0 +1,46 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// navigationPolicy is used by the delegates of the webview to decide the navigations and the new windows
+var navigationPolicy = frontend.NewNavigationPolicy(nil, nil, nil)
+
+// decideNavigation reports if the navigation of the main frame is allowed. Otherwise load is set to the URL to load
+// instead, which is freed by the caller, or left empty.
+//
+//export decideNavigation
+func decideNavigation(url *C.char, userInitiated C.int, load **C.char) C.int {
+	allow, loadURL := navigationPolicy.Navigation(C.GoString(url), userInitiated != 0)
+	if allow {
+		return 1
+	}
+	if loadURL != "" {
+		*load = C.CString(loadURL)
+	}
+	return 0
+}
+
+// decideNewWindow reports if the new window has been handled by the application. Then load is set to the URL to load
+// in the window, which is freed by the caller, or left empty.
+//
+//export decideNewWindow
+func decideNewWindow(url *C.char, userInitiated C.int, load **C.char) C.int {
+	handled, loadURL := navigationPolicy.NewWindow(C.GoString(url), userInitiated != 0)
+	if !handled {
+		return 0
+	}
+	if loadURL != "" {
+		*load = C.CString(loadURL)
+	}
+	return 1
+}
@@ -0,0 +1,60 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit
+#import <Foundation/Foundation.h>
+#import "Application.h"
+#import "WailsContext.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// The print operation sends its result to this channel
+var (
+	printToPDFResponse = make(chan bool)
+	printToPDFLock     sync.Mutex
+)
+
+// WindowPrintToPDF saves the print of the webview to a PDF, which is supported from macOS 11
+func (f *Frontend) WindowPrintToPDF(options frontend.PDFOptions) ([]byte, error) {
+	page, err := options.Page()
+	if err != nil {
+		return nil, err
+	}
+	path, err := frontend.NewPDFFile()
+	if err != nil {
+		return nil, err
+	}
+
+	printToPDFLock.Lock()
+	defer printToPDFLock.Unlock()
+
+	c := NewCalloc()
+	defer c.Free()
+	margins := page.Margins
+	C.WindowPrintToPDF(f.mainWindow.context, c.String(path), C.double(page.Width), C.double(page.Height),
+		C.double(margins.Top), C.double(margins.Bottom), C.double(margins.Left), C.double(margins.Right),
+		bool2Cint(options.Landscape), C.double(page.Scale), bool2Cint(options.PrintBackground), bool2Cint(options.HeaderAndFooter))
+
+	if !<-printToPDFResponse {
+		_ = os.Remove(path)
+		return nil, errors.New("printing to PDF failed")
+	}
+	return frontend.ReadPDFFile(path)
+}
+
+//export processPrintToPDFResponse
+func processPrintToPDFResponse(success C.int) {
+	printToPDFResponse <- success != 0
+}
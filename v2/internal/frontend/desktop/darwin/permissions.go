@@ -0,0 +1,35 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// permissionPolicy is used by the UI delegate of the webview to decide the camera and microphone requests
+var permissionPolicy = frontend.NewPermissionPolicy(nil)
+
+// decidePermission returns 1 to grant the request of the origin, 2 to deny it and 0 to prompt the user. otherKind is
+// set for the requests of the camera and the microphone.
+//
+//export decidePermission
+func decidePermission(origin *C.char, kind *C.char, otherKind *C.char) C.int {
+	kinds := []options.Permission{options.Permission(C.GoString(kind))}
+	if otherKind != nil {
+		kinds = append(kinds, options.Permission(C.GoString(otherKind)))
+	}
+	switch permissionPolicy.Decide(frontend.OriginOf(C.GoString(origin)), kinds...) {
+	case options.PermissionAllow:
+		return 1
+	case options.PermissionDeny:
+		return 2
+	}
+	return 0
+}
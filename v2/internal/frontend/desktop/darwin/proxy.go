@@ -0,0 +1,33 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit -framework Network
+#import <Foundation/Foundation.h>
+#import "Application.h"
+#import "WailsContext.h"
+*/
+import "C"
+
+import (
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// setupProxy sets the proxy of the options on the data store of the webview before the first page is loaded
+func (f *Frontend) setupProxy() {
+	proxy, _ := frontend.ParseProxy(f.frontendOptions.Proxy)
+	if proxy == nil {
+		return
+	}
+	c := NewCalloc()
+	defer c.Free()
+	bypass := strings.Join(proxy.BypassWithLoopback(), "\n")
+	if C.SetProxy(f.mainWindow.context, c.String(proxy.Scheme), c.String(proxy.Host), C.int(proxy.Port), c.String(proxy.Username), c.String(proxy.Password), c.String(bypass)) == 0 {
+		f.logger.Warning("The proxy requires macOS 14, the proxy settings of the system are used")
+	}
+}
@@ -0,0 +1,41 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit
+#import <Foundation/Foundation.h>
+#import "Application.h"
+#import "WailsContext.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// Share shows the sharing service picker relative to the anchor, or at the centre of the webview if there is none
+func (f *Frontend) Share(options frontend.ShareOptions) error {
+	files := ""
+	if len(options.Files) > 0 {
+		data, err := json.Marshal(options.Files)
+		if err != nil {
+			return err
+		}
+		files = string(data)
+	}
+	var anchor frontend.ShareAnchor
+	if options.Anchor != nil {
+		anchor = *options.Anchor
+	}
+
+	c := NewCalloc()
+	defer c.Free()
+	C.Share(f.mainWindow.context, c.String(options.Text), c.String(options.URL), c.String(files),
+		bool2Cint(options.Anchor != nil), C.int(anchor.X), C.int(anchor.Y), C.int(anchor.Width), C.int(anchor.Height))
+	return nil
+}
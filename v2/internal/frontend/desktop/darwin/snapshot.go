@@ -0,0 +1,53 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework Cocoa -framework WebKit
+#import <Foundation/Foundation.h>
+#import "Application.h"
+#import "WailsContext.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// The snapshot sends its result to this channel
+var (
+	snapshotResponse = make(chan bool)
+	snapshotLock     sync.Mutex
+)
+
+// WindowCaptureSnapshot captures the visible content of the webview with takeSnapshotWithConfiguration
+func (f *Frontend) WindowCaptureSnapshot() ([]byte, error) {
+	path, err := frontend.NewSnapshotFile()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+
+	c := NewCalloc()
+	defer c.Free()
+	C.WindowCaptureSnapshot(f.mainWindow.context, c.String(path))
+
+	if !<-snapshotResponse {
+		_ = os.Remove(path)
+		return nil, errors.New("capturing the snapshot failed")
+	}
+	return frontend.ReadSnapshotFile(path)
+}
+
+//export processSnapshotResponse
+func processSnapshotResponse(success C.int) {
+	snapshotResponse <- success != 0
+}
@@ -0,0 +1,68 @@
+//go:build darwin
+// +build darwin
+
+package darwin
+
+/*
+#cgo LDFLAGS: -framework Security
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"crypto/x509"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+// tlsPolicy is used by the navigation delegate of the webview to trust server certificates and provide client
+// certificates
+var tlsPolicy = frontend.NewTLSPolicy(nil)
+
+// tlsLogger is the logger of the frontend, set together with the tlsPolicy
+var tlsLogger *logger.Logger
+
+// acceptServerCertificate decides about the certificates of a server that isn't trusted by the system. The
+// certificates are passed as concatenated DER data.
+//
+//export acceptServerCertificate
+func acceptServerCertificate(host *C.char, data unsafe.Pointer, lengths *C.int, count C.int) C.int {
+	if !tlsPolicy.Enabled() || count == 0 {
+		return 0
+	}
+	var chain []*x509.Certificate
+	offset := 0
+	for _, length := range unsafe.Slice(lengths, int(count)) {
+		der := C.GoBytes(unsafe.Add(data, offset), length)
+		offset += int(length)
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return 0
+		}
+		chain = append(chain, certificate)
+	}
+	if !tlsPolicy.AcceptServer(C.GoString(host), chain) {
+		return 0
+	}
+	return 1
+}
+
+// clientCertificate returns the PKCS12 and its password, which are freed by the caller
+//
+//export clientCertificate
+func clientCertificate(host *C.char, port C.int, pkcs12 *unsafe.Pointer, length *C.int, password **C.char) C.int {
+	certificate := tlsPolicy.ClientCertificate(C.GoString(host), int(port), nil)
+	if certificate == nil {
+		return 0
+	}
+	if len(certificate.PKCS12) == 0 {
+		tlsLogger.Error("The client certificate for %s has no PKCS12", C.GoString(host))
+		return 0
+	}
+	*pkcs12 = C.CBytes(certificate.PKCS12)
+	*length = C.int(len(certificate.PKCS12))
+	*password = C.CString(certificate.Password)
+	return 1
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"unsafe"
 
+	"github.com/wailsapp/wails/v2/internal/frontend"
 	"github.com/wailsapp/wails/v2/pkg/menu"
 
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -54,6 +55,7 @@ func NewWindow(frontendOptions *options.App, debug bool, devtools bool) *Window
 	resizable := bool2Cint(!frontendOptions.DisableResize)
 	fullscreen := bool2Cint(frontendOptions.Fullscreen)
 	alwaysOnTop := bool2Cint(frontendOptions.AlwaysOnTop)
+	skipTaskbar := bool2Cint(frontendOptions.SkipTaskbar)
 	hideWindowOnClose := bool2Cint(frontendOptions.HideWindowOnClose)
 	startsHidden := bool2Cint(frontendOptions.StartHidden)
 	devtoolsEnabled := bool2Cint(devtools)
@@ -81,6 +83,12 @@ func NewWindow(frontendOptions *options.App, debug bool, devtools bool) *Window
 	}
 	singleInstanceUniqueId := c.String(singleInstanceUniqueIdStr)
 
+	var profileIdentifier *C.char
+	if identifier := webviewProfileIdentifier(frontendOptions.WebviewProfile); identifier != "" {
+		profileIdentifier = c.String(identifier)
+	}
+	webviewProfileInMemory := bool2Cint(frontendOptions.WebviewProfile != nil && frontendOptions.WebviewProfile.InMemory)
+
 	enableFraudulentWebsiteWarnings := C.bool(frontendOptions.EnableFraudulentWebsiteDetection)
 
 	enableDragAndDrop := C.bool(frontendOptions.DragAndDrop != nil && frontendOptions.DragAndDrop.EnableFileDrop)
@@ -118,11 +126,26 @@ func NewWindow(frontendOptions *options.App, debug bool, devtools bool) *Window
 
 		appearance = c.String(string(mac.Appearance))
 	}
+
+	if video := frontendOptions.VideoPlayback; video != nil {
+		if video.HardwareOverlays {
+			windowIsTranslucent = 0
+			webviewIsTransparent = 0
+		}
+		if video.FullscreenOptimizations && preferences.fullscreenEnabled == nil {
+			preferences.fullscreenEnabled = bool2CboolPtr(true)
+		}
+	}
+	if media := frontendOptions.Media; media != nil {
+		preferences.autoplayAllowed = C.bool(media.AutoplayAllowed)
+		preferences.backgroundThrottlingDisabled = C.bool(media.DisableBackgroundThrottling)
+	}
 	var context *C.WailsContext = C.Create(title, width, height, frameless, resizable, zoomable, fullscreen, fullSizeContent,
 		hideTitleBar, titlebarAppearsTransparent, hideTitle, useToolbar, hideToolbarSeparator, webviewIsTransparent,
-		alwaysOnTop, hideWindowOnClose, appearance, windowIsTranslucent, devtoolsEnabled, defaultContextMenuEnabled,
+		alwaysOnTop, skipTaskbar, hideWindowOnClose, appearance, windowIsTranslucent, devtoolsEnabled, defaultContextMenuEnabled,
 		windowStartState, startsHidden, minWidth, minHeight, maxWidth, maxHeight, enableFraudulentWebsiteWarnings,
 		preferences, singleInstanceEnabled, singleInstanceUniqueId, enableDragAndDrop, disableWebViewDragAndDrop,
+		profileIdentifier, webviewProfileInMemory,
 	)
 
 	// Create menu
@@ -130,6 +153,14 @@ func NewWindow(frontendOptions *options.App, debug bool, devtools bool) *Window
 		context: unsafe.Pointer(context),
 	}
 
+	userScript, err := frontend.InjectedScriptSource(frontendOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if userScript != "" {
+		C.AddUserScript(result.context, c.String(userScript))
+	}
+
 	if frontendOptions.BackgroundColour != nil {
 		result.SetBackgroundColour(frontendOptions.BackgroundColour.R, frontendOptions.BackgroundColour.G, frontendOptions.BackgroundColour.B, frontendOptions.BackgroundColour.A)
 	}
@@ -180,6 +211,63 @@ func (w *Window) ExecJS(js string) {
 	C.free(unsafe.Pointer(_js))
 }
 
+func (w *Window) LoadURL(url string) {
+	_url := C.CString(url)
+	C.LoadURL(w.context, _url)
+	C.free(unsafe.Pointer(_url))
+}
+
+// SetZoom sets the page zoom of the webview, which is supported from macOS 11
+func (w *Window) SetZoom(factor float64) {
+	C.SetZoom(w.context, C.double(factor))
+}
+
+// SetAudioMuted mutes the audio of the webview
+func (w *Window) SetAudioMuted(muted bool) {
+	C.SetAudioMuted(w.context, C.bool(muted))
+}
+
+// executeEditCommand sends the action of the command to the focused element of the webview of the context
+func executeEditCommand(context unsafe.Pointer, command frontend.EditCommand) {
+	if command == frontend.EditCommandFind {
+		_js := C.CString(frontend.FindScript)
+		C.ExecJS(context, _js)
+		C.free(unsafe.Pointer(_js))
+		return
+	}
+	selector, ok := editCommands[command]
+	if !ok {
+		return
+	}
+	_selector := C.CString(selector)
+	C.ExecuteEditCommand(context, _selector)
+	C.free(unsafe.Pointer(_selector))
+}
+
+// SetUserContent replaces the user content of the webview. WKWebView has no user stylesheets, they are added by
+// scripts instead.
+func (w *Window) SetUserContent(items []frontend.UserContentItem) {
+	C.ClearUserContent(w.context)
+	for _, item := range items {
+		script := C.CString(frontend.UserContentScript(item, false))
+		C.AddUserContent(w.context, script, C.bool(item.AllFrames), C.bool(item.InjectionTime == options.InjectAtDocumentEnd))
+		C.free(unsafe.Pointer(script))
+	}
+}
+
+func (w *Window) EmbedNativeView(view uintptr) {
+	C.EmbedNativeView(w.context, C.uintptr_t(view))
+}
+
+// SetNativeViewBounds places the view over the placeholder. The CSS pixels of the viewport are points of the webview.
+func (w *Window) SetNativeViewBounds(view uintptr, bounds frontend.NativeViewBounds) {
+	C.SetNativeViewBounds(w.context, C.uintptr_t(view), C.int(bounds.X), C.int(bounds.Y), C.int(bounds.Width), C.int(bounds.Height), C.bool(bounds.Visible))
+}
+
+func (w *Window) RemoveNativeView(view uintptr) {
+	C.RemoveNativeView(C.uintptr_t(view))
+}
+
 func (w *Window) SetPosition(x int, y int) {
 	C.SetPosition(w.context, C.int(x), C.int(y))
 }
@@ -192,6 +280,20 @@ func (w *Window) SetAlwaysOnTop(onTop bool) {
 	C.SetAlwaysOnTop(w.context, bool2Cint(onTop))
 }
 
+func (w *Window) SetSkipTaskbar(skip bool) {
+	C.SetSkipTaskbar(w.context, bool2Cint(skip))
+}
+
+// SetAppearance sets the NSAppearance of the window, an empty appearance follows the system
+func (w *Window) SetAppearance(appearance string) {
+	var a *C.char
+	if appearance != "" {
+		a = C.CString(appearance)
+		defer C.free(unsafe.Pointer(a))
+	}
+	C.SetAppearance(w.context, a)
+}
+
 func (w *Window) SetTitle(title string) {
 	t := C.CString(title)
 	C.SetTitle(w.context, t)
@@ -0,0 +1,56 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "window.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// authenticationPolicy is used by the signal handler of the webview to answer authentication challenges
+var authenticationPolicy = frontend.NewAuthenticationPolicy(nil, nil)
+
+// setupAuthentication connects the authenticate signal, which is shared by the authentication challenges and the
+// client certificate requests
+func setupAuthentication(webview unsafe.Pointer) {
+	if !authenticationPolicy.Enabled() && !tlsPolicy.HandlesClientCertificates() {
+		return
+	}
+	C.SetupAuthentication(webview)
+}
+
+// authenticationCredentials returns the credentials for the challenge, which are freed by the caller, or 0 for the
+// default handling. WebKitGTK only reports if the challenge is retried.
+//
+//export authenticationCredentials
+func authenticationCredentials(host *C.char, port C.int, realm *C.char, scheme *C.char, isProxy C.int, isRetry C.int, username **C.char, password **C.char) C.int {
+	challenge := options.AuthenticationChallenge{
+		Host:    C.GoString(host),
+		Port:    int(port),
+		Realm:   C.GoString(realm),
+		Scheme:  frontend.ParseAuthenticationScheme(C.GoString(scheme)),
+		IsProxy: isProxy != 0,
+	}
+	if isRetry != 0 {
+		challenge.PreviousFailures = 1
+	}
+	credentials := authenticationPolicy.Credentials(challenge)
+	if credentials == nil {
+		return 0
+	}
+	*username = C.CString(credentials.Username)
+	*password = C.CString(credentials.Password)
+	return 1
+}
@@ -10,6 +10,7 @@ package linux
 
 #include "gtk/gtk.h"
 #include "webkit2/webkit2.h"
+#include <stdlib.h>
 
 static gchar* GetClipboardText() {
 	GtkClipboard *clip = gtk_clipboard_get(GDK_SELECTION_CLIPBOARD);
@@ -23,9 +24,18 @@ static void SetClipboardText(gchar* text) {
 	clip = gtk_clipboard_get(GDK_SELECTION_PRIMARY);
 	gtk_clipboard_set_text(clip, text, -1);
 }
+
+static void ExecuteEditCommand(void *webview, const gchar *command) {
+	webkit_web_view_execute_editing_command(WEBKIT_WEB_VIEW(webview), command);
+}
 */
 import "C"
-import "sync"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
 
 func (f *Frontend) ClipboardGetText() (string, error) {
 	var text string
@@ -49,3 +59,35 @@ func (f *Frontend) ClipboardSetText(text string) error {
 	})
 	return nil
 }
+
+// editCommands maps the commands to the values of WEBKIT_EDITING_COMMAND_*
+var editCommands = map[frontend.EditCommand]string{
+	frontend.EditCommandCopy:               "Copy",
+	frontend.EditCommandCut:                "Cut",
+	frontend.EditCommandPaste:              "Paste",
+	frontend.EditCommandSelectAll:          "SelectAll",
+	frontend.EditCommandUndo:               "Undo",
+	frontend.EditCommandRedo:               "Redo",
+	frontend.EditCommandPasteAndMatchStyle: "PasteAsPlainText",
+}
+
+func (f *Frontend) ClipboardEditCommand(command frontend.EditCommand) {
+	f.mainWindow.ExecuteEditCommand(command)
+}
+
+// ExecuteEditCommand executes the command in the focused element of the webview
+func (w *Window) ExecuteEditCommand(command frontend.EditCommand) {
+	if command == frontend.EditCommandFind {
+		w.ExecJS(frontend.FindScript)
+		return
+	}
+	editCommand, ok := editCommands[command]
+	if !ok {
+		return
+	}
+	invokeOnMainThread(func() {
+		ccommand := (*C.gchar)(C.CString(editCommand))
+		defer C.free(unsafe.Pointer(ccommand))
+		C.ExecuteEditCommand(w.webview, ccommand)
+	})
+}
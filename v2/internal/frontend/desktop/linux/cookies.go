@@ -0,0 +1,139 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include <stdlib.h>
+#include "window.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// The cookie operations send their result to this channel, after the cookies that have been received
+var (
+	cookiesResult   = make(chan int)
+	cookiesReceived []frontend.Cookie
+	cookiesLock     sync.Mutex
+)
+
+// newWebContext returns the web context of the profile, nil for the default context
+func newWebContext(profile *options.WebviewProfile) unsafe.Pointer {
+	if profile == nil {
+		return nil
+	}
+	directory := C.CString(profile.Directory)
+	defer C.free(unsafe.Pointer(directory))
+	return C.NewWebContext(directory, bool2Cint(profile.InMemory))
+}
+
+// cookiesOperation runs the operation on the main thread and waits for its result
+func (f *Frontend) cookiesOperation(operation func()) ([]frontend.Cookie, error) {
+	cookiesLock.Lock()
+	defer cookiesLock.Unlock()
+	cookiesReceived = nil
+	invokeOnMainThread(operation)
+	switch <-cookiesResult {
+	case -1:
+		return nil, errors.New("getting all cookies requires WebKitGTK 2.42")
+	case 0:
+		return nil, errors.New("the operation on the cookies failed")
+	}
+	return cookiesReceived, nil
+}
+
+// CookiesGet returns the cookies that are sent to the URL, all cookies if it is empty
+func (f *Frontend) CookiesGet(url string) ([]frontend.Cookie, error) {
+	_url := C.CString(url)
+	defer C.free(unsafe.Pointer(_url))
+	return f.cookiesOperation(func() {
+		C.GetCookies(f.mainWindow.webview, _url)
+	})
+}
+
+// CookieSet adds the cookie or replaces the cookie with the same name, domain and path
+func (f *Frontend) CookieSet(cookie frontend.Cookie) error {
+	cookie, err := frontend.PrepareCookie(cookie)
+	if err != nil {
+		return err
+	}
+	var expires int64
+	if !cookie.Expires.IsZero() {
+		expires = cookie.Expires.Unix()
+	}
+	name, value, domain, path := C.CString(cookie.Name), C.CString(cookie.Value), C.CString(cookie.Domain), C.CString(cookie.Path)
+	defer freeCStrings(name, value, domain, path)
+	_, err = f.cookiesOperation(func() {
+		C.SetCookie(f.mainWindow.webview, name, value, domain, path, C.longlong(expires), bool2Cint(cookie.Secure), bool2Cint(cookie.HTTPOnly), C.int(cookie.SameSite))
+	})
+	return err
+}
+
+// CookieDelete deletes the cookie with the name, domain and path of the cookie
+func (f *Frontend) CookieDelete(cookie frontend.Cookie) error {
+	cookie, err := frontend.PrepareCookie(cookie)
+	if err != nil {
+		return err
+	}
+	// Secure cookies are only returned for secure URIs
+	cookie.Secure = true
+	uri, name, domain, path := C.CString(frontend.CookieURL(cookie)), C.CString(cookie.Name), C.CString(cookie.Domain), C.CString(cookie.Path)
+	defer freeCStrings(uri, name, domain, path)
+	_, err = f.cookiesOperation(func() {
+		C.DeleteCookie(f.mainWindow.webview, uri, name, domain, path)
+	})
+	return err
+}
+
+// SiteDataClear removes the data of all sites with webkit_website_data_manager_clear
+func (f *Frontend) SiteDataClear(data frontend.SiteData) error {
+	if data&frontend.SiteDataAll == 0 {
+		return nil
+	}
+	_, err := f.cookiesOperation(func() {
+		C.ClearSiteData(f.mainWindow.webview, bool2Cint(data&frontend.SiteDataCookies != 0), bool2Cint(data&frontend.SiteDataStorage != 0), bool2Cint(data&frontend.SiteDataCache != 0))
+	})
+	return err
+}
+
+func freeCStrings(values ...*C.char) {
+	for _, value := range values {
+		C.free(unsafe.Pointer(value))
+	}
+}
+
+//export processCookie
+func processCookie(name *C.char, value *C.char, domain *C.char, path *C.char, expires C.longlong, secure C.int, httpOnly C.int, sameSite C.int) {
+	cookie := frontend.Cookie{
+		Name:     C.GoString(name),
+		Value:    C.GoString(value),
+		Domain:   C.GoString(domain),
+		Path:     C.GoString(path),
+		Secure:   secure != 0,
+		HTTPOnly: httpOnly != 0,
+		SameSite: http.SameSite(sameSite),
+	}
+	if expires != 0 {
+		cookie.Expires = time.Unix(int64(expires), 0)
+	}
+	cookiesReceived = append(cookiesReceived, cookie)
+}
+
+//export processCookiesResult
+func processCookiesResult(result C.int) {
+	cookiesResult <- int(result)
+}
@@ -4,8 +4,11 @@
 package linux
 
 import (
-	"github.com/wailsapp/wails/v2/internal/frontend"
+	"errors"
 	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
 )
 
 /*
@@ -64,6 +67,36 @@ func (f *Frontend) SaveFileDialog(dialogOptions frontend.SaveDialogOptions) (str
 	return "", nil
 }
 
+// OpenMediaDialog prompts the user to select images or videos with the file chooser portal. The GTK file chooser is
+// used if the portal is not available.
+func (f *Frontend) OpenMediaDialog(dialogOptions frontend.MediaDialogOptions) ([]string, error) {
+	var filters []portal.Filter
+	for _, filterType := range frontend.MediaFilterTypes(dialogOptions.Type) {
+		filters = append(filters, portal.Filter{Name: frontend.MediaTypeName(filterType), MIMETypes: frontend.MediaMIMETypes(filterType)})
+	}
+	paths, err := portal.OpenFile(portal.OpenFileOptions{
+		Title:    dialogOptions.Title,
+		Multiple: dialogOptions.Multiple,
+		Filters:  filters,
+	})
+	if !errors.Is(err, portal.ErrUnavailable) {
+		return paths, err
+	}
+
+	options := frontend.OpenDialogOptions{
+		Title:   dialogOptions.Title,
+		Filters: frontend.MediaFilters(dialogOptions.Type),
+	}
+	if dialogOptions.Multiple {
+		return f.OpenMultipleFilesDialog(options)
+	}
+	result, err := f.OpenFileDialog(options)
+	if err != nil || result == "" {
+		return nil, err
+	}
+	return []string{result}, nil
+}
+
 func (f *Frontend) MessageDialog(dialogOptions frontend.MessageDialogOptions) (string, error) {
 	f.mainWindow.MessageDialog(dialogOptions)
 	return <-messageDialogResult, nil
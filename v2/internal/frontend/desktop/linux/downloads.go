@@ -0,0 +1,88 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include <stdlib.h>
+#include "window.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// downloads is used by the signal handlers of the downloads to decide their paths and report their progress
+var downloads = frontend.NewDownloadManager(nil, nil)
+
+// webkitDownloads are the downloads in flight, which are only used on the main thread
+var webkitDownloads = map[string]unsafe.Pointer{}
+
+// setupDownloads connects the download signal of the web context
+func setupDownloads(webview unsafe.Pointer, window *C.GtkWindow) {
+	if !downloads.Enabled() {
+		return
+	}
+	C.SetupDownloads(webview, window)
+}
+
+// decideDownload returns the path of the download and sets its id, which are freed by the caller. It returns nil if
+// the download is cancelled.
+//
+//export decideDownload
+func decideDownload(url *C.char, suggestedFilename *C.char, mimeType *C.char, id **C.char, prompt *C.int) *C.char {
+	destination, ok := downloads.Start(C.GoString(url), C.GoString(suggestedFilename), C.GoString(mimeType))
+	if !ok {
+		return nil
+	}
+	*id = C.CString(destination.ID)
+	if destination.Prompt {
+		*prompt = 1
+	}
+	return C.CString(destination.Path)
+}
+
+//export beginDownload
+func beginDownload(id *C.char, path *C.char, download unsafe.Pointer) {
+	goID := C.GoString(id)
+	webkitDownloads[goID] = download
+	downloads.Begin(goID, C.GoString(path), func() {
+		invokeOnMainThread(func() {
+			if download, ok := webkitDownloads[goID]; ok {
+				C.CancelDownload(download)
+			}
+		})
+	})
+}
+
+//export downloadProgress
+func downloadProgress(id *C.char, received C.longlong, total C.longlong) {
+	downloads.Progress(C.GoString(id), int64(received), int64(total))
+}
+
+//export downloadFinished
+func downloadFinished(id *C.char, message *C.char, cancelled C.int) {
+	goID := C.GoString(id)
+	delete(webkitDownloads, goID)
+	var err error
+	switch {
+	case cancelled != 0:
+		err = frontend.ErrDownloadCancelled
+	case message != nil:
+		err = errors.New(C.GoString(message))
+	}
+	downloads.Finish(goID, err)
+}
+
+// DownloadCancel cancels the download in flight
+func (f *Frontend) DownloadCancel(id string) {
+	downloads.Cancel(id)
+}
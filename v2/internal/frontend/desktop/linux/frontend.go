@@ -87,6 +87,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"text/template"
 	"unsafe"
 
@@ -124,6 +125,12 @@ type Frontend struct {
 	mainWindow *Window
 	bindings   *binding.Bindings
 	dispatcher frontend.Dispatcher
+
+	contentRecovery *frontend.ContentRecovery
+	userContent     *frontend.UserContentManager
+	nativeViews     *frontend.NativeViewManager
+	zoom            *frontend.Zoom
+	audioMuted      atomic.Bool
 }
 
 func (f *Frontend) RunMainLoop() {
@@ -154,6 +161,14 @@ func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.
 		bindings:        appBindings,
 		dispatcher:      dispatcher,
 		ctx:             ctx,
+		contentRecovery: frontend.NewContentRecovery(appoptions.WebviewRecovery),
+		userContent:     frontend.NewUserContentManager(),
+		nativeViews:     frontend.NewNativeViewManager(),
+	}
+	appDataDir, _ := ctx.Value("appdatadir").(string)
+	result.zoom = frontend.NewZoom(appoptions.Zoom, appDataDir, 1)
+	if appoptions.Media != nil {
+		result.audioMuted.Store(appoptions.Media.Muted)
 	}
 	result.startURL, _ = url.Parse(startURL)
 
@@ -182,6 +197,15 @@ func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.
 
 		go result.startRequestProcessor()
 	}
+	originPolicy = newOriginPolicy(appoptions.ExternalContent, result.startURL, result.logger)
+	tlsPolicy = frontend.NewTLSPolicy(appoptions.TLS)
+	tlsLogger = result.logger
+	// The proxy has been checked when the application was created
+	proxy, _ := frontend.ParseProxy(appoptions.Proxy)
+	authenticationPolicy = frontend.NewAuthenticationPolicy(appoptions.Authentication, proxy)
+	navigationPolicy = frontend.NewNavigationPolicy(appoptions.Navigation, result.startURL, result.BrowserOpenURL)
+	permissionPolicy = frontend.NewPermissionPolicy(appoptions.Permissions)
+	downloads = frontend.NewDownloadManager(appoptions.Downloads, frontend.EmitDownloads(ctx))
 
 	go result.startMessageProcessor()
 
@@ -196,6 +220,12 @@ func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.
 	}
 
 	result.mainWindow = NewWindow(appoptions, result.debug, result.devtoolsEnabled)
+	if factor := result.zoom.Factor(); factor != 1 {
+		result.mainWindow.SetZoom(factor)
+	}
+	if result.audioMuted.Load() {
+		result.mainWindow.SetAudioMuted(true)
+	}
 
 	C.install_signal_handlers()
 
@@ -221,15 +251,15 @@ func (f *Frontend) WindowReload() {
 }
 
 func (f *Frontend) WindowSetSystemDefaultTheme() {
-	return
+	f.setTheme(false, true)
 }
 
 func (f *Frontend) WindowSetLightTheme() {
-	return
+	f.setTheme(false, false)
 }
 
 func (f *Frontend) WindowSetDarkTheme() {
-	return
+	f.setTheme(true, false)
 }
 
 func (f *Frontend) Run(ctx context.Context) error {
@@ -258,6 +288,10 @@ func (f *Frontend) WindowSetAlwaysOnTop(b bool) {
 	f.mainWindow.SetKeepAbove(b)
 }
 
+func (f *Frontend) WindowSetSkipTaskbar(skip bool) {
+	f.mainWindow.SetSkipTaskbar(skip)
+}
+
 func (f *Frontend) WindowSetPosition(x, y int) {
 	f.mainWindow.SetPosition(x, y)
 }
@@ -376,6 +410,50 @@ func (f *Frontend) WindowPrint() {
 	f.ExecJS("window.print();")
 }
 
+func (f *Frontend) WindowAddUserContent(content options.UserContent) (string, error) {
+	item, err := f.userContent.Add(content)
+	if err != nil {
+		return "", err
+	}
+	f.mainWindow.SetUserContent(f.userContent.Items())
+	return item.ID, nil
+}
+
+func (f *Frontend) WindowRemoveUserContent(id string) {
+	if f.userContent.Remove(id) {
+		f.mainWindow.SetUserContent(f.userContent.Items())
+	}
+}
+
+func (f *Frontend) WindowSetZoom(factor float64) {
+	factor, err := f.zoom.Set(factor)
+	if err != nil {
+		f.logger.Error("Unable to persist the zoom factor: %s", err)
+	}
+	f.mainWindow.SetZoom(factor)
+}
+
+func (f *Frontend) WindowGetZoom() float64 {
+	return f.zoom.Factor()
+}
+
+func (f *Frontend) WindowSetAudioMuted(muted bool) {
+	f.audioMuted.Store(muted)
+	f.mainWindow.SetAudioMuted(muted)
+}
+
+func (f *Frontend) WindowIsAudioMuted() bool {
+	return f.audioMuted.Load()
+}
+
+func (f *Frontend) WindowRecycle() {
+	f.recycleContent(options.WebviewCrashReasonRecycled)
+}
+
+func (f *Frontend) recycleContent(reason options.WebviewCrashReason) {
+	f.contentRecovery.Recover(f.ctx, reason, f.startURL.String(), f.MessageDialog, f.mainWindow.Recycle)
+}
+
 type EventNotify struct {
 	Name string        `json:"name"`
 	Data []interface{} `json:"data"`
@@ -407,6 +485,7 @@ var edgeMap = map[string]uintptr{
 
 func (f *Frontend) processMessage(message string) {
 	if message == "DomReady" {
+		f.contentRecovery.Navigated()
 		if f.frontendOptions.OnDomReady != nil {
 			f.frontendOptions.OnDomReady(f.ctx)
 		}
@@ -425,6 +504,20 @@ func (f *Frontend) processMessage(message string) {
 		return
 	}
 
+	if message == "wails:contentcrashed" || message == "wails:memorylimit" || message == "wails:unresponsive" {
+		if f.frontendOptions.WebviewRecovery != nil {
+			reason := options.WebviewCrashReasonCrashed
+			switch message {
+			case "wails:memorylimit":
+				reason = options.WebviewCrashReasonMemoryLimit
+			case "wails:unresponsive":
+				reason = options.WebviewCrashReasonUnresponsive
+			}
+			go f.recycleContent(reason)
+		}
+		return
+	}
+
 	if strings.HasPrefix(message, "resize:") {
 		if !f.mainWindow.IsFullScreen() {
 			sl := strings.Split(message, ":")
@@ -462,6 +555,10 @@ func (f *Frontend) processMessage(message string) {
 			f.ExecJS("window.wails.flags.enableWailsDragAndDrop = true;")
 		}
 
+		if f.zoom.Shortcuts() {
+			f.ExecJS("window.wails.flags.enableZoomShortcuts = true;")
+		}
+
 		return
 	}
 
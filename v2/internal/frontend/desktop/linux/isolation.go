@@ -0,0 +1,40 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"net/url"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// originPolicy is used by the signal handlers of the webview to block third-party origins
+var originPolicy = frontend.NewOriginPolicy(nil, nil)
+
+// newOriginPolicy blocks third-party documents instead of isolating them. The script messages of WebKitGTK don't
+// tell which frame has sent them, so they can't be dropped for third-party frames.
+func newOriginPolicy(externalContent *options.ExternalContent, appURL *url.URL, myLogger *logger.Logger) *frontend.OriginPolicy {
+	if externalContent != nil && externalContent.Policy == options.ExternalContentIsolate {
+		myLogger.Warning("ExternalContentIsolate is not supported on Linux, the documents of third-party origins are blocked")
+		blocked := *externalContent
+		blocked.Policy = options.ExternalContentBlock
+		externalContent = &blocked
+	}
+	return frontend.NewOriginPolicy(externalContent, appURL)
+}
+
+//export allowNavigation
+func allowNavigation(url *C.char) C.int {
+	if !originPolicy.CanNavigate(C.GoString(url)) {
+		return 0
+	}
+	return 1
+}
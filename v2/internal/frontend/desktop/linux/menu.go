@@ -34,6 +34,7 @@ void addAccelerator(GtkWidget* menuItem, GtkAccelGroup* group, guint key, GdkMod
 }
 */
 import "C"
+import "github.com/wailsapp/wails/v2/internal/frontend"
 import "github.com/wailsapp/wails/v2/pkg/menu"
 import "unsafe"
 
@@ -79,14 +80,20 @@ func (w *Window) SetApplicationMenu(inmenu *menu.Menu) {
 	C.gtk_widget_show(w.menubar)
 }
 
-func processMenu(window *Window, menu *menu.Menu) {
-	for _, menuItem := range menu.Items {
-		submenu := processSubmenu(menuItem, window.accels)
+func processMenu(window *Window, inmenu *menu.Menu) {
+	for _, menuItem := range inmenu.Items {
+		if menuItem.Role == menu.EditMenuRole {
+			menuItem = frontend.EditMenu()
+		}
+		if menuItem.SubMenu == nil {
+			continue
+		}
+		submenu := processSubmenu(window, menuItem, window.accels)
 		C.gtk_menu_shell_append(C.toGtkMenuShell(unsafe.Pointer(window.menubar)), submenu)
 	}
 }
 
-func processSubmenu(menuItem *menu.MenuItem, group *C.GtkAccelGroup) *C.GtkWidget {
+func processSubmenu(window *Window, menuItem *menu.MenuItem, group *C.GtkAccelGroup) *C.GtkWidget {
 	existingMenu := gtkMenuCache[menuItem]
 	if existingMenu != nil {
 		return existingMenu
@@ -98,7 +105,7 @@ func processSubmenu(menuItem *menu.MenuItem, group *C.GtkAccelGroup) *C.GtkWidge
 		menuIdToItem[menuID] = menuItem
 		menuItemToId[menuItem] = menuID
 		menuIdCounter++
-		processMenuItem(gtkMenu, menuItem, group)
+		processMenuItem(window, gtkMenu, menuItem, group)
 	}
 	C.gtk_menu_item_set_submenu(C.toGtkMenuItem(unsafe.Pointer(submenu)), gtkMenu)
 	gtkMenuCache[menuItem] = existingMenu
@@ -107,11 +114,20 @@ func processSubmenu(menuItem *menu.MenuItem, group *C.GtkAccelGroup) *C.GtkWidge
 
 var currentRadioGroup *C.GSList
 
-func processMenuItem(parent *C.GtkWidget, menuItem *menu.MenuItem, group *C.GtkAccelGroup) {
+func processMenuItem(window *Window, parent *C.GtkWidget, menuItem *menu.MenuItem, group *C.GtkAccelGroup) {
 	if menuItem.Hidden {
 		return
 	}
 
+	if menuItem.Role == menu.EditMenuRole {
+		menuItem = frontend.EditMenu()
+	} else if item, ok := frontend.EditRoleItem(menuItem, window.ExecuteEditCommand); ok {
+		menuItem = item
+	} else if menuItem.Role != 0 {
+		// The other roles are macOS only
+		return
+	}
+
 	if menuItem.Type != menu.RadioType {
 		currentRadioGroup = nil
 	}
@@ -142,7 +158,7 @@ func processMenuItem(parent *C.GtkWidget, menuItem *menu.MenuItem, group *C.GtkA
 		}
 		gtkRadioMenuCache[menuItem] = append(gtkRadioMenuCache[menuItem], result)
 	case menu.SubmenuType:
-		result = processSubmenu(menuItem, group)
+		result = processSubmenu(window, menuItem, group)
 	}
 	C.gtk_menu_shell_append(C.toGtkMenuShell(unsafe.Pointer(parent)), result)
 	C.gtk_widget_show(result)
@@ -0,0 +1,99 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include <stdint.h>
+#include <gtk/gtk.h>
+#include <webkit2/webkit2.h>
+
+static void embedNativeView(GtkWidget *overlay, uintptr_t view) {
+    GtkWidget *widget = GTK_WIDGET((void *)view);
+    gtk_widget_set_halign(widget, GTK_ALIGN_START);
+    gtk_widget_set_valign(widget, GTK_ALIGN_START);
+    // Keep the widget hidden until the bounds of its placeholder are known
+    gtk_widget_set_no_show_all(widget, TRUE);
+    gtk_widget_hide(widget);
+    gtk_overlay_add_overlay(GTK_OVERLAY(overlay), widget);
+}
+
+static void setNativeViewBounds(void *webview, uintptr_t view, int x, int y, int width, int height, gboolean visible) {
+    GtkWidget *widget = GTK_WIDGET((void *)view);
+    // The bounds are CSS pixels, which are scaled by the zoom of the webview
+    double zoom = webkit_web_view_get_zoom_level(WEBKIT_WEB_VIEW(webview));
+    x = (int)(x * zoom);
+    y = (int)(y * zoom);
+    width = (int)(width * zoom);
+    height = (int)(height * zoom);
+
+    // Overlays can't have negative margins, placeholders that are scrolled out at the start are shrunk instead
+    if (x < 0) {
+        width += x;
+        x = 0;
+    }
+    if (y < 0) {
+        height += y;
+        y = 0;
+    }
+    if (!visible || width <= 0 || height <= 0) {
+        gtk_widget_hide(widget);
+        return;
+    }
+    gtk_widget_set_margin_start(widget, x);
+    gtk_widget_set_margin_top(widget, y);
+    gtk_widget_set_size_request(widget, width, height);
+    gtk_widget_show(widget);
+}
+
+static void removeNativeView(GtkWidget *overlay, uintptr_t view) {
+    GtkWidget *widget = GTK_WIDGET((void *)view);
+    if (gtk_widget_get_parent(widget) == overlay) {
+        gtk_container_remove(GTK_CONTAINER(overlay), widget);
+    }
+}
+*/
+import "C"
+import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// WindowEmbedNativeView adds the widget as an overlay of the webview. The handle is a pointer to a GtkWidget without a
+// parent. The overlay holds a reference to the widget until it is removed.
+func (f *Frontend) WindowEmbedNativeView(id string, handle uintptr) error {
+	bounds, err := f.nativeViews.Add(id, handle)
+	if err != nil {
+		return err
+	}
+	invokeOnMainThread(func() {
+		C.embedNativeView(f.mainWindow.overlay, C.uintptr_t(handle))
+		C.setNativeViewBounds(f.mainWindow.webview, C.uintptr_t(handle), C.int(bounds.X), C.int(bounds.Y), C.int(bounds.Width), C.int(bounds.Height), gtkBool(bounds.Visible))
+	})
+	return nil
+}
+
+// WindowRemoveNativeView removes the widget from the overlay, which destroys it unless the application holds a
+// reference to it
+func (f *Frontend) WindowRemoveNativeView(id string) {
+	handle, removed := f.nativeViews.Remove(id)
+	if !removed {
+		return
+	}
+	invokeOnMainThread(func() {
+		C.removeNativeView(f.mainWindow.overlay, C.uintptr_t(handle))
+	})
+}
+
+func (f *Frontend) WindowSetNativeViewBounds(bounds frontend.NativeViewBounds) {
+	handle, embedded := f.nativeViews.SetBounds(bounds)
+	if !embedded {
+		return
+	}
+	invokeOnMainThread(func() {
+		C.setNativeViewBounds(f.mainWindow.webview, C.uintptr_t(handle), C.int(bounds.X), C.int(bounds.Y), C.int(bounds.Width), C.int(bounds.Height), gtkBool(bounds.Visible))
+	})
+}
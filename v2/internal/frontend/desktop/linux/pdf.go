@@ -0,0 +1,70 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "window.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"sync"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// The print operation sends its result to this channel
+var (
+	printToPDFResult = make(chan bool)
+	printToPDFLock   sync.Mutex
+)
+
+// WindowPrintToPDF prints the page with the "Print to File" printer of GTK. The header and footer and the background
+// options aren't supported by WebKitGTK.
+func (f *Frontend) WindowPrintToPDF(options frontend.PDFOptions) ([]byte, error) {
+	page, err := options.Page()
+	if err != nil {
+		return nil, err
+	}
+	path, err := frontend.NewPDFFile()
+	if err != nil {
+		return nil, err
+	}
+
+	printToPDFLock.Lock()
+	defer printToPDFLock.Unlock()
+
+	uri := C.CString((&url.URL{Scheme: "file", Path: path}).String())
+	defer C.free(unsafe.Pointer(uri))
+	margins := page.Margins
+	landscape := 0
+	if options.Landscape {
+		landscape = 1
+	}
+	invokeOnMainThread(func() {
+		C.PrintToPDF(f.mainWindow.webview, uri, C.double(page.Width), C.double(page.Height),
+			C.double(margins.Top), C.double(margins.Bottom), C.double(margins.Left), C.double(margins.Right),
+			C.int(landscape), C.double(page.Scale))
+	})
+
+	if !<-printToPDFResult {
+		_ = os.Remove(path)
+		return nil, errors.New("printing to PDF failed")
+	}
+	return frontend.ReadPDFFile(path)
+}
+
+//export processPrintToPDFResult
+func processPrintToPDFResult(success C.int) {
+	printToPDFResult <- success != 0
+}
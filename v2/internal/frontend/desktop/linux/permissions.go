@@ -0,0 +1,50 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "window.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// permissionPolicy is used by the signal handler of the webview to decide the permission requests
+var permissionPolicy = frontend.NewPermissionPolicy(nil)
+
+// setupPermissions connects the permission-request signal if the application decides the requests
+func setupPermissions(webview unsafe.Pointer) {
+	if !permissionPolicy.Enabled() {
+		return
+	}
+	C.SetupPermissions(webview)
+}
+
+// decidePermission returns 1 to allow the request of the page at the URI, 2 to deny it and 0 for the default
+// handling. WebKitGTK doesn't report the frame of the request, so the origin is the one of the page. otherKind is set
+// for the requests of the camera and the microphone.
+//
+//export decidePermission
+func decidePermission(uri *C.char, kind *C.char, otherKind *C.char) C.int {
+	kinds := []options.Permission{options.Permission(C.GoString(kind))}
+	if otherKind != nil {
+		kinds = append(kinds, options.Permission(C.GoString(otherKind)))
+	}
+	switch permissionPolicy.Decide(frontend.OriginOf(C.GoString(uri)), kinds...) {
+	case options.PermissionAllow:
+		return 1
+	case options.PermissionDeny:
+		return 2
+	}
+	return 0
+}
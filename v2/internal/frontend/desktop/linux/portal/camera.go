@@ -0,0 +1,12 @@
+package portal
+
+import (
+	"github.com/godbus/dbus/v5"
+)
+
+// AccessCamera asks the user for access to the cameras, which the sandbox of Flatpak only allows through the portal.
+// It returns false when the user denies it.
+func AccessCamera() (bool, error) {
+	code, _, err := request("org.freedesktop.portal.Camera.AccessCamera", map[string]dbus.Variant{})
+	return err == nil && code == responseSuccess, err
+}
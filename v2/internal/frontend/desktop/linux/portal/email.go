@@ -0,0 +1,46 @@
+package portal
+
+import (
+	"os"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// EmailOptions contains the options of ComposeEmail
+type EmailOptions struct {
+	Subject string
+	Body    string
+	// Attachments are the paths of the attached files
+	Attachments []string
+}
+
+// ComposeEmail opens a new email in the email client of the user, which is the only way to share content through the
+// portal
+func ComposeEmail(parentWindow string, options EmailOptions) error {
+	var fds []dbus.UnixFD
+	for _, path := range options.Attachments {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		fds = append(fds, dbus.UnixFD(file.Fd()))
+	}
+	_, _, err := request("org.freedesktop.portal.Email.ComposeEmail", emailOptions(options, fds), parentWindow)
+	return err
+}
+
+func emailOptions(options EmailOptions, fds []dbus.UnixFD) map[string]dbus.Variant {
+	result := map[string]dbus.Variant{}
+	if options.Subject != "" {
+		result["subject"] = dbus.MakeVariant(options.Subject)
+	}
+	if body := strings.TrimSpace(options.Body); body != "" {
+		result["body"] = dbus.MakeVariant(body)
+	}
+	if len(fds) > 0 {
+		result["attachment_fds"] = dbus.MakeVariant(fds)
+	}
+	return result
+}
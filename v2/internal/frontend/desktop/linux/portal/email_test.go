@@ -0,0 +1,25 @@
+package portal
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestEmailOptions(t *testing.T) {
+	options := emailOptions(EmailOptions{Subject: "Report", Body: "See the attachment\n"}, []dbus.UnixFD{3, 4})
+	if subject, _ := options["subject"].Value().(string); subject != "Report" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+	if body, _ := options["body"].Value().(string); body != "See the attachment" {
+		t.Errorf("unexpected body %q", body)
+	}
+	if fds, _ := options["attachment_fds"].Value().([]dbus.UnixFD); len(fds) != 2 {
+		t.Errorf("unexpected attachments %v", options["attachment_fds"])
+	}
+
+	options = emailOptions(EmailOptions{Body: " "}, nil)
+	if len(options) != 0 {
+		t.Errorf("expected no options, got %v", options)
+	}
+}
@@ -0,0 +1,80 @@
+package portal
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// mimeRule is the type of the filter rules that match MIME types, 0 would match glob patterns
+const mimeRule uint32 = 1
+
+// Filter is a named list of MIME types, EG: "Images" and "image/*"
+type Filter struct {
+	Name      string
+	MIMETypes []string
+}
+
+type filterRule struct {
+	Type    uint32
+	Pattern string
+}
+
+type filter struct {
+	Name  string
+	Rules []filterRule
+}
+
+// OpenFileOptions are the options of OpenFile
+type OpenFileOptions struct {
+	// ParentWindow is the identifier of the parent window, EG: "x11:1c00003". The dialog is not modal without it.
+	ParentWindow string
+	Title        string
+	Multiple     bool
+	Filters      []Filter
+}
+
+// OpenFile prompts the user to select files with the file chooser and returns the paths. It returns no paths when
+// the user cancels.
+func OpenFile(options OpenFileOptions) ([]string, error) {
+	filters := make([]filter, 0, len(options.Filters))
+	for _, f := range options.Filters {
+		rules := make([]filterRule, len(f.MIMETypes))
+		for index, mimeType := range f.MIMETypes {
+			rules[index] = filterRule{Type: mimeRule, Pattern: mimeType}
+		}
+		filters = append(filters, filter{Name: f.Name, Rules: rules})
+	}
+	callOptions := map[string]dbus.Variant{
+		"multiple": dbus.MakeVariant(options.Multiple),
+		"modal":    dbus.MakeVariant(options.ParentWindow != ""),
+	}
+	if len(filters) > 0 {
+		callOptions["filters"] = dbus.MakeVariant(filters)
+		callOptions["current_filter"] = dbus.MakeVariant(filters[0])
+	}
+
+	code, results, err := request("org.freedesktop.portal.FileChooser.OpenFile", callOptions, options.ParentWindow, options.Title)
+	if err != nil || code == responseCancelled {
+		return nil, err
+	}
+	return urisToPaths(results)
+}
+
+// urisToPaths returns the paths of the file URIs of the results
+func urisToPaths(results map[string]dbus.Variant) ([]string, error) {
+	var uris []string
+	if value, ok := results["uris"]; ok {
+		_ = value.Store(&uris)
+	}
+	paths := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		parsed, err := url.Parse(uri)
+		if err != nil || parsed.Scheme != "file" {
+			return nil, fmt.Errorf("unsupported uri %s", uri)
+		}
+		paths = append(paths, parsed.Path)
+	}
+	return paths, nil
+}
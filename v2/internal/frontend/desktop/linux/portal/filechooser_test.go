@@ -0,0 +1,44 @@
+package portal
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestRequestPath(t *testing.T) {
+	if path := requestPath(":1.42", "wails1"); path != "/org/freedesktop/portal/desktop/request/1_42/wails1" {
+		t.Errorf("unexpected request path %s", path)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	results := map[string]dbus.Variant{
+		"uris": dbus.MakeVariant([]string{"file:///home/user/Pictures/My%20Photo.jpg", "file:///run/user/1000/doc/1a2b/clip.mp4"}),
+	}
+	code, parsed, err := parseResponse([]interface{}{uint32(0), results})
+	if err != nil || code != responseSuccess {
+		t.Fatal(code, err)
+	}
+	paths, err := urisToPaths(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || paths[0] != "/home/user/Pictures/My Photo.jpg" || paths[1] != "/run/user/1000/doc/1a2b/clip.mp4" {
+		t.Errorf("unexpected paths %v", paths)
+	}
+
+	code, _, err = parseResponse([]interface{}{uint32(1), map[string]dbus.Variant{}})
+	if err != nil || code != responseCancelled {
+		t.Errorf("expected a cancelled response, got %d, %v", code, err)
+	}
+
+	if _, _, err = parseResponse([]interface{}{uint32(2), map[string]dbus.Variant{}}); err == nil {
+		t.Error("expected an error")
+	}
+
+	results["uris"] = dbus.MakeVariant([]string{"https://example.com/photo.jpg"})
+	if _, err = urisToPaths(results); err == nil {
+		t.Error("expected an error for remote uris")
+	}
+}
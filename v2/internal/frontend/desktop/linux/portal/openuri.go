@@ -0,0 +1,40 @@
+package portal
+
+import (
+	"errors"
+	"os"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// OpenFileWith opens the file with an application. If ask is true, the user chooses the application with the
+// application chooser of the desktop, otherwise the default application is used if there is one. It returns false
+// when the user cancels.
+func OpenFileWith(parentWindow string, path string, ask bool) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	code, _, err := request("org.freedesktop.portal.OpenURI.OpenFile", map[string]dbus.Variant{
+		"ask": dbus.MakeVariant(ask),
+	}, parentWindow, dbus.UnixFD(file.Fd()))
+	return err == nil && code == responseSuccess, err
+}
+
+// OpenDirectory opens the directory that contains the file or directory in the file manager and selects it. It
+// returns ErrUnavailable if the portal is older than version 3 of OpenURI.
+func OpenDirectory(parentWindow string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	code, _, err := request("org.freedesktop.portal.OpenURI.OpenDirectory", map[string]dbus.Variant{}, parentWindow, dbus.UnixFD(file.Fd()))
+	if err == nil && code != responseSuccess {
+		return errors.New("opening the directory has been cancelled")
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+// Package portal uses the XDG desktop portal, which provides the native dialogs of the desktop environment and is the
+// only way to access the files of the user in Flatpak and Snap sandboxes.
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	desktopName      = "org.freedesktop.portal.Desktop"
+	desktopPath      = "/org/freedesktop/portal/desktop"
+	requestInterface = "org.freedesktop.portal.Request"
+)
+
+// Response codes of requests
+const (
+	responseSuccess   uint32 = 0
+	responseCancelled uint32 = 1
+)
+
+// ErrUnavailable is returned when there is no session bus or the portal doesn't provide the interface
+var ErrUnavailable = errors.New("the desktop portal is not available")
+
+var requestCounter atomic.Uint64
+
+// request calls the method of the portal and waits for the Response signal of the request. The options are passed
+// after the arguments, a handle token is added to them.
+func request(method string, options map[string]dbus.Variant, args ...interface{}) (uint32, map[string]dbus.Variant, error) {
+	conn, err := connect()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Close()
+	return requestOn(conn, method, options, args...)
+}
+
+// connect connects to the session bus. The connection is private, as the sessions of the portal live as long as the
+// connection that created them.
+func connect() (*dbus.Conn, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return conn, nil
+}
+
+// newToken returns a unique token for the handles of requests and sessions
+func newToken() string {
+	return "wails" + strconv.FormatUint(requestCounter.Add(1), 10)
+}
+
+// requestOn calls the method like request on an existing connection, EG: of a session
+func requestOn(conn *dbus.Conn, method string, options map[string]dbus.Variant, args ...interface{}) (uint32, map[string]dbus.Variant, error) {
+	token := newToken()
+	requestPath := requestPath(conn.Names()[0], token)
+
+	// The signal is subscribed before the call, as the response could be sent before the call returns
+	match := []dbus.MatchOption{dbus.WithMatchObjectPath(requestPath), dbus.WithMatchInterface(requestInterface), dbus.WithMatchMember("Response")}
+	err := conn.AddMatchSignal(match...)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.RemoveMatchSignal(match...)
+	signals := make(chan *dbus.Signal, 1)
+	conn.Signal(signals)
+	defer conn.RemoveSignal(signals)
+
+	options["handle_token"] = dbus.MakeVariant(token)
+	var handle dbus.ObjectPath
+	err = conn.Object(desktopName, desktopPath).Call(method, 0, append(args, options)...).Store(&handle)
+	if err != nil {
+		var dbusErr dbus.Error
+		if errors.As(err, &dbusErr) && (dbusErr.Name == "org.freedesktop.DBus.Error.ServiceUnknown" || dbusErr.Name == "org.freedesktop.DBus.Error.UnknownMethod") {
+			return 0, nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+		return 0, nil, err
+	}
+
+	for signal := range signals {
+		// Older portals don't use the handle token
+		if signal.Path != handle && signal.Path != requestPath {
+			continue
+		}
+		return parseResponse(signal.Body)
+	}
+	return 0, nil, errors.New("the session bus has been closed")
+}
+
+// requestPath returns the path of the request object that the portal creates for the token
+func requestPath(sender string, token string) dbus.ObjectPath {
+	sender = strings.ReplaceAll(strings.TrimPrefix(sender, ":"), ".", "_")
+	return dbus.ObjectPath(desktopPath + "/request/" + sender + "/" + token)
+}
+
+// parseResponse returns the response code and the results of the Response signal. Errors of the portal are returned
+// as error.
+func parseResponse(body []interface{}) (uint32, map[string]dbus.Variant, error) {
+	if len(body) != 2 {
+		return 0, nil, errors.New("invalid response of the desktop portal")
+	}
+	code, _ := body[0].(uint32)
+	results, _ := body[1].(map[string]dbus.Variant)
+	if code != responseSuccess && code != responseCancelled {
+		return code, nil, errors.New("the request of the desktop portal failed")
+	}
+	return code, results, nil
+}
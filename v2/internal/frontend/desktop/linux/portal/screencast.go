@@ -0,0 +1,213 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	screenCastInterface = "org.freedesktop.portal.ScreenCast"
+	sessionInterface    = "org.freedesktop.portal.Session"
+)
+
+// Source types of screen casts
+const (
+	SourceMonitor uint32 = 1
+	SourceWindow  uint32 = 2
+	SourceVirtual uint32 = 4
+)
+
+// Cursor modes of screen casts
+const (
+	CursorHidden   uint32 = 1
+	CursorEmbedded uint32 = 2
+	CursorMetadata uint32 = 4
+)
+
+// persistUntilRevoked keeps the permission of a screen cast until the user revokes it
+const persistUntilRevoked uint32 = 2
+
+// ErrCancelled is returned when the user cancels the request
+var ErrCancelled = errors.New("the request has been cancelled")
+
+// Screenshot takes a screenshot with the Screenshot portal and returns the path of the image. If interactive is true,
+// the user can choose the area of the screenshot.
+func Screenshot(parentWindow string, interactive bool) (string, error) {
+	code, results, err := request("org.freedesktop.portal.Screenshot.Screenshot", map[string]dbus.Variant{
+		"interactive": dbus.MakeVariant(interactive),
+		"modal":       dbus.MakeVariant(true),
+	}, parentWindow)
+	if err != nil {
+		return "", err
+	}
+	if code == responseCancelled {
+		return "", ErrCancelled
+	}
+	var uri string
+	if value, ok := results["uri"]; ok {
+		_ = value.Store(&uri)
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return "", fmt.Errorf("unsupported uri %s", uri)
+	}
+	return parsed.Path, nil
+}
+
+// ScreenCastOptions are the options of a screen cast
+type ScreenCastOptions struct {
+	// SourceTypes are the combined source types that the user can choose from
+	SourceTypes uint32
+	Multiple    bool
+	CursorMode  uint32
+	// RestoreToken restores the sources of an earlier screen cast without asking the user again. It can only be
+	// used once, the screen cast returns a new one.
+	RestoreToken string
+}
+
+// Stream is a PipeWire stream of a screen cast
+type Stream struct {
+	NodeID     uint32
+	SourceType uint32
+	X, Y       int
+	Width      int
+	Height     int
+}
+
+// ScreenCast is a screen cast session. The portal closes the session with the connection that created it.
+type ScreenCast struct {
+	Streams []Stream
+	// RestoreToken restores the sources of the session for the next screen cast, EG: after a restart
+	RestoreToken string
+
+	conn    *dbus.Conn
+	session dbus.ObjectPath
+}
+
+// StartScreenCast asks the user for the sources of a screen cast, or restores them with the restore token, and starts
+// the session
+func StartScreenCast(parentWindow string, options ScreenCastOptions) (*ScreenCast, error) {
+	conn, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	result := &ScreenCast{conn: conn}
+	err = result.start(parentWindow, options)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *ScreenCast) start(parentWindow string, options ScreenCastOptions) error {
+	code, results, err := requestOn(s.conn, screenCastInterface+".CreateSession", map[string]dbus.Variant{
+		"session_handle_token": dbus.MakeVariant(newToken()),
+	})
+	if err != nil {
+		return err
+	}
+	if code == responseCancelled {
+		return ErrCancelled
+	}
+	// The handle is a string in the results
+	var session string
+	if value, ok := results["session_handle"]; ok {
+		_ = value.Store(&session)
+	}
+	if session == "" {
+		return errors.New("the desktop portal didn't create a session")
+	}
+	s.session = dbus.ObjectPath(session)
+
+	sourceOptions := map[string]dbus.Variant{
+		"types":        dbus.MakeVariant(options.SourceTypes),
+		"multiple":     dbus.MakeVariant(options.Multiple),
+		"persist_mode": dbus.MakeVariant(persistUntilRevoked),
+	}
+	if options.CursorMode != 0 {
+		sourceOptions["cursor_mode"] = dbus.MakeVariant(options.CursorMode)
+	}
+	if options.RestoreToken != "" {
+		sourceOptions["restore_token"] = dbus.MakeVariant(options.RestoreToken)
+	}
+	code, _, err = requestOn(s.conn, screenCastInterface+".SelectSources", sourceOptions, s.session)
+	if err != nil {
+		return err
+	}
+	if code == responseCancelled {
+		return ErrCancelled
+	}
+
+	code, results, err = requestOn(s.conn, screenCastInterface+".Start", map[string]dbus.Variant{}, s.session, parentWindow)
+	if err != nil {
+		return err
+	}
+	if code == responseCancelled {
+		return ErrCancelled
+	}
+	s.Streams, err = parseStreams(results)
+	if err != nil {
+		return err
+	}
+	if value, ok := results["restore_token"]; ok {
+		_ = value.Store(&s.RestoreToken)
+	}
+	return nil
+}
+
+// parseStreams parses the a(ua{sv}) streams of the results of Start
+func parseStreams(results map[string]dbus.Variant) ([]Stream, error) {
+	value, ok := results["streams"]
+	if !ok {
+		return nil, errors.New("the screen cast has no streams")
+	}
+	var streams []struct {
+		NodeID     uint32
+		Properties map[string]dbus.Variant
+	}
+	err := value.Store(&streams)
+	if err != nil {
+		return nil, fmt.Errorf("invalid streams of the screen cast: %w", err)
+	}
+	result := make([]Stream, 0, len(streams))
+	for _, stream := range streams {
+		parsed := Stream{NodeID: stream.NodeID}
+		if value, ok := stream.Properties["source_type"]; ok {
+			_ = value.Store(&parsed.SourceType)
+		}
+		var pair struct{ A, B int32 }
+		if value, ok := stream.Properties["position"]; ok && value.Store(&pair) == nil {
+			parsed.X, parsed.Y = int(pair.A), int(pair.B)
+		}
+		if value, ok := stream.Properties["size"]; ok && value.Store(&pair) == nil {
+			parsed.Width, parsed.Height = int(pair.A), int(pair.B)
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
+
+// OpenPipeWireRemote returns the file descriptor of the PipeWire remote that provides the streams
+func (s *ScreenCast) OpenPipeWireRemote() (*os.File, error) {
+	var fd dbus.UnixFD
+	err := s.conn.Object(desktopName, desktopPath).Call(screenCastInterface+".OpenPipeWireRemote", 0, s.session, map[string]dbus.Variant{}).Store(&fd)
+	if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), "pipewire-"+strings.TrimPrefix(string(s.session), desktopPath+"/session/")), nil
+}
+
+// Close stops the screen cast
+func (s *ScreenCast) Close() error {
+	err := s.conn.Object(desktopName, s.session).Call(sessionInterface+".Close", 0).Err
+	if closeErr := s.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package portal
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestParseStreams(t *testing.T) {
+	// The streams as they are decoded from the message of the portal
+	streams := [][]interface{}{
+		{uint32(42), map[string]dbus.Variant{
+			"source_type": dbus.MakeVariant(SourceMonitor),
+			"position":    dbus.MakeVariant([]interface{}{int32(1920), int32(0)}),
+			"size":        dbus.MakeVariant([]interface{}{int32(2560), int32(1440)}),
+		}},
+		{uint32(43), map[string]dbus.Variant{}},
+	}
+	results := map[string]dbus.Variant{
+		"streams": dbus.MakeVariantWithSignature(streams, dbus.ParseSignatureMust("a(ua{sv})")),
+	}
+	parsed, err := parseStreams(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("unexpected streams %v", parsed)
+	}
+	if parsed[0] != (Stream{NodeID: 42, SourceType: SourceMonitor, X: 1920, Width: 2560, Height: 1440}) {
+		t.Errorf("unexpected stream %v", parsed[0])
+	}
+	if parsed[1] != (Stream{NodeID: 43}) {
+		t.Errorf("unexpected stream %v", parsed[1])
+	}
+
+	if _, err := parseStreams(map[string]dbus.Variant{}); err == nil {
+		t.Error("expected an error without streams")
+	}
+}
@@ -0,0 +1,39 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "window.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// setupProxy routes the requests of the webview through the proxy of the options. WebKitGTK proxies the loopback
+// addresses unless they are ignored.
+func setupProxy(webview unsafe.Pointer, proxyOptions *options.Proxy) {
+	proxy, _ := frontend.ParseProxy(proxyOptions)
+	if proxy == nil {
+		return
+	}
+	uri := C.CString(proxy.URL())
+	defer C.free(unsafe.Pointer(uri))
+	bypass := proxy.BypassWithLoopback()
+	ignoreHosts := make([]*C.char, len(bypass)+1)
+	for index, host := range bypass {
+		ignoreHosts[index] = C.CString(host)
+		defer C.free(unsafe.Pointer(ignoreHosts[index]))
+	}
+	C.SetProxy(webview, uri, &ignoreHosts[0])
+}
@@ -0,0 +1,38 @@
+//go:build linux
+// +build linux
+
+package linux
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/browser"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
+)
+
+// Share composes an email with the email portal, as Linux desktops have no share sheet. The text and the URL are the
+// body of the email and the files are attached to it. A mailto URL is opened if the portal is not available and
+// there are no files. The anchor is not used.
+func (f *Frontend) Share(options frontend.ShareOptions) error {
+	subject := options.Title
+	if subject == "" {
+		subject = f.frontendOptions.Title
+	}
+	body := strings.TrimSpace(options.Text + "\n\n" + options.URL)
+	err := portal.ComposeEmail("", portal.EmailOptions{
+		Subject:     subject,
+		Body:        body,
+		Attachments: options.Files,
+	})
+	if !errors.Is(err, portal.ErrUnavailable) {
+		return err
+	}
+	if len(options.Files) > 0 {
+		return errors.New("sharing files requires the email portal of xdg-desktop-portal")
+	}
+	query := url.Values{"subject": {subject}, "body": {body}}
+	return browser.OpenURL("mailto:?" + strings.ReplaceAll(query.Encode(), "+", "%20"))
+}
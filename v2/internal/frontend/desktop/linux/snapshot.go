@@ -0,0 +1,57 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "window.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// The snapshot sends its result to this channel
+var (
+	snapshotResult = make(chan bool)
+	snapshotLock   sync.Mutex
+)
+
+// WindowCaptureSnapshot captures the visible content of the webview with webkit_web_view_get_snapshot
+func (f *Frontend) WindowCaptureSnapshot() ([]byte, error) {
+	path, err := frontend.NewSnapshotFile()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotLock.Lock()
+	defer snapshotLock.Unlock()
+
+	_path := C.CString(path)
+	defer C.free(unsafe.Pointer(_path))
+	invokeOnMainThread(func() {
+		C.CaptureSnapshot(f.mainWindow.webview, _path)
+	})
+
+	if !<-snapshotResult {
+		_ = os.Remove(path)
+		return nil, errors.New("capturing the snapshot failed")
+	}
+	return frontend.ReadSnapshotFile(path)
+}
+
+//export processSnapshotResult
+func processSnapshotResult(success C.int) {
+	snapshotResult <- success != 0
+}
@@ -0,0 +1,62 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "gtk/gtk.h"
+#include <stdlib.h>
+
+static void ResetTheme() {
+	GtkSettings *settings = gtk_settings_get_default();
+	gtk_settings_reset_property(settings, "gtk-application-prefer-dark-theme");
+	gtk_settings_reset_property(settings, "gtk-theme-name");
+}
+
+static void SetPreferDarkTheme(gboolean dark) {
+	g_object_set(gtk_settings_get_default(), "gtk-application-prefer-dark-theme", dark, NULL);
+}
+
+static gchar* GetThemeName() {
+	gchar *name = NULL;
+	g_object_get(gtk_settings_get_default(), "gtk-theme-name", &name, NULL);
+	return name;
+}
+
+static void SetThemeName(const gchar *name) {
+	g_object_set(gtk_settings_get_default(), "gtk-theme-name", name, NULL);
+}
+*/
+import "C"
+import (
+	"strings"
+	"unsafe"
+)
+
+// setTheme forces the dark or light variant of the GTK theme, or follows the desktop again if system is true.
+// WebKitGTK derives prefers-color-scheme from the GTK theme, so the webview reflects the variant. The settings are
+// application wide, which is fine as there is only one window.
+func (f *Frontend) setTheme(dark bool, system bool) {
+	invokeOnMainThread(func() {
+		// Resetting restores the settings of the desktop, which keep changing with it
+		C.ResetTheme()
+		if system {
+			return
+		}
+		C.SetPreferDarkTheme(gtkBool(dark))
+
+		// Themes like Adwaita-dark don't have a light variant, it is the theme without the suffix
+		cName := C.GetThemeName()
+		name := C.GoString(cName)
+		C.g_free(C.gpointer(unsafe.Pointer(cName)))
+		if !dark && strings.HasSuffix(strings.ToLower(name), "-dark") {
+			lightName := C.CString(name[:len(name)-len("-dark")])
+			C.SetThemeName(lightName)
+			C.free(unsafe.Pointer(lightName))
+		}
+	})
+}
@@ -0,0 +1,79 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo linux pkg-config: gtk+-3.0
+#cgo !webkit2_41 pkg-config: webkit2gtk-4.0
+#cgo webkit2_41 pkg-config: webkit2gtk-4.1
+
+#include "window.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/pem"
+	"net/url"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+// tlsPolicy is used by the signal handlers of the webview to trust server certificates and provide client
+// certificates
+var tlsPolicy = frontend.NewTLSPolicy(nil)
+
+// tlsLogger is the logger of the frontend, set together with the tlsPolicy
+var tlsLogger *logger.Logger
+
+// setupTLS connects the TLS signals of the webview and allows the trusted certificates up front, so they are also
+// accepted for the subresources of a page. WebKitGTK only reports the certificate errors of pages.
+func setupTLS(webview unsafe.Pointer) {
+	if !tlsPolicy.Enabled() {
+		return
+	}
+	C.SetupTLS(webview)
+	for host, certificates := range tlsPolicy.TrustedCertificates() {
+		chost := C.CString(host)
+		for _, certificate := range certificates {
+			cpem := C.CString(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})))
+			C.AllowCertificate(webview, cpem, chost)
+			C.free(unsafe.Pointer(cpem))
+		}
+		C.free(unsafe.Pointer(chost))
+	}
+}
+
+// acceptServerCertificate returns the host to allow the certificate for, or NULL if it isn't accepted
+//
+//export acceptServerCertificate
+func acceptServerCertificate(uri *C.char, chainPEM *C.char) *C.char {
+	u, err := url.Parse(C.GoString(uri))
+	if err != nil {
+		return nil
+	}
+	chain, err := frontend.ParseCertificatesPEM([]byte(C.GoString(chainPEM)))
+	if err != nil || !tlsPolicy.AcceptServer(u.Hostname(), chain) {
+		return nil
+	}
+	return C.CString(u.Hostname())
+}
+
+// clientCertificatePEM returns the PEM of the client certificate and its key, or NULL for the default handling
+//
+//export clientCertificatePEM
+func clientCertificatePEM(host *C.char, port C.int) *C.char {
+	certificate := tlsPolicy.ClientCertificate(C.GoString(host), int(port), nil)
+	if certificate == nil {
+		return nil
+	}
+	data, _, err := frontend.ClientCertificatePEM(certificate)
+	if err != nil {
+		tlsLogger.Error("Unable to use the client certificate for %s: %v", C.GoString(host), err)
+		return nil
+	}
+	return C.CString(string(data))
+}
@@ -420,6 +420,102 @@ static void webviewLoadChanged(WebKitWebView *web_view, WebKitLoadEvent load_eve
 }
 
 extern void processURLRequest(void *request);
+extern int allowNavigation(char *url);
+extern int decideNavigation(char *url, int userInitiated, char **load);
+extern int decideNewWindow(char *url, int userInitiated, char **load);
+
+typedef struct LoadURIArgs
+{
+    WebKitWebView *webview;
+    char *uri;
+} LoadURIArgs;
+
+static gboolean loadURI(gpointer data)
+{
+    LoadURIArgs *args = (LoadURIArgs *)data;
+    webkit_web_view_load_uri(args->webview, args->uri);
+    free(args->uri);
+    free(args);
+    return G_SOURCE_REMOVE;
+}
+
+// loadDecidedURI loads the URI of a decision of the application, which is freed, once the signal has been handled
+static void loadDecidedURI(WebKitWebView *webview, char *uri)
+{
+    if (uri == NULL)
+    {
+        return;
+    }
+    LoadURIArgs *args = malloc(sizeof(LoadURIArgs));
+    args->webview = webview;
+    args->uri = uri;
+    g_idle_add(loadURI, args);
+}
+
+// This is called before the main frame or a frame navigates
+static gboolean decidePolicy(WebKitWebView *webview, WebKitPolicyDecision *decision, WebKitPolicyDecisionType type, gpointer data)
+{
+    if (type != WEBKIT_POLICY_DECISION_TYPE_NAVIGATION_ACTION)
+    {
+        return FALSE;
+    }
+    WebKitNavigationAction *action = webkit_navigation_policy_decision_get_navigation_action(WEBKIT_NAVIGATION_POLICY_DECISION(decision));
+    WebKitURIRequest *request = webkit_navigation_action_get_request(action);
+    char *uri = (char *)webkit_uri_request_get_uri(request);
+    if (!allowNavigation(uri))
+    {
+        webkit_policy_decision_ignore(decision);
+        return TRUE;
+    }
+    char *load = NULL;
+    if (!decideNavigation(uri, webkit_navigation_action_is_user_gesture(action), &load))
+    {
+        webkit_policy_decision_ignore(decision);
+        loadDecidedURI(webview, load);
+        return TRUE;
+    }
+    return FALSE;
+}
+
+// This is called when the page opens a new window, which is never created
+static GtkWidget *createWebView(WebKitWebView *webview, WebKitNavigationAction *action, gpointer data)
+{
+    WebKitURIRequest *request = webkit_navigation_action_get_request(action);
+    char *load = NULL;
+    if (decideNewWindow((char *)webkit_uri_request_get_uri(request), webkit_navigation_action_is_user_gesture(action), &load))
+    {
+        loadDecidedURI(webview, load);
+    }
+    return NULL;
+}
+
+// This is called when the web process of the webview terminated
+static void webProcessTerminated(WebKitWebView *webview, WebKitWebProcessTerminationReason reason, gpointer data)
+{
+#if WEBKIT_MAJOR_VERSION >= 2 && WEBKIT_MINOR_VERSION >= 34
+    if (reason == WEBKIT_WEB_PROCESS_TERMINATED_BY_API)
+    {
+        // We terminated the process ourselves to recycle the content
+        return;
+    }
+#endif
+    if (reason == WEBKIT_WEB_PROCESS_EXCEEDED_MEMORY_LIMIT)
+    {
+        processMessage("wails:memorylimit");
+        return;
+    }
+    processMessage("wails:contentcrashed");
+}
+
+static void webProcessResponsiveChanged(WebKitWebView *webview, GParamSpec *pspec, gpointer data)
+{
+#if WEBKIT_MAJOR_VERSION >= 2 && WEBKIT_MINOR_VERSION >= 34
+    if (!webkit_web_view_get_is_web_process_responsive(webview))
+    {
+        processMessage("wails:unresponsive");
+    }
+#endif
+}
 
 // This is called when the close button on the window is pressed
 gboolean close_button_pressed(GtkWidget *widget, GdkEvent *event, void *data)
@@ -497,13 +593,436 @@ static gboolean onDragDrop(GtkWidget* self, GdkDragContext* context, gint x, gin
 }
 
 // WebView
-GtkWidget *SetupWebview(void *contentManager, GtkWindow *window, int hideWindowOnClose, int gpuPolicy, int disableWebViewDragAndDrop, int enableDragAndDrop)
+extern char *acceptServerCertificate(char *uri, char *chainPEM);
+extern char *clientCertificatePEM(char *host, int port);
+extern int authenticationCredentials(char *host, int port, char *realm, char *scheme, int isProxy, int isRetry, char **username, char **password);
+
+// loadFailedWithTLSErrors asks the application to trust the certificate and loads the page again if it does
+static gboolean loadFailedWithTLSErrors(WebKitWebView *webview, gchar *failing_uri, GTlsCertificate *certificate, GTlsCertificateFlags errors, gpointer data)
+{
+    GString *chain = g_string_new(NULL);
+    for (GTlsCertificate *c = certificate; c != NULL; c = g_tls_certificate_get_issuer(c))
+    {
+        gchar *pem = NULL;
+        g_object_get(c, "certificate-pem", &pem, NULL);
+        if (pem != NULL)
+        {
+            g_string_append(chain, pem);
+            g_string_append_c(chain, '\n');
+            g_free(pem);
+        }
+    }
+    char *host = acceptServerCertificate(failing_uri, chain->str);
+    g_string_free(chain, TRUE);
+    if (host == NULL)
+    {
+        return FALSE;
+    }
+    webkit_web_context_allow_tls_certificate_for_host(webkit_web_view_get_context(webview), certificate, host);
+    free(host);
+    webkit_web_view_load_uri(webview, failing_uri);
+    return TRUE;
+}
+
+#if WEBKIT_CHECK_VERSION(2, 34, 0)
+// authenticateClientCertificate answers the request with the client certificate of the application
+static gboolean authenticateClientCertificate(WebKitAuthenticationRequest *request)
+{
+    char *pem = clientCertificatePEM((char *)webkit_authentication_request_get_host(request), webkit_authentication_request_get_port(request));
+    if (pem == NULL)
+    {
+        return FALSE;
+    }
+    GError *error = NULL;
+    GTlsCertificate *certificate = g_tls_certificate_new_from_pem(pem, -1, &error);
+    free(pem);
+    if (certificate == NULL)
+    {
+        g_printerr("Unable to load the client certificate: %s\n", error->message);
+        g_error_free(error);
+        return FALSE;
+    }
+    WebKitCredential *credential = webkit_credential_new_for_certificate(certificate, WEBKIT_CREDENTIAL_PERSISTENCE_FOR_SESSION);
+    webkit_authentication_request_authenticate(request, credential);
+    webkit_credential_free(credential);
+    g_object_unref(certificate);
+    return TRUE;
+}
+#endif
+
+static const char *authenticationSchemeName(WebKitAuthenticationScheme scheme)
+{
+    switch (scheme)
+    {
+    case WEBKIT_AUTHENTICATION_SCHEME_HTTP_BASIC:
+        return "basic";
+    case WEBKIT_AUTHENTICATION_SCHEME_HTTP_DIGEST:
+        return "digest";
+    case WEBKIT_AUTHENTICATION_SCHEME_NTLM:
+        return "ntlm";
+    case WEBKIT_AUTHENTICATION_SCHEME_NEGOTIATE:
+        return "negotiate";
+    default:
+        return NULL;
+    }
+}
+
+static gboolean authenticate(WebKitWebView *webview, WebKitAuthenticationRequest *request, gpointer data)
+{
+    WebKitAuthenticationScheme scheme = webkit_authentication_request_get_scheme(request);
+#if WEBKIT_CHECK_VERSION(2, 34, 0)
+    if (scheme == WEBKIT_AUTHENTICATION_SCHEME_CLIENT_CERTIFICATE_REQUESTED)
+    {
+        return authenticateClientCertificate(request);
+    }
+#endif
+    const char *name = authenticationSchemeName(scheme);
+    if (name == NULL)
+    {
+        return FALSE;
+    }
+    char *username = NULL;
+    char *password = NULL;
+    int answered = authenticationCredentials(
+        (char *)webkit_authentication_request_get_host(request),
+        webkit_authentication_request_get_port(request),
+        (char *)webkit_authentication_request_get_realm(request),
+        (char *)name,
+        webkit_authentication_request_is_for_proxy(request),
+        webkit_authentication_request_is_retry(request),
+        &username,
+        &password);
+    if (!answered)
+    {
+        return FALSE;
+    }
+    WebKitCredential *credential = webkit_credential_new(username, password, WEBKIT_CREDENTIAL_PERSISTENCE_FOR_SESSION);
+    free(username);
+    free(password);
+    webkit_authentication_request_authenticate(request, credential);
+    webkit_credential_free(credential);
+    return TRUE;
+}
+
+void SetupTLS(void *webview)
+{
+    g_signal_connect(G_OBJECT(webview), "load-failed-with-tls-errors", G_CALLBACK(loadFailedWithTLSErrors), NULL);
+}
+
+void SetupAuthentication(void *webview)
+{
+    g_signal_connect(G_OBJECT(webview), "authenticate", G_CALLBACK(authenticate), NULL);
+}
+
+extern int decidePermission(char *uri, char *kind, char *otherKind);
+
+// permissionRequest answers the request with the decision of the application: 1 allows and 2 denies it, 0 leaves it
+// to WebKit, which denies it. The camera and the microphone of a user media request are decided together.
+static gboolean permissionRequest(WebKitWebView *webview, WebKitPermissionRequest *request, gpointer data)
+{
+    char *kinds[2] = {NULL, NULL};
+    int count = 0;
+    if (WEBKIT_IS_USER_MEDIA_PERMISSION_REQUEST(request))
+    {
+        WebKitUserMediaPermissionRequest *media = WEBKIT_USER_MEDIA_PERMISSION_REQUEST(request);
+        if (webkit_user_media_permission_is_for_video_device(media))
+        {
+            kinds[count++] = "camera";
+        }
+        if (webkit_user_media_permission_is_for_audio_device(media))
+        {
+            kinds[count++] = "microphone";
+        }
+    }
+    else if (WEBKIT_IS_GEOLOCATION_PERMISSION_REQUEST(request))
+    {
+        kinds[count++] = "geolocation";
+    }
+    else if (WEBKIT_IS_NOTIFICATION_PERMISSION_REQUEST(request))
+    {
+        kinds[count++] = "notifications";
+    }
+#if WEBKIT_CHECK_VERSION(2, 42, 0)
+    else if (WEBKIT_IS_CLIPBOARD_PERMISSION_REQUEST(request))
+    {
+        kinds[count++] = "clipboardRead";
+    }
+#endif
+    if (count == 0)
+    {
+        kinds[count++] = "other";
+    }
+
+    const char *uri = webkit_web_view_get_uri(webview);
+    switch (decidePermission((char *)(uri != NULL ? uri : ""), kinds[0], kinds[1]))
+    {
+    case 1:
+        webkit_permission_request_allow(request);
+        return TRUE;
+    case 2:
+        webkit_permission_request_deny(request);
+        return TRUE;
+    default:
+        return FALSE;
+    }
+}
+
+// SetupPermissions lets the application decide the permission requests. getUserMedia is only available with media
+// streams enabled.
+void SetupPermissions(void *webview)
+{
+    WebKitSettings *settings = webkit_web_view_get_settings(WEBKIT_WEB_VIEW(webview));
+    webkit_settings_set_enable_media_stream(settings, TRUE);
+    g_signal_connect(G_OBJECT(webview), "permission-request", G_CALLBACK(permissionRequest), NULL);
+}
+
+void AllowCertificate(void *webview, char *pem, char *host)
+{
+    GTlsCertificate *certificate = g_tls_certificate_new_from_pem(pem, -1, NULL);
+    if (certificate == NULL)
+    {
+        return;
+    }
+    webkit_web_context_allow_tls_certificate_for_host(webkit_web_view_get_context(WEBKIT_WEB_VIEW(webview)), certificate, host);
+    g_object_unref(certificate);
+}
+
+// SetProxy routes the requests of the web context of the webview through the proxy, except for the ignored hosts
+void SetProxy(void *webview, char *uri, char **ignoreHosts)
+{
+    WebKitNetworkProxySettings *settings = webkit_network_proxy_settings_new(uri, (const char *const *)ignoreHosts);
+    webkit_web_context_set_network_proxy_settings(webkit_web_view_get_context(WEBKIT_WEB_VIEW(webview)), WEBKIT_NETWORK_PROXY_MODE_CUSTOM, settings);
+    webkit_network_proxy_settings_free(settings);
+}
+
+extern char *decideDownload(char *url, char *suggestedFilename, char *mimeType, char **id, int *prompt);
+extern void beginDownload(char *id, char *path, void *download);
+extern void downloadProgress(char *id, long long received, long long total);
+extern void downloadFinished(char *id, char *error, int cancelled);
+
+// askDownloadPath asks the user where to save the download with the path as the default. It returns NULL if the user
+// cancelled, the result is freed by the caller.
+static char *askDownloadPath(GtkWindow *window, char *path)
+{
+    GtkWidget *dialog = gtk_file_chooser_dialog_new(NULL, window, GTK_FILE_CHOOSER_ACTION_SAVE,
+                                                    "_Cancel", GTK_RESPONSE_CANCEL,
+                                                    "_Save", GTK_RESPONSE_ACCEPT,
+                                                    NULL);
+    GtkFileChooser *fc = GTK_FILE_CHOOSER(dialog);
+    gtk_file_chooser_set_do_overwrite_confirmation(fc, TRUE);
+    gtk_file_chooser_set_create_folders(fc, TRUE);
+    gchar *directory = g_path_get_dirname(path);
+    gchar *name = g_path_get_basename(path);
+    gtk_file_chooser_set_current_folder(fc, directory);
+    gtk_file_chooser_set_current_name(fc, name);
+    g_free(directory);
+    g_free(name);
+
+    char *result = NULL;
+    if (gtk_dialog_run(GTK_DIALOG(dialog)) == GTK_RESPONSE_ACCEPT)
+    {
+        gchar *filename = gtk_file_chooser_get_filename(fc);
+        result = strdup(filename);
+        g_free(filename);
+    }
+    gtk_widget_destroy(dialog);
+    return result;
+}
+
+static long long downloadTotal(WebKitDownload *download)
+{
+    WebKitURIResponse *response = webkit_download_get_response(download);
+    guint64 length = response != NULL ? webkit_uri_response_get_content_length(response) : 0;
+    return length > 0 ? (long long)length : -1;
+}
+
+// This is called once the response of the download has been received. The destination is decided synchronously, as
+// WebKitGTK only supports deciding it later since 2.40.
+static gboolean downloadDecideDestination(WebKitDownload *download, gchar *suggestedFilename, gpointer data)
+{
+    WebKitURIResponse *response = webkit_download_get_response(download);
+    const gchar *mimeType = response != NULL ? webkit_uri_response_get_mime_type(response) : NULL;
+    const gchar *url = webkit_uri_request_get_uri(webkit_download_get_request(download));
+    char *id = NULL;
+    int prompt = 0;
+    char *path = decideDownload((char *)url, suggestedFilename, (char *)(mimeType != NULL ? mimeType : ""), &id, &prompt);
+    if (path == NULL)
+    {
+        webkit_download_cancel(download);
+        return TRUE;
+    }
+    g_object_set_data_full(G_OBJECT(download), "wails-download", id, free);
+    if (prompt)
+    {
+        char *chosen = askDownloadPath((GtkWindow *)data, path);
+        free(path);
+        path = chosen;
+        if (path == NULL)
+        {
+            webkit_download_cancel(download);
+            return TRUE;
+        }
+    }
+    gchar *uri = g_filename_to_uri(path, NULL, NULL);
+    webkit_download_set_allow_overwrite(download, TRUE);
+    webkit_download_set_destination(download, uri);
+    g_free(uri);
+    beginDownload(id, path, download);
+    free(path);
+    return TRUE;
+}
+
+static void downloadReceivedData(WebKitDownload *download, guint64 length, gpointer data)
+{
+    char *id = g_object_get_data(G_OBJECT(download), "wails-download");
+    if (id != NULL)
+    {
+        downloadProgress(id, (long long)webkit_download_get_received_data_length(download), downloadTotal(download));
+    }
+}
+
+static void downloadFailed(WebKitDownload *download, GError *error, gpointer data)
+{
+    if (g_error_matches(error, WEBKIT_DOWNLOAD_ERROR, WEBKIT_DOWNLOAD_ERROR_CANCELLED_BY_USER))
+    {
+        g_object_set_data(G_OBJECT(download), "wails-cancelled", GINT_TO_POINTER(1));
+        return;
+    }
+    g_object_set_data_full(G_OBJECT(download), "wails-error", g_strdup(error->message), g_free);
+}
+
+// "finished" is also emitted after "failed"
+static void downloadFinishedSignal(WebKitDownload *download, gpointer data)
+{
+    char *id = g_object_get_data(G_OBJECT(download), "wails-download");
+    if (id != NULL)
+    {
+        downloadFinished(id, g_object_get_data(G_OBJECT(download), "wails-error"), g_object_get_data(G_OBJECT(download), "wails-cancelled") != NULL);
+    }
+}
+
+static void downloadStarted(WebKitWebContext *context, WebKitDownload *download, gpointer data)
+{
+    g_signal_connect(G_OBJECT(download), "decide-destination", G_CALLBACK(downloadDecideDestination), data);
+    g_signal_connect(G_OBJECT(download), "received-data", G_CALLBACK(downloadReceivedData), NULL);
+    g_signal_connect(G_OBJECT(download), "failed", G_CALLBACK(downloadFailed), NULL);
+    g_signal_connect(G_OBJECT(download), "finished", G_CALLBACK(downloadFinishedSignal), NULL);
+}
+
+void SetupDownloads(void *webview, GtkWindow *window)
+{
+    g_signal_connect(G_OBJECT(webkit_web_view_get_context(WEBKIT_WEB_VIEW(webview))), "download-started", G_CALLBACK(downloadStarted), window);
+}
+
+void CancelDownload(void *download)
+{
+    webkit_download_cancel(WEBKIT_DOWNLOAD(download));
+}
+
+extern void processPrintToPDFResult(int success);
+
+static void printToPDFFailed(WebKitPrintOperation *operation, GError *error, gpointer data)
+{
+    g_object_set_data(G_OBJECT(operation), "wails-failed", GINT_TO_POINTER(1));
+}
+
+// "finished" is also emitted after "failed"
+static void printToPDFFinished(WebKitPrintOperation *operation, gpointer data)
+{
+    processPrintToPDFResult(g_object_get_data(G_OBJECT(operation), "wails-failed") == NULL);
+    g_object_unref(operation);
+}
+
+// PrintToPDF prints the page to the file of the uri with the "Print to File" printer of GTK. The sizes are the portrait
+// size of the paper and the margins in inches.
+void PrintToPDF(void *webview, char *uri, double width, double height, double top, double bottom, double left, double right, int landscape, double scale)
 {
-    GtkWidget *webview = webkit_web_view_new_with_user_content_manager((WebKitUserContentManager *)contentManager);
+    GtkPrintSettings *settings = gtk_print_settings_new();
+    gtk_print_settings_set_printer(settings, "Print to File");
+    gtk_print_settings_set(settings, GTK_PRINT_SETTINGS_OUTPUT_FILE_FORMAT, "pdf");
+    gtk_print_settings_set(settings, GTK_PRINT_SETTINGS_OUTPUT_URI, uri);
+    gtk_print_settings_set_scale(settings, scale * 100);
+
+    GtkPaperSize *paperSize = gtk_paper_size_new_custom("wails", "Wails", width, height, GTK_UNIT_INCH);
+    GtkPageSetup *pageSetup = gtk_page_setup_new();
+    gtk_page_setup_set_paper_size(pageSetup, paperSize);
+    gtk_page_setup_set_orientation(pageSetup, landscape ? GTK_PAGE_ORIENTATION_LANDSCAPE : GTK_PAGE_ORIENTATION_PORTRAIT);
+    gtk_page_setup_set_top_margin(pageSetup, top, GTK_UNIT_INCH);
+    gtk_page_setup_set_bottom_margin(pageSetup, bottom, GTK_UNIT_INCH);
+    gtk_page_setup_set_left_margin(pageSetup, left, GTK_UNIT_INCH);
+    gtk_page_setup_set_right_margin(pageSetup, right, GTK_UNIT_INCH);
+
+    WebKitPrintOperation *operation = webkit_print_operation_new(WEBKIT_WEB_VIEW(webview));
+    webkit_print_operation_set_print_settings(operation, settings);
+    webkit_print_operation_set_page_setup(operation, pageSetup);
+    g_signal_connect(G_OBJECT(operation), "failed", G_CALLBACK(printToPDFFailed), NULL);
+    g_signal_connect(G_OBJECT(operation), "finished", G_CALLBACK(printToPDFFinished), NULL);
+    webkit_print_operation_print(operation);
+
+    g_object_unref(settings);
+    g_object_unref(pageSetup);
+    gtk_paper_size_free(paperSize);
+}
+
+extern void processSnapshotResult(int success);
+
+static void snapshotFinished(GObject *object, GAsyncResult *result, gpointer data)
+{
+    GError *error = NULL;
+    cairo_surface_t *surface = webkit_web_view_get_snapshot_finish(WEBKIT_WEB_VIEW(object), result, &error);
+    if (surface == NULL)
+    {
+        g_error_free(error);
+        processSnapshotResult(0);
+        return;
+    }
+    cairo_status_t status = cairo_surface_write_to_png(surface, (char *)data);
+    cairo_surface_destroy(surface);
+    processSnapshotResult(status == CAIRO_STATUS_SUCCESS);
+}
+
+// CaptureSnapshot writes the visible content of the webview to the PNG file of the path, which has to be valid until
+// processSnapshotResult is called
+void CaptureSnapshot(void *webview, char *path)
+{
+    webkit_web_view_get_snapshot(WEBKIT_WEB_VIEW(webview), WEBKIT_SNAPSHOT_REGION_VISIBLE, WEBKIT_SNAPSHOT_OPTIONS_NONE, NULL, snapshotFinished, path);
+}
+
+GtkWidget *SetupWebview(void *contentManager, void *webContext, GtkWindow *window, int hideWindowOnClose, int gpuPolicy, int disableWebViewDragAndDrop, int enableDragAndDrop, int autoplayAllowed)
+{
+    GtkWidget *webview;
+#if WEBKIT_CHECK_VERSION(2, 30, 0)
+    // The website policies are a construct-only property of the webview
+    if (autoplayAllowed)
+    {
+        WebKitWebsitePolicies *policies = webkit_website_policies_new_with_policies("autoplay", WEBKIT_AUTOPLAY_ALLOW, NULL);
+        webview = GTK_WIDGET(g_object_new(WEBKIT_TYPE_WEB_VIEW,
+                                          "web-context", webContext == NULL ? webkit_web_context_get_default() : webContext,
+                                          "user-content-manager", contentManager,
+                                          "website-policies", policies,
+                                          NULL));
+        g_object_unref(policies);
+    }
+    else
+#endif
+    if (webContext == NULL)
+    {
+        webview = webkit_web_view_new_with_user_content_manager((WebKitUserContentManager *)contentManager);
+    }
+    else
+    {
+        webview = GTK_WIDGET(g_object_new(WEBKIT_TYPE_WEB_VIEW, "web-context", webContext, "user-content-manager", contentManager, NULL));
+    }
     // gtk_container_add(GTK_CONTAINER(window), webview);
-    WebKitWebContext *context = webkit_web_context_get_default();
+    WebKitWebContext *context = webkit_web_view_get_context(WEBKIT_WEB_VIEW(webview));
     webkit_web_context_register_uri_scheme(context, "wails", (WebKitURISchemeRequestCallback)processURLRequest, NULL, NULL);
     g_signal_connect(G_OBJECT(webview), "load-changed", G_CALLBACK(webviewLoadChanged), NULL);
+    g_signal_connect(G_OBJECT(webview), "web-process-terminated", G_CALLBACK(webProcessTerminated), NULL);
+#if WEBKIT_MAJOR_VERSION >= 2 && WEBKIT_MINOR_VERSION >= 34
+    g_signal_connect(G_OBJECT(webview), "notify::is-web-process-responsive", G_CALLBACK(webProcessResponsiveChanged), NULL);
+#endif
+    g_signal_connect(G_OBJECT(webview), "decide-policy", G_CALLBACK(decidePolicy), NULL);
+    g_signal_connect(G_OBJECT(webview), "create", G_CALLBACK(createWebView), NULL);
 
     if(disableWebViewDragAndDrop)
     {
@@ -545,6 +1064,34 @@ GtkWidget *SetupWebview(void *contentManager, GtkWindow *window, int hideWindowO
     return webview;
 }
 
+// NewWebContext creates the web context of a profile, which stores its data in the directory or only in memory
+void *NewWebContext(char *directory, int inMemory)
+{
+    WebKitWebsiteDataManager *manager;
+    if (inMemory)
+    {
+        manager = webkit_website_data_manager_new_ephemeral();
+    }
+    else
+    {
+        char *data = g_build_filename(directory, "data", NULL);
+        char *cache = g_build_filename(directory, "cache", NULL);
+        manager = webkit_website_data_manager_new("base-data-directory", data, "base-cache-directory", cache, NULL);
+        g_free(data);
+        g_free(cache);
+    }
+    WebKitWebContext *context = webkit_web_context_new_with_website_data_manager(manager);
+    g_object_unref(manager);
+    if (!inMemory)
+    {
+        // WebKitGTK keeps the cookies in memory unless they have a persistent storage
+        char *cookies = g_build_filename(directory, "cookies.sqlite", NULL);
+        webkit_cookie_manager_set_persistent_storage(webkit_web_context_get_cookie_manager(context), cookies, WEBKIT_COOKIE_PERSISTENT_STORAGE_SQLITE);
+        g_free(cookies);
+    }
+    return context;
+}
+
 void DevtoolsEnabled(void *webview, int enabled, bool showInspector)
 {
     WebKitSettings *settings = webkit_web_view_get_settings(WEBKIT_WEB_VIEW(webview));
@@ -562,6 +1109,56 @@ void LoadIndex(void *webview, char *url)
     webkit_web_view_load_uri(WEBKIT_WEB_VIEW(webview), url);
 }
 
+void SetZoom(void *webview, double factor)
+{
+    webkit_web_view_set_zoom_level(WEBKIT_WEB_VIEW(webview), factor);
+}
+
+void SetAudioMuted(void *webview, int muted)
+{
+#if WEBKIT_CHECK_VERSION(2, 30, 0)
+    webkit_web_view_set_is_muted(WEBKIT_WEB_VIEW(webview), muted);
+#endif
+}
+
+void AddUserScript(void *contentManager, char *script)
+{
+    WebKitUserScript *userScript = webkit_user_script_new(script, WEBKIT_USER_CONTENT_INJECT_TOP_FRAME, WEBKIT_USER_SCRIPT_INJECT_AT_DOCUMENT_START, NULL, NULL);
+    webkit_user_content_manager_add_script(WEBKIT_USER_CONTENT_MANAGER(contentManager), userScript);
+    webkit_user_script_unref(userScript);
+}
+
+void ClearUserContent(void *contentManager)
+{
+    webkit_user_content_manager_remove_all_scripts(WEBKIT_USER_CONTENT_MANAGER(contentManager));
+    webkit_user_content_manager_remove_all_style_sheets(WEBKIT_USER_CONTENT_MANAGER(contentManager));
+}
+
+void AddUserContent(void *contentManager, char *script, char *stylesheet, int allFrames, int atDocumentEnd)
+{
+    WebKitUserContentInjectedFrames frames = allFrames ? WEBKIT_USER_CONTENT_INJECT_ALL_FRAMES : WEBKIT_USER_CONTENT_INJECT_TOP_FRAME;
+    if (stylesheet != NULL)
+    {
+        WebKitUserStyleSheet *userStyleSheet = webkit_user_style_sheet_new(stylesheet, frames, WEBKIT_USER_STYLE_LEVEL_USER, NULL, NULL);
+        webkit_user_content_manager_add_style_sheet(WEBKIT_USER_CONTENT_MANAGER(contentManager), userStyleSheet);
+        webkit_user_style_sheet_unref(userStyleSheet);
+        return;
+    }
+    WebKitUserScriptInjectionTime injectionTime = atDocumentEnd ? WEBKIT_USER_SCRIPT_INJECT_AT_DOCUMENT_END : WEBKIT_USER_SCRIPT_INJECT_AT_DOCUMENT_START;
+    WebKitUserScript *userScript = webkit_user_script_new(script, frames, injectionTime, NULL, NULL);
+    webkit_user_content_manager_add_script(WEBKIT_USER_CONTENT_MANAGER(contentManager), userScript);
+    webkit_user_script_unref(userScript);
+}
+
+void RecycleWebview(void *webview, char *url)
+{
+#if WEBKIT_MAJOR_VERSION >= 2 && WEBKIT_MINOR_VERSION >= 34
+    // Start with a fresh web process, this releases all the memory of the current page
+    webkit_web_view_terminate_web_process(WEBKIT_WEB_VIEW(webview));
+#endif
+    webkit_web_view_load_uri(WEBKIT_WEB_VIEW(webview), url);
+}
+
 static gboolean startDrag(gpointer data)
 {
     DragOptions *options = (DragOptions *)data;
@@ -827,4 +1424,256 @@ void InstallF12Hotkey(void *window)
     gtk_window_add_accel_group(GTK_WINDOW(window), accel_group);
     GClosure *closure = g_cclosure_new(G_CALLBACK(sendShowInspectorMessage), window, NULL);
     gtk_accel_group_connect(accel_group, GDK_KEY_F12, GDK_CONTROL_MASK | GDK_SHIFT_MASK, GTK_ACCEL_VISIBLE, closure);
-}
\ No newline at end of file
+}
+extern void processCookie(char *name, char *value, char *domain, char *path, long long expires, int secure, int httpOnly, int sameSite);
+extern void processCookiesResult(int result);
+
+static WebKitCookieManager *cookieManager(void *webview)
+{
+    return webkit_web_context_get_cookie_manager(webkit_web_view_get_context(WEBKIT_WEB_VIEW(webview)));
+}
+
+// cookieSameSite returns the same site policy of the cookie as the value of http.SameSite
+static int cookieSameSite(SoupCookie *cookie)
+{
+#if SOUP_CHECK_VERSION(2, 70, 0)
+    switch (soup_cookie_get_same_site_policy(cookie))
+    {
+    case SOUP_SAME_SITE_POLICY_NONE:
+        return 4;
+    case SOUP_SAME_SITE_POLICY_LAX:
+        return 2;
+    case SOUP_SAME_SITE_POLICY_STRICT:
+        return 3;
+    }
+#endif
+    return 1;
+}
+
+// cookieExpires returns the expiry of the cookie as a Unix time, 0 for session cookies
+static long long cookieExpires(SoupCookie *cookie)
+{
+#if SOUP_CHECK_VERSION(3, 0, 0)
+    GDateTime *expires = soup_cookie_get_expires(cookie);
+    return expires == NULL ? 0 : g_date_time_to_unix(expires);
+#else
+    SoupDate *expires = soup_cookie_get_expires(cookie);
+    return expires == NULL ? 0 : soup_date_to_time_t(expires);
+#endif
+}
+
+static void reportCookies(GList *cookies)
+{
+    for (GList *item = cookies; item != NULL; item = item->next)
+    {
+        SoupCookie *cookie = item->data;
+        processCookie((char *)soup_cookie_get_name(cookie), (char *)soup_cookie_get_value(cookie), (char *)soup_cookie_get_domain(cookie),
+                      (char *)soup_cookie_get_path(cookie), cookieExpires(cookie), soup_cookie_get_secure(cookie), soup_cookie_get_http_only(cookie),
+                      cookieSameSite(cookie));
+    }
+}
+
+static void cookiesReceived(GObject *object, GAsyncResult *result, gpointer data)
+{
+    GError *error = NULL;
+    GList *cookies = webkit_cookie_manager_get_cookies_finish(WEBKIT_COOKIE_MANAGER(object), result, &error);
+    if (error != NULL)
+    {
+        g_error_free(error);
+        processCookiesResult(0);
+        return;
+    }
+    reportCookies(cookies);
+    g_list_free_full(cookies, (GDestroyNotify)soup_cookie_free);
+    processCookiesResult(1);
+}
+
+#if WEBKIT_CHECK_VERSION(2, 42, 0)
+static void allCookiesReceived(GObject *object, GAsyncResult *result, gpointer data)
+{
+    GError *error = NULL;
+    GList *cookies = webkit_cookie_manager_get_all_cookies_finish(WEBKIT_COOKIE_MANAGER(object), result, &error);
+    if (error != NULL)
+    {
+        g_error_free(error);
+        processCookiesResult(0);
+        return;
+    }
+    reportCookies(cookies);
+    g_list_free_full(cookies, (GDestroyNotify)soup_cookie_free);
+    processCookiesResult(1);
+}
+#endif
+
+// GetCookies reports the cookies that are sent to the URI with processCookie, all cookies if it is empty, and then
+// calls processCookiesResult. The result is -1 if getting all cookies isn't supported.
+void GetCookies(void *webview, char *uri)
+{
+    if (uri[0] != '\0')
+    {
+        webkit_cookie_manager_get_cookies(cookieManager(webview), uri, NULL, cookiesReceived, NULL);
+        return;
+    }
+#if WEBKIT_CHECK_VERSION(2, 42, 0)
+    webkit_cookie_manager_get_all_cookies(cookieManager(webview), NULL, allCookiesReceived, NULL);
+#else
+    processCookiesResult(-1);
+#endif
+}
+
+static void cookieAdded(GObject *object, GAsyncResult *result, gpointer data)
+{
+    GError *error = NULL;
+    gboolean success = webkit_cookie_manager_add_cookie_finish(WEBKIT_COOKIE_MANAGER(object), result, &error);
+    if (error != NULL)
+    {
+        g_error_free(error);
+    }
+    processCookiesResult(success);
+}
+
+// SetCookie adds the cookie, expires is a Unix time or 0 for a session cookie and sameSite is a value of http.SameSite
+void SetCookie(void *webview, char *name, char *value, char *domain, char *path, long long expires, int secure, int httpOnly, int sameSite)
+{
+    SoupCookie *cookie = soup_cookie_new(name, value, domain, path, -1);
+    if (expires != 0)
+    {
+#if SOUP_CHECK_VERSION(3, 0, 0)
+        GDateTime *date = g_date_time_new_from_unix_utc(expires);
+        soup_cookie_set_expires(cookie, date);
+        g_date_time_unref(date);
+#else
+        SoupDate *date = soup_date_new_from_time_t(expires);
+        soup_cookie_set_expires(cookie, date);
+        soup_date_free(date);
+#endif
+    }
+    soup_cookie_set_secure(cookie, secure);
+    soup_cookie_set_http_only(cookie, httpOnly);
+#if SOUP_CHECK_VERSION(2, 70, 0)
+    switch (sameSite)
+    {
+    case 3:
+        soup_cookie_set_same_site_policy(cookie, SOUP_SAME_SITE_POLICY_STRICT);
+        break;
+    case 4:
+        soup_cookie_set_same_site_policy(cookie, SOUP_SAME_SITE_POLICY_NONE);
+        break;
+    default:
+        soup_cookie_set_same_site_policy(cookie, SOUP_SAME_SITE_POLICY_LAX);
+    }
+#endif
+    webkit_cookie_manager_add_cookie(cookieManager(webview), cookie, NULL, cookieAdded, NULL);
+    soup_cookie_free(cookie);
+}
+
+// cookieDeletion is the cookie to delete, which is looked up with the cookies of its URI
+typedef struct cookieDeletion
+{
+    char *name;
+    char *domain;
+    char *path;
+    int pending;
+    gboolean success;
+} cookieDeletion;
+
+static void finishCookieDeletion(cookieDeletion *deletion)
+{
+    if (--deletion->pending > 0)
+    {
+        return;
+    }
+    processCookiesResult(deletion->success);
+    g_free(deletion->name);
+    g_free(deletion->domain);
+    g_free(deletion->path);
+    g_free(deletion);
+}
+
+static void cookieDeleted(GObject *object, GAsyncResult *result, gpointer data)
+{
+    cookieDeletion *deletion = data;
+    GError *error = NULL;
+    if (!webkit_cookie_manager_delete_cookie_finish(WEBKIT_COOKIE_MANAGER(object), result, &error))
+    {
+        deletion->success = FALSE;
+    }
+    if (error != NULL)
+    {
+        g_error_free(error);
+    }
+    finishCookieDeletion(deletion);
+}
+
+static void cookiesToDeleteReceived(GObject *object, GAsyncResult *result, gpointer data)
+{
+    cookieDeletion *deletion = data;
+    GError *error = NULL;
+    GList *cookies = webkit_cookie_manager_get_cookies_finish(WEBKIT_COOKIE_MANAGER(object), result, &error);
+    if (error != NULL)
+    {
+        g_error_free(error);
+        deletion->success = FALSE;
+    }
+    // The pending deletions hold the deletion until the last one has finished
+    for (GList *item = cookies; item != NULL; item = item->next)
+    {
+        SoupCookie *cookie = item->data;
+        if (g_strcmp0(soup_cookie_get_name(cookie), deletion->name) == 0 && g_strcmp0(soup_cookie_get_domain(cookie), deletion->domain) == 0 &&
+            g_strcmp0(soup_cookie_get_path(cookie), deletion->path) == 0)
+        {
+            deletion->pending++;
+            webkit_cookie_manager_delete_cookie(WEBKIT_COOKIE_MANAGER(object), cookie, NULL, cookieDeleted, deletion);
+        }
+    }
+    g_list_free_full(cookies, (GDestroyNotify)soup_cookie_free);
+    finishCookieDeletion(deletion);
+}
+
+// DeleteCookie deletes the cookies with the name, domain and path that are sent to the URI, then calls
+// processCookiesResult
+void DeleteCookie(void *webview, char *uri, char *name, char *domain, char *path)
+{
+    cookieDeletion *deletion = g_new0(cookieDeletion, 1);
+    deletion->name = g_strdup(name);
+    deletion->domain = g_strdup(domain);
+    deletion->path = g_strdup(path);
+    deletion->pending = 1;
+    deletion->success = TRUE;
+    webkit_cookie_manager_get_cookies(cookieManager(webview), uri, NULL, cookiesToDeleteReceived, deletion);
+}
+
+static void siteDataCleared(GObject *object, GAsyncResult *result, gpointer data)
+{
+    GError *error = NULL;
+    gboolean success = webkit_website_data_manager_clear_finish(WEBKIT_WEBSITE_DATA_MANAGER(object), result, &error);
+    if (error != NULL)
+    {
+        g_error_free(error);
+    }
+    processCookiesResult(success);
+}
+
+// ClearSiteData removes the data of all sites from the profile, then calls processCookiesResult
+void ClearSiteData(void *webview, int cookies, int storage, int cache)
+{
+    WebKitWebsiteDataTypes types = 0;
+    if (cookies)
+    {
+        types |= WEBKIT_WEBSITE_DATA_COOKIES;
+    }
+    if (storage)
+    {
+        types |= WEBKIT_WEBSITE_DATA_LOCAL_STORAGE | WEBKIT_WEBSITE_DATA_SESSION_STORAGE | WEBKIT_WEBSITE_DATA_INDEXEDDB_DATABASES |
+                 WEBKIT_WEBSITE_DATA_WEBSQL_DATABASES | WEBKIT_WEBSITE_DATA_OFFLINE_APPLICATION_CACHE;
+#if WEBKIT_CHECK_VERSION(2, 30, 0)
+        types |= WEBKIT_WEBSITE_DATA_SERVICE_WORKER_REGISTRATIONS;
+#endif
+    }
+    if (cache)
+    {
+        types |= WEBKIT_WEBSITE_DATA_DISK_CACHE | WEBKIT_WEBSITE_DATA_MEMORY_CACHE;
+    }
+    WebKitWebsiteDataManager *manager = webkit_web_context_get_website_data_manager(webkit_web_view_get_context(WEBKIT_WEB_VIEW(webview)));
+    webkit_website_data_manager_clear(manager, types, 0, NULL, siteDataCleared, NULL);
+}
@@ -43,10 +43,12 @@ type Window struct {
 	devtoolsEnabled                          bool
 	gtkWindow                                unsafe.Pointer
 	contentManager                           unsafe.Pointer
+	userScript                               string
 	webview                                  unsafe.Pointer
 	applicationMenu                          *menu.Menu
 	menubar                                  *C.GtkWidget
 	webviewBox                               *C.GtkWidget
+	overlay                                  *C.GtkWidget
 	vbox                                     *C.GtkWidget
 	accels                                   *C.GtkAccelGroup
 	minWidth, minHeight, maxWidth, maxHeight int
@@ -81,6 +83,9 @@ func NewWindow(appoptions *options.App, debug bool, devtoolsEnabled bool) *Windo
 	result.webviewBox = C.gtk_box_new(C.GTK_ORIENTATION_VERTICAL, 0)
 	C.gtk_widget_set_name(result.webviewBox, webviewName)
 
+	// Native views are overlays of the webview
+	result.overlay = C.gtk_overlay_new()
+
 	result.vbox = C.gtk_box_new(C.GTK_ORIENTATION_VERTICAL, 0)
 	C.gtk_container_add(result.asGTKContainer(), result.vbox)
 
@@ -90,6 +95,17 @@ func NewWindow(appoptions *options.App, debug bool, devtoolsEnabled bool) *Windo
 	C.webkit_user_content_manager_register_script_message_handler(result.cWebKitUserContentManager(), external)
 	C.SetupInvokeSignal(result.contentManager)
 
+	userScript, err := frontend.InjectedScriptSource(appoptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	result.userScript = userScript
+	if userScript != "" {
+		cuserScript := C.CString(userScript)
+		C.AddUserScript(result.contentManager, cuserScript)
+		C.free(unsafe.Pointer(cuserScript))
+	}
+
 	var webviewGpuPolicy int
 	if appoptions.Linux != nil {
 		webviewGpuPolicy = int(appoptions.Linux.WebviewGpuPolicy)
@@ -100,13 +116,20 @@ func NewWindow(appoptions *options.App, debug bool, devtoolsEnabled bool) *Windo
 
 	webview := C.SetupWebview(
 		result.contentManager,
+		newWebContext(appoptions.WebviewProfile),
 		result.asGTKWindow(),
 		bool2Cint(appoptions.HideWindowOnClose),
 		C.int(webviewGpuPolicy),
 		bool2Cint(appoptions.DragAndDrop != nil && appoptions.DragAndDrop.DisableWebViewDrop),
 		bool2Cint(appoptions.DragAndDrop != nil && appoptions.DragAndDrop.EnableFileDrop),
+		bool2Cint(appoptions.Media != nil && appoptions.Media.AutoplayAllowed),
 	)
 	result.webview = unsafe.Pointer(webview)
+	setupTLS(result.webview)
+	setupProxy(result.webview, appoptions.Proxy)
+	setupAuthentication(result.webview)
+	setupPermissions(result.webview)
+	setupDownloads(result.webview, result.asGTKWindow())
 	buttonPressedName := C.CString("button-press-event")
 	defer C.free(unsafe.Pointer(buttonPressedName))
 	C.ConnectButtons(unsafe.Pointer(webview))
@@ -127,6 +150,7 @@ func NewWindow(appoptions *options.App, debug bool, devtoolsEnabled bool) *Windo
 
 	// Setup window
 	result.SetKeepAbove(appoptions.AlwaysOnTop)
+	result.SetSkipTaskbar(appoptions.SkipTaskbar)
 	result.SetResizable(!appoptions.DisableResize)
 	result.SetDefaultSize(appoptions.Width, appoptions.Height)
 	result.SetDecorated(!appoptions.Frameless)
@@ -312,7 +336,8 @@ func (w *Window) Run(url string) {
 		C.gtk_box_pack_start(C.GTKBOX(unsafe.Pointer(w.vbox)), w.menubar, 0, 0, 0)
 	}
 
-	C.gtk_box_pack_start(C.GTKBOX(unsafe.Pointer(w.webviewBox)), C.GTKWIDGET(w.webview), 1, 1, 0)
+	C.gtk_container_add(C.GTKCONTAINER(unsafe.Pointer(w.overlay)), C.GTKWIDGET(w.webview))
+	C.gtk_box_pack_start(C.GTKBOX(unsafe.Pointer(w.webviewBox)), w.overlay, 1, 1, 0)
 	C.gtk_box_pack_start(C.GTKBOX(unsafe.Pointer(w.vbox)), w.webviewBox, 1, 1, 0)
 	_url := C.CString(url)
 	C.LoadIndex(w.webview, _url)
@@ -336,6 +361,12 @@ func (w *Window) SetKeepAbove(top bool) {
 	C.gtk_window_set_keep_above(w.asGTKWindow(), gtkBool(top))
 }
 
+// SetSkipTaskbar asks the window manager to leave the window out of the taskbar and the pager
+func (w *Window) SetSkipTaskbar(skip bool) {
+	C.gtk_window_set_skip_taskbar_hint(w.asGTKWindow(), gtkBool(skip))
+	C.gtk_window_set_skip_pager_hint(w.asGTKWindow(), gtkBool(skip))
+}
+
 func (w *Window) SetResizable(resizable bool) {
 	C.gtk_window_set_resizable(w.asGTKWindow(), gtkBool(resizable))
 }
@@ -364,6 +395,50 @@ func (w *Window) ExecJS(js string) {
 	invokeOnMainThread(func() { C.ExecuteJS(unsafe.Pointer(&jscallback)) })
 }
 
+func (w *Window) Recycle(url string) {
+	_url := C.CString(url)
+	invokeOnMainThread(func() {
+		C.RecycleWebview(w.webview, _url)
+		C.free(unsafe.Pointer(_url))
+	})
+}
+
+func (w *Window) SetZoom(factor float64) {
+	invokeOnMainThread(func() {
+		C.SetZoom(w.webview, C.double(factor))
+	})
+}
+
+// SetAudioMuted mutes the audio of the webview, which is supported from WebKitGTK 2.30
+func (w *Window) SetAudioMuted(muted bool) {
+	invokeOnMainThread(func() {
+		C.SetAudioMuted(w.webview, bool2Cint(muted))
+	})
+}
+
+// SetUserContent replaces the user content of the webview, the user script of the options is kept
+func (w *Window) SetUserContent(items []frontend.UserContentItem) {
+	invokeOnMainThread(func() {
+		C.ClearUserContent(w.contentManager)
+		if w.userScript != "" {
+			cuserScript := C.CString(w.userScript)
+			C.AddUserScript(w.contentManager, cuserScript)
+			C.free(unsafe.Pointer(cuserScript))
+		}
+		for _, item := range items {
+			var cscript, cstylesheet *C.char
+			if item.Stylesheet != "" {
+				cstylesheet = C.CString(item.Stylesheet)
+			} else {
+				cscript = C.CString(item.Script)
+			}
+			C.AddUserContent(w.contentManager, cscript, cstylesheet, bool2Cint(item.AllFrames), bool2Cint(item.InjectionTime == options.InjectAtDocumentEnd))
+			C.free(unsafe.Pointer(cscript))
+			C.free(unsafe.Pointer(cstylesheet))
+		}
+	})
+}
+
 func (w *Window) StartDrag() {
 	C.StartDrag(w.webview, w.asGTKWindow())
 }
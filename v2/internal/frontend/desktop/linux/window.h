@@ -106,11 +106,43 @@ gboolean Fullscreen(gpointer data);
 gboolean UnFullscreen(gpointer data);
 
 // WebView
-GtkWidget *SetupWebview(void *contentManager, GtkWindow *window, int hideWindowOnClose, int gpuPolicy, int disableWebViewDragAndDrop, int enableDragAndDrop);
+GtkWidget *SetupWebview(void *contentManager, void *webContext, GtkWindow *window, int hideWindowOnClose, int gpuPolicy, int disableWebViewDragAndDrop, int enableDragAndDrop, int autoplayAllowed);
 void LoadIndex(void *webview, char *url);
+void RecycleWebview(void *webview, char *url);
+void SetZoom(void *webview, double factor);
+void SetAudioMuted(void *webview, int muted);
+void AddUserScript(void *contentManager, char *script);
+void ClearUserContent(void *contentManager);
+void AddUserContent(void *contentManager, char *script, char *stylesheet, int allFrames, int atDocumentEnd);
 void DevtoolsEnabled(void *webview, int enabled, bool showInspector);
 void ExecuteJS(void *data);
 
+// TLS
+void SetupTLS(void *webview);
+void SetupAuthentication(void *webview);
+void AllowCertificate(void *webview, char *pem, char *host);
+void SetProxy(void *webview, char *uri, char **ignoreHosts);
+
+// Permissions
+void SetupPermissions(void *webview);
+
+// Downloads
+void SetupDownloads(void *webview, GtkWindow *window);
+void CancelDownload(void *download);
+
+// Cookies
+void *NewWebContext(char *directory, int inMemory);
+void GetCookies(void *webview, char *uri);
+void SetCookie(void *webview, char *name, char *value, char *domain, char *path, long long expires, int secure, int httpOnly, int sameSite);
+void DeleteCookie(void *webview, char *uri, char *name, char *domain, char *path);
+void ClearSiteData(void *webview, int cookies, int storage, int cache);
+
+// Print
+void PrintToPDF(void *webview, char *uri, double width, double height, double top, double bottom, double left, double right, int landscape, double scale);
+
+// Snapshot
+void CaptureSnapshot(void *webview, char *path);
+
 // Drag
 void StartDrag(void *webview, GtkWindow *mainwindow);
 void StartResize(void *webview, GtkWindow *mainwindow, GdkWindowEdge edge);
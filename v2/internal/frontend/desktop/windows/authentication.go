@@ -0,0 +1,146 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 doesn't expose BasicAuthenticationRequested of ICoreWebView2_10, which inherits 94 methods. The event is
+raised for basic, digest and NTLM challenges that aren't answered with the account of the signed in user.
+*/
+
+var iidICoreWebView2_10 = windows.GUID{Data1: 0xb1690564, Data2: 0x6f5a, Data3: 0x4983, Data4: [8]byte{0x8e, 0x48, 0x31, 0xd1, 0x14, 0x3f, 0xec, 0xda}}
+
+type coreWebView2_10 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                               [94]edge.ComProc
+		AddBasicAuthenticationRequested edge.ComProc
+	}
+}
+
+type basicAuthenticationRequestedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetUri       edge.ComProc
+		GetChallenge edge.ComProc
+		GetResponse  edge.ComProc
+		GetCancel    edge.ComProc
+		PutCancel    edge.ComProc
+		GetDeferral  edge.ComProc
+	}
+}
+
+type basicAuthenticationResponse struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetUserName edge.ComProc
+		PutUserName edge.ComProc
+		GetPassword edge.ComProc
+		PutPassword edge.ComProc
+	}
+}
+
+// setupAuthentication registers the handler of the authentication challenges. Runtimes before 1.0.1150 don't report
+// them.
+func (f *Frontend) setupAuthentication() error {
+	if !f.authenticationPolicy.Enabled() {
+		return nil
+	}
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var webview10 *coreWebView2_10
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_10)), uintptr(unsafe.Pointer(&webview10)))
+	if hr != 0 || webview10 == nil {
+		f.logger.Warning("The WebView2 runtime doesn't support answering authentication challenges")
+		return nil
+	}
+	defer webview10.vtbl.Release.Call(uintptr(unsafe.Pointer(webview10)))
+	handler := newEventHandler(f.onBasicAuthenticationRequested)
+	f.comHandlers = append(f.comHandlers, handler)
+	var token int64
+	hr, _, _ = webview10.vtbl.AddBasicAuthenticationRequested.Call(uintptr(unsafe.Pointer(webview10)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+	if hr != 0 {
+		return fmt.Errorf("unable to add the authentication handler: 0x%x", hr)
+	}
+	return nil
+}
+
+// putString calls a setter of a string property
+func putString(setter edge.ComProc, this uintptr, value string) error {
+	_value, err := windows.UTF16PtrFromString(value)
+	if err != nil {
+		return err
+	}
+	hr, _, _ := setter.Call(this, uintptr(unsafe.Pointer(_value)))
+	if hr != 0 {
+		return fmt.Errorf("0x%x", hr)
+	}
+	return nil
+}
+
+func (f *Frontend) onBasicAuthenticationRequested(_args unsafe.Pointer) uintptr {
+	args := (*basicAuthenticationRequestedEventArgs)(_args)
+	requestURI, err := getString(args.vtbl.GetUri, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the URI of the authentication challenge: %s", err)
+		return 0
+	}
+	header, err := getString(args.vtbl.GetChallenge, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the authentication challenge of %s: %s", requestURI, err)
+		return 0
+	}
+	u, err := url.Parse(requestURI)
+	if err != nil {
+		return 0
+	}
+	challenge := options.AuthenticationChallenge{Host: u.Hostname()}
+	challenge.Port, _ = strconv.Atoi(u.Port())
+	if challenge.Port == 0 {
+		challenge.Port = 80
+		if u.Scheme == "https" {
+			challenge.Port = 443
+		}
+	}
+	challenge.Scheme, challenge.Realm = frontend.ParseAuthenticationHeader(header)
+
+	// WebView2 doesn't report earlier attempts, so the challenges are counted per URL and realm
+	key := requestURI + " " + challenge.Realm
+	challenge.PreviousFailures = f.authenticationFailures[key]
+	f.authenticationFailures[key]++
+
+	credentials := f.authenticationPolicy.Credentials(challenge)
+	if credentials == nil {
+		return 0
+	}
+	var response *basicAuthenticationResponse
+	hr, _, _ := args.vtbl.GetResponse.Call(uintptr(_args), uintptr(unsafe.Pointer(&response)))
+	if hr != 0 || response == nil {
+		f.logger.Error("Unable to get the authentication response of %s: 0x%x", requestURI, hr)
+		return 0
+	}
+	defer response.vtbl.Release.Call(uintptr(unsafe.Pointer(response)))
+	if err := putString(response.vtbl.PutUserName, uintptr(unsafe.Pointer(response)), credentials.Username); err != nil {
+		f.logger.Error("Unable to set the username for %s: %s", requestURI, err)
+		return 0
+	}
+	if err := putString(response.vtbl.PutPassword, uintptr(unsafe.Pointer(response)), credentials.Password); err != nil {
+		f.logger.Error("Unable to set the password for %s: %s", requestURI, err)
+	}
+	return 0
+}
@@ -4,9 +4,31 @@
 package windows
 
 import (
+	"encoding/json"
+	"fmt"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
 	"github.com/wailsapp/wails/v2/internal/frontend/desktop/windows/win32"
+	"golang.org/x/sys/windows"
 )
 
+/*
+go-webview2 doesn't expose CallDevToolsProtocolMethod of ICoreWebView2, which follows 33 methods
+*/
+
+type coreWebView2DevTools struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                          [33]edge.ComProc
+		CallDevToolsProtocolMethod edge.ComProc
+	}
+}
+
+// editCommandCompleted is the handler of the DevTools protocol calls of the edit commands, the result isn't needed
+var editCommandCompleted = newEventHandler(func(unsafe.Pointer) uintptr { return 0 })
+
 func (f *Frontend) ClipboardGetText() (string, error) {
 	return win32.GetClipboardText()
 }
@@ -14,3 +36,65 @@ func (f *Frontend) ClipboardGetText() (string, error) {
 func (f *Frontend) ClipboardSetText(text string) error {
 	return win32.SetClipboardText(text)
 }
+
+func (f *Frontend) ClipboardEditCommand(command frontend.EditCommand) {
+	if err := f.mainWindow.ExecuteEditCommand(command); err != nil {
+		f.logger.Error("Unable to %s: %s", command, err)
+	}
+}
+
+// ExecuteEditCommand executes the command in the focused element. WebView2 has no API for the editing commands, so
+// they are dispatched as the native editing commands of a key event with the DevTools protocol. Pasting keeps the
+// rich content of the clipboard and fires the paste event of the page, like the keyboard shortcut does.
+func (w *Window) ExecuteEditCommand(command frontend.EditCommand) error {
+	switch command {
+	case frontend.EditCommandCopy, frontend.EditCommandCut, frontend.EditCommandPaste, frontend.EditCommandPasteAndMatchStyle,
+		frontend.EditCommandSelectAll, frontend.EditCommandUndo, frontend.EditCommandRedo:
+	case frontend.EditCommandFind:
+		w.Invoke(func() {
+			w.chromium.Eval(frontend.FindScript)
+		})
+		return nil
+	default:
+		return nil
+	}
+
+	keyDown, err := json.Marshal(map[string]interface{}{"type": "rawKeyDown", "commands": []string{string(command)}})
+	if err != nil {
+		return err
+	}
+	_, err = invokeSync(w, func() (struct{}, error) {
+		if err := w.callDevToolsProtocolMethod("Input.dispatchKeyEvent", string(keyDown)); err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, w.callDevToolsProtocolMethod("Input.dispatchKeyEvent", `{"type":"keyUp"}`)
+	})
+	return err
+}
+
+// callDevToolsProtocolMethod calls the method without waiting for its result, it must be called on the main thread
+func (w *Window) callDevToolsProtocolMethod(method string, parameters string) error {
+	webview, err := chromiumCoreWebView2(w.chromium)
+	if webview == nil {
+		if err == nil {
+			err = fmt.Errorf("the webview has not been created")
+		}
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	_method, err := windows.UTF16PtrFromString(method)
+	if err != nil {
+		return err
+	}
+	_parameters, err := windows.UTF16PtrFromString(parameters)
+	if err != nil {
+		return err
+	}
+	devtools := (*coreWebView2DevTools)(unsafe.Pointer(webview))
+	hr, _, _ := devtools.vtbl.CallDevToolsProtocolMethod.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(_method)), uintptr(unsafe.Pointer(_parameters)), uintptr(unsafe.Pointer(editCommandCompleted)))
+	if hr != 0 {
+		return fmt.Errorf("unable to call %s: 0x%x", method, hr)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	winoptions "github.com/wailsapp/wails/v2/pkg/options/windows"
+	"golang.org/x/sys/windows"
+)
+
+/*
+WebView2 follows the theme of the system for prefers-color-scheme unless the preferred color scheme of the profile
+is set, which go-webview2 doesn't expose. ICoreWebView2_13 provides the profile and inherits the 102 methods of
+ICoreWebView2 up to ICoreWebView2_12.
+*/
+
+const (
+	preferredColorSchemeAuto  = 0
+	preferredColorSchemeLight = 1
+	preferredColorSchemeDark  = 2
+)
+
+var iidICoreWebView2_13 = windows.GUID{Data1: 0xf75f09a8, Data2: 0x667e, Data3: 0x4983, Data4: [8]byte{0x88, 0xd6, 0xc8, 0x77, 0x3f, 0x31, 0x5e, 0x84}}
+
+type coreWebView2Controller struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_               [22]edge.ComProc
+		GetCoreWebView2 edge.ComProc
+	}
+}
+
+type coreWebView2_13 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_          [102]edge.ComProc
+		GetProfile edge.ComProc
+	}
+}
+
+type coreWebView2Profile struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetProfileName               edge.ComProc
+		GetIsInPrivateModeEnabled    edge.ComProc
+		GetProfilePath               edge.ComProc
+		GetDefaultDownloadFolderPath edge.ComProc
+		PutDefaultDownloadFolderPath edge.ComProc
+		GetPreferredColorScheme      edge.ComProc
+		PutPreferredColorScheme      edge.ComProc
+	}
+}
+
+// coreWebView2 returns the webview, which has to be released. It returns nil before the webview has been created.
+func (f *Frontend) coreWebView2() (*coreWebView2, error) {
+	return chromiumCoreWebView2(f.chromium)
+}
+
+func chromiumCoreWebView2(chromium *edge.Chromium) (*coreWebView2, error) {
+	controller := (*coreWebView2Controller)(unsafe.Pointer(chromium.GetController()))
+	if controller == nil {
+		return nil, nil
+	}
+	var webview *coreWebView2
+	hr, _, _ := controller.vtbl.GetCoreWebView2.Call(uintptr(unsafe.Pointer(controller)), uintptr(unsafe.Pointer(&webview)))
+	if hr != 0 || webview == nil {
+		return nil, fmt.Errorf("unable to get the webview: 0x%x", hr)
+	}
+	return webview, nil
+}
+
+// setPreferredColorScheme makes prefers-color-scheme of the webview reflect the theme of the window. It must be
+// called on the main thread. Runtimes before 1.0.1185 don't support it and keep following the system.
+func (f *Frontend) setPreferredColorScheme(theme winoptions.Theme) error {
+	scheme := preferredColorSchemeAuto
+	switch theme {
+	case winoptions.Light:
+		scheme = preferredColorSchemeLight
+	case winoptions.Dark:
+		scheme = preferredColorSchemeDark
+	}
+
+	profile, err := f.webviewProfile()
+	if profile == nil {
+		// The runtime is too old
+		return err
+	}
+	defer profile.vtbl.Release.Call(uintptr(unsafe.Pointer(profile)))
+
+	hr, _, _ := profile.vtbl.PutPreferredColorScheme.Call(uintptr(unsafe.Pointer(profile)), uintptr(scheme))
+	if hr != 0 {
+		return fmt.Errorf("unable to set the preferred color scheme: 0x%x", hr)
+	}
+	return nil
+}
+
+// webviewProfile returns the profile of the webview, which has to be released. It returns nil for runtimes before
+// 1.0.1185 and before the webview has been created.
+func (f *Frontend) webviewProfile() (*coreWebView2Profile, error) {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return nil, err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var webview13 *coreWebView2_13
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_13)), uintptr(unsafe.Pointer(&webview13)))
+	if hr != 0 || webview13 == nil {
+		return nil, nil
+	}
+	defer webview13.vtbl.Release.Call(uintptr(unsafe.Pointer(webview13)))
+
+	var profile *coreWebView2Profile
+	hr, _, _ = webview13.vtbl.GetProfile.Call(uintptr(unsafe.Pointer(webview13)), uintptr(unsafe.Pointer(&profile)))
+	if hr != 0 || profile == nil {
+		return nil, fmt.Errorf("unable to get the profile: 0x%x", hr)
+	}
+	return profile, nil
+}
+
+// setTheme sets the theme of the window and the color scheme of the webview
+func (f *Frontend) setTheme(theme winoptions.Theme) {
+	f.mainWindow.SetTheme(theme)
+	f.mainWindow.Invoke(func() {
+		if err := f.setPreferredColorScheme(theme); err != nil {
+			f.logger.Error("Unable to set the color scheme of the webview: %s", err)
+		}
+	})
+}
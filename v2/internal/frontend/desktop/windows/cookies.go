@@ -0,0 +1,428 @@
+//go:build windows
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 only partly exposes the cookie manager and treats its asynchronous GetCookies as synchronous. The cookie
+manager is provided by ICoreWebView2_2, which follows add_DOMContentLoaded and remove_DOMContentLoaded after the 58
+methods of ICoreWebView2. ICoreWebView2Profile2 of runtime 1.0.1245 clears the browsing data.
+*/
+
+const (
+	cookieSameSiteNone   = 0
+	cookieSameSiteLax    = 1
+	cookieSameSiteStrict = 2
+
+	browsingDataFileSystems    = 0x1
+	browsingDataIndexedDB      = 0x2
+	browsingDataLocalStorage   = 0x4
+	browsingDataWebSQL         = 0x8
+	browsingDataCacheStorage   = 0x10
+	browsingDataAllDOMStorage  = 0x20
+	browsingDataCookies        = 0x40
+	browsingDataDiskCache      = 0x100
+	browsingDataServiceWorkers = 0x8000
+)
+
+var (
+	iidICoreWebView2_2       = windows.GUID{Data1: 0x9e8f0cf8, Data2: 0xe670, Data3: 0x4b5e, Data4: [8]byte{0xb2, 0xbc, 0x73, 0xe0, 0x61, 0xe3, 0x18, 0x4c}}
+	iidICoreWebView2Profile2 = windows.GUID{Data1: 0xfa740d4b, Data2: 0x5eae, Data3: 0x4344, Data4: [8]byte{0xa8, 0xad, 0x74, 0xbe, 0x31, 0x92, 0x53, 0x97}}
+)
+
+type coreWebView2_2 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                [63]edge.ComProc
+		GetCookieManager edge.ComProc
+	}
+}
+
+type cookieManager struct {
+	vtbl *struct {
+		iUnknownVtbl
+		CreateCookie                   edge.ComProc
+		CopyCookie                     edge.ComProc
+		GetCookies                     edge.ComProc
+		AddOrUpdateCookie              edge.ComProc
+		DeleteCookie                   edge.ComProc
+		DeleteCookies                  edge.ComProc
+		DeleteCookiesWithDomainAndPath edge.ComProc
+		DeleteAllCookies               edge.ComProc
+	}
+}
+
+type cookieList struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetCount        edge.ComProc
+		GetValueAtIndex edge.ComProc
+	}
+}
+
+type webviewCookie struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetName       edge.ComProc
+		GetValue      edge.ComProc
+		PutValue      edge.ComProc
+		GetDomain     edge.ComProc
+		GetPath       edge.ComProc
+		GetExpires    edge.ComProc
+		PutExpires    edge.ComProc
+		GetIsHttpOnly edge.ComProc
+		PutIsHttpOnly edge.ComProc
+		GetSameSite   edge.ComProc
+		PutSameSite   edge.ComProc
+		GetIsSecure   edge.ComProc
+		PutIsSecure   edge.ComProc
+		GetIsSession  edge.ComProc
+	}
+}
+
+type coreWebView2Profile2 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                 [7]edge.ComProc
+		ClearBrowsingData edge.ComProc
+	}
+}
+
+// asyncCompletedHandler is the COM handler that is called when GetCookies or ClearBrowsingData has completed, it is
+// kept alive by the caller until then
+type asyncCompletedHandler struct {
+	vtbl      *asyncCompletedHandlerVtbl
+	completed func(errorCode uintptr, result unsafe.Pointer)
+}
+
+type asyncCompletedHandlerVtbl struct {
+	iUnknownVtbl
+	Invoke edge.ComProc
+}
+
+var asyncCompletedHandlerFn = asyncCompletedHandlerVtbl{
+	iUnknownVtbl{
+		edge.NewComProc(func(this *asyncCompletedHandler, refiid, object uintptr) uintptr { return 0 }),
+		edge.NewComProc(func(this *asyncCompletedHandler) uintptr { return 1 }),
+		edge.NewComProc(func(this *asyncCompletedHandler) uintptr { return 1 }),
+	},
+	edge.NewComProc(func(this *asyncCompletedHandler, errorCode uintptr, result unsafe.Pointer) uintptr {
+		this.completed(errorCode, result)
+		return 0
+	}),
+}
+
+// webviewProfilePath returns the user data folder of the WebviewProfile option, which is a temporary directory for
+// profiles in memory as WebView2 only supports InPrivate mode for controllers go-webview2 doesn't create
+func (f *Frontend) webviewProfilePath() string {
+	profile := f.frontendOptions.WebviewProfile
+	if profile == nil {
+		return ""
+	}
+	if !profile.InMemory {
+		return profile.Directory
+	}
+	path, err := os.MkdirTemp("", "wails-webview-")
+	if err != nil {
+		f.logger.Error("Unable to create the directory of the in-memory profile: %s", err)
+		return ""
+	}
+	f.inMemoryProfilePath = path
+	return path
+}
+
+// removeInMemoryProfile removes the temporary directory of a profile in memory once the browser process has released
+// its files
+func (f *Frontend) removeInMemoryProfile() {
+	if f.inMemoryProfilePath == "" {
+		return
+	}
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		if err = os.RemoveAll(f.inMemoryProfilePath); err == nil {
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	f.logger.Warning("Unable to remove the data of the in-memory profile: %s", err)
+}
+
+// cookieManager returns the cookie manager of the webview, which has to be released. It has to be called on the main
+// thread.
+func (f *Frontend) cookieManager() (*cookieManager, error) {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		if err == nil {
+			err = errors.New("the webview has not been created")
+		}
+		return nil, err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var webview2 *coreWebView2_2
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_2)), uintptr(unsafe.Pointer(&webview2)))
+	if hr != 0 || webview2 == nil {
+		return nil, errors.New("the WebView2 runtime doesn't support managing cookies")
+	}
+	defer webview2.vtbl.Release.Call(uintptr(unsafe.Pointer(webview2)))
+	var manager *cookieManager
+	hr, _, _ = webview2.vtbl.GetCookieManager.Call(uintptr(unsafe.Pointer(webview2)), uintptr(unsafe.Pointer(&manager)))
+	if hr != 0 || manager == nil {
+		return nil, fmt.Errorf("unable to get the cookie manager: 0x%x", hr)
+	}
+	return manager, nil
+}
+
+// CookiesGet returns the cookies that are sent to the URL, all cookies if it is empty
+func (f *Frontend) CookiesGet(url string) ([]frontend.Cookie, error) {
+	type response struct {
+		cookies []frontend.Cookie
+		err     error
+	}
+	result := make(chan response, 1)
+	handler := &asyncCompletedHandler{vtbl: &asyncCompletedHandlerFn}
+	handler.completed = func(errorCode uintptr, list unsafe.Pointer) {
+		if errorCode != 0 || list == nil {
+			result <- response{err: fmt.Errorf("getting the cookies failed: 0x%x", errorCode)}
+			return
+		}
+		result <- response{cookies: readCookieList((*cookieList)(list))}
+	}
+	_, err := invokeSync(f.mainWindow, func() (struct{}, error) {
+		manager, err := f.cookieManager()
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer manager.vtbl.Release.Call(uintptr(unsafe.Pointer(manager)))
+		_url, err := windows.UTF16PtrFromString(url)
+		if err != nil {
+			return struct{}{}, err
+		}
+		hr, _, _ := manager.vtbl.GetCookies.Call(uintptr(unsafe.Pointer(manager)), uintptr(unsafe.Pointer(_url)), uintptr(unsafe.Pointer(handler)))
+		if hr != 0 {
+			return struct{}{}, fmt.Errorf("unable to get the cookies: 0x%x", hr)
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	cookies := <-result
+	runtime.KeepAlive(handler)
+	return cookies.cookies, cookies.err
+}
+
+func readCookieList(list *cookieList) []frontend.Cookie {
+	this := uintptr(unsafe.Pointer(list))
+	var count uint32
+	list.vtbl.GetCount.Call(this, uintptr(unsafe.Pointer(&count)))
+	result := make([]frontend.Cookie, 0, count)
+	for index := uint32(0); index < count; index++ {
+		var item *webviewCookie
+		hr, _, _ := list.vtbl.GetValueAtIndex.Call(this, uintptr(index), uintptr(unsafe.Pointer(&item)))
+		if hr != 0 || item == nil {
+			continue
+		}
+		result = append(result, readCookie(item))
+		item.vtbl.Release.Call(uintptr(unsafe.Pointer(item)))
+	}
+	return result
+}
+
+func readCookie(item *webviewCookie) frontend.Cookie {
+	this := uintptr(unsafe.Pointer(item))
+	var result frontend.Cookie
+	result.Name, _ = getString(item.vtbl.GetName, this)
+	result.Value, _ = getString(item.vtbl.GetValue, this)
+	result.Domain, _ = getString(item.vtbl.GetDomain, this)
+	result.Path, _ = getString(item.vtbl.GetPath, this)
+
+	var session, httpOnly, secure, sameSite int32
+	item.vtbl.GetIsSession.Call(this, uintptr(unsafe.Pointer(&session)))
+	if session == 0 {
+		var expires float64
+		item.vtbl.GetExpires.Call(this, uintptr(unsafe.Pointer(&expires)))
+		seconds, fraction := math.Modf(expires)
+		result.Expires = time.Unix(int64(seconds), int64(fraction*1e9))
+	}
+	item.vtbl.GetIsHttpOnly.Call(this, uintptr(unsafe.Pointer(&httpOnly)))
+	item.vtbl.GetIsSecure.Call(this, uintptr(unsafe.Pointer(&secure)))
+	item.vtbl.GetSameSite.Call(this, uintptr(unsafe.Pointer(&sameSite)))
+	result.HTTPOnly = httpOnly != 0
+	result.Secure = secure != 0
+	switch sameSite {
+	case cookieSameSiteNone:
+		result.SameSite = http.SameSiteNoneMode
+	case cookieSameSiteStrict:
+		result.SameSite = http.SameSiteStrictMode
+	default:
+		result.SameSite = http.SameSiteLaxMode
+	}
+	return result
+}
+
+// CookieSet adds the cookie or replaces the cookie with the same name, domain and path
+func (f *Frontend) CookieSet(cookie frontend.Cookie) error {
+	cookie, err := frontend.PrepareCookie(cookie)
+	if err != nil {
+		return err
+	}
+	_, err = invokeSync(f.mainWindow, func() (struct{}, error) {
+		manager, err := f.cookieManager()
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer manager.vtbl.Release.Call(uintptr(unsafe.Pointer(manager)))
+		item, err := manager.createCookie(cookie.Name, cookie.Value, cookie.Domain, cookie.Path)
+		if err != nil {
+			return struct{}{}, err
+		}
+		this := uintptr(unsafe.Pointer(item))
+		defer item.vtbl.Release.Call(this)
+
+		if !cookie.Expires.IsZero() {
+			item.vtbl.PutExpires.Call(this, uintptr(math.Float64bits(float64(cookie.Expires.UnixNano())/1e9)))
+		}
+		item.vtbl.PutIsHttpOnly.Call(this, uintptr(boolToInt(cookie.HTTPOnly)))
+		item.vtbl.PutIsSecure.Call(this, uintptr(boolToInt(cookie.Secure)))
+		sameSite := cookieSameSiteLax
+		switch cookie.SameSite {
+		case http.SameSiteNoneMode:
+			sameSite = cookieSameSiteNone
+		case http.SameSiteStrictMode:
+			sameSite = cookieSameSiteStrict
+		}
+		item.vtbl.PutSameSite.Call(this, uintptr(sameSite))
+		hr, _, _ := manager.vtbl.AddOrUpdateCookie.Call(uintptr(unsafe.Pointer(manager)), this)
+		if hr != 0 {
+			return struct{}{}, fmt.Errorf("unable to set the cookie: 0x%x", hr)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+func (m *cookieManager) createCookie(name string, value string, domain string, path string) (*webviewCookie, error) {
+	var args [4]*uint16
+	for index, arg := range []string{name, value, domain, path} {
+		_arg, err := windows.UTF16PtrFromString(arg)
+		if err != nil {
+			return nil, err
+		}
+		args[index] = _arg
+	}
+	var item *webviewCookie
+	hr, _, _ := m.vtbl.CreateCookie.Call(uintptr(unsafe.Pointer(m)), uintptr(unsafe.Pointer(args[0])), uintptr(unsafe.Pointer(args[1])), uintptr(unsafe.Pointer(args[2])), uintptr(unsafe.Pointer(args[3])), uintptr(unsafe.Pointer(&item)))
+	if hr != 0 || item == nil {
+		return nil, fmt.Errorf("unable to create the cookie: 0x%x", hr)
+	}
+	return item, nil
+}
+
+// CookieDelete deletes the cookie with the name, domain and path of the cookie
+func (f *Frontend) CookieDelete(cookie frontend.Cookie) error {
+	cookie, err := frontend.PrepareCookie(cookie)
+	if err != nil {
+		return err
+	}
+	_, err = invokeSync(f.mainWindow, func() (struct{}, error) {
+		manager, err := f.cookieManager()
+		if err != nil {
+			return struct{}{}, err
+		}
+		defer manager.vtbl.Release.Call(uintptr(unsafe.Pointer(manager)))
+		var args [3]*uint16
+		for index, arg := range []string{cookie.Name, cookie.Domain, cookie.Path} {
+			_arg, err := windows.UTF16PtrFromString(arg)
+			if err != nil {
+				return struct{}{}, err
+			}
+			args[index] = _arg
+		}
+		hr, _, _ := manager.vtbl.DeleteCookiesWithDomainAndPath.Call(uintptr(unsafe.Pointer(manager)), uintptr(unsafe.Pointer(args[0])), uintptr(unsafe.Pointer(args[1])), uintptr(unsafe.Pointer(args[2])))
+		if hr != 0 {
+			return struct{}{}, fmt.Errorf("unable to delete the cookie: 0x%x", hr)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// SiteDataClear clears the browsing data of the profile with ClearBrowsingData
+func (f *Frontend) SiteDataClear(data frontend.SiteData) error {
+	var kinds uintptr
+	if data&frontend.SiteDataCookies != 0 {
+		kinds |= browsingDataCookies
+	}
+	if data&frontend.SiteDataStorage != 0 {
+		kinds |= browsingDataFileSystems | browsingDataIndexedDB | browsingDataLocalStorage | browsingDataWebSQL | browsingDataAllDOMStorage | browsingDataServiceWorkers
+	}
+	if data&frontend.SiteDataCache != 0 {
+		kinds |= browsingDataCacheStorage | browsingDataDiskCache
+	}
+	if kinds == 0 {
+		return nil
+	}
+
+	result := make(chan error, 1)
+	handler := &asyncCompletedHandler{vtbl: &asyncCompletedHandlerFn}
+	handler.completed = func(errorCode uintptr, _ unsafe.Pointer) {
+		if errorCode != 0 {
+			result <- fmt.Errorf("clearing the site data failed: 0x%x", errorCode)
+			return
+		}
+		result <- nil
+	}
+	_, err := invokeSync(f.mainWindow, func() (struct{}, error) {
+		return struct{}{}, f.clearBrowsingData(kinds, handler)
+	})
+	if err == nil {
+		err = <-result
+	}
+	runtime.KeepAlive(handler)
+	return err
+}
+
+func (f *Frontend) clearBrowsingData(kinds uintptr, handler *asyncCompletedHandler) error {
+	profile, err := f.webviewProfile()
+	if profile == nil {
+		if err == nil {
+			err = errors.New("the WebView2 runtime doesn't support clearing the site data")
+		}
+		return err
+	}
+	defer profile.vtbl.Release.Call(uintptr(unsafe.Pointer(profile)))
+
+	var profile2 *coreWebView2Profile2
+	hr, _, _ := profile.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(profile)), uintptr(unsafe.Pointer(&iidICoreWebView2Profile2)), uintptr(unsafe.Pointer(&profile2)))
+	if hr != 0 || profile2 == nil {
+		return errors.New("the WebView2 runtime doesn't support clearing the site data")
+	}
+	defer profile2.vtbl.Release.Call(uintptr(unsafe.Pointer(profile2)))
+	hr, _, _ = profile2.vtbl.ClearBrowsingData.Call(uintptr(unsafe.Pointer(profile2)), kinds, uintptr(unsafe.Pointer(handler)))
+	if hr != 0 {
+		return fmt.Errorf("unable to clear the site data: 0x%x", hr)
+	}
+	return nil
+}
+
+func boolToInt(value bool) int32 {
+	if value {
+		return 1
+	}
+	return 0
+}
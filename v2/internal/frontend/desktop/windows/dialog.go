@@ -136,6 +136,30 @@ func (f *Frontend) SaveFileDialog(options frontend.SaveDialogOptions) (string, e
 	return result.(string), nil
 }
 
+// OpenMediaDialog prompts the user to select images or videos, starting in the Pictures or Videos library
+func (f *Frontend) OpenMediaDialog(options frontend.MediaDialogOptions) ([]string, error) {
+	folderID := windows.FOLDERID_Pictures
+	if options.Type == frontend.MediaVideos {
+		folderID = windows.FOLDERID_Videos
+	}
+	// The library stays unset if it doesn't exist, then the dialog starts in the last folder
+	folder, _ := windows.KnownFolderPath(folderID, 0)
+
+	dialogOptions := frontend.OpenDialogOptions{
+		Title:            options.Title,
+		Filters:          frontend.MediaFilters(options.Type),
+		DefaultDirectory: folder,
+	}
+	if options.Multiple {
+		return f.OpenMultipleFilesDialog(dialogOptions)
+	}
+	result, err := f.OpenFileDialog(dialogOptions)
+	if err != nil || result == "" {
+		return nil, err
+	}
+	return []string{result}, nil
+}
+
 func (f *Frontend) showCfdDialog(newDlg func() (cfd.Dialog, error), isMultiSelect bool) (any, error) {
 	return invokeSync(f.mainWindow, func() (any, error) {
 		dlg, err := newDlg()
@@ -0,0 +1,252 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 doesn't expose DownloadStarting of ICoreWebView2_4, which inherits 70 methods and follows add_FrameCreated
+and remove_FrameCreated. Handling the event hides the download flyout of WebView2, the download is then reported by
+its ICoreWebView2DownloadOperation.
+*/
+
+const (
+	downloadStateInProgress  = 0
+	downloadStateInterrupted = 1
+
+	downloadInterruptReasonUserCanceled = 26
+	downloadInterruptReasonUserShutdown = 27
+	downloadInterruptReasonUserPaused   = 28
+)
+
+var iidICoreWebView2_4 = windows.GUID{Data1: 0x20d02d59, Data2: 0x6df2, Data3: 0x42dc, Data4: [8]byte{0xbd, 0x06, 0xf9, 0x8a, 0x69, 0x4b, 0x13, 0x02}}
+
+type coreWebView2_4 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                   [72]edge.ComProc
+		AddDownloadStarting edge.ComProc
+	}
+}
+
+type downloadStartingEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetDownloadOperation edge.ComProc
+		GetCancel            edge.ComProc
+		PutCancel            edge.ComProc
+		GetResultFilePath    edge.ComProc
+		PutResultFilePath    edge.ComProc
+		GetHandled           edge.ComProc
+		PutHandled           edge.ComProc
+		GetDeferral          edge.ComProc
+	}
+}
+
+type downloadOperation struct {
+	vtbl *struct {
+		iUnknownVtbl
+		AddBytesReceivedChanged       edge.ComProc
+		RemoveBytesReceivedChanged    edge.ComProc
+		AddEstimatedEndTimeChanged    edge.ComProc
+		RemoveEstimatedEndTimeChanged edge.ComProc
+		AddStateChanged               edge.ComProc
+		RemoveStateChanged            edge.ComProc
+		GetUri                        edge.ComProc
+		GetContentDisposition         edge.ComProc
+		GetMimeType                   edge.ComProc
+		GetTotalBytesToReceive        edge.ComProc
+		GetBytesReceived              edge.ComProc
+		GetEstimatedEndTime           edge.ComProc
+		GetResultFilePath             edge.ComProc
+		GetState                      edge.ComProc
+		GetInterruptReason            edge.ComProc
+		Cancel                        edge.ComProc
+	}
+}
+
+type deferral struct {
+	vtbl *struct {
+		iUnknownVtbl
+		Complete edge.ComProc
+	}
+}
+
+// webviewDownload keeps the operation and the handlers of a download alive until it has finished. It is only used on
+// the main thread.
+type webviewDownload struct {
+	id        string
+	operation *downloadOperation
+	handlers  []*eventHandler
+	tokens    [2]int64
+}
+
+// setupDownloads registers the handler of the downloads. Runtimes before 1.0.902 don't report them and keep their
+// own handling.
+func (f *Frontend) setupDownloads() error {
+	if !f.downloads.Enabled() {
+		return nil
+	}
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var webview4 *coreWebView2_4
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_4)), uintptr(unsafe.Pointer(&webview4)))
+	if hr != 0 || webview4 == nil {
+		f.logger.Warning("The WebView2 runtime doesn't support handling downloads")
+		return nil
+	}
+	defer webview4.vtbl.Release.Call(uintptr(unsafe.Pointer(webview4)))
+	handler := newEventHandler(f.onDownloadStarting)
+	f.comHandlers = append(f.comHandlers, handler)
+	var token int64
+	hr, _, _ = webview4.vtbl.AddDownloadStarting.Call(uintptr(unsafe.Pointer(webview4)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+	if hr != 0 {
+		return fmt.Errorf("unable to add the download handler: 0x%x", hr)
+	}
+	return nil
+}
+
+func (f *Frontend) onDownloadStarting(_args unsafe.Pointer) uintptr {
+	args := (*downloadStartingEventArgs)(_args)
+	var operation *downloadOperation
+	hr, _, _ := args.vtbl.GetDownloadOperation.Call(uintptr(_args), uintptr(unsafe.Pointer(&operation)))
+	if hr != 0 || operation == nil {
+		f.logger.Error("Unable to get the download operation: 0x%x", hr)
+		return 0
+	}
+	this := uintptr(unsafe.Pointer(operation))
+	uri, err := getString(operation.vtbl.GetUri, this)
+	if err != nil {
+		f.logger.Error("Unable to get the URI of the download: %s", err)
+	}
+	mimeType, _ := getString(operation.vtbl.GetMimeType, this)
+	// The default path of WebView2 contains the filename of the download
+	defaultPath, _ := getString(args.vtbl.GetResultFilePath, uintptr(_args))
+
+	destination, ok := f.downloads.Start(uri, filepath.Base(defaultPath), mimeType)
+	if !ok {
+		args.vtbl.PutCancel.Call(uintptr(_args), 1)
+		operation.vtbl.Release.Call(this)
+		return 0
+	}
+	args.vtbl.PutHandled.Call(uintptr(_args), 1)
+	if !destination.Prompt {
+		f.beginDownload(destination.ID, destination.Path, args, operation)
+		return 0
+	}
+
+	// The download waits for the path until the deferral has been completed
+	var _deferral *deferral
+	hr, _, _ = args.vtbl.GetDeferral.Call(uintptr(_args), uintptr(unsafe.Pointer(&_deferral)))
+	if hr != 0 || _deferral == nil {
+		f.logger.Error("Unable to defer the download: 0x%x", hr)
+		f.beginDownload(destination.ID, destination.Path, args, operation)
+		return 0
+	}
+	args.vtbl.AddRef.Call(uintptr(_args))
+	go func() {
+		path, err := f.SaveFileDialog(frontend.SaveDialogOptions{
+			DefaultDirectory: filepath.Dir(destination.Path),
+			DefaultFilename:  filepath.Base(destination.Path),
+		})
+		if err != nil {
+			f.logger.Error("Unable to ask where to save the download: %s", err)
+		}
+		f.mainWindow.Invoke(func() {
+			if path == "" {
+				args.vtbl.PutCancel.Call(uintptr(_args), 1)
+				operation.vtbl.Release.Call(this)
+				f.downloads.Finish(destination.ID, frontend.ErrDownloadCancelled)
+			} else {
+				f.beginDownload(destination.ID, path, args, operation)
+			}
+			_deferral.vtbl.Complete.Call(uintptr(unsafe.Pointer(_deferral)))
+			_deferral.vtbl.Release.Call(uintptr(unsafe.Pointer(_deferral)))
+			args.vtbl.Release.Call(uintptr(_args))
+		})
+	}()
+	return 0
+}
+
+// beginDownload saves the download to the path and reports its progress until it has finished
+func (f *Frontend) beginDownload(id string, path string, args *downloadStartingEventArgs, operation *downloadOperation) {
+	this := uintptr(unsafe.Pointer(operation))
+	if err := putString(args.vtbl.PutResultFilePath, uintptr(unsafe.Pointer(args)), path); err != nil {
+		f.logger.Error("Unable to set the path of the download: %s", err)
+	}
+	item := &webviewDownload{id: id, operation: operation}
+	item.handlers = []*eventHandler{
+		newEventHandler(func(unsafe.Pointer) uintptr {
+			var received, total int64
+			operation.vtbl.GetBytesReceived.Call(this, uintptr(unsafe.Pointer(&received)))
+			operation.vtbl.GetTotalBytesToReceive.Call(this, uintptr(unsafe.Pointer(&total)))
+			f.downloads.Progress(id, received, total)
+			return 0
+		}),
+		newEventHandler(func(unsafe.Pointer) uintptr {
+			f.onDownloadStateChanged(item)
+			return 0
+		}),
+	}
+	f.webviewDownloads[id] = item
+	operation.vtbl.AddBytesReceivedChanged.Call(this, uintptr(unsafe.Pointer(item.handlers[0])), uintptr(unsafe.Pointer(&item.tokens[0])))
+	operation.vtbl.AddStateChanged.Call(this, uintptr(unsafe.Pointer(item.handlers[1])), uintptr(unsafe.Pointer(&item.tokens[1])))
+	f.downloads.Begin(id, path, func() {
+		f.mainWindow.Invoke(func() {
+			if f.webviewDownloads[id] != nil {
+				operation.vtbl.Cancel.Call(this)
+			}
+		})
+	})
+}
+
+func (f *Frontend) onDownloadStateChanged(item *webviewDownload) {
+	this := uintptr(unsafe.Pointer(item.operation))
+	var state, reason int32
+	item.operation.vtbl.GetState.Call(this, uintptr(unsafe.Pointer(&state)))
+	if state == downloadStateInProgress {
+		return
+	}
+	var err error
+	if state == downloadStateInterrupted {
+		item.operation.vtbl.GetInterruptReason.Call(this, uintptr(unsafe.Pointer(&reason)))
+		switch reason {
+		case downloadInterruptReasonUserPaused:
+			return
+		case downloadInterruptReasonUserCanceled, downloadInterruptReasonUserShutdown:
+			err = frontend.ErrDownloadCancelled
+		default:
+			err = fmt.Errorf("the download has been interrupted: reason %d", reason)
+		}
+	}
+	var received, total int64
+	item.operation.vtbl.GetBytesReceived.Call(this, uintptr(unsafe.Pointer(&received)))
+	item.operation.vtbl.GetTotalBytesToReceive.Call(this, uintptr(unsafe.Pointer(&total)))
+	f.downloads.Progress(item.id, received, total)
+	f.downloads.Finish(item.id, err)
+
+	// The handlers are removed later, as this one is running
+	delete(f.webviewDownloads, item.id)
+	go f.mainWindow.Invoke(func() {
+		item.operation.vtbl.RemoveBytesReceivedChanged.Call(this, uintptr(item.tokens[0]))
+		item.operation.vtbl.RemoveStateChanged.Call(this, uintptr(item.tokens[1]))
+		item.operation.vtbl.Release.Call(this)
+	})
+}
+
+// DownloadCancel cancels the download in flight
+func (f *Frontend) DownloadCancel(id string) {
+	f.downloads.Cancel(id)
+}
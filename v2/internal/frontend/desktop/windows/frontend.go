@@ -14,6 +14,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"text/template"
 	"time"
 	"unsafe"
@@ -65,6 +66,37 @@ type Frontend struct {
 	// Windows build number
 	versionInfo     *operatingsystem.WindowsVersionInfo
 	resizeDebouncer func(f func())
+
+	contentRecovery *frontend.ContentRecovery
+
+	// The ids of the scripts of the user content, and the handlers that wait for them. Only used on the main thread.
+	userContent         *frontend.UserContentManager
+	userContentScripts  map[string]string
+	userContentHandlers map[*addScriptCompletedHandler]struct{}
+
+	nativeViews *frontend.NativeViewManager
+	zoom        *frontend.Zoom
+	audioMuted  atomic.Bool
+
+	originPolicy *frontend.OriginPolicy
+	webview      *edge.ICoreWebView2
+
+	tlsPolicy *frontend.TLSPolicy
+	// comHandlers keeps the event handlers that have been registered with the webview alive
+	comHandlers []*eventHandler
+
+	authenticationPolicy   *frontend.AuthenticationPolicy
+	authenticationFailures map[string]int
+
+	navigationPolicy *frontend.NavigationPolicy
+	permissionPolicy *frontend.PermissionPolicy
+
+	downloads        *frontend.DownloadManager
+	webviewDownloads map[string]*webviewDownload
+
+	sharer *sharer
+
+	inMemoryProfilePath string
 }
 
 func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.Logger, appBindings *binding.Bindings, dispatcher frontend.Dispatcher) *Frontend {
@@ -73,13 +105,31 @@ func NewFrontend(ctx context.Context, appoptions *options.App, myLogger *logger.
 	versionInfo, _ := operatingsystem.GetWindowsVersionInfo()
 
 	result := &Frontend{
-		frontendOptions: appoptions,
-		logger:          myLogger,
-		bindings:        appBindings,
-		dispatcher:      dispatcher,
-		ctx:             ctx,
-		versionInfo:     versionInfo,
+		frontendOptions:     appoptions,
+		logger:              myLogger,
+		bindings:            appBindings,
+		dispatcher:          dispatcher,
+		ctx:                 ctx,
+		versionInfo:         versionInfo,
+		contentRecovery:     frontend.NewContentRecovery(appoptions.WebviewRecovery),
+		userContent:         frontend.NewUserContentManager(),
+		userContentScripts:  map[string]string{},
+		userContentHandlers: map[*addScriptCompletedHandler]struct{}{},
+		nativeViews:         frontend.NewNativeViewManager(),
+
+		webviewDownloads: map[string]*webviewDownload{},
+	}
+	if appoptions.Media != nil {
+		result.audioMuted.Store(appoptions.Media.Muted)
 	}
+	result.downloads = frontend.NewDownloadManager(appoptions.Downloads, frontend.EmitDownloads(ctx))
+
+	appDataDir, _ := ctx.Value("appdatadir").(string)
+	initialZoom := 1.0
+	if appoptions.Windows != nil && appoptions.Windows.ZoomFactor > 0 {
+		initialZoom = appoptions.Windows.ZoomFactor
+	}
+	result.zoom = frontend.NewZoom(appoptions.Zoom, appDataDir, initialZoom)
 
 	if appoptions.Windows != nil {
 		if appoptions.Windows.ResizeDebounceMS > 0 {
@@ -126,15 +176,15 @@ func (f *Frontend) WindowReload() {
 }
 
 func (f *Frontend) WindowSetSystemDefaultTheme() {
-	f.mainWindow.SetTheme(windows.SystemDefault)
+	f.setTheme(windows.SystemDefault)
 }
 
 func (f *Frontend) WindowSetLightTheme() {
-	f.mainWindow.SetTheme(windows.Light)
+	f.setTheme(windows.Light)
 }
 
 func (f *Frontend) WindowSetDarkTheme() {
-	f.mainWindow.SetTheme(windows.Dark)
+	f.setTheme(windows.Dark)
 }
 
 func (f *Frontend) Run(ctx context.Context) error {
@@ -210,6 +260,7 @@ func (f *Frontend) WindowClose() {
 
 func (f *Frontend) RunMainLoop() {
 	_ = winc.RunMainLoop()
+	f.removeInMemoryProfile()
 }
 
 func (f *Frontend) WindowCenter() {
@@ -224,6 +275,12 @@ func (f *Frontend) WindowSetAlwaysOnTop(b bool) {
 	f.mainWindow.SetAlwaysOnTop(b)
 }
 
+func (f *Frontend) WindowSetSkipTaskbar(skip bool) {
+	f.mainWindow.Invoke(func() {
+		f.mainWindow.SetSkipTaskbar(skip)
+	})
+}
+
 func (f *Frontend) WindowSetPosition(x, y int) {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
@@ -437,6 +494,32 @@ func (f *Frontend) WindowPrint() {
 	f.ExecJS("window.print();")
 }
 
+func (f *Frontend) WindowSetZoom(factor float64) {
+	factor, err := f.zoom.Set(factor)
+	if err != nil {
+		f.logger.Error("Unable to persist the zoom factor: %s", err)
+	}
+	f.mainWindow.Invoke(func() {
+		f.chromium.PutZoomFactor(factor)
+	})
+}
+
+func (f *Frontend) WindowGetZoom() float64 {
+	return f.zoom.Factor()
+}
+
+func (f *Frontend) WindowRecycle() {
+	f.recycleContent(options.WebviewCrashReasonRecycled)
+}
+
+func (f *Frontend) recycleContent(reason options.WebviewCrashReason) {
+	f.contentRecovery.Recover(f.ctx, reason, f.startURL.String(), f.MessageDialog, func(target string) {
+		f.mainWindow.Invoke(func() {
+			f.chromium.Navigate(target)
+		})
+	})
+}
+
 func (f *Frontend) setupChromium() {
 	chromium := f.chromium
 
@@ -445,6 +528,11 @@ func (f *Frontend) setupChromium() {
 		disableFeatues = append(disableFeatues, "msSmartScreenProtection")
 	}
 
+	enableFeatures := []string{}
+	if video := f.frontendOptions.VideoPlayback; video != nil && video.HardwareOverlays {
+		enableFeatures = append(enableFeatures, "DirectCompositionSoftwareOverlays", "DirectCompositionUnderlays")
+	}
+
 	if opts := f.frontendOptions.Windows; opts != nil {
 		chromium.DataPath = opts.WebviewUserDataPath
 		chromium.BrowserPath = opts.WebviewBrowserPath
@@ -457,6 +545,31 @@ func (f *Frontend) setupChromium() {
 		}
 	}
 
+	if path := f.webviewProfilePath(); path != "" {
+		chromium.DataPath = path
+	}
+
+	if f.frontendOptions.Authentication != nil && len(f.frontendOptions.Authentication.IntegratedServers) > 0 {
+		arg := fmt.Sprintf("--auth-server-allowlist=%s", strings.Join(f.frontendOptions.Authentication.IntegratedServers, ","))
+		chromium.AdditionalBrowserArgs = append(chromium.AdditionalBrowserArgs, arg)
+	}
+
+	// The proxy has been checked when the application was created
+	if proxy, _ := frontend.ParseProxy(f.frontendOptions.Proxy); proxy != nil {
+		chromium.AdditionalBrowserArgs = append(chromium.AdditionalBrowserArgs, "--proxy-server="+proxy.URL())
+		if len(proxy.Bypass) > 0 {
+			arg := fmt.Sprintf("--proxy-bypass-list=%s", strings.Join(proxy.Bypass, ";"))
+			chromium.AdditionalBrowserArgs = append(chromium.AdditionalBrowserArgs, arg)
+		}
+	}
+
+	chromium.AdditionalBrowserArgs = append(chromium.AdditionalBrowserArgs, f.mediaBrowserArgs()...)
+
+	if len(enableFeatures) > 0 {
+		arg := fmt.Sprintf("--enable-features=%s", strings.Join(enableFeatures, ","))
+		chromium.AdditionalBrowserArgs = append(chromium.AdditionalBrowserArgs, arg)
+	}
+
 	if len(disableFeatues) > 0 {
 		arg := fmt.Sprintf("--disable-features=%s", strings.Join(disableFeatues, ","))
 		chromium.AdditionalBrowserArgs = append(chromium.AdditionalBrowserArgs, arg)
@@ -517,6 +630,14 @@ func (f *Frontend) setupChromium() {
 				// The window has never been shown, make sure to show it
 				f.ShowWindow()
 			}
+			if kind == edge.COREWEBVIEW2_PROCESS_FAILED_KIND_RENDER_PROCESS_EXITED && f.frontendOptions.WebviewRecovery != nil {
+				// => Recover the content instead of keeping the error page.
+				go f.recycleContent(options.WebviewCrashReasonCrashed)
+			}
+		case edge.COREWEBVIEW2_PROCESS_FAILED_KIND_RENDER_PROCESS_UNRESPONSIVE:
+			if f.frontendOptions.WebviewRecovery != nil {
+				go f.recycleContent(options.WebviewCrashReasonUnresponsive)
+			}
 		}
 	}
 
@@ -530,6 +651,11 @@ func (f *Frontend) setupChromium() {
 		}
 	}
 	chromium.Resize()
+	if opts := f.frontendOptions.Windows; opts != nil && opts.Theme != windows.SystemDefault {
+		if err := f.setPreferredColorScheme(opts.Theme); err != nil {
+			f.logger.Error("Unable to set the color scheme of the webview: %s", err)
+		}
+	}
 	settings, err := chromium.GetSettings()
 	if err != nil {
 		log.Fatal(err)
@@ -543,10 +669,16 @@ func (f *Frontend) setupChromium() {
 		log.Fatal(err)
 	}
 
-	if opts := f.frontendOptions.Windows; opts != nil {
-		if opts.ZoomFactor > 0.0 {
-			chromium.PutZoomFactor(opts.ZoomFactor)
+	if factor := f.zoom.Factor(); factor != 1 {
+		chromium.PutZoomFactor(factor)
+	}
+	if f.audioMuted.Load() {
+		if err := f.putAudioMuted(true); err != nil {
+			f.logger.Error("Unable to mute the audio of the webview: %s", err)
 		}
+	}
+
+	if opts := f.frontendOptions.Windows; opts != nil {
 		err = settings.PutIsZoomControlEnabled(opts.IsZoomControlEnabled)
 		if err != nil {
 			log.Fatal(err)
@@ -577,8 +709,47 @@ func (f *Frontend) setupChromium() {
 	// Set background colour
 	f.WindowSetBackgroundColour(f.frontendOptions.BackgroundColour)
 
-	chromium.SetGlobalPermission(edge.CoreWebView2PermissionStateAllow)
+	f.originPolicy = frontend.NewOriginPolicy(f.frontendOptions.ExternalContent, f.startURL)
+
+	f.tlsPolicy = frontend.NewTLSPolicy(f.frontendOptions.TLS)
+	if err := f.setupTLS(); err != nil {
+		f.logger.Error("Unable to set up the TLS handlers: %s", err)
+	}
+
+	// WebView2 doesn't tell the challenges of the proxy apart, so its credentials aren't used
+	f.authenticationPolicy = frontend.NewAuthenticationPolicy(f.frontendOptions.Authentication, nil)
+	f.authenticationFailures = map[string]int{}
+	if err := f.setupAuthentication(); err != nil {
+		f.logger.Error("Unable to set up the authentication handler: %s", err)
+	}
+
+	f.navigationPolicy = frontend.NewNavigationPolicy(f.frontendOptions.Navigation, f.startURL, f.BrowserOpenURL)
+	if err := f.setupNavigation(); err != nil {
+		f.logger.Error("Unable to set up the navigation handlers: %s", err)
+	}
+
+	if err := f.setupDownloads(); err != nil {
+		f.logger.Error("Unable to set up the download handler: %s", err)
+	}
+
+	// The requests are allowed unless the application decides them
+	f.permissionPolicy = frontend.NewPermissionPolicy(f.frontendOptions.Permissions)
+	if f.permissionPolicy.Enabled() {
+		if err := f.setupPermissions(); err != nil {
+			f.logger.Error("Unable to set up the permission handler: %s", err)
+		}
+	} else {
+		chromium.SetGlobalPermission(edge.CoreWebView2PermissionStateAllow)
+	}
 	chromium.AddWebResourceRequestedFilter("*", edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT_ALL)
+
+	userScript, err := frontend.InjectedScriptSource(f.frontendOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if userScript != "" {
+		chromium.Init(userScript)
+	}
 	chromium.Navigate(f.startURL.String())
 }
 
@@ -610,11 +781,6 @@ func (f *Frontend) processRequest(req *edge.ICoreWebView2WebResourceRequest, arg
 		reqHeaders.Release()
 	}
 
-	if f.assets == nil {
-		// We are using the devServer let the WebView2 handle the request with its default handler
-		return
-	}
-
 	//Get the request
 	uri, _ := req.GetUri()
 	reqUri, err := url.ParseRequestURI(uri)
@@ -623,6 +789,15 @@ func (f *Frontend) processRequest(req *edge.ICoreWebView2WebResourceRequest, arg
 		return
 	}
 
+	if f.blockDocument(reqUri, args) {
+		return
+	}
+
+	if f.assets == nil {
+		// We are using the devServer let the WebView2 handle the request with its default handler
+		return
+	}
+
 	if reqUri.Scheme != f.startURL.Scheme {
 		// Let the WebView2 handle the request with its default handler
 		return
@@ -670,6 +845,10 @@ var edgeMap = map[string]uintptr{
 }
 
 func (f *Frontend) processMessage(message string) {
+	if !f.acceptMessage() {
+		return
+	}
+
 	if message == "drag" {
 		if !f.mainWindow.IsFullScreen() {
 			err := f.startDrag()
@@ -691,6 +870,15 @@ func (f *Frontend) processMessage(message string) {
 		)
 
 		f.ExecJS(cmd)
+
+		if limit := f.contentRecovery.MemoryLimitMB(); limit > 0 {
+			f.ExecJS(fmt.Sprintf("window.wails.setMemoryLimit(%d);", limit))
+		}
+		return
+	}
+
+	if message == "wails:memorylimit" {
+		go f.recycleContent(options.WebviewCrashReasonMemoryLimit)
 		return
 	}
 
@@ -714,6 +902,10 @@ func (f *Frontend) processMessage(message string) {
 }
 
 func (f *Frontend) processMessageWithAdditionalObjects(message string, sender *edge.ICoreWebView2, args *edge.ICoreWebView2WebMessageReceivedEventArgs) {
+	if !f.acceptMessage() {
+		return
+	}
+
 	if strings.HasPrefix(message, "file:drop") {
 		if !f.frontendOptions.DragAndDrop.EnableFileDrop {
 			return
@@ -826,7 +1018,31 @@ func (f *Frontend) ExecJS(js string) {
 	})
 }
 
+// navigationCompletedEventArgs gives access to IsSuccess, which isn't exposed by edge
+type navigationCompletedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetIsSuccess edge.ComProc
+	}
+}
+
+func navigationSucceeded(args *edge.ICoreWebView2NavigationCompletedEventArgs) bool {
+	if args == nil {
+		return false
+	}
+	completed := (*navigationCompletedEventArgs)(unsafe.Pointer(args))
+	var success int32
+	hr, _, _ := completed.vtbl.GetIsSuccess.Call(uintptr(unsafe.Pointer(args)), uintptr(unsafe.Pointer(&success)))
+	return hr == 0 && success != 0
+}
+
 func (f *Frontend) navigationCompleted(sender *edge.ICoreWebView2, args *edge.ICoreWebView2NavigationCompletedEventArgs) {
+	f.webview = sender
+
+	if navigationSucceeded(args) {
+		f.contentRecovery.Navigated()
+	}
+
 	if f.frontendOptions.OnDomReady != nil {
 		go f.frontendOptions.OnDomReady(f.ctx)
 	}
@@ -839,6 +1055,10 @@ func (f *Frontend) navigationCompleted(sender *edge.ICoreWebView2, args *edge.IC
 		f.ExecJS("window.wails.flags.enableWailsDragAndDrop = true;")
 	}
 
+	if f.zoom.Shortcuts() {
+		f.ExecJS("window.wails.flags.enableZoomShortcuts = true;")
+	}
+
 	if f.hasStarted {
 		return
 	}
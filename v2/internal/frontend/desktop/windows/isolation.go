@@ -0,0 +1,115 @@
+//go:build windows
+
+package windows
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 exposes neither the source of the document nor the resource context of a request, which are needed to
+isolate third-party origins. The following types mirror the beginning of the COM interfaces to call them.
+WebView2 only provides `window.chrome.webview` to the top-level document, so its origin decides if a message is
+accepted.
+*/
+
+type iUnknownVtbl struct {
+	QueryInterface edge.ComProc
+	AddRef         edge.ComProc
+	Release        edge.ComProc
+}
+
+type coreWebView2 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetSettings edge.ComProc
+		GetSource   edge.ComProc
+	}
+}
+
+type webResourceRequestedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetRequest         edge.ComProc
+		GetResponse        edge.ComProc
+		PutResponse        edge.ComProc
+		GetDeferral        edge.ComProc
+		GetResourceContext edge.ComProc
+	}
+}
+
+// acceptMessage reports if the top-level document is allowed to use the runtime bridge
+func (f *Frontend) acceptMessage() bool {
+	if !f.originPolicy.Isolated() {
+		return true
+	}
+
+	// The messages are rejected until the source of the document is known
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		if err != nil {
+			f.logger.Error(err.Error())
+		}
+		return false
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var _source *uint16
+	_, _, err = webview.vtbl.GetSource.Call(
+		uintptr(unsafe.Pointer(webview)),
+		uintptr(unsafe.Pointer(&_source)),
+	)
+	if err != nil && !errors.Is(err, windows.ERROR_SUCCESS) {
+		f.logger.Error("Unable to get the source of the document: %s", err)
+		return false
+	}
+	source := windows.UTF16PtrToString(_source)
+	windows.CoTaskMemFree(unsafe.Pointer(_source))
+
+	if !f.originPolicy.Trusted(frontend.OriginOf(source)) {
+		f.logger.Debug("Dropped message of third-party document %s", source)
+		return false
+	}
+	return true
+}
+
+// blockDocument responds with 403 to requests for documents that can't be loaded. It returns false if the request
+// is allowed.
+func (f *Frontend) blockDocument(reqUri *url.URL, args *edge.ICoreWebView2WebResourceRequestedEventArgs) bool {
+	if f.originPolicy.CanNavigate(reqUri.String()) {
+		return false
+	}
+
+	eventArgs := (*webResourceRequestedEventArgs)(unsafe.Pointer(args))
+	var resourceContext edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT
+	_, _, err := eventArgs.vtbl.GetResourceContext.Call(
+		uintptr(unsafe.Pointer(eventArgs)),
+		uintptr(unsafe.Pointer(&resourceContext)),
+	)
+	if err != nil && !errors.Is(err, windows.ERROR_SUCCESS) {
+		f.logger.Error("Unable to get the resource context of %s: %s", reqUri, err)
+	} else if resourceContext != edge.COREWEBVIEW2_WEB_RESOURCE_CONTEXT_DOCUMENT {
+		return false
+	}
+
+	f.logger.Debug("Blocked third-party document %s", reqUri)
+	response, err := f.chromium.Environment().CreateWebResourceResponse(nil, http.StatusForbidden, http.StatusText(http.StatusForbidden), "")
+	if err != nil {
+		f.logger.Error("Unable to block %s: %s", reqUri, err)
+		return true
+	}
+	defer response.Release()
+
+	err = args.PutResponse(response)
+	if err != nil {
+		f.logger.Error("Unable to block %s: %s", reqUri, err)
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 doesn't expose IsMuted of ICoreWebView2_8, which inherits 78 methods and follows add_IsMutedChanged,
+remove_IsMutedChanged and get_IsMuted. The autoplay policy and the background throttling are switches of the browser process.
+*/
+
+var iidICoreWebView2_8 = windows.GUID{Data1: 0xe9632730, Data2: 0x6e1e, Data3: 0x43ab, Data4: [8]byte{0xb7, 0xb8, 0x7b, 0x2c, 0x9e, 0x62, 0xe0, 0x94}}
+
+type coreWebView2_8 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_          [81]edge.ComProc
+		PutIsMuted edge.ComProc
+	}
+}
+
+// mediaBrowserArgs returns the switches of the browser process for the media options
+func (f *Frontend) mediaBrowserArgs() []string {
+	media := f.frontendOptions.Media
+	if media == nil {
+		return nil
+	}
+	var args []string
+	if media.AutoplayAllowed {
+		args = append(args, "--autoplay-policy=no-user-gesture-required")
+	}
+	if media.DisableBackgroundThrottling {
+		args = append(args, "--disable-background-timer-throttling", "--disable-renderer-backgrounding", "--disable-backgrounding-occluded-windows")
+	}
+	return args
+}
+
+func (f *Frontend) WindowSetAudioMuted(muted bool) {
+	f.audioMuted.Store(muted)
+	f.mainWindow.Invoke(func() {
+		if err := f.putAudioMuted(muted); err != nil {
+			f.logger.Error("Unable to mute the audio of the webview: %s", err)
+		}
+	})
+}
+
+func (f *Frontend) WindowIsAudioMuted() bool {
+	return f.audioMuted.Load()
+}
+
+// putAudioMuted mutes the audio of the webview, which is supported from runtime 1.0.992. It must be called on the
+// main thread, before the webview has been created the state is applied by setupChromium.
+func (f *Frontend) putAudioMuted(muted bool) error {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var webview8 *coreWebView2_8
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_8)), uintptr(unsafe.Pointer(&webview8)))
+	if hr != 0 || webview8 == nil {
+		return fmt.Errorf("the WebView2 runtime doesn't support muting the audio")
+	}
+	defer webview8.vtbl.Release.Call(uintptr(unsafe.Pointer(webview8)))
+
+	hr, _, _ = webview8.vtbl.PutIsMuted.Call(uintptr(unsafe.Pointer(webview8)), uintptr(boolToInt(muted)))
+	if hr != 0 {
+		return fmt.Errorf("unable to mute the audio: 0x%x", hr)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@
 package windows
 
 import (
+	"github.com/wailsapp/wails/v2/internal/frontend"
 	"github.com/wailsapp/wails/v2/internal/frontend/desktop/windows/winc"
 	"github.com/wailsapp/wails/v2/pkg/menu"
 )
@@ -44,23 +45,37 @@ func (w *Window) SetApplicationMenu(menu *menu.Menu) {
 	processMenu(w, menu)
 }
 
-func processMenu(window *Window, menu *menu.Menu) {
+func processMenu(window *Window, inmenu *menu.Menu) {
 	mainMenu := window.NewMenu()
-	for _, menuItem := range menu.Items {
+	for _, menuItem := range inmenu.Items {
+		if menuItem.Role == menu.EditMenuRole {
+			menuItem = frontend.EditMenu()
+		}
 		submenu := mainMenu.AddSubMenu(menuItem.Label)
 		if menuItem.SubMenu != nil {
 			for _, menuItem := range menuItem.SubMenu.Items {
-				processMenuItem(submenu, menuItem)
+				processMenuItem(window, submenu, menuItem)
 			}
 		}
 	}
 	mainMenu.Show()
 }
 
-func processMenuItem(parent *winc.MenuItem, menuItem *menu.MenuItem) {
+func processMenuItem(window *Window, parent *winc.MenuItem, menuItem *menu.MenuItem) {
 	if menuItem.Hidden {
 		return
 	}
+	if menuItem.Role == menu.EditMenuRole {
+		menuItem = frontend.EditMenu()
+	} else if item, ok := frontend.EditRoleItem(menuItem, func(command frontend.EditCommand) {
+		_ = window.ExecuteEditCommand(command)
+	}); ok {
+		addEditRoleItem(parent, item, menuItem.Role == menu.FindRole || menuItem.Accelerator != nil)
+		return
+	} else if menuItem.Role != 0 {
+		// The other roles are macOS only
+		return
+	}
 	switch menuItem.Type {
 	case menu.SeparatorType:
 		parent.AddSeparator()
@@ -118,11 +133,30 @@ func processMenuItem(parent *winc.MenuItem, menuItem *menu.MenuItem) {
 	case menu.SubmenuType:
 		submenu := parent.AddSubMenu(menuItem.Label)
 		for _, menuItem := range menuItem.SubMenu.Items {
-			processMenuItem(submenu, menuItem)
+			processMenuItem(window, submenu, menuItem)
 		}
 	}
 }
 
+// addEditRoleItem adds the item of a text editing role. WebView2 handles the standard editing shortcuts itself, so
+// they are only shown in the menu, the key presses would otherwise execute the commands twice. WebView2 doesn't handle
+// Find and custom accelerators, they are registered.
+func addEditRoleItem(parent *winc.MenuItem, item *menu.MenuItem, registerShortcut bool) {
+	label := item.Label
+	shortcut := acceleratorToWincShortcut(item.Accelerator)
+	if !registerShortcut && shortcut.Key != 0 {
+		label += "\t" + shortcut.String()
+		shortcut = winc.Shortcut{}
+	}
+	newItem := parent.AddItem(label, shortcut)
+	newItem.OnClick().Bind(func(e *winc.Event) {
+		item.Click(&menu.CallbackData{
+			MenuItem: item,
+		})
+	})
+	newItem.SetEnabled(!item.Disabled)
+}
+
 func (f *Frontend) MenuSetApplicationMenu(menu *menu.Menu) {
 	f.mainWindow.SetApplicationMenu(menu)
 }
@@ -0,0 +1,73 @@
+//go:build windows
+
+package windows
+
+import (
+	"math"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/windows/winc/w32"
+	"golang.org/x/sys/windows"
+)
+
+var procSetParent = windows.NewLazySystemDLL("user32.dll").NewProc("SetParent")
+
+// WindowEmbedNativeView makes the window a child of the main window, which is placed above the webview. The handle
+// is a HWND.
+func (f *Frontend) WindowEmbedNativeView(id string, handle uintptr) error {
+	bounds, err := f.nativeViews.Add(id, handle)
+	if err != nil {
+		return err
+	}
+	f.mainWindow.Invoke(func() {
+		hwnd := w32.HWND(handle)
+		style := uint32(w32.GetWindowLong(hwnd, w32.GWL_STYLE))
+		style = style&^(w32.WS_POPUP|w32.WS_CAPTION|w32.WS_THICKFRAME) | w32.WS_CHILD | w32.WS_CLIPSIBLINGS
+		w32.SetWindowLong(hwnd, w32.GWL_STYLE, style)
+		procSetParent.Call(handle, uintptr(f.mainWindow.Handle()))
+		setNativeViewBounds(hwnd, bounds)
+	})
+	return nil
+}
+
+// WindowRemoveNativeView hides the window and makes it a top-level window again
+func (f *Frontend) WindowRemoveNativeView(id string) {
+	handle, removed := f.nativeViews.Remove(id)
+	if !removed {
+		return
+	}
+	f.mainWindow.Invoke(func() {
+		hwnd := w32.HWND(handle)
+		w32.ShowWindow(hwnd, w32.SW_HIDE)
+		procSetParent.Call(handle, 0)
+		style := uint32(w32.GetWindowLong(hwnd, w32.GWL_STYLE)) &^ w32.WS_CHILD
+		w32.SetWindowLong(hwnd, w32.GWL_STYLE, style)
+	})
+}
+
+func (f *Frontend) WindowSetNativeViewBounds(bounds frontend.NativeViewBounds) {
+	handle, embedded := f.nativeViews.SetBounds(bounds)
+	if !embedded {
+		return
+	}
+	f.mainWindow.Invoke(func() {
+		setNativeViewBounds(w32.HWND(handle), bounds)
+	})
+}
+
+// setNativeViewBounds places the window over the placeholder. The webview fills the client area of the main window,
+// so the CSS pixels of the viewport only have to be scaled to physical pixels.
+func setNativeViewBounds(hwnd w32.HWND, bounds frontend.NativeViewBounds) {
+	if !bounds.Visible || bounds.Width <= 0 || bounds.Height <= 0 {
+		w32.SetWindowPos(hwnd, 0, 0, 0, 0, 0, w32.SWP_NOMOVE|w32.SWP_NOSIZE|w32.SWP_NOZORDER|w32.SWP_NOACTIVATE|w32.SWP_HIDEWINDOW)
+		return
+	}
+	scale := bounds.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	scaled := func(value int) int {
+		return int(math.Round(float64(value) * scale))
+	}
+	w32.SetWindowPos(hwnd, w32.HWND_TOP, scaled(bounds.X), scaled(bounds.Y), scaled(bounds.Width), scaled(bounds.Height), w32.SWP_NOACTIVATE|w32.SWP_SHOWWINDOW)
+}
@@ -0,0 +1,138 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+)
+
+/*
+go-webview2 doesn't expose NavigationStarting and NewWindowRequested of ICoreWebView2, which follow 4 and 41 methods.
+NavigationStarting is only raised for the main frame.
+*/
+
+type coreWebView2Navigation struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                     [4]edge.ComProc
+		AddNavigationStarting edge.ComProc
+		_                     [36]edge.ComProc
+		AddNewWindowRequested edge.ComProc
+	}
+}
+
+type navigationStartingEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetUri             edge.ComProc
+		GetIsUserInitiated edge.ComProc
+		GetIsRedirected    edge.ComProc
+		GetRequestHeaders  edge.ComProc
+		GetCancel          edge.ComProc
+		PutCancel          edge.ComProc
+	}
+}
+
+type newWindowRequestedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetUri             edge.ComProc
+		PutNewWindow       edge.ComProc
+		GetNewWindow       edge.ComProc
+		PutHandled         edge.ComProc
+		GetHandled         edge.ComProc
+		GetIsUserInitiated edge.ComProc
+	}
+}
+
+// setupNavigation registers the handlers of the navigations and the new windows that are decided by the application
+func (f *Frontend) setupNavigation() error {
+	if !f.navigationPolicy.HandlesNavigations() && !f.navigationPolicy.HandlesNewWindows() {
+		return nil
+	}
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	navigation := (*coreWebView2Navigation)(unsafe.Pointer(webview))
+	var token int64
+	if f.navigationPolicy.HandlesNavigations() {
+		handler := newEventHandler(f.onNavigationStarting)
+		f.comHandlers = append(f.comHandlers, handler)
+		hr, _, _ := navigation.vtbl.AddNavigationStarting.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+		if hr != 0 {
+			return fmt.Errorf("unable to add the navigation handler: 0x%x", hr)
+		}
+	}
+	if f.navigationPolicy.HandlesNewWindows() {
+		handler := newEventHandler(f.onNewWindowRequested)
+		f.comHandlers = append(f.comHandlers, handler)
+		hr, _, _ := navigation.vtbl.AddNewWindowRequested.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+		if hr != 0 {
+			return fmt.Errorf("unable to add the new window handler: 0x%x", hr)
+		}
+	}
+	return nil
+}
+
+// navigate loads the URL after the handler of the event has returned
+func (f *Frontend) navigate(url string) {
+	go f.mainWindow.Invoke(func() {
+		f.chromium.Navigate(url)
+	})
+}
+
+func (f *Frontend) onNavigationStarting(_args unsafe.Pointer) uintptr {
+	args := (*navigationStartingEventArgs)(_args)
+	uri, err := getString(args.vtbl.GetUri, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the URI of the navigation: %s", err)
+		return 0
+	}
+	if !f.originPolicy.CanNavigate(uri) {
+		// The document is blocked by processRequest
+		return 0
+	}
+	var userInitiated int32
+	args.vtbl.GetIsUserInitiated.Call(uintptr(_args), uintptr(unsafe.Pointer(&userInitiated)))
+	allow, load := f.navigationPolicy.Navigation(uri, userInitiated != 0)
+	if allow {
+		return 0
+	}
+	if hr, _, _ := args.vtbl.PutCancel.Call(uintptr(_args), 1); hr != 0 {
+		f.logger.Error("Unable to cancel the navigation to %s: 0x%x", uri, hr)
+		return 0
+	}
+	if load != "" {
+		f.navigate(load)
+	}
+	return 0
+}
+
+func (f *Frontend) onNewWindowRequested(_args unsafe.Pointer) uintptr {
+	args := (*newWindowRequestedEventArgs)(_args)
+	uri, err := getString(args.vtbl.GetUri, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the URI of the new window: %s", err)
+		return 0
+	}
+	var userInitiated int32
+	args.vtbl.GetIsUserInitiated.Call(uintptr(_args), uintptr(unsafe.Pointer(&userInitiated)))
+	handled, load := f.navigationPolicy.NewWindow(uri, userInitiated != 0)
+	if !handled {
+		return 0
+	}
+	if hr, _, _ := args.vtbl.PutHandled.Call(uintptr(_args), 1); hr != 0 {
+		f.logger.Error("Unable to handle the new window for %s: 0x%x", uri, hr)
+		return 0
+	}
+	if load != "" {
+		f.navigate(load)
+	}
+	return 0
+}
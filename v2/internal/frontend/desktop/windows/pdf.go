@@ -0,0 +1,269 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 doesn't expose PrintToPdf of ICoreWebView2_7, which inherits 77 methods, nor CreatePrintSettings of
+ICoreWebView2Environment6, which inherits 11 methods. PrintToPdf writes the PDF to a file and completes
+asynchronously on the main thread.
+*/
+
+const printOrientationLandscape = 1
+
+var (
+	iidICoreWebView2_7           = windows.GUID{Data1: 0x79c24d83, Data2: 0x09a3, Data3: 0x45ae, Data4: [8]byte{0x94, 0x18, 0x48, 0x7f, 0x32, 0xa5, 0x87, 0x40}}
+	iidICoreWebView2Environment6 = windows.GUID{Data1: 0xe59ee362, Data2: 0xacbd, Data3: 0x4857, Data4: [8]byte{0x9a, 0x8e, 0xd3, 0x64, 0x4d, 0x94, 0x59, 0xa9}}
+)
+
+type coreWebView2_7 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_          [77]edge.ComProc
+		PrintToPdf edge.ComProc
+	}
+}
+
+type coreWebView2Environment struct {
+	vtbl *struct {
+		iUnknownVtbl
+	}
+}
+
+type coreWebView2Environment6 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                   [11]edge.ComProc
+		CreatePrintSettings edge.ComProc
+	}
+}
+
+type printSettings struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetOrientation                edge.ComProc
+		PutOrientation                edge.ComProc
+		GetScaleFactor                edge.ComProc
+		PutScaleFactor                edge.ComProc
+		GetPageWidth                  edge.ComProc
+		PutPageWidth                  edge.ComProc
+		GetPageHeight                 edge.ComProc
+		PutPageHeight                 edge.ComProc
+		GetMarginTop                  edge.ComProc
+		PutMarginTop                  edge.ComProc
+		GetMarginBottom               edge.ComProc
+		PutMarginBottom               edge.ComProc
+		GetMarginLeft                 edge.ComProc
+		PutMarginLeft                 edge.ComProc
+		GetMarginRight                edge.ComProc
+		PutMarginRight                edge.ComProc
+		GetShouldPrintBackgrounds     edge.ComProc
+		PutShouldPrintBackgrounds     edge.ComProc
+		GetShouldPrintSelectionOnly   edge.ComProc
+		PutShouldPrintSelectionOnly   edge.ComProc
+		GetShouldPrintHeaderAndFooter edge.ComProc
+		PutShouldPrintHeaderAndFooter edge.ComProc
+		GetHeaderTitle                edge.ComProc
+		PutHeaderTitle                edge.ComProc
+		GetFooterUri                  edge.ComProc
+		PutFooterUri                  edge.ComProc
+	}
+}
+
+// printToPdfCompletedHandler is the COM handler that is called when PrintToPdf has completed, it is kept alive by
+// WindowPrintToPDF until then
+type printToPdfCompletedHandler struct {
+	vtbl      *printToPdfCompletedHandlerVtbl
+	completed func(errorCode uintptr, success bool)
+}
+
+type printToPdfCompletedHandlerVtbl struct {
+	iUnknownVtbl
+	Invoke edge.ComProc
+}
+
+var printToPdfCompletedHandlerFn = printToPdfCompletedHandlerVtbl{
+	iUnknownVtbl{
+		edge.NewComProc(func(this *printToPdfCompletedHandler, refiid, object uintptr) uintptr { return 0 }),
+		edge.NewComProc(func(this *printToPdfCompletedHandler) uintptr { return 1 }),
+		edge.NewComProc(func(this *printToPdfCompletedHandler) uintptr { return 1 }),
+	},
+	edge.NewComProc(func(this *printToPdfCompletedHandler, errorCode uintptr, success uintptr) uintptr {
+		this.completed(errorCode, uint32(success) != 0)
+		return 0
+	}),
+}
+
+// WindowPrintToPDF prints the page with PrintToPdf, which is supported from runtime 1.0.1418
+func (f *Frontend) WindowPrintToPDF(options frontend.PDFOptions) ([]byte, error) {
+	page, err := options.Page()
+	if err != nil {
+		return nil, err
+	}
+	path, err := frontend.NewPDFFile()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(chan error, 1)
+	handler := &printToPdfCompletedHandler{vtbl: &printToPdfCompletedHandlerFn}
+	handler.completed = func(errorCode uintptr, success bool) {
+		switch {
+		case errorCode != 0:
+			result <- fmt.Errorf("printing to PDF failed: 0x%x", errorCode)
+		case !success:
+			result <- fmt.Errorf("printing to PDF failed")
+		default:
+			result <- nil
+		}
+	}
+	_, err = invokeSync(f.mainWindow, func() (struct{}, error) {
+		return struct{}{}, f.printToPDF(path, options, page, handler)
+	})
+	if err == nil {
+		err = <-result
+	}
+	runtime.KeepAlive(handler)
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return frontend.ReadPDFFile(path)
+}
+
+func (f *Frontend) printToPDF(path string, options frontend.PDFOptions, page frontend.PDFPage, handler *printToPdfCompletedHandler) error {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		if err == nil {
+			err = fmt.Errorf("the webview has not been created")
+		}
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	var webview7 *coreWebView2_7
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_7)), uintptr(unsafe.Pointer(&webview7)))
+	if hr != 0 || webview7 == nil {
+		return fmt.Errorf("the WebView2 runtime doesn't support printing to PDF")
+	}
+	defer webview7.vtbl.Release.Call(uintptr(unsafe.Pointer(webview7)))
+
+	settings, err := f.printSettings(options, page)
+	if err != nil {
+		return err
+	}
+	defer settings.vtbl.Release.Call(uintptr(unsafe.Pointer(settings)))
+
+	_path, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	hr, _, _ = webview7.vtbl.PrintToPdf.Call(uintptr(unsafe.Pointer(webview7)), uintptr(unsafe.Pointer(_path)), uintptr(unsafe.Pointer(settings)), uintptr(unsafe.Pointer(handler)))
+	if hr != 0 {
+		return fmt.Errorf("unable to print to PDF: 0x%x", hr)
+	}
+	return nil
+}
+
+// printSettings creates the print settings of the options, which have to be released
+func (f *Frontend) printSettings(options frontend.PDFOptions, page frontend.PDFPage) (*printSettings, error) {
+	environment := (*coreWebView2Environment)(unsafe.Pointer(f.chromium.Environment()))
+	if environment == nil {
+		return nil, fmt.Errorf("the webview has not been created")
+	}
+	var environment6 *coreWebView2Environment6
+	hr, _, _ := environment.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(environment)), uintptr(unsafe.Pointer(&iidICoreWebView2Environment6)), uintptr(unsafe.Pointer(&environment6)))
+	if hr != 0 || environment6 == nil {
+		return nil, fmt.Errorf("the WebView2 runtime doesn't support print settings")
+	}
+	defer environment6.vtbl.Release.Call(uintptr(unsafe.Pointer(environment6)))
+
+	var settings *printSettings
+	hr, _, _ = environment6.vtbl.CreatePrintSettings.Call(uintptr(unsafe.Pointer(environment6)), uintptr(unsafe.Pointer(&settings)))
+	if hr != 0 || settings == nil {
+		return nil, fmt.Errorf("unable to create the print settings: 0x%x", hr)
+	}
+
+	this := uintptr(unsafe.Pointer(settings))
+	vtbl := settings.vtbl
+	orientation := uintptr(0)
+	if options.Landscape {
+		orientation = printOrientationLandscape
+	}
+	hr, _, _ = vtbl.PutOrientation.Call(this, orientation)
+	err := hresultError(hr)
+	for _, setter := range []struct {
+		put   edge.ComProc
+		value float64
+	}{
+		{vtbl.PutScaleFactor, page.Scale},
+		{vtbl.PutPageWidth, page.Width},
+		{vtbl.PutPageHeight, page.Height},
+		{vtbl.PutMarginTop, page.Margins.Top},
+		{vtbl.PutMarginBottom, page.Margins.Bottom},
+		{vtbl.PutMarginLeft, page.Margins.Left},
+		{vtbl.PutMarginRight, page.Margins.Right},
+	} {
+		if err == nil {
+			err = putDouble(setter.put, this, setter.value)
+		}
+	}
+	if err == nil {
+		err = putBool(vtbl.PutShouldPrintBackgrounds, this, options.PrintBackground)
+	}
+	if err == nil {
+		err = putBool(vtbl.PutShouldPrintHeaderAndFooter, this, options.HeaderAndFooter)
+	}
+	if err == nil && options.HeaderTitle != "" {
+		err = putString(vtbl.PutHeaderTitle, this, options.HeaderTitle)
+	}
+	if err == nil && options.FooterURL != "" {
+		err = putString(vtbl.PutFooterUri, this, options.FooterURL)
+	}
+	if err != nil {
+		vtbl.Release.Call(this)
+		return nil, fmt.Errorf("unable to set the print settings: %w", err)
+	}
+	return settings, nil
+}
+
+// putDouble calls a setter of a double property. On 64-bit Windows the system calls of Go also load the arguments
+// into the floating point registers, on 386 the double takes two words of the stack.
+func putDouble(setter edge.ComProc, this uintptr, value float64) error {
+	bits := math.Float64bits(value)
+	var hr uintptr
+	if unsafe.Sizeof(uintptr(0)) == 4 {
+		hr, _, _ = setter.Call(this, uintptr(uint32(bits)), uintptr(uint32(bits>>32)))
+	} else {
+		hr, _, _ = setter.Call(this, uintptr(bits))
+	}
+	return hresultError(hr)
+}
+
+// putBool calls a setter of a BOOL property
+func putBool(setter edge.ComProc, this uintptr, value bool) error {
+	_value := uintptr(0)
+	if value {
+		_value = 1
+	}
+	hr, _, _ := setter.Call(this, _value)
+	return hresultError(hr)
+}
+
+func hresultError(hr uintptr) error {
+	if hr != 0 {
+		return fmt.Errorf("0x%x", hr)
+	}
+	return nil
+}
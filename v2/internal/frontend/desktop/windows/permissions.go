@@ -0,0 +1,99 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+/*
+go-webview2 answers PermissionRequested of ICoreWebView2, which follows 20 methods, without reading the kind of the
+permission. The handler of the application is added after it, so its state is the one that is used.
+*/
+
+type coreWebView2Permissions struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                      [20]edge.ComProc
+		AddPermissionRequested edge.ComProc
+	}
+}
+
+type permissionRequestedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetUri             edge.ComProc
+		GetPermissionKind  edge.ComProc
+		GetIsUserInitiated edge.ComProc
+		GetState           edge.ComProc
+		PutState           edge.ComProc
+		GetDeferral        edge.ComProc
+	}
+}
+
+// setupPermissions registers the handler of the permission requests that are decided by the application
+func (f *Frontend) setupPermissions() error {
+	if !f.permissionPolicy.Enabled() {
+		return nil
+	}
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	permissions := (*coreWebView2Permissions)(unsafe.Pointer(webview))
+	handler := newEventHandler(f.onPermissionRequested)
+	f.comHandlers = append(f.comHandlers, handler)
+	var token int64
+	hr, _, _ := permissions.vtbl.AddPermissionRequested.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+	if hr != 0 {
+		return fmt.Errorf("unable to add the permission handler: 0x%x", hr)
+	}
+	return nil
+}
+
+// permissionOf returns the permission of a COREWEBVIEW2_PERMISSION_KIND
+func permissionOf(kind edge.CoreWebView2PermissionKind) options.Permission {
+	switch kind {
+	case edge.CoreWebView2PermissionKindMicrophone:
+		return options.PermissionMicrophone
+	case edge.CoreWebView2PermissionKindCamera:
+		return options.PermissionCamera
+	case edge.CoreWebView2PermissionKindGeolocation:
+		return options.PermissionGeolocation
+	case edge.CoreWebView2PermissionKindNotifications:
+		return options.PermissionNotifications
+	case edge.CoreWebView2PermissionKindClipboardRead:
+		return options.PermissionClipboardRead
+	}
+	return options.PermissionOther
+}
+
+func (f *Frontend) onPermissionRequested(_args unsafe.Pointer) uintptr {
+	args := (*permissionRequestedEventArgs)(_args)
+	uri, err := getString(args.vtbl.GetUri, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the URI of the permission request: %s", err)
+		return 0
+	}
+	var kind edge.CoreWebView2PermissionKind
+	args.vtbl.GetPermissionKind.Call(uintptr(_args), uintptr(unsafe.Pointer(&kind)))
+
+	state := edge.CoreWebView2PermissionStateDefault
+	switch f.permissionPolicy.Decide(frontend.OriginOf(uri), permissionOf(kind)) {
+	case options.PermissionAllow:
+		state = edge.CoreWebView2PermissionStateAllow
+	case options.PermissionDeny:
+		state = edge.CoreWebView2PermissionStateDeny
+	}
+	if hr, _, _ := args.vtbl.PutState.Call(uintptr(_args), uintptr(state)); hr != 0 {
+		f.logger.Error("Unable to answer the permission request of %s: 0x%x", uri, hr)
+	}
+	return 0
+}
@@ -0,0 +1,604 @@
+//go:build windows
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"golang.org/x/sys/windows"
+)
+
+/*
+The share UI is shown by the DataTransferManager of WinRT, which asks for the shared data with its DataRequested
+event. Desktop applications get the manager of their window with IDataTransferManagerInterop. The WinRT interfaces
+inherit IInspectable, whose 3 methods follow the methods of IUnknown. The IIDs of the parameterized interfaces are
+derived from the type signatures of their arguments.
+*/
+
+const (
+	asyncStatusStarted   = 0
+	asyncStatusCompleted = 1
+
+	eNoInterface = 0x80004002
+	eBounds      = 0x8000000b
+
+	// fileTimeout limits the time to get the storage files of the shared files
+	fileTimeout = 10 * time.Second
+)
+
+var (
+	combase                    = windows.NewLazySystemDLL("combase.dll")
+	procRoGetActivationFactory = combase.NewProc("RoGetActivationFactory")
+	procWindowsCreateString    = combase.NewProc("WindowsCreateString")
+	procWindowsDeleteString    = combase.NewProc("WindowsDeleteString")
+)
+
+var (
+	iidIUnknown                    = windows.GUID{Data1: 0x00000000, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	iidIInspectable                = windows.GUID{Data1: 0xaf86e2e0, Data2: 0xb12d, Data3: 0x4c6a, Data4: [8]byte{0x9c, 0x5a, 0xd7, 0xaa, 0x65, 0x10, 0x1e, 0x90}}
+	iidIAgileObject                = windows.GUID{Data1: 0x94ea2b94, Data2: 0xe9cc, Data3: 0x49e0, Data4: [8]byte{0xc0, 0xff, 0xee, 0x64, 0xca, 0x8f, 0x5b, 0x90}}
+	iidIAsyncInfo                  = windows.GUID{Data1: 0x00000036, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	iidIDataTransferManagerInterop = windows.GUID{Data1: 0x3a3dcd6c, Data2: 0x3eab, Data3: 0x43dc, Data4: [8]byte{0xbc, 0xde, 0x45, 0x67, 0x1c, 0xe8, 0x00, 0xc8}}
+	iidIDataTransferManager        = windows.GUID{Data1: 0xa5caee9b, Data2: 0x8708, Data3: 0x49d1, Data4: [8]byte{0x8d, 0x36, 0x67, 0xd2, 0x5a, 0x8d, 0xa0, 0x0c}}
+	iidIDataPackage2               = windows.GUID{Data1: 0x041c1fe9, Data2: 0x2409, Data3: 0x45e1, Data4: [8]byte{0xa5, 0x38, 0x4c, 0x53, 0xee, 0xee, 0x04, 0xa7}}
+	iidIUriRuntimeClassFactory     = windows.GUID{Data1: 0x44a9796f, Data2: 0x723e, Data3: 0x4fdf, Data4: [8]byte{0xa2, 0x18, 0x03, 0x3e, 0x75, 0xb0, 0xc0, 0x84}}
+	iidIStorageFileStatics         = windows.GUID{Data1: 0x5984c710, Data2: 0xdaf2, Data3: 0x43c8, Data4: [8]byte{0x8b, 0xb4, 0xa4, 0xd3, 0xea, 0xcf, 0xd0, 0x3f}}
+	iidIStorageItem                = windows.GUID{Data1: 0x4207a996, Data2: 0xca2f, Data3: 0x42f7, Data4: [8]byte{0xbd, 0xe8, 0x8b, 0x10, 0x45, 0x7a, 0x7f, 0x30}}
+
+	// IIterable<IStorageItem>, IIterator<IStorageItem> and TypedEventHandler<DataTransferManager, DataRequestedEventArgs>
+	iidIIterableStorageItem = windows.GUID{Data1: 0xbb8b8418, Data2: 0x65d1, Data3: 0x544b, Data4: [8]byte{0xb0, 0x83, 0x6d, 0x17, 0x2f, 0x56, 0x8c, 0x73}}
+	iidIIteratorStorageItem = windows.GUID{Data1: 0x05b487c2, Data2: 0x3830, Data3: 0x5d3c, Data4: [8]byte{0x98, 0xda, 0x25, 0xfa, 0x11, 0x54, 0x2d, 0xbd}}
+	iidDataRequestedHandler = windows.GUID{Data1: 0xec6f9cc8, Data2: 0x46d0, Data3: 0x5e0e, Data4: [8]byte{0xb4, 0xd2, 0x7d, 0x77, 0x73, 0xae, 0x37, 0xa0}}
+)
+
+type iUnknown struct {
+	vtbl *iUnknownVtbl
+}
+
+type iInspectableVtbl struct {
+	iUnknownVtbl
+	GetIids             edge.ComProc
+	GetRuntimeClassName edge.ComProc
+	GetTrustLevel       edge.ComProc
+}
+
+type dataTransferManagerInterop struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetForWindow         edge.ComProc
+		ShowShareUIForWindow edge.ComProc
+	}
+}
+
+type dataTransferManager struct {
+	vtbl *struct {
+		iInspectableVtbl
+		AddDataRequested    edge.ComProc
+		RemoveDataRequested edge.ComProc
+	}
+}
+
+type dataRequestedEventArgs struct {
+	vtbl *struct {
+		iInspectableVtbl
+		GetRequest edge.ComProc
+	}
+}
+
+type dataRequest struct {
+	vtbl *struct {
+		iInspectableVtbl
+		GetData edge.ComProc
+	}
+}
+
+type dataPackage struct {
+	vtbl *struct {
+		iInspectableVtbl
+		GetView                 edge.ComProc
+		GetProperties           edge.ComProc
+		_                       [8]edge.ComProc
+		SetText                 edge.ComProc
+		_                       [5]edge.ComProc
+		SetStorageItemsReadOnly edge.ComProc
+	}
+}
+
+type dataPackage2 struct {
+	vtbl *struct {
+		iInspectableVtbl
+		SetApplicationLink edge.ComProc
+		SetWebLink         edge.ComProc
+	}
+}
+
+type dataPackagePropertySet struct {
+	vtbl *struct {
+		iInspectableVtbl
+		GetTitle edge.ComProc
+		PutTitle edge.ComProc
+	}
+}
+
+type uriRuntimeClassFactory struct {
+	vtbl *struct {
+		iInspectableVtbl
+		CreateUri edge.ComProc
+	}
+}
+
+type storageFileStatics struct {
+	vtbl *struct {
+		iInspectableVtbl
+		GetFileFromPathAsync edge.ComProc
+	}
+}
+
+type asyncInfo struct {
+	vtbl *struct {
+		iInspectableVtbl
+		GetId     edge.ComProc
+		GetStatus edge.ComProc
+	}
+}
+
+// asyncOperation mirrors IAsyncOperation<StorageFile>
+type asyncOperation struct {
+	vtbl *struct {
+		iInspectableVtbl
+		PutCompleted edge.ComProc
+		GetCompleted edge.ComProc
+		GetResults   edge.ComProc
+	}
+}
+
+func release(object unsafe.Pointer) {
+	(*iUnknown)(object).vtbl.Release.Call(uintptr(object))
+}
+
+func addRef(object unsafe.Pointer) {
+	(*iUnknown)(object).vtbl.AddRef.Call(uintptr(object))
+}
+
+// hstring is a WinRT string, which is created with newHString and freed with deleteHString
+type hstring uintptr
+
+func newHString(value string) (hstring, error) {
+	_value, err := windows.UTF16FromString(value)
+	if err != nil {
+		return 0, err
+	}
+	var result hstring
+	hr, _, _ := procWindowsCreateString.Call(uintptr(unsafe.Pointer(&_value[0])), uintptr(len(_value)-1), uintptr(unsafe.Pointer(&result)))
+	if hr != 0 {
+		return 0, fmt.Errorf("unable to create the string: 0x%x", hr)
+	}
+	return result, nil
+}
+
+func deleteHString(value hstring) {
+	procWindowsDeleteString.Call(uintptr(value))
+}
+
+// activationFactory returns the interface of the activation factory of the WinRT class
+func activationFactory(className string, iid *windows.GUID) (unsafe.Pointer, error) {
+	name, err := newHString(className)
+	if err != nil {
+		return nil, err
+	}
+	defer deleteHString(name)
+	var factory unsafe.Pointer
+	hr, _, _ := procRoGetActivationFactory.Call(uintptr(name), uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(&factory)))
+	if hr != 0 || factory == nil {
+		return nil, fmt.Errorf("unable to get the activation factory of %s: 0x%x", className, hr)
+	}
+	return factory, nil
+}
+
+// queryInterface implements QueryInterface for the COM objects below, whose lifetime is managed by the Frontend
+func queryInterface(this unsafe.Pointer, refiid *windows.GUID, object *unsafe.Pointer, iid *windows.GUID, inspectable bool) uintptr {
+	switch *refiid {
+	case *iid, iidIUnknown, iidIAgileObject:
+	case iidIInspectable:
+		if !inspectable {
+			*object = nil
+			return eNoInterface
+		}
+	default:
+		*object = nil
+		return eNoInterface
+	}
+	*object = this
+	return 0
+}
+
+// dataRequestedHandler is the TypedEventHandler of the DataRequested event
+type dataRequestedHandler struct {
+	vtbl   *dataRequestedHandlerVtbl
+	invoke func(args *dataRequestedEventArgs) uintptr
+}
+
+type dataRequestedHandlerVtbl struct {
+	iUnknownVtbl
+	Invoke edge.ComProc
+}
+
+var dataRequestedHandlerFn = dataRequestedHandlerVtbl{
+	iUnknownVtbl{
+		edge.NewComProc(func(this *dataRequestedHandler, refiid *windows.GUID, object *unsafe.Pointer) uintptr {
+			return queryInterface(unsafe.Pointer(this), refiid, object, &iidDataRequestedHandler, false)
+		}),
+		edge.NewComProc(func(this *dataRequestedHandler) uintptr { return 1 }),
+		edge.NewComProc(func(this *dataRequestedHandler) uintptr { return 1 }),
+	},
+	edge.NewComProc(func(this *dataRequestedHandler, sender uintptr, args *dataRequestedEventArgs) uintptr {
+		return this.invoke(args)
+	}),
+}
+
+// storageItems implements IIterable<IStorageItem> for SetStorageItemsReadOnly. It keeps the iterators it creates.
+type storageItems struct {
+	vtbl      *storageItemsVtbl
+	items     []unsafe.Pointer
+	iterators []*storageItemIterator
+}
+
+type storageItemsVtbl struct {
+	iInspectableVtbl
+	First edge.ComProc
+}
+
+type storageItemIterator struct {
+	vtbl  *storageItemIteratorVtbl
+	items []unsafe.Pointer
+	index int
+}
+
+type storageItemIteratorVtbl struct {
+	iInspectableVtbl
+	GetCurrent    edge.ComProc
+	GetHasCurrent edge.ComProc
+	MoveNext      edge.ComProc
+	GetMany       edge.ComProc
+}
+
+// inspectableFn implements IInspectable for objects without runtime class
+var inspectableFn = struct {
+	GetIids             edge.ComProc
+	GetRuntimeClassName edge.ComProc
+	GetTrustLevel       edge.ComProc
+}{
+	edge.NewComProc(func(this uintptr, count *uint32, iids *uintptr) uintptr {
+		*count = 0
+		*iids = 0
+		return 0
+	}),
+	edge.NewComProc(func(this uintptr, name *hstring) uintptr {
+		*name = 0
+		return 0
+	}),
+	edge.NewComProc(func(this uintptr, level *int32) uintptr {
+		*level = 0
+		return 0
+	}),
+}
+
+var storageItemsFn = storageItemsVtbl{
+	iInspectableVtbl{
+		iUnknownVtbl{
+			edge.NewComProc(func(this *storageItems, refiid *windows.GUID, object *unsafe.Pointer) uintptr {
+				return queryInterface(unsafe.Pointer(this), refiid, object, &iidIIterableStorageItem, true)
+			}),
+			edge.NewComProc(func(this *storageItems) uintptr { return 1 }),
+			edge.NewComProc(func(this *storageItems) uintptr { return 1 }),
+		},
+		inspectableFn.GetIids,
+		inspectableFn.GetRuntimeClassName,
+		inspectableFn.GetTrustLevel,
+	},
+	edge.NewComProc(func(this *storageItems, first **storageItemIterator) uintptr {
+		iterator := &storageItemIterator{vtbl: &storageItemIteratorFn, items: this.items}
+		this.iterators = append(this.iterators, iterator)
+		*first = iterator
+		return 0
+	}),
+}
+
+var storageItemIteratorFn = storageItemIteratorVtbl{
+	iInspectableVtbl{
+		iUnknownVtbl{
+			edge.NewComProc(func(this *storageItemIterator, refiid *windows.GUID, object *unsafe.Pointer) uintptr {
+				return queryInterface(unsafe.Pointer(this), refiid, object, &iidIIteratorStorageItem, true)
+			}),
+			edge.NewComProc(func(this *storageItemIterator) uintptr { return 1 }),
+			edge.NewComProc(func(this *storageItemIterator) uintptr { return 1 }),
+		},
+		inspectableFn.GetIids,
+		inspectableFn.GetRuntimeClassName,
+		inspectableFn.GetTrustLevel,
+	},
+	edge.NewComProc(func(this *storageItemIterator, current *unsafe.Pointer) uintptr {
+		if this.index >= len(this.items) {
+			*current = nil
+			return eBounds
+		}
+		addRef(this.items[this.index])
+		*current = this.items[this.index]
+		return 0
+	}),
+	edge.NewComProc(func(this *storageItemIterator, hasCurrent *bool) uintptr {
+		*hasCurrent = this.index < len(this.items)
+		return 0
+	}),
+	edge.NewComProc(func(this *storageItemIterator, hasCurrent *bool) uintptr {
+		if this.index < len(this.items) {
+			this.index++
+		}
+		*hasCurrent = this.index < len(this.items)
+		return 0
+	}),
+	edge.NewComProc(func(this *storageItemIterator, capacity uint32, items *unsafe.Pointer, actual *uint32) uintptr {
+		result := unsafe.Slice(items, capacity)
+		count := uint32(0)
+		for count < capacity && this.index < len(this.items) {
+			addRef(this.items[this.index])
+			result[count] = this.items[this.index]
+			this.index++
+			count++
+		}
+		*actual = count
+		return 0
+	}),
+}
+
+// sharer provides the shared data to the DataTransferManager of the main window. It is only used on the main thread.
+type sharer struct {
+	interop *dataTransferManagerInterop
+	manager *dataTransferManager
+	handler *dataRequestedHandler
+	logger  *logger.Logger
+	options frontend.ShareOptions
+	title   string
+	items   *storageItems
+}
+
+// Share shows the share UI of Windows 10+. The share UI can't be positioned, so the anchor is not used.
+func (f *Frontend) Share(options frontend.ShareOptions) error {
+	_, err := invokeSync(f.mainWindow, func() (any, error) {
+		return nil, f.share(options)
+	})
+	return err
+}
+
+func (f *Frontend) share(options frontend.ShareOptions) error {
+	hwnd := uintptr(f.mainWindow.Handle())
+	if f.sharer == nil {
+		s, err := newSharer(hwnd, f.logger)
+		if err != nil {
+			return err
+		}
+		f.sharer = s
+	}
+	s := f.sharer
+
+	// The files of the previous share are released
+	if s.items != nil {
+		for _, item := range s.items.items {
+			release(item)
+		}
+		s.items = nil
+	}
+	if len(options.Files) > 0 {
+		items, err := storageFiles(options.Files)
+		if err != nil {
+			return err
+		}
+		s.items = &storageItems{vtbl: &storageItemsFn, items: items}
+	}
+	s.options = options
+	s.title = options.Title
+	if s.title == "" {
+		s.title = f.mainWindow.Text()
+	}
+
+	hr, _, _ := s.interop.vtbl.ShowShareUIForWindow.Call(uintptr(unsafe.Pointer(s.interop)), hwnd)
+	if hr != 0 {
+		return fmt.Errorf("unable to show the share UI: 0x%x", hr)
+	}
+	return nil
+}
+
+// newSharer gets the DataTransferManager of the window and adds the DataRequested handler, which is never removed
+func newSharer(hwnd uintptr, myLogger *logger.Logger) (*sharer, error) {
+	factory, err := activationFactory("Windows.ApplicationModel.DataTransfer.DataTransferManager", &iidIDataTransferManagerInterop)
+	if err != nil {
+		return nil, err
+	}
+	s := &sharer{interop: (*dataTransferManagerInterop)(factory), logger: myLogger}
+	hr, _, _ := s.interop.vtbl.GetForWindow.Call(uintptr(factory), hwnd, uintptr(unsafe.Pointer(&iidIDataTransferManager)), uintptr(unsafe.Pointer(&s.manager)))
+	if hr != 0 || s.manager == nil {
+		release(factory)
+		return nil, fmt.Errorf("unable to get the data transfer manager: 0x%x", hr)
+	}
+	s.handler = &dataRequestedHandler{vtbl: &dataRequestedHandlerFn, invoke: s.onDataRequested}
+	var token int64
+	hr, _, _ = s.manager.vtbl.AddDataRequested.Call(uintptr(unsafe.Pointer(s.manager)), uintptr(unsafe.Pointer(s.handler)), uintptr(unsafe.Pointer(&token)))
+	if hr != 0 {
+		release(unsafe.Pointer(s.manager))
+		release(factory)
+		return nil, fmt.Errorf("unable to add the data requested handler: 0x%x", hr)
+	}
+	return s, nil
+}
+
+// storageFiles returns the IStorageItem interfaces of the StorageFiles of the paths
+func storageFiles(paths []string) ([]unsafe.Pointer, error) {
+	factory, err := activationFactory("Windows.Storage.StorageFile", &iidIStorageFileStatics)
+	if err != nil {
+		return nil, err
+	}
+	defer release(factory)
+	statics := (*storageFileStatics)(factory)
+
+	var items []unsafe.Pointer
+	for _, path := range paths {
+		item, err := storageFile(statics, path)
+		if err != nil {
+			for _, item := range items {
+				release(item)
+			}
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func storageFile(statics *storageFileStatics, path string) (unsafe.Pointer, error) {
+	_path, err := newHString(path)
+	if err != nil {
+		return nil, err
+	}
+	defer deleteHString(_path)
+	var operation *asyncOperation
+	hr, _, _ := statics.vtbl.GetFileFromPathAsync.Call(uintptr(unsafe.Pointer(statics)), uintptr(_path), uintptr(unsafe.Pointer(&operation)))
+	if hr != 0 || operation == nil {
+		return nil, fmt.Errorf("unable to get the storage file of %s: 0x%x", path, hr)
+	}
+	defer release(unsafe.Pointer(operation))
+
+	var info *asyncInfo
+	hr, _, _ = operation.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(operation)), uintptr(unsafe.Pointer(&iidIAsyncInfo)), uintptr(unsafe.Pointer(&info)))
+	if hr != 0 || info == nil {
+		return nil, fmt.Errorf("unable to get the status of the storage file of %s: 0x%x", path, hr)
+	}
+	defer release(unsafe.Pointer(info))
+
+	// Files are resolved by the thread pool, so the main thread can wait for them
+	deadline := time.Now().Add(fileTimeout)
+	var status int32
+	for {
+		hr, _, _ = info.vtbl.GetStatus.Call(uintptr(unsafe.Pointer(info)), uintptr(unsafe.Pointer(&status)))
+		if hr != 0 {
+			return nil, fmt.Errorf("unable to get the status of the storage file of %s: 0x%x", path, hr)
+		}
+		if status != asyncStatusStarted {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out getting the storage file of %s", path)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if status != asyncStatusCompleted {
+		return nil, fmt.Errorf("unable to get the storage file of %s", path)
+	}
+
+	var file unsafe.Pointer
+	hr, _, _ = operation.vtbl.GetResults.Call(uintptr(unsafe.Pointer(operation)), uintptr(unsafe.Pointer(&file)))
+	if hr != 0 || file == nil {
+		return nil, fmt.Errorf("unable to get the storage file of %s: 0x%x", path, hr)
+	}
+	defer release(file)
+	var item unsafe.Pointer
+	hr, _, _ = (*iUnknown)(file).vtbl.QueryInterface.Call(uintptr(file), uintptr(unsafe.Pointer(&iidIStorageItem)), uintptr(unsafe.Pointer(&item)))
+	if hr != 0 || item == nil {
+		return nil, fmt.Errorf("unable to get the storage item of %s: 0x%x", path, hr)
+	}
+	return item, nil
+}
+
+// onDataRequested fills the data package of the request with the data of the last share
+func (s *sharer) onDataRequested(args *dataRequestedEventArgs) uintptr {
+	var request *dataRequest
+	hr, _, _ := args.vtbl.GetRequest.Call(uintptr(unsafe.Pointer(args)), uintptr(unsafe.Pointer(&request)))
+	if hr != 0 || request == nil {
+		return hr
+	}
+	defer release(unsafe.Pointer(request))
+	var data *dataPackage
+	hr, _, _ = request.vtbl.GetData.Call(uintptr(unsafe.Pointer(request)), uintptr(unsafe.Pointer(&data)))
+	if hr != 0 || data == nil {
+		return hr
+	}
+	defer release(unsafe.Pointer(data))
+
+	// The share UI requires a title
+	var properties *dataPackagePropertySet
+	hr, _, _ = data.vtbl.GetProperties.Call(uintptr(unsafe.Pointer(data)), uintptr(unsafe.Pointer(&properties)))
+	if hr == 0 && properties != nil {
+		if err := putHString(properties.vtbl.PutTitle, uintptr(unsafe.Pointer(properties)), s.title); err != nil {
+			s.logger.Error("Unable to set the title of the share: %s", err)
+		}
+		release(unsafe.Pointer(properties))
+	}
+
+	if s.options.Text != "" {
+		if err := putHString(data.vtbl.SetText, uintptr(unsafe.Pointer(data)), s.options.Text); err != nil {
+			s.logger.Error("Unable to share the text: %s", err)
+		}
+	}
+	if s.options.URL != "" {
+		if err := setWebLink(data, s.options.URL); err != nil {
+			s.logger.Error("Unable to share the URL: %s", err)
+		}
+	}
+	if s.items != nil {
+		hr, _, _ = data.vtbl.SetStorageItemsReadOnly.Call(uintptr(unsafe.Pointer(data)), uintptr(unsafe.Pointer(s.items)))
+		if hr != 0 {
+			s.logger.Error("Unable to share the files: 0x%x", hr)
+		}
+	}
+	return 0
+}
+
+// putHString calls a method that takes a string
+func putHString(method edge.ComProc, this uintptr, value string) error {
+	_value, err := newHString(value)
+	if err != nil {
+		return err
+	}
+	defer deleteHString(_value)
+	hr, _, _ := method.Call(this, uintptr(_value))
+	if hr != 0 {
+		return fmt.Errorf("0x%x", hr)
+	}
+	return nil
+}
+
+func setWebLink(data *dataPackage, url string) error {
+	var data2 *dataPackage2
+	hr, _, _ := data.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(data)), uintptr(unsafe.Pointer(&iidIDataPackage2)), uintptr(unsafe.Pointer(&data2)))
+	if hr != 0 || data2 == nil {
+		return errors.New("web links require Windows 10")
+	}
+	defer release(unsafe.Pointer(data2))
+
+	factory, err := activationFactory("Windows.Foundation.Uri", &iidIUriRuntimeClassFactory)
+	if err != nil {
+		return err
+	}
+	defer release(factory)
+	_url, err := newHString(url)
+	if err != nil {
+		return err
+	}
+	defer deleteHString(_url)
+	var uri unsafe.Pointer
+	hr, _, _ = (*uriRuntimeClassFactory)(factory).vtbl.CreateUri.Call(uintptr(factory), uintptr(_url), uintptr(unsafe.Pointer(&uri)))
+	if hr != 0 || uri == nil {
+		return fmt.Errorf("invalid URL %s: 0x%x", url, hr)
+	}
+	defer release(uri)
+	hr, _, _ = data2.vtbl.SetWebLink.Call(uintptr(unsafe.Pointer(data2)), uintptr(uri))
+	if hr != 0 {
+		return fmt.Errorf("0x%x", hr)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 doesn't expose CapturePreview of ICoreWebView2, which follows 27 methods. The preview is written to a
+file stream, the file is complete once the stream has been released.
+*/
+
+const (
+	capturePreviewImageFormatPNG = 0
+
+	stgmWrite           = 0x1
+	stgmShareDenyWrite  = 0x20
+	stgmCreate          = 0x1000
+	fileAttributeNormal = 0x80
+)
+
+var procSHCreateStreamOnFileEx = windows.NewLazySystemDLL("shlwapi.dll").NewProc("SHCreateStreamOnFileEx")
+
+type coreWebView2Capture struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_              [27]edge.ComProc
+		CapturePreview edge.ComProc
+	}
+}
+
+type fileStream struct {
+	vtbl *iUnknownVtbl
+}
+
+// capturePreviewCompletedHandler is the COM handler that is called when CapturePreview has completed, it is kept alive
+// by WindowCaptureSnapshot until then
+type capturePreviewCompletedHandler struct {
+	vtbl      *capturePreviewCompletedHandlerVtbl
+	stream    *fileStream
+	completed func(errorCode uintptr)
+}
+
+type capturePreviewCompletedHandlerVtbl struct {
+	iUnknownVtbl
+	Invoke edge.ComProc
+}
+
+var capturePreviewCompletedHandlerFn = capturePreviewCompletedHandlerVtbl{
+	iUnknownVtbl{
+		edge.NewComProc(func(this *capturePreviewCompletedHandler, refiid, object uintptr) uintptr { return 0 }),
+		edge.NewComProc(func(this *capturePreviewCompletedHandler) uintptr { return 1 }),
+		edge.NewComProc(func(this *capturePreviewCompletedHandler) uintptr { return 1 }),
+	},
+	edge.NewComProc(func(this *capturePreviewCompletedHandler, errorCode uintptr) uintptr {
+		this.stream.vtbl.Release.Call(uintptr(unsafe.Pointer(this.stream)))
+		this.completed(errorCode)
+		return 0
+	}),
+}
+
+// WindowCaptureSnapshot captures the visible content of the webview with CapturePreview
+func (f *Frontend) WindowCaptureSnapshot() ([]byte, error) {
+	path, err := frontend.NewSnapshotFile()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(chan error, 1)
+	handler := &capturePreviewCompletedHandler{vtbl: &capturePreviewCompletedHandlerFn}
+	handler.completed = func(errorCode uintptr) {
+		if errorCode != 0 {
+			result <- fmt.Errorf("capturing the snapshot failed: 0x%x", errorCode)
+			return
+		}
+		result <- nil
+	}
+	_, err = invokeSync(f.mainWindow, func() (struct{}, error) {
+		return struct{}{}, f.capturePreview(path, handler)
+	})
+	if err == nil {
+		err = <-result
+	}
+	runtime.KeepAlive(handler)
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return frontend.ReadSnapshotFile(path)
+}
+
+func (f *Frontend) capturePreview(path string, handler *capturePreviewCompletedHandler) error {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		if err == nil {
+			err = fmt.Errorf("the webview has not been created")
+		}
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	_path, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	var stream *fileStream
+	hr, _, _ := procSHCreateStreamOnFileEx.Call(uintptr(unsafe.Pointer(_path)), stgmCreate|stgmWrite|stgmShareDenyWrite, fileAttributeNormal, 1, 0, uintptr(unsafe.Pointer(&stream)))
+	if hr != 0 || stream == nil {
+		return fmt.Errorf("unable to open the snapshot file: 0x%x", hr)
+	}
+	handler.stream = stream
+
+	capture := (*coreWebView2Capture)(unsafe.Pointer(webview))
+	hr, _, _ = capture.vtbl.CapturePreview.Call(uintptr(unsafe.Pointer(webview)), capturePreviewImageFormatPNG, uintptr(unsafe.Pointer(stream)), uintptr(unsafe.Pointer(handler)))
+	if hr != 0 {
+		stream.vtbl.Release.Call(uintptr(unsafe.Pointer(stream)))
+		return fmt.Errorf("unable to capture the snapshot: 0x%x", hr)
+	}
+	return nil
+}
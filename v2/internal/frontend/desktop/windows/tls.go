@@ -0,0 +1,301 @@
+//go:build windows
+
+package windows
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 exposes neither ServerCertificateErrorDetected of ICoreWebView2_14 nor ClientCertificateRequested of
+ICoreWebView2_5. ICoreWebView2_5 inherits 74 methods and ICoreWebView2_14 inherits 103 methods. The handlers of the
+events are implemented as minimal COM objects.
+*/
+
+const (
+	serverCertificateErrorActionAlwaysAllow = 0
+	serverCertificateErrorActionDefault     = 2
+)
+
+var (
+	iidICoreWebView2_5  = windows.GUID{Data1: 0xbedb11b8, Data2: 0xd63c, Data3: 0x11eb, Data4: [8]byte{0xb8, 0xbc, 0x02, 0x42, 0xac, 0x13, 0x00, 0x03}}
+	iidICoreWebView2_14 = windows.GUID{Data1: 0x6daa4f10, Data2: 0x4a90, Data3: 0x4753, Data4: [8]byte{0x88, 0x98, 0x77, 0xc5, 0xdf, 0x53, 0x41, 0x65}}
+)
+
+type coreWebView2_5 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                             [74]edge.ComProc
+		AddClientCertificateRequested edge.ComProc
+	}
+}
+
+type coreWebView2_14 struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                                 [103]edge.ComProc
+		AddServerCertificateErrorDetected edge.ComProc
+	}
+}
+
+type serverCertificateErrorDetectedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetErrorStatus       edge.ComProc
+		GetRequestUri        edge.ComProc
+		GetServerCertificate edge.ComProc
+		GetAction            edge.ComProc
+		PutAction            edge.ComProc
+		GetDeferral          edge.ComProc
+	}
+}
+
+// certificate mirrors ICoreWebView2Certificate and the beginning of ICoreWebView2ClientCertificate
+type certificate struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetSubject                          edge.ComProc
+		GetIssuer                           edge.ComProc
+		GetValidFrom                        edge.ComProc
+		GetValidTo                          edge.ComProc
+		GetDerEncodedSerialNumber           edge.ComProc
+		GetDisplayName                      edge.ComProc
+		ToPemEncoding                       edge.ComProc
+		GetPemEncodedIssuerCertificateChain edge.ComProc
+	}
+}
+
+// collection mirrors ICoreWebView2StringCollection and ICoreWebView2ClientCertificateCollection
+type collection struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetCount        edge.ComProc
+		GetValueAtIndex edge.ComProc
+	}
+}
+
+type clientCertificateRequestedEventArgs struct {
+	vtbl *struct {
+		iUnknownVtbl
+		GetHost                          edge.ComProc
+		GetPort                          edge.ComProc
+		GetIsProxy                       edge.ComProc
+		GetAllowedCertificateAuthorities edge.ComProc
+		GetMutuallyTrustedCertificates   edge.ComProc
+		GetSelectedCertificate           edge.ComProc
+		PutSelectedCertificate           edge.ComProc
+		GetCancel                        edge.ComProc
+		PutCancel                        edge.ComProc
+		GetHandled                       edge.ComProc
+		PutHandled                       edge.ComProc
+		GetDeferral                      edge.ComProc
+	}
+}
+
+// eventHandler is a COM event handler that calls invoke with the event args. Its lifetime is managed by the
+// Frontend, which keeps a reference.
+type eventHandler struct {
+	vtbl   *eventHandlerVtbl
+	invoke func(args unsafe.Pointer) uintptr
+}
+
+type eventHandlerVtbl struct {
+	iUnknownVtbl
+	Invoke edge.ComProc
+}
+
+var eventHandlerFn = eventHandlerVtbl{
+	iUnknownVtbl{
+		edge.NewComProc(func(this *eventHandler, refiid, object uintptr) uintptr { return 0 }),
+		edge.NewComProc(func(this *eventHandler) uintptr { return 1 }),
+		edge.NewComProc(func(this *eventHandler) uintptr { return 1 }),
+	},
+	edge.NewComProc(func(this *eventHandler, sender uintptr, args unsafe.Pointer) uintptr {
+		return this.invoke(args)
+	}),
+}
+
+func newEventHandler(invoke func(args unsafe.Pointer) uintptr) *eventHandler {
+	return &eventHandler{vtbl: &eventHandlerFn, invoke: invoke}
+}
+
+// setupTLS registers the handlers of the TLS events. Runtimes before 1.0.1245 don't report certificate errors and
+// runtimes before 1.0.961 don't report client certificate requests.
+func (f *Frontend) setupTLS() error {
+	if !f.tlsPolicy.Enabled() {
+		return nil
+	}
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+	var token int64
+
+	var webview14 *coreWebView2_14
+	hr, _, _ := webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_14)), uintptr(unsafe.Pointer(&webview14)))
+	if hr == 0 && webview14 != nil {
+		handler := newEventHandler(f.onServerCertificateError)
+		f.comHandlers = append(f.comHandlers, handler)
+		hr, _, _ = webview14.vtbl.AddServerCertificateErrorDetected.Call(uintptr(unsafe.Pointer(webview14)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+		webview14.vtbl.Release.Call(uintptr(unsafe.Pointer(webview14)))
+		if hr != 0 {
+			return fmt.Errorf("unable to add the certificate error handler: 0x%x", hr)
+		}
+	} else {
+		f.logger.Warning("The WebView2 runtime doesn't support trusting server certificates")
+	}
+
+	if !f.tlsPolicy.HandlesClientCertificates() {
+		return nil
+	}
+	var webview5 *coreWebView2_5
+	hr, _, _ = webview.vtbl.QueryInterface.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(&iidICoreWebView2_5)), uintptr(unsafe.Pointer(&webview5)))
+	if hr != 0 || webview5 == nil {
+		f.logger.Warning("The WebView2 runtime doesn't support client certificates")
+		return nil
+	}
+	defer webview5.vtbl.Release.Call(uintptr(unsafe.Pointer(webview5)))
+	handler := newEventHandler(f.onClientCertificateRequested)
+	f.comHandlers = append(f.comHandlers, handler)
+	hr, _, _ = webview5.vtbl.AddClientCertificateRequested.Call(uintptr(unsafe.Pointer(webview5)), uintptr(unsafe.Pointer(handler)), uintptr(unsafe.Pointer(&token)))
+	if hr != 0 {
+		return fmt.Errorf("unable to add the client certificate handler: 0x%x", hr)
+	}
+	return nil
+}
+
+// getString calls a getter of a string property and frees the string
+func getString(getter edge.ComProc, this uintptr) (string, error) {
+	var value *uint16
+	hr, _, _ := getter.Call(this, uintptr(unsafe.Pointer(&value)))
+	if hr != 0 {
+		return "", fmt.Errorf("0x%x", hr)
+	}
+	defer windows.CoTaskMemFree(unsafe.Pointer(value))
+	return windows.UTF16PtrToString(value), nil
+}
+
+// certificateChain returns the certificate followed by its issuers
+func certificateChain(c *certificate) ([]*x509.Certificate, error) {
+	this := uintptr(unsafe.Pointer(c))
+	chainPEM, err := getString(c.vtbl.ToPemEncoding, this)
+	if err != nil {
+		return nil, err
+	}
+	var issuers *collection
+	hr, _, _ := c.vtbl.GetPemEncodedIssuerCertificateChain.Call(this, uintptr(unsafe.Pointer(&issuers)))
+	if hr == 0 && issuers != nil {
+		defer issuers.vtbl.Release.Call(uintptr(unsafe.Pointer(issuers)))
+		var count uint32
+		issuers.vtbl.GetCount.Call(uintptr(unsafe.Pointer(issuers)), uintptr(unsafe.Pointer(&count)))
+		for index := uint32(0); index < count; index++ {
+			var value *uint16
+			hr, _, _ = issuers.vtbl.GetValueAtIndex.Call(uintptr(unsafe.Pointer(issuers)), uintptr(index), uintptr(unsafe.Pointer(&value)))
+			if hr != 0 {
+				continue
+			}
+			chainPEM += "\n" + windows.UTF16PtrToString(value)
+			windows.CoTaskMemFree(unsafe.Pointer(value))
+		}
+	}
+	return frontend.ParseCertificatesPEM([]byte(chainPEM))
+}
+
+func (f *Frontend) onServerCertificateError(_args unsafe.Pointer) uintptr {
+	args := (*serverCertificateErrorDetectedEventArgs)(_args)
+	requestURI, err := getString(args.vtbl.GetRequestUri, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the URI of the certificate error: %s", err)
+		return 0
+	}
+	var _certificate *certificate
+	hr, _, _ := args.vtbl.GetServerCertificate.Call(uintptr(_args), uintptr(unsafe.Pointer(&_certificate)))
+	if hr != 0 || _certificate == nil {
+		f.logger.Error("Unable to get the certificate of %s: 0x%x", requestURI, hr)
+		return 0
+	}
+	defer _certificate.vtbl.Release.Call(uintptr(unsafe.Pointer(_certificate)))
+	chain, err := certificateChain(_certificate)
+	if err != nil {
+		f.logger.Error("Unable to read the certificate of %s: %s", requestURI, err)
+		return 0
+	}
+	u, err := url.Parse(requestURI)
+	if err != nil {
+		return 0
+	}
+
+	action := serverCertificateErrorActionDefault
+	if f.tlsPolicy.AcceptServer(u.Hostname(), chain) {
+		action = serverCertificateErrorActionAlwaysAllow
+	}
+	args.vtbl.PutAction.Call(uintptr(_args), uintptr(action))
+	return 0
+}
+
+func (f *Frontend) onClientCertificateRequested(_args unsafe.Pointer) uintptr {
+	args := (*clientCertificateRequestedEventArgs)(_args)
+	host, err := getString(args.vtbl.GetHost, uintptr(_args))
+	if err != nil {
+		f.logger.Error("Unable to get the host of the client certificate request: %s", err)
+		return 0
+	}
+	var port int32
+	args.vtbl.GetPort.Call(uintptr(_args), uintptr(unsafe.Pointer(&port)))
+
+	var candidates *collection
+	hr, _, _ := args.vtbl.GetMutuallyTrustedCertificates.Call(uintptr(_args), uintptr(unsafe.Pointer(&candidates)))
+	if hr != 0 || candidates == nil {
+		f.logger.Error("Unable to get the client certificates for %s: 0x%x", host, hr)
+		return 0
+	}
+	defer candidates.vtbl.Release.Call(uintptr(unsafe.Pointer(candidates)))
+	var count uint32
+	candidates.vtbl.GetCount.Call(uintptr(unsafe.Pointer(candidates)), uintptr(unsafe.Pointer(&count)))
+	var installed []*x509.Certificate
+	var handles []*certificate
+	for index := uint32(0); index < count; index++ {
+		var candidate *certificate
+		hr, _, _ = candidates.vtbl.GetValueAtIndex.Call(uintptr(unsafe.Pointer(candidates)), uintptr(index), uintptr(unsafe.Pointer(&candidate)))
+		if hr != 0 || candidate == nil {
+			continue
+		}
+		defer candidate.vtbl.Release.Call(uintptr(unsafe.Pointer(candidate)))
+		chain, err := certificateChain(candidate)
+		if err != nil {
+			continue
+		}
+		installed = append(installed, chain[0])
+		handles = append(handles, candidate)
+	}
+
+	result := f.tlsPolicy.ClientCertificate(host, int(port), installed)
+	if result == nil {
+		return 0
+	}
+	selected := result.Certificate
+	if selected == nil {
+		_, selected, err = frontend.ClientCertificatePEM(result)
+		if err != nil {
+			f.logger.Error("Unable to use the client certificate for %s: %s", host, err)
+			return 0
+		}
+	}
+	for index, candidate := range installed {
+		if candidate.Equal(selected) {
+			args.vtbl.PutSelectedCertificate.Call(uintptr(_args), uintptr(unsafe.Pointer(handles[index])))
+			args.vtbl.PutHandled.Call(uintptr(_args), 1)
+			return 0
+		}
+	}
+	f.logger.Error("The client certificate for %s has to be installed in the certificate store of the user", host)
+	return 0
+}
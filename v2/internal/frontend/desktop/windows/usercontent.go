@@ -0,0 +1,157 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/wailsapp/go-webview2/pkg/edge"
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"golang.org/x/sys/windows"
+)
+
+/*
+go-webview2 doesn't return the id of a script added with Init, which is needed to remove it again. The following
+types call AddScriptToExecuteOnDocumentCreated and RemoveScriptToExecuteOnDocumentCreated of ICoreWebView2, which
+follow 24 methods. Every user content is added as its own script. WebView2 runs the scripts at the start of all
+frames, UserContentScript emulates the injection time and the frames.
+*/
+
+type coreWebView2Scripts struct {
+	vtbl *struct {
+		iUnknownVtbl
+		_                                      [24]edge.ComProc
+		AddScriptToExecuteOnDocumentCreated    edge.ComProc
+		RemoveScriptToExecuteOnDocumentCreated edge.ComProc
+	}
+}
+
+// addScriptCompletedHandler is the COM handler that receives the id of an added script, it is kept alive by the
+// Frontend until then
+type addScriptCompletedHandler struct {
+	vtbl      *addScriptCompletedHandlerVtbl
+	completed func(errorCode uintptr, id string)
+}
+
+type addScriptCompletedHandlerVtbl struct {
+	iUnknownVtbl
+	Invoke edge.ComProc
+}
+
+var addScriptCompletedHandlerFn = addScriptCompletedHandlerVtbl{
+	iUnknownVtbl{
+		edge.NewComProc(func(this *addScriptCompletedHandler, refiid, object uintptr) uintptr { return 0 }),
+		edge.NewComProc(func(this *addScriptCompletedHandler) uintptr { return 1 }),
+		edge.NewComProc(func(this *addScriptCompletedHandler) uintptr { return 1 }),
+	},
+	edge.NewComProc(func(this *addScriptCompletedHandler, errorCode uintptr, id *uint16) uintptr {
+		this.completed(errorCode, windows.UTF16PtrToString(id))
+		return 0
+	}),
+}
+
+func (f *Frontend) WindowAddUserContent(content options.UserContent) (string, error) {
+	item, err := f.userContent.Add(content)
+	if err != nil {
+		return "", err
+	}
+
+	script := frontend.UserContentScript(item, true)
+	f.mainWindow.Invoke(func() {
+		if err := f.addUserContentScript(item.ID, script); err != nil {
+			f.logger.Error("Unable to add the user content: %s", err)
+		}
+	})
+	return item.ID, nil
+}
+
+func (f *Frontend) WindowRemoveUserContent(id string) {
+	// The content is not added to the documents that are loaded from now on
+	if !f.userContent.Remove(id) {
+		return
+	}
+	f.mainWindow.Invoke(func() {
+		scriptID, added := f.userContentScripts[id]
+		if !added {
+			// The script is removed once its id has been received
+			return
+		}
+		delete(f.userContentScripts, id)
+		if err := f.removeUserContentScript(scriptID); err != nil {
+			f.logger.Error("Unable to remove the user content: %s", err)
+		}
+	})
+}
+
+// addUserContentScript adds the script of the user content, it must be called on the main thread
+func (f *Frontend) addUserContentScript(id string, script string) error {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		if err == nil {
+			err = fmt.Errorf("the webview has not been created")
+		}
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	_script, err := windows.UTF16PtrFromString(script)
+	if err != nil {
+		return err
+	}
+	handler := &addScriptCompletedHandler{vtbl: &addScriptCompletedHandlerFn}
+	handler.completed = func(errorCode uintptr, scriptID string) {
+		delete(f.userContentHandlers, handler)
+		if errorCode != 0 {
+			f.logger.Error("Unable to add the user content: 0x%x", errorCode)
+			return
+		}
+		if !f.hasUserContent(id) {
+			// The content has been removed in the meantime
+			if err := f.removeUserContentScript(scriptID); err != nil {
+				f.logger.Error("Unable to remove the user content: %s", err)
+			}
+			return
+		}
+		f.userContentScripts[id] = scriptID
+	}
+	f.userContentHandlers[handler] = struct{}{}
+
+	scripts := (*coreWebView2Scripts)(unsafe.Pointer(webview))
+	hr, _, _ := scripts.vtbl.AddScriptToExecuteOnDocumentCreated.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(_script)), uintptr(unsafe.Pointer(handler)))
+	if hr != 0 {
+		delete(f.userContentHandlers, handler)
+		return fmt.Errorf("0x%x", hr)
+	}
+	return nil
+}
+
+// removeUserContentScript removes the script with the id of WebView2, it must be called on the main thread
+func (f *Frontend) removeUserContentScript(scriptID string) error {
+	webview, err := f.coreWebView2()
+	if webview == nil {
+		return err
+	}
+	defer webview.vtbl.Release.Call(uintptr(unsafe.Pointer(webview)))
+
+	_scriptID, err := windows.UTF16PtrFromString(scriptID)
+	if err != nil {
+		return err
+	}
+	scripts := (*coreWebView2Scripts)(unsafe.Pointer(webview))
+	hr, _, _ := scripts.vtbl.RemoveScriptToExecuteOnDocumentCreated.Call(uintptr(unsafe.Pointer(webview)), uintptr(unsafe.Pointer(_scriptID)))
+	if hr != 0 {
+		return fmt.Errorf("0x%x", hr)
+	}
+	return nil
+}
+
+func (f *Frontend) hasUserContent(id string) bool {
+	for _, item := range f.userContent.Items() {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -67,6 +67,9 @@ func NewWindow(parent winc.Controller, appoptions *options.App, versionInfo *ope
 	if appoptions.AlwaysOnTop {
 		exStyle |= w32.WS_EX_TOPMOST
 	}
+	if appoptions.SkipTaskbar {
+		exStyle = exStyle&^w32.WS_EX_APPWINDOW | w32.WS_EX_TOOLWINDOW
+	}
 
 	var dwStyle = w32.WS_OVERLAPPEDWINDOW
 
@@ -150,6 +153,10 @@ func (w *Window) Fullscreen() {
 	w.Form.SetMaxSize(0, 0)
 	w.Form.SetMinSize(0, 0)
 	w.Form.Fullscreen()
+	if w.removesFullscreenBackdrop() {
+		// DWM only flips the frames of opaque fullscreen windows independently
+		win32.EnableTranslucency(w.Handle(), win32.BackdropType(winoptions.None))
+	}
 }
 
 func (w *Window) UnFullscreen() {
@@ -162,6 +169,17 @@ func (w *Window) UnFullscreen() {
 	w.Form.UnFullscreen()
 	w.SetMinSize(w.minWidth, w.minHeight)
 	w.SetMaxSize(w.maxWidth, w.maxHeight)
+	if w.removesFullscreenBackdrop() {
+		win32.EnableTranslucency(w.Handle(), win32.BackdropType(w.frontendOptions.Windows.BackdropType))
+	}
+}
+
+// removesFullscreenBackdrop reports if the translucent backdrop is removed while the window is fullscreen
+func (w *Window) removesFullscreenBackdrop() bool {
+	video := w.frontendOptions.VideoPlayback
+	windowsOptions := w.frontendOptions.Windows
+	return video != nil && video.FullscreenOptimizations && windowsOptions != nil && windowsOptions.WindowIsTranslucent &&
+		win32.SupportsBackdropTypes()
 }
 
 func (w *Window) Restore() {
@@ -188,6 +206,27 @@ func (w *Window) IsVisible() bool {
 	return win32.IsVisible(w.Handle())
 }
 
+// SetSkipTaskbar makes the window a tool window, which the taskbar and Alt-Tab don't show. The taskbar only picks up
+// the change when the window is shown, so a visible window is hidden while the style changes.
+func (w *Window) SetSkipTaskbar(skip bool) {
+	hwnd := w.Handle()
+	exStyle := uint32(w32.GetWindowLong(hwnd, w32.GWL_EXSTYLE))
+	if skip {
+		exStyle = exStyle&^w32.WS_EX_APPWINDOW | w32.WS_EX_TOOLWINDOW
+	} else {
+		exStyle = exStyle&^w32.WS_EX_TOOLWINDOW | w32.WS_EX_APPWINDOW
+	}
+	visible := w.IsVisible()
+	if visible {
+		w32.ShowWindow(hwnd, w32.SW_HIDE)
+	}
+	w32.SetWindowLong(hwnd, w32.GWL_EXSTYLE, exStyle)
+	w32.SetWindowPos(hwnd, 0, 0, 0, 0, 0, w32.SWP_NOMOVE|w32.SWP_NOSIZE|w32.SWP_NOZORDER|w32.SWP_NOACTIVATE|w32.SWP_FRAMECHANGED)
+	if visible {
+		w32.ShowWindow(hwnd, w32.SW_SHOWNA)
+	}
+}
+
 func (w *Window) WndProc(msg uint32, wparam, lparam uintptr) uintptr {
 
 	switch msg {
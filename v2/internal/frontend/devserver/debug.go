@@ -0,0 +1,211 @@
+//go:build dev
+// +build dev
+
+package devserver
+
+import (
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"net/url"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	rpprof "runtime/pprof"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wailsapp/wails/v2/internal/binding"
+)
+
+// debugPage is the page of the debug tools at /wails/debug
+const debugPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Wails Debug</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+button { font-size: 1em; padding: 0.4em 1em; }
+#result { font-family: monospace; margin-top: 1em; }
+table { border-collapse: collapse; }
+td, th { padding: 0.3em 0.8em; text-align: left; }
+input[type=number] { width: 6em; }
+</style>
+</head>
+<body>
+<h1>Wails Debug</h1>
+<button id="heap">Take heap snapshot</button>
+<div id="result"></div>
+<h2>Profiles</h2>
+<ul>
+<li><a href="pprof/">Index</a></li>
+<li><a href="pprof/goroutine?debug=2">Goroutine stacks</a></li>
+<li><a href="pprof/heap?debug=1">Heap</a></li>
+<li><a href="pprof/allocs?debug=1">Allocations</a></li>
+</ul>
+<p>Profiles can be analysed with <code>go tool pprof http://%[1]s/wails/debug/pprof/heap</code></p>
+<h2>Mocks</h2>
+<table>
+<thead><tr><th>Mock</th><th>Service</th><th>Methods</th><th>Enabled</th><th>Latency (ms)</th><th>Jitter (ms)</th><th>Failure rate</th></tr></thead>
+<tbody id="mocks"><tr><td colspan="7">No mocks</td></tr></tbody>
+</table>
+<script>
+const millisecond = 1000000;
+function mockInput(mock, type, field, scale) {
+    const input = document.createElement("input");
+    input.type = type;
+    if (type === "checkbox") {
+        input.checked = mock[field];
+    } else {
+        input.min = 0;
+        input.step = field === "failureRate" ? 0.1 : 1;
+        input.value = mock[field] / scale;
+    }
+    input.addEventListener("change", () => {
+        mock[field] = type === "checkbox" ? input.checked : Number(input.value) * scale;
+        fetch("mocks/" + encodeURIComponent(mock.name), {method: "POST", headers: {"Content-Type": "application/json"}, body: JSON.stringify(mock)})
+            .then((response) => response.json())
+            .then((updated) => Object.assign(mock, updated));
+    });
+    return input;
+}
+fetch("mocks")
+    .then((response) => response.json())
+    .then((mocks) => {
+        if (mocks.length === 0) {
+            return;
+        }
+        const table = document.getElementById("mocks");
+        table.textContent = "";
+        for (const mock of mocks) {
+            const row = table.insertRow();
+            row.insertCell().textContent = mock.name;
+            row.insertCell().textContent = mock.service;
+            row.insertCell().textContent = mock.methods.join(", ") || "-";
+            row.insertCell().append(mockInput(mock, "checkbox", "enabled", 1));
+            row.insertCell().append(mockInput(mock, "number", "latency", millisecond));
+            row.insertCell().append(mockInput(mock, "number", "jitter", millisecond));
+            row.insertCell().append(mockInput(mock, "number", "failureRate", 1));
+        }
+    });
+
+document.getElementById("heap").addEventListener("click", () => {
+    const result = document.getElementById("result");
+    result.textContent = "Taking snapshot...";
+    fetch("heapsnapshot", {method: "POST"})
+        .then((response) => response.json())
+        .then((snapshot) => result.textContent = snapshot.error || "Saved to " + snapshot.path)
+        .catch((err) => result.textContent = err);
+});
+</script>
+</body>
+</html>
+`
+
+type heapSnapshot struct {
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// registerDebugHandlers serves net/http/pprof and the heap snapshots below /wails/debug. They are only served to
+// the local machine, as the dev server can be bound to other interfaces.
+func (d *DevWebServer) registerDebugHandlers() {
+	// The pprof index expects to be served at /debug/pprof/
+	profiles := http.NewServeMux()
+	profiles.HandleFunc("/debug/pprof/", pprof.Index)
+	profiles.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	profiles.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	profiles.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	profiles.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	pprofHandler := http.StripPrefix("/wails", profiles)
+
+	debug := d.server.Group("/wails/debug", localOnly)
+	debug.GET("", func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, "/wails/debug/")
+	})
+	debug.GET("/", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, fmt.Sprintf(debugPage, html.EscapeString(c.Request().Host)))
+	})
+	debug.Any("/pprof/*", echo.WrapHandler(pprofHandler))
+	debug.POST("/heapsnapshot", d.handleHeapSnapshot)
+	debug.GET("/mocks", d.handleMocks)
+	debug.POST("/mocks/:name", d.handleMockSettings)
+}
+
+// handleMocks lists the mocks of the bindings with their settings
+func (d *DevWebServer) handleMocks(c echo.Context) error {
+	result := []binding.MockInfo{}
+	for _, mock := range d.appBindings.Mocks() {
+		result = append(result, mock.Info())
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// handleMockSettings changes the settings of a mock, which apply to the next calls
+func (d *DevWebServer) handleMockSettings(c echo.Context) error {
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	mock := d.appBindings.Mock(name)
+	if mock == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	var settings binding.MockSettings
+	if err := c.Bind(&settings); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if settings.Latency < 0 || settings.Jitter < 0 || settings.FailureRate < 0 || settings.FailureRate > 1 {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	mock.SetSettings(settings)
+	d.logger.Info("Mock %s has been changed: enabled %t, latency %s, jitter %s, failure rate %g", name, settings.Enabled, settings.Latency, settings.Jitter, settings.FailureRate)
+	return c.JSON(http.StatusOK, mock.Info())
+}
+
+func (d *DevWebServer) handleHeapSnapshot(c echo.Context) error {
+	path, err := writeHeapSnapshot()
+	if err != nil {
+		d.logger.Error("Unable to take the heap snapshot: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, heapSnapshot{Error: err.Error()})
+	}
+	d.logger.Info("Heap snapshot saved to %s", path)
+	return c.JSON(http.StatusOK, heapSnapshot{Path: path})
+}
+
+// writeHeapSnapshot writes the heap profile after a garbage collection to the temp directory
+func writeHeapSnapshot() (string, error) {
+	name := fmt.Sprintf("wails-heap-%d-%s.pprof", os.Getpid(), time.Now().Format("20060102-150405"))
+	path := filepath.Join(os.TempDir(), name)
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	goruntime.GC()
+	err = rpprof.Lookup("heap").WriteTo(file, 0)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// localOnly rejects the requests that don't come from a loopback address
+func localOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		host, _, err := net.SplitHostPort(c.Request().RemoteAddr)
+		if err != nil {
+			host = c.Request().RemoteAddr
+		}
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return c.NoContent(http.StatusForbidden)
+		}
+		return next(c)
+	}
+}
@@ -54,6 +54,7 @@ func (d *DevWebServer) Run(ctx context.Context) error {
 
 	d.server.GET("/wails/reload", d.handleReload)
 	d.server.GET("/wails/ipc", d.handleIPCWebSocket)
+	d.registerDebugHandlers()
 
 	assetServerConfig, err := assetserver.BuildAssetServerConfig(d.appoptions)
 	if err != nil {
@@ -102,6 +103,12 @@ func (d *DevWebServer) Run(ctx context.Context) error {
 		log.Fatal(err)
 	}
 
+	err = assetServer.ServeWebSockets(assetServerConfig.WebSockets)
+	if err != nil {
+		log.Fatal(err)
+	}
+	assetServer.UseTemplates(assetServerConfig.Templates)
+
 	d.server.Any("/*", func(c echo.Context) error {
 		if c.IsWebSocket() {
 			wsHandler.ServeHTTP(c.Response(), c.Request())
@@ -122,6 +129,7 @@ func (d *DevWebServer) Run(ctx context.Context) error {
 		}(d.server, d.logger)
 
 		d.LogDebug("Serving DevServer at http://%s", devServerAddr)
+		d.LogDebug("Serving the debug tools at http://%s/wails/debug/", devServerAddr)
 	}
 
 	// Launch desktop app
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/wailsapp/wails/v2/internal/binding"
 	"github.com/wailsapp/wails/v2/internal/frontend"
 )
 
@@ -36,25 +37,22 @@ func (d *Dispatcher) processCallMessage(message string, sender frontend.Frontend
 			return "", fmt.Errorf("method '%s' not registered", payload.Name)
 		}
 
+		d.warnDeprecatedCall(registeredMethod)
+
 		args, err2 := registeredMethod.ParseArgs(payload.Args)
 		if err2 != nil {
 			errmsg := fmt.Errorf("error parsing arguments: %s", err2.Error())
 			result, _ := d.NewErrorCallback(errmsg.Error(), payload.CallbackID)
 			return result, errmsg
 		}
-		result, err = registeredMethod.Call(args)
+		result, err = d.callMethod(registeredMethod, args)
 	}
 
 	callbackMessage := &CallbackMessage{
 		CallbackID: payload.CallbackID,
 	}
 	if err != nil {
-		// Use the error formatter if one was provided
-		if d.errfmt != nil {
-			callbackMessage.Err = d.errfmt(err)
-		} else {
-			callbackMessage.Err = err.Error()
-		}
+		callbackMessage.Err = d.formatError(err)
 	} else {
 		callbackMessage.Result = result
 	}
@@ -84,3 +82,15 @@ func (d *Dispatcher) NewErrorCallback(message string, callbackID string) (string
 	d.log.Trace("json call result data: %+v\n", string(messageData))
 	return string(messageData), err
 }
+
+// warnDeprecatedCall logs a warning the first time a deprecated method is called in a dev or debug build
+func (d *Dispatcher) warnDeprecatedCall(method *binding.BoundMethod) {
+	if method.Deprecation == nil || d.ctx.Value("buildtype") == "production" {
+		return
+	}
+	if _, warned := d.warnedDeprecations.LoadOrStore(method.Name, true); warned {
+		return
+	}
+	d.log.Warning("Deprecated method called: method=%s replacement=%s since=%s message=%q",
+		method.Name, method.Deprecation.Replacement, method.Deprecation.Since, method.Deprecation.Message)
+}
@@ -2,6 +2,9 @@ package dispatcher
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
+
 	"github.com/pkg/errors"
 	"github.com/wailsapp/wails/v2/internal/binding"
 	"github.com/wailsapp/wails/v2/internal/frontend"
@@ -16,16 +19,26 @@ type Dispatcher struct {
 	bindingsDB *binding.DB
 	ctx        context.Context
 	errfmt     options.ErrorFormatter
+
+	panicRecovery *options.PanicRecovery
+
+	// warnedDeprecations holds the names of the deprecated methods that have been warned about
+	warnedDeprecations sync.Map
+
+	// pageState is the snapshot of runtime.State, it is restored after the page reloads
+	pageState     json.RawMessage
+	pageStateLock sync.Mutex
 }
 
-func NewDispatcher(ctx context.Context, log *logger.Logger, bindings *binding.Bindings, events frontend.Events, errfmt options.ErrorFormatter) *Dispatcher {
+func NewDispatcher(ctx context.Context, log *logger.Logger, bindings *binding.Bindings, events frontend.Events, errfmt options.ErrorFormatter, panicRecovery *options.PanicRecovery) *Dispatcher {
 	return &Dispatcher{
-		log:        log,
-		bindings:   bindings,
-		events:     events,
-		bindingsDB: bindings.DB(),
-		ctx:        ctx,
-		errfmt:     errfmt,
+		log:           log,
+		bindings:      bindings,
+		events:        events,
+		bindingsDB:    bindings.DB(),
+		ctx:           ctx,
+		errfmt:        errfmt,
+		panicRecovery: panicRecovery,
 	}
 }
 
@@ -48,6 +61,14 @@ func (d *Dispatcher) ProcessMessage(message string, sender frontend.Frontend) (s
 		return d.processBrowserMessage(message, sender)
 	case 'D':
 		return d.processDragAndDropMessage(message)
+	case 'M':
+		return d.processMediaCaptureMessage(message)
+	case 'P':
+		return d.processPerformanceMessage(message)
+	case 'G':
+		return d.processDownloadMessage(message, sender)
+	case 'V':
+		return d.processNativeViewMessage(message, sender)
 	case 'Q':
 		sender.Quit()
 		return "", nil
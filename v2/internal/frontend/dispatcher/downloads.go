@@ -0,0 +1,20 @@
+package dispatcher
+
+import (
+	"errors"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+func (d *Dispatcher) processDownloadMessage(message string, sender frontend.Frontend) (string, error) {
+	if len(message) < 2 {
+		return "", errors.New("Invalid download Message: " + message)
+	}
+	switch message[1] {
+	case 'C':
+		sender.DownloadCancel(message[2:])
+	default:
+		return "", errors.New("Invalid download Message: " + message)
+	}
+	return "", nil
+}
@@ -0,0 +1,19 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+func TestDownloadMessageFromWebview(t *testing.T) {
+	log := logger.New(nil)
+	bindings := binding.NewBindings(log, []interface{}{}, []interface{}{}, false, []interface{}{})
+	d := NewDispatcher(context.Background(), log, bindings, nil, nil, nil)
+
+	if _, err := d.ProcessMessage(`GS{"id":"1","url":"https://example.com/a","path":"/tmp/a","state":"completed"}`, nil); err == nil {
+		t.Error("expected the webview to be unable to report a download")
+	}
+}
@@ -0,0 +1,43 @@
+package dispatcher
+
+import "runtime/debug"
+
+const wailsModule = "github.com/wailsapp/wails/v2"
+
+// handshake tells the frontend that the runtime bridge is available and which bound structs it can call
+type handshake struct {
+	// Version is the version of the Wails module the application has been built with
+	Version    string   `json:"version"`
+	APIVersion string   `json:"apiVersion"`
+	Services   []string `json:"services"`
+}
+
+func (d *Dispatcher) handshake() *handshake {
+	return &handshake{
+		Version:    wailsVersion(),
+		APIVersion: d.bindings.APIVersion(),
+		Services:   d.bindingsDB.StructNames(),
+	}
+}
+
+// wailsVersion returns the version of the Wails module from the build info, EG: "v2.9.2", or "(devel)" if it has been
+// replaced with a local copy
+func wailsVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if buildInfo.Main.Path == wailsModule {
+		return buildInfo.Main.Version
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path != wailsModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return "(devel)"
+		}
+		return dep.Version
+	}
+	return ""
+}
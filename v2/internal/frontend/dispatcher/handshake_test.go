@@ -0,0 +1,39 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+)
+
+type HandshakeTest struct{}
+
+func (h *HandshakeTest) Hello() string {
+	return "hello"
+}
+
+func TestHandshake(t *testing.T) {
+	bindings := binding.NewBindings(logger.New(nil), []interface{}{&HandshakeTest{}, &PanicTest{}}, []interface{}{}, false, []interface{}{})
+	bindings.SetAPIVersion("3")
+	d := NewDispatcher(context.Background(), logger.New(nil), bindings, nil, nil, nil)
+
+	message, err := d.ProcessMessage(`C{"name":":wails:Handshake","args":[],"callbackID":"1"}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var callback struct {
+		Result handshake `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(message, "c")), &callback); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"dispatcher.HandshakeTest", "dispatcher.PanicTest"}
+	if callback.Result.APIVersion != "3" || !reflect.DeepEqual(callback.Result.Services, want) {
+		t.Errorf("Handshake = %+v, want API version 3 and services %v", callback.Result, want)
+	}
+}
@@ -1,6 +1,8 @@
 package dispatcher
 
 import (
+	"encoding/json"
+
 	"github.com/pkg/errors"
 	"github.com/wailsapp/wails/v2/internal/logger"
 	pkgLogger "github.com/wailsapp/wails/v2/pkg/logger"
@@ -42,8 +44,30 @@ func (d *Dispatcher) processLogMessage(message string) (string, error) {
 			return "", errors.New("Invalid Set Log Level Message: " + message)
 		}
 		d.log.SetLogLevel(loglevel)
+	case 'J':
+		return "", d.processStructuredLogMessage(messageText)
 	default:
 		return "", errors.New("Invalid Log Message: " + message)
 	}
 	return "", nil
 }
+
+// structuredLogMessage is a log of runtime.Log in the frontend
+type structuredLogMessage struct {
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+func (d *Dispatcher) processStructuredLogMessage(data string) error {
+	var message structuredLogMessage
+	if err := json.Unmarshal([]byte(data), &message); err != nil {
+		return errors.Wrap(err, "Invalid Structured Log Message")
+	}
+	level, err := pkgLogger.StringToLogLevel(message.Level)
+	if err != nil {
+		return errors.Wrap(err, "Invalid Structured Log Message")
+	}
+	d.log.LogFields(level, message.Message, message.Fields)
+	return nil
+}
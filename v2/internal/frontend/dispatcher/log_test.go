@@ -0,0 +1,53 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	pkgLogger "github.com/wailsapp/wails/v2/pkg/logger"
+)
+
+type structuredLog struct {
+	level   pkgLogger.LogLevel
+	message string
+	fields  map[string]interface{}
+}
+
+type recordingLogger struct {
+	pkgLogger.Logger
+	logs []structuredLog
+}
+
+func (r *recordingLogger) Log(level pkgLogger.LogLevel, message string, fields map[string]interface{}) {
+	r.logs = append(r.logs, structuredLog{level, message, fields})
+}
+
+func TestStructuredLogMessage(t *testing.T) {
+	output := &recordingLogger{Logger: pkgLogger.NewDefaultLogger()}
+	log := logger.New(output)
+	log.SetLogLevel(pkgLogger.DEBUG)
+	bindings := binding.NewBindings(log, []interface{}{}, []interface{}{}, false, []interface{}{})
+	d := NewDispatcher(context.Background(), log, bindings, nil, nil, nil)
+
+	for _, message := range []string{
+		`LJ{"level":"warning","message":"slow","fields":{"page":"/","correlationId":"abc"}}`,
+		`LJ{"level":"trace","message":"filtered","fields":{}}`,
+	} {
+		if _, err := d.ProcessMessage(message, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(output.logs) != 1 {
+		t.Fatalf("got %d logs, want 1", len(output.logs))
+	}
+	got := output.logs[0]
+	if got.level != pkgLogger.WARNING || got.message != "slow" || got.fields["correlationId"] != "abc" || got.fields["page"] != "/" {
+		t.Errorf("unexpected log %+v", got)
+	}
+
+	if _, err := d.ProcessMessage(`LJ{"level":"loud","message":"x"}`, nil); err == nil {
+		t.Error("expected an error for an invalid level")
+	}
+}
@@ -0,0 +1,17 @@
+package dispatcher
+
+import (
+	"encoding/json"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+func (d *Dispatcher) processMediaCaptureMessage(message string) (string, error) {
+	var state runtime.MediaCaptureState
+	err := json.Unmarshal([]byte(message[1:]), &state)
+	if err != nil {
+		return "", err
+	}
+	d.events.Emit(runtime.MediaCaptureEvent, state)
+	return "", nil
+}
@@ -0,0 +1,17 @@
+package dispatcher
+
+import (
+	"encoding/json"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+func (d *Dispatcher) processNativeViewMessage(message string, sender frontend.Frontend) (string, error) {
+	var bounds frontend.NativeViewBounds
+	err := json.Unmarshal([]byte(message[1:]), &bounds)
+	if err != nil {
+		return "", err
+	}
+	sender.WindowSetNativeViewBounds(bounds)
+	return "", nil
+}
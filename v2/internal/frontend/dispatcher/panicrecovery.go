@@ -0,0 +1,53 @@
+package dispatcher
+
+import (
+	"runtime/debug"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// callMethod calls the bound method and recovers panics as configured by the PanicRecovery option
+func (d *Dispatcher) callMethod(method *binding.BoundMethod, args []interface{}) (result interface{}, err error) {
+	if !d.recoversPanics(method.Name) {
+		return method.Call(args)
+	}
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+		report := options.PanicReport{
+			Method: method.Name,
+			Value:  value,
+			Stack:  string(debug.Stack()),
+		}
+		d.log.Error("Recovered panic in bound method %s: %v\n%s", method.Name, value, report.Stack)
+		if d.panicRecovery.OnPanic != nil {
+			d.panicRecovery.OnPanic(report)
+		}
+		result, err = nil, &options.PanicError{Method: method.Name, Value: value}
+	}()
+	return method.Call(args)
+}
+
+func (d *Dispatcher) recoversPanics(method string) bool {
+	if d.panicRecovery == nil {
+		return false
+	}
+	if d.panicRecovery.CrashInDev && d.ctx.Value("buildtype") == "dev" {
+		return false
+	}
+	return d.panicRecovery.Policy == nil || d.panicRecovery.Policy(method) == options.PanicRecover
+}
+
+// formatError returns the error of a call as it is passed to JS
+func (d *Dispatcher) formatError(err error) any {
+	if d.errfmt != nil {
+		return d.errfmt(err)
+	}
+	if panicErr, ok := err.(*options.PanicError); ok {
+		return panicErr
+	}
+	return err.Error()
+}
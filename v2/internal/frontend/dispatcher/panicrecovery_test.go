@@ -0,0 +1,70 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/binding"
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+type PanicTest struct{}
+
+func (p *PanicTest) Explode() string {
+	panic("boom")
+}
+
+func newPanicTestDispatcher(ctx context.Context, panicRecovery *options.PanicRecovery) *Dispatcher {
+	bindings := binding.NewBindings(logger.New(nil), []interface{}{&PanicTest{}}, []interface{}{}, false, []interface{}{})
+	return NewDispatcher(ctx, logger.New(nil), bindings, nil, nil, panicRecovery)
+}
+
+func TestPanicRecovery(t *testing.T) {
+	var reports []options.PanicReport
+	d := newPanicTestDispatcher(context.Background(), &options.PanicRecovery{
+		OnPanic: func(report options.PanicReport) {
+			reports = append(reports, report)
+		},
+	})
+
+	message, err := d.ProcessMessage(`C{"name":"dispatcher.PanicTest.Explode","args":[],"callbackID":"1"}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var callback struct {
+		Error map[string]interface{} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(message, "c")), &callback); err != nil {
+		t.Fatal(err)
+	}
+	if callback.Error["panic"] != true || callback.Error["method"] != "dispatcher.PanicTest.Explode" || callback.Error["message"] != "dispatcher.PanicTest.Explode panicked: boom" {
+		t.Errorf("unexpected error %v", callback.Error)
+	}
+	if len(reports) != 1 || reports[0].Value != "boom" || !strings.Contains(reports[0].Stack, "Explode") {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+}
+
+func TestPanicRecoveryPolicy(t *testing.T) {
+	devCtx := context.WithValue(context.Background(), "buildtype", "dev")
+	for name, test := range map[string]struct {
+		ctx      context.Context
+		recovery *options.PanicRecovery
+		recovers bool
+	}{
+		"disabled":                 {context.Background(), nil, false},
+		"default":                  {devCtx, &options.PanicRecovery{}, true},
+		"crash in dev":             {devCtx, &options.PanicRecovery{CrashInDev: true}, false},
+		"crash in dev, production": {context.WithValue(context.Background(), "buildtype", "production"), &options.PanicRecovery{CrashInDev: true}, true},
+		"policy": {context.Background(), &options.PanicRecovery{Policy: func(method string) options.PanicPolicy {
+			return options.PanicCrash
+		}}, false},
+	} {
+		if got := newPanicTestDispatcher(test.ctx, test.recovery).recoversPanics("main.App.Greet"); got != test.recovers {
+			t.Errorf("%s: got %v, want %v", name, got, test.recovers)
+		}
+	}
+}
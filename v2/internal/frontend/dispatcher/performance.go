@@ -0,0 +1,17 @@
+package dispatcher
+
+import (
+	"encoding/json"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+func (d *Dispatcher) processPerformanceMessage(message string) (string, error) {
+	var metrics runtime.PerformanceMetrics
+	err := json.Unmarshal([]byte(message[1:]), &metrics)
+	if err != nil {
+		return "", err
+	}
+	d.events.Emit(runtime.PerformanceEvent, metrics)
+	return "", nil
+}
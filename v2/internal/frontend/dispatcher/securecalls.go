@@ -36,13 +36,13 @@ func (d *Dispatcher) processSecureCallMessage(message string, sender frontend.Fr
 		result, _ := d.NewErrorCallback(errmsg.Error(), payload.CallbackID)
 		return result, errmsg
 	}
-	result, err = registeredMethod.Call(args)
+	result, err = d.callMethod(registeredMethod, args)
 
 	callbackMessage := &CallbackMessage{
 		CallbackID: payload.CallbackID,
 	}
 	if err != nil {
-		callbackMessage.Err = err.Error()
+		callbackMessage.Err = d.formatError(err)
 	} else {
 		callbackMessage.Result = result
 	}
@@ -13,6 +13,9 @@ import (
 
 const systemCallPrefix = ":wails:"
 
+// maxPageStateSize limits the size of the snapshots of runtime.State
+const maxPageStateSize = 4 << 20
+
 type position struct {
 	X int `json:"x"`
 	Y int `json:"y"`
@@ -44,8 +47,12 @@ func (d *Dispatcher) processSystemCall(payload callMessage, sender frontend.Fron
 		return sender.WindowIsNormal(), nil
 	case "WindowIsFullscreen":
 		return sender.WindowIsFullscreen(), nil
+	case "WindowGetZoom":
+		return sender.WindowGetZoom(), nil
 	case "Environment":
 		return runtime.Environment(d.ctx), nil
+	case "Handshake":
+		return d.handshake(), nil
 	case "ClipboardGetText":
 		t, err := sender.ClipboardGetText()
 		return t, err
@@ -61,6 +68,34 @@ func (d *Dispatcher) processSystemCall(payload callMessage, sender frontend.Fron
 			return false, err
 		}
 		return true, nil
+	case "Share":
+		if len(payload.Args) < 1 {
+			return nil, errors.New("empty argument, cannot share")
+		}
+		var options frontend.ShareOptions
+		if err := json.Unmarshal(payload.Args[0], &options); err != nil {
+			return nil, err
+		}
+		if err := options.Validate(); err != nil {
+			return nil, err
+		}
+		return nil, sender.Share(options)
+	case "StateSave":
+		if len(payload.Args) < 1 {
+			return nil, errors.New("empty argument, cannot save state")
+		}
+		if len(payload.Args[0]) > maxPageStateSize {
+			return nil, fmt.Errorf("the state exceeds %d bytes", maxPageStateSize)
+		}
+		d.pageStateLock.Lock()
+		d.pageState = payload.Args[0]
+		d.pageStateLock.Unlock()
+		return nil, nil
+	case "StateRestore":
+		d.pageStateLock.Lock()
+		defer d.pageStateLock.Unlock()
+		// null if no snapshot has been saved
+		return d.pageState, nil
 	default:
 		return nil, fmt.Errorf("unknown systemcall message: %s", payload.Name)
 	}
@@ -38,6 +38,8 @@ func (d *Dispatcher) processWindowMessage(message string, sender frontend.Fronte
 			} else if message[2:] == "TP:1" {
 				go sender.WindowSetAlwaysOnTop(true)
 			}
+		case "ST:0", "ST:1":
+			go sender.WindowSetSkipTaskbar(message[2:] == "ST:1")
 		}
 	case 'c':
 		go sender.WindowCenter()
@@ -64,6 +66,8 @@ func (d *Dispatcher) processWindowMessage(message string, sender frontend.Fronte
 		go sender.WindowShow()
 	case 'R':
 		go sender.WindowReloadApp()
+	case 'Y':
+		go sender.WindowRecycle()
 	case 'r':
 		var rgba options.RGBA
 		err := json.Unmarshal([]byte(message[3:]), &rgba)
@@ -91,6 +95,19 @@ func (d *Dispatcher) processWindowMessage(message string, sender frontend.Fronte
 		w := d.mustAtoI(parts[0])
 		h := d.mustAtoI(parts[1])
 		go sender.WindowSetMinSize(w, h)
+	case 'o':
+		switch message[2:] {
+		case "+":
+			go sender.WindowSetZoom(frontend.ZoomIn(sender.WindowGetZoom()))
+		case "-":
+			go sender.WindowSetZoom(frontend.ZoomOut(sender.WindowGetZoom()))
+		default:
+			factor, err := strconv.ParseFloat(strings.TrimPrefix(message[2:], ":"), 64)
+			if err != nil {
+				return "", errors.New("Invalid Zoom Message: " + message)
+			}
+			go sender.WindowSetZoom(factor)
+		}
 	default:
 		d.log.Error("unknown Window message: %s", message)
 	}
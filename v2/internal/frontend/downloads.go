@@ -0,0 +1,305 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// DownloadEvent is the event that is emitted with the Download when its state changes
+const DownloadEvent = "wails:download"
+
+// DownloadState is the state of a download
+type DownloadState string
+
+const (
+	DownloadStarted   DownloadState = "started"
+	DownloadProgress  DownloadState = "progress"
+	DownloadCompleted DownloadState = "completed"
+	DownloadCancelled DownloadState = "cancelled"
+	DownloadFailed    DownloadState = "failed"
+)
+
+// Download is a download of the webview. It is reported by the DownloadManager when its state changes.
+type Download struct {
+	ID            string        `json:"id"`
+	URL           string        `json:"url"`
+	Path          string        `json:"path"`
+	State         DownloadState `json:"state"`
+	ReceivedBytes int64         `json:"receivedBytes"`
+	// TotalBytes is -1 if the size of the download isn't known
+	TotalBytes int64  `json:"totalBytes"`
+	Error      string `json:"error,omitempty"`
+}
+
+// DownloadDestination is where a download that has been started is saved
+type DownloadDestination struct {
+	ID   string
+	Path string
+	// Prompt asks the user where to save the download, with Path as the default
+	Prompt bool
+}
+
+// ErrDownloadCancelled finishes a download that has been cancelled, by the user or with DownloadManager.Cancel
+var ErrDownloadCancelled = errors.New("the download has been cancelled")
+
+// downloadProgressInterval limits the progress reports of a download
+const downloadProgressInterval = 250 * time.Millisecond
+
+type download struct {
+	Download
+	cancel   func()
+	reported time.Time
+}
+
+// DownloadManager decides the paths of the downloads with the options of the application, keeps the downloads in
+// flight and reports their changes in order. The platforms call Start, then Begin once the path is final, Progress
+// and Finish.
+type DownloadManager struct {
+	options *options.Downloads
+	report  func(download Download)
+
+	lock      sync.Mutex
+	next      int
+	downloads map[string]*download
+
+	queueLock sync.Mutex
+	queue     []Download
+	wake      chan struct{}
+}
+
+// NewDownloadManager creates the manager for the options, which may be nil. report is called on a goroutine of the
+// manager with the changes of the downloads.
+func NewDownloadManager(downloads *options.Downloads, report func(download Download)) *DownloadManager {
+	result := &DownloadManager{
+		options:   downloads,
+		report:    report,
+		downloads: map[string]*download{},
+		wake:      make(chan struct{}, 1),
+	}
+	if downloads != nil && report != nil {
+		go result.processReports()
+	}
+	return result
+}
+
+// EmitDownloads returns the report of a DownloadManager that emits the changes with the events of the application
+// in ctx. The events are emitted by Go, the webview can't report downloads.
+func EmitDownloads(ctx context.Context) func(download Download) {
+	events, _ := ctx.Value("events").(Events)
+	if events == nil {
+		return nil
+	}
+	return func(download Download) {
+		events.Emit(DownloadEvent, download)
+	}
+}
+
+// Enabled reports if the downloads are handled by the application
+func (m *DownloadManager) Enabled() bool {
+	return m.options != nil
+}
+
+// Start decides the destination of a download. It returns false if the download should be cancelled.
+func (m *DownloadManager) Start(rawURL string, suggestedFilename string, mimeType string) (DownloadDestination, bool) {
+	name := downloadFilename(rawURL, suggestedFilename)
+	var decision options.DownloadDecision
+	if m.options.OnStart != nil {
+		decision = m.options.OnStart(options.DownloadRequest{URL: rawURL, SuggestedFilename: name, MimeType: mimeType})
+	}
+	if decision.Cancel {
+		return DownloadDestination{}, false
+	}
+
+	directory := m.directory()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	result := DownloadDestination{Path: decision.Path, Prompt: m.options.Prompt && decision.Path == ""}
+	if result.Path == "" {
+		result.Path = m.uniquePath(filepath.Join(directory, name))
+	} else if !filepath.IsAbs(result.Path) {
+		result.Path = filepath.Join(directory, result.Path)
+	}
+	if !result.Prompt {
+		// The webviews don't create the directory
+		_ = os.MkdirAll(filepath.Dir(result.Path), 0o755)
+	}
+	m.next++
+	result.ID = strconv.Itoa(m.next)
+	m.downloads[result.ID] = &download{Download: Download{ID: result.ID, URL: rawURL, Path: result.Path, TotalBytes: -1}}
+	return result, true
+}
+
+// Begin reports the start of the download to the final path. cancel is called by Cancel, the platform then finishes
+// the download with ErrDownloadCancelled.
+func (m *DownloadManager) Begin(id string, path string, cancel func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item := m.downloads[id]
+	if item == nil {
+		return
+	}
+	item.Path = path
+	item.State = DownloadStarted
+	item.cancel = cancel
+	item.reported = time.Now()
+	m.enqueue(item.Download)
+}
+
+// Progress reports the bytes that have been received. total is -1 if it isn't known.
+func (m *DownloadManager) Progress(id string, received int64, total int64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item := m.downloads[id]
+	if item == nil {
+		return
+	}
+	item.ReceivedBytes = received
+	item.TotalBytes = total
+	if time.Since(item.reported) < downloadProgressInterval {
+		return
+	}
+	item.State = DownloadProgress
+	item.reported = time.Now()
+	m.enqueue(item.Download)
+}
+
+// Finish reports the end of the download. It has completed if err is nil and it has been cancelled if err is
+// ErrDownloadCancelled.
+func (m *DownloadManager) Finish(id string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item := m.downloads[id]
+	if item == nil {
+		return
+	}
+	delete(m.downloads, id)
+	switch {
+	case err == nil:
+		item.State = DownloadCompleted
+		if item.TotalBytes >= 0 {
+			item.ReceivedBytes = item.TotalBytes
+		}
+	case errors.Is(err, ErrDownloadCancelled):
+		item.State = DownloadCancelled
+	default:
+		item.State = DownloadFailed
+		item.Error = err.Error()
+	}
+	m.enqueue(item.Download)
+}
+
+// Cancel cancels the download in flight. It returns false if there is no such download or it hasn't begun.
+func (m *DownloadManager) Cancel(id string) bool {
+	m.lock.Lock()
+	item := m.downloads[id]
+	var cancel func()
+	if item != nil {
+		cancel = item.cancel
+	}
+	m.lock.Unlock()
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	return true
+}
+
+func (m *DownloadManager) directory() string {
+	if m.options.Directory != "" {
+		return m.options.Directory
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return os.TempDir()
+	}
+	return filepath.Join(home, "Downloads")
+}
+
+// uniquePath numbers the filename if the file exists or another download is saved to it, EG: report (1).pdf
+func (m *DownloadManager) uniquePath(candidate string) string {
+	extension := filepath.Ext(candidate)
+	base := strings.TrimSuffix(candidate, extension)
+	result := candidate
+	for number := 1; m.pathInUse(result); number++ {
+		result = base + " (" + strconv.Itoa(number) + ")" + extension
+	}
+	return result
+}
+
+func (m *DownloadManager) pathInUse(candidate string) bool {
+	if _, err := os.Lstat(candidate); err == nil {
+		return true
+	}
+	for _, item := range m.downloads {
+		if item.Path == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue queues the report of the download, the webviews call the manager on their main thread which the reports
+// may require
+func (m *DownloadManager) enqueue(item Download) {
+	if m.report == nil {
+		return
+	}
+	m.queueLock.Lock()
+	m.queue = append(m.queue, item)
+	m.queueLock.Unlock()
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *DownloadManager) processReports() {
+	for range m.wake {
+		m.queueLock.Lock()
+		items := m.queue
+		m.queue = nil
+		m.queueLock.Unlock()
+		for _, item := range items {
+			m.report(item)
+		}
+	}
+}
+
+// downloadFilename returns a filename for the download that can't escape the directory
+func downloadFilename(rawURL string, suggestedFilename string) string {
+	name := sanitiseFilename(suggestedFilename)
+	if name == "" {
+		if parsed, err := url.Parse(rawURL); err == nil {
+			name = sanitiseFilename(path.Base(parsed.Path))
+		}
+	}
+	if name == "" {
+		name = "download"
+	}
+	return name
+}
+
+func sanitiseFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, " .")
+	if name == "" || name == "_" {
+		return ""
+	}
+	return name
+}
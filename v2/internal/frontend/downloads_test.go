@@ -0,0 +1,136 @@
+package frontend
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func collectDownloads(t *testing.T) (func(download Download), func(count int) []Download) {
+	reports := make(chan Download, 16)
+	report := func(download Download) {
+		reports <- download
+	}
+	wait := func(count int) []Download {
+		var result []Download
+		for len(result) < count {
+			select {
+			case item := <-reports:
+				result = append(result, item)
+			case <-time.After(time.Second):
+				t.Fatalf("got %d reports, want %d", len(result), count)
+			}
+		}
+		return result
+	}
+	return report, wait
+}
+
+func TestDownloadManagerDefaultPath(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "report.pdf"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewDownloadManager(&options.Downloads{Directory: directory}, nil)
+
+	first, ok := manager.Start("https://example.com/files/report.pdf", "", "application/pdf")
+	if !ok || first.Prompt || first.Path != filepath.Join(directory, "report (1).pdf") {
+		t.Errorf("Start() = %+v, %v, want the numbered path", first, ok)
+	}
+	second, _ := manager.Start("https://example.com/other", "report.pdf", "application/pdf")
+	if second.Path != filepath.Join(directory, "report (2).pdf") || second.ID == first.ID {
+		t.Errorf("Start() = %+v, want a new download saved to another path", second)
+	}
+	escaped, _ := manager.Start("https://example.com/", "../../etc/passwd", "")
+	if escaped.Path != filepath.Join(directory, "passwd") {
+		t.Errorf("Start() = %+v, want the path in the directory", escaped)
+	}
+	unnamed, _ := manager.Start("https://example.com/", "", "")
+	if unnamed.Path != filepath.Join(directory, "download") {
+		t.Errorf("Start() = %+v, want the fallback name", unnamed)
+	}
+}
+
+func TestDownloadManagerDecisions(t *testing.T) {
+	directory := t.TempDir()
+	var requests []options.DownloadRequest
+	manager := NewDownloadManager(&options.Downloads{
+		Directory: directory,
+		Prompt:    true,
+		OnStart: func(request options.DownloadRequest) options.DownloadDecision {
+			requests = append(requests, request)
+			switch request.SuggestedFilename {
+			case "blocked.exe":
+				return options.DownloadDecision{Cancel: true}
+			case "data.csv":
+				return options.DownloadDecision{Path: filepath.Join("exports", "data.csv")}
+			}
+			return options.DownloadDecision{}
+		},
+	}, nil)
+
+	if _, ok := manager.Start("https://example.com/blocked.exe", "", ""); ok {
+		t.Error("expected the download to be cancelled")
+	}
+	decided, _ := manager.Start("https://example.com/export", "data.csv", "text/csv")
+	if decided.Prompt || decided.Path != filepath.Join(directory, "exports", "data.csv") {
+		t.Errorf("Start() = %+v, want the decided path without a prompt", decided)
+	}
+	if _, err := os.Stat(filepath.Join(directory, "exports")); err != nil {
+		t.Errorf("expected the directory of the download to be created: %v", err)
+	}
+	prompted, _ := manager.Start("https://example.com/image.png", "", "image/png")
+	if !prompted.Prompt || prompted.Path != filepath.Join(directory, "image.png") {
+		t.Errorf("Start() = %+v, want a prompt with the default path", prompted)
+	}
+	if len(requests) != 3 || requests[1].MimeType != "text/csv" || requests[2].SuggestedFilename != "image.png" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+}
+
+func TestDownloadManagerReports(t *testing.T) {
+	report, wait := collectDownloads(t)
+	manager := NewDownloadManager(&options.Downloads{Directory: t.TempDir()}, report)
+
+	destination, _ := manager.Start("https://example.com/archive.zip", "", "")
+	cancelled := make(chan struct{}, 1)
+	if manager.Cancel(destination.ID) {
+		t.Error("expected downloads that haven't begun not to be cancelled")
+	}
+	manager.Begin(destination.ID, destination.Path, func() { cancelled <- struct{}{} })
+	manager.Progress(destination.ID, 10, 100)
+	if !manager.Cancel(destination.ID) {
+		t.Fatal("expected the download to be cancelled")
+	}
+	<-cancelled
+	manager.Finish(destination.ID, ErrDownloadCancelled)
+	manager.Finish(destination.ID, nil)
+
+	reports := wait(2)
+	if reports[0].State != DownloadStarted || reports[0].TotalBytes != -1 || reports[0].Path != destination.Path {
+		t.Errorf("unexpected report %+v", reports[0])
+	}
+	// The progress within the interval isn't reported
+	if reports[1].State != DownloadCancelled || reports[1].ReceivedBytes != 10 || reports[1].TotalBytes != 100 {
+		t.Errorf("unexpected report %+v", reports[1])
+	}
+	if manager.Cancel(destination.ID) {
+		t.Error("expected finished downloads not to be cancelled")
+	}
+
+	completed, _ := manager.Start("https://example.com/archive.zip", "", "")
+	manager.Begin(completed.ID, completed.Path, func() {})
+	manager.Finish(completed.ID, nil)
+	failed, _ := manager.Start("https://example.com/archive.zip", "", "")
+	manager.Begin(failed.ID, failed.Path, func() {})
+	manager.Finish(failed.ID, errors.New("network error"))
+
+	reports = wait(4)
+	if reports[1].State != DownloadCompleted || reports[3].State != DownloadFailed || reports[3].Error != "network error" {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+}
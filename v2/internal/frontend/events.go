@@ -1,11 +1,47 @@
 package frontend
 
+// EventPriority is the priority of the delivery of an event to the frontend. Pending events are delivered in the
+// order of their priority, so UI-critical events overtake bulk events when the frontend can't keep up.
+type EventPriority int
+
+const (
+	EventPriorityLow    EventPriority = -1
+	EventPriorityNormal EventPriority = 0
+	EventPriorityHigh   EventPriority = 1
+)
+
+// EventQueueMetrics are the metrics of the queue of a priority
+type EventQueueMetrics struct {
+	// Depth is the number of events that wait for the delivery
+	Depth int `json:"depth"`
+	// MaxDepth is the highest depth since the start
+	MaxDepth int `json:"maxDepth"`
+	// Delivered is the number of delivered events
+	Delivered uint64 `json:"delivered"`
+	// Promoted is the number of events that have been delivered before events of a higher priority, as they had
+	// been passed over too often
+	Promoted uint64 `json:"promoted"`
+	// Dropped is the number of pending low priority events that have been dropped for newer events, as the queue was
+	// full
+	Dropped uint64 `json:"dropped"`
+}
+
+// EventQueueStats are the metrics of the queues of the event delivery to the frontend
+type EventQueueStats struct {
+	High   EventQueueMetrics `json:"high"`
+	Normal EventQueueMetrics `json:"normal"`
+	Low    EventQueueMetrics `json:"low"`
+}
+
 type Events interface {
 	On(eventName string, callback func(...interface{})) func()
 	OnMultiple(eventName string, callback func(...interface{}), counter int) func()
 	Once(eventName string, callback func(...interface{})) func()
 	Emit(eventName string, data ...interface{})
+	EmitWithPriority(priority EventPriority, eventName string, data ...interface{})
 	Off(eventName string)
 	OffAll()
 	Notify(sender Frontend, name string, data ...interface{})
+	QueueStats() EventQueueStats
+	RegisterSchema(eventName string, samples ...interface{})
 }
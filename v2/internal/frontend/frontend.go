@@ -2,6 +2,10 @@ package frontend
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -36,6 +40,23 @@ type SaveDialogOptions struct {
 	TreatPackagesAsDirectories bool
 }
 
+// MediaType selects the media of the media dialog
+type MediaType string
+
+const (
+	MediaImages MediaType = "images"
+	MediaVideos MediaType = "videos"
+	// MediaAll allows images and videos
+	MediaAll MediaType = ""
+)
+
+// MediaDialogOptions contains the options for the OpenMediaDialog runtime method
+type MediaDialogOptions struct {
+	Title    string
+	Type     MediaType
+	Multiple bool
+}
+
 type DialogType string
 
 const (
@@ -76,6 +97,58 @@ type MessageDialogOptions struct {
 	Icon          []byte
 }
 
+// EditCommand is an editing command that is executed in the focused element of the webview
+type EditCommand string
+
+const (
+	EditCommandCopy               EditCommand = "copy"
+	EditCommandCut                EditCommand = "cut"
+	EditCommandPaste              EditCommand = "paste"
+	EditCommandSelectAll          EditCommand = "selectAll"
+	EditCommandUndo               EditCommand = "undo"
+	EditCommandRedo               EditCommand = "redo"
+	EditCommandPasteAndMatchStyle EditCommand = "pasteAndMatchStyle"
+	// EditCommandFind emits the menu.FindEvent, see FindScript
+	EditCommandFind EditCommand = "find"
+)
+
+// ShareAnchor is the rectangle of the UI element the share sheet is positioned relative to, in CSS pixels relative
+// to the viewport of the webview
+type ShareAnchor struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ShareOptions contains the options for the Share runtime method. At least one of Text, URL and Files is required.
+type ShareOptions struct {
+	// Title is the title of the share sheet on Windows and the subject of emails. It defaults to the window title.
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	URL   string `json:"url"`
+	// Files are the absolute paths of the shared files
+	Files []string `json:"files"`
+	// Anchor positions the share sheet on macOS. It is centred in the window if nil.
+	Anchor *ShareAnchor `json:"anchor"`
+}
+
+// Validate checks that there is something to share and that the files exist
+func (o ShareOptions) Validate() error {
+	if o.Text == "" && o.URL == "" && len(o.Files) == 0 {
+		return errors.New("nothing to share")
+	}
+	for _, file := range o.Files {
+		if !filepath.IsAbs(file) {
+			return fmt.Errorf("the shared file %s is not an absolute path", file)
+		}
+		if _, err := os.Stat(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type Frontend interface {
 	Run(ctx context.Context) error
 	RunMainLoop()
@@ -90,6 +163,7 @@ type Frontend interface {
 	OpenDirectoryDialog(dialogOptions OpenDialogOptions) (string, error)
 	SaveFileDialog(dialogOptions SaveDialogOptions) (string, error)
 	MessageDialog(dialogOptions MessageDialogOptions) (string, error)
+	OpenMediaDialog(dialogOptions MediaDialogOptions) ([]string, error)
 
 	// Window
 	WindowSetTitle(title string)
@@ -102,6 +176,7 @@ type Frontend interface {
 	WindowMinimise()
 	WindowUnminimise()
 	WindowSetAlwaysOnTop(b bool)
+	WindowSetSkipTaskbar(skip bool)
 	WindowSetPosition(x int, y int)
 	WindowGetPosition() (int, int)
 	WindowSetSize(width int, height int)
@@ -122,6 +197,18 @@ type Frontend interface {
 	WindowIsFullscreen() bool
 	WindowClose()
 	WindowPrint()
+	WindowPrintToPDF(options PDFOptions) ([]byte, error)
+	WindowCaptureSnapshot() ([]byte, error)
+	WindowRecycle()
+	WindowAddUserContent(content options.UserContent) (string, error)
+	WindowRemoveUserContent(id string)
+	WindowEmbedNativeView(id string, handle uintptr) error
+	WindowRemoveNativeView(id string)
+	WindowSetNativeViewBounds(bounds NativeViewBounds)
+	WindowSetZoom(factor float64)
+	WindowGetZoom() float64
+	WindowSetAudioMuted(muted bool)
+	WindowIsAudioMuted() bool
 
 	// Screen
 	ScreenGetAll() ([]Screen, error)
@@ -139,4 +226,17 @@ type Frontend interface {
 	// Clipboard
 	ClipboardGetText() (string, error)
 	ClipboardSetText(text string) error
+	ClipboardEditCommand(command EditCommand)
+
+	// Share
+	Share(options ShareOptions) error
+
+	// Downloads
+	DownloadCancel(id string)
+
+	// Cookies and site data
+	CookiesGet(url string) ([]Cookie, error)
+	CookieSet(cookie Cookie) error
+	CookieDelete(cookie Cookie) error
+	SiteDataClear(data SiteData) error
 }
@@ -0,0 +1,79 @@
+package frontend
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// OriginPolicy decides which documents can use the runtime bridge and which documents can be loaded
+type OriginPolicy struct {
+	policy  options.ExternalContentPolicy
+	trusted map[string]bool
+}
+
+// NewOriginPolicy creates the policy for the application, which is loaded from appURL
+func NewOriginPolicy(externalContent *options.ExternalContent, appURL *url.URL) *OriginPolicy {
+	result := &OriginPolicy{
+		trusted: map[string]bool{},
+	}
+	if externalContent == nil {
+		return result
+	}
+	result.policy = externalContent.Policy
+	result.trusted[OriginOf(appURL.String())] = true
+	for _, origin := range externalContent.TrustedOrigins {
+		result.trusted[OriginOf(origin)] = true
+	}
+	return result
+}
+
+// Isolated reports if documents of third-party origins are kept from the runtime bridge
+func (p *OriginPolicy) Isolated() bool {
+	return p.policy != options.ExternalContentAllow
+}
+
+// Trusted reports if documents of the origin can use the runtime bridge
+func (p *OriginPolicy) Trusted(origin string) bool {
+	return p.policy == options.ExternalContentAllow || p.trusted[origin]
+}
+
+// CanNavigate reports if the document of the URL can be loaded. Documents without an origin, EG: about:blank, are
+// always loaded, they can't use the runtime bridge unless everything is allowed.
+func (p *OriginPolicy) CanNavigate(rawURL string) bool {
+	if p.policy != options.ExternalContentBlock {
+		return true
+	}
+	origin := OriginOf(rawURL)
+	return origin == "" || p.trusted[origin]
+}
+
+// OriginOf returns the serialised origin of the URL, EG: "https://example.com:8443". It is empty for URLs with an
+// opaque origin.
+func OriginOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	if strings.EqualFold(u.Scheme, "blob") {
+		return OriginOf(u.Opaque)
+	}
+	if u.Host == "" {
+		return ""
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return scheme + "://" + host
+}
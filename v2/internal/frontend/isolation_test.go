@@ -0,0 +1,58 @@
+package frontend
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestOriginOf(t *testing.T) {
+	tests := map[string]string{
+		"wails://wails/index.html":           "wails://wails",
+		"http://wails.localhost:34115/":      "http://wails.localhost:34115",
+		"HTTPS://Example.com:443/docs?a=b":   "https://example.com",
+		"http://example.com:80":              "http://example.com",
+		"http://[::1]/":                      "http://[::1]",
+		"http://[::1]:8080/":                 "http://[::1]:8080",
+		"blob:https://example.com/3f2c-9a1b": "https://example.com",
+		"about:blank":                        "",
+		"data:text/html,<p>":                 "",
+	}
+	for rawURL, want := range tests {
+		if got := OriginOf(rawURL); got != want {
+			t.Errorf("OriginOf(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestOriginPolicy(t *testing.T) {
+	appURL, _ := url.Parse("wails://wails/")
+
+	allow := NewOriginPolicy(nil, appURL)
+	if !allow.Trusted("https://example.com") || !allow.CanNavigate("https://example.com/") {
+		t.Error("expected all origins to be allowed without options")
+	}
+
+	isolate := NewOriginPolicy(&options.ExternalContent{
+		Policy:         options.ExternalContentIsolate,
+		TrustedOrigins: []string{"https://auth.example.com/"},
+	}, appURL)
+	if !isolate.Trusted("wails://wails") || !isolate.Trusted("https://auth.example.com") {
+		t.Error("expected the application and the trusted origins to be trusted")
+	}
+	if isolate.Trusted("https://docs.example.com") || isolate.Trusted("") {
+		t.Error("expected third-party origins to be isolated")
+	}
+	if !isolate.CanNavigate("https://docs.example.com/") {
+		t.Error("expected third-party documents to be loaded when isolated")
+	}
+
+	block := NewOriginPolicy(&options.ExternalContent{Policy: options.ExternalContentBlock}, appURL)
+	if block.CanNavigate("https://docs.example.com/") || block.Trusted("https://docs.example.com") {
+		t.Error("expected third-party documents to be blocked")
+	}
+	if !block.CanNavigate("wails://wails/about.html") || !block.CanNavigate("about:blank") {
+		t.Error("expected the application and documents without an origin to be loaded")
+	}
+}
@@ -0,0 +1,67 @@
+package frontend
+
+import "strings"
+
+var (
+	imageExtensions = []string{"jpg", "jpeg", "png", "gif", "webp", "heic", "heif", "bmp", "tif", "tiff"}
+	videoExtensions = []string{"mp4", "m4v", "mov", "avi", "mkv", "webm", "wmv"}
+)
+
+// MediaExtensions returns the file extensions of the media type without dot
+func MediaExtensions(mediaType MediaType) []string {
+	switch mediaType {
+	case MediaImages:
+		return imageExtensions
+	case MediaVideos:
+		return videoExtensions
+	default:
+		return append(append([]string{}, imageExtensions...), videoExtensions...)
+	}
+}
+
+// MediaMIMETypes returns the MIME types of the media type, EG: for the file chooser portal
+func MediaMIMETypes(mediaType MediaType) []string {
+	switch mediaType {
+	case MediaImages:
+		return []string{"image/*"}
+	case MediaVideos:
+		return []string{"video/*"}
+	default:
+		return []string{"image/*", "video/*"}
+	}
+}
+
+// MediaFilterTypes returns the media types of the dialog filters. Dialogs for all media can be filtered to images or
+// videos.
+func MediaFilterTypes(mediaType MediaType) []MediaType {
+	if mediaType == MediaImages || mediaType == MediaVideos {
+		return []MediaType{mediaType}
+	}
+	return []MediaType{MediaAll, MediaImages, MediaVideos}
+}
+
+// MediaTypeName returns the display name of the media type
+func MediaTypeName(mediaType MediaType) string {
+	switch mediaType {
+	case MediaImages:
+		return "Images"
+	case MediaVideos:
+		return "Videos"
+	default:
+		return "Images and Videos"
+	}
+}
+
+// MediaFilters returns the file dialog filters of the media type
+func MediaFilters(mediaType MediaType) []FileFilter {
+	var filters []FileFilter
+	for _, filterType := range MediaFilterTypes(mediaType) {
+		extensions := MediaExtensions(filterType)
+		patterns := make([]string, len(extensions))
+		for index, extension := range extensions {
+			patterns[index] = "*." + extension
+		}
+		filters = append(filters, FileFilter{DisplayName: MediaTypeName(filterType), Pattern: strings.Join(patterns, ";")})
+	}
+	return filters
+}
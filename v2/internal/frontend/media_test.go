@@ -0,0 +1,18 @@
+package frontend
+
+import "testing"
+
+func TestMediaFilters(t *testing.T) {
+	filters := MediaFilters(MediaVideos)
+	if len(filters) != 1 || filters[0].Pattern != "*.mp4;*.m4v;*.mov;*.avi;*.mkv;*.webm;*.wmv" {
+		t.Errorf("unexpected video filters %+v", filters)
+	}
+
+	filters = MediaFilters(MediaAll)
+	if len(filters) != 3 || filters[0].Pattern != filters[1].Pattern+";"+filters[2].Pattern {
+		t.Errorf("expected the first filter to combine images and videos, got %+v", filters)
+	}
+	if len(imageExtensions) != 10 {
+		t.Errorf("combining the extensions must not modify the image extensions")
+	}
+}
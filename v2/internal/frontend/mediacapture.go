@@ -0,0 +1,68 @@
+package frontend
+
+import "github.com/wailsapp/wails/v2/pkg/options"
+
+// mediaCaptureScript tracks the streams of getUserMedia and getDisplayMedia and reports the capture state with the
+// 'M' message whenever it changes. window._wailsMediaCapture.stop stops the tracks of a kind, or all tracks.
+const mediaCaptureScript = `(function() {
+var mediaDevices = navigator.mediaDevices;
+if (!mediaDevices) { return; }
+var tracks = [];
+var reported = JSON.stringify({camera: false, microphone: false, screen: false});
+function report(force) {
+	var state = {camera: false, microphone: false, screen: false};
+	tracks = tracks.filter(function(entry) { return entry.track.readyState === 'live'; });
+	tracks.forEach(function(entry) { state[entry.kind] = true; });
+	var message = JSON.stringify(state);
+	if ((message !== reported || force) && window.WailsInvoke) {
+		reported = message;
+		window.WailsInvoke('M' + message);
+	}
+}
+function track(stream, screen) {
+	stream.getTracks().forEach(function(mediaTrack) {
+		var kind = screen ? 'screen' : (mediaTrack.kind === 'video' ? 'camera' : 'microphone');
+		var stop = mediaTrack.stop;
+		mediaTrack.stop = function() { stop.call(mediaTrack); report(); };
+		mediaTrack.addEventListener('ended', function() { report(); });
+		tracks.push({kind: kind, track: mediaTrack});
+	});
+	report();
+	return stream;
+}
+function wrap(name, screen) {
+	var original = mediaDevices[name];
+	if (!original) { return; }
+	mediaDevices[name] = function() {
+		return original.apply(mediaDevices, arguments).then(function(stream) { return track(stream, screen); });
+	};
+}
+wrap('getUserMedia', false);
+wrap('getDisplayMedia', true);
+window.addEventListener('pagehide', function() {
+	tracks = [];
+	if (reported !== JSON.stringify({camera: false, microphone: false, screen: false})) { report(true); }
+});
+window._wailsMediaCapture = {
+	stop: function(kind) {
+		tracks.forEach(function(entry) {
+			if (!kind || entry.kind === kind) { entry.track.stop(); }
+		});
+		report();
+	}
+};
+})();
+`
+
+// InjectedScriptSource returns the scripts of the application options that are injected at the start of every page
+func InjectedScriptSource(appoptions *options.App) (string, error) {
+	userScript, err := UserScriptSource(appoptions.UserScript)
+	if err != nil {
+		return "", err
+	}
+	userScript = PerformanceScriptSource(appoptions.PerformanceMetrics) + userScript
+	if appoptions.EnableMediaCaptureEvents {
+		return mediaCaptureScript + userScript, nil
+	}
+	return userScript, nil
+}
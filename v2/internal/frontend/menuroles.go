@@ -0,0 +1,99 @@
+package frontend
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+)
+
+// FindScript emits the menu.FindEvent through the runtime of the page, so it reaches the listeners of the frontend
+// and of the application
+var FindScript = "window.runtime.EventsEmit(" + strconv.Quote(menu.FindEvent) + ");"
+
+// editRole is the standard item of a text editing role
+type editRole struct {
+	label       string
+	accelerator *keys.Accelerator
+	command     EditCommand
+}
+
+var editRoles = map[menu.Role]editRole{
+	menu.UndoRole:               {"Undo", keys.CmdOrCtrl("z"), EditCommandUndo},
+	menu.RedoRole:               {"Redo", keys.Combo("z", keys.CmdOrCtrlKey, keys.ShiftKey), EditCommandRedo},
+	menu.CutRole:                {"Cut", keys.CmdOrCtrl("x"), EditCommandCut},
+	menu.CopyRole:               {"Copy", keys.CmdOrCtrl("c"), EditCommandCopy},
+	menu.PasteRole:              {"Paste", keys.CmdOrCtrl("v"), EditCommandPaste},
+	menu.PasteAndMatchStyleRole: {"Paste and Match Style", keys.Combo("v", keys.CmdOrCtrlKey, keys.OptionOrAltKey, keys.ShiftKey), EditCommandPasteAndMatchStyle},
+	menu.SelectAllRole:          {"Select All", keys.CmdOrCtrl("a"), EditCommandSelectAll},
+	menu.FindRole:               {"Find", keys.CmdOrCtrl("f"), EditCommandFind},
+}
+
+// EditRoleCommand returns the command of a text editing role
+func EditRoleCommand(role menu.Role) (EditCommand, bool) {
+	editRole, ok := editRoles[role]
+	return editRole.command, ok
+}
+
+// editRoleItems keeps the text item of each item with a text editing role, so the platforms get the same item when
+// the menu is processed again
+var (
+	editRoleItems     = map[*menu.MenuItem]*menu.MenuItem{}
+	editRoleItemsLock sync.Mutex
+)
+
+// EditRoleItem returns the text item of an item with a text editing role, which calls execute with the command of
+// the role when it is clicked. The label and the accelerator of the item replace the standard ones. A Find item with
+// a Click callback calls the callback instead. ok is false if the item has no text editing role.
+// The same text item is returned for an item on every call and it is updated with the fields of the item, so changes
+// of the item, EG: to Disabled or Label, apply when the platform processes the menu again.
+func EditRoleItem(item *menu.MenuItem, execute func(command EditCommand)) (result *menu.MenuItem, ok bool) {
+	role, ok := editRoles[item.Role]
+	if !ok {
+		return nil, false
+	}
+
+	editRoleItemsLock.Lock()
+	result = editRoleItems[item]
+	if result == nil {
+		result = &menu.MenuItem{}
+		editRoleItems[item] = result
+	}
+	editRoleItemsLock.Unlock()
+
+	result.Label = role.label
+	result.Accelerator = role.accelerator
+	result.Type = menu.TextType
+	result.Disabled = item.Disabled
+	result.Hidden = item.Hidden
+	result.Click = func(*menu.CallbackData) {
+		execute(role.command)
+	}
+	if item.Label != "" {
+		result.Label = item.Label
+	}
+	if item.Accelerator != nil {
+		result.Accelerator = item.Accelerator
+	}
+	if item.Role == menu.FindRole && item.Click != nil {
+		result.Click = func(*menu.CallbackData) {
+			item.Click(&menu.CallbackData{MenuItem: item})
+		}
+	}
+	return result, true
+}
+
+// EditMenu returns the submenu of the EditMenuRole on Windows and Linux, macOS has its own
+func EditMenu() *menu.MenuItem {
+	return menu.SubMenu("Edit", menu.NewMenuFromItems(
+		menu.Undo(),
+		menu.Redo(),
+		menu.Separator(),
+		menu.Cut(),
+		menu.Copy(),
+		menu.Paste(),
+		menu.Separator(),
+		menu.SelectAll(),
+	))
+}
@@ -0,0 +1,103 @@
+package frontend
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+)
+
+func TestEditRoleItem(t *testing.T) {
+	var executed []EditCommand
+	execute := func(command EditCommand) {
+		executed = append(executed, command)
+	}
+
+	item, ok := EditRoleItem(menu.Copy(), execute)
+	if !ok {
+		t.Fatal("the Copy role is not a text editing role")
+	}
+	if item.Label != "Copy" || item.Accelerator.Key != "c" || item.Type != menu.TextType {
+		t.Errorf("unexpected item %+v", item)
+	}
+	item.Click(&menu.CallbackData{MenuItem: item})
+
+	custom := menu.PasteAndMatchStyle()
+	custom.Label = "Einfügen und Stil anpassen"
+	custom.Accelerator = keys.Combo("v", keys.CmdOrCtrlKey, keys.ShiftKey)
+	custom.Disabled = true
+	item, _ = EditRoleItem(custom, execute)
+	if item.Label != custom.Label || item.Accelerator != custom.Accelerator || !item.Disabled {
+		t.Errorf("the item doesn't override the role: %+v", item)
+	}
+	item.Click(&menu.CallbackData{MenuItem: item})
+
+	if len(executed) != 2 || executed[0] != EditCommandCopy || executed[1] != EditCommandPasteAndMatchStyle {
+		t.Errorf("executed = %v", executed)
+	}
+	if command, ok := EditRoleCommand(menu.PasteRole); !ok || command != EditCommandPaste {
+		t.Errorf("EditRoleCommand(PasteRole) = %v, %v", command, ok)
+	}
+
+	if _, ok := EditRoleItem(menu.Text("Open", nil, nil), execute); ok {
+		t.Error("a text item has no text editing role")
+	}
+	if _, ok := EditRoleItem(menu.EditMenu(), execute); ok {
+		t.Error("the Edit menu is not a text editing role")
+	}
+}
+
+func TestEditRoleItemUpdate(t *testing.T) {
+	source := menu.Copy()
+	item, _ := EditRoleItem(source, func(EditCommand) {})
+
+	source.Disabled = true
+	source.Label = "Kopieren"
+	updated, _ := EditRoleItem(source, func(EditCommand) {})
+	if updated != item {
+		t.Error("expected the same item for the item on every call")
+	}
+	if !item.Disabled || item.Label != "Kopieren" {
+		t.Errorf("the item has not been updated: %+v", item)
+	}
+
+	if other, _ := EditRoleItem(menu.Copy(), func(EditCommand) {}); other == item || other.Disabled {
+		t.Error("expected another item for another Copy item")
+	}
+}
+
+func TestEditRoleItemFind(t *testing.T) {
+	var executed []EditCommand
+	execute := func(command EditCommand) {
+		executed = append(executed, command)
+	}
+
+	item, _ := EditRoleItem(menu.Find(), execute)
+	item.Click(&menu.CallbackData{MenuItem: item})
+	if len(executed) != 1 || executed[0] != EditCommandFind {
+		t.Errorf("executed = %v", executed)
+	}
+
+	find := menu.Find()
+	var clicked *menu.MenuItem
+	find.Click = func(data *menu.CallbackData) {
+		clicked = data.MenuItem
+	}
+	item, _ = EditRoleItem(find, execute)
+	item.Click(&menu.CallbackData{MenuItem: item})
+	if clicked != find || len(executed) != 1 {
+		t.Errorf("the Click callback of the item has not been called instead of emitting the event")
+	}
+}
+
+func TestEditMenu(t *testing.T) {
+	var roles []menu.Role
+	for _, item := range EditMenu().SubMenu.Items {
+		roles = append(roles, item.Role)
+	}
+	want := []menu.Role{menu.UndoRole, menu.RedoRole, 0, menu.CutRole, menu.CopyRole, menu.PasteRole, 0, menu.SelectAllRole}
+	if !reflect.DeepEqual(roles, want) {
+		t.Errorf("roles = %v, want %v", roles, want)
+	}
+}
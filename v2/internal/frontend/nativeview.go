@@ -0,0 +1,93 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// NativeViewBounds is the region of the placeholder element of a native view in CSS pixels, relative to the viewport
+// of the webview. It is reported by WindowTrackNativeView of the JS runtime.
+type NativeViewBounds struct {
+	ID      string `json:"id"`
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Visible bool   `json:"visible"`
+	// Scale is the devicePixelRatio of the webview, which contains the zoom of the page and, on Windows, the scale
+	// of the display
+	Scale float64 `json:"scale"`
+}
+
+// NativeViewManager keeps the native views that are embedded in a window and the last bounds of their placeholders,
+// which can be reported before the view is embedded
+type NativeViewManager struct {
+	lock    sync.Mutex
+	handles map[string]uintptr
+	bounds  map[string]NativeViewBounds
+}
+
+func NewNativeViewManager() *NativeViewManager {
+	return &NativeViewManager{
+		handles: map[string]uintptr{},
+		bounds:  map[string]NativeViewBounds{},
+	}
+}
+
+// Add adds the view and returns the last bounds of its placeholder. Views are hidden until their placeholder is
+// visible.
+func (m *NativeViewManager) Add(id string, handle uintptr) (NativeViewBounds, error) {
+	if id == "" {
+		return NativeViewBounds{}, errors.New("native views require an id")
+	}
+	if handle == 0 {
+		return NativeViewBounds{}, errors.New("invalid handle of native view " + id)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, exists := m.handles[id]; exists {
+		return NativeViewBounds{}, fmt.Errorf("a native view with the id %s has already been embedded", id)
+	}
+	for otherID, other := range m.handles {
+		if other == handle {
+			return NativeViewBounds{}, fmt.Errorf("the native view %s has already been embedded as %s", id, otherID)
+		}
+	}
+	m.handles[id] = handle
+	bounds, exists := m.bounds[id]
+	if !exists {
+		bounds = NativeViewBounds{ID: id}
+	}
+	return bounds, nil
+}
+
+// Remove removes the view and returns its handle. It returns false if there is no such view.
+func (m *NativeViewManager) Remove(id string) (uintptr, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	handle, exists := m.handles[id]
+	delete(m.handles, id)
+	return handle, exists
+}
+
+// SetBounds remembers the bounds of the placeholder and returns the handle of the view if it has been embedded
+func (m *NativeViewManager) SetBounds(bounds NativeViewBounds) (uintptr, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bounds[bounds.ID] = bounds
+	handle, exists := m.handles[bounds.ID]
+	return handle, exists
+}
+
+// Handles returns the handles of all views, EG: to move them along with the webview
+func (m *NativeViewManager) Handles() map[string]uintptr {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	result := make(map[string]uintptr, len(m.handles))
+	for id, handle := range m.handles {
+		result[id] = handle
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package frontend
+
+import "testing"
+
+func TestNativeViewManager(t *testing.T) {
+	m := NewNativeViewManager()
+
+	if _, embedded := m.SetBounds(NativeViewBounds{ID: "video", X: 10, Y: 20, Width: 640, Height: 360, Visible: true}); embedded {
+		t.Error("SetBounds() found a view that hasn't been embedded")
+	}
+	bounds, err := m.Add("video", 0x1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds != (NativeViewBounds{ID: "video", X: 10, Y: 20, Width: 640, Height: 360, Visible: true}) {
+		t.Errorf("Add() = %+v, want the bounds that have been reported before", bounds)
+	}
+	if bounds, _ := m.Add("map", 0x5678); bounds != (NativeViewBounds{ID: "map"}) {
+		t.Errorf("Add() = %+v, want hidden bounds", bounds)
+	}
+
+	for _, tt := range []struct {
+		id     string
+		handle uintptr
+	}{
+		{"", 0x1},
+		{"other", 0},
+		{"video", 0x9999},
+		{"other", 0x1234},
+	} {
+		if _, err := m.Add(tt.id, tt.handle); err == nil {
+			t.Errorf("Add(%q, %x) didn't fail", tt.id, tt.handle)
+		}
+	}
+
+	if handle, embedded := m.SetBounds(NativeViewBounds{ID: "video"}); !embedded || handle != 0x1234 {
+		t.Errorf("SetBounds() = %x, %v", handle, embedded)
+	}
+	if handle, removed := m.Remove("video"); !removed || handle != 0x1234 {
+		t.Errorf("Remove() = %x, %v", handle, removed)
+	}
+	if _, removed := m.Remove("video"); removed {
+		t.Error("Remove() removed the view twice")
+	}
+	if handles := m.Handles(); len(handles) != 1 || handles["map"] != 0x5678 {
+		t.Errorf("Handles() = %v", handles)
+	}
+}
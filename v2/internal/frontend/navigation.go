@@ -0,0 +1,80 @@
+package frontend
+
+import (
+	"net/url"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// NavigationPolicy decides the navigations and the new windows of the webview with the callbacks of the application
+type NavigationPolicy struct {
+	options       *options.Navigation
+	appOrigin     string
+	openInBrowser func(url string)
+}
+
+// NewNavigationPolicy creates the policy for the application, which is loaded from appURL. openInBrowser is called
+// on a new goroutine.
+func NewNavigationPolicy(navigation *options.Navigation, appURL *url.URL, openInBrowser func(url string)) *NavigationPolicy {
+	result := &NavigationPolicy{options: navigation, openInBrowser: openInBrowser}
+	if appURL != nil {
+		result.appOrigin = OriginOf(appURL.String())
+	}
+	return result
+}
+
+// HandlesNavigations reports if the navigations are decided by the application
+func (p *NavigationPolicy) HandlesNavigations() bool {
+	return p.options != nil && p.options.OnNavigation != nil
+}
+
+// HandlesNewWindows reports if the new windows are decided by the application
+func (p *NavigationPolicy) HandlesNewWindows() bool {
+	return p.options != nil && p.options.OnNewWindow != nil
+}
+
+func (p *NavigationPolicy) request(rawURL string, userInitiated bool) options.NavigationRequest {
+	origin := OriginOf(rawURL)
+	return options.NavigationRequest{
+		URL:           rawURL,
+		Internal:      origin != "" && origin == p.appOrigin,
+		UserInitiated: userInitiated,
+	}
+}
+
+// Navigation decides the navigation to the URL. If it isn't allowed, the webview cancels it and loads the returned
+// URL instead if it isn't empty.
+func (p *NavigationPolicy) Navigation(rawURL string, userInitiated bool) (allow bool, load string) {
+	if !p.HandlesNavigations() {
+		return true, ""
+	}
+	decision := p.options.OnNavigation(p.request(rawURL, userInitiated))
+	if decision.Action == options.NavigationAllow || (decision.Action == options.NavigationRedirect && decision.URL == rawURL) {
+		return true, ""
+	}
+	return false, p.apply(rawURL, decision)
+}
+
+// NewWindow decides the new window for the URL. If it is handled, the webview doesn't open the window and loads the
+// returned URL in the window instead if it isn't empty. Unhandled windows are left to the webview.
+func (p *NavigationPolicy) NewWindow(rawURL string, userInitiated bool) (handled bool, load string) {
+	if !p.HandlesNewWindows() {
+		return false, ""
+	}
+	return true, p.apply(rawURL, p.options.OnNewWindow(p.request(rawURL, userInitiated)))
+}
+
+// apply returns the URL to load for the decision and opens the browser
+func (p *NavigationPolicy) apply(rawURL string, decision options.NavigationDecision) string {
+	switch decision.Action {
+	case options.NavigationAllow:
+		return rawURL
+	case options.NavigationOpenInBrowser:
+		if p.openInBrowser != nil {
+			go p.openInBrowser(rawURL)
+		}
+	case options.NavigationRedirect:
+		return decision.URL
+	}
+	return ""
+}
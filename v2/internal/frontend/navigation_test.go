@@ -0,0 +1,90 @@
+package frontend
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestNavigationPolicyWithoutCallbacks(t *testing.T) {
+	policy := NewNavigationPolicy(nil, nil, nil)
+	if allow, load := policy.Navigation("https://example.com/", true); !allow || load != "" {
+		t.Errorf("Navigation() = %v, %q, want the navigation to be allowed", allow, load)
+	}
+	if handled, _ := policy.NewWindow("https://example.com/", true); handled {
+		t.Error("expected the new window to be left to the webview")
+	}
+}
+
+func TestNavigationPolicy(t *testing.T) {
+	appURL, _ := url.Parse("wails://wails/")
+	opened := make(chan string, 1)
+	var requests []options.NavigationRequest
+	decide := func(request options.NavigationRequest) options.NavigationDecision {
+		requests = append(requests, request)
+		switch {
+		case request.Internal:
+			return options.NavigationDecision{}
+		case strings.HasPrefix(request.URL, "https://docs.example.com/"):
+			return options.NavigationDecision{Action: options.NavigationRedirect, URL: "wails://wails/docs"}
+		case strings.HasPrefix(request.URL, "https://"):
+			return options.NavigationDecision{Action: options.NavigationOpenInBrowser}
+		}
+		return options.NavigationDecision{Action: options.NavigationDeny}
+	}
+	policy := NewNavigationPolicy(&options.Navigation{OnNavigation: decide, OnNewWindow: decide}, appURL, func(url string) {
+		opened <- url
+	})
+
+	tests := []struct {
+		url   string
+		allow bool
+		load  string
+	}{
+		{"wails://wails/settings", true, ""},
+		{"https://docs.example.com/guide", false, "wails://wails/docs"},
+		{"https://example.com/", false, ""},
+		{"ftp://example.com/", false, ""},
+	}
+	for _, tt := range tests {
+		if allow, load := policy.Navigation(tt.url, false); allow != tt.allow || load != tt.load {
+			t.Errorf("Navigation(%q) = %v, %q, want %v, %q", tt.url, allow, load, tt.allow, tt.load)
+		}
+	}
+	select {
+	case url := <-opened:
+		if url != "https://example.com/" {
+			t.Errorf("opened %q in the browser", url)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the URL to be opened in the browser")
+	}
+	if !requests[0].Internal || requests[1].Internal {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+
+	// New windows that are allowed are loaded in the window
+	if handled, load := policy.NewWindow("wails://wails/popup", true); !handled || load != "wails://wails/popup" {
+		t.Errorf("NewWindow() = %v, %q", handled, load)
+	}
+	if handled, load := policy.NewWindow("ftp://example.com/", true); !handled || load != "" {
+		t.Errorf("NewWindow() = %v, %q", handled, load)
+	}
+	if !requests[len(requests)-1].UserInitiated {
+		t.Error("expected the request to be user initiated")
+	}
+}
+
+func TestNavigationRedirectToSameURL(t *testing.T) {
+	policy := NewNavigationPolicy(&options.Navigation{
+		OnNavigation: func(request options.NavigationRequest) options.NavigationDecision {
+			return options.NavigationDecision{Action: options.NavigationRedirect, URL: request.URL}
+		},
+	}, nil, nil)
+	if allow, _ := policy.Navigation("https://example.com/", false); !allow {
+		t.Error("expected a redirect to the same URL to be allowed")
+	}
+}
@@ -0,0 +1,130 @@
+package frontend
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PDFOptions contains the options for the WindowPrintToPDF runtime method. The sizes are in inches.
+type PDFOptions struct {
+	// PaperSize is the name of the paper: Letter, Legal, Tabloid, A3, A4 or A5. Default: Letter
+	PaperSize string `json:"paperSize"`
+	// PageWidth and PageHeight are the size of a custom paper, they replace the PaperSize
+	PageWidth  float64 `json:"pageWidth"`
+	PageHeight float64 `json:"pageHeight"`
+	// Margins are the margins of the pages. Default: 0.4 inches on every side
+	Margins   *PDFMargins `json:"margins"`
+	Landscape bool        `json:"landscape"`
+	// Scale scales the content, from 0.1 to 2. Default: 1
+	Scale           float64 `json:"scale"`
+	PrintBackground bool    `json:"printBackground"`
+	// HeaderAndFooter prints the title of the document in the header and its URL in the footer
+	HeaderAndFooter bool `json:"headerAndFooter"`
+	// HeaderTitle replaces the title in the header. Windows only.
+	HeaderTitle string `json:"headerTitle"`
+	// FooterURL replaces the URL in the footer. Windows only.
+	FooterURL string `json:"footerURL"`
+}
+
+// PDFMargins are the margins of the pages in inches
+type PDFMargins struct {
+	Top    float64 `json:"top"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+	Right  float64 `json:"right"`
+}
+
+// PDFPage is the page of the PDFOptions with the defaults applied. Width and Height are the portrait size of the
+// paper, the orientation is applied by the webview.
+type PDFPage struct {
+	Width   float64
+	Height  float64
+	Margins PDFMargins
+	Scale   float64
+}
+
+// paperSizes are the portrait sizes of the named papers in inches
+var paperSizes = map[string][2]float64{
+	"letter":  {8.5, 11},
+	"legal":   {8.5, 14},
+	"tabloid": {11, 17},
+	"a3":      {11.69, 16.54},
+	"a4":      {8.27, 11.69},
+	"a5":      {5.83, 8.27},
+}
+
+const defaultPDFMargin = 0.4
+
+// Page applies the defaults and checks that the content fits between the margins
+func (o PDFOptions) Page() (PDFPage, error) {
+	page := PDFPage{Width: o.PageWidth, Height: o.PageHeight, Scale: o.Scale}
+	if page.Width <= 0 || page.Height <= 0 {
+		paperSize := o.PaperSize
+		if paperSize == "" {
+			paperSize = "Letter"
+		}
+		size, ok := paperSizes[strings.ToLower(paperSize)]
+		if !ok {
+			return PDFPage{}, fmt.Errorf("unknown paper size %s", o.PaperSize)
+		}
+		page.Width, page.Height = size[0], size[1]
+	}
+	if page.Scale == 0 {
+		page.Scale = 1
+	}
+	if page.Scale < 0.1 || page.Scale > 2 {
+		return PDFPage{}, fmt.Errorf("the scale %g is not between 0.1 and 2", page.Scale)
+	}
+	if o.Margins != nil {
+		page.Margins = *o.Margins
+	} else {
+		page.Margins = PDFMargins{Top: defaultPDFMargin, Bottom: defaultPDFMargin, Left: defaultPDFMargin, Right: defaultPDFMargin}
+	}
+	margins := page.Margins
+	if margins.Top < 0 || margins.Bottom < 0 || margins.Left < 0 || margins.Right < 0 {
+		return PDFPage{}, errors.New("the margins must not be negative")
+	}
+	width, height := page.Width, page.Height
+	if o.Landscape {
+		width, height = height, width
+	}
+	if margins.Left+margins.Right >= width || margins.Top+margins.Bottom >= height {
+		return PDFPage{}, errors.New("the margins are larger than the page")
+	}
+	return page, nil
+}
+
+// NewPDFFile returns the path of an empty temporary file the webview prints the PDF to
+func NewPDFFile() (string, error) {
+	return newTempFile("wails-*.pdf")
+}
+
+// newTempFile creates an empty temporary file with the pattern of os.CreateTemp and returns its path
+func newTempFile(pattern string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	path := file.Name()
+	if err := file.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// ReadPDFFile returns the PDF that has been printed to the file of NewPDFFile and removes the file
+func ReadPDFFile(path string) ([]byte, error) {
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return nil, errors.New("the webview has not printed a PDF")
+	}
+	return data, nil
+}
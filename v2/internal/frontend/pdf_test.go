@@ -0,0 +1,85 @@
+package frontend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPDFOptionsPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PDFOptions
+		want    PDFPage
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			options: PDFOptions{},
+			want:    PDFPage{Width: 8.5, Height: 11, Margins: PDFMargins{Top: 0.4, Bottom: 0.4, Left: 0.4, Right: 0.4}, Scale: 1},
+		},
+		{
+			name:    "a4 landscape",
+			options: PDFOptions{PaperSize: "A4", Landscape: true, Margins: &PDFMargins{}, Scale: 0.5},
+			want:    PDFPage{Width: 8.27, Height: 11.69, Scale: 0.5},
+		},
+		{
+			name:    "custom size",
+			options: PDFOptions{PaperSize: "unknown", PageWidth: 4, PageHeight: 6, Margins: &PDFMargins{Top: 1}},
+			want:    PDFPage{Width: 4, Height: 6, Margins: PDFMargins{Top: 1}, Scale: 1},
+		},
+		{
+			name:    "unknown paper",
+			options: PDFOptions{PaperSize: "B5"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid scale",
+			options: PDFOptions{Scale: 3},
+			wantErr: true,
+		},
+		{
+			name:    "negative margin",
+			options: PDFOptions{Margins: &PDFMargins{Left: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "margins larger than the landscape page",
+			options: PDFOptions{PaperSize: "A5", Landscape: true, Margins: &PDFMargins{Top: 3, Bottom: 3}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.options.Page()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Page() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Page() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPDFFile(t *testing.T) {
+	path, err := NewPDFFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPDFFile(path); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the file has not been removed: %v", err)
+	}
+
+	path = filepath.Join(t.TempDir(), "print.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ReadPDFFile(path)
+	if err != nil || string(data) != "%PDF-1.4\n" {
+		t.Errorf("ReadPDFFile() = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,77 @@
+package frontend
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// performanceScript measures the frames with requestAnimationFrame and the long tasks with a PerformanceObserver
+// where they are supported, and reports them with the 'P' message every interval. The frames are only measured while
+// the page is visible and the frames within 100ms of a scroll event count as scroll frames.
+const performanceScript = `(function() {
+if (!window.requestAnimationFrame || !window.performance) { return; }
+var interval = %d, slowFrame = %d;
+var frames, scrollFrames, scrollSlowFrames, longTasks, longTaskTime, start;
+var last = 0, scrolled = -1000;
+var longTasksSupported = !!(window.PerformanceObserver && PerformanceObserver.supportedEntryTypes &&
+	PerformanceObserver.supportedEntryTypes.indexOf('longtask') >= 0);
+function reset() {
+	frames = []; scrollFrames = 0; scrollSlowFrames = 0; longTasks = 0; longTaskTime = 0; start = performance.now();
+}
+function percentile(sorted, fraction) {
+	return sorted.length ? sorted[Math.min(sorted.length - 1, Math.floor(sorted.length * fraction))] : 0;
+}
+function frame(time) {
+	if (last) {
+		var duration = time - last;
+		frames.push(duration);
+		if (time - scrolled < 100) {
+			scrollFrames++;
+			if (duration > slowFrame) { scrollSlowFrames++; }
+		}
+	}
+	last = time;
+	requestAnimationFrame(frame);
+}
+function report() {
+	if ((frames.length || longTasks) && window.WailsInvoke) {
+		var sorted = frames.slice().sort(function(a, b) { return a - b; });
+		window.WailsInvoke('P' + JSON.stringify({
+			url: location.href,
+			duration: performance.now() - start,
+			frames: frames.length,
+			slowFrames: frames.filter(function(duration) { return duration > slowFrame; }).length,
+			frameTimeP50: percentile(sorted, 0.5),
+			frameTimeP95: percentile(sorted, 0.95),
+			frameTimeMax: percentile(sorted, 1),
+			scrollFrames: scrollFrames,
+			scrollSlowFrames: scrollSlowFrames,
+			longTasks: longTasks,
+			longTaskTime: longTaskTime,
+			longTasksSupported: longTasksSupported
+		}));
+	}
+	reset();
+}
+reset();
+if (longTasksSupported) {
+	new PerformanceObserver(function(list) {
+		list.getEntries().forEach(function(entry) { longTasks++; longTaskTime += entry.duration; });
+	}).observe({entryTypes: ['longtask']});
+}
+document.addEventListener('scroll', function() { scrolled = performance.now(); }, {capture: true, passive: true});
+document.addEventListener('visibilitychange', function() { last = 0; });
+requestAnimationFrame(frame);
+setInterval(report, interval);
+window.addEventListener('pagehide', report);
+})();
+`
+
+// PerformanceScriptSource returns the script that reports the performance metrics of the options
+func PerformanceScriptSource(metrics *options.PerformanceMetrics) string {
+	if metrics == nil {
+		return ""
+	}
+	return fmt.Sprintf(performanceScript, metrics.Interval.Milliseconds(), metrics.SlowFrameThreshold.Milliseconds())
+}
@@ -0,0 +1,46 @@
+package frontend
+
+import "github.com/wailsapp/wails/v2/pkg/options"
+
+// PermissionPolicy decides the permission requests of the webview with the callback of the application
+type PermissionPolicy struct {
+	options *options.Permissions
+}
+
+// NewPermissionPolicy creates the policy of the application
+func NewPermissionPolicy(permissions *options.Permissions) *PermissionPolicy {
+	return &PermissionPolicy{options: permissions}
+}
+
+// Enabled reports if the permission requests are decided by the application
+func (p *PermissionPolicy) Enabled() bool {
+	return p.options != nil
+}
+
+// Decide returns the decision of a request of the page at the origin, PermissionDefault for the handling of the
+// platform. A request for several kinds is denied if one of them is denied and only allowed if all of them are.
+func (p *PermissionPolicy) Decide(origin string, kinds ...options.Permission) options.PermissionDecision {
+	if !p.Enabled() {
+		return options.PermissionDefault
+	}
+	if len(kinds) == 0 {
+		return p.options.Default
+	}
+	result := options.PermissionAllow
+	for _, kind := range kinds {
+		decision := options.PermissionDefault
+		if p.options.OnPermissionRequest != nil {
+			decision = p.options.OnPermissionRequest(origin, kind)
+		}
+		if decision == options.PermissionDefault {
+			decision = p.options.Default
+		}
+		switch decision {
+		case options.PermissionDeny:
+			return options.PermissionDeny
+		case options.PermissionDefault:
+			result = options.PermissionDefault
+		}
+	}
+	return result
+}
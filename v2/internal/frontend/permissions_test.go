@@ -0,0 +1,58 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestPermissionPolicy(t *testing.T) {
+	disabled := NewPermissionPolicy(nil)
+	if disabled.Enabled() || disabled.Decide("https://example.com", options.PermissionCamera) != options.PermissionDefault {
+		t.Error("expected the handling of the platform without options")
+	}
+
+	var requests []options.Permission
+	policy := NewPermissionPolicy(&options.Permissions{
+		OnPermissionRequest: func(origin string, kind options.Permission) options.PermissionDecision {
+			requests = append(requests, kind)
+			switch {
+			case origin != "https://meet.example.com":
+				return options.PermissionDefault
+			case kind == options.PermissionGeolocation:
+				return options.PermissionDeny
+			}
+			return options.PermissionAllow
+		},
+		Default: options.PermissionDeny,
+	})
+	tests := []struct {
+		origin string
+		kinds  []options.Permission
+		want   options.PermissionDecision
+	}{
+		{"https://meet.example.com", []options.Permission{options.PermissionCamera, options.PermissionMicrophone}, options.PermissionAllow},
+		{"https://meet.example.com", []options.Permission{options.PermissionGeolocation}, options.PermissionDeny},
+		{"https://ads.example.com", []options.Permission{options.PermissionNotifications}, options.PermissionDeny},
+	}
+	for _, tt := range tests {
+		if got := policy.Decide(tt.origin, tt.kinds...); got != tt.want {
+			t.Errorf("Decide(%s, %v) = %v, want %v", tt.origin, tt.kinds, got, tt.want)
+		}
+	}
+	if len(requests) != 4 {
+		t.Errorf("expected every kind to be requested, got %v", requests)
+	}
+
+	prompt := NewPermissionPolicy(&options.Permissions{
+		OnPermissionRequest: func(origin string, kind options.Permission) options.PermissionDecision {
+			if kind == options.PermissionCamera {
+				return options.PermissionAllow
+			}
+			return options.PermissionDefault
+		},
+	})
+	if got := prompt.Decide("https://example.com", options.PermissionCamera, options.PermissionMicrophone); got != options.PermissionDefault {
+		t.Errorf("expected the handling of the platform when a kind is left to it, got %v", got)
+	}
+}
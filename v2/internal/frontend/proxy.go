@@ -0,0 +1,82 @@
+package frontend
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// loopbackHosts are connected to directly, so the dev server and local services aren't proxied
+var loopbackHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// ProxyServer is the parsed proxy of the application options
+type ProxyServer struct {
+	// Scheme is http, https or socks5
+	Scheme   string
+	Host     string
+	Port     int
+	Bypass   []string
+	Username string
+	Password string
+}
+
+// ParseProxy parses the proxy of the options, it returns nil without a proxy
+func ParseProxy(proxy *options.Proxy) (*ProxyServer, error) {
+	if proxy == nil {
+		return nil, nil
+	}
+	parsed, err := url.Parse(proxy.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", proxy.URL, err)
+	}
+	result := &ProxyServer{
+		Scheme:   strings.ToLower(parsed.Scheme),
+		Host:     parsed.Hostname(),
+		Username: proxy.Username,
+		Password: proxy.Password,
+	}
+	if result.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: the URL has no host", proxy.URL)
+	}
+	defaultPort := map[string]int{"http": 80, "https": 443, "socks5": 1080}[result.Scheme]
+	if defaultPort == 0 {
+		return nil, fmt.Errorf("invalid proxy %q: the scheme has to be http, https or socks5", proxy.URL)
+	}
+	result.Port = defaultPort
+	if port := parsed.Port(); port != "" {
+		result.Port, err = strconv.Atoi(port)
+		if err != nil || result.Port <= 0 || result.Port > 65535 {
+			return nil, fmt.Errorf("invalid proxy %q: invalid port", proxy.URL)
+		}
+	}
+	if parsed.User != nil && result.Username == "" {
+		result.Username = parsed.User.Username()
+		result.Password, _ = parsed.User.Password()
+	}
+	for _, host := range proxy.Bypass {
+		if host = strings.TrimSpace(host); host != "" {
+			result.Bypass = append(result.Bypass, host)
+		}
+	}
+	return result, nil
+}
+
+// URL returns the URL of the proxy without the credentials
+func (p *ProxyServer) URL() string {
+	return p.Scheme + "://" + net.JoinHostPort(p.Host, strconv.Itoa(p.Port))
+}
+
+// BypassWithLoopback returns the bypassed hosts and the loopback addresses, for the webviews that don't connect to
+// them directly by themselves
+func (p *ProxyServer) BypassWithLoopback() []string {
+	return append(append([]string{}, p.Bypass...), loopbackHosts...)
+}
+
+// HasCredentials reports if the challenges of the proxy are answered with stored credentials
+func (p *ProxyServer) HasCredentials() bool {
+	return p != nil && p.Username != ""
+}
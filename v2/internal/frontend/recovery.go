@@ -0,0 +1,214 @@
+package frontend
+
+import (
+	"context"
+	"net/url"
+	goruntime "runtime"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// WebviewCrashedEvent is the event that is emitted after the webview content has been recycled
+const WebviewCrashedEvent = "wails:webview:crashed"
+
+// watchdogInterval is how often the watchdog pings the page
+var watchdogInterval = time.Second
+
+// stableContentDuration is how long loaded content has to keep running before its crash resets the recoveries
+var stableContentDuration = time.Minute
+
+// maxReloadBackoff limits the doubling of the ReloadBackoff
+const maxReloadBackoff = time.Minute
+
+// ContentRecovery keeps track of recycled webview content and decides which page should be loaded to recover
+type ContentRecovery struct {
+	options    *options.WebviewRecovery
+	recoveries int
+	// recovering is true while the content is recovered, crashes that are reported meanwhile are ignored
+	recovering bool
+	// fallback is true while the fallback page is shown
+	fallback bool
+	lastPong time.Time
+	// navigated is when the content has been loaded successfully the last time
+	navigated time.Time
+	watchdog  sync.Once
+	lock      sync.Mutex
+	sleep     func(time.Duration)
+	goos      string
+}
+
+func NewContentRecovery(recoveryOptions *options.WebviewRecovery) *ContentRecovery {
+	return &ContentRecovery{
+		options: recoveryOptions,
+		sleep:   time.Sleep,
+		goos:    goruntime.GOOS,
+	}
+}
+
+// MemoryLimitMB returns the memory limit of the page, 0 if none has been configured
+func (r *ContentRecovery) MemoryLimitMB() int {
+	if r.options == nil {
+		return 0
+	}
+	return r.options.MemoryLimitMB
+}
+
+// Recover notifies the application about the recycled content and loads the URL that recovers it with load. The
+// content is reloaded with the startURL after the backoff or, with the error panel policy, after the user has chosen
+// in the panel shown with dialog. Once the maximum number of automatic recoveries has been reached, a data URL of
+// the fallback page is loaded instead of the startURL.
+func (r *ContentRecovery) Recover(ctx context.Context, reason options.WebviewCrashReason, startURL string, dialog func(MessageDialogOptions) (string, error), load func(target string)) {
+	if r.options == nil || reason == options.WebviewCrashReasonRecycled {
+		r.emit(ctx, options.WebviewCrash{Reason: reason})
+		if r.options != nil && r.options.OnContentCrashed != nil {
+			go r.options.OnContentCrashed(ctx, reason)
+		}
+		load(startURL)
+		return
+	}
+
+	r.lock.Lock()
+	if r.recovering {
+		r.lock.Unlock()
+		return
+	}
+	r.recovering = true
+	if !r.navigated.IsZero() && time.Since(r.navigated) >= stableContentDuration {
+		r.recoveries = 0
+	}
+	r.navigated = time.Time{}
+	r.recoveries++
+	crash := options.WebviewCrash{
+		Reason:     reason,
+		Recoveries: r.recoveries,
+		Fallback:   r.recoveries > r.options.MaxRecoveries,
+	}
+	r.lock.Unlock()
+
+	r.emit(ctx, crash)
+	if r.options.OnContentCrashed != nil {
+		go r.options.OnContentCrashed(ctx, reason)
+	}
+
+	target := startURL
+	switch {
+	case crash.Fallback:
+		target = "data:text/html;charset=utf-8," + url.PathEscape(r.options.FallbackHTML)
+	case r.options.Policy == options.WebviewRecoveryErrorPanel && dialog != nil:
+		r.showErrorPanel(ctx, crash, dialog)
+	case r.options.ReloadBackoff > 0:
+		r.sleep(r.reloadBackoff(crash.Recoveries))
+	}
+	load(target)
+
+	r.lock.Lock()
+	r.recovering = false
+	r.fallback = crash.Fallback
+	r.lastPong = time.Now()
+	r.lock.Unlock()
+}
+
+// reloadBackoff returns the ReloadBackoff doubled for every further recovery, up to the maxReloadBackoff. A larger
+// ReloadBackoff is used as it is.
+func (r *ContentRecovery) reloadBackoff(recoveries int) time.Duration {
+	backoff := r.options.ReloadBackoff
+	for i := 1; i < recoveries && backoff < maxReloadBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxReloadBackoff && r.options.ReloadBackoff <= maxReloadBackoff {
+		backoff = maxReloadBackoff
+	}
+	return backoff
+}
+
+func (r *ContentRecovery) emit(ctx context.Context, crash options.WebviewCrash) {
+	if events, _ := ctx.Value("events").(Events); events != nil {
+		events.Emit(WebviewCrashedEvent, string(crash.Reason), crash)
+	}
+}
+
+// showErrorPanel waits until the user has chosen to reload or to report the crash, the content is reloaded after the
+// report. Any other answer, EG: closing the panel, reloads the content.
+func (r *ContentRecovery) showErrorPanel(ctx context.Context, crash options.WebviewCrash, dialog func(MessageDialogOptions) (string, error)) {
+	panel := r.options.ErrorPanel
+	buttons := []string{panel.ReloadButton}
+	dialogType := ErrorDialog
+	if r.options.OnReport != nil {
+		buttons = append(buttons, panel.ReportButton)
+		dialogType = QuestionDialog
+	}
+	result, err := dialog(MessageDialogOptions{
+		Type:          dialogType,
+		Title:         panel.Title,
+		Message:       panel.Message,
+		Buttons:       buttons,
+		DefaultButton: panel.ReloadButton,
+		CancelButton:  panel.ReloadButton,
+	})
+	if err == nil && r.options.OnReport != nil && errorPanelReports(r.goos, panel)[result] {
+		r.options.OnReport(ctx, crash)
+	}
+}
+
+// errorPanelReports maps the answers of the error panel to whether the crash is reported. The panel answers with the
+// label of the button, but Windows shows Yes for the Reload and No for the Report button.
+func errorPanelReports(goos string, panel options.WebviewErrorPanel) map[string]bool {
+	if goos == "windows" {
+		return map[string]bool{"Yes": false, "No": true}
+	}
+	return map[string]bool{panel.ReloadButton: false, panel.ReportButton: true}
+}
+
+// Watchdog pings the page every second with ping and calls unresponsive when it hasn't answered with Pong for the
+// UnresponsiveTimeout, for the webviews that don't detect unresponsive pages. It is started once and stops with ctx.
+func (r *ContentRecovery) Watchdog(ctx context.Context, ping func(), unresponsive func()) {
+	if r.options == nil {
+		return
+	}
+	r.watchdog.Do(func() {
+		r.Pong()
+		go func() {
+			ticker := time.NewTicker(watchdogInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				r.lock.Lock()
+				paused := r.recovering || r.fallback
+				elapsed := time.Since(r.lastPong)
+				r.lock.Unlock()
+				if paused {
+					continue
+				}
+				if elapsed > r.options.UnresponsiveTimeout {
+					unresponsive()
+					continue
+				}
+				ping()
+			}
+		}()
+	})
+}
+
+// Navigated records that the content has been loaded successfully. The recoveries are reset by the next crash once
+// the content has kept running for the stableContentDuration, crashes that happen long after each other don't add up
+// to the MaxRecoveries. The fallback page doesn't count as loaded content.
+func (r *ContentRecovery) Navigated() {
+	r.lock.Lock()
+	if !r.fallback {
+		r.navigated = time.Now()
+	}
+	r.lock.Unlock()
+}
+
+// Pong records an answer of the page to a ping of the watchdog
+func (r *ContentRecovery) Pong() {
+	r.lock.Lock()
+	r.lastPong = time.Now()
+	r.lock.Unlock()
+}
@@ -0,0 +1,165 @@
+package frontend
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func newTestRecovery(recovery *options.WebviewRecovery) *ContentRecovery {
+	appoptions := &options.App{WebviewRecovery: recovery}
+	options.MergeDefaults(appoptions)
+	return NewContentRecovery(appoptions.WebviewRecovery)
+}
+
+func TestRecoverReloadBackoff(t *testing.T) {
+	r := newTestRecovery(&options.WebviewRecovery{MaxRecoveries: 2, ReloadBackoff: time.Second})
+	var delays []time.Duration
+	r.sleep = func(delay time.Duration) {
+		delays = append(delays, delay)
+	}
+	var targets []string
+	load := func(target string) {
+		targets = append(targets, target)
+	}
+	for _, reason := range []options.WebviewCrashReason{options.WebviewCrashReasonCrashed, options.WebviewCrashReasonUnresponsive, options.WebviewCrashReasonCrashed} {
+		r.Recover(context.Background(), reason, "wails://wails/", nil, load)
+	}
+	r.Recover(context.Background(), options.WebviewCrashReasonRecycled, "wails://wails/", nil, load)
+
+	if want := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(delays, want) {
+		t.Errorf("delays = %v, want %v", delays, want)
+	}
+	if len(targets) != 4 || targets[0] != "wails://wails/" || targets[1] != "wails://wails/" || !strings.HasPrefix(targets[2], "data:text/html") || targets[3] != "wails://wails/" {
+		t.Errorf("unexpected targets %v", targets)
+	}
+}
+
+func TestRecoverReloadBackoffLimit(t *testing.T) {
+	r := newTestRecovery(&options.WebviewRecovery{MaxRecoveries: 100, ReloadBackoff: time.Second})
+	var delays []time.Duration
+	r.sleep = func(delay time.Duration) {
+		delays = append(delays, delay)
+	}
+	for i := 0; i < 70; i++ {
+		r.Recover(context.Background(), options.WebviewCrashReasonCrashed, "wails://wails/", nil, func(string) {})
+	}
+	if delays[5] != 32*time.Second || delays[6] != maxReloadBackoff || delays[69] != maxReloadBackoff {
+		t.Errorf("expected the backoff to be limited to %v, got %v", maxReloadBackoff, delays)
+	}
+
+	r = newTestRecovery(&options.WebviewRecovery{ReloadBackoff: 2 * time.Minute})
+	if backoff := r.reloadBackoff(3); backoff != 2*time.Minute {
+		t.Errorf("expected a larger backoff to be kept, got %v", backoff)
+	}
+}
+
+func TestRecoverNavigated(t *testing.T) {
+	r := newTestRecovery(&options.WebviewRecovery{MaxRecoveries: 1})
+	var targets []string
+	load := func(target string) {
+		targets = append(targets, target)
+	}
+	r.Recover(context.Background(), options.WebviewCrashReasonCrashed, "wails://wails/", nil, load)
+	r.Navigated()
+	r.navigated = r.navigated.Add(-stableContentDuration)
+	r.Recover(context.Background(), options.WebviewCrashReasonCrashed, "wails://wails/", nil, load)
+	if len(targets) != 2 || targets[1] != "wails://wails/" {
+		t.Fatalf("expected the recoveries to be reset after the content kept running, got %v", targets)
+	}
+
+	r.Navigated()
+	r.Recover(context.Background(), options.WebviewCrashReasonCrashed, "wails://wails/", nil, load)
+	if len(targets) != 3 || !strings.HasPrefix(targets[2], "data:text/html") {
+		t.Errorf("expected a crash right after the load to show the fallback page, got %v", targets)
+	}
+}
+
+func TestErrorPanelReports(t *testing.T) {
+	panel := options.WebviewErrorPanel{ReloadButton: "Reload", ReportButton: "Report"}
+	for _, tt := range []struct {
+		goos   string
+		answer string
+		report bool
+	}{
+		{"darwin", "Report", true},
+		{"darwin", "Reload", false},
+		{"darwin", "No", false},
+		{"windows", "No", true},
+		{"windows", "Yes", false},
+		{"windows", "Report", false},
+		{"windows", "Error", false},
+	} {
+		if report := errorPanelReports(tt.goos, panel)[tt.answer]; report != tt.report {
+			t.Errorf("%s: answer %q reports %v, want %v", tt.goos, tt.answer, report, tt.report)
+		}
+	}
+}
+
+func TestRecoverErrorPanel(t *testing.T) {
+	var reports []options.WebviewCrash
+	r := newTestRecovery(&options.WebviewRecovery{
+		Policy: options.WebviewRecoveryErrorPanel,
+		OnReport: func(ctx context.Context, crash options.WebviewCrash) {
+			reports = append(reports, crash)
+		},
+	})
+	r.goos = "linux"
+	var shown []MessageDialogOptions
+	answer := "Report"
+	dialog := func(dialogOptions MessageDialogOptions) (string, error) {
+		shown = append(shown, dialogOptions)
+		return answer, nil
+	}
+	loads := 0
+	load := func(string) {
+		loads++
+	}
+	r.Recover(context.Background(), options.WebviewCrashReasonUnresponsive, "wails://wails/", dialog, load)
+	answer = "Reload"
+	r.Recover(context.Background(), options.WebviewCrashReasonCrashed, "wails://wails/", dialog, load)
+
+	if len(shown) != 2 || !reflect.DeepEqual(shown[0].Buttons, []string{"Reload", "Report"}) || shown[0].DefaultButton != "Reload" {
+		t.Errorf("unexpected panels %+v", shown)
+	}
+	if want := []options.WebviewCrash{{Reason: options.WebviewCrashReasonUnresponsive, Recoveries: 1}}; !reflect.DeepEqual(reports, want) {
+		t.Errorf("reports = %+v, want %+v", reports, want)
+	}
+	if loads != 2 {
+		t.Errorf("content loaded %d times, want 2", loads)
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	watchdogInterval = time.Millisecond
+	defer func() { watchdogInterval = time.Second }()
+	r := newTestRecovery(&options.WebviewRecovery{UnresponsiveTimeout: 50 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	answer := make(chan bool, 1)
+	answer <- true
+	unresponsive := make(chan struct{}, 1)
+	r.Watchdog(ctx, func() {
+		select {
+		case <-answer:
+			r.Pong()
+		default:
+		}
+	}, func() {
+		select {
+		case unresponsive <- struct{}{}:
+		default:
+		}
+	})
+
+	select {
+	case <-unresponsive:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the unresponsive page has not been reported")
+	}
+}
@@ -0,0 +1,35 @@
+/*
+ _	   __	  _ __
+| |	 / /___ _(_) /____
+| | /| / / __ `/ / / ___/
+| |/ |/ / /_/ / / (__  )
+|__/|__/\__,_/_/_/____/
+The electron alternative for Go
+(c) Lea Anthony 2019-present
+*/
+
+/* jshint esversion: 9 */
+
+import {EventsOn} from './events';
+
+/**
+ * Registers a callback for the changes of the downloads of the webview.
+ * This requires the Downloads application option.
+ *
+ * @export
+ * @param {function({id: string, url: string, path: string, state: string, receivedBytes: number, totalBytes: number, error?: string})} callback
+ * @return {function} A function to unregister the callback
+ */
+export function OnDownload(callback) {
+    return EventsOn("wails:download", callback);
+}
+
+/**
+ * Cancels the download with the id in flight.
+ *
+ * @export
+ * @param {string} id
+ */
+export function DownloadCancel(id) {
+    window.WailsInvoke('GC' + id);
+}
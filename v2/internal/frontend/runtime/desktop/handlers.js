@@ -0,0 +1,63 @@
+/*
+ _	   __	  _ __
+| |	 / /___ _(_) /____
+| | /| / / __ `/ / / ___/
+| |/ |/ / /_/ / / (__  )
+|__/|__/\__,_/_/_/____/
+The electron alternative for Go
+(c) Lea Anthony 2019-present
+*/
+
+/* jshint esversion: 9 */
+
+import {EventsEmit} from "./events";
+
+const handlers = {};
+
+/**
+ * Registers a handler that Go can call with HandlerCall. The handler receives the arguments of the call and its
+ * return value, or the value of the returned promise, is the result of the call.
+ *
+ * @export
+ * @param {string} name
+ * @param {function} handler
+ * @returns {function} A function to unregister the handler
+ */
+export function HandlerRegister(name, handler) {
+    handlers[name] = handler;
+    return () => {
+        if (handlers[name] === handler) {
+            delete handlers[name];
+        }
+    };
+}
+
+/**
+ * Unregisters the handler with the given name
+ *
+ * @export
+ * @param {string} name
+ */
+export function HandlerUnregister(name) {
+    delete handlers[name];
+}
+
+/**
+ * Called by Go to run a handler, the result is reported with an event
+ *
+ * @export
+ * @param {string} id
+ * @param {string} name
+ * @param {any[]} args
+ */
+export function CallHandler(id, name, args) {
+    const eventName = "wails:handler:" + id;
+    const handler = handlers[name];
+    if (!handler) {
+        EventsEmit(eventName, {notFound: true});
+        return;
+    }
+    new Promise((resolve) => resolve(handler(...args)))
+        .then((result) => EventsEmit(eventName, {result: result === undefined ? null : result}))
+        .catch((error) => EventsEmit(eventName, {error: error instanceof Error ? error.message : String(error)}));
+}
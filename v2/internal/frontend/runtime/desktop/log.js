@@ -111,3 +111,47 @@ export const LogLevel = {
 	WARNING: 4,
 	ERROR: 5,
 };
+
+// pageId identifies the page load in the structured logs, as the logs of a reloaded page use a new one
+const pageId = Math.random().toString(36).slice(2, 10);
+
+/**
+ * Sends a structured log message to the backend with the given level, message and fields
+ *
+ * @param {string} level
+ * @param {string} message
+ * @param {Object} fields
+ */
+function sendStructuredLogMessage(level, message, fields) {
+	const merged = {page: window.location.pathname, pageId: pageId};
+	for (const [key, value] of Object.entries(fields || {})) {
+		merged[key] = value instanceof Error ? value.toString() : value;
+	}
+	sendLogMessage('J', JSON.stringify({level: level, message: String(message), fields: merged}));
+}
+
+/**
+ * Creates a structured logger that adds the given fields to its logs
+ *
+ * @param {Object} base
+ * @returns {Object}
+ */
+function newLog(base) {
+	const log = (level) => (message, fields) => sendStructuredLogMessage(level, message, Object.assign({}, base, fields));
+	return {
+		Trace: log('trace'),
+		Debug: log('debug'),
+		Info: log('info'),
+		Warn: log('warning'),
+		Error: log('error'),
+		With: (fields) => newLog(Object.assign({}, base, fields)),
+		WithCorrelationID: (id) => newLog(Object.assign({}, base, {correlationId: id})),
+	};
+}
+
+/**
+ * Structured logging with the backend, the fields are kept by structured loggers like logger.NewSlogLogger
+ *
+ * @export
+ */
+export const Log = newLog({});
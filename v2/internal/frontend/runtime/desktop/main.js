@@ -18,6 +18,11 @@ import * as Browser from "./browser";
 import * as Clipboard from "./clipboard";
 import * as DragAndDrop from "./draganddrop";
 import * as ContextMenu from "./contextmenu";
+import * as State from "./state";
+import {Share} from "./share";
+import * as Downloads from "./downloads";
+import {Paginate} from "./pagination";
+import {CallHandler, HandlerRegister, HandlerUnregister} from "./handlers";
 
 export function Quit() {
     window.WailsInvoke('Q');
@@ -35,6 +40,10 @@ export function Environment() {
     return Call(":wails:Environment");
 }
 
+export function Handshake() {
+    return Call(":wails:Handshake");
+}
+
 // The JS runtime
 window.runtime = {
     ...Log,
@@ -43,12 +52,18 @@ window.runtime = {
     ...Screen,
     ...Clipboard,
     ...DragAndDrop,
+    ...Downloads,
+    State,
+    Share,
     EventsOn,
     EventsOnce,
     EventsOnMultiple,
     EventsEmit,
     EventsOff,
+    HandlerRegister,
+    HandlerUnregister,
     Environment,
+    Handshake,
     Show,
     Hide,
     Quit
@@ -57,8 +72,10 @@ window.runtime = {
 // Internal wails endpoints
 window.wails = {
     Callback,
+    CallHandler,
     EventsNotify,
     SetBindings,
+    Paginate,
     eventListeners,
     callbacks,
     flags: {
@@ -74,6 +91,7 @@ window.wails = {
         cssDropProperty: "--wails-drop-target",
         cssDropValue: "drop",
         enableWailsDragAndDrop: false,
+        enableZoomShortcuts: false,
     }
 };
 
@@ -121,6 +139,19 @@ window.wails.setCSSDropProperties = function (property, value) {
     window.wails.flags.cssDropValue = value;
 }
 
+window.wails.setMemoryLimit = function (limitMB) {
+    if (!window.performance || !window.performance.memory || limitMB <= 0) {
+        return;
+    }
+    const limit = limitMB * 1024 * 1024;
+    const timer = setInterval(() => {
+        if (window.performance.memory.usedJSHeapSize > limit) {
+            clearInterval(timer);
+            window.WailsInvoke("wails:memorylimit");
+        }
+    }, 5000);
+}
+
 window.addEventListener('mousedown', (e) => {
     // Check for resizing
     if (window.wails.flags.resizeEdge) {
@@ -194,6 +225,28 @@ window.addEventListener('mousemove', function (e) {
 
 });
 
+// Setup the zoom shortcuts
+window.addEventListener('keydown', function (e) {
+    if (!window.wails.flags.enableZoomShortcuts || !(e.ctrlKey || e.metaKey) || e.altKey) {
+        return;
+    }
+    switch (e.key) {
+        case '=':
+        case '+':
+            window.WailsInvoke('Wo+');
+            break;
+        case '-':
+            window.WailsInvoke('Wo-');
+            break;
+        case '0':
+            window.WailsInvoke('Wo:1');
+            break;
+        default:
+            return;
+    }
+    e.preventDefault();
+});
+
 // Setup context menu hook
 window.addEventListener('contextmenu', function (e) {
     // always show the contextmenu in debug & dev
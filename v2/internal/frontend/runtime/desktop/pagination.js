@@ -0,0 +1,66 @@
+/*
+ _	   __	  _ __
+| |	 / /___ _(_) /____
+| | /| / / __ `/ / / ___/
+| |/ |/ / /_/ / / (__  )
+|__/|__/\__,_/_/_/____/
+The electron alternative for Go
+(c) Lea Anthony 2019-present
+*/
+
+/* jshint esversion: 9 */
+
+function newPage(fetch, data) {
+    const page = {
+        items: data.items || [],
+        nextCursor: data.nextCursor || "",
+        total: data.total,
+        hasNext: !!data.nextCursor,
+
+        // next fetches the following page
+        next() {
+            if (!page.hasNext) {
+                return Promise.reject(new Error("this is the last page"));
+            }
+            return Paginate(fetch, page.nextCursor);
+        },
+
+        // all fetches the remaining pages and returns the items of this and the following pages
+        async all() {
+            const items = [...page.items];
+            let current = page;
+            while (current.hasNext) {
+                current = await current.next();
+                items.push(...current.items);
+            }
+            return items;
+        },
+
+        // Iterating the page with for await iterates the items of this and the following pages, which are fetched
+        // when they are reached
+        async* [Symbol.asyncIterator]() {
+            let current = page;
+            while (true) {
+                yield* current.items;
+                if (!current.hasNext) {
+                    return;
+                }
+                current = await current.next();
+            }
+        },
+    };
+    return page;
+}
+
+/**
+ * Paginate fetches the page at the cursor of a bound method that returns a pagination.Page. It is called by the
+ * generated bindings.
+ *
+ * @export
+ * @param {function(string): Promise<any>} fetch Calls the bound method with the cursor
+ * @param {string} [cursor] The cursor of the page, the first page if empty
+ * @return {Promise<any>} The page
+ */
+export function Paginate(fetch, cursor) {
+    return fetch(cursor || "").then((data) => newPage(fetch, data || {}));
+}
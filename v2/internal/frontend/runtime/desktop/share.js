@@ -0,0 +1,36 @@
+/*
+ _	   __	  _ __
+| |	 / /___ _(_) /____
+| | /| / / __ `/ / / ___/
+| |/ |/ / /_/ / / (__  )
+|__/|__/\__,_/_/_/____/
+The electron alternative for Go
+(c) Lea Anthony 2019-present
+*/
+
+/* jshint esversion: 9 */
+
+import {Call} from "./calls";
+
+/**
+ * Shows the share sheet of the platform with the text, the URL and the files of the options.
+ * The share sheet is positioned relative to the anchor element on macOS.
+ *
+ * @export
+ * @param {{title?: string, text?: string, url?: string, files?: string[], anchor?: Element}} options
+ * @return {Promise<void>}
+ */
+export function Share(options) {
+    const {anchor, ...rest} = options || {};
+    let rect = null;
+    if (anchor && anchor.getBoundingClientRect) {
+        const bounds = anchor.getBoundingClientRect();
+        rect = {
+            x: Math.round(bounds.left),
+            y: Math.round(bounds.top),
+            width: Math.round(bounds.width),
+            height: Math.round(bounds.height),
+        };
+    }
+    return Call(":wails:Share", [{...rest, anchor: rect}]);
+}
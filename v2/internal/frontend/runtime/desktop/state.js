@@ -0,0 +1,199 @@
+/*
+ _	   __	  _ __
+| |	 / /___ _(_) /____
+| | /| / / __ `/ / / ___/
+| |/ |/ / /_/ / / (__  )
+|__/|__/\__,_/_/_/____/
+The electron alternative for Go
+(c) Lea Anthony 2019-present
+*/
+
+/* jshint esversion: 9 */
+
+import {Call} from "./calls";
+
+// The snapshot is kept by the application, so it survives hot reloads, WindowReload and the recovery of crashed
+// webview content. It is taken before the page unloads and periodically, as crashed pages don't unload.
+const snapshotInterval = 2000;
+// The elements of the previous page are restored when they are rendered within this time
+const restoreTimeout = 10000;
+const preserveAttribute = "data-wails-preserve";
+
+const preserved = new Map();
+let restored = null;
+let lastSnapshot = null;
+let cleared = false;
+
+function currentURL() {
+    return window.location.pathname + window.location.search + window.location.hash;
+}
+
+function elementKey(element) {
+    return element.getAttribute(preserveAttribute) || element.id;
+}
+
+function snapshot() {
+    const state = {
+        url: currentURL(),
+        scroll: [window.scrollX, window.scrollY],
+        elements: {},
+        values: {},
+    };
+    document.querySelectorAll(`[${preserveAttribute}]`).forEach((element) => {
+        const key = elementKey(element);
+        if (!key) {
+            return;
+        }
+        const entry = {scroll: [element.scrollLeft, element.scrollTop]};
+        if (element.type === "checkbox" || element.type === "radio") {
+            entry.checked = element.checked;
+        } else if ("value" in element && element.type !== "password" && element.type !== "file") {
+            entry.value = element.value;
+        }
+        state.elements[key] = entry;
+    });
+    // Values that haven't been claimed by a Preserve call of this page are kept for later pages
+    if (restored && restored.values) {
+        Object.assign(state.values, restored.values);
+    }
+    preserved.forEach((entry, key) => {
+        try {
+            state.values[key] = entry.getter();
+        } catch (e) {
+            console.error(`State.Preserve: the getter of '${key}' failed:`, e);
+        }
+    });
+    return state;
+}
+
+function save() {
+    // The snapshot of the previous page must not be overwritten before it has been restored
+    if (restored === null || cleared) {
+        return;
+    }
+    const state = snapshot();
+    const json = JSON.stringify(state);
+    if (json === lastSnapshot) {
+        return;
+    }
+    lastSnapshot = json;
+    Call(":wails:StateSave", [state]).catch((e) => console.error("State: saving the snapshot failed:", e));
+}
+
+function restoreElement(element, entry) {
+    if (entry.checked !== undefined) {
+        element.checked = entry.checked;
+    } else if (entry.value !== undefined && element.value !== entry.value) {
+        element.value = entry.value;
+        // Frameworks with bound inputs only notice the value with an input event
+        element.dispatchEvent(new Event("input", {bubbles: true}));
+    }
+    if (entry.scroll) {
+        element.scrollLeft = entry.scroll[0];
+        element.scrollTop = entry.scroll[1];
+    }
+}
+
+// restorePage restores the scroll position and the declared elements of the previous page as they are rendered
+function restorePage(state) {
+    if (state.url !== currentURL()) {
+        return;
+    }
+    const pendingElements = new Map(Object.entries(state.elements || {}));
+    let pendingScroll = state.scroll && (state.scroll[0] !== 0 || state.scroll[1] !== 0) ? state.scroll : null;
+
+    const restorePending = () => {
+        pendingElements.forEach((entry, key) => {
+            const element = document.querySelector(`[${preserveAttribute}="${CSS.escape(key)}"]`) || document.getElementById(key);
+            if (element && element.hasAttribute(preserveAttribute)) {
+                restoreElement(element, entry);
+                pendingElements.delete(key);
+            }
+        });
+        if (pendingScroll) {
+            window.scrollTo(pendingScroll[0], pendingScroll[1]);
+            // The page might not be long enough yet
+            if (Math.abs(window.scrollY - pendingScroll[1]) < 1 && Math.abs(window.scrollX - pendingScroll[0]) < 1) {
+                pendingScroll = null;
+            }
+        }
+        return pendingElements.size === 0 && pendingScroll === null;
+    };
+
+    const start = () => {
+        if (restorePending()) {
+            return;
+        }
+        const observer = new MutationObserver(() => {
+            if (restorePending()) {
+                observer.disconnect();
+            }
+        });
+        observer.observe(document.documentElement, {childList: true, subtree: true});
+        setTimeout(() => observer.disconnect(), restoreTimeout);
+    };
+    if (document.readyState === "loading") {
+        document.addEventListener("DOMContentLoaded", start, {once: true});
+    } else {
+        start();
+    }
+}
+
+function restoreValue(key) {
+    if (!restored || !restored.values || !(key in restored.values)) {
+        return;
+    }
+    const value = restored.values[key];
+    delete restored.values[key];
+    try {
+        preserved.get(key).setter(value);
+    } catch (e) {
+        console.error(`State.Preserve: the setter of '${key}' failed:`, e);
+    }
+}
+
+/**
+ * Preserve keeps application state across reloads of the page. The getter is called before the page reloads and
+ * the value is passed to the setter after the reload, once Preserve has been called again with the same key.
+ * The value has to be serialisable to JSON.
+ *
+ * @export
+ * @param {string} key
+ * @param {function(): any} getter
+ * @param {function(any): void} setter
+ * @return {function(): void} A function that stops preserving the state
+ */
+export function Preserve(key, getter, setter) {
+    preserved.set(key, {getter, setter});
+    restoreValue(key);
+    return () => {
+        preserved.delete(key);
+    };
+}
+
+/**
+ * Clear discards the snapshot and stops taking snapshots of this page, EG: when the user logs out. Nothing is
+ * restored after the next reload.
+ *
+ * @export
+ */
+export function Clear() {
+    cleared = true;
+    preserved.clear();
+    restored = {};
+    return Call(":wails:StateSave", [null]);
+}
+
+Call(":wails:StateRestore").then((state) => {
+    restored = state || {};
+    if (state) {
+        restorePage(state);
+        preserved.forEach((_, key) => restoreValue(key));
+    }
+}).catch(() => {
+    restored = {};
+});
+
+setInterval(save, snapshotInterval);
+window.addEventListener("pagehide", save);
+window.addEventListener("beforeunload", save);
@@ -12,6 +12,10 @@ The electron alternative for Go
 
 
 import {Call} from "./calls";
+import {EventsEmit, EventsOn} from "./events";
+
+// ColorSchemeEvent is emitted with "dark" or "light" when the color scheme of the window changes
+const ColorSchemeEvent = "wails:color-scheme";
 
 export function WindowReload() {
     window.location.reload();
@@ -21,6 +25,10 @@ export function WindowReloadApp() {
     window.WailsInvoke('WR');
 }
 
+export function WindowRecycle() {
+    window.WailsInvoke('WY');
+}
+
 export function WindowSetSystemDefaultTheme() {
     window.WailsInvoke('WASDT');
 }
@@ -33,6 +41,35 @@ export function WindowSetDarkTheme() {
     window.WailsInvoke('WADT');
 }
 
+const darkColorScheme = window.matchMedia ? window.matchMedia("(prefers-color-scheme: dark)") : null;
+
+/**
+ * Returns the color scheme of the window, which prefers-color-scheme reflects. It follows the system unless a theme
+ * has been forced with WindowSetLightTheme or WindowSetDarkTheme.
+ *
+ * @export
+ * @return {string} "dark" or "light"
+ */
+export function WindowColorScheme() {
+    return darkColorScheme && darkColorScheme.matches ? "dark" : "light";
+}
+
+/**
+ * Registers a callback for changes of the color scheme of the window
+ *
+ * @export
+ * @param {function(string): void} callback Called with "dark" or "light"
+ * @return {function} A function to cancel the listener
+ */
+export function OnColorSchemeChange(callback) {
+    return EventsOn(ColorSchemeEvent, callback);
+}
+
+if (darkColorScheme) {
+    // The event is emitted to Go as well
+    darkColorScheme.addEventListener("change", () => EventsEmit(ColorSchemeEvent, WindowColorScheme()));
+}
+
 /**
  * Place the window in the center of the screen
  *
@@ -136,6 +173,16 @@ export function WindowSetAlwaysOnTop(b) {
     window.WailsInvoke('WATP:' + (b ? '1' : '0'));
 }
 
+/**
+ * Hides the window from the taskbar and the window switcher or shows it again
+ *
+ * @export
+ * @param {boolean} skip
+ */
+export function WindowSetSkipTaskbar(skip) {
+    window.WailsInvoke('WAST:' + (skip ? '1' : '0'));
+}
+
 
 
 
@@ -267,3 +314,113 @@ export function WindowSetBackgroundColour(R, G, B, A) {
     window.WailsInvoke('Wr:' + rgba);
 }
 
+/**
+ * Sets the zoom factor of the page, e.g. 1.5 for 150%
+ *
+ * @export
+ * @param {number} factor The zoom factor
+ */
+export function WindowSetZoom(factor) {
+    window.WailsInvoke('Wo:' + factor);
+}
+
+/**
+ * Returns the zoom factor of the page
+ *
+ * @export
+ * @return {Promise<number>} The zoom factor
+ */
+export function WindowGetZoom() {
+    return Call(":wails:WindowGetZoom");
+}
+
+/**
+ * Zooms the page in to the next zoom level
+ *
+ * @export
+ */
+export function WindowZoomIn() {
+    window.WailsInvoke('Wo+');
+}
+
+/**
+ * Zooms the page out to the previous zoom level
+ *
+ * @export
+ */
+export function WindowZoomOut() {
+    window.WailsInvoke('Wo-');
+}
+
+/**
+ * Resets the zoom factor of the page to 1
+ *
+ * @export
+ */
+export function WindowZoomReset() {
+    window.WailsInvoke('Wo:1');
+}
+
+
+/**
+ * Keeps the native view with the id, which is embedded with WindowEmbedNativeView in Go, over the placeholder element.
+ * The view follows the element when it is moved, resized, scrolled or hidden.
+ *
+ * @export
+ * @param {string} id The id of the native view
+ * @param {Element} element The placeholder element
+ * @return {function(): void} A function that stops tracking the element and hides the view
+ */
+export function WindowTrackNativeView(id, element) {
+    let last = "";
+    let intersecting = true;
+    let frame = 0;
+    const send = (bounds) => {
+        const message = JSON.stringify(bounds);
+        if (message !== last) {
+            last = message;
+            window.WailsInvoke('V' + message);
+        }
+    };
+    const update = () => {
+        frame = 0;
+        const rect = element.getBoundingClientRect();
+        send({
+            id,
+            x: Math.round(rect.left),
+            y: Math.round(rect.top),
+            width: Math.round(rect.width),
+            height: Math.round(rect.height),
+            visible: intersecting && element.isConnected && window.getComputedStyle(element).visibility !== 'hidden',
+            scale: window.devicePixelRatio || 1,
+        });
+    };
+    // Coalesce the updates of a frame
+    const schedule = () => {
+        if (!frame) {
+            frame = window.requestAnimationFrame(update);
+        }
+    };
+
+    const resizeObserver = new ResizeObserver(schedule);
+    resizeObserver.observe(element);
+    const intersectionObserver = new IntersectionObserver((entries) => {
+        intersecting = entries[entries.length - 1].isIntersecting;
+        schedule();
+    });
+    intersectionObserver.observe(element);
+    window.addEventListener('scroll', schedule, true);
+    window.addEventListener('resize', schedule);
+    update();
+
+    return () => {
+        resizeObserver.disconnect();
+        intersectionObserver.disconnect();
+        window.removeEventListener('scroll', schedule, true);
+        window.removeEventListener('resize', schedule);
+        if (frame) {
+            window.cancelAnimationFrame(frame);
+        }
+        send({id, x: 0, y: 0, width: 0, height: 0, visible: false, scale: window.devicePixelRatio || 1});
+    };
+}
@@ -0,0 +1,162 @@
+package runtime
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// starvationLimit is the number of times that pending events can be passed over by events of a higher priority
+// before one of them is delivered first
+const starvationLimit = 8
+
+// maxQueuedEvents is the number of pending events per priority. The oldest pending low priority event is dropped for
+// a new one, events of the other priorities are delivered by their emitter instead.
+var maxQueuedEvents = 1024
+
+type queuedEvent struct {
+	sender frontend.Frontend // The frontend that sent the event doesn't receive it
+	name   string
+	data   []interface{}
+}
+
+// eventQueue delivers the events to the frontends in the order of their priority. Events of the same priority keep
+// their order. The events are delivered by the goroutine that emits them, unless another goroutine is delivering
+// events already, which delivers them as well.
+type eventQueue struct {
+	lock       sync.Mutex
+	delivering bool
+	// idle is closed once the pending events have been delivered
+	idle    chan struct{}
+	deliver func(event queuedEvent)
+
+	// Indexed by queueIndex, high priority first
+	queues  [3][]queuedEvent
+	passed  [3]int
+	metrics [3]frontend.EventQueueMetrics
+}
+
+func newEventQueue(deliver func(event queuedEvent)) *eventQueue {
+	return &eventQueue{deliver: deliver}
+}
+
+func queueIndex(priority frontend.EventPriority) int {
+	switch {
+	case priority > frontend.EventPriorityNormal:
+		return 0
+	case priority < frontend.EventPriorityNormal:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// emit queues the event and delivers the pending events, it returns without delivering them while another goroutine
+// delivers the events
+func (q *eventQueue) emit(priority frontend.EventPriority, event queuedEvent) {
+	q.lock.Lock()
+	index := queueIndex(priority)
+	metrics := &q.metrics[index]
+	if len(q.queues[index]) >= maxQueuedEvents {
+		if index != queueIndex(frontend.EventPriorityLow) {
+			// Only bulk events are dropped, this one is delivered ahead of the pending events
+			metrics.Delivered++
+			q.lock.Unlock()
+			q.deliver(event)
+			return
+		}
+		q.queues[index][0] = queuedEvent{}
+		q.queues[index] = q.queues[index][1:]
+		metrics.Dropped++
+	}
+	q.queues[index] = append(q.queues[index], event)
+	metrics.Depth = len(q.queues[index])
+	metrics.MaxDepth = max(metrics.MaxDepth, metrics.Depth)
+	if q.delivering {
+		q.lock.Unlock()
+		return
+	}
+	q.delivering = true
+	q.idle = make(chan struct{})
+	q.lock.Unlock()
+	q.run()
+}
+
+func (q *eventQueue) empty() bool {
+	for _, queue := range q.queues {
+		if len(queue) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// next removes the next event from the queues. It must be called with the lock held and pending events.
+func (q *eventQueue) next() queuedEvent {
+	highest := 0
+	for len(q.queues[highest]) == 0 {
+		highest++
+	}
+	// An event that has been passed over too often is delivered first, the lowest priority first
+	selected := highest
+	for index := len(q.queues) - 1; index > highest; index-- {
+		if len(q.queues[index]) > 0 && q.passed[index] >= starvationLimit {
+			selected = index
+			q.metrics[index].Promoted++
+			break
+		}
+	}
+	for index := highest; index < len(q.queues); index++ {
+		if index != selected && len(q.queues[index]) > 0 {
+			q.passed[index]++
+		}
+	}
+	q.passed[selected] = 0
+
+	event := q.queues[selected][0]
+	q.queues[selected][0] = queuedEvent{}
+	q.queues[selected] = q.queues[selected][1:]
+	metrics := &q.metrics[selected]
+	metrics.Depth = len(q.queues[selected])
+	metrics.Delivered++
+	return event
+}
+
+// run delivers the pending events until the queues are empty
+func (q *eventQueue) run() {
+	q.lock.Lock()
+	for !q.empty() {
+		event := q.next()
+		q.lock.Unlock()
+		q.deliver(event)
+		q.lock.Lock()
+	}
+	q.delivering = false
+	close(q.idle)
+	q.lock.Unlock()
+}
+
+// wait waits up to the timeout for the pending events to be delivered
+func (q *eventQueue) wait(timeout time.Duration) {
+	q.lock.Lock()
+	delivering, idle := q.delivering, q.idle
+	q.lock.Unlock()
+	if !delivering {
+		return
+	}
+	select {
+	case <-idle:
+	case <-time.After(timeout):
+	}
+}
+
+func (q *eventQueue) stats() frontend.EventQueueStats {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return frontend.EventQueueStats{
+		High:   q.metrics[queueIndex(frontend.EventPriorityHigh)],
+		Normal: q.metrics[queueIndex(frontend.EventPriorityNormal)],
+		Low:    q.metrics[queueIndex(frontend.EventPriorityLow)],
+	}
+}
@@ -0,0 +1,143 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// blockedQueue returns a queue whose delivery of the event "block" waits until unblock is closed, so the events that
+// are emitted meanwhile are pending as if the frontend was congested
+func blockedQueue(t *testing.T) (queue *eventQueue, unblock chan struct{}, delivered chan string) {
+	t.Helper()
+	delivered = make(chan string, 100)
+	unblock = make(chan struct{})
+	queue = newEventQueue(func(event queuedEvent) {
+		if event.name == "block" {
+			<-unblock
+		}
+		delivered <- event.name
+	})
+	go queue.emit(frontend.EventPriorityNormal, queuedEvent{name: "block"})
+	for queue.stats().Normal.Delivered == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	return queue, unblock, delivered
+}
+
+func receive(t *testing.T, delivered chan string, count int) []string {
+	t.Helper()
+	var order []string
+	for i := 0; i < count; i++ {
+		select {
+		case name := <-delivered:
+			order = append(order, name)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %v", order)
+		}
+	}
+	return order
+}
+
+func TestEventQueue(t *testing.T) {
+	queue, unblock, delivered := blockedQueue(t)
+	queue.emit(frontend.EventPriorityLow, queuedEvent{name: "low0"})
+	queue.emit(frontend.EventPriorityLow, queuedEvent{name: "low1"})
+	queue.emit(frontend.EventPriorityNormal, queuedEvent{name: "normal0"})
+	for i := 0; i < 10; i++ {
+		queue.emit(frontend.EventPriorityHigh, queuedEvent{name: fmt.Sprintf("high%d", i)})
+	}
+	stats := queue.stats()
+	if stats.High.Depth != 10 || stats.Normal.Depth != 1 || stats.Low.Depth != 2 {
+		t.Errorf("unexpected depths %+v", stats)
+	}
+	close(unblock)
+
+	// The starving events are delivered after being passed over starvationLimit times
+	order := receive(t, delivered, 14)
+	want := []string{"block", "high0", "high1", "high2", "high3", "high4", "high5", "high6", "high7", "low0", "normal0", "high8", "high9", "low1"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+
+	queue.wait(5 * time.Second)
+	stats = queue.stats()
+	wantStats := frontend.EventQueueStats{
+		High:   frontend.EventQueueMetrics{MaxDepth: 10, Delivered: 10},
+		Normal: frontend.EventQueueMetrics{MaxDepth: 1, Delivered: 2, Promoted: 1},
+		Low:    frontend.EventQueueMetrics{MaxDepth: 2, Delivered: 2, Promoted: 1},
+	}
+	if stats != wantStats {
+		t.Errorf("expected %+v, got %+v", wantStats, stats)
+	}
+}
+
+func TestEventQueueHighPriority(t *testing.T) {
+	queue, unblock, delivered := blockedQueue(t)
+	queue.emit(frontend.EventPriorityNormal, queuedEvent{name: "normal0"})
+	queue.emit(frontend.EventPriorityNormal, queuedEvent{name: "normal1"})
+	queue.emit(frontend.EventPriorityHigh, queuedEvent{name: "high"})
+	close(unblock)
+
+	// The high priority event overtakes the normal events that have been emitted before
+	if order, want := receive(t, delivered, 4), []string{"block", "high", "normal0", "normal1"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestEventQueueLimit(t *testing.T) {
+	defer func(limit int) { maxQueuedEvents = limit }(maxQueuedEvents)
+	maxQueuedEvents = 2
+
+	queue, unblock, delivered := blockedQueue(t)
+	for i := 0; i < 4; i++ {
+		queue.emit(frontend.EventPriorityLow, queuedEvent{name: fmt.Sprintf("low%d", i)})
+	}
+	for i := 0; i < 3; i++ {
+		queue.emit(frontend.EventPriorityHigh, queuedEvent{name: fmt.Sprintf("high%d", i)})
+	}
+	stats := queue.stats()
+	if stats.Low.Depth != 2 || stats.Low.Dropped != 2 {
+		t.Errorf("expected the low priority queue to be limited to 2 events, got %+v", stats.Low)
+	}
+	// High priority events are never dropped, the one that exceeds the limit is delivered by its emitter
+	if stats.High.Depth != 2 || stats.High.Dropped != 0 || stats.High.Delivered != 1 {
+		t.Errorf("expected the high priority events to be kept, got %+v", stats.High)
+	}
+
+	close(unblock)
+	queue.wait(5 * time.Second)
+	close(delivered)
+	var order []string
+	for name := range delivered {
+		order = append(order, name)
+	}
+	if want := []string{"high2", "block", "high0", "high1", "low2", "low3"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+}
+
+func TestEmitWithPriority(t *testing.T) {
+	events := NewEvents(nopLogger{})
+	var lock sync.Mutex
+	var delivered []string
+	events.queue = newEventQueue(func(event queuedEvent) {
+		lock.Lock()
+		delivered = append(delivered, event.name)
+		lock.Unlock()
+	})
+
+	// Events are delivered before Emit returns while the frontend keeps up
+	events.Emit("normal")
+	events.EmitWithPriority(frontend.EventPriorityLow, "low")
+	events.Close()
+	lock.Lock()
+	defer lock.Unlock()
+	if want := []string{"normal", "low"}; !reflect.DeepEqual(delivered, want) {
+		t.Errorf("expected %v, got %v", want, delivered)
+	}
+}
@@ -1,12 +1,17 @@
 package runtime
 
 import (
+	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/wailsapp/wails/v2/internal/frontend"
 )
 
+// drainTimeout is how long Close waits for the pending events to be delivered
+const drainTimeout = time.Second
+
 type Logger interface {
 	Trace(format string, v ...interface{})
 }
@@ -29,16 +34,21 @@ type Events struct {
 	// Go event listeners
 	listeners  map[string][]*eventListener
 	notifyLock sync.RWMutex
+
+	// Delivery to the frontends
+	queue *eventQueue
+
+	schemas eventSchemas
 }
 
 func (e *Events) Notify(sender frontend.Frontend, name string, data ...interface{}) {
-	e.notifyBackend(name, data...)
-	for _, thisFrontend := range e.frontend {
-		if thisFrontend == sender {
-			continue
+	if e.schemas.enabled() {
+		if err := e.schemas.validate(name, data); err != nil {
+			e.schemas.warn("%s, emitted by the frontend", err)
 		}
-		thisFrontend.Notify(name, data...)
 	}
+	e.notifyBackend(name, data...)
+	e.queue.emit(frontend.EventPriorityNormal, queuedEvent{sender: sender, name: name, data: data})
 }
 
 func (e *Events) On(eventName string, callback func(...interface{})) func() {
@@ -54,9 +64,51 @@ func (e *Events) Once(eventName string, callback func(...interface{})) func() {
 }
 
 func (e *Events) Emit(eventName string, data ...interface{}) {
+	e.EmitWithPriority(frontend.EventPriorityNormal, eventName, data...)
+}
+
+// EmitWithPriority emits the event. Go listeners are notified immediately. The event is delivered to the frontends on
+// the calling goroutine, unless another goroutine is delivering events, which delivers the pending events in the order
+// of their priority.
+func (e *Events) EmitWithPriority(priority frontend.EventPriority, eventName string, data ...interface{}) {
+	if e.schemas.enabled() {
+		if err := e.schemas.validate(eventName, data); err != nil {
+			e.schemas.warn("%s, emitted by:\n%s", err, debug.Stack())
+		}
+	}
 	e.notifyBackend(eventName, data...)
+	e.queue.emit(priority, queuedEvent{name: eventName, data: data})
+}
+
+// Close waits up to the drainTimeout for the pending events to be delivered to the frontends
+func (e *Events) Close() {
+	e.queue.wait(drainTimeout)
+}
+
+// RegisterSchema registers the types of the data of the event, one sample value per argument
+func (e *Events) RegisterSchema(eventName string, samples ...interface{}) {
+	e.schemas.register(eventName, samples)
+}
+
+// EnableSchemaValidation checks the data of the events with registered schemas on emit and logs mismatches with warn
+func (e *Events) EnableSchemaValidation(warn func(format string, v ...interface{})) {
+	e.schemas.lock.Lock()
+	defer e.schemas.lock.Unlock()
+	e.schemas.warn = warn
+}
+
+// QueueStats returns the metrics of the queues of the delivery to the frontends
+func (e *Events) QueueStats() frontend.EventQueueStats {
+	return e.queue.stats()
+}
+
+// notifyFrontends delivers the event to the frontends except the sender
+func (e *Events) notifyFrontends(event queuedEvent) {
 	for _, thisFrontend := range e.frontend {
-		thisFrontend.Notify(eventName, data...)
+		if thisFrontend == event.sender {
+			continue
+		}
+		thisFrontend.Notify(event.name, event.data...)
 	}
 }
 
@@ -78,6 +130,7 @@ func NewEvents(log Logger) *Events {
 		log:       log,
 		listeners: make(map[string][]*eventListener),
 	}
+	result.queue = newEventQueue(result.notifyFrontends)
 	return result
 }
 
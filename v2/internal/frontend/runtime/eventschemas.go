@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// eventSchemas are the types of the data of events, which are checked on emit in dev builds to catch changes of the
+// data that the listeners of the event don't expect
+type eventSchemas struct {
+	lock  sync.RWMutex
+	types map[string][]reflect.Type
+	// warn logs the mismatches, validation is disabled if it is nil
+	warn func(format string, v ...interface{})
+}
+
+func (s *eventSchemas) register(eventName string, samples []interface{}) {
+	types := make([]reflect.Type, len(samples))
+	for index, sample := range samples {
+		types[index] = reflect.TypeOf(sample)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.types == nil {
+		s.types = map[string][]reflect.Type{}
+	}
+	s.types[eventName] = types
+}
+
+func (s *eventSchemas) enabled() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.warn != nil
+}
+
+// validate returns an error that describes how the data doesn't match the registered types of the event. Events
+// without registered types are not checked.
+func (s *eventSchemas) validate(eventName string, data []interface{}) error {
+	s.lock.RLock()
+	types, registered := s.types[eventName]
+	s.lock.RUnlock()
+	if !registered {
+		return nil
+	}
+	if len(data) != len(types) {
+		return fmt.Errorf("event '%s' has %d arguments, want %d", eventName, len(data), len(types))
+	}
+	for index, value := range data {
+		if err := validateEventValue(types[index], value); err != nil {
+			return fmt.Errorf("argument %d of event '%s' %w", index+1, eventName, err)
+		}
+	}
+	return nil
+}
+
+// validateEventValue checks if the value can be decoded into the type without unknown fields and has the fields of
+// the type that aren't omitted when empty. Values of the frontend are decoded from JSON, so they are compared by
+// their JSON encoding.
+func validateEventValue(expected reflect.Type, value interface{}) error {
+	if expected == nil {
+		// Registered as nil, any value is allowed
+		return nil
+	}
+	if value == nil {
+		switch expected.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+			return nil
+		}
+		return fmt.Errorf("is null, want %s", expected)
+	}
+	actual := reflect.TypeOf(value)
+	if actual == expected || (actual.Kind() == reflect.Pointer && actual.Elem() == expected) {
+		return nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("can't be encoded as JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(reflect.New(expected).Interface()); err != nil {
+		return fmt.Errorf("doesn't match %s: %w", expected, err)
+	}
+
+	structType := expected
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil
+	}
+	var missing []string
+	for _, name := range requiredFields(structType) {
+		if _, found := fields[name]; !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("misses the fields %s of %s", strings.Join(missing, ", "), expected)
+	}
+	return nil
+}
+
+// requiredFields returns the JSON names of the exported fields of the struct that aren't omitted when empty
+func requiredFields(structType reflect.Type) []string {
+	var result []string
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if strings.Contains(","+options+",", ",omitempty,") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type user struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Age   int    `json:"age"`
+}
+
+func TestEventSchemas(t *testing.T) {
+	var schemas eventSchemas
+	schemas.register("user:updated", []interface{}{user{}})
+	schemas.register("progress", []interface{}{0, ""})
+
+	tests := []struct {
+		name  string
+		event string
+		data  []interface{}
+		want  string
+	}{
+		{name: "go type", event: "user:updated", data: []interface{}{user{Name: "Ada"}}},
+		{name: "pointer", event: "user:updated", data: []interface{}{&user{Name: "Ada"}}},
+		{name: "frontend", event: "user:updated", data: []interface{}{map[string]interface{}{"name": "Ada", "age": 36.0}}},
+		{name: "unregistered", event: "other", data: []interface{}{1, 2, 3}},
+		{name: "unknown field", event: "user:updated", data: []interface{}{map[string]interface{}{"name": "Ada", "age": 36.0, "admin": true}}, want: `unknown field "admin"`},
+		{name: "missing field", event: "user:updated", data: []interface{}{map[string]interface{}{"name": "Ada"}}, want: "misses the fields age"},
+		{name: "wrong type", event: "progress", data: []interface{}{"50%", "copying"}, want: "argument 1 of event 'progress' doesn't match int"},
+		{name: "arguments", event: "progress", data: []interface{}{50}, want: "has 1 arguments, want 2"},
+		{name: "null", event: "progress", data: []interface{}{nil, ""}, want: "is null, want int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schemas.validate(tt.event, tt.data)
+			if got := fmt.Sprint(err); (tt.want == "" && err != nil) || !strings.Contains(got, tt.want) {
+				t.Errorf("validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventsSchemaValidation(t *testing.T) {
+	events := NewEvents(nopLogger{})
+	events.RegisterSchema("progress", 0)
+	var warnings []string
+	warn := func(format string, v ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, v...))
+	}
+
+	// Validation is disabled until it is enabled for dev builds
+	events.Emit("progress", "50%")
+	events.EnableSchemaValidation(warn)
+	events.Emit("progress", 50)
+	events.Emit("progress", "50%")
+	events.Notify(nil, "progress", "50%")
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "TestEventsSchemaValidation") || !strings.HasSuffix(warnings[1], "emitted by the frontend") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Trace(format string, v ...interface{}) {}
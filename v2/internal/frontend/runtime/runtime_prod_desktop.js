@@ -1 +1 @@
-(()=>{var j=Object.defineProperty;var g=(e,t)=>{for(var n in t)j(e,n,{get:t[n],enumerable:!0})};var b={};g(b,{LogDebug:()=>X,LogError:()=>q,LogFatal:()=>Q,LogInfo:()=>$,LogLevel:()=>Z,LogPrint:()=>J,LogTrace:()=>N,LogWarning:()=>Y,SetLogLevel:()=>_});function u(e,t){window.WailsInvoke("L"+e+t)}function N(e){u("T",e)}function J(e){u("P",e)}function X(e){u("D",e)}function $(e){u("I",e)}function Y(e){u("W",e)}function q(e){u("E",e)}function Q(e){u("F",e)}function _(e){u("S",e)}var Z={TRACE:1,DEBUG:2,INFO:3,WARNING:4,ERROR:5};var y=class{constructor(t,n,o){this.eventName=t,this.maxCallbacks=o||-1,this.Callback=i=>(n.apply(null,i),this.maxCallbacks===-1?!1:(this.maxCallbacks-=1,this.maxCallbacks===0))}},d={};function v(e,t,n){d[e]=d[e]||[];let o=new y(e,t,n);return d[e].push(o),()=>K(o)}function W(e,t){return v(e,t,-1)}function A(e,t){return v(e,t,1)}function P(e){let t=e.name,n=d[t]?.slice()||[];if(n.length){for(let o=n.length-1;o>=0;o-=1){let i=n[o],r=e.data;i.Callback(r)&&n.splice(o,1)}n.length===0?m(t):d[t]=n}}function R(e){let t;try{t=JSON.parse(e)}catch{let o="Invalid JSON passed to Notify: "+e;throw new Error(o)}P(t)}function M(e){let t={name:e,data:[].slice.apply(arguments).slice(1)};P(t),window.WailsInvoke("EE"+JSON.stringify(t))}function m(e){delete d[e],window.WailsInvoke("EX"+e)}function x(e,...t){m(e),t.length>0&&t.forEach(n=>{m(n)})}function K(e){let t=e.eventName;d[t]=d[t].filter(n=>n!==e),d[t].length===0&&m(t)}var c={};function ee(){var e=new Uint32Array(1);return window.crypto.getRandomValues(e)[0]}function te(){return Math.random()*9007199254740991}var D;window.crypto?D=ee:D=te;function a(e,t,n){return n==null&&(n=0),new Promise(function(o,i){var r;do r=e+"-"+D();while(c[r]);var l;n>0&&(l=setTimeout(function(){i(Error("Call to "+e+" timed out. Request ID: "+r))},n)),c[r]={timeoutHandle:l,reject:i,resolve:o};try{let w={name:e,args:t,callbackID:r};window.WailsInvoke("C"+JSON.stringify(w))}catch(w){console.error(w)}})}window.ObfuscatedCall=(e,t,n)=>(n==null&&(n=0),new Promise(function(o,i){var r;do r=e+"-"+D();while(c[r]);var l;n>0&&(l=setTimeout(function(){i(Error("Call to method "+e+" timed out. Request ID: "+r))},n)),c[r]={timeoutHandle:l,reject:i,resolve:o};try{let w={id:e,args:t,callbackID:r};window.WailsInvoke("c"+JSON.stringify(w))}catch(w){console.error(w)}}));function z(e){let t;try{t=JSON.parse(e)}catch(i){let r=`Invalid JSON passed to callback: ${i.message}. Message: ${e}`;throw runtime.LogDebug(r),new Error(r)}let n=t.callbackid,o=c[n];if(!o){let i=`Callback '${n}' not registered!!!`;throw console.error(i),new Error(i)}clearTimeout(o.timeoutHandle),delete c[n],t.error?o.reject(t.error):o.resolve(t.result)}window.go={};function B(e){try{e=JSON.parse(e)}catch(t){console.error(t)}window.go=window.go||{},Object.keys(e).forEach(t=>{window.go[t]=window.go[t]||{},Object.keys(e[t]).forEach(n=>{window.go[t][n]=window.go[t][n]||{},Object.keys(e[t][n]).forEach(o=>{window.go[t][n][o]=function(){let i=0;function r(){let l=[].slice.call(arguments);return a([t,n,o].join("."),l,i)}return r.setTimeout=function(l){i=l},r.getTimeout=function(){return i},r}()})})})}var T={};g(T,{WindowCenter:()=>le,WindowFullscreen:()=>we,WindowGetPosition:()=>We,WindowGetSize:()=>ce,WindowHide:()=>xe,WindowIsFullscreen:()=>fe,WindowIsMaximised:()=>ye,WindowIsMinimised:()=>Se,WindowIsNormal:()=>Ie,WindowMaximise:()=>he,WindowMinimise:()=>Te,WindowReload:()=>ne,WindowReloadApp:()=>oe,WindowSetAlwaysOnTop:()=>me,WindowSetBackgroundColour:()=>Ce,WindowSetDarkTheme:()=>se,WindowSetLightTheme:()=>re,WindowSetMaxSize:()=>ge,WindowSetMinSize:()=>pe,WindowSetPosition:()=>ve,WindowSetSize:()=>ue,WindowSetSystemDefaultTheme:()=>ie,WindowSetTitle:()=>ae,WindowShow:()=>De,WindowToggleMaximise:()=>Ee,WindowUnfullscreen:()=>de,WindowUnmaximise:()=>be,WindowUnminimise:()=>ke});function ne(){window.location.reload()}function oe(){window.WailsInvoke("WR")}function ie(){window.WailsInvoke("WASDT")}function re(){window.WailsInvoke("WALT")}function se(){window.WailsInvoke("WADT")}function le(){window.WailsInvoke("Wc")}function ae(e){window.WailsInvoke("WT"+e)}function we(){window.WailsInvoke("WF")}function de(){window.WailsInvoke("Wf")}function fe(){return a(":wails:WindowIsFullscreen")}function ue(e,t){window.WailsInvoke("Ws:"+e+":"+t)}function ce(){return a(":wails:WindowGetSize")}function ge(e,t){window.WailsInvoke("WZ:"+e+":"+t)}function pe(e,t){window.WailsInvoke("Wz:"+e+":"+t)}function me(e){window.WailsInvoke("WATP:"+(e?"1":"0"))}function ve(e,t){window.WailsInvoke("Wp:"+e+":"+t)}function We(){return a(":wails:WindowGetPos")}function xe(){window.WailsInvoke("WH")}function De(){window.WailsInvoke("WS")}function he(){window.WailsInvoke("WM")}function Ee(){window.WailsInvoke("Wt")}function be(){window.WailsInvoke("WU")}function ye(){return a(":wails:WindowIsMaximised")}function Te(){window.WailsInvoke("Wm")}function ke(){window.WailsInvoke("Wu")}function Se(){return a(":wails:WindowIsMinimised")}function Ie(){return a(":wails:WindowIsNormal")}function Ce(e,t,n,o){let i=JSON.stringify({r:e||0,g:t||0,b:n||0,a:o||255});window.WailsInvoke("Wr:"+i)}var k={};g(k,{ScreenGetAll:()=>Oe});function Oe(){return a(":wails:ScreenGetAll")}var S={};g(S,{BrowserOpenURL:()=>Le});function Le(e){window.WailsInvoke("BO:"+e)}var I={};g(I,{ClipboardGetText:()=>Pe,ClipboardSetText:()=>Ae});function Ae(e){return a(":wails:ClipboardSetText",[e])}function Pe(){return a(":wails:ClipboardGetText")}var C={};g(C,{CanResolveFilePaths:()=>U,OnFileDrop:()=>Me,OnFileDropOff:()=>ze,ResolveFilePaths:()=>Re});var s={registered:!1,defaultUseDropTarget:!0,useDropTarget:!0,nextDeactivate:null,nextDeactivateTimeout:null},p="wails-drop-target-active";function h(e){let t=e.getPropertyValue(window.wails.flags.cssDropProperty).trim();return t?t===window.wails.flags.cssDropValue:!1}function F(e){if(!window.wails.flags.enableWailsDragAndDrop||(e.dataTransfer.dropEffect="copy",e.preventDefault(),!s.useDropTarget))return;let t=e.target;if(s.nextDeactivate&&s.nextDeactivate(),!t||!h(getComputedStyle(t)))return;let n=t;for(;n;)h(n.style)&&n.classList.add(p),n=n.parentElement}function G(e){if(!!window.wails.flags.enableWailsDragAndDrop&&(e.preventDefault(),!!s.useDropTarget)){if(!e.target||!h(getComputedStyle(e.target)))return null;s.nextDeactivate&&s.nextDeactivate(),s.nextDeactivate=()=>{Array.from(document.getElementsByClassName(p)).forEach(t=>t.classList.remove(p)),s.nextDeactivate=null,s.nextDeactivateTimeout&&(clearTimeout(s.nextDeactivateTimeout),s.nextDeactivateTimeout=null)},s.nextDeactivateTimeout=setTimeout(()=>{s.nextDeactivate&&s.nextDeactivate()},50)}}function H(e){if(!!window.wails.flags.enableWailsDragAndDrop){if(e.preventDefault(),U()){let t=[];e.dataTransfer.items?t=[...e.dataTransfer.items].map((n,o)=>{if(n.kind==="file")return n.getAsFile()}):t=[...e.dataTransfer.files],window.runtime.ResolveFilePaths(e.x,e.y,t)}!s.useDropTarget||(s.nextDeactivate&&s.nextDeactivate(),Array.from(document.getElementsByClassName(p)).forEach(t=>t.classList.remove(p)))}}function U(){return window.chrome?.webview?.postMessageWithAdditionalObjects!=null}function Re(e,t,n){window.chrome?.webview?.postMessageWithAdditionalObjects&&chrome.webview.postMessageWithAdditionalObjects(`file:drop:${e}:${t}`,n)}function Me(e,t){if(typeof e!="function"){console.error("DragAndDropCallback is not a function");return}if(s.registered)return;s.registered=!0;let n=typeof t;s.useDropTarget=n==="undefined"||n!=="boolean"?s.defaultUseDropTarget:t,window.addEventListener("dragover",F),window.addEventListener("dragleave",G),window.addEventListener("drop",H);let o=e;s.useDropTarget&&(o=function(i,r,l){let w=document.elementFromPoint(i,r);if(!w||!h(getComputedStyle(w)))return null;e(i,r,l)}),W("wails:file-drop",o)}function ze(){window.removeEventListener("dragover",F),window.removeEventListener("dragleave",G),window.removeEventListener("drop",H),x("wails:file-drop"),s.registered=!1}function V(e){let t=e.target;switch(window.getComputedStyle(t).getPropertyValue("--default-contextmenu").trim()){case"show":return;case"hide":e.preventDefault();return;default:if(t.isContentEditable)return;let i=window.getSelection(),r=i.toString().length>0;if(r)for(let l=0;l<i.rangeCount;l++){let O=i.getRangeAt(l).getClientRects();for(let E=0;E<O.length;E++){let L=O[E];if(document.elementFromPoint(L.left,L.top)===t)return}}if((t.tagName==="INPUT"||t.tagName==="TEXTAREA")&&(r||!t.readOnly&&!t.disabled))return;e.preventDefault()}}function Fe(){window.WailsInvoke("Q")}function Ge(){window.WailsInvoke("S")}function He(){window.WailsInvoke("H")}function Ue(){return a(":wails:Environment")}window.runtime={...b,...T,...S,...k,...I,...C,EventsOn:W,EventsOnce:A,EventsOnMultiple:v,EventsEmit:M,EventsOff:x,Environment:Ue,Show:Ge,Hide:He,Quit:Fe};window.wails={Callback:z,EventsNotify:R,SetBindings:B,eventListeners:d,callbacks:c,flags:{disableScrollbarDrag:!1,disableDefaultContextMenu:!1,enableResize:!1,defaultCursor:null,borderThickness:6,shouldDrag:!1,deferDragToMouseMove:!0,cssDragProperty:"--wails-draggable",cssDragValue:"drag",cssDropProperty:"--wails-drop-target",cssDropValue:"drop",enableWailsDragAndDrop:!1}};window.wailsbindings&&(window.wails.SetBindings(window.wailsbindings),delete window.wails.SetBindings);delete window.wailsbindings;var Ve=function(e){var t=window.getComputedStyle(e.target).getPropertyValue(window.wails.flags.cssDragProperty);return t&&(t=t.trim()),!(t!==window.wails.flags.cssDragValue||e.buttons!==1||e.detail!==1)};window.wails.setCSSDragProperties=function(e,t){window.wails.flags.cssDragProperty=e,window.wails.flags.cssDragValue=t};window.wails.setCSSDropProperties=function(e,t){window.wails.flags.cssDropProperty=e,window.wails.flags.cssDropValue=t};window.addEventListener("mousedown",e=>{if(window.wails.flags.resizeEdge){window.WailsInvoke("resize:"+window.wails.flags.resizeEdge),e.preventDefault();return}if(Ve(e)){if(window.wails.flags.disableScrollbarDrag&&(e.offsetX>e.target.clientWidth||e.offsetY>e.target.clientHeight))return;window.wails.flags.deferDragToMouseMove?window.wails.flags.shouldDrag=!0:(e.preventDefault(),window.WailsInvoke("drag"));return}else window.wails.flags.shouldDrag=!1});window.addEventListener("mouseup",()=>{window.wails.flags.shouldDrag=!1});function f(e){document.documentElement.style.cursor=e||window.wails.flags.defaultCursor,window.wails.flags.resizeEdge=e}window.addEventListener("mousemove",function(e){if(window.wails.flags.shouldDrag&&(window.wails.flags.shouldDrag=!1,(e.buttons!==void 0?e.buttons:e.which)>0)){window.WailsInvoke("drag");return}if(!window.wails.flags.enableResize)return;window.wails.flags.defaultCursor==null&&(window.wails.flags.defaultCursor=document.documentElement.style.cursor),window.outerWidth-e.clientX<window.wails.flags.borderThickness&&window.outerHeight-e.clientY<window.wails.flags.borderThickness&&(document.documentElement.style.cursor="se-resize");let t=window.outerWidth-e.clientX<window.wails.flags.borderThickness,n=e.clientX<window.wails.flags.borderThickness,o=e.clientY<window.wails.flags.borderThickness,i=window.outerHeight-e.clientY<window.wails.flags.borderThickness;!n&&!t&&!o&&!i&&window.wails.flags.resizeEdge!==void 0?f():t&&i?f("se-resize"):n&&i?f("sw-resize"):n&&o?f("nw-resize"):o&&t?f("ne-resize"):n?f("w-resize"):o?f("n-resize"):i?f("s-resize"):t&&f("e-resize")});window.addEventListener("contextmenu",function(e){window.wails.flags.disableDefaultContextMenu?e.preventDefault():V(e)});window.WailsInvoke("runtime:ready");})();
+(()=>{var de=Object.defineProperty;var g=(e,t)=>{for(var n in t)de(e,n,{get:t[n],enumerable:!0})};var P={};g(P,{Log:()=>be,LogDebug:()=>fe,LogError:()=>me,LogFatal:()=>ve,LogInfo:()=>pe,LogLevel:()=>xe,LogPrint:()=>ue,LogTrace:()=>we,LogWarning:()=>ge,SetLogLevel:()=>he});function f(e,t){window.WailsInvoke("L"+e+t)}function we(e){f("T",e)}function ue(e){f("P",e)}function fe(e){f("D",e)}function pe(e){f("I",e)}function ge(e){f("W",e)}function me(e){f("E",e)}function ve(e){f("F",e)}function he(e){f("S",e)}var xe={TRACE:1,DEBUG:2,INFO:3,WARNING:4,ERROR:5},We=Math.random().toString(36).slice(2,10);function De(e,t,n){let o={page:window.location.pathname,pageId:We};for(let[i,r]of Object.entries(n||{}))o[i]=r instanceof Error?r.toString():r;f("J",JSON.stringify({level:e,message:String(t),fields:o}))}function M(e){let t=n=>(o,i)=>De(n,o,Object.assign({},e,i));return{Trace:t("trace"),Debug:t("debug"),Info:t("info"),Warn:t("warning"),Error:t("error"),With:n=>M(Object.assign({},e,n)),WithCorrelationID:n=>M(Object.assign({},e,{correlationId:n}))}}var be=M({});var A=class{constructor(t,n,o){this.eventName=t,this.maxCallbacks=o||-1,this.Callback=i=>(n.apply(null,i),this.maxCallbacks===-1?!1:(this.maxCallbacks-=1,this.maxCallbacks===0))}},u={};function k(e,t,n){u[e]=u[e]||[];let o=new A(e,t,n);return u[e].push(o),()=>Se(o)}function m(e,t){return k(e,t,-1)}function V(e,t){return k(e,t,1)}function J(e){let t=e.name,n=u[t]?.slice()||[];if(n.length){for(let o=n.length-1;o>=0;o-=1){let i=n[o],r=e.data;i.Callback(r)&&n.splice(o,1)}n.length===0?E(t):u[t]=n}}function $(e){let t;try{t=JSON.parse(e)}catch{let o="Invalid JSON passed to Notify: "+e;throw new Error(o)}J(t)}function v(e){let t={name:e,data:[].slice.apply(arguments).slice(1)};J(t),window.WailsInvoke("EE"+JSON.stringify(t))}function E(e){delete u[e],window.WailsInvoke("EX"+e)}function y(e,...t){E(e),t.length>0&&t.forEach(n=>{E(n)})}function Se(e){let t=e.eventName;u[t]=u[t].filter(n=>n!==e),u[t].length===0&&E(t)}var h={};function Ee(){var e=new Uint32Array(1);return window.crypto.getRandomValues(e)[0]}function ke(){return Math.random()*9007199254740991}var I;window.crypto?I=Ee:I=ke;function s(e,t,n){return n==null&&(n=0),new Promise(function(o,i){var r;do r=e+"-"+I();while(h[r]);var a;n>0&&(a=setTimeout(function(){i(Error("Call to "+e+" timed out. Request ID: "+r))},n)),h[r]={timeoutHandle:a,reject:i,resolve:o};try{let c={name:e,args:t,callbackID:r};window.WailsInvoke("C"+JSON.stringify(c))}catch(c){console.error(c)}})}window.ObfuscatedCall=(e,t,n)=>(n==null&&(n=0),new Promise(function(o,i){var r;do r=e+"-"+I();while(h[r]);var a;n>0&&(a=setTimeout(function(){i(Error("Call to method "+e+" timed out. Request ID: "+r))},n)),h[r]={timeoutHandle:a,reject:i,resolve:o};try{let c={id:e,args:t,callbackID:r};window.WailsInvoke("c"+JSON.stringify(c))}catch(c){console.error(c)}}));function Z(e){let t;try{t=JSON.parse(e)}catch(i){let r=`Invalid JSON passed to callback: ${i.message}. Message: ${e}`;throw runtime.LogDebug(r),new Error(r)}let n=t.callbackid,o=h[n];if(!o){let i=`Callback '${n}' not registered!!!`;throw console.error(i),new Error(i)}clearTimeout(o.timeoutHandle),delete h[n],t.error?o.reject(t.error):o.resolve(t.result)}window.go={};function X(e){try{e=JSON.parse(e)}catch(t){console.error(t)}window.go=window.go||{},Object.keys(e).forEach(t=>{window.go[t]=window.go[t]||{},Object.keys(e[t]).forEach(n=>{window.go[t][n]=window.go[t][n]||{},Object.keys(e[t][n]).forEach(o=>{window.go[t][n][o]=function(){let i=0;function r(){let a=[].slice.call(arguments);return s([t,n,o].join("."),a,i)}return r.setTimeout=function(a){i=a},r.getTimeout=function(){return i},r}()})})})}var R={};g(R,{OnColorSchemeChange:()=>Me,WindowCenter:()=>Pe,WindowColorScheme:()=>q,WindowFullscreen:()=>Re,WindowGetPosition:()=>Je,WindowGetSize:()=>Fe,WindowGetZoom:()=>it,WindowHide:()=>$e,WindowIsFullscreen:()=>He,WindowIsMaximised:()=>Ke,WindowIsMinimised:()=>et,WindowIsNormal:()=>tt,WindowMaximise:()=>Xe,WindowMinimise:()=>_e,WindowRecycle:()=>Ce,WindowReload:()=>ye,WindowReloadApp:()=>Ie,WindowSetAlwaysOnTop:()=>Ge,WindowSetBackgroundColour:()=>nt,WindowSetDarkTheme:()=>Le,WindowSetLightTheme:()=>Oe,WindowSetMaxSize:()=>Ne,WindowSetMinSize:()=>je,WindowSetPosition:()=>Ve,WindowSetSize:()=>Be,WindowSetSkipTaskbar:()=>Ue,WindowSetSystemDefaultTheme:()=>Te,WindowSetTitle:()=>Ae,WindowSetZoom:()=>ot,WindowShow:()=>Ze,WindowToggleMaximise:()=>Ye,WindowTrackNativeView:()=>lt,WindowUnfullscreen:()=>ze,WindowUnmaximise:()=>qe,WindowUnminimise:()=>Qe,WindowZoomIn:()=>rt,WindowZoomOut:()=>st,WindowZoomReset:()=>at});var Y="wails:color-scheme";function ye(){window.location.reload()}function Ie(){window.WailsInvoke("WR")}function Ce(){window.WailsInvoke("WY")}function Te(){window.WailsInvoke("WASDT")}function Oe(){window.WailsInvoke("WALT")}function Le(){window.WailsInvoke("WADT")}var C=window.matchMedia?window.matchMedia("(prefers-color-scheme: dark)"):null;function q(){return C&&C.matches?"dark":"light"}function Me(e){return m(Y,e)}C&&C.addEventListener("change",()=>v(Y,q()));function Pe(){window.WailsInvoke("Wc")}function Ae(e){window.WailsInvoke("WT"+e)}function Re(){window.WailsInvoke("WF")}function ze(){window.WailsInvoke("Wf")}function He(){return s(":wails:WindowIsFullscreen")}function Be(e,t){window.WailsInvoke("Ws:"+e+":"+t)}function Fe(){return s(":wails:WindowGetSize")}function Ne(e,t){window.WailsInvoke("WZ:"+e+":"+t)}function je(e,t){window.WailsInvoke("Wz:"+e+":"+t)}function Ge(e){window.WailsInvoke("WATP:"+(e?"1":"0"))}function Ue(e){window.WailsInvoke("WAST:"+(e?"1":"0"))}function Ve(e,t){window.WailsInvoke("Wp:"+e+":"+t)}function Je(){return s(":wails:WindowGetPos")}function $e(){window.WailsInvoke("WH")}function Ze(){window.WailsInvoke("WS")}function Xe(){window.WailsInvoke("WM")}function Ye(){window.WailsInvoke("Wt")}function qe(){window.WailsInvoke("WU")}function Ke(){return s(":wails:WindowIsMaximised")}function _e(){window.WailsInvoke("Wm")}function Qe(){window.WailsInvoke("Wu")}function et(){return s(":wails:WindowIsMinimised")}function tt(){return s(":wails:WindowIsNormal")}function nt(e,t,n,o){let i=JSON.stringify({r:e||0,g:t||0,b:n||0,a:o||255});window.WailsInvoke("Wr:"+i)}function ot(e){window.WailsInvoke("Wo:"+e)}function it(){return s(":wails:WindowGetZoom")}function rt(){window.WailsInvoke("Wo+")}function st(){window.WailsInvoke("Wo-")}function at(){window.WailsInvoke("Wo:1")}function lt(e,t){let n="",o=!0,i=0,r=d=>{let L=JSON.stringify(d);L!==n&&(n=L,window.WailsInvoke("V"+L))},a=()=>{i=0;let d=t.getBoundingClientRect();r({id:e,x:Math.round(d.left),y:Math.round(d.top),width:Math.round(d.width),height:Math.round(d.height),visible:o&&t.isConnected&&window.getComputedStyle(t).visibility!=="hidden",scale:window.devicePixelRatio||1})},c=()=>{i||(i=window.requestAnimationFrame(a))},D=new ResizeObserver(c);D.observe(t);let x=new IntersectionObserver(d=>{o=d[d.length-1].isIntersecting,c()});return x.observe(t),window.addEventListener("scroll",c,!0),window.addEventListener("resize",c),a(),()=>{D.disconnect(),x.disconnect(),window.removeEventListener("scroll",c,!0),window.removeEventListener("resize",c),i&&window.cancelAnimationFrame(i),r({id:e,x:0,y:0,width:0,height:0,visible:!1,scale:window.devicePixelRatio||1})}}var z={};g(z,{ScreenGetAll:()=>ct});function ct(){return s(":wails:ScreenGetAll")}var H={};g(H,{BrowserOpenURL:()=>dt});function dt(e){window.WailsInvoke("BO:"+e)}var B={};g(B,{ClipboardGetText:()=>ut,ClipboardSetText:()=>wt});function wt(e){return s(":wails:ClipboardSetText",[e])}function ut(){return s(":wails:ClipboardGetText")}var F={};g(F,{CanResolveFilePaths:()=>ee,OnFileDrop:()=>pt,OnFileDropOff:()=>gt,ResolveFilePaths:()=>ft});var l={registered:!1,defaultUseDropTarget:!0,useDropTarget:!0,nextDeactivate:null,nextDeactivateTimeout:null},b="wails-drop-target-active";function T(e){let t=e.getPropertyValue(window.wails.flags.cssDropProperty).trim();return t?t===window.wails.flags.cssDropValue:!1}function K(e){if(!window.wails.flags.enableWailsDragAndDrop||(e.dataTransfer.dropEffect="copy",e.preventDefault(),!l.useDropTarget))return;let t=e.target;if(l.nextDeactivate&&l.nextDeactivate(),!t||!T(getComputedStyle(t)))return;let n=t;for(;n;)T(n.style)&&n.classList.add(b),n=n.parentElement}function _(e){if(!!window.wails.flags.enableWailsDragAndDrop&&(e.preventDefault(),!!l.useDropTarget)){if(!e.target||!T(getComputedStyle(e.target)))return null;l.nextDeactivate&&l.nextDeactivate(),l.nextDeactivate=()=>{Array.from(document.getElementsByClassName(b)).forEach(t=>t.classList.remove(b)),l.nextDeactivate=null,l.nextDeactivateTimeout&&(clearTimeout(l.nextDeactivateTimeout),l.nextDeactivateTimeout=null)},l.nextDeactivateTimeout=setTimeout(()=>{l.nextDeactivate&&l.nextDeactivate()},50)}}function Q(e){if(!!window.wails.flags.enableWailsDragAndDrop){if(e.preventDefault(),ee()){let t=[];e.dataTransfer.items?t=[...e.dataTransfer.items].map((n,o)=>{if(n.kind==="file")return n.getAsFile()}):t=[...e.dataTransfer.files],window.runtime.ResolveFilePaths(e.x,e.y,t)}!l.useDropTarget||(l.nextDeactivate&&l.nextDeactivate(),Array.from(document.getElementsByClassName(b)).forEach(t=>t.classList.remove(b)))}}function ee(){return window.chrome?.webview?.postMessageWithAdditionalObjects!=null}function ft(e,t,n){window.chrome?.webview?.postMessageWithAdditionalObjects&&chrome.webview.postMessageWithAdditionalObjects(`file:drop:${e}:${t}`,n)}function pt(e,t){if(typeof e!="function"){console.error("DragAndDropCallback is not a function");return}if(l.registered)return;l.registered=!0;let n=typeof t;l.useDropTarget=n==="undefined"||n!=="boolean"?l.defaultUseDropTarget:t,window.addEventListener("dragover",K),window.addEventListener("dragleave",_),window.addEventListener("drop",Q);let o=e;l.useDropTarget&&(o=function(i,r,a){let c=document.elementFromPoint(i,r);if(!c||!T(getComputedStyle(c)))return null;e(i,r,a)}),m("wails:file-drop",o)}function gt(){window.removeEventListener("dragover",K),window.removeEventListener("dragleave",_),window.removeEventListener("drop",Q),y("wails:file-drop"),l.registered=!1}function te(e){let t=e.target;switch(window.getComputedStyle(t).getPropertyValue("--default-contextmenu").trim()){case"show":return;case"hide":e.preventDefault();return;default:if(t.isContentEditable)return;let i=window.getSelection(),r=i.toString().length>0;if(r)for(let a=0;a<i.rangeCount;a++){let D=i.getRangeAt(a).getClientRects();for(let x=0;x<D.length;x++){let d=D[x];if(document.elementFromPoint(d.left,d.top)===t)return}}if((t.tagName==="INPUT"||t.tagName==="TEXTAREA")&&(r||!t.readOnly&&!t.disabled))return;e.preventDefault()}}var j={};g(j,{Clear:()=>Et,Preserve:()=>St});var vt=2e3,ht=1e4,O="data-wails-preserve",W=new Map,w=null,ne=null,oe=!1;function ie(){return window.location.pathname+window.location.search+window.location.hash}function xt(e){return e.getAttribute(O)||e.id}function Wt(){let e={url:ie(),scroll:[window.scrollX,window.scrollY],elements:{},values:{}};return document.querySelectorAll(`[${O}]`).forEach(t=>{let n=xt(t);if(!n)return;let o={scroll:[t.scrollLeft,t.scrollTop]};t.type==="checkbox"||t.type==="radio"?o.checked=t.checked:"value"in t&&t.type!=="password"&&t.type!=="file"&&(o.value=t.value),e.elements[n]=o}),w&&w.values&&Object.assign(e.values,w.values),W.forEach((t,n)=>{try{e.values[n]=t.getter()}catch(o){console.error(`State.Preserve: the getter of '${n}' failed:`,o)}}),e}function N(){if(w===null||oe)return;let e=Wt(),t=JSON.stringify(e);t!==ne&&(ne=t,s(":wails:StateSave",[e]).catch(n=>console.error("State: saving the snapshot failed:",n)))}function Dt(e,t){t.checked!==void 0?e.checked=t.checked:t.value!==void 0&&e.value!==t.value&&(e.value=t.value,e.dispatchEvent(new Event("input",{bubbles:!0}))),t.scroll&&(e.scrollLeft=t.scroll[0],e.scrollTop=t.scroll[1])}function bt(e){if(e.url!==ie())return;let t=new Map(Object.entries(e.elements||{})),n=e.scroll&&(e.scroll[0]!==0||e.scroll[1]!==0)?e.scroll:null,o=()=>(t.forEach((r,a)=>{let c=document.querySelector(`[${O}="${CSS.escape(a)}"]`)||document.getElementById(a);c&&c.hasAttribute(O)&&(Dt(c,r),t.delete(a))}),n&&(window.scrollTo(n[0],n[1]),Math.abs(window.scrollY-n[1])<1&&Math.abs(window.scrollX-n[0])<1&&(n=null)),t.size===0&&n===null),i=()=>{if(o())return;let r=new MutationObserver(()=>{o()&&r.disconnect()});r.observe(document.documentElement,{childList:!0,subtree:!0}),setTimeout(()=>r.disconnect(),ht)};document.readyState==="loading"?document.addEventListener("DOMContentLoaded",i,{once:!0}):i()}function re(e){if(!w||!w.values||!(e in w.values))return;let t=w.values[e];delete w.values[e];try{W.get(e).setter(t)}catch(n){console.error(`State.Preserve: the setter of '${e}' failed:`,n)}}function St(e,t,n){return W.set(e,{getter:t,setter:n}),re(e),()=>{W.delete(e)}}function Et(){return oe=!0,W.clear(),w={},s(":wails:StateSave",[null])}s(":wails:StateRestore").then(e=>{w=e||{},e&&(bt(e),W.forEach((t,n)=>re(n)))}).catch(()=>{w={}});setInterval(N,vt);window.addEventListener("pagehide",N);window.addEventListener("beforeunload",N);function se(e){let{anchor:t,...n}=e||{},o=null;if(t&&t.getBoundingClientRect){let i=t.getBoundingClientRect();o={x:Math.round(i.left),y:Math.round(i.top),width:Math.round(i.width),height:Math.round(i.height)}}return s(":wails:Share",[{...n,anchor:o}])}var G={};g(G,{DownloadCancel:()=>yt,OnDownload:()=>kt});function kt(e){return m("wails:download",e)}function yt(e){window.WailsInvoke("GC"+e)}function It(e,t){let n={items:t.items||[],nextCursor:t.nextCursor||"",total:t.total,hasNext:!!t.nextCursor,next(){return n.hasNext?U(e,n.nextCursor):Promise.reject(new Error("this is the last page"))},async all(){let o=[...n.items],i=n;for(;i.hasNext;)i=await i.next(),o.push(...i.items);return o},async*[Symbol.asyncIterator](){let o=n;for(;;){if(yield*o.items,!o.hasNext)return;o=await o.next()}}};return n}function U(e,t){return e(t||"").then(n=>It(e,n||{}))}var S={};function ae(e,t){return S[e]=t,()=>{S[e]===t&&delete S[e]}}function le(e){delete S[e]}function ce(e,t,n){let o="wails:handler:"+e,i=S[t];if(!i){v(o,{notFound:!0});return}new Promise(r=>r(i(...n))).then(r=>v(o,{result:r===void 0?null:r})).catch(r=>v(o,{error:r instanceof Error?r.message:String(r)}))}function Ct(){window.WailsInvoke("Q")}function Tt(){window.WailsInvoke("S")}function Ot(){window.WailsInvoke("H")}function Lt(){return s(":wails:Environment")}function Mt(){return s(":wails:Handshake")}window.runtime={...P,...R,...H,...z,...B,...F,...G,State:j,Share:se,EventsOn:m,EventsOnce:V,EventsOnMultiple:k,EventsEmit:v,EventsOff:y,HandlerRegister:ae,HandlerUnregister:le,Environment:Lt,Handshake:Mt,Show:Tt,Hide:Ot,Quit:Ct};window.wails={Callback:Z,CallHandler:ce,EventsNotify:$,SetBindings:X,Paginate:U,eventListeners:u,callbacks:h,flags:{disableScrollbarDrag:!1,disableDefaultContextMenu:!1,enableResize:!1,defaultCursor:null,borderThickness:6,shouldDrag:!1,deferDragToMouseMove:!0,cssDragProperty:"--wails-draggable",cssDragValue:"drag",cssDropProperty:"--wails-drop-target",cssDropValue:"drop",enableWailsDragAndDrop:!1,enableZoomShortcuts:!1}};window.wailsbindings&&(window.wails.SetBindings(window.wailsbindings),delete window.wails.SetBindings);delete window.wailsbindings;var Pt=function(e){var t=window.getComputedStyle(e.target).getPropertyValue(window.wails.flags.cssDragProperty);return t&&(t=t.trim()),!(t!==window.wails.flags.cssDragValue||e.buttons!==1||e.detail!==1)};window.wails.setCSSDragProperties=function(e,t){window.wails.flags.cssDragProperty=e,window.wails.flags.cssDragValue=t};window.wails.setCSSDropProperties=function(e,t){window.wails.flags.cssDropProperty=e,window.wails.flags.cssDropValue=t};window.wails.setMemoryLimit=function(e){if(!window.performance||!window.performance.memory||e<=0)return;let t=e*1024*1024,n=setInterval(()=>{window.performance.memory.usedJSHeapSize>t&&(clearInterval(n),window.WailsInvoke("wails:memorylimit"))},5e3)};window.addEventListener("mousedown",e=>{if(window.wails.flags.resizeEdge){window.WailsInvoke("resize:"+window.wails.flags.resizeEdge),e.preventDefault();return}if(Pt(e)){if(window.wails.flags.disableScrollbarDrag&&(e.offsetX>e.target.clientWidth||e.offsetY>e.target.clientHeight))return;window.wails.flags.deferDragToMouseMove?window.wails.flags.shouldDrag=!0:(e.preventDefault(),window.WailsInvoke("drag"));return}else window.wails.flags.shouldDrag=!1});window.addEventListener("mouseup",()=>{window.wails.flags.shouldDrag=!1});function p(e){document.documentElement.style.cursor=e||window.wails.flags.defaultCursor,window.wails.flags.resizeEdge=e}window.addEventListener("mousemove",function(e){if(window.wails.flags.shouldDrag&&(window.wails.flags.shouldDrag=!1,(e.buttons!==void 0?e.buttons:e.which)>0)){window.WailsInvoke("drag");return}if(!window.wails.flags.enableResize)return;window.wails.flags.defaultCursor==null&&(window.wails.flags.defaultCursor=document.documentElement.style.cursor),window.outerWidth-e.clientX<window.wails.flags.borderThickness&&window.outerHeight-e.clientY<window.wails.flags.borderThickness&&(document.documentElement.style.cursor="se-resize");let t=window.outerWidth-e.clientX<window.wails.flags.borderThickness,n=e.clientX<window.wails.flags.borderThickness,o=e.clientY<window.wails.flags.borderThickness,i=window.outerHeight-e.clientY<window.wails.flags.borderThickness;!n&&!t&&!o&&!i&&window.wails.flags.resizeEdge!==void 0?p():t&&i?p("se-resize"):n&&i?p("sw-resize"):n&&o?p("nw-resize"):o&&t?p("ne-resize"):n?p("w-resize"):o?p("n-resize"):i?p("s-resize"):t&&p("e-resize")});window.addEventListener("keydown",function(e){if(!(!window.wails.flags.enableZoomShortcuts||!(e.ctrlKey||e.metaKey)||e.altKey)){switch(e.key){case"=":case"+":window.WailsInvoke("Wo+");break;case"-":window.WailsInvoke("Wo-");break;case"0":window.WailsInvoke("Wo:1");break;default:return}e.preventDefault()}});window.addEventListener("contextmenu",function(e){window.wails.flags.disableDefaultContextMenu?e.preventDefault():te(e)});window.WailsInvoke("runtime:ready");})();
@@ -32,6 +32,60 @@ export interface EnvironmentInfo {
     arch: string;
 }
 
+// The state of the runtime bridge
+export interface RuntimeInfo {
+    // False if the page is not running in a Wails application or the bridge didn't answer
+    available: boolean;
+    // The version of the Wails module the application has been built with
+    version: string;
+    // The APIVersion application option
+    apiVersion: string;
+    // The bound structs that can be called, EG: "main.App"
+    services: string[];
+    // Why the runtime bridge is not available
+    reason?: string;
+}
+
+export interface ShareOptions {
+    title?: string;
+    text?: string;
+    url?: string;
+    // Absolute paths of the shared files
+    files?: string[];
+    // The element the share sheet is positioned relative to on macOS
+    anchor?: Element;
+}
+
+export interface Download {
+    id: string;
+    url: string;
+    path: string;
+    state: "started" | "progress" | "completed" | "cancelled" | "failed";
+    receivedBytes: number;
+    // -1 if the size of the download isn't known
+    totalBytes: number;
+    error?: string;
+}
+
+// The JSON of a pagination.Page of a bound method
+export interface PageData<T> {
+    items: T[];
+    // The cursor of the next page, empty for the last page
+    nextCursor: string;
+    // The number of items of the result set, -1 if unknown
+    total: number;
+}
+
+// A page of a bound method that returns a pagination.Page and takes the cursor as last argument.
+// Iterating it with for await iterates the items of this and the following pages, which are fetched lazily.
+export interface Page<T> extends PageData<T>, AsyncIterable<T> {
+    hasNext: boolean;
+    // Fetches the next page
+    next(): Promise<Page<T>>;
+    // Fetches the remaining pages and returns the items of this and the following pages
+    all(): Promise<T[]>;
+}
+
 // [EventsEmit](https://wails.io/docs/reference/runtime/events#eventsemit)
 // emits the given event. Optional data may be passed with the event.
 // This will trigger any event listeners.
@@ -56,6 +110,14 @@ export function EventsOff(eventName: string, ...additionalEventNames: string[]):
 // unregisters all listeners.
 export function EventsOffAll(): void;
 
+// [HandlerRegister](https://wails.io/docs/reference/runtime/handlers#handlerregister)
+// registers a handler that Go can call with HandlerCall. It returns a function to unregister the handler.
+export function HandlerRegister(name: string, handler: (...args: any) => any): () => void;
+
+// [HandlerUnregister](https://wails.io/docs/reference/runtime/handlers#handlerunregister)
+// unregisters the handler with the given name.
+export function HandlerUnregister(name: string): void;
+
 // [LogPrint](https://wails.io/docs/reference/runtime/log#logprint)
 // logs the given message as a raw message
 export function LogPrint(message: string): void;
@@ -85,6 +147,24 @@ export function LogInfo(message: string): void;
 // logs the given message at the `warning` log level.
 export function LogWarning(message: string): void;
 
+// A structured logger of the [Log](https://wails.io/docs/reference/runtime/log#structured-logging) API.
+// The fields are sent with the page and the page load ID to the logger of the application.
+export interface StructuredLog {
+    Trace(message: string, fields?: Record<string, any>): void;
+    Debug(message: string, fields?: Record<string, any>): void;
+    Info(message: string, fields?: Record<string, any>): void;
+    Warn(message: string, fields?: Record<string, any>): void;
+    Error(message: string, fields?: Record<string, any>): void;
+    // Returns a logger that adds the fields to its logs
+    With(fields: Record<string, any>): StructuredLog;
+    // Returns a logger that adds the correlation ID to its logs as the correlationId field
+    WithCorrelationID(id: string): StructuredLog;
+}
+
+// [Log](https://wails.io/docs/reference/runtime/log#structured-logging)
+// sends structured logs with fields to the logger of the application.
+export const Log: StructuredLog;
+
 // [WindowReload](https://wails.io/docs/reference/runtime/window#windowreload)
 // Forces a reload by the main application as well as connected browsers.
 export function WindowReload(): void;
@@ -93,25 +173,38 @@ export function WindowReload(): void;
 // Reloads the application frontend.
 export function WindowReloadApp(): void;
 
+// [WindowRecycle](https://wails.io/docs/reference/runtime/window#windowrecycle)
+// Loads the application frontend again. On Linux the content process of the webview is terminated first.
+export function WindowRecycle(): void;
+
 // [WindowSetAlwaysOnTop](https://wails.io/docs/reference/runtime/window#windowsetalwaysontop)
 // Sets the window AlwaysOnTop or not on top.
 export function WindowSetAlwaysOnTop(b: boolean): void;
 
+// [WindowSetSkipTaskbar](https://wails.io/docs/reference/runtime/window#windowsetskiptaskbar)
+// Hides the window from the taskbar and the window switcher or shows it again.
+export function WindowSetSkipTaskbar(skip: boolean): void;
+
 // [WindowSetSystemDefaultTheme](https://wails.io/docs/next/reference/runtime/window#windowsetsystemdefaulttheme)
-// *Windows only*
 // Sets window theme to system default (dark/light).
 export function WindowSetSystemDefaultTheme(): void;
 
 // [WindowSetLightTheme](https://wails.io/docs/next/reference/runtime/window#windowsetlighttheme)
-// *Windows only*
 // Sets window to light theme.
 export function WindowSetLightTheme(): void;
 
 // [WindowSetDarkTheme](https://wails.io/docs/next/reference/runtime/window#windowsetdarktheme)
-// *Windows only*
 // Sets window to dark theme.
 export function WindowSetDarkTheme(): void;
 
+// [WindowColorScheme](https://wails.io/docs/reference/runtime/window#windowcolorscheme)
+// Returns the color scheme of the window, which prefers-color-scheme reflects.
+export function WindowColorScheme(): "dark" | "light";
+
+// [OnColorSchemeChange](https://wails.io/docs/reference/runtime/window#oncolorschemechange)
+// Registers a callback for changes of the color scheme of the window. Returns a function to cancel the listener.
+export function OnColorSchemeChange(callback: (scheme: "dark" | "light") => void): () => void;
+
 // [WindowCenter](https://wails.io/docs/reference/runtime/window#windowcenter)
 // Centers the window on the monitor the window is currently on.
 export function WindowCenter(): void;
@@ -202,6 +295,30 @@ export function WindowIsNormal(): Promise<boolean>;
 // Sets the background colour of the window to the given RGBA colour definition. This colour will show through for all transparent pixels.
 export function WindowSetBackgroundColour(R: number, G: number, B: number, A: number): void;
 
+// [WindowTrackNativeView](https://wails.io/docs/reference/runtime/window#windowtracknativeview)
+// Keeps the native view that is embedded with WindowEmbedNativeView in Go over the placeholder element. Call the returned function to stop tracking the element.
+export function WindowTrackNativeView(id: string, element: Element): () => void;
+
+// [WindowSetZoom](https://wails.io/docs/reference/runtime/window#windowsetzoom)
+// Sets the zoom factor of the page, e.g. 1.5 for 150%. The factor is limited by the Zoom option.
+export function WindowSetZoom(factor: number): void;
+
+// [WindowGetZoom](https://wails.io/docs/reference/runtime/window#windowgetzoom)
+// Returns the zoom factor of the page.
+export function WindowGetZoom(): Promise<number>;
+
+// [WindowZoomIn](https://wails.io/docs/reference/runtime/window#windowzoomin)
+// Zooms the page in to the next zoom level.
+export function WindowZoomIn(): void;
+
+// [WindowZoomOut](https://wails.io/docs/reference/runtime/window#windowzoomout)
+// Zooms the page out to the previous zoom level.
+export function WindowZoomOut(): void;
+
+// [WindowZoomReset](https://wails.io/docs/reference/runtime/window#windowzoomreset)
+// Resets the zoom factor of the page to 1.
+export function WindowZoomReset(): void;
+
 // [ScreenGetAll](https://wails.io/docs/reference/runtime/window#screengetall)
 // Gets the all screens. Call this anew each time you want to refresh data from the underlying windowing system.
 export function ScreenGetAll(): Promise<Screen[]>;
@@ -214,6 +331,14 @@ export function BrowserOpenURL(url: string): void;
 // Returns information about the environment
 export function Environment(): Promise<EnvironmentInfo>;
 
+// [RuntimeReady](https://wails.io/docs/reference/runtime/intro#runtimeready)
+// Resolves with the state of the runtime bridge, it never rejects
+export function RuntimeReady(timeout?: number): Promise<RuntimeInfo>;
+
+// [OnRuntimeDegraded](https://wails.io/docs/reference/runtime/intro#onruntimedegraded)
+// Calls the callback if the runtime bridge is not available. Resolves with true if it is available.
+export function OnRuntimeDegraded(callback: (info: RuntimeInfo) => void): Promise<boolean>;
+
 // [Quit](https://wails.io/docs/reference/runtime/intro#quit)
 // Quits the application.
 export function Quit(): void;
@@ -234,6 +359,18 @@ export function ClipboardGetText(): Promise<string>;
 // Sets a text on the clipboard
 export function ClipboardSetText(text: string): Promise<boolean>;
 
+// [Share](https://wails.io/docs/reference/runtime/share#share)
+// Shows the share sheet of the platform with the text, the URL and the files of the options.
+export function Share(options: ShareOptions): Promise<void>;
+
+// [OnDownload](https://wails.io/docs/reference/runtime/downloads#ondownload)
+// Registers a callback for the changes of the downloads of the webview. It returns a function to unregister the callback.
+export function OnDownload(callback: (download: Download) => void): () => void;
+
+// [DownloadCancel](https://wails.io/docs/reference/runtime/downloads#downloadcancel)
+// Cancels the download with the id in flight.
+export function DownloadCancel(id: string): void;
+
 // [OnFileDrop](https://wails.io/docs/reference/runtime/draganddrop#onfiledrop)
 // OnFileDrop listens to drag and drop events and calls the callback with the coordinates of the drop and an array of path strings.
 export function OnFileDrop(callback: (x: number, y: number ,paths: string[]) => void, useDropTarget: boolean) :void
@@ -246,4 +383,19 @@ export function OnFileDropOff() :void
 export function CanResolveFilePaths(): boolean;
 
 // Resolves file paths for an array of files
-export function ResolveFilePaths(files: File[]): void
\ No newline at end of file
+export function ResolveFilePaths(files: File[]): void
+// [WebSocketURL](https://wails.io/docs/reference/runtime/intro#websocketurl)
+// Returns the URL of a WebSocket handler of the AssetServer
+export function WebSocketURL(path: string): string;
+
+// [State](https://wails.io/docs/reference/runtime/state)
+// Preserves scroll positions, form values and application state across reloads of the page.
+export const State: {
+    // [Preserve](https://wails.io/docs/reference/runtime/state#preserve)
+    // Calls the getter before the page reloads and passes the value to the setter after the reload.
+    // Returns a function that stops preserving the state.
+    Preserve<T>(key: string, getter: () => T, setter: (value: T) => void): () => void;
+    // [Clear](https://wails.io/docs/reference/runtime/state#clear)
+    // Discards the snapshot and stops taking snapshots of the page.
+    Clear(): Promise<void>;
+};
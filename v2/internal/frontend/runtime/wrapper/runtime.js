@@ -36,6 +36,31 @@ export function LogFatal(message) {
     window.runtime.LogFatal(message);
 }
 
+function wrapLog(log) {
+    return {
+        Trace: (message, fields) => log.Trace(message, fields),
+        Debug: (message, fields) => log.Debug(message, fields),
+        Info: (message, fields) => log.Info(message, fields),
+        Warn: (message, fields) => log.Warn(message, fields),
+        Error: (message, fields) => log.Error(message, fields),
+        With: (fields) => wrapLog(log.With(fields)),
+        WithCorrelationID: (id) => wrapLog(log.WithCorrelationID(id)),
+    };
+}
+
+/**
+ * Log sends structured logs with fields to the logger of the application.
+ */
+export const Log = {
+    Trace: (message, fields) => window.runtime.Log.Trace(message, fields),
+    Debug: (message, fields) => window.runtime.Log.Debug(message, fields),
+    Info: (message, fields) => window.runtime.Log.Info(message, fields),
+    Warn: (message, fields) => window.runtime.Log.Warn(message, fields),
+    Error: (message, fields) => window.runtime.Log.Error(message, fields),
+    With: (fields) => wrapLog(window.runtime.Log.With(fields)),
+    WithCorrelationID: (id) => wrapLog(window.runtime.Log.WithCorrelationID(id)),
+};
+
 export function EventsOnMultiple(eventName, callback, maxCallbacks) {
     return window.runtime.EventsOnMultiple(eventName, callback, maxCallbacks);
 }
@@ -57,6 +82,14 @@ export function EventsEmit(eventName) {
     return window.runtime.EventsEmit.apply(null, args);
 }
 
+export function HandlerRegister(name, handler) {
+    return window.runtime.HandlerRegister(name, handler);
+}
+
+export function HandlerUnregister(name) {
+    window.runtime.HandlerUnregister(name);
+}
+
 export function WindowReload() {
     window.runtime.WindowReload();
 }
@@ -65,10 +98,18 @@ export function WindowReloadApp() {
     window.runtime.WindowReloadApp();
 }
 
+export function WindowRecycle() {
+    window.runtime.WindowRecycle();
+}
+
 export function WindowSetAlwaysOnTop(b) {
     window.runtime.WindowSetAlwaysOnTop(b);
 }
 
+export function WindowSetSkipTaskbar(skip) {
+    window.runtime.WindowSetSkipTaskbar(skip);
+}
+
 export function WindowSetSystemDefaultTheme() {
     window.runtime.WindowSetSystemDefaultTheme();
 }
@@ -81,6 +122,14 @@ export function WindowSetDarkTheme() {
     window.runtime.WindowSetDarkTheme();
 }
 
+export function WindowColorScheme() {
+    return window.runtime.WindowColorScheme();
+}
+
+export function OnColorSchemeChange(callback) {
+    return window.runtime.OnColorSchemeChange(callback);
+}
+
 export function WindowCenter() {
     window.runtime.WindowCenter();
 }
@@ -161,6 +210,30 @@ export function WindowSetBackgroundColour(R, G, B, A) {
     window.runtime.WindowSetBackgroundColour(R, G, B, A);
 }
 
+export function WindowTrackNativeView(id, element) {
+    return window.runtime.WindowTrackNativeView(id, element);
+}
+
+export function WindowSetZoom(factor) {
+    window.runtime.WindowSetZoom(factor);
+}
+
+export function WindowGetZoom() {
+    return window.runtime.WindowGetZoom();
+}
+
+export function WindowZoomIn() {
+    window.runtime.WindowZoomIn();
+}
+
+export function WindowZoomOut() {
+    window.runtime.WindowZoomOut();
+}
+
+export function WindowZoomReset() {
+    window.runtime.WindowZoomReset();
+}
+
 export function ScreenGetAll() {
     return window.runtime.ScreenGetAll();
 }
@@ -181,6 +254,59 @@ export function Environment() {
     return window.runtime.Environment();
 }
 
+let runtimeReady = null;
+
+function degradedRuntime(reason) {
+    return {available: false, version: "", apiVersion: "", services: [], reason};
+}
+
+/**
+ * RuntimeReady resolves with the state of the runtime bridge. It never rejects: if the page is not running in a
+ * Wails application, EG: the assets have been opened in a plain browser, or the bridge doesn't answer within the
+ * timeout, EG: for a document of an isolated origin, `available` is false and `reason` says why.
+ *
+ * @export
+ * @param {number} [timeout=3000] - Milliseconds to wait for the bridge to answer
+ * @return {Promise<{available: boolean, version: string, apiVersion: string, services: string[], reason?: string}>}
+ */
+export function RuntimeReady(timeout) {
+    if (runtimeReady) {
+        return runtimeReady;
+    }
+    if (!window.runtime || !window.runtime.Handshake) {
+        runtimeReady = Promise.resolve(degradedRuntime("the Wails runtime is not loaded"));
+        return runtimeReady;
+    }
+    runtimeReady = new Promise((resolve) => {
+        const timer = setTimeout(() => resolve(degradedRuntime("the runtime bridge didn't answer")), timeout || 3000);
+        window.runtime.Handshake().then((info) => {
+            clearTimeout(timer);
+            resolve({...info, available: true});
+        }, (error) => {
+            clearTimeout(timer);
+            resolve(degradedRuntime(String(error)));
+        });
+    });
+    return runtimeReady;
+}
+
+/**
+ * OnRuntimeDegraded calls the callback with the state of the runtime bridge if it is not available, so the frontend
+ * can render a fallback.
+ *
+ * @export
+ * @param {function} callback
+ * @return {Promise<boolean>} - Resolves with true if the runtime bridge is available
+ */
+export function OnRuntimeDegraded(callback) {
+    return RuntimeReady().then((info) => {
+        if (!info.available) {
+            callback(info);
+        }
+        return info.available;
+    });
+}
+
 export function Quit() {
     window.runtime.Quit();
 }
@@ -201,6 +327,18 @@ export function ClipboardSetText(text) {
     return window.runtime.ClipboardSetText(text);
 }
 
+export function Share(options) {
+    return window.runtime.Share(options);
+}
+
+export function OnDownload(callback) {
+    return window.runtime.OnDownload(callback);
+}
+
+export function DownloadCancel(id) {
+    window.runtime.DownloadCancel(id);
+}
+
 /**
  * Callback for OnFileDrop returns a slice of file path strings when a drop is finished.
  *
@@ -235,4 +373,20 @@ export function CanResolveFilePaths() {
 
 export function ResolveFilePaths(files) {
     return window.runtime.ResolveFilePaths(files);
-}
\ No newline at end of file
+}
+
+export function WebSocketURL(path) {
+    return window.runtime.WebSocketURL(path);
+}
+
+/**
+ * State preserves scroll positions, form values and application state across reloads of the page.
+ */
+export const State = {
+    Preserve(key, getter, setter) {
+        return window.runtime.State.Preserve(key, getter, setter);
+    },
+    Clear() {
+        return window.runtime.State.Clear();
+    },
+};
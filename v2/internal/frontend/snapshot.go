@@ -0,0 +1,28 @@
+package frontend
+
+import (
+	"bytes"
+	"errors"
+	"os"
+)
+
+// pngSignature are the first bytes of every PNG
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// NewSnapshotFile returns the path of an empty temporary file the webview writes the snapshot to
+func NewSnapshotFile() (string, error) {
+	return newTempFile("wails-*.png")
+}
+
+// ReadSnapshotFile returns the PNG that has been written to the file of NewSnapshotFile and removes the file
+func ReadSnapshotFile(path string) ([]byte, error) {
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errors.New("the webview has not written a PNG")
+	}
+	return data, nil
+}
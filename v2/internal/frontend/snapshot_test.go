@@ -0,0 +1,42 @@
+package frontend
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSnapshotFile(t *testing.T) {
+	path, err := NewSnapshotFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Ext(path) != ".png" {
+		t.Errorf("NewSnapshotFile() = %s, want a .png file", path)
+	}
+	if _, err := ReadSnapshotFile(path); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the file has not been removed: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	path = filepath.Join(t.TempDir(), "snapshot.png")
+	if err := os.WriteFile(path, buffer.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ReadSnapshotFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, buffer.Bytes()) {
+		t.Error("ReadSnapshotFile() returned different data")
+	}
+}
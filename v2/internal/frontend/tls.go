@@ -0,0 +1,185 @@
+package frontend
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// TLSPolicy decides about the certificates of servers that aren't trusted by the system and provides the client
+// certificates
+type TLSPolicy struct {
+	options *options.TLS
+	trusted map[string][]*x509.Certificate
+}
+
+// NewTLSPolicy creates the policy of the application
+func NewTLSPolicy(tlsOptions *options.TLS) *TLSPolicy {
+	result := &TLSPolicy{
+		options: tlsOptions,
+		trusted: map[string][]*x509.Certificate{},
+	}
+	if tlsOptions == nil {
+		return result
+	}
+	for host, certificates := range tlsOptions.TrustedCertificates {
+		host = strings.ToLower(host)
+		result.trusted[host] = append(result.trusted[host], certificates...)
+	}
+	return result
+}
+
+// Enabled reports if the default TLS handling of the webview is customised
+func (p *TLSPolicy) Enabled() bool {
+	return p.options != nil
+}
+
+// HandlesClientCertificates reports if the client certificates are provided by the application
+func (p *TLSPolicy) HandlesClientCertificates() bool {
+	return p.options != nil && p.options.OnClientCertificate != nil
+}
+
+// TrustedCertificates returns the certificates that are trusted per host, EG: to register them with the webview up
+// front
+func (p *TLSPolicy) TrustedCertificates() map[string][]*x509.Certificate {
+	return p.trusted
+}
+
+// AcceptServer reports if the chain of a server that isn't trusted by the system is accepted for the host
+func (p *TLSPolicy) AcceptServer(host string, chain []*x509.Certificate) bool {
+	if p.options == nil || len(chain) == 0 {
+		return false
+	}
+	if p.trustedChain(host, chain) {
+		return true
+	}
+	if p.options.OnServerTrust == nil {
+		return false
+	}
+	return p.options.OnServerTrust(options.ServerTrustRequest{Host: host, Chain: chain})
+}
+
+// trustedChain reports if the leaf of the chain is a trusted certificate of the host or is issued by one of them. The
+// other certificates of the chain are sent by the server, so they are only used as intermediates.
+func (p *TLSPolicy) trustedChain(host string, chain []*x509.Certificate) bool {
+	trusted := p.trusted[strings.ToLower(host)]
+	if len(trusted) == 0 {
+		return false
+	}
+	roots := x509.NewCertPool()
+	for _, certificate := range trusted {
+		if certificate.Equal(chain[0]) {
+			return true
+		}
+		roots.AddCert(certificate)
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range chain[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err == nil
+}
+
+// ClientCertificate returns the client certificate for the server or nil for the default handling
+func (p *TLSPolicy) ClientCertificate(host string, port int, installed []*x509.Certificate) *options.ClientCertificate {
+	if !p.HandlesClientCertificates() {
+		return nil
+	}
+	return p.options.OnClientCertificate(options.ClientCertificateRequest{
+		Host:      host,
+		Port:      port,
+		Installed: installed,
+	})
+}
+
+// ParseCertificatesPEM parses the certificates of the PEM data, EG: the chain of a server
+func ParseCertificatesPEM(data []byte) ([]*x509.Certificate, error) {
+	var result []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, certificate)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	return result, nil
+}
+
+// ClientCertificatePEM decodes the PKCS12 of the client certificate. It returns the PEM of the certificate, followed
+// by its chain and its PKCS8 private key, and the certificate itself.
+func ClientCertificatePEM(certificate *options.ClientCertificate) ([]byte, *x509.Certificate, error) {
+	blocks, err := pkcs12.ToPEM(certificate.PKCS12, certificate.Password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode the client certificate: %w", err)
+	}
+	var key crypto.Signer
+	var chain []*x509.Certificate
+	for _, block := range blocks {
+		switch block.Type {
+		case "CERTIFICATE":
+			parsed, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			chain = append(chain, parsed)
+		case "PRIVATE KEY":
+			// pkcs12 labels the PKCS1 and EC keys as PRIVATE KEY
+			if rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+				key = rsaKey
+			} else if ecKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				key = ecKey
+			} else {
+				return nil, nil, errors.New("unsupported private key of the client certificate")
+			}
+		}
+	}
+	if key == nil || len(chain) == 0 {
+		return nil, nil, errors.New("the client certificate has no certificate or private key")
+	}
+
+	// The certificate of the key comes first
+	leaf := -1
+	for index, candidate := range chain {
+		if publicKey, ok := candidate.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); ok && publicKey.Equal(key.Public()) {
+			leaf = index
+			break
+		}
+	}
+	if leaf == -1 {
+		return nil, nil, errors.New("the client certificate has no certificate for its private key")
+	}
+	chain[0], chain[leaf] = chain[leaf], chain[0]
+
+	var result []byte
+	for _, certificate := range chain {
+		result = append(result, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})...)
+	}
+	keyData, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	result = append(result, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyData})...)
+	return result, chain[0], nil
+}
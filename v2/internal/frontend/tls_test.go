@@ -0,0 +1,175 @@
+package frontend
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func selfSigned(t *testing.T, name string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	data, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+func TestTLSPolicy(t *testing.T) {
+	intranet := selfSigned(t, "intranet.example.com")
+	other := selfSigned(t, "other.example.com")
+
+	disabled := NewTLSPolicy(nil)
+	if disabled.Enabled() || disabled.AcceptServer("intranet.example.com", []*x509.Certificate{intranet}) {
+		t.Error("expected no certificate to be accepted without options")
+	}
+
+	var requests []options.ServerTrustRequest
+	policy := NewTLSPolicy(&options.TLS{
+		TrustedCertificates: map[string][]*x509.Certificate{
+			"Intranet.example.com": {intranet},
+		},
+		OnServerTrust: func(request options.ServerTrustRequest) bool {
+			requests = append(requests, request)
+			return request.Host == "accepted.example.com"
+		},
+	})
+	if !policy.AcceptServer("intranet.example.com", []*x509.Certificate{intranet}) {
+		t.Error("expected the trusted certificate to be accepted")
+	}
+	if len(requests) != 0 {
+		t.Error("expected OnServerTrust not to be called for trusted certificates")
+	}
+	if policy.AcceptServer("intranet.example.com", []*x509.Certificate{other}) {
+		t.Error("expected an unknown certificate to be rejected")
+	}
+	if !policy.AcceptServer("accepted.example.com", []*x509.Certificate{other}) {
+		t.Error("expected the decision of OnServerTrust")
+	}
+	if len(requests) != 2 || requests[1].Chain[0] != other {
+		t.Errorf("unexpected requests %v", requests)
+	}
+	if policy.HandlesClientCertificates() || policy.ClientCertificate("intranet.example.com", 443, nil) != nil {
+		t.Error("expected the default handling of client certificates")
+	}
+}
+
+// issued returns a CA and a certificate for the host that is issued by it
+func issued(t *testing.T, host string) (*x509.Certificate, *x509.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Intranet CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caData, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	data, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca, certificate
+}
+
+func TestTLSPolicyChain(t *testing.T) {
+	pinned := selfSigned(t, "intranet.example.com")
+	ca, server := issued(t, "ca.example.com")
+	policy := NewTLSPolicy(&options.TLS{
+		TrustedCertificates: map[string][]*x509.Certificate{
+			"intranet.example.com": {pinned},
+			"ca.example.com":       {ca},
+			"other.example.com":    {ca},
+		},
+	})
+
+	attacker := selfSigned(t, "intranet.example.com")
+	if policy.AcceptServer("intranet.example.com", []*x509.Certificate{attacker, pinned}) {
+		t.Error("expected a chain with the pinned certificate appended to the certificate of an attacker to be rejected")
+	}
+	if !policy.AcceptServer("ca.example.com", []*x509.Certificate{server}) {
+		t.Error("expected a certificate issued by the trusted CA to be accepted")
+	}
+	if !policy.AcceptServer("ca.example.com", []*x509.Certificate{server, ca}) {
+		t.Error("expected a chain with the trusted CA to be accepted")
+	}
+	if policy.AcceptServer("other.example.com", []*x509.Certificate{server}) {
+		t.Error("expected a certificate of another host to be rejected")
+	}
+	if policy.AcceptServer("ca.example.com", []*x509.Certificate{attacker, server, ca}) {
+		t.Error("expected a leaf that isn't issued by the trusted CA to be rejected")
+	}
+}
+
+func TestParseCertificatesPEM(t *testing.T) {
+	first := selfSigned(t, "first.example.com")
+	second := selfSigned(t, "second.example.com")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: first.Raw})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: second.Raw})...)
+
+	chain, err := ParseCertificatesPEM(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 || !chain[0].Equal(first) || !chain[1].Equal(second) {
+		t.Error("expected both certificates in order")
+	}
+	if _, err := ParseCertificatesPEM([]byte("invalid")); err == nil {
+		t.Error("expected an error without certificates")
+	}
+	if _, _, err := ClientCertificatePEM(&options.ClientCertificate{PKCS12: []byte("invalid")}); err == nil {
+		t.Error("expected an error for an invalid PKCS12")
+	}
+}
@@ -0,0 +1,105 @@
+package frontend
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// UserContentItem is user content that has been added to a window
+type UserContentItem struct {
+	ID string
+	options.UserContent
+}
+
+// UserContentManager keeps the user content of a window in the order it has been added
+type UserContentManager struct {
+	lock   sync.Mutex
+	nextID int
+	items  []UserContentItem
+}
+
+func NewUserContentManager() *UserContentManager {
+	return &UserContentManager{}
+}
+
+// Add validates the content and adds it after all other content
+func (m *UserContentManager) Add(content options.UserContent) (UserContentItem, error) {
+	if (content.Script == "") == (content.Stylesheet == "") {
+		return UserContentItem{}, errors.New("user content requires either a script or a stylesheet")
+	}
+	if content.InjectionTime != options.InjectAtDocumentStart && content.InjectionTime != options.InjectAtDocumentEnd {
+		return UserContentItem{}, errors.New("invalid injection time of user content")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.nextID++
+	item := UserContentItem{
+		ID:          "usercontent-" + strconv.Itoa(m.nextID),
+		UserContent: content,
+	}
+	m.items = append(m.items, item)
+	return item, nil
+}
+
+// Remove removes the content with the given id. It returns false if there is no such content.
+func (m *UserContentManager) Remove(id string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for index, item := range m.items {
+		if item.ID == id {
+			m.items = append(m.items[:index:index], m.items[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Items returns the current content in the order it has been added
+func (m *UserContentManager) Items() []UserContentItem {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]UserContentItem(nil), m.items...)
+}
+
+// UserContentScript returns the content as a script, for webviews that only support user scripts. Stylesheets are
+// added as style elements. If emulate is set, the script also takes care of the injection time and the frames, for
+// webviews that run every user script at the start of every frame. The emulated scripts are run by a script element,
+// so their top-level declarations stay global.
+func UserContentScript(item UserContentItem, emulate bool) string {
+	source := item.Script
+	if item.Stylesheet != "" {
+		css, _ := json.Marshal(item.Stylesheet)
+		source = "(function(){var s=document.createElement('style');s.textContent=" + string(css) + ";" +
+			"var a=function(){(document.head||document.documentElement).appendChild(s);};" +
+			"if(document.documentElement){a();}else{new MutationObserver(function(m,o){if(document.documentElement){o.disconnect();a();}}).observe(document,{childList:true});}})();"
+	}
+	if !emulate || item.AllFrames && item.InjectionTime == options.InjectAtDocumentStart {
+		return source
+	}
+
+	var result strings.Builder
+	result.WriteString("(function(){")
+	if !item.AllFrames {
+		result.WriteString("if(window.top!==window){return;}")
+	}
+	if item.Stylesheet != "" {
+		result.WriteString("var run=function(){\n" + source + "\n};")
+	} else {
+		script, _ := json.Marshal(source)
+		result.WriteString("var run=function(){var s=document.createElement('script');s.textContent=" + string(script) + ";" +
+			"(document.head||document.documentElement).appendChild(s);s.remove();};")
+	}
+	if item.InjectionTime == options.InjectAtDocumentEnd {
+		result.WriteString("if(document.readyState==='loading'){document.addEventListener('DOMContentLoaded',run);}else{run();}")
+	} else {
+		result.WriteString("if(document.documentElement){run();}else{new MutationObserver(function(m,o){if(document.documentElement){o.disconnect();run();}}).observe(document,{childList:true});}")
+	}
+	result.WriteString("})();\n")
+	return result.String()
+}
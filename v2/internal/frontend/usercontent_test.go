@@ -0,0 +1,115 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestUserContentManager(t *testing.T) {
+	m := NewUserContentManager()
+
+	_, err := m.Add(options.UserContent{})
+	if err == nil {
+		t.Error("expected an error for content without a script and a stylesheet")
+	}
+	_, err = m.Add(options.UserContent{Script: "a", Stylesheet: "b"})
+	if err == nil {
+		t.Error("expected an error for content with a script and a stylesheet")
+	}
+
+	first, err := m.Add(options.UserContent{Script: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := m.Add(options.UserContent{Stylesheet: "body{}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	third, err := m.Add(options.UserContent{Script: "third"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID == second.ID || second.ID == third.ID {
+		t.Errorf("expected unique ids, got %s, %s and %s", first.ID, second.ID, third.ID)
+	}
+
+	if !m.Remove(second.ID) {
+		t.Errorf("expected %s to be removed", second.ID)
+	}
+	if m.Remove(second.ID) {
+		t.Errorf("expected %s to be removed only once", second.ID)
+	}
+
+	items := m.Items()
+	if len(items) != 2 || items[0].ID != first.ID || items[1].ID != third.ID {
+		t.Errorf("unexpected items %+v", items)
+	}
+}
+
+func TestUserContentScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		content options.UserContent
+		emulate bool
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "native script",
+			content: options.UserContent{Script: "var a = 1;"},
+			want:    []string{"var a = 1;"},
+			notWant: []string{"window.top", "DOMContentLoaded"},
+		},
+		{
+			name:    "stylesheet",
+			content: options.UserContent{Stylesheet: "body { color: red; }"},
+			want:    []string{"createElement('style')", `"body { color: red; }"`},
+		},
+		{
+			// The declarations of the script are global, so the script isn't wrapped in a block
+			name:    "main frame at document start",
+			content: options.UserContent{Script: "let a = 1;"},
+			emulate: true,
+			want:    []string{"if(window.top!==window){return;}", "createElement('script')", `s.textContent="let a = 1;"`},
+			notWant: []string{"DOMContentLoaded", "{\nlet a = 1;"},
+		},
+		{
+			name:    "all frames at document start",
+			content: options.UserContent{Script: "let a = 1;", AllFrames: true},
+			emulate: true,
+			want:    []string{"let a = 1;"},
+			notWant: []string{"window.top", "createElement", "function"},
+		},
+		{
+			name:    "all frames at document end",
+			content: options.UserContent{Script: "var a = 1;", InjectionTime: options.InjectAtDocumentEnd, AllFrames: true},
+			emulate: true,
+			want:    []string{"DOMContentLoaded", `s.textContent="var a = 1;"`},
+			notWant: []string{"window.top"},
+		},
+		{
+			name:    "stylesheet of the main frame",
+			content: options.UserContent{Stylesheet: "body { color: red; }"},
+			emulate: true,
+			want:    []string{"if(window.top!==window){return;}", "createElement('style')"},
+			notWant: []string{"createElement('script')"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UserContentScript(UserContentItem{ID: "test", UserContent: tt.content}, tt.emulate)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in %q", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("unexpected %q in %q", notWant, got)
+				}
+			}
+		})
+	}
+}
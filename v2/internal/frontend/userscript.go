@@ -0,0 +1,41 @@
+package frontend
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// UserScriptSource returns the source of the script that is injected at the start of every page. It is empty if no
+// user script has been configured.
+func UserScriptSource(userScript *options.UserScript) (string, error) {
+	if userScript == nil {
+		return "", nil
+	}
+
+	names := make([]string, 0, len(userScript.Globals))
+	for name := range userScript.Globals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result strings.Builder
+	for _, name := range names {
+		value, err := json.Marshal(userScript.Globals[name])
+		if err != nil {
+			return "", err
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString("window[" + string(key) + "] = " + string(value) + ";\n")
+	}
+	if userScript.Script != "" {
+		result.WriteString(userScript.Script)
+		result.WriteString("\n")
+	}
+	return result.String(), nil
+}
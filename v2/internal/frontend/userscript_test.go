@@ -0,0 +1,65 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestUserScriptSource(t *testing.T) {
+	source, err := UserScriptSource(nil)
+	if err != nil || source != "" {
+		t.Errorf("expected no source without a user script, got %q, %v", source, err)
+	}
+
+	source, err = UserScriptSource(&options.UserScript{
+		Globals: map[string]interface{}{
+			"NONCE":         "</script>",
+			"FEATURE_FLAGS": map[string]bool{"beta": true},
+		},
+		Script: "window.polyfilled = true;",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `window["FEATURE_FLAGS"] = {"beta":true};
+window["NONCE"] = "\u003c/script\u003e";
+window.polyfilled = true;
+`
+	if source != want {
+		t.Errorf("got %q, want %q", source, want)
+	}
+
+	_, err = UserScriptSource(&options.UserScript{
+		Globals: map[string]interface{}{"invalid": func() {}},
+	})
+	if err == nil {
+		t.Error("expected an error for a value that can't be marshalled")
+	}
+}
+
+func TestInjectedScriptSource(t *testing.T) {
+	appoptions := &options.App{UserScript: &options.UserScript{Script: "window.polyfilled = true;"}}
+	source, err := InjectedScriptSource(appoptions)
+	if err != nil || source != "window.polyfilled = true;\n" {
+		t.Errorf("expected only the user script, got %q, %v", source, err)
+	}
+
+	appoptions.EnableMediaCaptureEvents = true
+	source, err = InjectedScriptSource(appoptions)
+	if err != nil || source != mediaCaptureScript+"window.polyfilled = true;\n" {
+		t.Errorf("expected the media capture script before the user script, got %q, %v", source, err)
+	}
+
+	appoptions.EnableMediaCaptureEvents = false
+	appoptions.PerformanceMetrics = &options.PerformanceMetrics{Interval: 5 * time.Second, SlowFrameThreshold: 34 * time.Millisecond}
+	source, err = InjectedScriptSource(appoptions)
+	if err != nil || !strings.HasPrefix(source, "(function() {") || !strings.HasSuffix(source, "window.polyfilled = true;\n") {
+		t.Errorf("expected the performance script before the user script, got %q, %v", source, err)
+	}
+	if !strings.Contains(source, "var interval = 5000, slowFrame = 34;") {
+		t.Errorf("expected the interval and the threshold in milliseconds, got %q", source)
+	}
+}
@@ -0,0 +1,120 @@
+package frontend
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+// ZoomFilename is the file in the application data directory the zoom factor is persisted in
+const ZoomFilename = "zoom.json"
+
+// zoomLevels are the zoom factors ZoomIn and ZoomOut step through, the ones of the browsers
+var zoomLevels = []float64{0.25, 0.33, 0.5, 0.67, 0.75, 0.8, 0.9, 1, 1.1, 1.25, 1.5, 1.75, 2, 2.5, 3, 4, 5}
+
+// ZoomIn returns the zoom level after the factor
+func ZoomIn(factor float64) float64 {
+	for _, level := range zoomLevels {
+		if level > factor+0.001 {
+			return level
+		}
+	}
+	return factor
+}
+
+// ZoomOut returns the zoom level before the factor
+func ZoomOut(factor float64) float64 {
+	for i := len(zoomLevels) - 1; i >= 0; i-- {
+		if zoomLevels[i] < factor-0.001 {
+			return zoomLevels[i]
+		}
+	}
+	return factor
+}
+
+// zoomState is the content of the ZoomFilename
+type zoomState struct {
+	Factor float64 `json:"factor"`
+}
+
+// Zoom keeps the zoom factor of the page within the limits of the options and persists it
+type Zoom struct {
+	options *options.Zoom
+	path    string
+	factor  float64
+	lock    sync.Mutex
+}
+
+// NewZoom creates the zoom of the page with the initial factor, which is replaced by the persisted factor if the
+// options persist it. The factor isn't persisted without appDataDir.
+func NewZoom(zoomOptions *options.Zoom, appDataDir string, initial float64) *Zoom {
+	if initial <= 0 {
+		initial = 1
+	}
+	z := &Zoom{options: zoomOptions, factor: initial}
+	if zoomOptions != nil && zoomOptions.Persist && appDataDir != "" {
+		z.path = filepath.Join(appDataDir, ZoomFilename)
+		var state zoomState
+		if data, err := os.ReadFile(z.path); err == nil && json.Unmarshal(data, &state) == nil && state.Factor > 0 {
+			z.factor = state.Factor
+		}
+	}
+	z.factor = z.clamp(z.factor)
+	return z
+}
+
+// Shortcuts returns true if the page zooms with the keyboard shortcuts
+func (z *Zoom) Shortcuts() bool {
+	return z.options != nil && z.options.Shortcuts
+}
+
+// Factor returns the current zoom factor
+func (z *Zoom) Factor() float64 {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	return z.factor
+}
+
+// Set limits the factor to the options, persists it and returns it. Factors that aren't positive reset the zoom.
+func (z *Zoom) Set(factor float64) (float64, error) {
+	if factor <= 0 {
+		factor = 1
+	}
+	factor = z.clamp(factor)
+
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	changed := factor != z.factor
+	z.factor = factor
+	if !changed || z.path == "" {
+		return factor, nil
+	}
+	data, err := json.Marshal(zoomState{Factor: factor})
+	if err != nil {
+		return factor, err
+	}
+	if err := os.MkdirAll(filepath.Dir(z.path), 0o755); err != nil {
+		return factor, err
+	}
+	return factor, os.WriteFile(z.path, data, 0o644)
+}
+
+func (z *Zoom) clamp(factor float64) float64 {
+	minimum, maximum := zoomLevels[0], zoomLevels[len(zoomLevels)-1]
+	if z.options != nil && z.options.Minimum > 0 {
+		minimum = z.options.Minimum
+	}
+	if z.options != nil && z.options.Maximum > 0 {
+		maximum = z.options.Maximum
+	}
+	if factor < minimum {
+		factor = minimum
+	}
+	if factor > maximum {
+		factor = maximum
+	}
+	return factor
+}
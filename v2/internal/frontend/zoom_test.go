@@ -0,0 +1,80 @@
+package frontend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestZoomLevels(t *testing.T) {
+	tests := []struct {
+		factor  float64
+		in, out float64
+	}{
+		{factor: 1, in: 1.1, out: 0.9},
+		{factor: 1.2, in: 1.25, out: 1.1},
+		{factor: 0.25, in: 0.33, out: 0.25},
+		{factor: 5, in: 5, out: 4},
+	}
+	for _, tt := range tests {
+		if got := ZoomIn(tt.factor); got != tt.in {
+			t.Errorf("ZoomIn(%g) = %g, want %g", tt.factor, got, tt.in)
+		}
+		if got := ZoomOut(tt.factor); got != tt.out {
+			t.Errorf("ZoomOut(%g) = %g, want %g", tt.factor, got, tt.out)
+		}
+	}
+}
+
+func TestZoomSet(t *testing.T) {
+	zoom := NewZoom(&options.Zoom{Minimum: 0.5, Maximum: 2}, "", 0)
+	if got := zoom.Factor(); got != 1 {
+		t.Fatalf("Factor() = %g, want 1", got)
+	}
+	for _, tt := range []struct{ factor, want float64 }{
+		{1.5, 1.5},
+		{3, 2},
+		{0.1, 0.5},
+		{-1, 1},
+	} {
+		got, err := zoom.Set(tt.factor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want || zoom.Factor() != tt.want {
+			t.Errorf("Set(%g) = %g, want %g", tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestZoomWithoutOptions(t *testing.T) {
+	zoom := NewZoom(nil, t.TempDir(), 1.5)
+	if zoom.Shortcuts() {
+		t.Error("Shortcuts() = true, want false")
+	}
+	if got, _ := zoom.Set(10); got != 5 {
+		t.Errorf("Set(10) = %g, want 5", got)
+	}
+}
+
+func TestZoomPersist(t *testing.T) {
+	dir := t.TempDir()
+	zoomOptions := &options.Zoom{Persist: true, Minimum: 0.25, Maximum: 5}
+	zoom := NewZoom(zoomOptions, dir, 1)
+	if _, err := zoom.Set(1.25); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ZoomFilename)); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewZoom(zoomOptions, dir, 2).Factor(); got != 1.25 {
+		t.Errorf("restored factor = %g, want 1.25", got)
+	}
+
+	// The factor is only restored when it's persisted
+	if got := NewZoom(&options.Zoom{}, dir, 2).Factor(); got != 2 {
+		t.Errorf("factor = %g, want 2", got)
+	}
+}
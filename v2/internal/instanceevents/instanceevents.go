@@ -0,0 +1,223 @@
+// Package instanceevents exchanges events between the running instances of an application.
+//
+// Every instance listens on a Unix socket in a directory that is shared by all instances of the user with the same
+// unique id. The directory is in the runtime directory of the user, or in its cache directory, and is only accessible
+// by the user.
+// An event is sent by connecting to the sockets of all other instances and writing the JSON encoded envelope.
+package instanceevents
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+const (
+	socketSuffix = ".sock"
+	dialTimeout  = time.Second
+)
+
+// Bus sends events to and receives events from the other instances of the application
+type Bus struct {
+	id       string
+	dir      string
+	listener net.Listener
+	onEvent  func(event options.InstanceEvent)
+	logger   *logger.Logger
+
+	closeOnce sync.Once
+}
+
+// New starts listening for the events of the other instances with the same unique id. onEvent is called for every
+// received event.
+func New(uniqueID string, onEvent func(event options.InstanceEvent), logger *logger.Logger) (*Bus, error) {
+	if uniqueID == "" {
+		return nil, errors.New("instance events require a unique id")
+	}
+
+	id, err := newInstanceID()
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := userDir()
+	if err != nil {
+		return nil, err
+	}
+	// The unique id is hashed to keep the socket paths below the length limit of Unix sockets
+	hash := sha256.Sum256([]byte(uniqueID))
+	dir := filepath.Join(base, "wails-"+hex.EncodeToString(hash[:8]))
+	err = createDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, id+socketSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Bus{
+		id:       id,
+		dir:      dir,
+		listener: listener,
+		onEvent:  onEvent,
+		logger:   logger,
+	}
+	go result.serve()
+	return result, nil
+}
+
+// ID returns the identifier of this instance
+func (b *Bus) ID() string {
+	return b.id
+}
+
+// Emit sends the event to all other running instances
+func (b *Bus) Emit(eventName string, data ...interface{}) error {
+	message, err := json.Marshal(options.InstanceEvent{
+		Sender: b.id,
+		Name:   eventName,
+		Data:   data,
+	})
+	if err != nil {
+		return err
+	}
+
+	peers, err := b.peers()
+	if err != nil {
+		return err
+	}
+	for _, peer := range peers {
+		err := b.send(peer, message)
+		if err != nil {
+			b.logger.Debug("Unable to send instance event to %s: %s", peer, err)
+		}
+	}
+	return nil
+}
+
+// Close stops listening and removes the socket of this instance
+func (b *Bus) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		err = b.listener.Close()
+		_ = os.Remove(filepath.Join(b.dir, b.id+socketSuffix))
+	})
+	return err
+}
+
+func (b *Bus) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				b.logger.Error("Instance events stopped: %s", err)
+			}
+			return
+		}
+		go b.receive(conn)
+	}
+}
+
+func (b *Bus) receive(conn net.Conn) {
+	defer conn.Close()
+	var event options.InstanceEvent
+	err := json.NewDecoder(conn).Decode(&event)
+	if err != nil {
+		b.logger.Debug("Invalid instance event: %s", err)
+		return
+	}
+	if b.onEvent != nil {
+		b.onEvent(event)
+	}
+}
+
+// peers returns the socket paths of the other instances
+func (b *Bus) peers() ([]string, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, socketSuffix) || name == b.id+socketSuffix {
+			continue
+		}
+		result = append(result, filepath.Join(b.dir, name))
+	}
+	return result, nil
+}
+
+func (b *Bus) send(peer string, message []byte) error {
+	conn, err := net.DialTimeout("unix", peer, dialTimeout)
+	if err != nil {
+		var netErr net.Error
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			// The instance has exited without removing its socket
+			_ = os.Remove(peer)
+		}
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	_, err = conn.Write(message)
+	return err
+}
+
+// userDir returns the directory of the user the socket directories are created in, which isn't shared with other
+// users like the temporary directory
+func userDir() (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("instance events require the cache directory of the user: %w", err)
+	}
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// createDir creates the socket directory, which is only accessible by the user. An existing directory has to be a
+// directory of the user that isn't accessible by others, otherwise another user could receive or inject the events.
+func createDir(dir string) error {
+	err := os.Mkdir(dir, 0o700)
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return checkOwner(dir, info)
+}
+
+func newInstanceID() (string, error) {
+	id := make([]byte, 6)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,104 @@
+package instanceevents
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/logger"
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestBus(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	myLogger := logger.New(nil)
+	uniqueID := "instanceevents-test-" + time.Now().Format(time.RFC3339Nano)
+
+	received := make(chan options.InstanceEvent, 1)
+	receiver, err := New(uniqueID, func(event options.InstanceEvent) {
+		received <- event
+	}, myLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+
+	sender, err := New(uniqueID, func(event options.InstanceEvent) {
+		t.Errorf("the sender received its own event %s", event.Name)
+	}, myLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+
+	err = sender.Emit("refresh", "library")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-received:
+		if event.Sender != sender.ID() || event.Name != "refresh" || len(event.Data) != 1 || event.Data[0] != "library" {
+			t.Errorf("unexpected event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the event")
+	}
+
+	// Events of closed instances are not delivered
+	err = receiver.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sender.Emit("refresh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peers, err := sender.peers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 0 {
+		t.Errorf("expected no peers, got %v", peers)
+	}
+}
+
+func TestCreateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the mode of directories isn't checked on Windows")
+	}
+	base := t.TempDir()
+
+	dir := filepath.Join(base, "events")
+	if err := createDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(dir); err != nil || info.Mode().Perm() != 0o700 {
+		t.Errorf("expected the directory to be created with the mode 0700, got %v, %v", info, err)
+	}
+	if err := createDir(dir); err != nil {
+		t.Errorf("expected the existing directory to be used: %s", err)
+	}
+
+	// A directory that has been created by another user first is accessible by others
+	shared := filepath.Join(base, "shared")
+	if err := os.Mkdir(shared, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(shared, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := createDir(shared); err == nil {
+		t.Error("expected a directory that is accessible by others to be rejected")
+	}
+
+	link := filepath.Join(base, "link")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := createDir(link); err == nil {
+		t.Error("expected a symbolic link to be rejected")
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package instanceevents
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkOwner checks that the directory is owned by the user and is not accessible by the group and others
+func checkOwner(dir string, info os.FileInfo) error {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is owned by another user", dir)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("%s is accessible by other users, its mode is %s", dir, info.Mode().Perm())
+	}
+	return nil
+}
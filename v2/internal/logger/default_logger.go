@@ -105,3 +105,28 @@ func (l *Logger) Fatal(format string, args ...interface{}) {
 	l.output.Fatal(fmt.Sprintf(format, args...))
 	os.Exit(1)
 }
+
+// LogFields logs the message with the fields at the level. The fields are kept by the structured loggers and appended
+// to the message for the others.
+func (l *Logger) LogFields(level LogLevel, message string, fields map[string]interface{}) {
+	if l.logLevel > level {
+		return
+	}
+	if structured, ok := l.output.(logger.StructuredLogger); ok {
+		structured.Log(level, message, fields)
+		return
+	}
+	message = logger.FormatFields(message, fields)
+	switch level {
+	case logger.TRACE:
+		l.output.Trace(message)
+	case logger.DEBUG:
+		l.output.Debug(message)
+	case logger.WARNING:
+		l.output.Warning(message)
+	case logger.ERROR:
+		l.output.Error(message)
+	default:
+		l.output.Info(message)
+	}
+}
@@ -0,0 +1,65 @@
+package nativedeps
+
+import (
+	"debug/elf"
+	"errors"
+	"strings"
+)
+
+// webkitSymbolVersions are the WebKitGTK functions that are only available since a version, see the webkit2_*
+// build tags of pkg/assetserver/webview
+var webkitSymbolVersions = map[string]string{
+	"webkit_uri_scheme_request_get_http_method":      "2.36",
+	"webkit_uri_scheme_request_get_http_headers":     "2.36",
+	"webkit_uri_scheme_request_finish_with_response": "2.36",
+	"webkit_uri_scheme_response_new":                 "2.36",
+	"webkit_uri_scheme_request_get_http_body":        "2.40",
+}
+
+var elfArchs = map[elf.Machine]string{
+	elf.EM_386:     "386",
+	elf.EM_X86_64:  "amd64",
+	elf.EM_ARM:     "arm",
+	elf.EM_AARCH64: "arm64",
+	elf.EM_RISCV:   "riscv64",
+}
+
+func analyzeELF(file *elf.File) (*Report, error) {
+	report := &Report{Format: "ELF", Arch: elfArchs[file.Machine]}
+	if report.Arch == "" {
+		report.Arch = file.Machine.String()
+	}
+
+	libraries, err := file.ImportedLibraries()
+	if err != nil {
+		return nil, err
+	}
+	report.Libraries = libraries
+
+	webkit := ""
+	for _, library := range libraries {
+		// EG: libwebkit2gtk-4.1.so.0
+		if strings.HasPrefix(library, "libwebkit2gtk-") {
+			webkit = strings.TrimPrefix(strings.Split(library, ".so")[0], "lib")
+			report.add(Requirement{Name: webkit, Source: "library " + library})
+		}
+	}
+
+	symbols, err := file.ImportedSymbols()
+	if errors.Is(err, elf.ErrNoSymbols) {
+		// Statically linked
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, symbol := range symbols {
+		if version, ok := strings.CutPrefix(symbol.Version, "GLIBC_"); ok {
+			report.add(Requirement{Name: "glibc", Version: version, Source: "symbol " + symbol.Name + "@" + symbol.Version})
+		}
+		if version := webkitSymbolVersions[symbol.Name]; version != "" && webkit != "" {
+			report.add(Requirement{Name: webkit, Version: version, Source: "symbol " + symbol.Name})
+		}
+	}
+	return report, nil
+}
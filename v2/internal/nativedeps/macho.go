@@ -0,0 +1,80 @@
+package nativedeps
+
+import (
+	"debug/macho"
+	"fmt"
+	"strings"
+)
+
+const (
+	loadCmdVersionMinMacOSX = 0x24
+	loadCmdBuildVersion     = 0x32
+
+	platformMacOS = 1
+)
+
+var machoArchs = map[macho.Cpu]string{
+	macho.Cpu386:   "386",
+	macho.CpuAmd64: "amd64",
+	macho.CpuArm64: "arm64",
+}
+
+func analyzeMachO(file *macho.File) (*Report, error) {
+	report := &Report{Format: "Mach-O", Arch: machoArchs[file.Cpu]}
+	if report.Arch == "" {
+		report.Arch = file.Cpu.String()
+	}
+
+	libraries, err := file.ImportedLibraries()
+	if err != nil {
+		return nil, err
+	}
+	report.Libraries = libraries
+
+	for _, load := range file.Loads {
+		data := load.Raw()
+		if len(data) < 16 {
+			continue
+		}
+		switch file.ByteOrder.Uint32(data) {
+		case loadCmdBuildVersion:
+			if file.ByteOrder.Uint32(data[8:]) == platformMacOS {
+				report.add(Requirement{Name: "macOS", Version: machoVersion(file.ByteOrder.Uint32(data[12:])), Source: "LC_BUILD_VERSION"})
+			}
+		case loadCmdVersionMinMacOSX:
+			report.add(Requirement{Name: "macOS", Version: machoVersion(file.ByteOrder.Uint32(data[8:])), Source: "LC_VERSION_MIN_MACOSX"})
+		}
+	}
+	return report, nil
+}
+
+// analyzeFat merges the reports of the architectures of a universal binary
+func analyzeFat(fat *macho.FatFile) (*Report, error) {
+	result := &Report{Format: "Mach-O universal"}
+	var archs []string
+	for _, arch := range fat.Arches {
+		report, err := analyzeMachO(arch.File)
+		if err != nil {
+			return nil, err
+		}
+		archs = append(archs, report.Arch)
+		for _, library := range report.Libraries {
+			if !containsString(result.Libraries, library) {
+				result.Libraries = append(result.Libraries, library)
+			}
+		}
+		for _, requirement := range report.Requirements {
+			result.add(requirement)
+		}
+	}
+	result.Arch = strings.Join(archs, ", ")
+	return result, nil
+}
+
+// machoVersion formats a version that is encoded as xxxx.yy.zz, EG: 0x000a0d00 is 10.13
+func machoVersion(version uint32) string {
+	if version&0xff == 0 {
+		return fmt.Sprintf("%d.%d", version>>16, (version>>8)&0xff)
+	}
+	return fmt.Sprintf("%d.%d.%d", version>>16, (version>>8)&0xff, version&0xff)
+}
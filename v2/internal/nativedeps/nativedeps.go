@@ -0,0 +1,175 @@
+// Package nativedeps reports the native libraries and the minimum versions of the system components that a built
+// application requires.
+package nativedeps
+
+import (
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Requirement is a system component that the application needs to run
+type Requirement struct {
+	Name string `json:"name"`
+	// Version is the minimum version of the component. It is empty if any version is supported.
+	Version string `json:"version,omitempty"`
+	// Source is the part of the binary that the requirement was derived from
+	Source string `json:"source"`
+}
+
+// Report lists the native requirements of a binary
+type Report struct {
+	Format       string        `json:"format"`
+	Arch         string        `json:"arch"`
+	GoVersion    string        `json:"goVersion,omitempty"`
+	WailsVersion string        `json:"wailsVersion,omitempty"`
+	BuildTags    string        `json:"buildTags,omitempty"`
+	Libraries    []string      `json:"libraries"`
+	Requirements []Requirement `json:"requirements"`
+}
+
+// Analyze inspects the ELF, PE or Mach-O binary at the path
+func Analyze(path string) (*Report, error) {
+	var report *Report
+	var err error
+	if file, elfErr := elf.Open(path); elfErr == nil {
+		report, err = analyzeELF(file)
+		file.Close()
+	} else if file, peErr := pe.Open(path); peErr == nil {
+		report, err = analyzePE(file)
+		file.Close()
+	} else if file, machoErr := macho.Open(path); machoErr == nil {
+		report, err = analyzeMachO(file)
+		file.Close()
+	} else if fat, fatErr := macho.OpenFat(path); fatErr == nil {
+		report, err = analyzeFat(fat)
+		fat.Close()
+	} else {
+		return nil, fmt.Errorf("%s is not an ELF, PE or Mach-O binary", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Binaries that weren't built by Go are still analysed
+	if info, err := buildinfo.ReadFile(path); err == nil {
+		report.GoVersion = info.GoVersion
+		for _, dep := range info.Deps {
+			switch dep.Path {
+			case "github.com/wailsapp/wails/v2":
+				report.WailsVersion = dep.Version
+			case "github.com/wailsapp/go-webview2":
+				report.add(Requirement{Name: "WebView2 Runtime", Version: minimumWebView2Runtime, Source: "module " + dep.Path + " " + dep.Version})
+			}
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "-tags" {
+				report.BuildTags = setting.Value
+			}
+		}
+	}
+
+	if report.Libraries == nil {
+		report.Libraries = []string{}
+	}
+	if report.Requirements == nil {
+		report.Requirements = []Requirement{}
+	}
+	sort.Strings(report.Libraries)
+	sort.SliceStable(report.Requirements, func(i, j int) bool {
+		return report.Requirements[i].Name < report.Requirements[j].Name
+	})
+	return report, nil
+}
+
+// add adds the requirement or raises the version of the requirement with the same name
+func (r *Report) add(requirement Requirement) {
+	for index, existing := range r.Requirements {
+		if existing.Name != requirement.Name {
+			continue
+		}
+		if CompareVersions(requirement.Version, existing.Version) > 0 {
+			r.Requirements[index] = requirement
+		}
+		return
+	}
+	r.Requirements = append(r.Requirements, requirement)
+}
+
+// Requirement returns the requirement with the name or nil
+func (r *Report) Requirement(name string) *Requirement {
+	for index := range r.Requirements {
+		if r.Requirements[index].Name == name {
+			return &r.Requirements[index]
+		}
+	}
+	return nil
+}
+
+// Compare returns the changes of the report against the baseline that raise the requirements of the binary: new
+// libraries, new requirements and higher minimum versions
+func Compare(baseline *Report, report *Report) []string {
+	var result []string
+	for _, library := range report.Libraries {
+		if !containsString(baseline.Libraries, library) {
+			result = append(result, "new library "+library)
+		}
+	}
+	for _, requirement := range report.Requirements {
+		previous := baseline.Requirement(requirement.Name)
+		switch {
+		case previous == nil:
+			result = append(result, fmt.Sprintf("new requirement %s %s", requirement.Name, displayVersion(requirement.Version)))
+		case CompareVersions(requirement.Version, previous.Version) > 0:
+			result = append(result, fmt.Sprintf("%s raised from %s to %s (%s)", requirement.Name, displayVersion(previous.Version), requirement.Version, requirement.Source))
+		}
+	}
+	return result
+}
+
+func displayVersion(version string) string {
+	if version == "" {
+		return "any"
+	}
+	return version
+}
+
+// CompareVersions compares dotted versions, EG: 2.36 and 2.40.1. Missing parts count as 0 and an empty version is
+// lower than all other versions.
+func CompareVersions(a string, b string) int {
+	if a == "" || b == "" {
+		return strings.Compare(a, b)
+	}
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numberA, numberB int
+		if i < len(partsA) {
+			numberA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numberB, _ = strconv.Atoi(partsB[i])
+		}
+		if numberA != numberB {
+			if numberA < numberB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
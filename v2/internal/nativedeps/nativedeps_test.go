@@ -0,0 +1,80 @@
+package nativedeps
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.36", "2.40", -1},
+		{"2.40.1", "2.40", 1},
+		{"10.13", "10.13.0", 0},
+		{"94.0.992.31", "94.0.1000.0", -1},
+		{"", "2.36", -1},
+		{"", "", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &Report{
+		Libraries: []string{"libc.so.6", "libwebkit2gtk-4.0.so.37"},
+		Requirements: []Requirement{
+			{Name: "glibc", Version: "2.34"},
+			{Name: "webkit2gtk-4.0", Version: "2.36"},
+		},
+	}
+	report := &Report{
+		Libraries: []string{"libc.so.6", "libwebkit2gtk-4.0.so.37", "libsoup-2.4.so.1"},
+		Requirements: []Requirement{
+			{Name: "glibc", Version: "2.32"},
+			{Name: "webkit2gtk-4.0", Version: "2.40", Source: "symbol webkit_uri_scheme_request_get_http_body"},
+		},
+	}
+
+	got := strings.Join(Compare(baseline, report), "\n")
+	want := "new library libsoup-2.4.so.1\nwebkit2gtk-4.0 raised from 2.36 to 2.40 (symbol webkit_uri_scheme_request_get_http_body)"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if changes := Compare(report, report); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestMachoVersion(t *testing.T) {
+	if got := machoVersion(0x000a0d00); got != "10.13" {
+		t.Errorf("expected 10.13, got %s", got)
+	}
+	if got := machoVersion(0x000b0001); got != "11.0.1" {
+		t.Errorf("expected 11.0.1, got %s", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	path, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	report, err := Analyze(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.GoVersion == "" || report.Arch == "" {
+		t.Errorf("expected the Go version and the architecture of the test binary, got %+v", report)
+	}
+
+	_, err = Analyze("nativedeps_test.go")
+	if err == nil {
+		t.Error("expected an error for a source file")
+	}
+}
@@ -0,0 +1,53 @@
+package nativedeps
+
+import (
+	"debug/pe"
+	"fmt"
+	"strings"
+)
+
+// minimumWebView2Runtime is the runtime that go-webview2 needs, see wv2installer.MinimumRuntimeVersion
+const minimumWebView2Runtime = "94.0.992.31"
+
+var peArchs = map[uint16]string{
+	pe.IMAGE_FILE_MACHINE_I386:  "386",
+	pe.IMAGE_FILE_MACHINE_AMD64: "amd64",
+	pe.IMAGE_FILE_MACHINE_ARMNT: "arm",
+	pe.IMAGE_FILE_MACHINE_ARM64: "arm64",
+}
+
+func analyzePE(file *pe.File) (*Report, error) {
+	report := &Report{Format: "PE", Arch: peArchs[file.Machine]}
+	if report.Arch == "" {
+		report.Arch = fmt.Sprintf("0x%x", file.Machine)
+	}
+
+	// pe.File.ImportedLibraries isn't implemented, the symbols are in the form "name:library"
+	symbols, err := file.ImportedSymbols()
+	if err != nil {
+		return nil, err
+	}
+	for _, symbol := range symbols {
+		library := strings.ToLower(symbol[strings.LastIndexByte(symbol, ':')+1:])
+		if containsString(report.Libraries, library) {
+			continue
+		}
+		report.Libraries = append(report.Libraries, library)
+		if library == "webview2loader.dll" {
+			report.add(Requirement{Name: "WebView2 Runtime", Version: minimumWebView2Runtime, Source: "library " + library})
+		}
+	}
+
+	// Windows refuses to start binaries with a higher subsystem version
+	var major, minor uint16
+	switch header := file.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		major, minor = header.MajorSubsystemVersion, header.MinorSubsystemVersion
+	case *pe.OptionalHeader64:
+		major, minor = header.MajorSubsystemVersion, header.MinorSubsystemVersion
+	}
+	if major != 0 {
+		report.add(Requirement{Name: "Windows", Version: fmt.Sprintf("%d.%d", major, minor), Source: "subsystem version"})
+	}
+	return report, nil
+}
@@ -52,6 +52,7 @@ var (
 
 	AllowDarkModeForWindow func(HWND, bool) uintptr
 	SetPreferredAppMode    func(int32) uintptr
+	FlushMenuThemes        func()
 )
 
 type PreferredAppMode = int32
@@ -108,6 +109,14 @@ func init() {
 			}
 			SetPreferredAppMode(PreferredAppModeAllowDark)
 		}
+
+		// FlushMenuThemes applies the preferred app mode to the menus
+		procFlushMenuThemes, err := windows.GetProcAddressByOrdinal(uxtheme, uintptr(136))
+		if err == nil {
+			FlushMenuThemes = func() {
+				syscall.SyscallN(procFlushMenuThemes)
+			}
+		}
 	}
 
 }
@@ -1,15 +1,26 @@
 package process
 
 import (
+	"errors"
 	"os"
 	"os/exec"
+	"sync/atomic"
+	"time"
 )
 
+// stopTimeout is the time the process has to exit after Stop before it is killed
+const stopTimeout = 5 * time.Second
+
 // Process defines a process that can be executed
 type Process struct {
 	cmd         *exec.Cmd
 	exitChannel chan bool
 	Running     bool
+
+	// Stop is called by Kill to stop the process before it is killed, EG: to let a debugger kill the application
+	Stop func() error
+
+	killed atomic.Bool
 }
 
 // NewProcess creates a new process struct
@@ -34,7 +45,8 @@ func (p *Process) Start(exitCodeChannel chan int) error {
 
 	go func(cmd *exec.Cmd, running *bool, exitChannel chan bool, exitCodeChannel chan int) {
 		err := cmd.Wait()
-		if err == nil {
+		// Exits caused by Kill aren't reported
+		if err == nil && !p.killed.Load() {
 			exitCodeChannel <- 0
 		}
 		*running = false
@@ -49,7 +61,19 @@ func (p *Process) Kill() error {
 	if !p.Running {
 		return nil
 	}
+	p.killed.Store(true)
+	if p.Stop != nil && p.Stop() == nil {
+		select {
+		case <-p.exitChannel:
+			return nil
+		case <-time.After(stopTimeout):
+		}
+	}
 	err := p.cmd.Process.Kill()
+	if errors.Is(err, os.ErrProcessDone) {
+		<-p.exitChannel
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,52 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// BuildProfile is a named set of options for `wails build`. Options that are given on the command line take
+// precedence over the profile, except for the tags and ldflags which are combined.
+type BuildProfile struct {
+	Platform       string `json:"platform"`
+	OutputFilename string `json:"outputfilename"`
+	Tags           string `json:"tags"`
+	LdFlags        string `json:"ldflags"`
+	WebView2       string `json:"webview2"`
+
+	Debug          bool `json:"debug"`
+	Devtools       bool `json:"devtools"`
+	Obfuscated     bool `json:"obfuscated"`
+	TrimPath       bool `json:"trimpath"`
+	Upx            bool `json:"upx"`
+	NSIS           bool `json:"nsis"`
+	WindowsConsole bool `json:"windowsconsole"`
+	SkipBindings   bool `json:"skipbindings"`
+
+	// Flags are compiled into the application and returned by runtime.BuildInfo, EG: "channel": "beta"
+	Flags map[string]string `json:"flags"`
+
+	// Assets are the policies that strip the source maps, comments and console statements from the assets. They are
+	// compiled into the application and ignored by debug builds.
+	Assets []assetserver.Policy `json:"assets"`
+}
+
+// GetBuildProfile returns the build profile with the name
+func (p *Project) GetBuildProfile(name string) (*BuildProfile, error) {
+	profile := p.BuildProfiles[name]
+	if profile == nil {
+		names := make([]string, 0, len(p.BuildProfiles))
+		for profileName := range p.BuildProfiles {
+			names = append(names, profileName)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown build profile '%s': no buildProfiles in the project config", name)
+		}
+		return nil, fmt.Errorf("unknown build profile '%s', available profiles: %s", name, strings.Join(names, ", "))
+	}
+	return profile, nil
+}
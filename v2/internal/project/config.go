@@ -0,0 +1,344 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ConfigError is a problem at a position of a project config file
+type ConfigError struct {
+	Line    int
+	Column  int
+	Key     string
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Key, e.Message)
+}
+
+// configEnums are the allowed values of the string options
+var configEnums = map[string][]string{
+	"nsisType":                           {"multiple", "single"},
+	"bindings.ts_generation.outputType":  {"classes", "interfaces"},
+	"bindings.ts_generation.nullability": {"optional", "nullable", "strict"},
+}
+
+// configNode is a value of a JSON document with its position
+type configNode struct {
+	start, end int
+	delim      json.Delim
+	token      json.Token
+
+	keys      []string
+	keyStarts []int
+	values    []*configNode
+}
+
+func (n *configNode) isNull() bool {
+	return n.delim == 0 && n.token == nil
+}
+
+func (n *configNode) get(key string) *configNode {
+	for index, k := range n.keys {
+		if k == key {
+			return n.values[index]
+		}
+	}
+	return nil
+}
+
+func parseConfig(data []byte) (*configNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := parseConfigNode(data, decoder)
+	if err != nil {
+		return nil, configSyntaxError(data, decoder, err)
+	}
+	if decoder.More() {
+		return nil, configError(data, int(decoder.InputOffset()), "", "unexpected data after the config")
+	}
+	return node, nil
+}
+
+func parseConfigNode(data []byte, decoder *json.Decoder) (*configNode, error) {
+	start := skipSeparators(data, int(decoder.InputOffset()))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &configNode{start: start}
+	switch token {
+	case json.Delim('{'):
+		result.delim = '{'
+		for decoder.More() {
+			keyStart := skipSeparators(data, int(decoder.InputOffset()))
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := parseConfigNode(data, decoder)
+			if err != nil {
+				return nil, err
+			}
+			result.keys = append(result.keys, key.(string))
+			result.keyStarts = append(result.keyStarts, keyStart)
+			result.values = append(result.values, value)
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		result.delim = '['
+		for decoder.More() {
+			value, err := parseConfigNode(data, decoder)
+			if err != nil {
+				return nil, err
+			}
+			result.values = append(result.values, value)
+		}
+		_, err = decoder.Token()
+	default:
+		result.token = token
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.end = int(decoder.InputOffset())
+	return result, nil
+}
+
+func skipSeparators(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n:,", data[offset]) != -1 {
+		offset++
+	}
+	return offset
+}
+
+func configError(data []byte, offset int, key string, message string) *ConfigError {
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	column := offset - bytes.LastIndexByte(data[:offset], '\n')
+	return &ConfigError{Line: line, Column: column, Key: key, Message: message}
+}
+
+func configSyntaxError(data []byte, decoder *json.Decoder, err error) *ConfigError {
+	offset := int(decoder.InputOffset())
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = int(syntaxErr.Offset)
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return configError(data, offset, "", err.Error())
+}
+
+// Validate checks the project config, EG: wails.json or an environment overlay, and returns the unknown keys,
+// the values of the wrong type and the invalid options with their position
+func Validate(data []byte) []*ConfigError {
+	root, err := parseConfig(data)
+	if err != nil {
+		return []*ConfigError{err.(*ConfigError)}
+	}
+	v := &configValidator{data: data}
+	v.check(root, reflect.TypeOf(Project{}), "")
+	return v.errors
+}
+
+type configValidator struct {
+	data   []byte
+	errors []*ConfigError
+}
+
+func (v *configValidator) fail(node *configNode, key string, format string, args ...interface{}) {
+	v.errors = append(v.errors, configError(v.data, node.start, key, fmt.Sprintf(format, args...)))
+}
+
+func (v *configValidator) check(node *configNode, typ reflect.Type, key string) {
+	// null leaves the defaults untouched
+	if node.isNull() {
+		return
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		value, ok := node.token.(string)
+		if !ok {
+			v.fail(node, key, "expected a string")
+			return
+		}
+		if allowed := configEnums[key]; allowed != nil && value != "" && !containsString(allowed, value) {
+			v.fail(node, key, "%q is not one of %s", value, strings.Join(allowed, ", "))
+		}
+	case reflect.Bool:
+		if _, ok := node.token.(bool); !ok {
+			v.fail(node, key, "expected a boolean")
+		}
+	case reflect.Int:
+		number, ok := node.token.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			v.fail(node, key, "expected an integer")
+		}
+	case reflect.Slice:
+		if node.delim != '[' {
+			v.fail(node, key, "expected an array")
+			return
+		}
+		for index, value := range node.values {
+			v.check(value, typ.Elem(), fmt.Sprintf("%s[%d]", key, index))
+		}
+	case reflect.Map:
+		if node.delim != '{' {
+			v.fail(node, key, "expected an object")
+			return
+		}
+		for index, value := range node.values {
+			v.check(value, typ.Elem(), joinConfigKey(key, node.keys[index]))
+		}
+	case reflect.Struct:
+		if node.delim != '{' {
+			v.fail(node, key, "expected an object")
+			return
+		}
+		for index, name := range node.keys {
+			if key == "" && name == "$schema" {
+				continue
+			}
+			field, ok := configField(typ, name)
+			if !ok {
+				v.errors = append(v.errors, configError(v.data, node.keyStarts[index], joinConfigKey(key, name), "unknown key"))
+				continue
+			}
+			v.check(node.values[index], field.Type, joinConfigKey(key, configFieldName(field)))
+		}
+	}
+}
+
+// configField looks up the field of the key, case-insensitive like encoding/json
+func configField(typ reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		if strings.EqualFold(configFieldName(field), key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func configFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func joinConfigKey(parent string, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConfigValue returns the JSON value of the dotted key, EG: "bindings.ts_generation.outputType"
+func GetConfigValue(data []byte, key string) (json.RawMessage, error) {
+	node, err := parseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(key, ".") {
+		if node.delim != '{' {
+			return nil, fmt.Errorf("%s is not an object", key)
+		}
+		node = node.get(name)
+		if node == nil {
+			return nil, fmt.Errorf("%s is not set", key)
+		}
+	}
+	return json.RawMessage(data[node.start:node.end]), nil
+}
+
+// SetConfigValue sets the dotted key to the JSON value. The formatting of the rest of the config is kept. Missing
+// objects on the path of the key are added.
+func SetConfigValue(data []byte, key string, value json.RawMessage) ([]byte, error) {
+	if !json.Valid(value) {
+		return nil, fmt.Errorf("invalid value for %s", key)
+	}
+	node, err := parseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	names := strings.Split(key, ".")
+	for index, name := range names {
+		if node.delim != '{' {
+			return nil, fmt.Errorf("%s is not an object", strings.Join(names[:index], "."))
+		}
+		child := node.get(name)
+		if child == nil {
+			// Add the remaining keys as nested objects
+			entry := value
+			for i := len(names) - 1; i > index; i-- {
+				entry = json.RawMessage(fmt.Sprintf("{%q: %s}", names[i], entry))
+			}
+			return insertConfigEntry(data, node, name, entry), nil
+		}
+		node = child
+	}
+
+	var result bytes.Buffer
+	result.Write(data[:node.start])
+	result.Write(value)
+	result.Write(data[node.end:])
+	return result.Bytes(), nil
+}
+
+// insertConfigEntry adds the key after the last key of the object, with the same indentation
+func insertConfigEntry(data []byte, object *configNode, key string, value json.RawMessage) []byte {
+	entry := fmt.Sprintf("%q: %s", key, value)
+
+	var result bytes.Buffer
+	if len(object.keys) == 0 {
+		result.Write(data[:object.start+1])
+		result.WriteString(entry)
+		result.Write(data[object.end-1:])
+		return result.Bytes()
+	}
+
+	last := len(object.keys) - 1
+	keyStart := object.keyStarts[last]
+	lineStart := bytes.LastIndexByte(data[:keyStart], '\n') + 1
+	indent := data[lineStart:keyStart]
+	separator := ", "
+	if len(bytes.TrimSpace(indent)) == 0 && lineStart > 0 {
+		separator = ",\n" + string(indent)
+	}
+
+	valueEnd := object.values[last].end
+	result.Write(data[:valueEnd])
+	result.WriteString(separator)
+	result.WriteString(entry)
+	result.Write(data[valueEnd:])
+	return result.Bytes()
+}
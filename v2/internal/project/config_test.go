@@ -0,0 +1,157 @@
+package project_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name: "valid config",
+			config: `{
+  "$schema": "https://wails.io/schemas/config.v2.json",
+  "name": "app",
+  "debounceMS": 100,
+  "Author": {"name": "me"},
+  "info": {"copyright": null},
+  "bindings": {"ts_generation": {"outputType": "interfaces"}},
+  "plugins": {"deploy": "./deploy.sh"},
+  "buildProfiles": {"store": {"trimpath": true, "flags": {"channel": "beta"}}}
+}`,
+		},
+		{
+			name: "errors with positions",
+			config: `{
+  "name": 1,
+  "frontend:biuld": "npm run build",
+  "obfuscated": "",
+  "bindings": {
+    "ts_generation": {"outputType": "records"}
+  }
+}`,
+			want: []string{
+				"2:11: name: expected a string",
+				"3:3: frontend:biuld: unknown key",
+				"4:17: obfuscated: expected a boolean",
+				`6:37: bindings.ts_generation.outputType: "records" is not one of classes, interfaces`,
+			},
+		},
+		{
+			name:   "syntax error",
+			config: "{\n  \"name\": \"app\",\n}",
+			want:   []string{"2:17: invalid character ',' looking for beginning of value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range project.Validate([]byte(tt.config)) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestGetConfigValue(t *testing.T) {
+	config := []byte(`{"name": "app", "bindings": {"ts_generation": {"prefix": "T"}}}`)
+
+	value, err := project.GetConfigValue(config, "bindings.ts_generation.prefix")
+	if err != nil || string(value) != `"T"` {
+		t.Errorf(`expected "T", got %s, %v`, value, err)
+	}
+	value, err = project.GetConfigValue(config, "bindings")
+	if err != nil || string(value) != `{"ts_generation": {"prefix": "T"}}` {
+		t.Errorf("unexpected value %s, %v", value, err)
+	}
+	_, err = project.GetConfigValue(config, "author.name")
+	if err == nil {
+		t.Error("expected an error for a missing key")
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	config := []byte(`{
+  "name": "app",
+  "bindings": {}
+}
+`)
+
+	got, err := project.SetConfigValue(config, "name", []byte(`"other"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "name": "other",
+  "bindings": {}
+}
+`
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	got, err = project.SetConfigValue(got, "bindings.ts_generation.outputType", []byte(`"interfaces"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = project.SetConfigValue(got, "debounceMS", []byte(`200`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{
+  "name": "other",
+  "bindings": {"ts_generation": {"outputType": "interfaces"}},
+  "debounceMS": 200
+}
+`
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	_, err = project.SetConfigValue(got, "name.first", []byte(`"a"`))
+	if err == nil {
+		t.Error("expected an error for a key below a string")
+	}
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("wails.json", `{"name": "app", "devServer": "localhost:34115", "author": {"name": "me", "email": "me@example.com"}}`)
+	writeFile("wails.dev.json", `{"devServer": "localhost:3000", "author": {"email": "dev@example.com"}}`)
+
+	dev, err := project.LoadEnvironment(dir, "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Name != "app" || dev.DevServer != "localhost:3000" || dev.Author.Name != "me" || dev.Author.Email != "dev@example.com" {
+		t.Errorf("unexpected merged config %+v", dev)
+	}
+	if dev.Save() == nil {
+		t.Error("expected a merged config not to be saved")
+	}
+
+	prod, err := project.LoadEnvironment(dir, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prod.DevServer != "localhost:34115" {
+		t.Errorf("expected the config without an overlay, got %s", prod.DevServer)
+	}
+}
@@ -0,0 +1,71 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OverlayFilename returns the filename of the overlay of the environment, EG: wails.dev.json for "dev"
+func OverlayFilename(projectPath string, environment string) string {
+	return filepath.Join(projectPath, "wails."+environment+".json")
+}
+
+// LoadEnvironment loads the project like Load, and merges the overlay of the environment into the config if it
+// exists. Objects are merged key by key, all other values of the overlay replace the values of wails.json.
+func LoadEnvironment(projectPath string, environment string) (*Project, error) {
+	overlayFile := OverlayFilename(projectPath, environment)
+	overlayBytes, err := os.ReadFile(overlayFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return Load(projectPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	projectFile := filepath.Join(projectPath, "wails.json")
+	rawBytes, err := os.ReadFile(projectFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config, overlay map[string]interface{}
+	err = json.Unmarshal(rawBytes, &config)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", projectFile, err)
+	}
+	err = json.Unmarshal(overlayBytes, &overlay)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", overlayFile, err)
+	}
+	merged, err := json.Marshal(mergeConfig(config, overlay))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Parse(merged)
+	if err != nil {
+		return nil, err
+	}
+	result.filename = projectFile
+	result.overlay = overlayFile
+	return result, nil
+}
+
+func mergeConfig(config map[string]interface{}, overlay map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	for key, value := range overlay {
+		overlayObject, isObject := value.(map[string]interface{})
+		configObject, hasObject := config[key].(map[string]interface{})
+		if isObject && hasObject {
+			config[key] = mergeConfig(configObject, overlayObject)
+			continue
+		}
+		config[key] = value
+	}
+	return config
+}
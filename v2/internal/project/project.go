@@ -2,6 +2,7 @@ package project
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -28,6 +29,8 @@ type Project struct {
 	DevWatcherCommand string `json:"frontend:dev:watcher"`
 	// The url of the external wails dev server. If this is set, this server is used for the frontend. Default ""
 	FrontendDevServerURL string `json:"frontend:dev:serverUrl"`
+	// Additional frontend dev servers that `wails dev` runs next to the main frontend, EG: for the UIs of plugins
+	FrontendDevServers []DevServer `json:"frontend:dev:servers,omitempty"`
 
 	// Directory to generate the API Module
 	WailsJSDir string `json:"wailsjsdir"`
@@ -74,6 +77,9 @@ type Project struct {
 	// Fully qualified filename
 	filename string
 
+	// The overlay of the environment that has been merged into the config
+	overlay string
+
 	// The debounce time for hot-reload of the built-in dev server. Default 100
 	DebounceMS int `json:"debounceMS"`
 
@@ -94,6 +100,22 @@ type Project struct {
 	FrontendDir string `json:"frontend:dir"`
 
 	Bindings Bindings `json:"bindings"`
+
+	// Custom commands of the CLI, EG: "deploy": "./scripts/deploy.sh" runs the script for `wails deploy`.
+	// Relative paths are resolved against the project directory.
+	Plugins map[string]string `json:"plugins,omitempty"`
+
+	// Named sets of build options, selected with `wails build -profile <name>`
+	BuildProfiles map[string]*BuildProfile `json:"buildProfiles,omitempty"`
+
+	// Options of `wails release`
+	Release *Release `json:"release,omitempty"`
+
+	// Options of `wails publish`
+	Publish *Publish `json:"publish,omitempty"`
+
+	// Notices of the third-party dependencies that `wails build` bundles with the application
+	Notices *Notices `json:"notices,omitempty"`
 }
 
 func (p *Project) GetFrontendDir() string {
@@ -139,6 +161,9 @@ func (p *Project) IsFrontendDevServerURLAutoDiscovery() bool {
 }
 
 func (p *Project) Save() error {
+	if p.overlay != "" {
+		return fmt.Errorf("the project config can't be saved, %s has been merged into it", filepath.Base(p.overlay))
+	}
 	data, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
 		return err
@@ -229,6 +254,8 @@ type FileAssociation struct {
 	Description string `json:"description"`
 	IconName    string `json:"iconName"`
 	Role        string `json:"role"`
+	// MimeType of the files, EG: text/markdown
+	MimeType string `json:"mimeType,omitempty"`
 }
 
 type Protocol struct {
@@ -237,14 +264,118 @@ type Protocol struct {
 	Role        string `json:"role"`
 }
 
+// DevServer is an additional frontend dev server of `wails dev`. The application serves the requests of Path with it.
+type DevServer struct {
+	// Name prefixes the output of the dev server
+	Name string `json:"name"`
+	// Dir is the directory the command is run in, relative to the project directory
+	Dir string `json:"dir"`
+	// Command starts the dev server, $PORT is replaced with the port of the dev server, which is also set as the PORT
+	// environment variable
+	Command string `json:"command"`
+	// Path is the path of the URLs that are served by the dev server, EG: "/plugins/chart/". The path isn't removed
+	// from the requests, so it must be the base path of the dev server.
+	Path string `json:"path"`
+	// Port of the dev server. Default: a free port
+	Port int `json:"port,omitempty"`
+}
+
+func (d DevServer) GetDir(projectDir string) string {
+	if filepath.IsAbs(d.Dir) {
+		return d.Dir
+	}
+	return filepath.Join(projectDir, d.Dir)
+}
+
 type Bindings struct {
 	TsGeneration TsGeneration `json:"ts_generation"`
+
+	// JSONSchema generates a JSON Schema document of the bound methods
+	JSONSchema bool `json:"json_schema"`
 }
 
 type TsGeneration struct {
 	Prefix     string `json:"prefix"`
 	Suffix     string `json:"suffix"`
 	OutputType string `json:"outputType"`
+	// Nullability of the generated fields (optional|nullable|strict)
+	Nullability string `json:"nullability"`
+}
+
+type Release struct {
+	// The platforms to build, EG: ["windows/amd64", "darwin/universal"]. Default: the current platform
+	Platforms []string `json:"platforms"`
+	// The build profile to use
+	Profile string `json:"profile"`
+	// The prefix of the version tags. Default "v"
+	TagPrefix string `json:"tagPrefix"`
+	// The markdown file the release notes are prepended to, EG: CHANGELOG.md
+	Changelog string `json:"changelog"`
+	// The base URL of the artifacts in the update feed
+	FeedURL string `json:"feedUrl"`
+}
+
+// Publish contains the store listings of `wails publish`
+type Publish struct {
+	// The build profile of the store builds
+	Profile string          `json:"profile"`
+	MAS     *PublishMAS     `json:"mas,omitempty"`
+	MSStore *PublishMSStore `json:"msstore,omitempty"`
+	Snap    *PublishSnap    `json:"snap,omitempty"`
+}
+
+// PublishMAS is the Mac App Store listing
+type PublishMAS struct {
+	// The bundle identifier of the App Store Connect record, EG: com.example.myapp
+	BundleID string `json:"bundleId"`
+	// The entitlements of the App Sandbox. Default: build/darwin/entitlements.plist
+	Entitlements string `json:"entitlements"`
+	// The provisioning profile of the App Store distribution. Default: build/darwin/embedded.provisionprofile
+	ProvisioningProfile string `json:"provisioningProfile"`
+	// The signing identities, EG: "3rd Party Mac Developer Application: Example Ltd (TEAMID)" and
+	// "3rd Party Mac Developer Installer: Example Ltd (TEAMID)"
+	ApplicationIdentity string `json:"applicationIdentity"`
+	InstallerIdentity   string `json:"installerIdentity"`
+}
+
+// PublishMSStore is the Microsoft Store listing. The identity is shown in Partner Center under Product identity.
+type PublishMSStore struct {
+	IdentityName         string `json:"identityName"`
+	Publisher            string `json:"publisher"`
+	PublisherDisplayName string `json:"publisherDisplayName"`
+	// The Store ID of the product, which is required to upload the package
+	ProductID string `json:"productId"`
+}
+
+// PublishSnap is the Snap Store listing
+type PublishSnap struct {
+	// The snapcraft.yaml of the snap. Default: build/linux/snap/snapcraft.yaml
+	Snapcraft string `json:"snapcraft"`
+	// The channel the uploaded snap is released to. Default "edge"
+	Channel string `json:"channel"`
+}
+
+// Notices generates THIRD_PARTY_NOTICES.txt with the licenses of the Go modules and npm packages of the application
+// next to the index.html of the embedded assets
+type Notices struct {
+	// The Go modules and npm packages that aren't listed, EG: private modules. A trailing "*" matches a prefix, EG:
+	// "github.com/mycompany/*"
+	Exclude []string `json:"exclude"`
+}
+
+func (r *Release) GetTagPrefix() string {
+	if r.TagPrefix == "" {
+		return "v"
+	}
+	return r.TagPrefix
+}
+
+// GetChannel returns the channel the snap is released to
+func (s *PublishSnap) GetChannel() string {
+	if s.Channel == "" {
+		return "edge"
+	}
+	return s.Channel
 }
 
 // Parse the given JSON data into a Project struct
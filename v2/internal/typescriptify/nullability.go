@@ -0,0 +1,87 @@
+package typescriptify
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	tsOptionalTag = "ts_optional"
+	tsNullableTag = "ts_nullable"
+)
+
+// Nullability controls how pointers, zero values and omitempty are mapped to optional (`?`) and nullable (`| null`)
+// Typescript properties
+type Nullability string
+
+const (
+	// NullabilityOptional maps pointers and omitempty fields to optional properties
+	NullabilityOptional Nullability = "optional"
+	// NullabilityNullable maps omitempty fields to optional properties and fields that can be marshalled as null,
+	// EG: pointers, slices and maps without omitempty, to nullable properties
+	NullabilityNullable Nullability = "nullable"
+	// NullabilityStrict is the same as NullabilityNullable, but fails on fields where the Typescript type is
+	// ambiguous, unless they have a ts_optional or ts_nullable tag
+	NullabilityStrict Nullability = "strict"
+)
+
+func (t *TypeScriptify) WithNullability(n Nullability) *TypeScriptify {
+	t.Nullability = n
+	return t
+}
+
+// fieldNullability determines if the field is optional and nullable. The field type has already been dereferenced
+// if the field is a pointer.
+func (t *TypeScriptify) fieldNullability(structType reflect.Type, field reflect.StructField, isPtr bool, optional bool) (bool, bool, error) {
+	optionalTag, hasOptionalTag := field.Tag.Lookup(tsOptionalTag)
+	nullableTag, hasNullableTag := field.Tag.Lookup(tsNullableTag)
+
+	nullable := false
+	if t.Nullability == NullabilityNullable || t.Nullability == NullabilityStrict {
+		omitEmpty := hasTagOption(field, "omitempty")
+		nilable := isPtr
+		switch field.Type.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Interface:
+			nilable = true
+		}
+
+		if t.Nullability == NullabilityStrict {
+			switch {
+			case omitEmpty && !nilable && !hasOptionalTag:
+				return false, false, fmt.Errorf("ambiguous field %s.%s: the zero value is omitted, add a %s tag", structType.Name(), field.Name, tsOptionalTag)
+			case !omitEmpty && !isPtr && nilable && !hasNullableTag:
+				return false, false, fmt.Errorf("ambiguous field %s.%s: the value may be null, add a %s tag", structType.Name(), field.Name, tsNullableTag)
+			}
+		}
+
+		optional = omitEmpty
+		nullable = nilable && !omitEmpty
+	}
+
+	var err error
+	if hasOptionalTag {
+		optional, err = strconv.ParseBool(optionalTag)
+		if err != nil {
+			return false, false, fmt.Errorf("invalid %s tag of %s.%s: %w", tsOptionalTag, structType.Name(), field.Name, err)
+		}
+	}
+	if hasNullableTag {
+		nullable, err = strconv.ParseBool(nullableTag)
+		if err != nil {
+			return false, false, fmt.Errorf("invalid %s tag of %s.%s: %w", tsNullableTag, structType.Name(), field.Name, err)
+		}
+	}
+	return optional, nullable, nil
+}
+
+func hasTagOption(field reflect.StructField, option string) bool {
+	parts := strings.Split(field.Tag.Get("json"), ",")
+	for _, part := range parts[1:] {
+		if part == option {
+			return true
+		}
+	}
+	return false
+}
@@ -90,6 +90,7 @@ type TypeScriptify struct {
 	BackupDir         string // If empty no backup
 	DontExport        bool
 	CreateInterface   bool
+	Nullability       Nullability
 	customImports     []string
 
 	structTypes []StructType
@@ -294,7 +295,11 @@ func (t *typeScriptClassBuilder) AddMapField(fieldName string, field reflect.Str
 			fieldName = fmt.Sprintf(`"%s"?`, strippedFieldName)
 		}
 	}
-	t.fields = append(t.fields, fmt.Sprintf("%s%s: {[key: %s]: %s};", t.indent, fieldName, keyTypeStr, valueTypeName))
+	nullType := ""
+	if t.nullable {
+		nullType = " | null"
+	}
+	t.fields = append(t.fields, fmt.Sprintf("%s%s: {[key: %s]: %s}%s;", t.indent, fieldName, keyTypeStr, valueTypeName, nullType))
 	if valueType.Kind() == reflect.Struct {
 		t.constructorBody = append(t.constructorBody, fmt.Sprintf("%s%sthis%s = this.convertValues(source[\"%s\"], %s, true);", t.indent, t.indent, dotField, strippedFieldName, t.prefix+valueTypeName+t.suffix))
 	} else {
@@ -632,7 +637,16 @@ func (t *TypeScriptify) convertType(depth int, typeOf reflect.Type, customCode m
 			continue
 		}
 
-		var err error
+		optional, nullable, err := t.fieldNullability(typeOf, field, isPtr, strings.HasSuffix(jsonFieldName, "?"))
+		if err != nil {
+			return "", err
+		}
+		jsonFieldName = strings.TrimSuffix(jsonFieldName, "?")
+		if optional {
+			jsonFieldName += "?"
+		}
+		builder.nullable = nullable
+
 		fldOpts := t.getFieldOptions(typeOf, field)
 		if fldOpts.TSTransform != "" {
 			t.logf(depth, "- simple field %s.%s", typeOf.Name(), field.Name)
@@ -797,6 +811,8 @@ type typeScriptClassBuilder struct {
 	constructorBody      []string
 	prefix, suffix       string
 	namespace            string
+	// nullable is set when the field that is currently added can be null
+	nullable bool
 }
 
 func (t *typeScriptClassBuilder) AddSimpleArrayField(fieldName string, field reflect.StructField, arrayDepth int, opts TypeOptions) error {
@@ -906,6 +922,9 @@ func (t *typeScriptClassBuilder) addField(fld, fldType string, isAnyType bool) {
 		fldType = strings.Split(fldType, ".")[0]
 		t.fields = append(t.fields, fmt.Sprint(t.indent, "// Go type: ", fldType, "\n", t.indent, fld, ": any;"))
 	} else {
+		if t.nullable {
+			fldType += " | null"
+		}
 		t.fields = append(t.fields, fmt.Sprint(t.indent, fld, ": ", fldType, ";"))
 	}
 }
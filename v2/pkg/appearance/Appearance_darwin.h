@@ -0,0 +1,11 @@
+#ifndef Appearance_darwin_h
+#define Appearance_darwin_h
+
+#include <stdbool.h>
+
+bool AppearanceIsDark(void);
+void* AppearanceWatch(int watcher);
+void AppearanceStopWatching(void *observer);
+void AppearanceSetMenus(const char *mode);
+
+#endif /* Appearance_darwin_h */
@@ -0,0 +1,62 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AppKit/AppKit.h>
+#include <string.h>
+
+#import "Appearance_darwin.h"
+
+extern void processAppearanceChange(int watcher);
+
+@interface AppearanceObserver : NSObject
+@property int watcher;
+@end
+
+@implementation AppearanceObserver
+- (void)observeValueForKeyPath:(NSString *)keyPath ofObject:(id)object change:(NSDictionary *)change context:(void *)context {
+    processAppearanceChange(self.watcher);
+}
+@end
+
+// AppearanceIsDark returns if the effective appearance of the application is dark, which follows the system as Set
+// only changes the appearance of the window and of the menus
+bool AppearanceIsDark(void) {
+    if (@available(macOS 10.14, *)) {
+        NSAppearanceName name = [[NSApplication sharedApplication].effectiveAppearance bestMatchFromAppearancesWithNames:@[NSAppearanceNameAqua, NSAppearanceNameDarkAqua]];
+        return [name isEqualToString:NSAppearanceNameDarkAqua];
+    }
+    return false;
+}
+
+// AppearanceWatch observes the effectiveAppearance of the application for the watcher. It returns the observer, which
+// is removed by AppearanceStopWatching.
+void* AppearanceWatch(int watcher) {
+    AppearanceObserver *observer = [AppearanceObserver new];
+    observer.watcher = watcher;
+    [[NSApplication sharedApplication] addObserver:observer forKeyPath:@"effectiveAppearance" options:NSKeyValueObservingOptionNew context:nil];
+    return observer;
+}
+
+void AppearanceStopWatching(void *context) {
+    AppearanceObserver *observer = (AppearanceObserver*)context;
+    [[NSApplication sharedApplication] removeObserver:observer forKeyPath:@"effectiveAppearance"];
+    [observer release];
+}
+
+// AppearanceSetMenus sets the appearance of the menus of the menu bar, which don't follow the appearance of the window
+void AppearanceSetMenus(const char *mode) {
+    NSString *name = nil;
+    if (strcmp(mode, "light") == 0) {
+        name = NSAppearanceNameAqua;
+    } else if (strcmp(mode, "dark") == 0) {
+        if (@available(macOS 10.14, *)) {
+            name = NSAppearanceNameDarkAqua;
+        }
+    }
+    dispatch_async(dispatch_get_main_queue(), ^{
+        if (@available(macOS 10.14, *)) {
+            NSMenu *menu = [NSApplication sharedApplication].mainMenu;
+            menu.appearance = name != nil ? [NSAppearance appearanceNamed:name] : nil;
+        }
+    });
+}
@@ -0,0 +1,128 @@
+// Package appearance notices the changes of the dark and light appearance of the system, also while the frontend
+// isn't loaded or a theme has been forced, and forces the appearance of the window and of its menus with Set. The
+// changes are the change notifications of the registry on Windows, the effectiveAppearance of the application on
+// macOS and the color-scheme of the settings portal on Linux. Bind the Appearance service to use it from JS.
+package appearance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ChangeEvent is emitted by EmitEvents with the Mode of the system after the change
+const ChangeEvent = "appearance:change"
+
+// Mode is an appearance
+type Mode string
+
+const (
+	Light Mode = "light"
+	Dark  Mode = "dark"
+	// System follows the appearance of the system, it is only used by Set
+	System Mode = "system"
+)
+
+// ErrNotSupported is returned when the platform doesn't report the appearance, EG: on Linux without the settings
+// portal
+var ErrNotSupported = errors.New("appearance: the appearance of the system is not supported")
+
+type backend interface {
+	system() (Mode, error)
+	// watch calls changed when the appearance may have changed until stop is called
+	watch(changed func()) (stop func(), err error)
+}
+
+// Appearance is the appearance service
+type Appearance struct {
+	// OnChange is called with the appearance of the system after it has changed
+	OnChange func(mode Mode)
+
+	backend backend
+	lock    sync.Mutex
+	current Mode
+	stop    func()
+}
+
+// New creates the appearance service
+func New() *Appearance {
+	return &Appearance{backend: newBackend()}
+}
+
+// EmitEvents emits ChangeEvent to the frontend
+func EmitEvents(ctx context.Context, a *Appearance) {
+	a.OnChange = func(mode Mode) {
+		runtime.EventsEmit(ctx, ChangeEvent, mode)
+	}
+}
+
+// System returns the appearance of the system, Light or Dark
+func (a *Appearance) System() (Mode, error) {
+	return a.backend.system()
+}
+
+// Watch calls OnChange until Stop is called
+func (a *Appearance) Watch() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.stop != nil {
+		return nil
+	}
+	mode, err := a.backend.system()
+	if err != nil {
+		return err
+	}
+	a.current = mode
+	stop, err := a.backend.watch(a.changed)
+	if err != nil {
+		return err
+	}
+	a.stop = stop
+	return nil
+}
+
+// Stop stops watching
+func (a *Appearance) Stop() {
+	a.lock.Lock()
+	stop := a.stop
+	a.stop = nil
+	a.lock.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+// changed calls OnChange if the appearance has changed, as the platforms also report the changes of other settings
+func (a *Appearance) changed() {
+	mode, err := a.backend.system()
+	if err != nil {
+		return
+	}
+	a.lock.Lock()
+	updated := mode != a.current
+	a.current = mode
+	a.lock.Unlock()
+	if updated && a.OnChange != nil {
+		a.OnChange(mode)
+	}
+}
+
+// Set forces the Light or Dark appearance of the window, which changes prefers-color-scheme of the webview, the title
+// bar and the menus, or follows the system again for System. It doesn't change the appearance that is reported.
+func Set(ctx context.Context, mode Mode) error {
+	switch mode {
+	case Light:
+		runtime.WindowSetLightTheme(ctx)
+	case Dark:
+		runtime.WindowSetDarkTheme(ctx)
+	case System:
+		runtime.WindowSetSystemDefaultTheme(ctx)
+	default:
+		return fmt.Errorf("appearance: invalid mode '%s'", mode)
+	}
+	setMenus(mode)
+	return nil
+}
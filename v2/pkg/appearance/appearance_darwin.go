@@ -0,0 +1,69 @@
+//go:build darwin
+
+package appearance
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AppKit
+
+#import "Appearance_darwin.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// kvoBackend observes the effectiveAppearance of the application with key-value observing. The changes are delivered
+// by the main run loop of the application.
+type kvoBackend struct{}
+
+var (
+	watchersLock sync.Mutex
+	watchers     = map[int]func(){}
+	nextWatcher  int
+)
+
+//export processAppearanceChange
+func processAppearanceChange(watcher C.int) {
+	watchersLock.Lock()
+	changed := watchers[int(watcher)]
+	watchersLock.Unlock()
+	if changed != nil {
+		changed()
+	}
+}
+
+func newBackend() backend {
+	return kvoBackend{}
+}
+
+func (kvoBackend) system() (Mode, error) {
+	if C.AppearanceIsDark() {
+		return Dark, nil
+	}
+	return Light, nil
+}
+
+func (kvoBackend) watch(changed func()) (func(), error) {
+	watchersLock.Lock()
+	id := nextWatcher
+	nextWatcher++
+	watchers[id] = changed
+	watchersLock.Unlock()
+	observer := C.AppearanceWatch(C.int(id))
+	return func() {
+		C.AppearanceStopWatching(observer)
+		watchersLock.Lock()
+		delete(watchers, id)
+		watchersLock.Unlock()
+	}, nil
+}
+
+func setMenus(mode Mode) {
+	cmode := C.CString(string(mode))
+	defer C.free(unsafe.Pointer(cmode))
+	C.AppearanceSetMenus(cmode)
+}
@@ -0,0 +1,93 @@
+//go:build linux
+
+package appearance
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	portalDestination = "org.freedesktop.portal.Desktop"
+	portalPath        = "/org/freedesktop/portal/desktop"
+	settingsInterface = "org.freedesktop.portal.Settings"
+	appearanceSetting = "org.freedesktop.appearance"
+	colorSchemeKey    = "color-scheme"
+
+	// colorSchemeDark is the color-scheme that prefers dark, 0 has no preference and 2 prefers light
+	colorSchemeDark = 1
+)
+
+// portalBackend reads the color-scheme of the settings portal, which the desktops set to the appearance of the
+// desktop. The menus follow the GTK theme, which is set by the window functions of the runtime.
+type portalBackend struct{}
+
+func newBackend() backend {
+	return portalBackend{}
+}
+
+func (portalBackend) system() (Mode, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	settings := conn.Object(portalDestination, portalPath)
+	var value dbus.Variant
+	if err := settings.Call(settingsInterface+".ReadOne", 0, appearanceSetting, colorSchemeKey).Store(&value); err != nil {
+		// Read is the deprecated method of older portals, it wraps the value in another variant
+		if err := settings.Call(settingsInterface+".Read", 0, appearanceSetting, colorSchemeKey).Store(&value); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrNotSupported, err)
+		}
+		if inner, ok := value.Value().(dbus.Variant); ok {
+			value = inner
+		}
+	}
+	return colorSchemeMode(value), nil
+}
+
+func colorSchemeMode(value dbus.Variant) Mode {
+	if scheme, _ := value.Value().(uint32); scheme == colorSchemeDark {
+		return Dark
+	}
+	return Light
+}
+
+func (portalBackend) watch(changed func()) (func(), error) {
+	// A private connection, so that the signals stop when it is closed
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	err = conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(portalPath),
+		dbus.WithMatchInterface(settingsInterface),
+		dbus.WithMatchMember("SettingChanged"),
+	)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	signals := make(chan *dbus.Signal, 10)
+	conn.Signal(signals)
+	go func() {
+		for signal := range signals {
+			if isColorSchemeChange(signal) {
+				changed()
+			}
+		}
+	}()
+	return func() { conn.Close() }, nil
+}
+
+// isColorSchemeChange returns if the SettingChanged signal is a change of the color-scheme
+func isColorSchemeChange(signal *dbus.Signal) bool {
+	if signal.Name != settingsInterface+".SettingChanged" || len(signal.Body) < 2 {
+		return false
+	}
+	namespace, _ := signal.Body[0].(string)
+	key, _ := signal.Body[1].(string)
+	return namespace == appearanceSetting && key == colorSchemeKey
+}
+
+func setMenus(mode Mode) {}
@@ -0,0 +1,46 @@
+//go:build linux
+
+package appearance
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestIsColorSchemeChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		signal *dbus.Signal
+		want   bool
+	}{
+		{
+			name:   "color-scheme",
+			signal: &dbus.Signal{Name: settingsInterface + ".SettingChanged", Body: []interface{}{appearanceSetting, colorSchemeKey, dbus.MakeVariant(uint32(1))}},
+			want:   true,
+		},
+		{
+			name:   "accent color",
+			signal: &dbus.Signal{Name: settingsInterface + ".SettingChanged", Body: []interface{}{appearanceSetting, "accent-color", dbus.MakeVariant(uint32(1))}},
+		},
+		{
+			name:   "gnome setting",
+			signal: &dbus.Signal{Name: settingsInterface + ".SettingChanged", Body: []interface{}{"org.gnome.desktop.interface", colorSchemeKey, dbus.MakeVariant("prefer-dark")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isColorSchemeChange(tt.signal); got != tt.want {
+				t.Errorf("isColorSchemeChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorSchemeMode(t *testing.T) {
+	for value, want := range map[uint32]Mode{0: Light, 1: Dark, 2: Light} {
+		if got := colorSchemeMode(dbus.MakeVariant(value)); got != want {
+			t.Errorf("colorSchemeMode(%d) = %s, want %s", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !windows
+
+package appearance
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) system() (Mode, error) {
+	return "", ErrNotSupported
+}
+
+func (unsupportedBackend) watch(changed func()) (func(), error) {
+	return nil, ErrNotSupported
+}
+
+func setMenus(mode Mode) {}
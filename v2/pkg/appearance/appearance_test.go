@@ -0,0 +1,60 @@
+package appearance
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeBackend struct {
+	current Mode
+	err     error
+	changed func()
+	stopped bool
+}
+
+func (b *fakeBackend) system() (Mode, error) {
+	return b.current, b.err
+}
+
+func (b *fakeBackend) watch(changed func()) (func(), error) {
+	b.changed = changed
+	return func() { b.stopped = true }, nil
+}
+
+func TestWatch(t *testing.T) {
+	backend := &fakeBackend{current: Light}
+	a := &Appearance{backend: backend}
+	var modes []Mode
+	a.OnChange = func(mode Mode) {
+		modes = append(modes, mode)
+	}
+	if err := a.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	// Windows also reports the changes of the other personalization settings
+	for _, mode := range []Mode{Light, Dark, Dark, Light} {
+		backend.current = mode
+		backend.changed()
+	}
+	a.Stop()
+
+	if want := []Mode{Dark, Light}; !reflect.DeepEqual(modes, want) {
+		t.Errorf("modes = %v, want %v", modes, want)
+	}
+	if !backend.stopped {
+		t.Error("Stop() didn't stop the backend")
+	}
+
+	a = &Appearance{backend: &fakeBackend{err: ErrNotSupported}}
+	if err := a.Watch(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Watch() = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestSetInvalidMode(t *testing.T) {
+	if err := Set(context.Background(), "sepia"); err == nil {
+		t.Error("Set() accepted an invalid mode")
+	}
+}
@@ -0,0 +1,91 @@
+//go:build windows
+
+package appearance
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/wailsapp/wails/v2/internal/platform/win32"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const personalizeKey = `Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`
+
+// registryBackend reads AppsUseLightTheme of the personalization settings and watches the key for changes
+type registryBackend struct{}
+
+func newBackend() backend {
+	return registryBackend{}
+}
+
+func (registryBackend) system() (Mode, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, personalizeKey, registry.QUERY_VALUE)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	defer key.Close()
+	// Windows versions without dark mode don't have the value
+	if light, _, err := key.GetIntegerValue("AppsUseLightTheme"); err == nil && light == 0 {
+		return Dark, nil
+	}
+	return Light, nil
+}
+
+func (registryBackend) watch(changed func()) (func(), error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, personalizeKey, registry.NOTIFY)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	changedEvent, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		key.Close()
+		return nil, err
+	}
+	stopEvent, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		windows.CloseHandle(changedEvent)
+		key.Close()
+		return nil, err
+	}
+	go func() {
+		// The notification ends when the thread that registered it exits
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer key.Close()
+		defer windows.CloseHandle(changedEvent)
+		defer windows.CloseHandle(stopEvent)
+		for {
+			if err := windows.RegNotifyChangeKeyValue(windows.Handle(key), false, windows.REG_NOTIFY_CHANGE_LAST_SET, changedEvent, true); err != nil {
+				return
+			}
+			event, err := windows.WaitForMultipleObjects([]windows.Handle{changedEvent, stopEvent}, false, windows.INFINITE)
+			if err != nil || event != windows.WAIT_OBJECT_0 {
+				return
+			}
+			changed()
+		}
+	}()
+	return func() { windows.SetEvent(stopEvent) }, nil
+}
+
+// setMenus sets the preferred app mode of uxtheme, which themes the menus that open from the menu bar and the context
+// menus, as the window functions of the runtime only theme the title bar and the webview. The menu bar itself isn't
+// themed by Windows.
+func setMenus(mode Mode) {
+	if win32.SetPreferredAppMode == nil {
+		return
+	}
+	switch mode {
+	case Light:
+		win32.SetPreferredAppMode(win32.PreferredAppModeForceLight)
+	case Dark:
+		win32.SetPreferredAppMode(win32.PreferredAppModeForceDark)
+	default:
+		win32.SetPreferredAppMode(win32.PreferredAppModeAllowDark)
+	}
+	if win32.FlushMenuThemes != nil {
+		win32.FlushMenuThemes()
+	}
+}
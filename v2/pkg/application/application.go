@@ -2,12 +2,14 @@ package application
 
 import (
 	"context"
+	"os"
 	"sync"
 
 	"github.com/wailsapp/wails/v2/internal/app"
 	"github.com/wailsapp/wails/v2/internal/signal"
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/power"
 )
 
 // Application is the main Wails application
@@ -81,6 +83,32 @@ func (a *Application) Quit() {
 	})
 }
 
+// Relaunch will shut down the application and start a new instance of it with the given arguments
+func (a *Application) Relaunch(args ...string) {
+	a.shutdown.Do(func() {
+		a.application.Relaunch(args)
+	})
+}
+
+// Restart will shut down the application and start a new instance of it with the arguments it has been started with
+func (a *Application) Restart() {
+	a.Relaunch(os.Args[1:]...)
+}
+
+// RelaunchElevated will shut down the application and start a new instance of it with elevated privileges and the
+// given arguments. Run returns elevate.ErrCancelled if the user declines the elevation.
+func (a *Application) RelaunchElevated(args ...string) {
+	a.shutdown.Do(func() {
+		a.application.RelaunchElevated(args)
+	})
+}
+
+// AcquirePowerLock keeps the computer awake until release is called, EG: during a long upload. The title of the
+// application is the reason of the lock.
+func (a *Application) AcquirePowerLock(kind power.LockKind) (release func(), err error) {
+	return power.AcquireLock(kind, a.options.Title)
+}
+
 // Bind the given struct to the application
 func (a *Application) Bind(boundStruct any) {
 	a.options.Bind = append(a.options.Bind, boundStruct)
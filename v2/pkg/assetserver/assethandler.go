@@ -63,6 +63,10 @@ func NewAssetHandler(options assetserver.Options, log Logger) (http.Handler, err
 		if err != nil {
 			return nil, err
 		}
+
+		if len(options.Policies) > 0 {
+			vfs = newPolicyFS(vfs, options.Policies)
+		}
 	}
 
 	var result http.Handler = &assetHandler{
@@ -47,6 +47,9 @@ type AssetServer struct {
 	// plugin scripts
 	pluginScripts map[string]string
 
+	// server-side rendered pages
+	templates map[string]assetserver.Template
+
 	assetServerWebView
 }
 
@@ -64,7 +67,17 @@ func NewAssetServer(bindingsJSON string, options assetserver.Options, servingFro
 		return nil, err
 	}
 
-	return NewAssetServerWithHandler(handler, bindingsJSON, servingFromDisk, logger, runtime)
+	result, err := NewAssetServerWithHandler(handler, bindingsJSON, servingFromDisk, logger, runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	err = result.ServeWebSockets(options.WebSockets)
+	if err != nil {
+		return nil, err
+	}
+	result.UseTemplates(options.Templates)
+	return result, nil
 }
 
 func NewAssetServerWithHandler(handler http.Handler, bindingsJSON string, servingFromDisk bool, logger Logger, runtime RuntimeAssets) (*AssetServer, error) {
@@ -135,8 +148,12 @@ func (d *AssetServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		}
 		d.writeBlob(rw, path, content)
 
+	} else if path == noticesPath {
+		d.serveNotices(rw, req)
 	} else if script, ok := d.pluginScripts[path]; ok {
 		d.writeBlob(rw, path, []byte(script))
+	} else if tmpl, ok := d.templates[path]; ok {
+		d.serveTemplate(rw, req, tmpl)
 	} else if d.isRuntimeInjectionMatch(path) {
 		recorder := &bodyRecorder{
 			ResponseWriter: rw,
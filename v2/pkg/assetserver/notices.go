@@ -0,0 +1,69 @@
+package assetserver
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// noticesPath is the path of the built-in page with the notices of the third-party dependencies
+const noticesPath = "/wails/licenses"
+
+var noticesPage = template.Must(template.New("licenses").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Third-party licenses</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; }
+pre { white-space: pre-wrap; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>Third-party licenses</h1>
+{{if .}}<pre>{{.}}</pre>{{else}}<p>No third-party notices have been bundled with this application.</p>{{end}}
+</body>
+</html>
+`))
+
+// serveNotices renders the notices file of the assets as an HTML page, EG: for the About window of the application
+func (d *AssetServer) serveNotices(rw http.ResponseWriter, req *http.Request) {
+	noticesReq := req.Clone(req.Context())
+	noticesReq.URL.Path = "/" + assetserver.NoticesFile
+	noticesReq.URL.RawPath = ""
+
+	recorder := &bodyRecorder{
+		ResponseWriter: rw,
+		doRecord: func(code int, h http.Header) bool {
+			return code == http.StatusOK || code == http.StatusNotFound
+		},
+	}
+	d.handler.ServeHTTP(recorder, noticesReq)
+
+	body := recorder.Body()
+	if body == nil {
+		// The response has been passed through, EG: an error of the handler
+		return
+	}
+
+	notices := ""
+	// Handlers that serve the index.html for unknown paths don't have the notices
+	if recorder.Code() == http.StatusOK && !strings.Contains(rw.Header().Get(HeaderContentType), "text/html") {
+		notices = body.String()
+	}
+
+	var page bytes.Buffer
+	if err := noticesPage.Execute(&page, notices); err != nil {
+		d.serveError(rw, err, "Unable to render the notices")
+		return
+	}
+	header := rw.Header()
+	header.Del("ETag")
+	header.Del("Last-Modified")
+	header.Set(HeaderContentType, "text/html; charset=utf-8")
+	d.writeBlob(rw, "licenses.html", page.Bytes())
+}
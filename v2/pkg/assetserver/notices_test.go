@@ -0,0 +1,47 @@
+package assetserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+func TestNotices(t *testing.T) {
+	server, err := NewAssetServer("", assetserver.Options{
+		Assets: fstest.MapFS{
+			"index.html":            {Data: []byte("<html><body></body></html>")},
+			assetserver.NoticesFile: {Data: []byte("github.com/example/module v1.0.0\n\nCopyright <Example>")},
+		},
+	}, false, nil, testRuntimeAssets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, noticesPath, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d", recorder.Code)
+	}
+	if contentType := recorder.Header().Get(HeaderContentType); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("got content type %s", contentType)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, "Copyright &lt;Example&gt;") {
+		t.Errorf("the notices have not been rendered: %s", body)
+	}
+
+	server, err = NewAssetServer("", assetserver.Options{
+		Assets: fstest.MapFS{"index.html": {Data: []byte("<html><body></body></html>")}},
+	}, false, nil, testRuntimeAssets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, noticesPath, nil))
+	if body := recorder.Body.String(); recorder.Code != http.StatusOK || !strings.Contains(body, "No third-party notices") {
+		t.Errorf("got status %d and body %s without notices", recorder.Code, body)
+	}
+}
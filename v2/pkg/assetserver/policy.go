@@ -0,0 +1,440 @@
+package assetserver
+
+import (
+	"bytes"
+	"io"
+	iofs "io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// policyFS applies the policies to the files of the assets, the transformed files are cached
+type policyFS struct {
+	fs       iofs.FS
+	policies []assetserver.Policy
+
+	lock  sync.Mutex
+	cache map[string][]byte
+}
+
+func newPolicyFS(fs iofs.FS, policies []assetserver.Policy) *policyFS {
+	return &policyFS{
+		fs:       fs,
+		policies: policies,
+		cache:    make(map[string][]byte),
+	}
+}
+
+// policy combines the policies that match the file
+func (p *policyFS) policy(name string) assetserver.Policy {
+	var result assetserver.Policy
+	for _, policy := range p.policies {
+		target := name
+		if !strings.Contains(policy.Pattern, "/") {
+			target = path.Base(name)
+		}
+		if matched, _ := path.Match(policy.Pattern, target); policy.Pattern != "" && !matched {
+			continue
+		}
+		result.StripSourceMaps = result.StripSourceMaps || policy.StripSourceMaps
+		result.StripComments = result.StripComments || policy.StripComments
+		result.StripConsole = result.StripConsole || policy.StripConsole
+	}
+	return result
+}
+
+func (p *policyFS) Open(name string) (iofs.File, error) {
+	// The source maps follow the policies of the files they map, EG: app.js.map the ones of app.js
+	if strings.HasSuffix(name, ".map") && (p.policy(name).StripSourceMaps || p.policy(strings.TrimSuffix(name, ".map")).StripSourceMaps) {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrNotExist}
+	}
+
+	file, err := p.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	policy := p.policy(name)
+	strip := stripFuncs[strings.ToLower(path.Ext(name))]
+	if strip == nil || policy == (assetserver.Policy{}) {
+		return file, nil
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		return file, err
+	}
+	defer file.Close()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	data, cached := p.cache[name]
+	if !cached {
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		data = []byte(strip(string(content), policy))
+		p.cache[name] = data
+	}
+	return &policyFile{Reader: bytes.NewReader(data), info: policyFileInfo{FileInfo: info, size: int64(len(data))}}, nil
+}
+
+// policyFile is a transformed file, which can be seeked by http.ServeContent
+type policyFile struct {
+	*bytes.Reader
+	info policyFileInfo
+}
+
+func (f *policyFile) Stat() (iofs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *policyFile) Close() error {
+	return nil
+}
+
+type policyFileInfo struct {
+	iofs.FileInfo
+	size int64
+}
+
+func (i policyFileInfo) Size() int64 {
+	return i.size
+}
+
+// stripFuncs are the functions that apply a policy to the files with the extensions
+var stripFuncs = map[string]func(src string, policy assetserver.Policy) string{
+	".js":   stripJS,
+	".mjs":  stripJS,
+	".cjs":  stripJS,
+	".css":  stripCSS,
+	".html": stripHTML,
+	".htm":  stripHTML,
+}
+
+// keepComment returns true if the policy keeps the JS or CSS comment
+func keepComment(comment string, policy assetserver.Policy) bool {
+	body := comment[2:]
+	if strings.HasPrefix(body, "# sourceMappingURL=") || strings.HasPrefix(body, "@ sourceMappingURL=") {
+		return !policy.StripSourceMaps
+	}
+	if !policy.StripComments {
+		return true
+	}
+	// Legal comments are kept, the same as minifiers do
+	return strings.HasPrefix(body, "!") || strings.Contains(body, "@license") || strings.Contains(body, "@preserve")
+}
+
+// removedComment returns what replaces a block comment, so that the tokens around it aren't joined
+func removedComment(comment string) string {
+	if strings.Contains(comment, "\n") {
+		return "\n"
+	}
+	return " "
+}
+
+const (
+	tokenSpace = iota
+	tokenComment
+	tokenLiteral
+	tokenWord
+	tokenPunct
+)
+
+// wordsBeforeExpression are the keywords that can be followed by a regular expression
+var wordsBeforeExpression = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true, "delete": true,
+	"void": true, "throw": true, "case": true, "do": true, "else": true, "yield": true, "await": true,
+}
+
+// jsLexer splits scripts into the tokens that matter for stripping them. Strings, template literals and regular
+// expressions are single tokens, so that their content is never changed.
+type jsLexer struct {
+	src string
+	pos int
+	// prev is the last punctuator, or 'a' after an operand, and prevWord the last word. They tell regular
+	// expressions from divisions.
+	prev     byte
+	prevWord string
+}
+
+func (l *jsLexer) regexAllowed() bool {
+	if l.prevWord != "" {
+		return wordsBeforeExpression[l.prevWord]
+	}
+	switch l.prev {
+	case 'a', ')', ']':
+		return false
+	}
+	return true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// next returns the kind, the start and the end of the next token
+func (l *jsLexer) next() (int, int, int) {
+	src, start := l.src, l.pos
+	c := src[start]
+	end := start + 1
+	kind := tokenPunct
+	switch {
+	case isSpace(c):
+		for end < len(src) && isSpace(src[end]) {
+			end++
+		}
+		kind = tokenSpace
+	case c == '/' && strings.HasPrefix(src[start:], "//"):
+		end = strings.IndexByte(src[start:], '\n')
+		if end == -1 {
+			end = len(src)
+		} else {
+			end += start
+		}
+		kind = tokenComment
+	case c == '/' && strings.HasPrefix(src[start:], "/*"):
+		end = strings.Index(src[start+2:], "*/")
+		if end == -1 {
+			end = len(src)
+		} else {
+			end += start + 4
+		}
+		kind = tokenComment
+	case c == '\'' || c == '"':
+		end = skipString(src, start)
+		kind = tokenLiteral
+	case c == '`':
+		end = skipTemplate(src, start)
+		kind = tokenLiteral
+	case c == '/' && l.regexAllowed():
+		end = skipRegex(src, start)
+		kind = tokenLiteral
+	case isWordChar(c):
+		for end < len(src) && isWordChar(src[end]) {
+			end++
+		}
+		kind = tokenWord
+	}
+	l.pos = end
+
+	switch kind {
+	case tokenWord:
+		l.prev, l.prevWord = 'a', src[start:end]
+	case tokenLiteral:
+		l.prev, l.prevWord = 'a', ""
+	case tokenPunct:
+		l.prev, l.prevWord = c, ""
+	}
+	return kind, start, end
+}
+
+// nextSignificant returns the next token that isn't a space or a comment, the kind is -1 at the end
+func (l *jsLexer) nextSignificant() (int, int, int) {
+	for l.pos < len(l.src) {
+		kind, start, end := l.next()
+		if kind != tokenSpace && kind != tokenComment {
+			return kind, start, end
+		}
+	}
+	return -1, len(l.src), len(l.src)
+}
+
+// skipConsoleCall skips the `.method(...)` that follows console and returns false if it isn't a call
+func (l *jsLexer) skipConsoleCall() bool {
+	ahead := *l
+	if kind, start, _ := ahead.nextSignificant(); kind != tokenPunct || l.src[start] != '.' {
+		return false
+	}
+	if kind, _, _ := ahead.nextSignificant(); kind != tokenWord {
+		return false
+	}
+	if kind, start, _ := ahead.nextSignificant(); kind != tokenPunct || l.src[start] != '(' {
+		return false
+	}
+	depth := 1
+	for depth > 0 {
+		kind, start, _ := ahead.nextSignificant()
+		switch {
+		case kind == -1:
+			return false
+		case kind != tokenPunct:
+		case l.src[start] == '(':
+			depth++
+		case l.src[start] == ')':
+			depth--
+		}
+	}
+	*l = ahead
+	return true
+}
+
+// skipString returns the end of the string literal at start
+func skipString(src string, start int) int {
+	quote := src[start]
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case quote, '\n':
+			return i + 1
+		}
+	}
+	return len(src)
+}
+
+// skipTemplate returns the end of the template literal at start, including the expressions of its placeholders
+func skipTemplate(src string, start int) int {
+	for i := start + 1; i < len(src); i++ {
+		switch {
+		case src[i] == '\\':
+			i++
+		case src[i] == '`':
+			return i + 1
+		case strings.HasPrefix(src[i:], "${"):
+			l := jsLexer{src: src, pos: i + 2, prev: '{'}
+			depth := 1
+			for depth > 0 && l.pos < len(src) {
+				kind, tokenStart, _ := l.next()
+				if kind != tokenPunct {
+					continue
+				}
+				switch src[tokenStart] {
+				case '{':
+					depth++
+				case '}':
+					depth--
+				}
+			}
+			i = l.pos - 1
+		}
+	}
+	return len(src)
+}
+
+// skipRegex returns the end of the regular expression literal at start, including its flags
+func skipRegex(src string, start int) int {
+	class := false
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '[':
+			class = true
+		case ']':
+			class = false
+		case '\n':
+			return i
+		case '/':
+			if class {
+				continue
+			}
+			i++
+			for i < len(src) && isWordChar(src[i]) {
+				i++
+			}
+			return i
+		}
+	}
+	return len(src)
+}
+
+// stripJS applies the policy to a script
+func stripJS(src string, policy assetserver.Policy) string {
+	var out strings.Builder
+	l := &jsLexer{src: src}
+	for l.pos < len(src) {
+		prev := l.prev
+		kind, start, end := l.next()
+		token := src[start:end]
+		switch {
+		case kind == tokenComment && !keepComment(token, policy):
+			if strings.HasPrefix(token, "/*") {
+				out.WriteString(removedComment(token))
+			}
+			continue
+		// Properties named console, EG: window.console, aren't the console
+		case kind == tokenWord && token == "console" && policy.StripConsole && prev != '.' && l.skipConsoleCall():
+			out.WriteString("void 0")
+			l.prev, l.prevWord = 'a', ""
+			continue
+		}
+		out.WriteString(token)
+	}
+	return out.String()
+}
+
+// stripCSS applies the policy to a stylesheet
+func stripCSS(src string, policy assetserver.Policy) string {
+	var out strings.Builder
+	for i := 0; i < len(src); {
+		switch {
+		case src[i] == '\'' || src[i] == '"':
+			end := skipString(src, i)
+			out.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end == -1 {
+				end = len(src)
+			} else {
+				end += i + 4
+			}
+			comment := src[i:end]
+			if keepComment(comment, policy) {
+				out.WriteString(comment)
+			} else {
+				out.WriteString(removedComment(comment))
+			}
+			i = end
+		default:
+			out.WriteByte(src[i])
+			i++
+		}
+	}
+	return out.String()
+}
+
+var (
+	htmlElement = regexp.MustCompile(`(?is)<!--.*?-->|<(script|style)\b([^>]*)>(.*?)</(?:script|style)\s*>`)
+	htmlType    = regexp.MustCompile(`(?i)\btype\s*=\s*["']?([^"'\s>]+)`)
+)
+
+// stripHTML applies the policy to the comments, the inline scripts and the inline styles of a page
+func stripHTML(src string, policy assetserver.Policy) string {
+	return htmlElement.ReplaceAllStringFunc(src, func(element string) string {
+		match := htmlElement.FindStringSubmatchIndex(element)
+		if match[2] == -1 {
+			// Conditional comments are kept
+			if policy.StripComments && !strings.HasPrefix(element, "<!--[") && !strings.HasPrefix(element, "<!--<![") {
+				return ""
+			}
+			return element
+		}
+		tag, attributes := strings.ToLower(element[match[2]:match[3]]), element[match[4]:match[5]]
+		content := element[match[6]:match[7]]
+		var stripped string
+		if tag == "style" {
+			stripped = stripCSS(content, policy)
+		} else {
+			scriptType := ""
+			if found := htmlType.FindStringSubmatch(attributes); found != nil {
+				scriptType = strings.ToLower(found[1])
+			}
+			switch scriptType {
+			case "", "module", "text/javascript", "application/javascript":
+				stripped = stripJS(content, policy)
+			default:
+				return element
+			}
+		}
+		return element[:match[6]] + stripped + element[match[7]:]
+	})
+}
@@ -0,0 +1,131 @@
+package assetserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+var stripAll = assetserver.Policy{StripSourceMaps: true, StripComments: true, StripConsole: true}
+
+func TestStripJS(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		policy assetserver.Policy
+		want   string
+	}{
+		{
+			name:   "comments",
+			src:    "/*! MIT */\nconst a = 1; // one\n/* two\n */const b = a/*x*/+2;\n",
+			policy: assetserver.Policy{StripComments: true},
+			want:   "/*! MIT */\nconst a = 1; \n\nconst b = a +2;\n",
+		},
+		{
+			name:   "literals",
+			src:    "const url = 'http://a//b'; const re = /\\/\\/[/*]/g; const t = `//${a /* c */ + `//`}`; const d = x / y; // end",
+			policy: assetserver.Policy{StripComments: true},
+			want:   "const url = 'http://a//b'; const re = /\\/\\/[/*]/g; const t = `//${a /* c */ + `//`}`; const d = x / y; ",
+		},
+		{
+			name:   "source map",
+			src:    "run();\n//# sourceMappingURL=app.js.map",
+			policy: assetserver.Policy{StripSourceMaps: true},
+			want:   "run();\n",
+		},
+		{
+			name:   "source map kept",
+			src:    "run(); // one\n//# sourceMappingURL=app.js.map",
+			policy: assetserver.Policy{StripComments: true},
+			want:   "run(); \n//# sourceMappingURL=app.js.map",
+		},
+		{
+			name:   "console",
+			src:    "console.log('a)', f(1), [2]);if(x)console . warn(`${y}`)\nwindow.console.log(1);a&&console.error(e);const log=console.log;",
+			policy: assetserver.Policy{StripConsole: true},
+			want:   "void 0;if(x)void 0\nwindow.console.log(1);a&&void 0;const log=console.log;",
+		},
+		{
+			name:   "nothing",
+			src:    "console.log(1) // one",
+			policy: assetserver.Policy{},
+			want:   "console.log(1) // one",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripJS(tt.src, tt.policy); got != tt.want {
+				t.Errorf("stripJS() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripCSS(t *testing.T) {
+	src := "/*! MIT */\nbody { content: '/* not a comment */'; /* color */ }\n/*# sourceMappingURL=app.css.map */"
+	want := "/*! MIT */\nbody { content: '/* not a comment */';   }\n "
+	if got := stripCSS(src, stripAll); got != want {
+		t.Errorf("stripCSS() = %q, want %q", got, want)
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+	src := `<html><!-- comment --><!--[if IE]>ie<![endif]--><script>console.log(1) // one
+</script><script type="text/template">console.log(1)</script><style>/* two */a{}</style></html>`
+	want := `<html><!--[if IE]>ie<![endif]--><script>void 0 
+</script><script type="text/template">console.log(1)</script><style> a{}</style></html>`
+	if got := stripHTML(src, stripAll); got != want {
+		t.Errorf("stripHTML() = %q, want %q", got, want)
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	assets := fstest.MapFS{
+		"index.html":        {Data: []byte("<html><!-- comment --></html>")},
+		"assets/app.js":     {Data: []byte("console.log(1)\n//# sourceMappingURL=app.js.map")},
+		"assets/app.js.map": {Data: []byte("{}")},
+		"assets/app.css":    {Data: []byte("/* comment */a{}")},
+		"vendor/lib.js":     {Data: []byte("console.log(1)")},
+	}
+	handler, err := NewAssetHandler(assetserver.Options{
+		Assets: assets,
+		Policies: []assetserver.Policy{
+			{Pattern: "*.js", StripSourceMaps: true},
+			{Pattern: "assets/*.js", StripConsole: true},
+			{StripComments: true},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/", http.StatusOK, "<html></html>"},
+		{"/assets/app.js", http.StatusOK, "void 0\n"},
+		{"/assets/app.js.map", http.StatusNotFound, ""},
+		{"/assets/app.css", http.StatusOK, " a{}"},
+		{"/vendor/lib.js", http.StatusOK, "console.log(1)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			body, _ := io.ReadAll(recorder.Body)
+			if recorder.Code != tt.wantStatus || string(body) != tt.wantBody {
+				t.Errorf("%s = %d %q, want %d %q", tt.path, recorder.Code, body, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+
+	if err := (assetserver.Options{Assets: assets, Policies: []assetserver.Policy{{Pattern: "[", StripComments: true}}}).Validate(); err == nil {
+		t.Error("Validate() didn't fail for a malformed pattern")
+	}
+}
@@ -0,0 +1,46 @@
+package assetserver
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// UseTemplates serves the given pages from server-side rendered templates
+func (d *AssetServer) UseTemplates(templates map[string]assetserver.Template) {
+	d.templates = templates
+}
+
+func (d *AssetServer) serveTemplate(rw http.ResponseWriter, req *http.Request, tmpl assetserver.Template) {
+	var data interface{}
+	if tmpl.Data != nil {
+		var err error
+		data, err = tmpl.Data(req)
+		if err != nil {
+			d.serveError(rw, err, "Unable to get the data of the template for %s", req.URL.Path)
+			return
+		}
+	}
+
+	var buffer bytes.Buffer
+	var err error
+	if tmpl.Name != "" {
+		err = tmpl.Template.ExecuteTemplate(&buffer, tmpl.Name, data)
+	} else {
+		err = tmpl.Template.Execute(&buffer, data)
+	}
+	if err != nil {
+		d.serveError(rw, err, "Unable to render the template for %s", req.URL.Path)
+		return
+	}
+
+	content, err := d.processIndexHTML(buffer.Bytes())
+	if err != nil {
+		d.serveError(rw, err, "Unable to processIndexHTML")
+		return
+	}
+	// Rendered pages depend on the request, so they must not be cached
+	rw.Header().Set(HeaderCacheControl, "no-cache")
+	d.writeBlob(rw, indexHTML, content)
+}
@@ -0,0 +1,60 @@
+package assetserver
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+type testRuntimeAssets struct{}
+
+func (testRuntimeAssets) DesktopIPC() []byte       { return nil }
+func (testRuntimeAssets) WebsocketIPC() []byte     { return nil }
+func (testRuntimeAssets) RuntimeDesktopJS() []byte { return nil }
+
+func TestTemplates(t *testing.T) {
+	page := template.Must(template.New("page").Parse(`<html><head></head><body>Hello {{.}}</body></html>`))
+	server, err := NewAssetServer("", assetserver.Options{
+		Templates: map[string]assetserver.Template{
+			"/settings": {
+				Template: page,
+				Data: func(req *http.Request) (interface{}, error) {
+					return req.URL.Query().Get("name"), nil
+				},
+			},
+			"/broken": {
+				Template: page,
+				Data: func(req *http.Request) (interface{}, error) {
+					return nil, errors.New("no data")
+				},
+			},
+		},
+	}, false, nil, testRuntimeAssets{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/settings?name=<World>", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d", recorder.Code)
+	}
+	body := recorder.Body.String()
+	if !strings.Contains(body, "Hello &lt;World&gt;") {
+		t.Errorf("the template has not been rendered: %s", body)
+	}
+	if !strings.Contains(body, runtimeJSPath) || !strings.Contains(body, ipcJSPath) {
+		t.Errorf("the runtime has not been injected: %s", body)
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/broken", nil))
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d for a failing template, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+}
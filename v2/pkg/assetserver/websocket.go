@@ -0,0 +1,110 @@
+package assetserver
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const webSocketTokenParam = "wails-token"
+
+/*
+The custom schemes of the webviews can't carry WebSocket connections on any of the platforms. Therefore the WebSocket
+handlers are served by a HTTP server on the loopback interface. The frontend gets the URL of a handler with
+`window.runtime.WebSocketURL(path)`, which adds a random token that is checked for every connection, so other local
+processes can't connect to the handlers.
+*/
+type webSocketServer struct {
+	baseURL string
+	token   string
+}
+
+var (
+	webSocketServerLock   sync.Mutex
+	sharedWebSocketServer *webSocketServer
+)
+
+// ServeWebSockets serves the given WebSocket handlers and adds `window.runtime.WebSocketURL` to the runtime. The
+// handlers are served by a single server for the lifetime of the application, which is shared by all AssetServers.
+func (d *AssetServer) ServeWebSockets(handlers map[string]http.Handler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	server, err := startWebSocketServer(handlers, d.logger)
+	if err != nil {
+		return fmt.Errorf("unable to serve the WebSocket handlers: %w", err)
+	}
+
+	d.runtimeJS = append(d.runtimeJS, []byte(fmt.Sprintf(
+		"\nwindow.runtime.WebSocketURL=function(path){return %s+path+(path.indexOf('?')===-1?'?':'&')+%s;};\n",
+		strconv.Quote(server.baseURL), strconv.Quote(webSocketTokenParam+"="+server.token)))...)
+	return nil
+}
+
+func startWebSocketServer(handlers map[string]http.Handler, logger Logger) (*webSocketServer, error) {
+	webSocketServerLock.Lock()
+	defer webSocketServerLock.Unlock()
+
+	if sharedWebSocketServer != nil {
+		return sharedWebSocketServer, nil
+	}
+
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &webSocketServer{
+		baseURL: "ws://" + listener.Addr().String(),
+		token:   hex.EncodeToString(token),
+	}
+
+	mux := http.NewServeMux()
+	for path, handler := range handlers {
+		mux.Handle(path, result.authorize(handler))
+	}
+
+	go func() {
+		err := http.Serve(listener, mux)
+		if err != nil && logger != nil {
+			logger.Error("[AssetServer] WebSocket server stopped: %s", err)
+		}
+	}()
+
+	sharedWebSocketServer = result
+	return result, nil
+}
+
+// authorize rejects all requests that aren't WebSocket upgrades with the token of the server
+func (s *webSocketServer) authorize(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !isWebSocket(req) {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		query := req.URL.Query()
+		if subtle.ConstantTimeCompare([]byte(query.Get(webSocketTokenParam)), []byte(s.token)) != 1 {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// Hide the token from the handler
+		query.Del(webSocketTokenParam)
+		req.URL.RawQuery = query.Encode()
+		req.RequestURI = req.URL.RequestURI()
+		handler.ServeHTTP(rw, req)
+	})
+}
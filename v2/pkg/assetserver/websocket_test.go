@@ -0,0 +1,46 @@
+package assetserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebSocketServerAuthorize(t *testing.T) {
+	server := &webSocketServer{token: "secret"}
+
+	var gotQuery string
+	handler := server.authorize(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotQuery = req.URL.RawQuery
+		rw.WriteHeader(http.StatusSwitchingProtocols)
+	}))
+
+	tests := []struct {
+		name      string
+		target    string
+		websocket bool
+		want      int
+	}{
+		{name: "no upgrade", target: "/ws?wails-token=secret", want: http.StatusBadRequest},
+		{name: "no token", target: "/ws", websocket: true, want: http.StatusForbidden},
+		{name: "wrong token", target: "/ws?wails-token=guess", websocket: true, want: http.StatusForbidden},
+		{name: "valid token", target: "/ws?room=1&wails-token=secret", websocket: true, want: http.StatusSwitchingProtocols},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.websocket {
+				req.Header.Set(HeaderUpgrade, "websocket")
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != tt.want {
+				t.Errorf("got status %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+
+	if gotQuery != "room=1" {
+		t.Errorf("the token has not been removed from the query: %s", gotQuery)
+	}
+}
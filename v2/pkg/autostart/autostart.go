@@ -0,0 +1,76 @@
+// Package autostart launches the application at the login of the current user, EG: for applications that live in the
+// system tray. The application can be started hidden at login, the HiddenArgument is passed to it and StartedHidden
+// reports it, EG: for the StartHidden option of options.App.
+package autostart
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HiddenArgument is passed to the application when it is started hidden at login
+const HiddenArgument = "--autostart-hidden"
+
+// ErrNotSupported is returned when the platform has no way to start applications at login
+var ErrNotSupported = errors.New("autostart: starting at login is not supported")
+
+type backend interface {
+	enable(name string, executable string, hidden bool) error
+	disable(name string) error
+	isEnabled(name string, executable string) (bool, error)
+}
+
+var platform = newBackend()
+
+// Set enables or disables the start of the running executable at login. When hidden is set, the HiddenArgument is
+// passed to the application.
+//
+//   - Windows: the executable is added to HKEY_CURRENT_USER\Software\Microsoft\Windows\CurrentVersion\Run.
+//   - macOS: a launch agent is written to ~/Library/LaunchAgents, it is loaded at the next login.
+//   - Linux: a desktop file is written to the autostart directory of the user, EG: ~/.config/autostart.
+func Set(enabled bool, hidden bool) error {
+	name, executable, err := currentExecutable()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return platform.disable(name)
+	}
+	return platform.enable(name, executable, hidden)
+}
+
+// IsEnabled reports whether the running executable is started at login. It is false when the entry has been disabled
+// by the user, EG: in the startup apps of the Task Manager, or when the executable has been moved.
+func IsEnabled() (bool, error) {
+	name, executable, err := currentExecutable()
+	if err != nil {
+		return false, err
+	}
+	return platform.isEnabled(name, executable)
+}
+
+// StartedHidden reports whether the application has been started hidden at login
+func StartedHidden() bool {
+	for _, arg := range os.Args[1:] {
+		if arg == HiddenArgument {
+			return true
+		}
+	}
+	return false
+}
+
+// currentExecutable returns the name of the entries of the executable, which is its file name without extension
+func currentExecutable() (string, string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", "", err
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return "", "", err
+	}
+	name := strings.TrimSuffix(filepath.Base(executable), filepath.Ext(executable))
+	return name, executable, nil
+}
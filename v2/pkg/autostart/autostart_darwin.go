@@ -0,0 +1,93 @@
+//go:build darwin
+
+package autostart
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// launchAgentBackend writes a launch agent of the user, which launchd starts at login
+type launchAgentBackend struct{}
+
+func newBackend() backend {
+	return launchAgentBackend{}
+}
+
+func launchAgentPath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", launchAgentLabel(name)+".plist"), nil
+}
+
+func launchAgentLabel(name string) string {
+	return "autostart." + strings.ReplaceAll(name, " ", "-")
+}
+
+func xmlEscape(value string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(value)
+}
+
+func launchAgent(name string, executable string, hidden bool) string {
+	arguments := "\t\t<string>" + xmlEscape(executable) + "</string>\n"
+	if hidden {
+		arguments += "\t\t<string>" + HiddenArgument + "</string>\n"
+	}
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>` + xmlEscape(launchAgentLabel(name)) + `</string>
+	<key>ProgramArguments</key>
+	<array>
+` + arguments + `	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>ProcessType</key>
+	<string>Interactive</string>
+</dict>
+</plist>
+`
+}
+
+func (launchAgentBackend) enable(name string, executable string, hidden bool) error {
+	path, err := launchAgentPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(launchAgent(name, executable, hidden)), 0o644)
+}
+
+func (launchAgentBackend) disable(name string) error {
+	path, err := launchAgentPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (launchAgentBackend) isEnabled(name string, executable string) (bool, error) {
+	path, err := launchAgentPath(name)
+	if err != nil {
+		return false, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// The launch agent is outdated when the executable has been moved
+	return string(content) == launchAgent(name, executable, false) || string(content) == launchAgent(name, executable, true), nil
+}
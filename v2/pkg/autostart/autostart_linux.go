@@ -0,0 +1,94 @@
+//go:build linux
+
+package autostart
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// xdgBackend writes the desktop files of the XDG autostart specification, which are started by the desktops at login
+type xdgBackend struct{}
+
+func newBackend() backend {
+	return xdgBackend{}
+}
+
+// autostartDirectory is the directory of the autostart desktop files of the user
+func autostartDirectory() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "autostart"), nil
+}
+
+func desktopFilePath(name string) (string, error) {
+	directory, err := autostartDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(directory, name+".desktop"), nil
+}
+
+func desktopFile(name string, executable string, hidden bool) string {
+	exec := quoteExec(executable)
+	if hidden {
+		exec += " " + HiddenArgument
+	}
+	return "[Desktop Entry]\n" +
+		"Type=Application\n" +
+		"Name=" + name + "\n" +
+		"Exec=" + exec + "\n" +
+		"X-GNOME-Autostart-enabled=true\n"
+}
+
+// quoteExec quotes the argument of the Exec key with the rules of the desktop entry specification
+func quoteExec(arg string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	// The backslashes are escaped again as the value of the key is a string
+	return strings.ReplaceAll(`"`+replacer.Replace(arg)+`"`, `\`, `\\`)
+}
+
+func (xdgBackend) enable(name string, executable string, hidden bool) error {
+	path, err := desktopFilePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(desktopFile(name, executable, hidden)), 0o644)
+}
+
+func (xdgBackend) disable(name string) error {
+	path, err := desktopFilePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (xdgBackend) isEnabled(name string, executable string) (bool, error) {
+	path, err := desktopFilePath(name)
+	if err != nil {
+		return false, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// The desktop file is outdated when the executable has been moved
+	return string(content) == desktopFile(name, executable, false) || string(content) == desktopFile(name, executable, true), nil
+}
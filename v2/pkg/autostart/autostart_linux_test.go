@@ -0,0 +1,50 @@
+package autostart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnable(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	backend := xdgBackend{}
+
+	enabled, err := backend.isEnabled("myapp", "/opt/app/myapp")
+	if err != nil || enabled {
+		t.Fatalf("unexpected state: %v %v", enabled, err)
+	}
+	if err := backend.enable("myapp", "/opt/app/myapp", true); err != nil {
+		t.Fatal(err)
+	}
+
+	directory, _ := autostartDirectory()
+	content, err := os.ReadFile(filepath.Join(directory, "myapp.desktop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[Desktop Entry]\nType=Application\nName=myapp\nExec=\"/opt/app/myapp\" --autostart-hidden\nX-GNOME-Autostart-enabled=true\n"
+	if string(content) != expected {
+		t.Errorf("unexpected desktop file:\n%s", content)
+	}
+
+	enabled, err = backend.isEnabled("myapp", "/opt/app/myapp")
+	if err != nil || !enabled {
+		t.Errorf("unexpected state: %v %v", enabled, err)
+	}
+	enabled, _ = backend.isEnabled("myapp", "/opt/moved/myapp")
+	if enabled {
+		t.Error("the moved executable is enabled")
+	}
+
+	if err := backend.disable("myapp"); err != nil {
+		t.Fatal(err)
+	}
+	enabled, err = backend.isEnabled("myapp", "/opt/app/myapp")
+	if err != nil || enabled {
+		t.Errorf("unexpected state: %v %v", enabled, err)
+	}
+	if err := backend.disable("myapp"); err != nil {
+		t.Errorf("disabling twice failed: %s", err)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !windows
+
+package autostart
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) enable(name string, executable string, hidden bool) error {
+	return ErrNotSupported
+}
+
+func (unsupportedBackend) disable(name string) error {
+	return ErrNotSupported
+}
+
+func (unsupportedBackend) isEnabled(name string, executable string) (bool, error) {
+	return false, ErrNotSupported
+}
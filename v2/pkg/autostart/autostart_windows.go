@@ -0,0 +1,107 @@
+//go:build windows
+
+package autostart
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	runKey = `Software\Microsoft\Windows\CurrentVersion\Run`
+	// startupApprovedKey holds the entries that have been disabled by the user in the Task Manager or the settings
+	startupApprovedKey = `Software\Microsoft\Windows\CurrentVersion\Explorer\StartupApproved\Run`
+)
+
+// registryBackend adds the executable to the Run key of the current user, which doesn't require elevation
+type registryBackend struct{}
+
+func newBackend() backend {
+	return registryBackend{}
+}
+
+func runCommand(executable string, hidden bool) string {
+	command := `"` + executable + `"`
+	if hidden {
+		command += " " + HiddenArgument
+	}
+	return command
+}
+
+func (registryBackend) enable(name string, executable string, hidden bool) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, runKey, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+	if err := key.SetStringValue(name, runCommand(executable, hidden)); err != nil {
+		return err
+	}
+
+	// Enabling the start by the application reverts the choice of the user in the Task Manager
+	approved, err := registry.OpenKey(registry.CURRENT_USER, startupApprovedKey, registry.SET_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer approved.Close()
+	if err := approved.DeleteValue(name); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (registryBackend) disable(name string) error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKey, registry.SET_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+	if err := key.DeleteValue(name); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (registryBackend) isEnabled(name string, executable string) (bool, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKey, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer key.Close()
+	value, _, err := key.GetStringValue(name)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if value != runCommand(executable, false) && value != runCommand(executable, true) {
+		return false, nil
+	}
+	return !isDisabledByUser(name), nil
+}
+
+// isDisabledByUser reads the state of the entry in the StartupApproved key, the lowest bit of the first byte is set
+// when the entry is disabled
+func isDisabledByUser(name string) bool {
+	key, err := registry.OpenKey(registry.CURRENT_USER, startupApprovedKey, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+	state, _, err := key.GetBinaryValue(name)
+	if err != nil || len(state) == 0 {
+		return false
+	}
+	return state[0]&1 == 1
+}
@@ -0,0 +1,23 @@
+#ifndef Bluetooth_darwin_h
+#define Bluetooth_darwin_h
+
+#include <stdbool.h>
+
+// The error codes of the completions
+#define BluetoothOK 0
+#define BluetoothUnknownDevice 1
+#define BluetoothNotConnected 2
+#define BluetoothUnknownCharacteristic 3
+#define BluetoothFailed 4
+
+void* BluetoothNew(void);
+int BluetoothAuthorization(void);
+void BluetoothStartScan(void *bluetooth, const char *servicesJSON);
+void BluetoothStopScan(void *bluetooth);
+void BluetoothConnect(void *bluetooth, int request, const char *identifier);
+void BluetoothDisconnect(void *bluetooth, const char *identifier);
+void BluetoothRead(void *bluetooth, int request, const char *identifier, const char *service, const char *characteristic);
+void BluetoothWrite(void *bluetooth, int request, const char *identifier, const char *service, const char *characteristic, const void *value, int length, bool withResponse);
+void BluetoothSetNotify(void *bluetooth, int request, const char *identifier, const char *service, const char *characteristic, bool enabled);
+
+#endif /* Bluetooth_darwin_h */
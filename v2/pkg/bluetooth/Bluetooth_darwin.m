@@ -0,0 +1,404 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <CoreBluetooth/CoreBluetooth.h>
+#include <string.h>
+
+#import "Bluetooth_darwin.h"
+
+extern void bluetoothStateChanged(int state);
+extern void bluetoothDiscovered(char *deviceJSON);
+extern void bluetoothConnected(int request, char *servicesJSON, int code, char *message);
+extern void bluetoothDisconnected(char *identifier);
+extern void bluetoothCompleted(int request, void *value, int length, int code, char *message);
+extern void bluetoothNotified(char *identifier, char *service, char *characteristic, void *value, int length);
+
+static NSString *const baseUUID = @"-0000-1000-8000-00805f9b34fb";
+
+// fullUUID returns the lowercase 128 bit form of the UUID like the Go side, EG: for the 16 bit UUIDs of CoreBluetooth
+static NSString* fullUUID(CBUUID *uuid) {
+    NSString *value = [uuid.UUIDString lowercaseString];
+    if (value.length == 4) {
+        return [NSString stringWithFormat:@"0000%@%@", value, baseUUID];
+    }
+    if (value.length == 8) {
+        return [value stringByAppendingString:baseUUID];
+    }
+    return value;
+}
+
+static NSString* toJSON(id object) {
+    NSData *data = [NSJSONSerialization dataWithJSONObject:object options:0 error:nil];
+    return [[[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding] autorelease];
+}
+
+static NSArray* characteristicProperties(CBCharacteristic *characteristic) {
+    NSMutableArray *properties = [NSMutableArray array];
+    if (characteristic.properties & CBCharacteristicPropertyRead) {
+        [properties addObject:@"read"];
+    }
+    if (characteristic.properties & CBCharacteristicPropertyWrite) {
+        [properties addObject:@"write"];
+    }
+    if (characteristic.properties & CBCharacteristicPropertyWriteWithoutResponse) {
+        [properties addObject:@"writeWithoutResponse"];
+    }
+    if (characteristic.properties & CBCharacteristicPropertyNotify) {
+        [properties addObject:@"notify"];
+    }
+    if (characteristic.properties & CBCharacteristicPropertyIndicate) {
+        [properties addObject:@"indicate"];
+    }
+    return properties;
+}
+
+// WailsBluetooth handles the delegate callbacks of CoreBluetooth on its own serial queue. The completions of the
+// requests are queued by peripheral, service and characteristic.
+@interface WailsBluetooth : NSObject <CBCentralManagerDelegate, CBPeripheralDelegate>
+@property (nonatomic) dispatch_queue_t queue;
+@property (nonatomic, retain) CBCentralManager *manager;
+@property (nonatomic, retain) NSMutableDictionary<NSString*, CBPeripheral*> *peripherals;
+@property (nonatomic, retain) NSMutableDictionary<NSString*, NSNumber*> *connectRequests;
+@property (nonatomic, retain) NSMutableDictionary<NSString*, NSNumber*> *pendingServices;
+@property (nonatomic, retain) NSMutableDictionary<NSString*, NSMutableArray*> *readRequests;
+@property (nonatomic, retain) NSMutableDictionary<NSString*, NSMutableArray*> *writeRequests;
+@property (nonatomic, retain) NSMutableDictionary<NSString*, NSMutableArray*> *notifyRequests;
+@end
+
+@implementation WailsBluetooth
+
+- (instancetype)init {
+    self = [super init];
+    if (self) {
+        self.queue = dispatch_queue_create("wails.bluetooth", DISPATCH_QUEUE_SERIAL);
+        self.peripherals = [NSMutableDictionary dictionary];
+        self.connectRequests = [NSMutableDictionary dictionary];
+        self.pendingServices = [NSMutableDictionary dictionary];
+        self.readRequests = [NSMutableDictionary dictionary];
+        self.writeRequests = [NSMutableDictionary dictionary];
+        self.notifyRequests = [NSMutableDictionary dictionary];
+        self.manager = [[[CBCentralManager alloc] initWithDelegate:self queue:self.queue] autorelease];
+    }
+    return self;
+}
+
+- (NSString*)keyFor:(CBPeripheral*)peripheral characteristic:(CBCharacteristic*)characteristic {
+    return [NSString stringWithFormat:@"%@/%@/%@", peripheral.identifier.UUIDString, fullUUID(characteristic.service.UUID), fullUUID(characteristic.UUID)];
+}
+
+// find returns the characteristic of the service of the connected peripheral or sets the error code
+- (CBCharacteristic*)find:(NSString*)identifier service:(NSString*)service characteristic:(NSString*)characteristic code:(int*)code {
+    CBPeripheral *peripheral = self.peripherals[identifier];
+    if (peripheral == nil) {
+        *code = BluetoothUnknownDevice;
+        return nil;
+    }
+    if (peripheral.state != CBPeripheralStateConnected) {
+        *code = BluetoothNotConnected;
+        return nil;
+    }
+    for (CBService *candidate in peripheral.services) {
+        if (![fullUUID(candidate.UUID) isEqualToString:service]) {
+            continue;
+        }
+        for (CBCharacteristic *result in candidate.characteristics) {
+            if ([fullUUID(result.UUID) isEqualToString:characteristic]) {
+                return result;
+            }
+        }
+    }
+    *code = BluetoothUnknownCharacteristic;
+    return nil;
+}
+
+- (void)queueRequest:(int)request in:(NSMutableDictionary<NSString*, NSMutableArray*>*)requests key:(NSString*)key {
+    if (requests[key] == nil) {
+        requests[key] = [NSMutableArray array];
+    }
+    [requests[key] addObject:@(request)];
+}
+
+// complete completes the first request of the key or all of them
+- (void)complete:(NSMutableDictionary<NSString*, NSMutableArray*>*)requests key:(NSString*)key all:(bool)all value:(NSData*)value error:(NSError*)error {
+    NSMutableArray *pending = requests[key];
+    while (pending.count > 0) {
+        int request = [pending[0] intValue];
+        [pending removeObjectAtIndex:0];
+        if (error != nil) {
+            bluetoothCompleted(request, NULL, 0, BluetoothFailed, (char*)error.localizedDescription.UTF8String);
+        } else {
+            bluetoothCompleted(request, (void*)value.bytes, (int)value.length, BluetoothOK, NULL);
+        }
+        if (!all) {
+            break;
+        }
+    }
+}
+
+- (void)finishConnect:(CBPeripheral*)peripheral code:(int)code error:(NSError*)error {
+    NSString *identifier = peripheral.identifier.UUIDString;
+    NSNumber *request = self.connectRequests[identifier];
+    [self.pendingServices removeObjectForKey:identifier];
+    if (request == nil) {
+        return;
+    }
+    [self.connectRequests removeObjectForKey:identifier];
+    if (code != BluetoothOK) {
+        NSString *message = error != nil ? error.localizedDescription : @"";
+        bluetoothConnected([request intValue], NULL, code, (char*)message.UTF8String);
+        return;
+    }
+    NSMutableArray *services = [NSMutableArray array];
+    for (CBService *service in peripheral.services) {
+        NSMutableArray *characteristics = [NSMutableArray array];
+        for (CBCharacteristic *characteristic in service.characteristics) {
+            [characteristics addObject:@{@"uuid": fullUUID(characteristic.UUID), @"properties": characteristicProperties(characteristic)}];
+        }
+        [services addObject:@{@"uuid": fullUUID(service.UUID), @"characteristics": characteristics}];
+    }
+    bluetoothConnected([request intValue], (char*)toJSON(services).UTF8String, BluetoothOK, NULL);
+}
+
+- (void)centralManagerDidUpdateState:(CBCentralManager *)central {
+    bluetoothStateChanged((int)central.state);
+}
+
+- (void)centralManager:(CBCentralManager *)central didDiscoverPeripheral:(CBPeripheral *)peripheral advertisementData:(NSDictionary<NSString *,id> *)advertisementData RSSI:(NSNumber *)RSSI {
+    NSString *identifier = peripheral.identifier.UUIDString;
+    self.peripherals[identifier] = peripheral;
+
+    NSString *name = advertisementData[CBAdvertisementDataLocalNameKey] ?: peripheral.name ?: @"";
+    NSMutableArray *services = [NSMutableArray array];
+    for (CBUUID *uuid in advertisementData[CBAdvertisementDataServiceUUIDsKey]) {
+        [services addObject:fullUUID(uuid)];
+    }
+    NSMutableDictionary *device = [NSMutableDictionary dictionaryWithDictionary:@{
+        @"id": identifier,
+        @"name": name,
+        @"rssi": RSSI,
+        @"services": services,
+        @"connected": @(peripheral.state == CBPeripheralStateConnected),
+    }];
+    // The manufacturer data start with the little endian company identifier
+    NSData *data = advertisementData[CBAdvertisementDataManufacturerDataKey];
+    if (data.length >= 2) {
+        const uint8_t *bytes = data.bytes;
+        NSString *company = [NSString stringWithFormat:@"%u", bytes[0] | bytes[1] << 8];
+        NSData *value = [data subdataWithRange:NSMakeRange(2, data.length - 2)];
+        device[@"manufacturerData"] = @{company: [value base64EncodedStringWithOptions:0]};
+    }
+    bluetoothDiscovered((char*)toJSON(device).UTF8String);
+}
+
+- (void)centralManager:(CBCentralManager *)central didConnectPeripheral:(CBPeripheral *)peripheral {
+    peripheral.delegate = self;
+    [peripheral discoverServices:nil];
+}
+
+- (void)centralManager:(CBCentralManager *)central didFailToConnectPeripheral:(CBPeripheral *)peripheral error:(NSError *)error {
+    [self finishConnect:peripheral code:BluetoothFailed error:error];
+}
+
+- (void)centralManager:(CBCentralManager *)central didDisconnectPeripheral:(CBPeripheral *)peripheral error:(NSError *)error {
+    NSString *identifier = peripheral.identifier.UUIDString;
+    [self finishConnect:peripheral code:BluetoothNotConnected error:error];
+    NSError *disconnected = error ?: [NSError errorWithDomain:CBErrorDomain code:CBErrorPeripheralDisconnected userInfo:nil];
+    for (NSMutableDictionary *requests in @[self.readRequests, self.writeRequests, self.notifyRequests]) {
+        for (NSString *key in [requests allKeys]) {
+            if ([key hasPrefix:[identifier stringByAppendingString:@"/"]]) {
+                [self complete:requests key:key all:true value:nil error:disconnected];
+            }
+        }
+    }
+    bluetoothDisconnected((char*)identifier.UTF8String);
+}
+
+- (void)peripheral:(CBPeripheral *)peripheral didDiscoverServices:(NSError *)error {
+    if (error != nil) {
+        [self finishConnect:peripheral code:BluetoothFailed error:error];
+        return;
+    }
+    if (peripheral.services.count == 0) {
+        [self finishConnect:peripheral code:BluetoothOK error:nil];
+        return;
+    }
+    self.pendingServices[peripheral.identifier.UUIDString] = @(peripheral.services.count);
+    for (CBService *service in peripheral.services) {
+        [peripheral discoverCharacteristics:nil forService:service];
+    }
+}
+
+- (void)peripheral:(CBPeripheral *)peripheral didDiscoverCharacteristicsForService:(CBService *)service error:(NSError *)error {
+    NSString *identifier = peripheral.identifier.UUIDString;
+    NSNumber *pending = self.pendingServices[identifier];
+    if (pending == nil) {
+        return;
+    }
+    if (error != nil) {
+        [self finishConnect:peripheral code:BluetoothFailed error:error];
+        return;
+    }
+    if ([pending intValue] <= 1) {
+        [self finishConnect:peripheral code:BluetoothOK error:nil];
+        return;
+    }
+    self.pendingServices[identifier] = @([pending intValue] - 1);
+}
+
+- (void)peripheral:(CBPeripheral *)peripheral didUpdateValueForCharacteristic:(CBCharacteristic *)characteristic error:(NSError *)error {
+    NSString *key = [self keyFor:peripheral characteristic:characteristic];
+    if (self.readRequests[key].count > 0) {
+        [self complete:self.readRequests key:key all:true value:characteristic.value error:error];
+        return;
+    }
+    if (error == nil && characteristic.isNotifying) {
+        NSData *value = characteristic.value;
+        bluetoothNotified((char*)peripheral.identifier.UUIDString.UTF8String, (char*)fullUUID(characteristic.service.UUID).UTF8String, (char*)fullUUID(characteristic.UUID).UTF8String, (void*)value.bytes, (int)value.length);
+    }
+}
+
+- (void)peripheral:(CBPeripheral *)peripheral didWriteValueForCharacteristic:(CBCharacteristic *)characteristic error:(NSError *)error {
+    [self complete:self.writeRequests key:[self keyFor:peripheral characteristic:characteristic] all:false value:nil error:error];
+}
+
+- (void)peripheral:(CBPeripheral *)peripheral didUpdateNotificationStateForCharacteristic:(CBCharacteristic *)characteristic error:(NSError *)error {
+    [self complete:self.notifyRequests key:[self keyFor:peripheral characteristic:characteristic] all:false value:nil error:error];
+}
+
+@end
+
+// shortUUID returns the CBUUID of the UUID, which is short for the UUIDs of the Bluetooth base UUID
+static CBUUID* shortUUID(NSString *uuid) {
+    if ([uuid hasSuffix:baseUUID] && [uuid hasPrefix:@"0000"]) {
+        return [CBUUID UUIDWithString:[uuid substringWithRange:NSMakeRange(4, 4)]];
+    }
+    return [CBUUID UUIDWithString:uuid];
+}
+
+void* BluetoothNew(void) {
+    return [WailsBluetooth new];
+}
+
+// BluetoothAuthorization returns the CBManagerAuthorization of the application
+int BluetoothAuthorization(void) {
+    if (@available(macOS 10.15, *)) {
+        return (int)[CBManager authorization];
+    }
+    return 3;
+}
+
+void BluetoothStartScan(void *bluetooth, const char *servicesJSON) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    @autoreleasepool {
+        NSData *data = [NSData dataWithBytes:servicesJSON length:strlen(servicesJSON)];
+        NSMutableArray *services = nil;
+        for (NSString *uuid in [NSJSONSerialization JSONObjectWithData:data options:0 error:nil]) {
+            if (services == nil) {
+                services = [NSMutableArray array];
+            }
+            [services addObject:shortUUID(uuid)];
+        }
+        dispatch_async(b.queue, ^{
+            [b.manager scanForPeripheralsWithServices:services options:@{CBCentralManagerScanOptionAllowDuplicatesKey: @YES}];
+        });
+    }
+}
+
+void BluetoothStopScan(void *bluetooth) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    dispatch_async(b.queue, ^{
+        [b.manager stopScan];
+    });
+}
+
+void BluetoothConnect(void *bluetooth, int request, const char *identifier) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    @autoreleasepool {
+        NSString *peripheralIdentifier = [NSString stringWithUTF8String:identifier];
+        dispatch_async(b.queue, ^{
+            CBPeripheral *peripheral = b.peripherals[peripheralIdentifier];
+            if (peripheral == nil) {
+                bluetoothConnected(request, NULL, BluetoothUnknownDevice, "");
+                return;
+            }
+            b.connectRequests[peripheralIdentifier] = @(request);
+            [b.manager connectPeripheral:peripheral options:nil];
+        });
+    }
+}
+
+void BluetoothDisconnect(void *bluetooth, const char *identifier) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    @autoreleasepool {
+        NSString *peripheralIdentifier = [NSString stringWithUTF8String:identifier];
+        dispatch_async(b.queue, ^{
+            CBPeripheral *peripheral = b.peripherals[peripheralIdentifier];
+            if (peripheral != nil) {
+                [b.manager cancelPeripheralConnection:peripheral];
+            }
+        });
+    }
+}
+
+void BluetoothRead(void *bluetooth, int request, const char *identifier, const char *service, const char *characteristic) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    @autoreleasepool {
+        NSString *peripheralIdentifier = [NSString stringWithUTF8String:identifier];
+        NSString *serviceUUID = [NSString stringWithUTF8String:service];
+        NSString *characteristicUUID = [NSString stringWithUTF8String:characteristic];
+        dispatch_async(b.queue, ^{
+            int code = BluetoothOK;
+            CBCharacteristic *found = [b find:peripheralIdentifier service:serviceUUID characteristic:characteristicUUID code:&code];
+            if (found == nil) {
+                bluetoothCompleted(request, NULL, 0, code, "");
+                return;
+            }
+            [b queueRequest:request in:b.readRequests key:[b keyFor:found.service.peripheral characteristic:found]];
+            [found.service.peripheral readValueForCharacteristic:found];
+        });
+    }
+}
+
+void BluetoothWrite(void *bluetooth, int request, const char *identifier, const char *service, const char *characteristic, const void *value, int length, bool withResponse) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    @autoreleasepool {
+        NSString *peripheralIdentifier = [NSString stringWithUTF8String:identifier];
+        NSString *serviceUUID = [NSString stringWithUTF8String:service];
+        NSString *characteristicUUID = [NSString stringWithUTF8String:characteristic];
+        NSData *data = [NSData dataWithBytes:value length:length];
+        dispatch_async(b.queue, ^{
+            int code = BluetoothOK;
+            CBCharacteristic *found = [b find:peripheralIdentifier service:serviceUUID characteristic:characteristicUUID code:&code];
+            if (found == nil) {
+                bluetoothCompleted(request, NULL, 0, code, "");
+                return;
+            }
+            if (!withResponse) {
+                [found.service.peripheral writeValue:data forCharacteristic:found type:CBCharacteristicWriteWithoutResponse];
+                bluetoothCompleted(request, NULL, 0, BluetoothOK, NULL);
+                return;
+            }
+            [b queueRequest:request in:b.writeRequests key:[b keyFor:found.service.peripheral characteristic:found]];
+            [found.service.peripheral writeValue:data forCharacteristic:found type:CBCharacteristicWriteWithResponse];
+        });
+    }
+}
+
+void BluetoothSetNotify(void *bluetooth, int request, const char *identifier, const char *service, const char *characteristic, bool enabled) {
+    WailsBluetooth *b = (WailsBluetooth*)bluetooth;
+    @autoreleasepool {
+        NSString *peripheralIdentifier = [NSString stringWithUTF8String:identifier];
+        NSString *serviceUUID = [NSString stringWithUTF8String:service];
+        NSString *characteristicUUID = [NSString stringWithUTF8String:characteristic];
+        dispatch_async(b.queue, ^{
+            int code = BluetoothOK;
+            CBCharacteristic *found = [b find:peripheralIdentifier service:serviceUUID characteristic:characteristicUUID code:&code];
+            if (found == nil) {
+                bluetoothCompleted(request, NULL, 0, code, "");
+                return;
+            }
+            [b queueRequest:request in:b.notifyRequests key:[b keyFor:found.service.peripheral characteristic:found]];
+            [found.service.peripheral setNotifyValue:enabled forCharacteristic:found];
+        });
+    }
+}
@@ -0,0 +1,342 @@
+// Package bluetooth scans for Bluetooth LE devices and reads, writes and subscribes to their GATT characteristics, as
+// Web Bluetooth isn't available in the webviews. It uses BlueZ on Linux and CoreBluetooth on macOS. Windows isn't
+// supported yet. Bind the Bluetooth service to use it from JS and use EmitEvents to stream the scan results and the
+// notifications to the frontend. Values are base64 strings in JS.
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// The events that are emitted by EmitEvents
+const (
+	// DeviceEvent is emitted with a Device when it is discovered or its advertisement changes
+	DeviceEvent = "bluetooth:device"
+	// NotificationEvent is emitted with a Notification for every notification of a subscribed characteristic
+	NotificationEvent = "bluetooth:notification"
+	// DisconnectedEvent is emitted with the ID of a device when it disconnects
+	DisconnectedEvent = "bluetooth:disconnected"
+)
+
+var (
+	// ErrNotSupported is returned when the platform or the computer doesn't support Bluetooth LE
+	ErrNotSupported = errors.New("bluetooth: not supported")
+	// ErrPermissionDenied is returned when the user or the sandbox doesn't allow the application to use Bluetooth
+	ErrPermissionDenied = errors.New("bluetooth: permission denied")
+	// ErrPoweredOff is returned when Bluetooth is turned off
+	ErrPoweredOff = errors.New("bluetooth: powered off")
+	// ErrUnknownDevice is returned for devices that haven't been discovered
+	ErrUnknownDevice = errors.New("bluetooth: unknown device")
+	// ErrNotConnected is returned for GATT operations of devices that aren't connected
+	ErrNotConnected = errors.New("bluetooth: not connected")
+	// ErrUnknownCharacteristic is returned for characteristics that the device doesn't provide
+	ErrUnknownCharacteristic = errors.New("bluetooth: unknown characteristic")
+)
+
+// Permission is the state of the permission of the application to use Bluetooth
+type Permission string
+
+const (
+	PermissionGranted Permission = "granted"
+	PermissionDenied  Permission = "denied"
+	// PermissionUndetermined means that the user hasn't been asked yet. The first use of the service asks.
+	PermissionUndetermined Permission = "undetermined"
+)
+
+// Device is a discovered Bluetooth LE device
+type Device struct {
+	// ID identifies the device, EG: its address on Linux or the identifier of CoreBluetooth on macOS
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	RSSI int    `json:"rssi"`
+	// Services are the UUIDs of the advertised services
+	Services []string `json:"services"`
+	// ManufacturerData are the manufacturer specific data of the advertisement by company identifier
+	ManufacturerData map[uint16][]byte `json:"manufacturerData,omitempty"`
+	Connected        bool              `json:"connected"`
+}
+
+// ScanOptions are the options of a scan
+type ScanOptions struct {
+	// Services only reports the devices that advertise one of the service UUIDs
+	Services []string `json:"services"`
+}
+
+// Service is a GATT service of a connected device
+type Service struct {
+	UUID            string           `json:"uuid"`
+	Characteristics []Characteristic `json:"characteristics"`
+}
+
+// Characteristic is a GATT characteristic of a service
+type Characteristic struct {
+	UUID string `json:"uuid"`
+	// Properties are the supported operations: read, write, writeWithoutResponse, notify and indicate
+	Properties []string `json:"properties"`
+}
+
+// Notification is the new value of a subscribed characteristic
+type Notification struct {
+	DeviceID       string `json:"deviceId"`
+	Service        string `json:"service"`
+	Characteristic string `json:"characteristic"`
+	Value          []byte `json:"value"`
+}
+
+// adapter is the Bluetooth LE stack of the platform. The UUIDs are normalised.
+type adapter interface {
+	permission() (Permission, error)
+	startScan(services []string, found func(Device)) error
+	stopScan() error
+	connect(id string, disconnected func()) ([]Service, error)
+	disconnect(id string) error
+	read(id string, service string, characteristic string) ([]byte, error)
+	write(id string, service string, characteristic string, value []byte, withResponse bool) error
+	subscribe(id string, service string, characteristic string, notify func([]byte)) error
+	unsubscribe(id string, service string, characteristic string) error
+}
+
+// Bluetooth is the Bluetooth LE service
+type Bluetooth struct {
+	// OnDevice is called when a device is discovered or its advertisement changes
+	OnDevice func(device Device)
+	// OnNotification is called for the notifications of the subscribed characteristics
+	OnNotification func(notification Notification)
+	// OnDisconnected is called with the ID of a connected device when it disconnects
+	OnDisconnected func(id string)
+
+	init    sync.Once
+	adapter adapter
+	initErr error
+
+	lock    sync.Mutex
+	devices map[string]Device
+}
+
+// New creates the Bluetooth service. The platform stack is started on first use, which asks the user for permission
+// on macOS.
+func New() *Bluetooth {
+	return &Bluetooth{devices: map[string]Device{}}
+}
+
+// EmitEvents emits the discovered devices, the notifications and the disconnections as events to the frontend
+func EmitEvents(ctx context.Context, b *Bluetooth) {
+	b.OnDevice = func(device Device) {
+		runtime.EventsEmit(ctx, DeviceEvent, device)
+	}
+	b.OnNotification = func(notification Notification) {
+		runtime.EventsEmit(ctx, NotificationEvent, notification)
+	}
+	b.OnDisconnected = func(id string) {
+		runtime.EventsEmit(ctx, DisconnectedEvent, id)
+	}
+}
+
+func (b *Bluetooth) getAdapter() (adapter, error) {
+	b.init.Do(func() {
+		b.adapter, b.initErr = newAdapter()
+	})
+	return b.adapter, b.initErr
+}
+
+// Permission returns the state of the permission of the application
+func (b *Bluetooth) Permission() (Permission, error) {
+	a, err := b.getAdapter()
+	if err != nil {
+		return "", err
+	}
+	return a.permission()
+}
+
+// StartScan starts scanning for devices, which are passed to OnDevice. Scanning again replaces the options.
+func (b *Bluetooth) StartScan(options ScanOptions) error {
+	a, err := b.getAdapter()
+	if err != nil {
+		return err
+	}
+	services, err := normaliseUUIDs(options.Services)
+	if err != nil {
+		return err
+	}
+	return a.startScan(services, b.found)
+}
+
+// StopScan stops scanning
+func (b *Bluetooth) StopScan() error {
+	a, err := b.getAdapter()
+	if err != nil {
+		return err
+	}
+	return a.stopScan()
+}
+
+func (b *Bluetooth) found(device Device) {
+	b.lock.Lock()
+	b.devices[device.ID] = device
+	b.lock.Unlock()
+	if b.OnDevice != nil {
+		b.OnDevice(device)
+	}
+}
+
+// Devices returns the discovered devices, sorted by signal strength
+func (b *Bluetooth) Devices() []Device {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	result := make([]Device, 0, len(b.devices))
+	for _, device := range b.devices {
+		result = append(result, device)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RSSI != result[j].RSSI {
+			return result[i].RSSI > result[j].RSSI
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// Connect connects to a discovered device and returns its GATT services
+func (b *Bluetooth) Connect(id string) ([]Service, error) {
+	a, err := b.getAdapter()
+	if err != nil {
+		return nil, err
+	}
+	b.lock.Lock()
+	_, known := b.devices[id]
+	b.lock.Unlock()
+	if !known {
+		return nil, fmt.Errorf("%w %s", ErrUnknownDevice, id)
+	}
+	services, err := a.connect(id, func() {
+		b.setConnected(id, false)
+		if b.OnDisconnected != nil {
+			b.OnDisconnected(id)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.setConnected(id, true)
+	return services, nil
+}
+
+func (b *Bluetooth) setConnected(id string, connected bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if device, ok := b.devices[id]; ok {
+		device.Connected = connected
+		b.devices[id] = device
+	}
+}
+
+// Disconnect disconnects from the device
+func (b *Bluetooth) Disconnect(id string) error {
+	a, err := b.getAdapter()
+	if err != nil {
+		return err
+	}
+	return a.disconnect(id)
+}
+
+// Read reads the value of the characteristic of the service. UUIDs can be short, EG: "180d".
+func (b *Bluetooth) Read(id string, service string, characteristic string) ([]byte, error) {
+	a, service, characteristic, err := b.resolve(service, characteristic)
+	if err != nil {
+		return nil, err
+	}
+	return a.read(id, service, characteristic)
+}
+
+// Write writes the value of the characteristic of the service, with or without response of the device
+func (b *Bluetooth) Write(id string, service string, characteristic string, value []byte, withResponse bool) error {
+	a, service, characteristic, err := b.resolve(service, characteristic)
+	if err != nil {
+		return err
+	}
+	return a.write(id, service, characteristic, value, withResponse)
+}
+
+// Subscribe subscribes to the notifications or indications of the characteristic, which are passed to
+// OnNotification
+func (b *Bluetooth) Subscribe(id string, service string, characteristic string) error {
+	a, service, characteristic, err := b.resolve(service, characteristic)
+	if err != nil {
+		return err
+	}
+	return a.subscribe(id, service, characteristic, func(value []byte) {
+		if b.OnNotification != nil {
+			b.OnNotification(Notification{DeviceID: id, Service: service, Characteristic: characteristic, Value: value})
+		}
+	})
+}
+
+// Unsubscribe stops the notifications of the characteristic
+func (b *Bluetooth) Unsubscribe(id string, service string, characteristic string) error {
+	a, service, characteristic, err := b.resolve(service, characteristic)
+	if err != nil {
+		return err
+	}
+	return a.unsubscribe(id, service, characteristic)
+}
+
+func (b *Bluetooth) resolve(service string, characteristic string) (adapter, string, string, error) {
+	a, err := b.getAdapter()
+	if err != nil {
+		return nil, "", "", err
+	}
+	service, err = NormaliseUUID(service)
+	if err != nil {
+		return nil, "", "", err
+	}
+	characteristic, err = NormaliseUUID(characteristic)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return a, service, characteristic, nil
+}
+
+// baseUUID is the Bluetooth base UUID that the 16 and 32 bit UUIDs are short for
+const baseUUID = "-0000-1000-8000-00805f9b34fb"
+
+// NormaliseUUID returns the lowercase 128 bit form of the UUID, EG: "0000180d-0000-1000-8000-00805f9b34fb" for "180D"
+func NormaliseUUID(uuid string) (string, error) {
+	uuid = strings.ToLower(strings.TrimSpace(uuid))
+	switch len(uuid) {
+	case 4:
+		uuid = "0000" + uuid + baseUUID
+	case 8:
+		uuid += baseUUID
+	}
+	if len(uuid) != 36 || uuid[8] != '-' || uuid[13] != '-' || uuid[18] != '-' || uuid[23] != '-' {
+		return "", fmt.Errorf("bluetooth: invalid UUID %s", uuid)
+	}
+	for index, r := range uuid {
+		if index == 8 || index == 13 || index == 18 || index == 23 {
+			continue
+		}
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return "", fmt.Errorf("bluetooth: invalid UUID %s", uuid)
+		}
+	}
+	return uuid, nil
+}
+
+func normaliseUUIDs(uuids []string) ([]string, error) {
+	result := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		normalised, err := NormaliseUUID(uuid)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, normalised)
+	}
+	return result, nil
+}
@@ -0,0 +1,344 @@
+//go:build darwin
+
+package bluetooth
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework CoreBluetooth
+
+#include <stdlib.h>
+#import "Bluetooth_darwin.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// The CBManagerState of the central manager
+const (
+	stateUnknown = iota
+	stateResetting
+	stateUnsupported
+	stateUnauthorized
+	statePoweredOff
+	statePoweredOn
+)
+
+// requestTimeout is how long the requests wait for CoreBluetooth, which doesn't time out connections
+const requestTimeout = 30 * time.Second
+
+// cbAdapter uses a CBCentralManager. Scanning needs the NSBluetoothAlwaysUsageDescription key in Info.plist.
+type cbAdapter struct {
+	bluetooth unsafe.Pointer
+}
+
+type completion struct {
+	value []byte
+	err   error
+}
+
+// There is one central manager, which delivers its callbacks to the globals
+var (
+	darwinLock   sync.Mutex
+	managerState = stateUnknown
+	stateChanged = make(chan struct{})
+	requests     = map[int]chan completion{}
+	nextRequest  int
+	foundDevice  func(Device)
+	disconnected = map[string]func(){}
+	notify       = map[string]func([]byte){}
+)
+
+//export bluetoothStateChanged
+func bluetoothStateChanged(state C.int) {
+	darwinLock.Lock()
+	managerState = int(state)
+	close(stateChanged)
+	stateChanged = make(chan struct{})
+	darwinLock.Unlock()
+}
+
+//export bluetoothDiscovered
+func bluetoothDiscovered(deviceJSON *C.char) {
+	var device Device
+	if json.Unmarshal([]byte(C.GoString(deviceJSON)), &device) != nil {
+		return
+	}
+	darwinLock.Lock()
+	found := foundDevice
+	darwinLock.Unlock()
+	if found != nil {
+		found(device)
+	}
+}
+
+//export bluetoothConnected
+func bluetoothConnected(request C.int, servicesJSON *C.char, code C.int, message *C.char) {
+	if code != C.BluetoothOK {
+		complete(int(request), nil, completionError(code, message))
+		return
+	}
+	complete(int(request), []byte(C.GoString(servicesJSON)), nil)
+}
+
+//export bluetoothDisconnected
+func bluetoothDisconnected(identifier *C.char) {
+	id := C.GoString(identifier)
+	darwinLock.Lock()
+	callback := disconnected[id]
+	delete(disconnected, id)
+	for key := range notify {
+		if strings.HasPrefix(key, id+"/") {
+			delete(notify, key)
+		}
+	}
+	darwinLock.Unlock()
+	if callback != nil {
+		callback()
+	}
+}
+
+//export bluetoothCompleted
+func bluetoothCompleted(request C.int, value unsafe.Pointer, length C.int, code C.int, message *C.char) {
+	if code != C.BluetoothOK {
+		complete(int(request), nil, completionError(code, message))
+		return
+	}
+	var data []byte
+	if value != nil {
+		data = C.GoBytes(value, length)
+	}
+	complete(int(request), data, nil)
+}
+
+//export bluetoothNotified
+func bluetoothNotified(identifier *C.char, service *C.char, characteristic *C.char, value unsafe.Pointer, length C.int) {
+	darwinLock.Lock()
+	callback := notify[characteristicKey(C.GoString(identifier), C.GoString(service), C.GoString(characteristic))]
+	darwinLock.Unlock()
+	if callback != nil {
+		callback(C.GoBytes(value, length))
+	}
+}
+
+func completionError(code C.int, message *C.char) error {
+	text := ""
+	if message != nil {
+		text = C.GoString(message)
+	}
+	switch code {
+	case C.BluetoothUnknownDevice:
+		return ErrUnknownDevice
+	case C.BluetoothNotConnected:
+		return ErrNotConnected
+	case C.BluetoothUnknownCharacteristic:
+		return ErrUnknownCharacteristic
+	}
+	return errors.New("bluetooth: " + text)
+}
+
+func characteristicKey(id string, service string, characteristic string) string {
+	return id + "/" + service + "/" + characteristic
+}
+
+func complete(request int, value []byte, err error) {
+	darwinLock.Lock()
+	result := requests[request]
+	delete(requests, request)
+	darwinLock.Unlock()
+	if result != nil {
+		result <- completion{value: value, err: err}
+	}
+}
+
+// do starts a request and waits for its completion
+func do(start func(request C.int)) ([]byte, error) {
+	result := make(chan completion, 1)
+	darwinLock.Lock()
+	nextRequest++
+	request := nextRequest
+	requests[request] = result
+	darwinLock.Unlock()
+	start(C.int(request))
+	select {
+	case completed := <-result:
+		return completed.value, completed.err
+	case <-time.After(requestTimeout):
+		darwinLock.Lock()
+		delete(requests, request)
+		darwinLock.Unlock()
+		return nil, fmt.Errorf("bluetooth: no response in %v", requestTimeout)
+	}
+}
+
+func newAdapter() (adapter, error) {
+	return &cbAdapter{bluetooth: C.BluetoothNew()}, nil
+}
+
+// ready waits for the central manager to power on, which includes the user answering the permission prompt
+func (a *cbAdapter) ready() error {
+	timeout := time.After(requestTimeout)
+	for {
+		darwinLock.Lock()
+		state, changed := managerState, stateChanged
+		darwinLock.Unlock()
+		switch state {
+		case statePoweredOn:
+			return nil
+		case statePoweredOff:
+			return ErrPoweredOff
+		case stateUnauthorized:
+			return ErrPermissionDenied
+		case stateUnsupported:
+			return ErrNotSupported
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			return fmt.Errorf("bluetooth: not ready in %v", requestTimeout)
+		}
+	}
+}
+
+func (a *cbAdapter) permission() (Permission, error) {
+	// CBManagerAuthorization
+	switch C.BluetoothAuthorization() {
+	case 0:
+		return PermissionUndetermined, nil
+	case 3:
+		return PermissionGranted, nil
+	default:
+		return PermissionDenied, nil
+	}
+}
+
+func (a *cbAdapter) startScan(services []string, found func(Device)) error {
+	err := a.ready()
+	if err != nil {
+		return err
+	}
+	servicesJSON, err := json.Marshal(services)
+	if err != nil {
+		return err
+	}
+	darwinLock.Lock()
+	foundDevice = found
+	darwinLock.Unlock()
+	cServices := C.CString(string(servicesJSON))
+	defer C.free(unsafe.Pointer(cServices))
+	C.BluetoothStartScan(a.bluetooth, cServices)
+	return nil
+}
+
+func (a *cbAdapter) stopScan() error {
+	darwinLock.Lock()
+	foundDevice = nil
+	darwinLock.Unlock()
+	C.BluetoothStopScan(a.bluetooth)
+	return nil
+}
+
+func (a *cbAdapter) connect(id string, onDisconnected func()) ([]Service, error) {
+	err := a.ready()
+	if err != nil {
+		return nil, err
+	}
+	cID := C.CString(id)
+	defer C.free(unsafe.Pointer(cID))
+	darwinLock.Lock()
+	disconnected[id] = onDisconnected
+	darwinLock.Unlock()
+	servicesJSON, err := do(func(request C.int) {
+		C.BluetoothConnect(a.bluetooth, request, cID)
+	})
+	if err != nil {
+		darwinLock.Lock()
+		delete(disconnected, id)
+		darwinLock.Unlock()
+		// Cancel the pending connection
+		C.BluetoothDisconnect(a.bluetooth, cID)
+		return nil, err
+	}
+	var services []Service
+	err = json.Unmarshal(servicesJSON, &services)
+	return services, err
+}
+
+func (a *cbAdapter) disconnect(id string) error {
+	cID := C.CString(id)
+	defer C.free(unsafe.Pointer(cID))
+	C.BluetoothDisconnect(a.bluetooth, cID)
+	return nil
+}
+
+// characteristic calls the function with the C strings of the characteristic, which are freed after it returns
+func characteristic(id string, service string, characteristic string, call func(cID *C.char, cService *C.char, cCharacteristic *C.char)) {
+	cID := C.CString(id)
+	defer C.free(unsafe.Pointer(cID))
+	cService := C.CString(service)
+	defer C.free(unsafe.Pointer(cService))
+	cCharacteristic := C.CString(characteristic)
+	defer C.free(unsafe.Pointer(cCharacteristic))
+	call(cID, cService, cCharacteristic)
+}
+
+func (a *cbAdapter) read(id string, service string, uuid string) ([]byte, error) {
+	var value []byte
+	var err error
+	characteristic(id, service, uuid, func(cID *C.char, cService *C.char, cCharacteristic *C.char) {
+		value, err = do(func(request C.int) {
+			C.BluetoothRead(a.bluetooth, request, cID, cService, cCharacteristic)
+		})
+	})
+	return value, err
+}
+
+func (a *cbAdapter) write(id string, service string, uuid string, value []byte, withResponse bool) error {
+	var err error
+	cValue := C.CBytes(value)
+	defer C.free(cValue)
+	characteristic(id, service, uuid, func(cID *C.char, cService *C.char, cCharacteristic *C.char) {
+		_, err = do(func(request C.int) {
+			C.BluetoothWrite(a.bluetooth, request, cID, cService, cCharacteristic, cValue, C.int(len(value)), C.bool(withResponse))
+		})
+	})
+	return err
+}
+
+func (a *cbAdapter) subscribe(id string, service string, uuid string, callback func([]byte)) error {
+	key := characteristicKey(id, service, uuid)
+	darwinLock.Lock()
+	notify[key] = callback
+	darwinLock.Unlock()
+	err := a.setNotify(id, service, uuid, true)
+	if err != nil {
+		darwinLock.Lock()
+		delete(notify, key)
+		darwinLock.Unlock()
+	}
+	return err
+}
+
+func (a *cbAdapter) unsubscribe(id string, service string, uuid string) error {
+	darwinLock.Lock()
+	delete(notify, characteristicKey(id, service, uuid))
+	darwinLock.Unlock()
+	return a.setNotify(id, service, uuid, false)
+}
+
+func (a *cbAdapter) setNotify(id string, service string, uuid string, enabled bool) error {
+	var err error
+	characteristic(id, service, uuid, func(cID *C.char, cService *C.char, cCharacteristic *C.char) {
+		_, err = do(func(request C.int) {
+			C.BluetoothSetNotify(a.bluetooth, request, cID, cService, cCharacteristic, C.bool(enabled))
+		})
+	})
+	return err
+}
@@ -0,0 +1,535 @@
+//go:build linux
+
+package bluetooth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	bluez                   = "org.bluez"
+	adapterInterface        = "org.bluez.Adapter1"
+	deviceInterface         = "org.bluez.Device1"
+	serviceInterface        = "org.bluez.GattService1"
+	characteristicInterface = "org.bluez.GattCharacteristic1"
+	propertiesInterface     = "org.freedesktop.DBus.Properties"
+	objectManagerInterface  = "org.freedesktop.DBus.ObjectManager"
+
+	// servicesTimeout is how long Connect waits for BlueZ to discover the services of the device
+	servicesTimeout = 30 * time.Second
+)
+
+type managedObjects map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+
+// bluezAdapter uses the first adapter of BlueZ over the system bus
+type bluezAdapter struct {
+	conn *dbus.Conn
+	path dbus.ObjectPath
+
+	lock sync.Mutex
+	// found is set while scanning
+	found        func(Device)
+	filter       []string
+	devices      map[dbus.ObjectPath]map[string]dbus.Variant
+	disconnected map[dbus.ObjectPath]func()
+	notify       map[dbus.ObjectPath]func([]byte)
+}
+
+func newAdapter() (adapter, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	objects, err := getManagedObjects(conn)
+	if err != nil {
+		conn.Close()
+		return nil, bluezError(err)
+	}
+	path := findAdapter(objects)
+	if path == "" {
+		conn.Close()
+		return nil, fmt.Errorf("%w: no adapter", ErrNotSupported)
+	}
+
+	a := &bluezAdapter{
+		conn:         conn,
+		path:         path,
+		devices:      map[dbus.ObjectPath]map[string]dbus.Variant{},
+		disconnected: map[dbus.ObjectPath]func(){},
+		notify:       map[dbus.ObjectPath]func([]byte){},
+	}
+	err = conn.AddMatchSignal(
+		dbus.WithMatchInterface(propertiesInterface),
+		dbus.WithMatchMember("PropertiesChanged"),
+		dbus.WithMatchPathNamespace(path),
+	)
+	if err == nil {
+		err = conn.AddMatchSignal(
+			dbus.WithMatchObjectPath("/"),
+			dbus.WithMatchInterface(objectManagerInterface),
+			dbus.WithMatchMember("InterfacesAdded"),
+		)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, bluezError(err)
+	}
+	signals := make(chan *dbus.Signal, 64)
+	conn.Signal(signals)
+	go a.dispatch(signals)
+	return a, nil
+}
+
+func getManagedObjects(conn *dbus.Conn) (managedObjects, error) {
+	var objects managedObjects
+	err := conn.Object(bluez, "/").Call(objectManagerInterface+".GetManagedObjects", 0).Store(&objects)
+	return objects, err
+}
+
+// findAdapter returns the path of the first adapter, EG: /org/bluez/hci0
+func findAdapter(objects managedObjects) dbus.ObjectPath {
+	var paths []string
+	for path, interfaces := range objects {
+		if _, ok := interfaces[adapterInterface]; ok {
+			paths = append(paths, string(path))
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	sort.Strings(paths)
+	return dbus.ObjectPath(paths[0])
+}
+
+// bluezError maps the errors of BlueZ and D-Bus to the errors of the package
+func bluezError(err error) error {
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+	switch dbusErr.Name {
+	case "org.freedesktop.DBus.Error.AccessDenied", "org.bluez.Error.NotAuthorized", "org.bluez.Error.NotPermitted":
+		return fmt.Errorf("%w: %v", ErrPermissionDenied, err)
+	case "org.freedesktop.DBus.Error.ServiceUnknown", "org.freedesktop.DBus.Error.NameHasNoOwner":
+		// The sandbox of Flatpak hides BlueZ unless the application has --allow=bluetooth
+		if _, statErr := os.Stat("/.flatpak-info"); statErr == nil {
+			return fmt.Errorf("%w: %v", ErrPermissionDenied, err)
+		}
+		return fmt.Errorf("%w: %v", ErrNotSupported, err)
+	case "org.bluez.Error.NotReady":
+		return fmt.Errorf("%w: %v", ErrPoweredOff, err)
+	case "org.bluez.Error.NotConnected":
+		return fmt.Errorf("%w: %v", ErrNotConnected, err)
+	case "org.freedesktop.DBus.Error.UnknownObject":
+		return fmt.Errorf("%w: %v", ErrUnknownDevice, err)
+	}
+	return err
+}
+
+func (a *bluezAdapter) permission() (Permission, error) {
+	_, err := a.conn.Object(bluez, a.path).GetProperty(adapterInterface + ".Powered")
+	if err != nil {
+		err = bluezError(err)
+		if errors.Is(err, ErrPermissionDenied) {
+			return PermissionDenied, nil
+		}
+		return "", err
+	}
+	return PermissionGranted, nil
+}
+
+func (a *bluezAdapter) startScan(services []string, found func(Device)) error {
+	filter := map[string]dbus.Variant{
+		"Transport":     dbus.MakeVariant("le"),
+		"DuplicateData": dbus.MakeVariant(true),
+	}
+	if len(services) > 0 {
+		filter["UUIDs"] = dbus.MakeVariant(services)
+	}
+	adapter := a.conn.Object(bluez, a.path)
+	err := adapter.Call(adapterInterface+".SetDiscoveryFilter", 0, filter).Err
+	if err != nil {
+		return bluezError(err)
+	}
+	objects, err := getManagedObjects(a.conn)
+	if err != nil {
+		return bluezError(err)
+	}
+
+	a.lock.Lock()
+	scanning := a.found != nil
+	a.found = found
+	a.filter = services
+	var known []Device
+	for path, interfaces := range objects {
+		properties, ok := interfaces[deviceInterface]
+		if !ok || !strings.HasPrefix(string(path), string(a.path)+"/") {
+			continue
+		}
+		a.devices[path] = properties
+		// Report the devices that BlueZ has already seen
+		if device := parseDevice(properties); device.RSSI != 0 && matchesServices(device, services) {
+			known = append(known, device)
+		}
+	}
+	a.lock.Unlock()
+	for _, device := range known {
+		found(device)
+	}
+
+	if scanning {
+		return nil
+	}
+	err = adapter.Call(adapterInterface+".StartDiscovery", 0).Err
+	if err != nil {
+		a.lock.Lock()
+		a.found = nil
+		a.lock.Unlock()
+		return bluezError(err)
+	}
+	return nil
+}
+
+func (a *bluezAdapter) stopScan() error {
+	a.lock.Lock()
+	scanning := a.found != nil
+	a.found = nil
+	a.lock.Unlock()
+	if !scanning {
+		return nil
+	}
+	err := a.conn.Object(bluez, a.path).Call(adapterInterface+".StopDiscovery", 0).Err
+	if err != nil {
+		return bluezError(err)
+	}
+	return nil
+}
+
+// dispatch delivers the signals of BlueZ until the connection is closed
+func (a *bluezAdapter) dispatch(signals chan *dbus.Signal) {
+	for signal := range signals {
+		switch signal.Name {
+		case objectManagerInterface + ".InterfacesAdded":
+			if len(signal.Body) < 2 {
+				continue
+			}
+			path, _ := signal.Body[0].(dbus.ObjectPath)
+			interfaces, _ := signal.Body[1].(map[string]map[string]dbus.Variant)
+			if properties, ok := interfaces[deviceInterface]; ok && strings.HasPrefix(string(path), string(a.path)+"/") {
+				a.deviceChanged(path, properties, true)
+			}
+		case propertiesInterface + ".PropertiesChanged":
+			if len(signal.Body) < 2 {
+				continue
+			}
+			name, _ := signal.Body[0].(string)
+			changed, _ := signal.Body[1].(map[string]dbus.Variant)
+			switch name {
+			case deviceInterface:
+				a.deviceChanged(signal.Path, changed, false)
+			case characteristicInterface:
+				value, ok := changed["Value"].Value().([]byte)
+				if !ok {
+					continue
+				}
+				a.lock.Lock()
+				notify := a.notify[signal.Path]
+				a.lock.Unlock()
+				if notify != nil {
+					notify(value)
+				}
+			}
+		}
+	}
+}
+
+func (a *bluezAdapter) deviceChanged(path dbus.ObjectPath, changed map[string]dbus.Variant, added bool) {
+	a.lock.Lock()
+	properties := a.devices[path]
+	if properties == nil || added {
+		properties = map[string]dbus.Variant{}
+		a.devices[path] = properties
+	}
+	for name, value := range changed {
+		properties[name] = value
+	}
+	device := parseDevice(properties)
+	found, filter := a.found, a.filter
+	var disconnected func()
+	if connected, ok := changed["Connected"].Value().(bool); ok && !connected {
+		disconnected = a.disconnected[path]
+		delete(a.disconnected, path)
+		for characteristic := range a.notify {
+			if strings.HasPrefix(string(characteristic), string(path)+"/") {
+				delete(a.notify, characteristic)
+			}
+		}
+	}
+	a.lock.Unlock()
+
+	if disconnected != nil {
+		disconnected()
+	}
+	_, rssi := changed["RSSI"]
+	_, data := changed["ManufacturerData"]
+	if found != nil && (added || rssi || data) && matchesServices(device, filter) {
+		found(device)
+	}
+}
+
+// parseDevice returns the device of the properties of org.bluez.Device1
+func parseDevice(properties map[string]dbus.Variant) Device {
+	device := Device{}
+	device.ID, _ = properties["Address"].Value().(string)
+	if alias, ok := properties["Alias"].Value().(string); ok {
+		device.Name = alias
+	}
+	if name, ok := properties["Name"].Value().(string); ok {
+		device.Name = name
+	}
+	if rssi, ok := properties["RSSI"].Value().(int16); ok {
+		device.RSSI = int(rssi)
+	}
+	if uuids, ok := properties["UUIDs"].Value().([]string); ok {
+		for _, uuid := range uuids {
+			device.Services = append(device.Services, strings.ToLower(uuid))
+		}
+	}
+	if data, ok := properties["ManufacturerData"].Value().(map[uint16]dbus.Variant); ok {
+		device.ManufacturerData = map[uint16][]byte{}
+		for company, value := range data {
+			if bytes, ok := value.Value().([]byte); ok {
+				device.ManufacturerData[company] = bytes
+			}
+		}
+	}
+	device.Connected, _ = properties["Connected"].Value().(bool)
+	return device
+}
+
+// matchesServices reports if the device advertises one of the services. Every device matches no services.
+func matchesServices(device Device, services []string) bool {
+	if len(services) == 0 {
+		return true
+	}
+	for _, service := range services {
+		for _, advertised := range device.Services {
+			if advertised == service {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// devicePath returns the path of the device with the address, EG: /org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF
+func (a *bluezAdapter) devicePath(id string) dbus.ObjectPath {
+	return a.path + "/dev_" + dbus.ObjectPath(strings.ReplaceAll(strings.ToUpper(id), ":", "_"))
+}
+
+func (a *bluezAdapter) connect(id string, disconnected func()) ([]Service, error) {
+	path := a.devicePath(id)
+	device := a.conn.Object(bluez, path)
+	a.lock.Lock()
+	a.disconnected[path] = disconnected
+	a.lock.Unlock()
+	err := device.Call(deviceInterface+".Connect", 0).Err
+	if err != nil {
+		a.lock.Lock()
+		delete(a.disconnected, path)
+		a.lock.Unlock()
+		return nil, bluezError(err)
+	}
+
+	deadline := time.Now().Add(servicesTimeout)
+	for {
+		resolved, err := device.GetProperty(deviceInterface + ".ServicesResolved")
+		if err != nil {
+			return nil, bluezError(err)
+		}
+		if value, _ := resolved.Value().(bool); value {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("bluetooth: the services of %s weren't discovered in %v", id, servicesTimeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	objects, err := getManagedObjects(a.conn)
+	if err != nil {
+		return nil, bluezError(err)
+	}
+	return parseServices(objects, path), nil
+}
+
+func (a *bluezAdapter) disconnect(id string) error {
+	err := a.conn.Object(bluez, a.devicePath(id)).Call(deviceInterface+".Disconnect", 0).Err
+	if err != nil {
+		return bluezError(err)
+	}
+	return nil
+}
+
+// parseServices returns the GATT services of the device, sorted by UUID
+func parseServices(objects managedObjects, device dbus.ObjectPath) []Service {
+	services := map[dbus.ObjectPath]*Service{}
+	for path, interfaces := range objects {
+		properties, ok := interfaces[serviceInterface]
+		if !ok {
+			continue
+		}
+		if parent, _ := properties["Device"].Value().(dbus.ObjectPath); parent != device {
+			continue
+		}
+		uuid, _ := properties["UUID"].Value().(string)
+		services[path] = &Service{UUID: strings.ToLower(uuid), Characteristics: []Characteristic{}}
+	}
+	for _, interfaces := range objects {
+		properties, ok := interfaces[characteristicInterface]
+		if !ok {
+			continue
+		}
+		parent, _ := properties["Service"].Value().(dbus.ObjectPath)
+		service := services[parent]
+		if service == nil {
+			continue
+		}
+		uuid, _ := properties["UUID"].Value().(string)
+		flags, _ := properties["Flags"].Value().([]string)
+		service.Characteristics = append(service.Characteristics, Characteristic{
+			UUID:       strings.ToLower(uuid),
+			Properties: parseFlags(flags),
+		})
+	}
+
+	result := make([]Service, 0, len(services))
+	for _, service := range services {
+		sort.Slice(service.Characteristics, func(i, j int) bool {
+			return service.Characteristics[i].UUID < service.Characteristics[j].UUID
+		})
+		result = append(result, *service)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UUID < result[j].UUID
+	})
+	return result
+}
+
+// parseFlags returns the properties of the flags of BlueZ, EG: writeWithoutResponse for write-without-response
+func parseFlags(flags []string) []string {
+	properties := []string{}
+	for _, flag := range flags {
+		switch flag {
+		case "read", "write", "notify", "indicate":
+			properties = append(properties, flag)
+		case "write-without-response":
+			properties = append(properties, "writeWithoutResponse")
+		}
+	}
+	return properties
+}
+
+// characteristicPath returns the path of the characteristic of the service of the connected device
+func (a *bluezAdapter) characteristicPath(id string, service string, characteristic string) (dbus.ObjectPath, error) {
+	objects, err := getManagedObjects(a.conn)
+	if err != nil {
+		return "", bluezError(err)
+	}
+	return findCharacteristic(objects, a.devicePath(id), service, characteristic)
+}
+
+func findCharacteristic(objects managedObjects, device dbus.ObjectPath, service string, characteristic string) (dbus.ObjectPath, error) {
+	properties, ok := objects[device][deviceInterface]
+	if !ok {
+		return "", ErrUnknownDevice
+	}
+	if connected, _ := properties["Connected"].Value().(bool); !connected {
+		return "", ErrNotConnected
+	}
+	for path, interfaces := range objects {
+		properties, ok := interfaces[characteristicInterface]
+		if !ok {
+			continue
+		}
+		if uuid, _ := properties["UUID"].Value().(string); !strings.EqualFold(uuid, characteristic) {
+			continue
+		}
+		parent, _ := properties["Service"].Value().(dbus.ObjectPath)
+		uuid, _ := objects[parent][serviceInterface]["UUID"].Value().(string)
+		if parentDevice, _ := objects[parent][serviceInterface]["Device"].Value().(dbus.ObjectPath); parentDevice == device && strings.EqualFold(uuid, service) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w %s of service %s", ErrUnknownCharacteristic, characteristic, service)
+}
+
+func (a *bluezAdapter) read(id string, service string, characteristic string) ([]byte, error) {
+	path, err := a.characteristicPath(id, service, characteristic)
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	err = a.conn.Object(bluez, path).Call(characteristicInterface+".ReadValue", 0, map[string]dbus.Variant{}).Store(&value)
+	if err != nil {
+		return nil, bluezError(err)
+	}
+	return value, nil
+}
+
+func (a *bluezAdapter) write(id string, service string, characteristic string, value []byte, withResponse bool) error {
+	path, err := a.characteristicPath(id, service, characteristic)
+	if err != nil {
+		return err
+	}
+	writeType := "command"
+	if withResponse {
+		writeType = "request"
+	}
+	options := map[string]dbus.Variant{"type": dbus.MakeVariant(writeType)}
+	err = a.conn.Object(bluez, path).Call(characteristicInterface+".WriteValue", 0, value, options).Err
+	if err != nil {
+		return bluezError(err)
+	}
+	return nil
+}
+
+func (a *bluezAdapter) subscribe(id string, service string, characteristic string, notify func([]byte)) error {
+	path, err := a.characteristicPath(id, service, characteristic)
+	if err != nil {
+		return err
+	}
+	a.lock.Lock()
+	a.notify[path] = notify
+	a.lock.Unlock()
+	err = a.conn.Object(bluez, path).Call(characteristicInterface+".StartNotify", 0).Err
+	if err != nil {
+		a.lock.Lock()
+		delete(a.notify, path)
+		a.lock.Unlock()
+		return bluezError(err)
+	}
+	return nil
+}
+
+func (a *bluezAdapter) unsubscribe(id string, service string, characteristic string) error {
+	path, err := a.characteristicPath(id, service, characteristic)
+	if err != nil {
+		return err
+	}
+	a.lock.Lock()
+	delete(a.notify, path)
+	a.lock.Unlock()
+	err = a.conn.Object(bluez, path).Call(characteristicInterface+".StopNotify", 0).Err
+	if err != nil {
+		return bluezError(err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+//go:build linux
+
+package bluetooth
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const device = dbus.ObjectPath("/org/bluez/hci0/dev_AA_BB_CC_DD_EE_FF")
+
+func testObjects(connected bool) managedObjects {
+	return managedObjects{
+		"/org/bluez/hci1": {adapterInterface: {}},
+		"/org/bluez/hci0": {adapterInterface: {}},
+		device: {deviceInterface: {
+			"Address":   dbus.MakeVariant("AA:BB:CC:DD:EE:FF"),
+			"Alias":     dbus.MakeVariant("AA-BB-CC-DD-EE-FF"),
+			"Name":      dbus.MakeVariant("Heart Rate"),
+			"RSSI":      dbus.MakeVariant(int16(-58)),
+			"UUIDs":     dbus.MakeVariant([]string{"0000180D-0000-1000-8000-00805F9B34FB"}),
+			"Connected": dbus.MakeVariant(connected),
+			"ManufacturerData": dbus.MakeVariant(map[uint16]dbus.Variant{
+				76: dbus.MakeVariant([]byte{2, 21}),
+			}),
+		}},
+		device + "/service000c": {serviceInterface: {
+			"UUID":   dbus.MakeVariant("0000180d-0000-1000-8000-00805f9b34fb"),
+			"Device": dbus.MakeVariant(device),
+		}},
+		device + "/service000c/char000f": {characteristicInterface: {
+			"UUID":    dbus.MakeVariant("00002a39-0000-1000-8000-00805f9b34fb"),
+			"Service": dbus.MakeVariant(device + "/service000c"),
+			"Flags":   dbus.MakeVariant([]string{"write", "write-without-response", "authorize"}),
+		}},
+		device + "/service000c/char000d": {characteristicInterface: {
+			"UUID":    dbus.MakeVariant("00002a37-0000-1000-8000-00805f9b34fb"),
+			"Service": dbus.MakeVariant(device + "/service000c"),
+			"Flags":   dbus.MakeVariant([]string{"notify"}),
+		}},
+		"/org/bluez/hci0/dev_11_22_33_44_55_66/service0001": {serviceInterface: {
+			"UUID":   dbus.MakeVariant("0000180f-0000-1000-8000-00805f9b34fb"),
+			"Device": dbus.MakeVariant(dbus.ObjectPath("/org/bluez/hci0/dev_11_22_33_44_55_66")),
+		}},
+	}
+}
+
+func TestFindAdapter(t *testing.T) {
+	if got := findAdapter(testObjects(false)); got != "/org/bluez/hci0" {
+		t.Errorf("findAdapter() = %v, want /org/bluez/hci0", got)
+	}
+	if got := findAdapter(managedObjects{}); got != "" {
+		t.Errorf("findAdapter() = %v, want none", got)
+	}
+}
+
+func TestParseDevice(t *testing.T) {
+	want := Device{
+		ID:               "AA:BB:CC:DD:EE:FF",
+		Name:             "Heart Rate",
+		RSSI:             -58,
+		Services:         []string{"0000180d-0000-1000-8000-00805f9b34fb"},
+		ManufacturerData: map[uint16][]byte{76: {2, 21}},
+		Connected:        true,
+	}
+	if got := parseDevice(testObjects(true)[device][deviceInterface]); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDevice() = %v, want %v", got, want)
+	}
+	if !matchesServices(want, []string{"0000180f-0000-1000-8000-00805f9b34fb", "0000180d-0000-1000-8000-00805f9b34fb"}) {
+		t.Error("matchesServices() = false, want true")
+	}
+	if matchesServices(want, []string{"0000180f-0000-1000-8000-00805f9b34fb"}) {
+		t.Error("matchesServices() = true, want false")
+	}
+}
+
+func TestParseServices(t *testing.T) {
+	want := []Service{{
+		UUID: "0000180d-0000-1000-8000-00805f9b34fb",
+		Characteristics: []Characteristic{
+			{UUID: "00002a37-0000-1000-8000-00805f9b34fb", Properties: []string{"notify"}},
+			{UUID: "00002a39-0000-1000-8000-00805f9b34fb", Properties: []string{"write", "writeWithoutResponse"}},
+		},
+	}}
+	if got := parseServices(testObjects(true), device); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseServices() = %v, want %v", got, want)
+	}
+}
+
+func TestFindCharacteristic(t *testing.T) {
+	got, err := findCharacteristic(testObjects(true), device, "0000180d-0000-1000-8000-00805f9b34fb", "00002a37-0000-1000-8000-00805f9b34fb")
+	if err != nil || got != device+"/service000c/char000d" {
+		t.Errorf("findCharacteristic() = %v, %v", got, err)
+	}
+	_, err = findCharacteristic(testObjects(true), device, "0000180f-0000-1000-8000-00805f9b34fb", "00002a37-0000-1000-8000-00805f9b34fb")
+	if !errors.Is(err, ErrUnknownCharacteristic) {
+		t.Errorf("findCharacteristic() error = %v, want ErrUnknownCharacteristic", err)
+	}
+	_, err = findCharacteristic(testObjects(false), device, "0000180d-0000-1000-8000-00805f9b34fb", "00002a37-0000-1000-8000-00805f9b34fb")
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("findCharacteristic() error = %v, want ErrNotConnected", err)
+	}
+	_, err = findCharacteristic(testObjects(true), "/org/bluez/hci0/dev_00", "0000180d-0000-1000-8000-00805f9b34fb", "00002a37-0000-1000-8000-00805f9b34fb")
+	if !errors.Is(err, ErrUnknownDevice) {
+		t.Errorf("findCharacteristic() error = %v, want ErrUnknownDevice", err)
+	}
+}
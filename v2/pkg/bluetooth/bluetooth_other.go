@@ -0,0 +1,7 @@
+//go:build !linux && !darwin
+
+package bluetooth
+
+func newAdapter() (adapter, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,117 @@
+package bluetooth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormaliseUUID(t *testing.T) {
+	tests := []struct {
+		uuid    string
+		want    string
+		wantErr bool
+	}{
+		{uuid: "180D", want: "0000180d-0000-1000-8000-00805f9b34fb"},
+		{uuid: "0000180d", want: "0000180d-0000-1000-8000-00805f9b34fb"},
+		{uuid: "6E400001-B5A3-F393-E0A9-E50E24DCCA9E", want: "6e400001-b5a3-f393-e0a9-e50e24dcca9e"},
+		{uuid: "180", wantErr: true},
+		{uuid: "6e400001b5a3f393e0a9e50e24dcca9e0000", wantErr: true},
+		{uuid: "6e400001-b5a3-f393-e0a9-e50e24dccaxx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			got, err := NormaliseUUID(tt.uuid)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormaliseUUID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormaliseUUID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeAdapter records the operations and keeps the callbacks
+type fakeAdapter struct {
+	adapter
+	found        func(Device)
+	disconnected func()
+	notify       func([]byte)
+	subscribed   string
+}
+
+func (f *fakeAdapter) startScan(services []string, found func(Device)) error {
+	f.found = found
+	return nil
+}
+
+func (f *fakeAdapter) connect(id string, disconnected func()) ([]Service, error) {
+	f.disconnected = disconnected
+	return []Service{{UUID: "0000180d-0000-1000-8000-00805f9b34fb"}}, nil
+}
+
+func (f *fakeAdapter) subscribe(id string, service string, characteristic string, notify func([]byte)) error {
+	f.subscribed = id + " " + service + " " + characteristic
+	f.notify = notify
+	return nil
+}
+
+func newFake() (*Bluetooth, *fakeAdapter) {
+	fake := &fakeAdapter{}
+	b := New()
+	b.init.Do(func() {
+		b.adapter = fake
+	})
+	return b, fake
+}
+
+func TestBluetooth(t *testing.T) {
+	b, fake := newFake()
+	var devices []Device
+	var notifications []Notification
+	var disconnected []string
+	b.OnDevice = func(device Device) { devices = append(devices, device) }
+	b.OnNotification = func(notification Notification) { notifications = append(notifications, notification) }
+	b.OnDisconnected = func(id string) { disconnected = append(disconnected, id) }
+
+	if _, err := b.Connect("AA:BB"); !errors.Is(err, ErrUnknownDevice) {
+		t.Fatalf("Connect() error = %v, want ErrUnknownDevice", err)
+	}
+	if err := b.StartScan(ScanOptions{Services: []string{"nope"}}); err == nil {
+		t.Fatal("StartScan() accepted an invalid UUID")
+	}
+	if err := b.StartScan(ScanOptions{Services: []string{"180d"}}); err != nil {
+		t.Fatal(err)
+	}
+	fake.found(Device{ID: "AA:BB", RSSI: -70})
+	fake.found(Device{ID: "CC:DD", RSSI: -40})
+	fake.found(Device{ID: "AA:BB", RSSI: -60})
+	if len(devices) != 3 {
+		t.Fatalf("OnDevice was called %d times, want 3", len(devices))
+	}
+	if got := b.Devices(); len(got) != 2 || got[0].ID != "CC:DD" || got[1].RSSI != -60 {
+		t.Fatalf("Devices() = %v", got)
+	}
+
+	if _, err := b.Connect("AA:BB"); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Devices(); !got[1].Connected {
+		t.Fatalf("Devices() = %v, want AA:BB connected", got)
+	}
+	if err := b.Subscribe("AA:BB", "180D", "2A37"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "AA:BB 0000180d-0000-1000-8000-00805f9b34fb 00002a37-0000-1000-8000-00805f9b34fb"; fake.subscribed != want {
+		t.Fatalf("subscribed %q, want %q", fake.subscribed, want)
+	}
+	fake.notify([]byte{0, 72})
+	if len(notifications) != 1 || notifications[0].Characteristic != "00002a37-0000-1000-8000-00805f9b34fb" || notifications[0].Value[1] != 72 {
+		t.Fatalf("notifications = %v", notifications)
+	}
+
+	fake.disconnected()
+	if len(disconnected) != 1 || disconnected[0] != "AA:BB" || b.Devices()[1].Connected {
+		t.Fatalf("disconnected = %v, devices = %v", disconnected, b.Devices())
+	}
+}
@@ -0,0 +1,21 @@
+#ifndef Calendars_darwin_h
+#define Calendars_darwin_h
+
+// The entities and the statuses of the access
+#define CalendarEntityEvents    0
+#define CalendarEntityReminders 1
+
+#define CalendarUndetermined 0
+#define CalendarGranted      1
+#define CalendarDenied       2
+#define CalendarRestricted   3
+
+int CalendarsStatus(int entity);
+int CalendarsRequest(int entity);
+
+// The results are JSON arrays that have to be freed, the calendar IDs are a JSON array too
+char* CalendarsGetCalendars(int entity);
+char* CalendarsGetEvents(double start, double end, const char *calendarIDsJSON);
+char* CalendarsGetReminders(const char *calendarIDsJSON);
+
+#endif /* Calendars_darwin_h */
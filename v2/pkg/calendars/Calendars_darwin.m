@@ -0,0 +1,169 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AppKit/AppKit.h>
+#import <EventKit/EventKit.h>
+
+#import "Calendars_darwin.h"
+
+// store is shared, as every store reads all calendars
+static EKEventStore* store(void) {
+    static EKEventStore *eventStore;
+    static dispatch_once_t once;
+    dispatch_once(&once, ^{
+        eventStore = [EKEventStore new];
+    });
+    return eventStore;
+}
+
+static EKEntityType entityType(int entity) {
+    return entity == CalendarEntityReminders ? EKEntityTypeReminder : EKEntityTypeEvent;
+}
+
+static char* toJSON(id object) {
+    NSData *data = [NSJSONSerialization dataWithJSONObject:object options:0 error:nil];
+    NSString *json = [[[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding] autorelease];
+    return strdup(json.UTF8String);
+}
+
+static NSString* nonNil(NSString *value) {
+    return value == nil ? @"" : value;
+}
+
+static NSString* hexColor(NSColor *color) {
+    NSColor *rgb = [color colorUsingColorSpace:[NSColorSpace sRGBColorSpace]];
+    if (rgb == nil) {
+        return @"";
+    }
+    return [NSString stringWithFormat:@"#%02X%02X%02X", (int)lround(rgb.redComponent * 255), (int)lround(rgb.greenComponent * 255), (int)lround(rgb.blueComponent * 255)];
+}
+
+// calendarsWithIDs returns the calendars of the JSON array of IDs, nil for all calendars. The unknown IDs are
+// skipped.
+static NSArray<EKCalendar*>* calendarsWithIDs(const char *calendarIDsJSON) {
+    NSData *data = [NSData dataWithBytes:calendarIDsJSON length:strlen(calendarIDsJSON)];
+    NSArray *ids = [NSJSONSerialization JSONObjectWithData:data options:0 error:nil];
+    if (![ids isKindOfClass:[NSArray class]] || ids.count == 0) {
+        return nil;
+    }
+    NSMutableArray *calendars = [NSMutableArray array];
+    for (NSString *identifier in ids) {
+        EKCalendar *calendar = [store() calendarWithIdentifier:identifier];
+        if (calendar != nil) {
+            [calendars addObject:calendar];
+        }
+    }
+    return calendars;
+}
+
+// Full access is required from macOS 14, the access to add events only is denied
+int CalendarsStatus(int entity) {
+    switch ([EKEventStore authorizationStatusForEntityType:entityType(entity)]) {
+    case EKAuthorizationStatusNotDetermined:
+        return CalendarUndetermined;
+    case EKAuthorizationStatusRestricted:
+        return CalendarRestricted;
+    case EKAuthorizationStatusAuthorized:
+        return CalendarGranted;
+    default:
+        return CalendarDenied;
+    }
+}
+
+// CalendarsRequest asks with the NSCalendarsFullAccessUsageDescription or NSRemindersFullAccessUsageDescription of
+// Info.plist, NSCalendarsUsageDescription and NSRemindersUsageDescription before macOS 14
+int CalendarsRequest(int entity) {
+    dispatch_semaphore_t answered = dispatch_semaphore_create(0);
+    void (^completion)(BOOL, NSError*) = ^(BOOL granted, NSError *error) {
+        dispatch_semaphore_signal(answered);
+    };
+    if (@available(macOS 14.0, *)) {
+        if (entity == CalendarEntityReminders) {
+            [store() requestFullAccessToRemindersWithCompletion:completion];
+        } else {
+            [store() requestFullAccessToEventsWithCompletion:completion];
+        }
+    } else {
+        [store() requestAccessToEntityType:entityType(entity) completion:completion];
+    }
+    dispatch_semaphore_wait(answered, DISPATCH_TIME_FOREVER);
+    dispatch_release(answered);
+    // The store doesn't see the calendars that it couldn't access when it was created
+    [store() reset];
+    return CalendarsStatus(entity);
+}
+
+char* CalendarsGetCalendars(int entity) {
+    @autoreleasepool {
+        NSMutableArray *result = [NSMutableArray array];
+        for (EKCalendar *calendar in [store() calendarsForEntityType:entityType(entity)]) {
+            [result addObject:@{
+                @"id": calendar.calendarIdentifier,
+                @"title": nonNil(calendar.title),
+                @"color": hexColor(calendar.color),
+                @"source": nonNil(calendar.source.title),
+            }];
+        }
+        return toJSON(result);
+    }
+}
+
+char* CalendarsGetEvents(double start, double end, const char *calendarIDsJSON) {
+    @autoreleasepool {
+        NSArray *calendars = calendarsWithIDs(calendarIDsJSON);
+        NSMutableArray *result = [NSMutableArray array];
+        if (calendars != nil && calendars.count == 0) {
+            return toJSON(result);
+        }
+        NSPredicate *predicate = [store() predicateForEventsWithStartDate:[NSDate dateWithTimeIntervalSince1970:start]
+                                                                  endDate:[NSDate dateWithTimeIntervalSince1970:end]
+                                                                calendars:calendars];
+        for (EKEvent *event in [store() eventsMatchingPredicate:predicate]) {
+            [result addObject:@{
+                @"id": nonNil(event.eventIdentifier),
+                @"calendarId": event.calendar.calendarIdentifier,
+                @"title": nonNil(event.title),
+                @"location": nonNil(event.location),
+                @"notes": nonNil(event.notes),
+                @"start": @(event.startDate.timeIntervalSince1970),
+                @"end": @(event.endDate.timeIntervalSince1970),
+                @"allDay": @(event.allDay),
+                @"recurring": @(event.hasRecurrenceRules || event.isDetached),
+            }];
+        }
+        return toJSON(result);
+    }
+}
+
+char* CalendarsGetReminders(const char *calendarIDsJSON) {
+    @autoreleasepool {
+        NSArray *calendars = calendarsWithIDs(calendarIDsJSON);
+        NSMutableArray *result = [NSMutableArray array];
+        if (calendars != nil && calendars.count == 0) {
+            return toJSON(result);
+        }
+        dispatch_semaphore_t fetched = dispatch_semaphore_create(0);
+        NSPredicate *predicate = [store() predicateForRemindersInCalendars:calendars];
+        [store() fetchRemindersMatchingPredicate:predicate completion:^(NSArray<EKReminder*> *reminders) {
+            for (EKReminder *reminder in reminders) {
+                NSMutableDictionary *item = [NSMutableDictionary dictionaryWithDictionary:@{
+                    @"id": reminder.calendarItemIdentifier,
+                    @"calendarId": reminder.calendar.calendarIdentifier,
+                    @"title": nonNil(reminder.title),
+                    @"notes": nonNil(reminder.notes),
+                    @"completed": @(reminder.completed),
+                    @"priority": @(reminder.priority),
+                }];
+                NSDate *due = reminder.dueDateComponents == nil ? nil : [[NSCalendar currentCalendar] dateFromComponents:reminder.dueDateComponents];
+                if (due != nil) {
+                    item[@"due"] = @(due.timeIntervalSince1970);
+                }
+                [result addObject:item];
+            }
+            dispatch_semaphore_signal(fetched);
+        }];
+        dispatch_semaphore_wait(fetched, DISPATCH_TIME_FOREVER);
+        dispatch_release(fetched);
+        return toJSON(result);
+    }
+}
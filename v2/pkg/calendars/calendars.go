@@ -0,0 +1,202 @@
+// Package calendars reads the calendars, events and reminders of the calendar stores of the operating system, EG: for
+// scheduling applications. It uses EventKit on macOS and Evolution Data Server on Linux, which also contains the
+// accounts of GNOME Online Accounts. Windows isn't supported, as its appointments API is only available to packaged
+// applications. The access is read-only and asks the user for permission on macOS. Bind the Calendars service to use it
+// from JS.
+package calendars
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/permissions"
+)
+
+// Entity is the kind of the items of a calendar, the user grants the access to each of them separately on macOS
+type Entity string
+
+const (
+	Events    Entity = "events"
+	Reminders Entity = "reminders"
+)
+
+var (
+	// ErrNotSupported is returned when the platform has no calendar store
+	ErrNotSupported = errors.New("calendars: not supported")
+	// ErrAccessDenied is returned when the user or a policy doesn't allow the access to the calendars
+	ErrAccessDenied = errors.New("calendars: access denied")
+	// ErrUnknownEntity is returned for entities other than Events and Reminders
+	ErrUnknownEntity = errors.New("calendars: unknown entity")
+	// ErrInvalidRange is returned when the end of the range isn't after its start
+	ErrInvalidRange = errors.New("calendars: the end is not after the start")
+)
+
+// Calendar is a calendar of events or a list of reminders
+type Calendar struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Entity Entity `json:"entity"`
+	// Color is the colour of the calendar as #RRGGBB, empty if it has none
+	Color string `json:"color,omitempty"`
+	// Source is the account of the calendar, EG: iCloud or a Google account
+	Source string `json:"source,omitempty"`
+}
+
+// Event is an occurrence of an event. The occurrences of a repeating event share the ID.
+type Event struct {
+	ID         string    `json:"id"`
+	CalendarID string    `json:"calendarId"`
+	Title      string    `json:"title"`
+	Location   string    `json:"location,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	// AllDay events start and end at midnight in the local time zone
+	AllDay    bool `json:"allDay"`
+	Recurring bool `json:"recurring"`
+}
+
+// Reminder is a reminder or a task
+type Reminder struct {
+	ID         string `json:"id"`
+	CalendarID string `json:"calendarId"`
+	Title      string `json:"title"`
+	Notes      string `json:"notes,omitempty"`
+	// Due is the date the reminder is due, nil if it has none
+	Due       *time.Time `json:"due,omitempty"`
+	Completed bool       `json:"completed"`
+	// Priority is from 1, the highest, to 9, the lowest, or 0 if the reminder has none
+	Priority int `json:"priority"`
+}
+
+type backend interface {
+	status(entity Entity) (permissions.Status, error)
+	// request asks the user for the undetermined access and returns the answer
+	request(entity Entity) (permissions.Status, error)
+	calendars(entity Entity) ([]Calendar, error)
+	// events returns the occurrences that overlap the range in the calendars, all calendars if calendarIDs is empty
+	events(start time.Time, end time.Time, calendarIDs []string) ([]Event, error)
+	reminders(calendarIDs []string) ([]Reminder, error)
+}
+
+// Calendars is the calendars service
+type Calendars struct {
+	backend backend
+}
+
+// New creates the calendars service
+func New() *Calendars {
+	return &Calendars{backend: newBackend()}
+}
+
+func validEntity(entity Entity) error {
+	if entity != Events && entity != Reminders {
+		return fmt.Errorf("%w: %s", ErrUnknownEntity, entity)
+	}
+	return nil
+}
+
+// Status returns the status of the access to the entity without asking the user
+func (c *Calendars) Status(entity Entity) (permissions.Status, error) {
+	if err := validEntity(entity); err != nil {
+		return "", err
+	}
+	return c.backend.status(entity)
+}
+
+// RequestAccess asks the user for the access to the entity if it is undetermined and returns its status. The readers
+// ask on first use, RequestAccess asks up front, EG: on an onboarding screen.
+func (c *Calendars) RequestAccess(entity Entity) (permissions.Status, error) {
+	status, err := c.Status(entity)
+	if err != nil || status != permissions.Undetermined {
+		return status, err
+	}
+	return c.backend.request(entity)
+}
+
+func (c *Calendars) access(entity Entity) error {
+	status, err := c.RequestAccess(entity)
+	if err != nil {
+		return err
+	}
+	if status != permissions.Granted {
+		return fmt.Errorf("%w: %s", ErrAccessDenied, status)
+	}
+	return nil
+}
+
+// Calendars returns the calendars of the entity, sorted by source and title
+func (c *Calendars) Calendars(entity Entity) ([]Calendar, error) {
+	if err := c.access(entity); err != nil {
+		return nil, err
+	}
+	result, err := c.backend.calendars(entity)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Source != result[j].Source {
+			return result[i].Source < result[j].Source
+		}
+		return result[i].Title < result[j].Title
+	})
+	return result, nil
+}
+
+// Events returns the occurrences of the events between start and end in the calendars, all calendars if calendarIDs
+// is empty. They are sorted by their start. EventKit limits the range to 4 years.
+func (c *Calendars) Events(start time.Time, end time.Time, calendarIDs []string) ([]Event, error) {
+	if !end.After(start) {
+		return nil, ErrInvalidRange
+	}
+	if err := c.access(Events); err != nil {
+		return nil, err
+	}
+	result, err := c.backend.events(start, end, calendarIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result, nil
+}
+
+// Reminders returns the reminders of the lists, all lists if calendarIDs is empty. The completed reminders are left
+// out unless includeCompleted is true. They are sorted by their due date, the ones without come last.
+func (c *Calendars) Reminders(calendarIDs []string, includeCompleted bool) ([]Reminder, error) {
+	if err := c.access(Reminders); err != nil {
+		return nil, err
+	}
+	all, err := c.backend.reminders(calendarIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := []Reminder{}
+	for _, reminder := range all {
+		if includeCompleted || !reminder.Completed {
+			result = append(result, reminder)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Due == nil || result[j].Due == nil {
+			return result[j].Due == nil && result[i].Due != nil
+		}
+		return result[i].Due.Before(*result[j].Due)
+	})
+	return result, nil
+}
+
+// filterCalendars reports if the calendar is one of the IDs, all calendars are if ids is empty
+func filterCalendars(ids []string) func(id string) bool {
+	if len(ids) == 0 {
+		return func(string) bool { return true }
+	}
+	set := map[string]bool{}
+	for _, id := range ids {
+		set[id] = true
+	}
+	return func(id string) bool { return set[id] }
+}
@@ -0,0 +1,131 @@
+//go:build darwin
+
+package calendars
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AppKit -framework EventKit
+
+#include <stdlib.h>
+#import "Calendars_darwin.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/pkg/permissions"
+)
+
+var entityIDs = map[Entity]C.int{
+	Events:    C.CalendarEntityEvents,
+	Reminders: C.CalendarEntityReminders,
+}
+
+// eventKitBackend reads the calendars of EventKit, which asks with the usage descriptions of Info.plist. Calls that
+// ask the user block until the user answers.
+type eventKitBackend struct{}
+
+func newBackend() backend {
+	return eventKitBackend{}
+}
+
+// eventKitEvent and eventKitReminder are the JSON of EventKit, the dates are seconds since the Unix epoch
+type eventKitEvent struct {
+	Event
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+type eventKitReminder struct {
+	Reminder
+	Due *float64 `json:"due"`
+}
+
+func status(result C.int) permissions.Status {
+	switch result {
+	case C.CalendarGranted:
+		return permissions.Granted
+	case C.CalendarDenied:
+		return permissions.Denied
+	case C.CalendarRestricted:
+		return permissions.Restricted
+	}
+	return permissions.Undetermined
+}
+
+func toTime(seconds float64) time.Time {
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*1e9))
+}
+
+// decode decodes and frees the JSON of EventKit
+func decode(result *C.char, v interface{}) error {
+	defer C.free(unsafe.Pointer(result))
+	return json.Unmarshal([]byte(C.GoString(result)), v)
+}
+
+func calendarIDsJSON(calendarIDs []string) *C.char {
+	if calendarIDs == nil {
+		calendarIDs = []string{}
+	}
+	ids, _ := json.Marshal(calendarIDs)
+	return C.CString(string(ids))
+}
+
+func (eventKitBackend) status(entity Entity) (permissions.Status, error) {
+	return status(C.CalendarsStatus(entityIDs[entity])), nil
+}
+
+func (eventKitBackend) request(entity Entity) (permissions.Status, error) {
+	return status(C.CalendarsRequest(entityIDs[entity])), nil
+}
+
+func (eventKitBackend) calendars(entity Entity) ([]Calendar, error) {
+	var result []Calendar
+	if err := decode(C.CalendarsGetCalendars(entityIDs[entity]), &result); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Entity = entity
+	}
+	return result, nil
+}
+
+func (eventKitBackend) events(start time.Time, end time.Time, calendarIDs []string) ([]Event, error) {
+	ids := calendarIDsJSON(calendarIDs)
+	defer C.free(unsafe.Pointer(ids))
+	var events []eventKitEvent
+	err := decode(C.CalendarsGetEvents(C.double(float64(start.UnixNano())/1e9), C.double(float64(end.UnixNano())/1e9), ids), &events)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Event, 0, len(events))
+	for _, event := range events {
+		event.Event.Start = toTime(event.Start)
+		event.Event.End = toTime(event.End)
+		result = append(result, event.Event)
+	}
+	return result, nil
+}
+
+func (eventKitBackend) reminders(calendarIDs []string) ([]Reminder, error) {
+	ids := calendarIDsJSON(calendarIDs)
+	defer C.free(unsafe.Pointer(ids))
+	var reminders []eventKitReminder
+	if err := decode(C.CalendarsGetReminders(ids), &reminders); err != nil {
+		return nil, err
+	}
+	result := make([]Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.Due != nil {
+			due := toTime(*reminder.Due)
+			reminder.Reminder.Due = &due
+		}
+		result = append(result, reminder.Reminder)
+	}
+	return result, nil
+}
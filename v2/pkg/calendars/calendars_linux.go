@@ -0,0 +1,213 @@
+//go:build linux
+
+package calendars
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/wailsapp/wails/v2/pkg/permissions"
+)
+
+const (
+	sourcesBus               = "org.gnome.evolution.dataserver.Sources5"
+	sourceManagerPath        = "/org/gnome/evolution/dataserver/SourceManager"
+	sourceInterface          = "org.gnome.evolution.dataserver.Source"
+	calendarFactoryPath      = "/org/gnome/evolution/dataserver/CalendarFactory"
+	calendarFactoryInterface = "org.gnome.evolution.dataserver.CalendarFactory"
+	calendarInterface        = "org.gnome.evolution.dataserver.Calendar"
+	objectManagerInterface   = "org.freedesktop.DBus.ObjectManager"
+
+	// iCalendarTimeFormat is the format of the times of the queries
+	iCalendarTimeFormat = "20060102T150405Z"
+)
+
+// calendarBuses are the bus names of the calendar factory, the newest version of Evolution Data Server first
+var calendarBuses = []string{"org.gnome.evolution.dataserver.Calendar8", "org.gnome.evolution.dataserver.Calendar7"}
+
+type managedObjects map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+
+// source is an ESource of the registry of Evolution Data Server, its data is a key file
+type source struct {
+	uid  string
+	data map[string]map[string]string
+}
+
+// edsBackend reads the calendars of Evolution Data Server over the session bus. Linux doesn't restrict the access
+// outside of sandboxes, Flatpak applications need --talk-name for the buses of Evolution Data Server.
+type edsBackend struct{}
+
+func newBackend() backend {
+	return edsBackend{}
+}
+
+func (edsBackend) status(entity Entity) (permissions.Status, error) {
+	return permissions.Granted, nil
+}
+
+func (edsBackend) request(entity Entity) (permissions.Status, error) {
+	return permissions.Granted, nil
+}
+
+func (b edsBackend) calendars(entity Entity) ([]Calendar, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	sources, err := getSources(conn)
+	if err != nil {
+		return nil, err
+	}
+	return sourceCalendars(sources, entity), nil
+}
+
+func (b edsBackend) events(start time.Time, end time.Time, calendarIDs []string) ([]Event, error) {
+	query := fmt.Sprintf(`(occur-in-time-range? (make-time "%s") (make-time "%s"))`, start.UTC().Format(iCalendarTimeFormat), end.UTC().Format(iCalendarTimeFormat))
+	result := []Event{}
+	err := b.query(Events, calendarIDs, query, func(calendarID string, objects []string) {
+		result = append(result, parseEvents(objects, calendarID, start, end)...)
+	})
+	return result, err
+}
+
+func (b edsBackend) reminders(calendarIDs []string) ([]Reminder, error) {
+	result := []Reminder{}
+	err := b.query(Reminders, calendarIDs, "#t", func(calendarID string, objects []string) {
+		for _, object := range objects {
+			for _, vtodo := range parseComponents(object, "VTODO") {
+				result = append(result, parseReminder(vtodo, calendarID))
+			}
+		}
+	})
+	return result, err
+}
+
+// query calls found with the objects of each calendar that match the query. The calendars that can't be read, EG:
+// the remote calendars while offline, are skipped unless none can be read.
+func (b edsBackend) query(entity Entity, calendarIDs []string, query string, found func(calendarID string, objects []string)) error {
+	calendars, err := b.calendars(entity)
+	if err != nil {
+		return err
+	}
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	method := "OpenCalendar"
+	if entity == Reminders {
+		method = "OpenTaskList"
+	}
+	included := filterCalendars(calendarIDs)
+	var lastErr error
+	read := 0
+	for _, calendar := range calendars {
+		if !included(calendar.ID) {
+			continue
+		}
+		objects, err := getObjects(conn, method, calendar.ID, query)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		read++
+		found(calendar.ID, objects)
+	}
+	if read == 0 && lastErr != nil {
+		return edsError(lastErr)
+	}
+	return nil
+}
+
+func getSources(conn *dbus.Conn) ([]source, error) {
+	var objects managedObjects
+	err := conn.Object(sourcesBus, sourceManagerPath).Call(objectManagerInterface+".GetManagedObjects", 0).Store(&objects)
+	if err != nil {
+		return nil, edsError(err)
+	}
+	var result []source
+	for _, interfaces := range objects {
+		properties, ok := interfaces[sourceInterface]
+		if !ok {
+			continue
+		}
+		uid, _ := properties["UID"].Value().(string)
+		data, _ := properties["Data"].Value().(string)
+		result = append(result, source{uid: uid, data: parseKeyFile(data)})
+	}
+	return result, nil
+}
+
+// sourceCalendars returns the enabled calendars or task lists of the sources, their source is the display name of
+// their parent, EG: the account of GNOME Online Accounts
+func sourceCalendars(sources []source, entity Entity) []Calendar {
+	extension := "Calendar"
+	if entity == Reminders {
+		extension = "Task List"
+	}
+	names := map[string]string{}
+	for _, s := range sources {
+		names[s.uid] = s.data["Data Source"]["DisplayName"]
+	}
+	result := []Calendar{}
+	for _, s := range sources {
+		group, ok := s.data[extension]
+		if !ok || s.data["Data Source"]["Enabled"] == "false" {
+			continue
+		}
+		result = append(result, Calendar{
+			ID:     s.uid,
+			Title:  s.data["Data Source"]["DisplayName"],
+			Entity: entity,
+			Color:  group["Color"],
+			Source: names[s.data["Data Source"]["Parent"]],
+		})
+	}
+	return result
+}
+
+// getObjects opens the calendar with the factory and returns its iCalendar objects that match the query
+func getObjects(conn *dbus.Conn, method string, uid string, query string) ([]string, error) {
+	var call *dbus.Call
+	for _, bus := range calendarBuses {
+		call = conn.Object(bus, calendarFactoryPath).Call(calendarFactoryInterface+"."+method, 0, uid)
+		if call.Err == nil {
+			break
+		}
+	}
+	if call.Err != nil {
+		return nil, call.Err
+	}
+	if len(call.Body) < 2 {
+		return nil, errors.New("the calendar factory returned no calendar")
+	}
+	calendar := conn.Object(fmt.Sprint(call.Body[1]), dbus.ObjectPath(fmt.Sprint(call.Body[0])))
+	if err := calendar.Call(calendarInterface+".Open", 0).Err; err != nil {
+		return nil, err
+	}
+	defer calendar.Call(calendarInterface+".Close", 0)
+
+	var objects []string
+	err := calendar.Call(calendarInterface+".GetObjectList", 0, query).Store(&objects)
+	return objects, err
+}
+
+func edsError(err error) error {
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+	switch dbusErr.Name {
+	case "org.freedesktop.DBus.Error.AccessDenied":
+		return fmt.Errorf("%w: %v", ErrAccessDenied, err)
+	case "org.freedesktop.DBus.Error.ServiceUnknown", "org.freedesktop.DBus.Error.NameHasNoOwner":
+		// The sandbox of Flatpak hides the buses unless the application is allowed to talk to them
+		if _, statErr := os.Stat("/.flatpak-info"); statErr == nil {
+			return fmt.Errorf("%w: %v", ErrAccessDenied, err)
+		}
+		return fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+//go:build linux
+
+package calendars
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParseComponents(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nSUMMARY:Team\r\n  meeting\r\nDESCRIPTION:Room 1\\, floor 2\\nBring notes\r\n" +
+		"DTSTART;TZID=\"/freeassociation.sourceforge.net/Europe/Berlin\":20240301T090000\r\nDURATION:PT1H30M\r\n" +
+		"BEGIN:VALARM\r\nDESCRIPTION:Alarm\r\nEND:VALARM\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	vevents := parseComponents(data, "VEVENT")
+	if len(vevents) != 1 {
+		t.Fatalf("parseComponents() = %d components, want 1", len(vevents))
+	}
+	event, err := parseEvent(vevents[0], "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, berlin)
+	want := Event{
+		ID:         "1",
+		CalendarID: "work",
+		Title:      "Team meeting",
+		Notes:      "Room 1, floor 2\nBring notes",
+		Start:      start,
+		End:        start.Add(90 * time.Minute),
+	}
+	if !event.Start.Equal(want.Start) || !event.End.Equal(want.End) {
+		t.Errorf("parseEvent() = %v - %v, want %v - %v", event.Start, event.End, want.Start, want.End)
+	}
+	event.Start, event.End = want.Start, want.End
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("parseEvent() = %+v, want %+v", event, want)
+	}
+}
+
+func TestParseAllDayEvent(t *testing.T) {
+	vevent := parseComponents("BEGIN:VEVENT\nUID:2\nDTSTART;VALUE=DATE:20240301\nEND:VEVENT\n", "VEVENT")[0]
+	event, err := parseEvent(vevent, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	if !event.AllDay || !event.Start.Equal(start) || !event.End.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("parseEvent() = %+v", event)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"PT15M":     15 * time.Minute,
+		"P1DT2H":    26 * time.Hour,
+		"P2W":       14 * 24 * time.Hour,
+		"-PT30S":    -30 * time.Second,
+		"+PT1H0M5S": time.Hour + 5*time.Second,
+	} {
+		if got, err := parseDuration(value); err != nil || got != want {
+			t.Errorf("parseDuration(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := parseDuration("1H"); err == nil {
+		t.Error("parseDuration() of an invalid duration succeeded")
+	}
+}
+
+// eventStarts returns the sorted starts, the service sorts the events
+func eventStarts(events []Event) []string {
+	var result []string
+	for _, event := range events {
+		result = append(result, event.Start.UTC().Format("2006-01-02T15:04"))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestParseRecurringEvents(t *testing.T) {
+	object := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\nUID:standup\nDTSTART:20240304T090000Z\nDTEND:20240304T091500Z\n" +
+		"RRULE:FREQ=WEEKLY;BYDAY=FR,MO,WE\nEXDATE:20240306T090000Z\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nUID:standup\nRECURRENCE-ID:20240308T090000Z\nDTSTART:20240308T100000Z\nDTEND:20240308T101500Z\nEND:VEVENT\n" +
+		"END:VCALENDAR\n"
+	start := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	events := parseEvents([]string{object}, "work", start, start.AddDate(0, 0, 7))
+	want := []string{"2024-03-08T10:00", "2024-03-11T09:00"}
+	if got := eventStarts(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEvents() = %v, want %v", got, want)
+	}
+	for _, event := range events {
+		if !event.Recurring || event.End.Sub(event.Start) != 15*time.Minute {
+			t.Errorf("occurrence %+v", event)
+		}
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	first := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	event := Event{ID: "1", Start: first, End: first.Add(time.Hour)}
+	rangeEnd := first.AddDate(1, 0, 0)
+	for _, tt := range []struct {
+		rule string
+		want []string
+	}{
+		// The months without a 31st are skipped
+		{"FREQ=MONTHLY;COUNT=3", []string{"2024-01-31T12:00", "2024-03-31T12:00", "2024-05-31T12:00"}},
+		{"FREQ=DAILY;INTERVAL=2;UNTIL=20240204T120000Z", []string{"2024-01-31T12:00", "2024-02-02T12:00", "2024-02-04T12:00"}},
+		{"FREQ=YEARLY", []string{"2024-01-31T12:00"}},
+	} {
+		rule, ok := parseRecurrence(tt.rule)
+		if !ok {
+			t.Fatalf("parseRecurrence(%q) isn't supported", tt.rule)
+		}
+		if got := eventStarts(rule.occurrences(event, nil, first, rangeEnd)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("occurrences of %q = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+	for _, rule := range []string{"FREQ=MONTHLY;BYDAY=1MO", "FREQ=HOURLY", "FREQ=DAILY;BYMONTH=1"} {
+		if _, ok := parseRecurrence(rule); ok {
+			t.Errorf("parseRecurrence(%q) is supported", rule)
+		}
+	}
+}
+
+func TestParseReminder(t *testing.T) {
+	vtodo := parseComponents("BEGIN:VTODO\nUID:milk\nSUMMARY:Buy milk\nDUE;VALUE=DATE:20240302\nPRIORITY:1\nSTATUS:COMPLETED\nEND:VTODO\n", "VTODO")[0]
+	reminder := parseReminder(vtodo, "shopping")
+	due := time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local)
+	if reminder.ID != "milk" || reminder.Title != "Buy milk" || !reminder.Completed || reminder.Priority != 1 ||
+		reminder.Due == nil || !reminder.Due.Equal(due) {
+		t.Errorf("parseReminder() = %+v", reminder)
+	}
+}
+
+func TestSourceCalendars(t *testing.T) {
+	sources := []source{
+		{uid: "google", data: parseKeyFile("[Data Source]\nDisplayName=jane@example.com\n")},
+		{uid: "work", data: parseKeyFile("[Data Source]\nDisplayName=Work\nDisplayName[de]=Arbeit\nParent=google\nEnabled=true\n\n[Calendar]\nColor=#3465a4\n")},
+		{uid: "disabled", data: parseKeyFile("[Data Source]\nDisplayName=Old\nEnabled=false\n[Calendar]\n")},
+		{uid: "tasks", data: parseKeyFile("[Data Source]\nDisplayName=Tasks\n[Task List]\n")},
+	}
+	want := []Calendar{{ID: "work", Title: "Work", Entity: Events, Color: "#3465a4", Source: "jane@example.com"}}
+	if got := sourceCalendars(sources, Events); !reflect.DeepEqual(got, want) {
+		t.Errorf("sourceCalendars(Events) = %+v, want %+v", got, want)
+	}
+	if got := sourceCalendars(sources, Reminders); len(got) != 1 || got[0].ID != "tasks" {
+		t.Errorf("sourceCalendars(Reminders) = %+v", got)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !linux && !darwin
+
+package calendars
+
+import (
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/permissions"
+)
+
+// unsupportedBackend is used on the platforms without a calendar store that unpackaged applications can read
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) status(entity Entity) (permissions.Status, error) {
+	return "", ErrNotSupported
+}
+
+func (unsupportedBackend) request(entity Entity) (permissions.Status, error) {
+	return "", ErrNotSupported
+}
+
+func (unsupportedBackend) calendars(entity Entity) ([]Calendar, error) {
+	return nil, ErrNotSupported
+}
+
+func (unsupportedBackend) events(start time.Time, end time.Time, calendarIDs []string) ([]Event, error) {
+	return nil, ErrNotSupported
+}
+
+func (unsupportedBackend) reminders(calendarIDs []string) ([]Reminder, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,153 @@
+package calendars
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/permissions"
+)
+
+// fakeBackend grants the requested access and records the requests
+type fakeBackend struct {
+	statuses     map[Entity]permissions.Status
+	requested    []Entity
+	allCalendars []Calendar
+	allEvents    []Event
+	allReminders []Reminder
+}
+
+func (f *fakeBackend) status(entity Entity) (permissions.Status, error) {
+	return f.statuses[entity], nil
+}
+
+func (f *fakeBackend) request(entity Entity) (permissions.Status, error) {
+	f.requested = append(f.requested, entity)
+	f.statuses[entity] = permissions.Granted
+	return permissions.Granted, nil
+}
+
+func (f *fakeBackend) calendars(entity Entity) ([]Calendar, error) {
+	return f.allCalendars, nil
+}
+
+func (f *fakeBackend) events(start time.Time, end time.Time, calendarIDs []string) ([]Event, error) {
+	return f.allEvents, nil
+}
+
+func (f *fakeBackend) reminders(calendarIDs []string) ([]Reminder, error) {
+	return f.allReminders, nil
+}
+
+func newFake() (*Calendars, *fakeBackend) {
+	fake := &fakeBackend{statuses: map[Entity]permissions.Status{
+		Events:    permissions.Undetermined,
+		Reminders: permissions.Denied,
+	}}
+	return &Calendars{backend: fake}, fake
+}
+
+func TestAccess(t *testing.T) {
+	c, fake := newFake()
+	if _, err := c.Status("contacts"); !errors.Is(err, ErrUnknownEntity) {
+		t.Errorf("Status() error = %v, want ErrUnknownEntity", err)
+	}
+	if _, err := c.Calendars(Events); err != nil {
+		t.Fatal(err)
+	}
+	// The access is only requested while it is undetermined
+	if _, err := c.Calendars(Events); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Reminders(nil, false); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Reminders() error = %v, want ErrAccessDenied", err)
+	}
+	if !reflect.DeepEqual(fake.requested, []Entity{Events}) {
+		t.Errorf("requested %v", fake.requested)
+	}
+}
+
+func TestCalendarsSorted(t *testing.T) {
+	c, fake := newFake()
+	fake.allCalendars = []Calendar{
+		{ID: "1", Title: "Work", Source: "iCloud"},
+		{ID: "2", Title: "Birthdays", Source: "iCloud"},
+		{ID: "3", Title: "Home", Source: "Google"},
+	}
+	calendars, err := c.Calendars(Events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, calendar := range calendars {
+		ids = append(ids, calendar.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"3", "2", "1"}) {
+		t.Errorf("Calendars() = %v", ids)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	c, fake := newFake()
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := c.Events(start, start, nil); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Events() error = %v, want ErrInvalidRange", err)
+	}
+	if len(fake.requested) != 0 {
+		t.Errorf("requested %v for an invalid range", fake.requested)
+	}
+	fake.allEvents = []Event{
+		{ID: "late", Start: start.Add(5 * time.Hour)},
+		{ID: "early", Start: start.Add(time.Hour)},
+	}
+	events, err := c.Events(start, start.AddDate(0, 0, 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].ID != "early" {
+		t.Errorf("Events() = %+v", events)
+	}
+}
+
+func TestReminders(t *testing.T) {
+	c, fake := newFake()
+	fake.statuses[Reminders] = permissions.Granted
+	soon := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 1, 0)
+	fake.allReminders = []Reminder{
+		{ID: "undated"},
+		{ID: "later", Due: &later},
+		{ID: "done", Due: &soon, Completed: true},
+		{ID: "soon", Due: &soon},
+	}
+	for _, tt := range []struct {
+		includeCompleted bool
+		want             []string
+	}{
+		{false, []string{"soon", "later", "undated"}},
+		{true, []string{"done", "soon", "later", "undated"}},
+	} {
+		reminders, err := c.Reminders(nil, tt.includeCompleted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, reminder := range reminders {
+			ids = append(ids, reminder.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("Reminders(%v) = %v, want %v", tt.includeCompleted, ids, tt.want)
+		}
+	}
+}
+
+func TestFilterCalendars(t *testing.T) {
+	if !filterCalendars(nil)("any") {
+		t.Error("all calendars aren't included without IDs")
+	}
+	included := filterCalendars([]string{"a", "b"})
+	if !included("b") || included("c") {
+		t.Error("filterCalendars() doesn't filter by the IDs")
+	}
+}
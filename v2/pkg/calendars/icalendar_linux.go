@@ -0,0 +1,477 @@
+//go:build linux
+
+package calendars
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrences limits the iterations over a recurrence rule, EG: for daily events that started decades ago
+const maxRecurrences = 100000
+
+// component is a component of iCalendar data, EG: a VEVENT, without its nested components
+type component struct {
+	properties []property
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func (c component) get(name string) (property, bool) {
+	for _, p := range c.properties {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return property{}, false
+}
+
+func (c component) all(name string) []property {
+	var result []property
+	for _, p := range c.properties {
+		if p.name == name {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// text returns the unescaped text of the property
+func (c component) text(name string) string {
+	p, _ := c.get(name)
+	return unescapeText(p.value)
+}
+
+// parseComponents returns the components with the name, the nested components like VALARM are skipped
+func parseComponents(data string, name string) []component {
+	var result []component
+	var current *component
+	depth := 0
+	for _, line := range unfold(data) {
+		p, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case p.name == "BEGIN" && current == nil:
+			if strings.EqualFold(p.value, name) {
+				current = &component{}
+			}
+		case p.name == "BEGIN":
+			depth++
+		case p.name == "END" && current != nil && depth > 0:
+			depth--
+		case p.name == "END" && current != nil:
+			result = append(result, *current)
+			current = nil
+		case current != nil && depth == 0:
+			current.properties = append(current.properties, p)
+		}
+	}
+	return result
+}
+
+// unfold joins the lines that have been folded by starting them with a space or a tab
+func unfold(data string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseProperty parses NAME;PARAM=VALUE:VALUE, the parameter values can be quoted
+func parseProperty(line string) (property, bool) {
+	var parts []string
+	quoted := false
+	last := 0
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == ';':
+			parts = append(parts, line[last:i])
+			last = i + 1
+		case r == ':':
+			parts = append(parts, line[last:i])
+			p := property{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: line[i+1:]}
+			for _, param := range parts[1:] {
+				if key, value, found := strings.Cut(param, "="); found {
+					p.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+				}
+			}
+			return p, p.name != ""
+		}
+	}
+	return property{}, false
+}
+
+func unescapeText(value string) string {
+	var result strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			result.WriteRune('\n')
+		case escaped:
+			result.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			result.WriteRune(r)
+		}
+		escaped = false
+	}
+	return result.String()
+}
+
+// parseTime parses a DATE or DATE-TIME property, dates are all day in the local time zone
+func parseTime(p property) (time.Time, bool, error) {
+	return parseTimeValue(p.value, p.params)
+}
+
+func parseTimeValue(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loadLocation(params["TZID"]))
+	return t, false, err
+}
+
+// loadLocation returns the time zone of the TZID. Evolution prefixes the Olson names, EG:
+// /freeassociation.sourceforge.net/Europe/Berlin, so the prefixes are stripped until a zone is found. Unknown zones
+// and floating times are local.
+func loadLocation(tzid string) *time.Location {
+	parts := strings.Split(strings.Trim(tzid, "/"), "/")
+	for i := range parts {
+		name := strings.Join(parts[i:], "/")
+		if name == "" {
+			break
+		}
+		if location, err := time.LoadLocation(name); err == nil {
+			return location
+		}
+	}
+	return time.Local
+}
+
+// parseDuration parses the durations of iCalendar, EG: PT1H30M or P1D
+func parseDuration(value string) (time.Duration, error) {
+	sign := time.Duration(1)
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+	}
+	value = strings.TrimLeft(value, "+-")
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, errors.New("invalid duration " + value)
+	}
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour, 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	var result time.Duration
+	number := ""
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == 'T':
+		case c >= '0' && c <= '9':
+			number += string(c)
+		default:
+			n, err := strconv.Atoi(number)
+			if err != nil || units[c] == 0 {
+				return 0, errors.New("invalid duration " + value)
+			}
+			result += time.Duration(n) * units[c]
+			number = ""
+		}
+	}
+	return sign * result, nil
+}
+
+// parseEvent returns the first occurrence of the VEVENT
+func parseEvent(vevent component, calendarID string) (Event, error) {
+	dtstart, ok := vevent.get("DTSTART")
+	if !ok {
+		return Event{}, errors.New("the event has no start")
+	}
+	start, allDay, err := parseTime(dtstart)
+	if err != nil {
+		return Event{}, err
+	}
+	end := start
+	if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+	if dtend, ok := vevent.get("DTEND"); ok {
+		if end, _, err = parseTime(dtend); err != nil {
+			return Event{}, err
+		}
+	} else if duration, ok := vevent.get("DURATION"); ok {
+		d, err := parseDuration(duration.value)
+		if err != nil {
+			return Event{}, err
+		}
+		end = start.Add(d)
+	}
+	_, rrule := vevent.get("RRULE")
+	_, detached := vevent.get("RECURRENCE-ID")
+	return Event{
+		ID:         vevent.text("UID"),
+		CalendarID: calendarID,
+		Title:      vevent.text("SUMMARY"),
+		Location:   vevent.text("LOCATION"),
+		Notes:      vevent.text("DESCRIPTION"),
+		Start:      start,
+		End:        end,
+		AllDay:     allDay,
+		Recurring:  rrule || detached,
+	}, nil
+}
+
+// parseEvents returns the occurrences of the events of the objects of a calendar that overlap the range. The detached
+// occurrences of repeating events, which have a RECURRENCE-ID, replace the occurrences of their rule.
+func parseEvents(objects []string, calendarID string, rangeStart time.Time, rangeEnd time.Time) []Event {
+	var vevents []component
+	for _, object := range objects {
+		vevents = append(vevents, parseComponents(object, "VEVENT")...)
+	}
+	detached := map[string][]time.Time{}
+	for _, vevent := range vevents {
+		if id, ok := vevent.get("RECURRENCE-ID"); ok {
+			if t, _, err := parseTime(id); err == nil {
+				uid := vevent.text("UID")
+				detached[uid] = append(detached[uid], t)
+			}
+		}
+	}
+
+	result := []Event{}
+	for _, vevent := range vevents {
+		event, err := parseEvent(vevent, calendarID)
+		if err != nil {
+			continue
+		}
+		rrule, repeating := vevent.get("RRULE")
+		_, isDetached := vevent.get("RECURRENCE-ID")
+		if !repeating || isDetached {
+			if overlaps(event.Start, event.End, rangeStart, rangeEnd) {
+				result = append(result, event)
+			}
+			continue
+		}
+		rule, supported := parseRecurrence(rrule.value)
+		if !supported {
+			// Evolution Data Server has matched an occurrence in the range
+			result = append(result, event)
+			continue
+		}
+		excluded := detached[event.ID]
+		for _, exdate := range vevent.all("EXDATE") {
+			for _, value := range strings.Split(exdate.value, ",") {
+				if t, _, err := parseTimeValue(value, exdate.params); err == nil {
+					excluded = append(excluded, t)
+				}
+			}
+		}
+		result = append(result, rule.occurrences(event, excluded, rangeStart, rangeEnd)...)
+	}
+	return result
+}
+
+// parseReminder returns the reminder of the VTODO
+func parseReminder(vtodo component, calendarID string) Reminder {
+	reminder := Reminder{
+		ID:         vtodo.text("UID"),
+		CalendarID: calendarID,
+		Title:      vtodo.text("SUMMARY"),
+		Notes:      vtodo.text("DESCRIPTION"),
+		Completed:  strings.EqualFold(vtodo.text("STATUS"), "COMPLETED"),
+	}
+	if _, ok := vtodo.get("COMPLETED"); ok {
+		reminder.Completed = true
+	}
+	if due, ok := vtodo.get("DUE"); ok {
+		if t, _, err := parseTime(due); err == nil {
+			reminder.Due = &t
+		}
+	}
+	reminder.Priority, _ = strconv.Atoi(vtodo.text("PRIORITY"))
+	return reminder
+}
+
+// overlaps reports if the event overlaps the range, events without duration overlap when they start in the range
+func overlaps(start time.Time, end time.Time, rangeStart time.Time, rangeEnd time.Time) bool {
+	if !end.After(start) {
+		return !start.Before(rangeStart) && start.Before(rangeEnd)
+	}
+	return end.After(rangeStart) && start.Before(rangeEnd)
+}
+
+// recurrence is a recurrence rule. Only the rules with a frequency, an interval, a count, an end and the weekdays of
+// weekly rules are expanded.
+type recurrence struct {
+	frequency string
+	interval  int
+	count     int
+	until     time.Time
+	weekdays  []time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday, "FR": time.Friday,
+	"SA": time.Saturday, "SU": time.Sunday,
+}
+
+// parseRecurrence parses the RRULE, it returns false for the rules that aren't supported
+func parseRecurrence(value string) (recurrence, bool) {
+	rule := recurrence{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, value, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(value)
+		case "COUNT":
+			rule.count, err = strconv.Atoi(value)
+		case "UNTIL":
+			rule.until, _, err = parseTimeValue(value, nil)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return recurrence{}, false
+				}
+				rule.weekdays = append(rule.weekdays, weekday)
+			}
+		case "WKST":
+		default:
+			return recurrence{}, false
+		}
+		if err != nil {
+			return recurrence{}, false
+		}
+	}
+	switch rule.frequency {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return recurrence{}, false
+	}
+	if rule.interval < 1 || (len(rule.weekdays) > 0 && rule.frequency != "WEEKLY") {
+		return recurrence{}, false
+	}
+	// The weeks start on Monday
+	sort.Slice(rule.weekdays, func(i, j int) bool {
+		return (rule.weekdays[i]+6)%7 < (rule.weekdays[j]+6)%7
+	})
+	return rule, true
+}
+
+// starts returns the starts of the nth period of the rule, the dates that don't exist are skipped, EG: the 31st of
+// the shorter months
+func (r recurrence) starts(first time.Time, n int) []time.Time {
+	step := n * r.interval
+	switch r.frequency {
+	case "DAILY":
+		return []time.Time{first.AddDate(0, 0, step)}
+	case "WEEKLY":
+		if len(r.weekdays) == 0 {
+			return []time.Time{first.AddDate(0, 0, 7*step)}
+		}
+		monday := first.AddDate(0, 0, -int((first.Weekday()+6)%7)+7*step)
+		var result []time.Time
+		for _, weekday := range r.weekdays {
+			t := monday.AddDate(0, 0, int((weekday+6)%7))
+			if !t.Before(first) {
+				result = append(result, t)
+			}
+		}
+		return result
+	case "MONTHLY":
+		if t := first.AddDate(0, step, 0); t.Day() == first.Day() {
+			return []time.Time{t}
+		}
+	case "YEARLY":
+		if t := first.AddDate(step, 0, 0); t.Day() == first.Day() {
+			return []time.Time{t}
+		}
+	}
+	return nil
+}
+
+// occurrences returns the occurrences of the event that overlap the range, except the excluded starts
+func (r recurrence) occurrences(event Event, excluded []time.Time, rangeStart time.Time, rangeEnd time.Time) []Event {
+	days := int(math.Round(event.End.Sub(event.Start).Hours() / 24))
+	duration := event.End.Sub(event.Start)
+	var result []Event
+	count := 0
+	for n := 0; n < maxRecurrences; n++ {
+		for _, start := range r.starts(event.Start, n) {
+			if !start.Before(rangeEnd) || (!r.until.IsZero() && start.After(r.until)) || (r.count > 0 && count >= r.count) {
+				return result
+			}
+			count++
+			if isExcluded(start, excluded) {
+				continue
+			}
+			occurrence := event
+			occurrence.Start = start
+			occurrence.End = start.Add(duration)
+			if event.AllDay {
+				occurrence.End = start.AddDate(0, 0, days)
+			}
+			if overlaps(occurrence.Start, occurrence.End, rangeStart, rangeEnd) {
+				result = append(result, occurrence)
+			}
+		}
+	}
+	return result
+}
+
+func isExcluded(start time.Time, excluded []time.Time) bool {
+	for _, t := range excluded {
+		if t.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKeyFile parses the key file of an ESource into its groups, the localised keys are skipped
+func parseKeyFile(data string) map[string]map[string]string {
+	result := map[string]map[string]string{}
+	var group map[string]string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			group = map[string]string{}
+			result[line[1:len(line)-1]] = group
+		case group != nil:
+			key, value, found := strings.Cut(line, "=")
+			if found && !strings.Contains(key, "[") {
+				group[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return result
+}
@@ -25,6 +25,8 @@ type Options struct {
 	TsPrefix         string
 	TsSuffix         string
 	TsOutputType     string
+	TsNullability    string
+	JSONSchema       bool
 }
 
 // GenerateBindings generates bindings for the Wails project in the given ProjectDirectory.
@@ -82,6 +84,10 @@ func GenerateBindings(options Options) (string, error) {
 	env = shell.SetEnv(env, "tsprefix", options.TsPrefix)
 	env = shell.SetEnv(env, "tssuffix", options.TsSuffix)
 	env = shell.SetEnv(env, "tsoutputtype", options.TsOutputType)
+	env = shell.SetEnv(env, "tsnullability", options.TsNullability)
+	if options.JSONSchema {
+		env = shell.SetEnv(env, "jsonschema", "true")
+	}
 
 	stdout, stderr, err = shell.RunCommandWithEnv(env, workingDirectory, filename)
 	if err != nil {
@@ -2,7 +2,9 @@ package build
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -30,6 +32,9 @@ const (
 	VERBOSE int = 2
 )
 
+// buildInfoPackage holds the variables of the build profile
+const buildInfoPackage = "github.com/wailsapp/wails/v2/internal/app"
+
 // BaseBuilder is the common builder struct
 type BaseBuilder struct {
 	filesToDelete slicer.StringSlicer
@@ -160,6 +165,12 @@ func (b *BaseBuilder) CompileProject(options *Options) error {
 		return err
 	}
 
+	// The sidecars are staged for every compilation, as universal binaries are compiled per architecture
+	err = stageSidecars(options)
+	if err != nil {
+		return err
+	}
+
 	verbose := options.Verbosity == VERBOSE
 	// Run go mod tidy first
 	if !options.SkipModTidy {
@@ -249,6 +260,25 @@ func (b *BaseBuilder) CompileProject(options *Options) error {
 		ldflags.Add(options.LDFlags)
 	}
 
+	// The build profile is read by runtime.BuildInfo
+	if options.BuildProfile != "" {
+		ldflags.Add("-X " + buildInfoPackage + ".buildProfile=" + url.QueryEscape(options.BuildProfile))
+	}
+	if len(options.BuildFlags) > 0 {
+		flags := url.Values{}
+		for name, value := range options.BuildFlags {
+			flags.Set(name, value)
+		}
+		ldflags.Add("-X " + buildInfoPackage + ".buildFlags=" + flags.Encode())
+	}
+	if options.Mode == Production && len(options.AssetPolicies) > 0 {
+		policies, err := json.Marshal(options.AssetPolicies)
+		if err != nil {
+			return err
+		}
+		ldflags.Add("-X " + buildInfoPackage + ".assetPolicies=" + url.QueryEscape(string(policies)))
+	}
+
 	if options.Mode == Production {
 		ldflags.Add("-w", "-s")
 		if options.Platform == "windows" && !options.WindowsConsole {
@@ -557,7 +587,14 @@ func (b *BaseBuilder) BuildFrontend(outputLogger *clilogger.CLILogger) error {
 	if b.projectData.OutputType == "dev" {
 		installCommand = b.projectData.GetDevInstallerCommand()
 	}
-	if installCommand == "" {
+	if b.options.Offline {
+		// The lockfile replaces the install command
+		printBulletPoint("Installing frontend dependencies offline: ")
+		if err := b.installOffline(frontendDir, verbose); err != nil {
+			return err
+		}
+		outputLogger.Println("Done.")
+	} else if installCommand == "" {
 		// No - don't install
 		printBulletPoint("No Install command. Skipping.")
 		pterm.Println("")
@@ -592,7 +629,11 @@ func (b *BaseBuilder) BuildFrontend(outputLogger *clilogger.CLILogger) error {
 		pterm.Println("")
 		pterm.Info.Println("Build command: '" + buildCommand + "'")
 	}
-	stdout, stderr, err := shell.RunCommand(frontendDir, cmd[0], cmd[1:]...)
+	var env []string
+	if b.options.Offline {
+		env = offlineEnv()
+	}
+	stdout, stderr, err := shell.RunCommandWithEnv(env, frontendDir, cmd[0], cmd[1:]...)
 	if verbose || err != nil {
 		for _, l := range strings.Split(stdout, "\n") {
 			pterm.Printf("    %s\n", l)
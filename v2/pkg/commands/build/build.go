@@ -20,6 +20,7 @@ import (
 
 	"github.com/wailsapp/wails/v2/internal/project"
 	"github.com/wailsapp/wails/v2/pkg/clilogger"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 )
 
 // Mode is the type used to indicate the build modes
@@ -69,6 +70,11 @@ type Options struct {
 	Obfuscated        bool                 // Indicates that bound methods should be obfuscated
 	GarbleArgs        string               // The arguments for Garble
 	SkipBindings      bool                 // Skip binding generation
+	BuildProfile      string               // The name of the build profile
+	BuildFlags        map[string]string    // The flags of the build profile, returned by runtime.BuildInfo
+	AssetPolicies     []assetserver.Policy // The asset policies of the build profile
+	Offline           bool                 // Install the frontend dependencies from the verified lockfile without network access
+	OfflineStore      string               // The package store to install the frontend dependencies from when offline
 }
 
 // Build the project!
@@ -141,6 +147,12 @@ func Build(options *Options) (string, error) {
 
 	compileBinary := ""
 	if !options.IgnoreApplication {
+		// The notices are generated after the frontend has been built, so that they are embedded with its assets
+		err = generateNotices(options)
+		if err != nil {
+			return "", err
+		}
+
 		compileBinary, err = execBuildApplication(builder, options)
 		if err != nil {
 			return "", err
@@ -228,14 +240,16 @@ func GenerateBindings(buildOptions *Options) error {
 
 	// Generate Bindings
 	output, err := bindings.GenerateBindings(bindings.Options{
-		Compiler:     buildOptions.Compiler,
-		Tags:         buildOptions.UserTags,
-		GoModTidy:    !buildOptions.SkipModTidy,
-		Platform:     buildOptions.Platform,
-		Arch:         buildOptions.Arch,
-		TsPrefix:     buildOptions.ProjectData.Bindings.TsGeneration.Prefix,
-		TsSuffix:     buildOptions.ProjectData.Bindings.TsGeneration.Suffix,
-		TsOutputType: buildOptions.ProjectData.Bindings.TsGeneration.OutputType,
+		Compiler:      buildOptions.Compiler,
+		Tags:          buildOptions.UserTags,
+		GoModTidy:     !buildOptions.SkipModTidy,
+		Platform:      buildOptions.Platform,
+		Arch:          buildOptions.Arch,
+		TsPrefix:      buildOptions.ProjectData.Bindings.TsGeneration.Prefix,
+		TsSuffix:      buildOptions.ProjectData.Bindings.TsGeneration.Suffix,
+		TsOutputType:  buildOptions.ProjectData.Bindings.TsGeneration.OutputType,
+		TsNullability: buildOptions.ProjectData.Bindings.TsGeneration.Nullability,
+		JSONSchema:    buildOptions.ProjectData.Bindings.JSONSchema,
 	})
 	if err != nil {
 		return err
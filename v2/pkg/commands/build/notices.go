@@ -0,0 +1,330 @@
+package build
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/internal/staticanalysis"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// notice is a third-party dependency of the notices
+type notice struct {
+	Name    string
+	Version string
+	// License is the license of the package.json of npm packages
+	License string
+	Text    string
+}
+
+// generateNotices writes the notices of the Go modules that are linked into the application and of the production
+// dependencies of the frontend next to the index.html of the embedded assets
+func generateNotices(options *Options) error {
+	config := options.ProjectData.Notices
+	if config == nil || options.Mode == Dev {
+		return nil
+	}
+	printBulletPoint("Generating third-party notices: ")
+
+	notices, err := goModuleNotices(options)
+	if err != nil {
+		return err
+	}
+	packages, err := npmPackageNotices(options.ProjectData.GetFrontendDir())
+	if err != nil {
+		return err
+	}
+	notices = append(notices, packages...)
+	notices = excludeNotices(notices, config.Exclude)
+	sort.Slice(notices, func(i, j int) bool {
+		return notices[i].Name < notices[j].Name
+	})
+
+	directories, err := assetRootDirectories(options.ProjectData.Path)
+	if err != nil {
+		return err
+	}
+	if len(directories) == 0 {
+		pterm.Warning.Println("No embedded index.html has been found for the third-party notices")
+		return nil
+	}
+	content := renderNotices(options.ProjectData.Name, notices)
+	for _, directory := range directories {
+		err = os.WriteFile(filepath.Join(directory, assetserver.NoticesFile), []byte(content), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Verbosity == VERBOSE {
+		pterm.Println(fmt.Sprintf("Listed %d third-party dependencies", len(notices)))
+	}
+	pterm.Println("Done.")
+	return nil
+}
+
+// goModuleNotices returns the modules of the packages that are compiled for the target of the build
+func goModuleNotices(options *Options) ([]notice, error) {
+	const format = `{{with .Module}}{{if not .Main}}{{.Path}}	{{.Version}}	{{if .Replace}}{{.Replace.Dir}}{{else}}{{.Dir}}{{end}}{{end}}{{end}}`
+	args := []string{"list", "-deps", "-f", format}
+	if len(options.UserTags) > 0 {
+		args = append(args, "-tags", strings.Join(options.UserTags, ","))
+	}
+	cmd := exec.Command(options.Compiler, append(args, "./...")...)
+	cmd.Dir = options.ProjectData.Path
+	arch := options.Arch
+	if arch == "universal" {
+		arch = "arm64"
+	}
+	cmd.Env = append(os.Environ(), "GOOS="+options.Platform, "GOARCH="+arch)
+	output, err := cmd.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return nil, fmt.Errorf("listing the Go modules failed: %s", strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, err
+	}
+
+	notices := []notice{}
+	listed := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 3 || listed[fields[0]] {
+			continue
+		}
+		listed[fields[0]] = true
+		notices = append(notices, notice{
+			Name:    fields[0],
+			Version: fields[1],
+			Text:    licenseText(fields[2]),
+		})
+	}
+	return notices, nil
+}
+
+type packageJSON struct {
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	License      json.RawMessage   `json:"license"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+func readPackageJSON(directory string) (*packageJSON, error) {
+	data, err := os.ReadFile(filepath.Join(directory, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+	var result packageJSON
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filepath.Join(directory, "package.json"), err)
+	}
+	return &result, nil
+}
+
+// license returns the license of the package.json, which is a SPDX expression or an object with a type
+func (p *packageJSON) license() string {
+	var license string
+	if json.Unmarshal(p.License, &license) == nil {
+		return license
+	}
+	var object struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal(p.License, &object)
+	return object.Type
+}
+
+// npmPackageNotices returns the installed dependencies of the frontend and their dependencies, the devDependencies
+// are only used to build the frontend
+func npmPackageNotices(frontendDir string) ([]notice, error) {
+	frontend, err := readPackageJSON(frontendDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type dependency struct {
+		name string
+		from string
+	}
+	queue := []dependency{}
+	for name := range frontend.Dependencies {
+		queue = append(queue, dependency{name: name, from: frontendDir})
+	}
+
+	notices := []notice{}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		directory := resolvePackage(frontendDir, next.from, next.name)
+		if directory == "" || visited[directory] {
+			// Optional dependencies of other platforms aren't installed
+			continue
+		}
+		visited[directory] = true
+		pkg, err := readPackageJSON(directory)
+		if err != nil {
+			return nil, err
+		}
+		name := pkg.Name
+		if name == "" {
+			name = next.name
+		}
+		notices = append(notices, notice{
+			Name:    name,
+			Version: pkg.Version,
+			License: pkg.license(),
+			Text:    licenseText(directory),
+		})
+		for dependencyName := range pkg.Dependencies {
+			queue = append(queue, dependency{name: dependencyName, from: directory})
+		}
+	}
+	return notices, nil
+}
+
+// resolvePackage finds the directory of the package like Node: in the node_modules of the directory and of its
+// parents up to the frontend directory. Symlinks are resolved, so the sibling packages of pnpm are found.
+func resolvePackage(frontendDir string, from string, name string) string {
+	directory := from
+	for {
+		candidate := filepath.Join(directory, "node_modules", filepath.FromSlash(name))
+		if _, err := os.Stat(filepath.Join(candidate, "package.json")); err == nil {
+			if resolved, err := filepath.EvalSymlinks(candidate); err == nil {
+				return resolved
+			}
+			return candidate
+		}
+		parent := filepath.Dir(directory)
+		if directory == frontendDir || parent == directory || !strings.HasPrefix(parent, frontendDir) {
+			return ""
+		}
+		directory = parent
+	}
+}
+
+// licenseText returns the license and notice files at the root of the directory
+func licenseText(directory string) string {
+	if directory == "" {
+		return ""
+	}
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return ""
+	}
+	var texts []string
+	for _, entry := range entries {
+		if entry.IsDir() || !isLicenseFile(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(directory, entry.Name()))
+		if err != nil {
+			continue
+		}
+		texts = append(texts, strings.TrimSpace(string(data)))
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+func isLicenseFile(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, prefix := range []string{"LICENSE", "LICENCE", "COPYING", "NOTICE", "UNLICENSE"} {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeNotices removes the dependencies that match the patterns, a trailing "*" matches a prefix
+func excludeNotices(notices []notice, patterns []string) []notice {
+	result := notices[:0]
+	for _, notice := range notices {
+		excluded := false
+		for _, pattern := range patterns {
+			if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix && strings.HasPrefix(notice.Name, prefix) || pattern == notice.Name {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			result = append(result, notice)
+		}
+	}
+	return result
+}
+
+func renderNotices(appName string, notices []notice) string {
+	const separator = "--------------------------------------------------------------------------------\n"
+	var builder strings.Builder
+	builder.WriteString("THIRD-PARTY NOTICES\n\n")
+	builder.WriteString(appName + " includes the following third-party software. Their licenses are reproduced below.\n\n")
+	for _, notice := range notices {
+		builder.WriteString(separator)
+		builder.WriteString(notice.Name)
+		if notice.Version != "" {
+			builder.WriteString(" " + notice.Version)
+		}
+		if notice.License != "" {
+			builder.WriteString(" (" + notice.License + ")")
+		}
+		builder.WriteString("\n" + separator + "\n")
+		if notice.Text == "" {
+			builder.WriteString("No license file has been found.\n\n")
+			continue
+		}
+		builder.WriteString(notice.Text + "\n\n")
+	}
+	return builder.String()
+}
+
+// assetRootDirectories returns the shallowest directories with an index.html of the embedded directories, which are
+// the roots of the assets
+func assetRootDirectories(projectDir string) ([]string, error) {
+	embedDetails, err := staticanalysis.GetEmbedDetails(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	found := map[string]bool{}
+	for _, embedDetail := range embedDetails {
+		root := ""
+		err := filepath.WalkDir(embedDetail.GetFullPath(), func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if entry.IsDir() && entry.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			if !entry.IsDir() && entry.Name() == "index.html" {
+				directory := filepath.Dir(path)
+				if root == "" || strings.Count(directory, string(filepath.Separator)) < strings.Count(root, string(filepath.Separator)) {
+					root = directory
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if root != "" && !found[root] {
+			found[root] = true
+			result = append(result, root)
+		}
+	}
+	return result, nil
+}
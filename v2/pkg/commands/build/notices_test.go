@@ -0,0 +1,74 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNpmPackageNotices(t *testing.T) {
+	frontendDir := t.TempDir()
+	writeTestFile(t, filepath.Join(frontendDir, "package.json"), `{"dependencies": {"ui": "^1.0.0"}, "devDependencies": {"vite": "^5.0.0"}}`)
+	writeTestFile(t, filepath.Join(frontendDir, "node_modules", "ui", "package.json"), `{"name": "ui", "version": "1.2.0", "license": "MIT", "dependencies": {"icons": "^2.0.0", "shared": "^1.0.0"}}`)
+	writeTestFile(t, filepath.Join(frontendDir, "node_modules", "ui", "LICENSE"), "MIT License ui")
+	writeTestFile(t, filepath.Join(frontendDir, "node_modules", "ui", "node_modules", "icons", "package.json"), `{"name": "icons", "version": "2.1.0", "license": {"type": "ISC"}}`)
+	writeTestFile(t, filepath.Join(frontendDir, "node_modules", "shared", "package.json"), `{"name": "shared", "version": "1.0.1", "license": "Apache-2.0"}`)
+	writeTestFile(t, filepath.Join(frontendDir, "node_modules", "shared", "NOTICE.txt"), "Shared notice")
+	writeTestFile(t, filepath.Join(frontendDir, "node_modules", "vite", "package.json"), `{"name": "vite", "version": "5.0.0"}`)
+
+	notices, err := npmPackageNotices(frontendDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []notice{
+		{Name: "ui", Version: "1.2.0", License: "MIT", Text: "MIT License ui"},
+		{Name: "icons", Version: "2.1.0", License: "ISC"},
+		{Name: "shared", Version: "1.0.1", License: "Apache-2.0", Text: "Shared notice"},
+	}
+	if len(notices) != 3 || notices[0] != expected[0] {
+		t.Fatalf("unexpected notices: %+v", notices)
+	}
+	// The dependencies of a package are listed in the order of the map
+	rest := map[string]notice{notices[1].Name: notices[1], notices[2].Name: notices[2]}
+	if rest["icons"] != expected[1] || rest["shared"] != expected[2] {
+		t.Errorf("unexpected notices: %+v", notices)
+	}
+}
+
+func TestExcludeNotices(t *testing.T) {
+	notices := []notice{{Name: "github.com/mycompany/core"}, {Name: "github.com/other/module"}, {Name: "private-ui"}}
+	result := excludeNotices(notices, []string{"github.com/mycompany/*", "private-ui"})
+	if !reflect.DeepEqual(result, []notice{{Name: "github.com/other/module"}}) {
+		t.Errorf("unexpected notices: %+v", result)
+	}
+}
+
+func TestRenderNotices(t *testing.T) {
+	content := renderNotices("MyApp", []notice{
+		{Name: "github.com/example/module", Version: "v1.0.0", Text: "BSD License"},
+		{Name: "ui", Version: "1.2.0", License: "MIT"},
+	})
+	for _, expected := range []string{
+		"MyApp includes the following third-party software",
+		"github.com/example/module v1.0.0\n",
+		"BSD License",
+		"ui 1.2.0 (MIT)\n",
+		"No license file has been found.",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("%q is missing from the notices:\n%s", expected, content)
+		}
+	}
+}
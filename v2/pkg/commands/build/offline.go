@@ -0,0 +1,422 @@
+package build
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wailsapp/wails/v2/internal/fs"
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+// lockfiles are the lockfiles of the package managers in the order they are looked up
+var lockfiles = []struct {
+	name    string
+	manager string
+}{
+	{"package-lock.json", "npm"},
+	{"npm-shrinkwrap.json", "npm"},
+	{"pnpm-lock.yaml", "pnpm"},
+	{"yarn.lock", "yarn"},
+}
+
+// integritySizes are the digest sizes of the algorithms of subresource integrity hashes
+var integritySizes = map[string]int{
+	"sha512": 64,
+	"sha384": 48,
+	"sha256": 32,
+	"sha1":   20,
+}
+
+// yarnChecksum is a checksum of Yarn 2 or later, EG: 10c0/<sha512 in hex>
+var yarnChecksum = regexp.MustCompile(`^([0-9a-z]+/)?[0-9a-f]{128}$`)
+
+// lockedPackage is a package of a lockfile
+type lockedPackage struct {
+	name      string
+	version   string
+	resolved  string
+	integrity string
+	// path is the directory of the package in node_modules, only known for npm
+	path string
+	// local packages are linked or copied from the disk and never downloaded
+	local bool
+	// optional packages can be missing, EG: the binaries of other platforms
+	optional bool
+	// git packages are cloned, which can't be verified
+	git bool
+}
+
+func (p lockedPackage) String() string {
+	if p.version == "" {
+		return p.name
+	}
+	return p.name + "@" + p.version
+}
+
+// lockfile is the lockfile of the frontend
+type lockfile struct {
+	// manager is npm, pnpm, yarn or yarn-berry for Yarn 2 or later
+	manager  string
+	path     string
+	packages []lockedPackage
+}
+
+// readLockfile reads the lockfile of the frontend directory
+func readLockfile(frontendDir string) (*lockfile, error) {
+	for _, candidate := range lockfiles {
+		path := filepath.Join(frontendDir, candidate.name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result := &lockfile{manager: candidate.manager, path: path}
+		switch {
+		case candidate.manager == "npm":
+			result.packages, err = parseNpmLockfile(data)
+		case candidate.manager == "pnpm":
+			result.packages, err = parsePnpmLockfile(data)
+		case strings.Contains(string(data), "__metadata:"):
+			result.manager = "yarn-berry"
+			result.packages, err = parseYarnBerryLockfile(data)
+		default:
+			result.packages = parseYarnLockfile(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		sort.Slice(result.packages, func(i, j int) bool {
+			return result.packages[i].String() < result.packages[j].String()
+		})
+		return result, nil
+	}
+	return nil, fmt.Errorf("offline builds need the lockfile of the package manager in '%s': package-lock.json, pnpm-lock.yaml or yarn.lock", frontendDir)
+}
+
+func parseNpmLockfile(data []byte) ([]lockedPackage, error) {
+	var lock struct {
+		LockfileVersion int `json:"lockfileVersion"`
+		Packages        map[string]struct {
+			Version   string `json:"version"`
+			Resolved  string `json:"resolved"`
+			Integrity string `json:"integrity"`
+			Link      bool   `json:"link"`
+			InBundle  bool   `json:"inBundle"`
+			Optional  bool   `json:"optional"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	if lock.LockfileVersion < 2 {
+		return nil, fmt.Errorf("lockfileVersion %d isn't supported, update the lockfile with npm 7 or later", lock.LockfileVersion)
+	}
+	var result []lockedPackage
+	for path, entry := range lock.Packages {
+		// The root package and the workspaces aren't dependencies
+		index := strings.LastIndex(path, "node_modules/")
+		if index == -1 {
+			continue
+		}
+		result = append(result, lockedPackage{
+			name:      path[index+len("node_modules/"):],
+			version:   entry.Version,
+			resolved:  entry.Resolved,
+			integrity: entry.Integrity,
+			path:      path,
+			// Bundled packages come with the tarball of the package that bundles them
+			local:    entry.Link || entry.InBundle || strings.HasPrefix(entry.Resolved, "file:"),
+			optional: entry.Optional,
+			git:      strings.HasPrefix(entry.Resolved, "git"),
+		})
+	}
+	return result, nil
+}
+
+func parsePnpmLockfile(data []byte) ([]lockedPackage, error) {
+	var lock struct {
+		Packages map[string]struct {
+			Resolution struct {
+				Integrity string `yaml:"integrity"`
+				Tarball   string `yaml:"tarball"`
+				Directory string `yaml:"directory"`
+				Type      string `yaml:"type"`
+			} `yaml:"resolution"`
+			Optional bool `yaml:"optional"`
+		} `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	var result []lockedPackage
+	for key, entry := range lock.Packages {
+		resolution := entry.Resolution
+		result = append(result, lockedPackage{
+			// The keys are name@version, older lockfiles start them with a slash
+			name:      strings.TrimPrefix(key, "/"),
+			resolved:  resolution.Tarball,
+			integrity: resolution.Integrity,
+			local:     resolution.Directory != "" || strings.HasPrefix(resolution.Tarball, "file:"),
+			optional:  entry.Optional,
+			git:       resolution.Type == "git",
+		})
+	}
+	return result, nil
+}
+
+func parseYarnBerryLockfile(data []byte) ([]lockedPackage, error) {
+	var lock map[string]struct {
+		Version    string `yaml:"version"`
+		Resolution string `yaml:"resolution"`
+		Checksum   string `yaml:"checksum"`
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	var result []lockedPackage
+	for key, entry := range lock {
+		if key == "__metadata" {
+			continue
+		}
+		// The resolutions are name@protocol:version, EG: @babel/core@npm:7.24.0
+		name, protocol := key, entry.Resolution
+		if index := strings.LastIndex(entry.Resolution, "@"); index > 0 {
+			name, protocol = entry.Resolution[:index], entry.Resolution[index+1:]
+		}
+		result = append(result, lockedPackage{
+			name:      name,
+			version:   entry.Version,
+			resolved:  entry.Resolution,
+			integrity: entry.Checksum,
+			local:     hasPrefix(protocol, "workspace:", "link:", "portal:", "file:"),
+			git:       strings.Contains(protocol, "git"),
+		})
+	}
+	return result, nil
+}
+
+// hasPrefix returns true if value has one of the prefixes
+func hasPrefix(value string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseYarnLockfile parses the lockfiles of Yarn 1, which aren't YAML
+func parseYarnLockfile(data []byte) []lockedPackage {
+	var result []lockedPackage
+	var current *lockedPackage
+	finish := func() {
+		if current != nil {
+			current.local = current.resolved == "" || strings.HasPrefix(current.resolved, "file:")
+			current.git = strings.HasPrefix(current.resolved, "git")
+			result = append(result, *current)
+		}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			finish()
+			// "name@range", "name@other range":
+			spec := strings.Trim(strings.Split(strings.TrimSuffix(line, ":"), ",")[0], `"`)
+			name := spec
+			if index := strings.LastIndex(spec, "@"); index > 0 {
+				name = spec[:index]
+			}
+			current = &lockedPackage{name: name}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "version":
+			current.version = value
+		case "resolved":
+			current.resolved = value
+		case "integrity":
+			current.integrity = value
+		}
+	}
+	finish()
+	return result
+}
+
+// validIntegrity returns true if the integrity is a subresource integrity hash with a known algorithm
+func validIntegrity(integrity string) bool {
+	hashes := strings.Fields(integrity)
+	if len(hashes) == 0 {
+		return false
+	}
+	for _, hash := range hashes {
+		algorithm, digest, found := strings.Cut(hash, "-")
+		size, known := integritySizes[algorithm]
+		if !found || !known {
+			return false
+		}
+		// The digest can be followed by options, EG: ?foo
+		digest, _, _ = strings.Cut(digest, "?")
+		decoded, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil || len(decoded) != size {
+			return false
+		}
+	}
+	return true
+}
+
+// verify returns the packages that would be downloaded without a verifiable integrity hash
+func (l *lockfile) verify() []string {
+	var problems []string
+	for _, p := range l.packages {
+		switch {
+		case p.local:
+		case p.git:
+			problems = append(problems, p.String()+": resolved from git, which can't be verified or installed offline")
+		case p.integrity == "":
+			problems = append(problems, p.String()+": no integrity hash")
+		case l.manager == "yarn-berry" && !yarnChecksum.MatchString(p.integrity):
+			problems = append(problems, p.String()+": invalid checksum "+p.integrity)
+		case l.manager != "yarn-berry" && !validIntegrity(p.integrity):
+			problems = append(problems, p.String()+": invalid integrity hash "+p.integrity)
+		}
+	}
+	return problems
+}
+
+// missingFromNodeModules returns the packages of the npm lockfile that aren't in node_modules with the locked version
+func (l *lockfile) missingFromNodeModules(frontendDir string) []string {
+	var missing []string
+	for _, p := range l.packages {
+		if p.path == "" || p.local {
+			continue
+		}
+		var packageJSON struct {
+			Version string `json:"version"`
+		}
+		data, err := os.ReadFile(filepath.Join(frontendDir, filepath.FromSlash(p.path), "package.json"))
+		if err == nil {
+			err = json.Unmarshal(data, &packageJSON)
+		}
+		if err != nil {
+			if !p.optional {
+				missing = append(missing, p.String())
+			}
+			continue
+		}
+		if packageJSON.Version != p.version {
+			missing = append(missing, fmt.Sprintf("%s: node_modules has %s", p, packageJSON.Version))
+		}
+	}
+	return missing
+}
+
+// offlineInstallCommand returns the command that installs the dependencies of the lockfile without network access and
+// verifies them. The store is the cache of npm, the store of pnpm or the cache folder of Yarn.
+func offlineInstallCommand(manager string, store string) ([]string, []string) {
+	env := offlineEnv()
+	var cmd []string
+	switch manager {
+	case "npm":
+		cmd = []string{"npm", "ci", "--offline", "--no-audit", "--no-fund"}
+		if store != "" {
+			cmd = append(cmd, "--cache", store)
+		}
+	case "pnpm":
+		cmd = []string{"pnpm", "install", "--offline", "--frozen-lockfile"}
+		if store != "" {
+			cmd = append(cmd, "--store-dir", store)
+		}
+	case "yarn":
+		cmd = []string{"yarn", "install", "--offline", "--frozen-lockfile"}
+		if store != "" {
+			cmd = append(cmd, "--cache-folder", store)
+		}
+	case "yarn-berry":
+		cmd = []string{"yarn", "install", "--immutable", "--immutable-cache", "--check-cache"}
+		env = shell.SetEnv(env, "YARN_ENABLE_NETWORK", "false")
+		if store != "" {
+			env = shell.SetEnv(env, "YARN_CACHE_FOLDER", store)
+		}
+	}
+	return cmd, env
+}
+
+// offlineEnv is the environment of the frontend build command when building offline
+func offlineEnv() []string {
+	return shell.SetEnv(os.Environ(), "npm_config_offline", "true")
+}
+
+// installOffline verifies the lockfile and installs the frontend dependencies without network access, from the
+// vendored node_modules or the given store. It fails with the packages that would need network access.
+func (b *BaseBuilder) installOffline(frontendDir string, verbose bool) error {
+	lock, err := readLockfile(frontendDir)
+	if err != nil {
+		return err
+	}
+	if problems := lock.verify(); len(problems) > 0 {
+		return fmt.Errorf("the integrity of '%s' can't be verified:\n    %s", lock.path, strings.Join(problems, "\n    "))
+	}
+
+	store := b.options.OfflineStore
+	if store != "" {
+		store, err = filepath.Abs(store)
+		if err != nil {
+			return err
+		}
+		if !fs.DirExists(store) {
+			return fmt.Errorf("the offline store '%s' does not exist", store)
+		}
+	}
+
+	// npm ci would replace the vendored node_modules, so it is only run when packages are missing
+	if lock.manager == "npm" && fs.DirExists(filepath.Join(frontendDir, "node_modules")) {
+		missing := lock.missingFromNodeModules(frontendDir)
+		if len(missing) == 0 {
+			if verbose {
+				pterm.Println("Using the vendored node_modules")
+			}
+			return nil
+		}
+		if store == "" {
+			return fmt.Errorf("node_modules doesn't have the packages of '%s', which need network access without an offline store:\n    %s", lock.path, strings.Join(missing, "\n    "))
+		}
+	}
+
+	cmd, env := offlineInstallCommand(lock.manager, store)
+	if verbose {
+		pterm.Println("")
+		pterm.Info.Println("Install command: '" + strings.Join(cmd, " ") + "'")
+	}
+	stdout, stderr, err := shell.RunCommandWithEnv(env, frontendDir, cmd[0], cmd[1:]...)
+	if verbose || err != nil {
+		for _, l := range strings.Split(stdout, "\n") {
+			pterm.Printf("    %s\n", l)
+		}
+		for _, l := range strings.Split(stderr, "\n") {
+			pterm.Printf("    %s\n", l)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("the frontend dependencies can't be installed without network access, vendor node_modules or pass the store of %s with -offlinestore: %w", lock.manager, err)
+	}
+	return nil
+}
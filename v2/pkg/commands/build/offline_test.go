@@ -0,0 +1,182 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testIntegrity = "sha512-ntBlRrBlP5YNvaAwc9TR4ZVuoZ8A6kpCQlDyLSlYxmwQuakjPiFgXWDNgHe2WvXdkKXnzos4rs8TJVG3OcsNHA=="
+	testChecksum  = "10c0/b2ca52cbeac0f6ce07b45adf6e172a20b49351ccea03272acb5b1d078131934bd25756ea2ecd64be5d967452557b13fd9d324e1cbd5ac86033913c3851b4931b"
+)
+
+var testLockfiles = map[string]string{
+	"package-lock.json": `{
+  "name": "frontend",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "frontend"},
+    "node_modules/vite": {"version": "5.2.0", "resolved": "https://registry.npmjs.org/vite/-/vite-5.2.0.tgz", "integrity": "` + testIntegrity + `"},
+    "node_modules/@esbuild/win32-x64": {"version": "0.20.2", "resolved": "https://registry.npmjs.org/@esbuild/win32-x64/-/win32-x64-0.20.2.tgz", "integrity": "` + testIntegrity + `", "optional": true},
+    "node_modules/local": {"resolved": "packages/local", "link": true},
+    "node_modules/unverified": {"version": "1.0.0", "resolved": "https://registry.npmjs.org/unverified/-/unverified-1.0.0.tgz"},
+    "node_modules/forked": {"version": "1.0.0", "resolved": "git+ssh://git@github.com/user/forked.git#abc"}
+  }
+}`,
+	"pnpm-lock.yaml": `lockfileVersion: '9.0'
+
+packages:
+
+  vite@5.2.0:
+    resolution: {integrity: ` + testIntegrity + `}
+
+  local@file:packages/local:
+    resolution: {directory: packages/local, type: directory}
+
+  unverified@1.0.0:
+    resolution: {tarball: https://example.com/unverified-1.0.0.tgz}
+
+  forked@https://codeload.github.com/user/forked/tar.gz/abc:
+    resolution: {type: git, repo: https://github.com/user/forked.git, commit: abc}
+`,
+	"yarn.lock": `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"local@file:packages/local":
+  version "1.0.0"
+
+vite@^5.0.0, vite@^5.2.0:
+  version "5.2.0"
+  resolved "https://registry.yarnpkg.com/vite/-/vite-5.2.0.tgz#abc"
+  integrity ` + testIntegrity + `
+
+unverified@^1.0.0:
+  version "1.0.0"
+  resolved "https://registry.yarnpkg.com/unverified/-/unverified-1.0.0.tgz#abc"
+
+"forked@git+ssh://git@github.com/user/forked.git":
+  version "1.0.0"
+  resolved "git+ssh://git@github.com/user/forked.git#abc"
+`,
+}
+
+func writeLockfile(t *testing.T, name string, content string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func Test_lockfileVerify(t *testing.T) {
+	for name, content := range testLockfiles {
+		t.Run(name, func(t *testing.T) {
+			lock, err := readLockfile(writeLockfile(t, name, content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			problems := lock.verify()
+			if len(problems) != 2 || !strings.HasPrefix(problems[0], "forked") || !strings.HasSuffix(problems[0], "resolved from git, which can't be verified or installed offline") ||
+				!strings.HasPrefix(problems[1], "unverified") || !strings.HasSuffix(problems[1], "no integrity hash") {
+				t.Errorf("verify() = %v", problems)
+			}
+		})
+	}
+}
+
+func Test_lockfileYarnBerry(t *testing.T) {
+	dir := writeLockfile(t, "yarn.lock", `__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"frontend@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "frontend@workspace:."
+  languageName: unknown
+  linkType: soft
+
+"@vitejs/plugin-vue@npm:^5.0.0":
+  version: 5.0.4
+  resolution: "@vitejs/plugin-vue@npm:5.0.4"
+  checksum: `+testChecksum+`
+  languageName: node
+  linkType: hard
+
+"vite@npm:^5.0.0, vite@npm:^5.2.0":
+  version: 5.2.0
+  resolution: "vite@npm:5.2.0"
+  checksum: 12345
+  languageName: node
+  linkType: hard
+`)
+	lock, err := readLockfile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.manager != "yarn-berry" {
+		t.Errorf("manager = %s, want yarn-berry", lock.manager)
+	}
+	if problems := lock.verify(); !reflect.DeepEqual(problems, []string{"vite@5.2.0: invalid checksum 12345"}) {
+		t.Errorf("verify() = %v", problems)
+	}
+}
+
+func Test_readLockfile(t *testing.T) {
+	if _, err := readLockfile(t.TempDir()); err == nil {
+		t.Error("readLockfile() didn't fail without a lockfile")
+	}
+	if _, err := readLockfile(writeLockfile(t, "package-lock.json", `{"lockfileVersion": 1, "dependencies": {}}`)); err == nil {
+		t.Error("readLockfile() didn't fail for a lockfile of npm 6")
+	}
+}
+
+func Test_validIntegrity(t *testing.T) {
+	tests := []struct {
+		integrity string
+		want      bool
+	}{
+		{testIntegrity, true},
+		{"sha1-mQfTxL+ETFDz+ArJTa3sB+FHOHY=", true},
+		{testIntegrity + " sha1-mQfTxL+ETFDz+ArJTa3sB+FHOHY=", true},
+		{"md5-1B2M2Y8AsgTpgAmY7PhCfg==", false},
+		{"sha512-tooshort", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := validIntegrity(tt.integrity); got != tt.want {
+			t.Errorf("validIntegrity(%q) = %v, want %v", tt.integrity, got, tt.want)
+		}
+	}
+}
+
+func Test_missingFromNodeModules(t *testing.T) {
+	dir := writeLockfile(t, "package-lock.json", `{
+  "lockfileVersion": 3,
+  "packages": {
+    "node_modules/vite": {"version": "5.2.0", "integrity": "`+testIntegrity+`"},
+    "node_modules/vue": {"version": "3.4.0", "integrity": "`+testIntegrity+`"},
+    "node_modules/@esbuild/win32-x64": {"version": "0.20.2", "integrity": "`+testIntegrity+`", "optional": true},
+    "node_modules/vite/node_modules/rollup": {"version": "4.0.0", "integrity": "`+testIntegrity+`"}
+  }
+}`)
+	for path, version := range map[string]string{"vite": "5.2.0", "vue": "3.3.0", "vite/node_modules/rollup": "4.0.0"} {
+		packageDir := filepath.Join(dir, "node_modules", filepath.FromSlash(path))
+		if err := os.MkdirAll(packageDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(packageDir, "package.json"), []byte(`{"version": "`+version+`"}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lock, err := readLockfile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := lock.missingFromNodeModules(dir); !reflect.DeepEqual(missing, []string{"vue@3.4.0: node_modules has 3.3.0"}) {
+		t.Errorf("missingFromNodeModules() = %v", missing)
+	}
+}
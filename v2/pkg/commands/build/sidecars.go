@@ -0,0 +1,105 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/wailsapp/wails/v2/pkg/sidecar"
+)
+
+// stageSidecars copies the sidecars of the target platform to build/sidecars/embedded and writes their manifest.
+// The files of build/sidecars/<os>_<arch> take precedence over the files of build/sidecars/<os>, which take
+// precedence over build/sidecars/all.
+func stageSidecars(options *Options) error {
+	source := filepath.Join(options.ProjectData.GetBuildDir(), "sidecars")
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		return nil
+	}
+	target := filepath.Join(source, "embedded")
+	err := os.RemoveAll(target)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(target, 0o755)
+	if err != nil {
+		return err
+	}
+
+	manifest := sidecar.Manifest{
+		Platform: options.Platform + "/" + options.Arch,
+		Sidecars: []sidecar.Entry{},
+	}
+	staged := make(map[string]bool)
+	for _, dir := range []string{options.Platform + "_" + options.Arch, options.Platform, "all"} {
+		entries, err := os.ReadDir(filepath.Join(source, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || strings.HasPrefix(name, ".") || staged[name] {
+				continue
+			}
+			staged[name] = true
+			sum, size, err := copySidecar(filepath.Join(source, dir, name), filepath.Join(target, name))
+			if err != nil {
+				return err
+			}
+			sidecarName := name
+			if options.Platform == "windows" {
+				sidecarName = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			manifest.Sidecars = append(manifest.Sidecars, sidecar.Entry{
+				Name:   sidecarName,
+				File:   name,
+				SHA256: sum,
+				Size:   size,
+			})
+		}
+	}
+	sort.Slice(manifest.Sidecars, func(i, j int) bool {
+		return manifest.Sidecars[i].Name < manifest.Sidecars[j].Name
+	})
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if options.Verbosity == VERBOSE {
+		pterm.Println(fmt.Sprintf("Staged %d sidecars for %s", len(manifest.Sidecars), manifest.Platform))
+	}
+	return os.WriteFile(filepath.Join(target, sidecar.ManifestFile), data, 0o644)
+}
+
+// copySidecar copies the file and returns its checksum and size
+func copySidecar(source string, target string) (string, int64, error) {
+	input, err := os.Open(source)
+	if err != nil {
+		return "", 0, err
+	}
+	defer input.Close()
+	output, err := os.Create(target)
+	if err != nil {
+		return "", 0, err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(output, hash), input)
+	if closeErr := output.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
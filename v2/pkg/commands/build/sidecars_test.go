@@ -0,0 +1,78 @@
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/project"
+	"github.com/wailsapp/wails/v2/pkg/sidecar"
+)
+
+func Test_stageSidecars(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"windows_amd64/server.exe": "amd64 server",
+		"windows/server.exe":       "windows server",
+		"windows/tool.exe":         "windows tool",
+		"all/model.bin":            "model",
+		"linux/server":             "linux server",
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, "build", "sidecars", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	options := &Options{
+		Platform:    "windows",
+		Arch:        "amd64",
+		ProjectData: &project.Project{Path: dir, BuildDir: "build"},
+	}
+	if err := stageSidecars(options); err != nil {
+		t.Fatal(err)
+	}
+
+	embedded := filepath.Join(dir, "build", "sidecars", "embedded")
+	data, err := os.ReadFile(filepath.Join(embedded, sidecar.ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest sidecar.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Platform != "windows/amd64" {
+		t.Errorf("platform = %s, want windows/amd64", manifest.Platform)
+	}
+	var names []string
+	for _, entry := range manifest.Sidecars {
+		names = append(names, entry.Name+"="+entry.File)
+	}
+	want := []string{"model=model.bin", "server=server.exe", "tool=tool.exe"}
+	if len(names) != len(want) {
+		t.Fatalf("sidecars = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("sidecars = %v, want %v", names, want)
+		}
+	}
+	server, _ := os.ReadFile(filepath.Join(embedded, "server.exe"))
+	if string(server) != "amd64 server" {
+		t.Errorf("server.exe = %s, want the file of windows_amd64", server)
+	}
+
+	// The previous target is replaced
+	options.Platform = "linux"
+	if err := stageSidecars(options); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(embedded, "server.exe")); !os.IsNotExist(err) {
+		t.Error("server.exe has not been removed")
+	}
+}
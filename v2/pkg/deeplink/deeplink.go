@@ -0,0 +1,76 @@
+// Package deeplink registers the application as the handler of custom URL schemes for the current user at runtime,
+// EG: at the first run of an application that hasn't been installed with an installer or for the redirect of an OAuth
+// flow. The schemes of the protocols of wails.json are registered at install time by the installer on Windows and by
+// the Info.plist on macOS. The URLs are delivered to the application with the DeepLinks option of options.App.
+package deeplink
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotSupported is returned when the platform has no way to register the handler of a scheme, EG: on macOS for
+// applications that aren't bundled
+var ErrNotSupported = errors.New("deeplink: registering schemes is not supported")
+
+type backend interface {
+	register(scheme string, executable string) error
+	isRegistered(scheme string, executable string) (bool, error)
+}
+
+var platform = newBackend()
+
+// Register registers the running executable as the handler of the scheme, EG: "myapp" for myapp:// URLs.
+//
+//   - Windows: the scheme is registered in HKEY_CURRENT_USER\Software\Classes and the URL is passed as the last argument.
+//   - macOS: the application becomes the default handler of the scheme, which must be declared in the Info.plist.
+//   - Linux: a desktop file with the x-scheme-handler MIME type is written to the applications directory of the user and
+//     set as the default handler with xdg-mime. The URL is passed as the last argument.
+func Register(scheme string) error {
+	if err := validateScheme(scheme); err != nil {
+		return err
+	}
+	executable, err := currentExecutable()
+	if err != nil {
+		return err
+	}
+	return platform.register(scheme, executable)
+}
+
+// IsRegistered reports whether the running executable is the handler of the scheme
+func IsRegistered(scheme string) (bool, error) {
+	if err := validateScheme(scheme); err != nil {
+		return false, err
+	}
+	executable, err := currentExecutable()
+	if err != nil {
+		return false, err
+	}
+	return platform.isRegistered(scheme, executable)
+}
+
+// validateScheme checks the scheme with the syntax of RFC 3986: a letter followed by letters, digits, "+", "-" and "."
+func validateScheme(scheme string) error {
+	if scheme == "" {
+		return errors.New("deeplink: the scheme is empty")
+	}
+	for index, char := range scheme {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z':
+		case index > 0 && (char >= '0' && char <= '9' || char == '+' || char == '-' || char == '.'):
+		default:
+			return fmt.Errorf("deeplink: invalid scheme %q, the scheme is given without \"://\"", scheme)
+		}
+	}
+	return nil
+}
+
+func currentExecutable() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(executable)
+}
@@ -0,0 +1,76 @@
+//go:build darwin
+
+package deeplink
+
+/*
+#cgo LDFLAGS: -framework CoreServices
+
+#include <CoreServices/CoreServices.h>
+#include <stdlib.h>
+
+// notBundled is returned when the application has no bundle identifier
+#define notBundled -1
+
+static int registerScheme(const char* scheme) {
+	CFStringRef identifier = CFBundleGetIdentifier(CFBundleGetMainBundle());
+	if (identifier == NULL) {
+		return notBundled;
+	}
+	CFStringRef urlScheme = CFStringCreateWithCString(NULL, scheme, kCFStringEncodingUTF8);
+	OSStatus status = LSSetDefaultHandlerForURLScheme(urlScheme, identifier);
+	CFRelease(urlScheme);
+	return status;
+}
+
+static int isSchemeRegistered(const char* scheme) {
+	CFStringRef identifier = CFBundleGetIdentifier(CFBundleGetMainBundle());
+	if (identifier == NULL) {
+		return notBundled;
+	}
+	CFStringRef urlScheme = CFStringCreateWithCString(NULL, scheme, kCFStringEncodingUTF8);
+	CFStringRef handler = LSCopyDefaultHandlerForURLScheme(urlScheme);
+	CFRelease(urlScheme);
+	if (handler == NULL) {
+		return 0;
+	}
+	int registered = CFStringCompare(handler, identifier, kCFCompareCaseInsensitive) == kCFCompareEqualTo;
+	CFRelease(handler);
+	return registered;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// launchServicesBackend sets the default handler with Launch Services, which identifies the application by its bundle
+type launchServicesBackend struct{}
+
+func newBackend() backend {
+	return launchServicesBackend{}
+}
+
+func (launchServicesBackend) register(scheme string, executable string) error {
+	cScheme := C.CString(scheme)
+	defer C.free(unsafe.Pointer(cScheme))
+	switch status := C.registerScheme(cScheme); status {
+	case 0:
+		return nil
+	case C.notBundled:
+		return fmt.Errorf("%w: the application isn't bundled", ErrNotSupported)
+	default:
+		return fmt.Errorf("registering the scheme %s failed with status %d", scheme, int(status))
+	}
+}
+
+func (launchServicesBackend) isRegistered(scheme string, executable string) (bool, error) {
+	cScheme := C.CString(scheme)
+	defer C.free(unsafe.Pointer(cScheme))
+	registered := C.isSchemeRegistered(cScheme)
+	if registered == C.notBundled {
+		return false, fmt.Errorf("%w: the application isn't bundled", ErrNotSupported)
+	}
+	return registered == 1, nil
+}
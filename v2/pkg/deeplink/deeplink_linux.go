@@ -0,0 +1,103 @@
+//go:build linux
+
+package deeplink
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// xdgBackend registers the schemes with desktop files, which is how the URL openers of the desktops find the handler
+type xdgBackend struct{}
+
+func newBackend() backend {
+	return xdgBackend{}
+}
+
+// runCommand runs the command and returns its output
+var runCommand = func(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%w: %s is not installed", ErrNotSupported, name)
+	}
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", name, err)
+	}
+	return string(output), nil
+}
+
+// applicationsDirectory is the directory of the desktop files of the user
+func applicationsDirectory() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "applications"), nil
+}
+
+// desktopFileID is the name of the desktop file of the scheme, EG: myapp-myapp.desktop
+func desktopFileID(scheme string, executable string) string {
+	return filepath.Base(executable) + "-" + strings.ToLower(scheme) + ".desktop"
+}
+
+// desktopFile returns the content of the desktop file, which is hidden from the application menus
+func desktopFile(scheme string, executable string) string {
+	return "[Desktop Entry]\n" +
+		"Type=Application\n" +
+		"Name=" + filepath.Base(executable) + "\n" +
+		"Exec=" + quoteExec(executable) + " %u\n" +
+		"NoDisplay=true\n" +
+		"MimeType=x-scheme-handler/" + strings.ToLower(scheme) + ";\n"
+}
+
+// quoteExec quotes the argument of the Exec key with the rules of the desktop entry specification
+func quoteExec(arg string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	// The backslashes are escaped again as the value of the key is a string
+	return strings.ReplaceAll(`"`+replacer.Replace(arg)+`"`, `\`, `\\`)
+}
+
+func (xdgBackend) register(scheme string, executable string) error {
+	directory, err := applicationsDirectory()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return err
+	}
+	id := desktopFileID(scheme, executable)
+	if err := os.WriteFile(filepath.Join(directory, id), []byte(desktopFile(scheme, executable)), 0o644); err != nil {
+		return err
+	}
+	// The cache of the MIME types is optional, the desktop file is found without it
+	_, _ = runCommand("update-desktop-database", directory)
+	_, err = runCommand("xdg-mime", "default", id, "x-scheme-handler/"+strings.ToLower(scheme))
+	return err
+}
+
+func (xdgBackend) isRegistered(scheme string, executable string) (bool, error) {
+	output, err := runCommand("xdg-mime", "query", "default", "x-scheme-handler/"+strings.ToLower(scheme))
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(output) != desktopFileID(scheme, executable) {
+		return false, nil
+	}
+	directory, err := applicationsDirectory()
+	if err != nil {
+		return false, err
+	}
+	content, err := os.ReadFile(filepath.Join(directory, desktopFileID(scheme, executable)))
+	if err != nil {
+		return false, nil
+	}
+	// The desktop file is outdated when the executable has been moved
+	return string(content) == desktopFile(scheme, executable), nil
+}
@@ -0,0 +1,61 @@
+package deeplink
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestQuoteExec(t *testing.T) {
+	if quoted := quoteExec(`/opt/My App/app`); quoted != `"/opt/My App/app"` {
+		t.Errorf("unexpected quoting: %s", quoted)
+	}
+	if quoted := quoteExec(`/opt/$app"`); quoted != `"/opt/\\$app\\""` {
+		t.Errorf("unexpected quoting: %s", quoted)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	defaultHandler := ""
+	var commands [][]string
+	runCommand = func(name string, args ...string) (string, error) {
+		commands = append(commands, append([]string{name}, args...))
+		if name == "xdg-mime" && args[0] == "default" {
+			defaultHandler = args[1]
+		}
+		return defaultHandler + "\n", nil
+	}
+
+	backend := xdgBackend{}
+	registered, err := backend.isRegistered("MyApp", "/opt/app/myapp")
+	if err != nil || registered {
+		t.Fatalf("unexpected registration: %v %v", registered, err)
+	}
+	if err := backend.register("MyApp", "/opt/app/myapp"); err != nil {
+		t.Fatal(err)
+	}
+
+	directory, _ := applicationsDirectory()
+	content, err := os.ReadFile(filepath.Join(directory, "myapp-myapp.desktop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[Desktop Entry]\nType=Application\nName=myapp\nExec=\"/opt/app/myapp\" %u\nNoDisplay=true\nMimeType=x-scheme-handler/myapp;\n"
+	if string(content) != expected {
+		t.Errorf("unexpected desktop file:\n%s", content)
+	}
+	if !reflect.DeepEqual(commands[len(commands)-1], []string{"xdg-mime", "default", "myapp-myapp.desktop", "x-scheme-handler/myapp"}) {
+		t.Errorf("unexpected commands: %v", commands)
+	}
+
+	registered, err = backend.isRegistered("MyApp", "/opt/app/myapp")
+	if err != nil || !registered {
+		t.Errorf("unexpected registration: %v %v", registered, err)
+	}
+	registered, _ = backend.isRegistered("MyApp", "/opt/moved/myapp")
+	if registered {
+		t.Error("the moved executable is registered")
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !windows
+
+package deeplink
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) register(scheme string, executable string) error {
+	return ErrNotSupported
+}
+
+func (unsupportedBackend) isRegistered(scheme string, executable string) (bool, error) {
+	return false, ErrNotSupported
+}
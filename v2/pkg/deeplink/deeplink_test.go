@@ -0,0 +1,16 @@
+package deeplink
+
+import "testing"
+
+func TestValidateScheme(t *testing.T) {
+	for _, scheme := range []string{"myapp", "my-app.v2", "web+app"} {
+		if err := validateScheme(scheme); err != nil {
+			t.Errorf("%s: %s", scheme, err)
+		}
+	}
+	for _, scheme := range []string{"", "myapp://", "2app", "my app"} {
+		if err := validateScheme(scheme); err == nil {
+			t.Errorf("%q has been accepted", scheme)
+		}
+	}
+}
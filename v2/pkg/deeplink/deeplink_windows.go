@@ -0,0 +1,73 @@
+//go:build windows
+
+package deeplink
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// registryBackend registers the schemes in the classes of the current user, which don't require elevation
+type registryBackend struct{}
+
+func newBackend() backend {
+	return registryBackend{}
+}
+
+func schemeKey(scheme string) string {
+	return `Software\Classes\` + scheme
+}
+
+func openCommand(executable string) string {
+	return `"` + executable + `" "%1"`
+}
+
+func (registryBackend) register(scheme string, executable string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, schemeKey(scheme), registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+	if err := key.SetStringValue("", "URL:"+scheme+" protocol"); err != nil {
+		return err
+	}
+	if err := key.SetStringValue("URL Protocol", ""); err != nil {
+		return err
+	}
+
+	icon, _, err := registry.CreateKey(registry.CURRENT_USER, schemeKey(scheme)+`\DefaultIcon`, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer icon.Close()
+	if err := icon.SetStringValue("", executable+",0"); err != nil {
+		return err
+	}
+
+	command, _, err := registry.CreateKey(registry.CURRENT_USER, schemeKey(scheme)+`\shell\open\command`, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer command.Close()
+	return command.SetStringValue("", openCommand(executable))
+}
+
+func (registryBackend) isRegistered(scheme string, executable string) (bool, error) {
+	command, err := registry.OpenKey(registry.CURRENT_USER, schemeKey(scheme)+`\shell\open\command`, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer command.Close()
+	value, _, err := command.GetStringValue("")
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return value == openCommand(executable), nil
+}
@@ -0,0 +1,17 @@
+#ifndef Displays_darwin_h
+#define Displays_darwin_h
+
+#include <stdbool.h>
+
+typedef struct {
+    unsigned int id;
+    char *name;
+    bool primary;
+    char *profile;
+    int brightness;
+} DisplayInfo;
+
+int DisplaysGet(DisplayInfo **displays);
+void DisplaysFree(DisplayInfo *displays, int count);
+
+#endif /* Displays_darwin_h */
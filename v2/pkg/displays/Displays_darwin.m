@@ -0,0 +1,64 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AppKit/AppKit.h>
+#include <dlfcn.h>
+#include <stdlib.h>
+#include <string.h>
+
+#import "Displays_darwin.h"
+
+typedef int (*GetBrightnessFunc)(CGDirectDisplayID display, float *brightness);
+
+// builtinBrightness reads the brightness of the built-in display with DisplayServices, which is loaded at runtime as
+// the external displays don't report their brightness
+static int builtinBrightness(CGDirectDisplayID display) {
+    static GetBrightnessFunc getBrightness = NULL;
+    static dispatch_once_t once;
+    dispatch_once(&once, ^{
+        void *handle = dlopen("/System/Library/PrivateFrameworks/DisplayServices.framework/DisplayServices", RTLD_LAZY);
+        if (handle != NULL) {
+            getBrightness = (GetBrightnessFunc)dlsym(handle, "DisplayServicesGetBrightness");
+        }
+    });
+    float brightness = 0;
+    if (getBrightness == NULL || !CGDisplayIsBuiltin(display) || getBrightness(display, &brightness) != 0) {
+        return -1;
+    }
+    return (int)(brightness * 100 + 0.5);
+}
+
+static char* copyString(NSString *string) {
+    return strdup(string != nil ? [string UTF8String] : "");
+}
+
+// DisplaysGet returns the number of displays and stores them in displays, which are freed with DisplaysFree
+int DisplaysGet(DisplayInfo **displays) {
+    @autoreleasepool {
+        NSArray<NSScreen *> *screens = [NSScreen screens];
+        int count = (int)[screens count];
+        *displays = calloc(count, sizeof(DisplayInfo));
+        for (int i = 0; i < count; i++) {
+            NSScreen *screen = screens[i];
+            CGDirectDisplayID display = [[[screen deviceDescription] objectForKey:@"NSScreenNumber"] unsignedIntValue];
+            NSString *name = nil;
+            if (@available(macOS 10.15, *)) {
+                name = [screen localizedName];
+            }
+            (*displays)[i].id = display;
+            (*displays)[i].name = copyString(name);
+            (*displays)[i].primary = CGDisplayIsMain(display);
+            (*displays)[i].profile = copyString([[screen colorSpace] localizedName]);
+            (*displays)[i].brightness = builtinBrightness(display);
+        }
+        return count;
+    }
+}
+
+void DisplaysFree(DisplayInfo *displays, int count) {
+    for (int i = 0; i < count; i++) {
+        free(displays[i].name);
+        free(displays[i].profile);
+    }
+    free(displays);
+}
@@ -0,0 +1,136 @@
+// Package displays reports the ICC colour profile and the brightness of the displays and notices when they change,
+// EG: to warn about uncalibrated displays in imaging applications or to adapt the rendering. The profile is the one
+// assigned by the colour management of the system: GetICMProfile on Windows, the colour space of the NSScreen on macOS
+// and the default profile of the display devices of colord on Linux. The brightness is only reported where the display
+// exposes it: over DDC/CI on Windows, for the built-in display on macOS and for the backlight on Linux. Use
+// runtime.ScreenGetAll for the sizes of the displays. Bind the Displays service to use it from JS.
+package displays
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ChangeEvent is emitted by EmitEvents with the displays when a profile, a brightness or the displays have changed
+const ChangeEvent = "displays:change"
+
+// ErrNotSupported is returned when the displays can't be enumerated on the platform
+var ErrNotSupported = errors.New("displays: not supported")
+
+// UnknownBrightness is the Brightness of the displays that don't expose their brightness
+const UnknownBrightness = -1
+
+const defaultInterval = 5 * time.Second
+
+type backend interface {
+	displays() ([]Display, error)
+}
+
+// Display is the colour and brightness information of a display
+type Display struct {
+	// ID identifies the display: the device name on Windows, the display ID on macOS and the colord device ID on Linux
+	ID string `json:"id"`
+	// Name is the name of the display, EG: the model of the monitor
+	Name    string `json:"name"`
+	Primary bool   `json:"primary"`
+	// ColorProfile is the name of the ICC profile assigned to the display, empty if none has been assigned
+	ColorProfile string `json:"colorProfile"`
+	// Brightness is the brightness in percent, UnknownBrightness if it is not readable
+	Brightness int `json:"brightness"`
+}
+
+// Displays is the displays service
+type Displays struct {
+	// Interval is how often Watch checks the displays. Default: 5 seconds
+	Interval time.Duration
+
+	// OnChange is called with all displays when a display has changed
+	OnChange func(displays []Display)
+
+	backend backend
+	lock    sync.Mutex
+	stop    chan struct{}
+}
+
+// New creates the displays service
+func New() *Displays {
+	return &Displays{backend: newBackend()}
+}
+
+// EmitEvents emits ChangeEvent to the frontend
+func EmitEvents(ctx context.Context, d *Displays) {
+	d.OnChange = func(displays []Display) {
+		runtime.EventsEmit(ctx, ChangeEvent, displays)
+	}
+}
+
+// All returns the displays, the primary display first
+func (d *Displays) All() ([]Display, error) {
+	displays, err := d.backend.displays()
+	if err != nil {
+		return nil, err
+	}
+	for i, display := range displays {
+		if display.Primary && i > 0 {
+			copy(displays[1:i+1], displays[:i])
+			displays[0] = display
+			break
+		}
+	}
+	return displays, nil
+}
+
+// Watch calls OnChange until Stop is called. It fails if the displays can't be enumerated.
+func (d *Displays) Watch() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.stop != nil {
+		return nil
+	}
+	last, err := d.All()
+	if err != nil {
+		return err
+	}
+	interval := d.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	d.stop = make(chan struct{})
+	go d.watch(d.stop, interval, last)
+	return nil
+}
+
+// Stop stops watching
+func (d *Displays) Stop() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+}
+
+func (d *Displays) watch(stop chan struct{}, interval time.Duration, last []Display) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		displays, err := d.All()
+		if err != nil || reflect.DeepEqual(displays, last) {
+			continue
+		}
+		last = displays
+		if d.OnChange != nil {
+			d.OnChange(displays)
+		}
+	}
+}
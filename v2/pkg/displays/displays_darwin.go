@@ -0,0 +1,40 @@
+//go:build darwin
+
+package displays
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AppKit -framework CoreGraphics
+
+#import "Displays_darwin.h"
+*/
+import "C"
+
+import (
+	"strconv"
+	"unsafe"
+)
+
+// screenBackend reads the colour space of the screens of AppKit
+type screenBackend struct{}
+
+func newBackend() backend {
+	return screenBackend{}
+}
+
+func (screenBackend) displays() ([]Display, error) {
+	var displays *C.DisplayInfo
+	count := int(C.DisplaysGet(&displays))
+	defer C.DisplaysFree(displays, C.int(count))
+	result := make([]Display, 0, count)
+	for _, display := range unsafe.Slice(displays, count) {
+		result = append(result, Display{
+			ID:           strconv.FormatUint(uint64(display.id), 10),
+			Name:         C.GoString(display.name),
+			Primary:      bool(display.primary),
+			ColorProfile: C.GoString(display.profile),
+			Brightness:   int(display.brightness),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,111 @@
+//go:build linux
+
+package displays
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	colordName      = "org.freedesktop.ColorManager"
+	colordPath      = "/org/freedesktop/ColorManager"
+	colordDevice    = "org.freedesktop.ColorManager.Device"
+	colordProfile   = "org.freedesktop.ColorManager.Profile"
+	outputPriority  = "OutputPriority"
+	primaryPriority = "primary"
+)
+
+// backlightDir is the sysfs class of the backlights of the built-in panels
+var backlightDir = "/sys/class/backlight"
+
+// colordBackend asks colord for the display devices and their default profile. The desktops register their outputs
+// with colord and tell it which one is the primary output. The brightness is the backlight of the embedded panel.
+type colordBackend struct{}
+
+func newBackend() backend {
+	return colordBackend{}
+}
+
+func (colordBackend) displays() ([]Display, error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	var devices []dbus.ObjectPath
+	err = conn.Object(colordName, colordPath).Call(colordName+".GetDevicesByKind", 0, "display").Store(&devices)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	result := make([]Display, 0, len(devices))
+	for _, path := range devices {
+		var properties map[string]dbus.Variant
+		err := conn.Object(colordName, path).Call("org.freedesktop.DBus.Properties.GetAll", 0, colordDevice).Store(&properties)
+		if err != nil {
+			continue
+		}
+		display := colordDisplay(properties)
+		if embedded, _ := properties["Embedded"].Value().(bool); embedded {
+			display.Brightness = backlightBrightness(backlightDir)
+		}
+		if profiles, _ := properties["Profiles"].Value().([]dbus.ObjectPath); len(profiles) > 0 {
+			// The first profile is the default profile of the device
+			if title, err := conn.Object(colordName, profiles[0]).GetProperty(colordProfile + ".Title"); err == nil {
+				display.ColorProfile, _ = title.Value().(string)
+			}
+		}
+		result = append(result, display)
+	}
+	return result, nil
+}
+
+// colordDisplay returns the display of the properties of a colord device, without profile and brightness
+func colordDisplay(properties map[string]dbus.Variant) Display {
+	id, _ := properties["DeviceId"].Value().(string)
+	vendor, _ := properties["Vendor"].Value().(string)
+	model, _ := properties["Model"].Value().(string)
+	metadata, _ := properties["Metadata"].Value().(map[string]string)
+	name := strings.TrimSpace(vendor + " " + model)
+	if model != "" && strings.HasPrefix(model, vendor) {
+		name = model
+	}
+	return Display{
+		ID:         id,
+		Name:       name,
+		Primary:    metadata[outputPriority] == primaryPriority,
+		Brightness: UnknownBrightness,
+	}
+}
+
+// backlightBrightness returns the brightness of the first backlight in dir in percent
+func backlightBrightness(dir string) int {
+	backlights, err := os.ReadDir(dir)
+	if err != nil {
+		return UnknownBrightness
+	}
+	for _, backlight := range backlights {
+		current, err := readInt(filepath.Join(dir, backlight.Name(), "brightness"))
+		if err != nil {
+			continue
+		}
+		maximum, err := readInt(filepath.Join(dir, backlight.Name(), "max_brightness"))
+		if err != nil || maximum <= 0 {
+			continue
+		}
+		return current * 100 / maximum
+	}
+	return UnknownBrightness
+}
+
+func readInt(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
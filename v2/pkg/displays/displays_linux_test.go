@@ -0,0 +1,48 @@
+//go:build linux
+
+package displays
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestColordDisplay(t *testing.T) {
+	display := colordDisplay(map[string]dbus.Variant{
+		"DeviceId": dbus.MakeVariant("xrandr-Dell Inc.-DELL U2720Q-1234"),
+		"Vendor":   dbus.MakeVariant("Dell Inc."),
+		"Model":    dbus.MakeVariant("DELL U2720Q"),
+		"Metadata": dbus.MakeVariant(map[string]string{outputPriority: primaryPriority}),
+	})
+	want := Display{ID: "xrandr-Dell Inc.-DELL U2720Q-1234", Name: "Dell Inc. DELL U2720Q", Primary: true, Brightness: UnknownBrightness}
+	if display != want {
+		t.Errorf("colordDisplay() = %+v, want %+v", display, want)
+	}
+
+	display = colordDisplay(map[string]dbus.Variant{"Vendor": dbus.MakeVariant("LG"), "Model": dbus.MakeVariant("LG Ultra HD")})
+	if display.Name != "LG Ultra HD" || display.Primary {
+		t.Errorf("colordDisplay() = %+v", display)
+	}
+}
+
+func TestBacklightBrightness(t *testing.T) {
+	dir := t.TempDir()
+	if brightness := backlightBrightness(dir); brightness != UnknownBrightness {
+		t.Errorf("brightness without backlight = %d", brightness)
+	}
+	backlight := filepath.Join(dir, "intel_backlight")
+	if err := os.Mkdir(backlight, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{"brightness": "9600\n", "max_brightness": "19200\n"} {
+		if err := os.WriteFile(filepath.Join(backlight, name), []byte(value), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if brightness := backlightBrightness(dir); brightness != 50 {
+		t.Errorf("brightness = %d, want 50", brightness)
+	}
+}
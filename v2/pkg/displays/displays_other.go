@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !windows
+
+package displays
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) displays() ([]Display, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,84 @@
+package displays
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBackend returns the displays one after another and then the last ones
+type fakeBackend struct {
+	lock    sync.Mutex
+	results [][]Display
+	err     error
+}
+
+func (b *fakeBackend) displays() ([]Display, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.err != nil {
+		return nil, b.err
+	}
+	displays := append([]Display(nil), b.results[0]...)
+	if len(b.results) > 1 {
+		b.results = b.results[1:]
+	}
+	return displays, nil
+}
+
+func TestAllPrimaryFirst(t *testing.T) {
+	d := &Displays{backend: &fakeBackend{results: [][]Display{{{ID: "1"}, {ID: "2"}, {ID: "3", Primary: true}}}}}
+	displays, err := d.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Display{{ID: "3", Primary: true}, {ID: "1"}, {ID: "2"}}
+	if !reflect.DeepEqual(displays, want) {
+		t.Errorf("All() = %+v, want %+v", displays, want)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	calibrated := Display{ID: "1", Primary: true, ColorProfile: "Calibrated", Brightness: 80}
+	backend := &fakeBackend{results: [][]Display{
+		{{ID: "1", Primary: true, ColorProfile: "sRGB", Brightness: 80}}, // checked by Watch
+		{{ID: "1", Primary: true, ColorProfile: "sRGB", Brightness: 80}},
+		{calibrated},
+		{calibrated},
+		{calibrated, {ID: "2", Brightness: UnknownBrightness}},
+	}}
+	d := &Displays{Interval: time.Millisecond, backend: backend}
+
+	var lock sync.Mutex
+	var changes [][]Display
+	done := make(chan struct{})
+	d.OnChange = func(displays []Display) {
+		lock.Lock()
+		defer lock.Unlock()
+		changes = append(changes, displays)
+		if len(changes) == 2 {
+			close(done)
+		}
+	}
+
+	if err := d.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	want := [][]Display{{calibrated}, {calibrated, {ID: "2", Brightness: UnknownBrightness}}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+
+	d = &Displays{backend: &fakeBackend{err: ErrNotSupported}}
+	if err := d.Watch(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Watch() = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,136 @@
+//go:build windows
+
+package displays
+
+import (
+	"errors"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/windows/winc/w32"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	gdi32                                       = windows.NewLazySystemDLL("gdi32.dll")
+	procGetICMProfile                           = gdi32.NewProc("GetICMProfileW")
+	user32                                      = windows.NewLazySystemDLL("user32.dll")
+	procEnumDisplayDevices                      = user32.NewProc("EnumDisplayDevicesW")
+	dxva2                                       = windows.NewLazySystemDLL("dxva2.dll")
+	procGetNumberOfPhysicalMonitorsFromHMONITOR = dxva2.NewProc("GetNumberOfPhysicalMonitorsFromHMONITOR")
+	procGetPhysicalMonitorsFromHMONITOR         = dxva2.NewProc("GetPhysicalMonitorsFromHMONITOR")
+	procGetMonitorBrightness                    = dxva2.NewProc("GetMonitorBrightness")
+	procDestroyPhysicalMonitors                 = dxva2.NewProc("DestroyPhysicalMonitors")
+)
+
+// displayDevice is DISPLAY_DEVICEW
+type displayDevice struct {
+	cb           uint32
+	deviceName   [32]uint16
+	deviceString [128]uint16
+	stateFlags   uint32
+	deviceID     [128]uint16
+	deviceKey    [128]uint16
+}
+
+// physicalMonitor is PHYSICAL_MONITOR
+type physicalMonitor struct {
+	handle      windows.Handle
+	description [128]uint16
+}
+
+// monitorContainer collects the monitors of EnumDisplayMonitors
+type monitorContainer struct {
+	monitors []w32.HMONITOR
+}
+
+func enumProc(hMonitor w32.HMONITOR, hdcMonitor w32.HDC, lprcMonitor *w32.RECT, container *monitorContainer) uintptr {
+	container.monitors = append(container.monitors, hMonitor)
+	return w32.TRUE
+}
+
+// gdiBackend reads the profiles from the device contexts of the monitors and the brightness over DDC/CI
+type gdiBackend struct{}
+
+func newBackend() backend {
+	return gdiBackend{}
+}
+
+func (gdiBackend) displays() ([]Display, error) {
+	var container monitorContainer
+	if !w32.EnumDisplayMonitors(0, nil, syscall.NewCallback(enumProc), unsafe.Pointer(&container)) {
+		return nil, errors.New("displays: EnumDisplayMonitors failed")
+	}
+	result := make([]Display, 0, len(container.monitors))
+	for _, monitor := range container.monitors {
+		var info w32.MONITORINFOEX
+		info.CbSize = uint32(unsafe.Sizeof(info))
+		if !w32.GetMonitorInfo(monitor, (*w32.MONITORINFO)(unsafe.Pointer(&info))) {
+			continue
+		}
+		device := windows.UTF16ToString(info.SzDevice[:])
+		result = append(result, Display{
+			ID:           device,
+			Name:         monitorName(&info.SzDevice[0]),
+			Primary:      info.DwFlags&w32.MONITORINFOF_PRIMARY != 0,
+			ColorProfile: colorProfile(&info.SzDevice[0]),
+			Brightness:   brightness(monitor),
+		})
+	}
+	return result, nil
+}
+
+// monitorName returns the name of the monitor attached to the display adapter device
+func monitorName(device *uint16) string {
+	var monitor displayDevice
+	monitor.cb = uint32(unsafe.Sizeof(monitor))
+	ret, _, _ := procEnumDisplayDevices.Call(uintptr(unsafe.Pointer(device)), 0, uintptr(unsafe.Pointer(&monitor)), 0)
+	if ret == 0 {
+		return ""
+	}
+	return windows.UTF16ToString(monitor.deviceString[:])
+}
+
+// colorProfile returns the file name of the ICC profile of the device without the colour directory
+func colorProfile(device *uint16) string {
+	driver, _ := windows.UTF16PtrFromString("DISPLAY")
+	dc := w32.CreateDC(driver, device, nil, nil)
+	if dc == 0 {
+		return ""
+	}
+	defer w32.DeleteDC(dc)
+	size := uint32(windows.MAX_PATH)
+	profile := make([]uint16, size)
+	ret, _, _ := procGetICMProfile.Call(uintptr(dc), uintptr(unsafe.Pointer(&size)), uintptr(unsafe.Pointer(&profile[0])))
+	if ret == 0 {
+		return ""
+	}
+	return filepath.Base(windows.UTF16ToString(profile))
+}
+
+// brightness reads the brightness of the first physical monitor of the monitor, monitors without DDC/CI support fail
+func brightness(monitor w32.HMONITOR) int {
+	if procGetNumberOfPhysicalMonitorsFromHMONITOR.Find() != nil {
+		return UnknownBrightness
+	}
+	var count uint32
+	ret, _, _ := procGetNumberOfPhysicalMonitorsFromHMONITOR.Call(uintptr(monitor), uintptr(unsafe.Pointer(&count)))
+	if ret == 0 || count == 0 {
+		return UnknownBrightness
+	}
+	physical := make([]physicalMonitor, count)
+	ret, _, _ = procGetPhysicalMonitorsFromHMONITOR.Call(uintptr(monitor), uintptr(count), uintptr(unsafe.Pointer(&physical[0])))
+	if ret == 0 {
+		return UnknownBrightness
+	}
+	defer procDestroyPhysicalMonitors.Call(uintptr(count), uintptr(unsafe.Pointer(&physical[0])))
+
+	var minimum, current, maximum uint32
+	ret, _, _ = procGetMonitorBrightness.Call(uintptr(physical[0].handle),
+		uintptr(unsafe.Pointer(&minimum)), uintptr(unsafe.Pointer(&current)), uintptr(unsafe.Pointer(&maximum)))
+	if ret == 0 || maximum <= minimum {
+		return UnknownBrightness
+	}
+	return int((current - minimum) * 100 / (maximum - minimum))
+}
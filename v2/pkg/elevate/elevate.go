@@ -0,0 +1,64 @@
+// Package elevate provides functions to run commands and relaunch Wails applications with elevated privileges.
+// The user is asked for permission by the operating system: UAC on Windows, an administrator prompt on macOS
+// and pkexec on Linux.
+package elevate
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// ErrCancelled is returned when the user declined the elevation request
+var ErrCancelled = errors.New("the elevation request has been cancelled")
+
+// Result contains the result of a command that has been run with elevated privileges
+type Result struct {
+	// ExitCode is the exit code of the command
+	ExitCode int
+
+	// Output contains the combined stdout and stderr of the command. The output is not available on Windows.
+	Output string
+
+	// Error is set if the command could not be run or failed
+	Error error
+}
+
+// Run runs the command with elevated privileges in the background and calls the callback with the result
+// once the command has finished
+func Run(callback func(Result), name string, args ...string) {
+	go func() {
+		result := Command(name, args...)
+		if callback != nil {
+			callback(result)
+		}
+	}()
+}
+
+// Command runs the command with elevated privileges and waits for it to finish
+func Command(name string, args ...string) Result {
+	return command(name, args)
+}
+
+// Relaunch starts a new instance of this application with elevated privileges and the given arguments.
+// The current instance should quit afterward, EG: with runtime.Quit.
+func Relaunch(args ...string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return relaunch(exe, args)
+}
+
+// IsElevated returns true if the current process is running with elevated privileges
+func IsElevated() bool {
+	return isElevated()
+}
+
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,70 @@
+//go:build darwin
+
+package elevate
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+// osascriptErrorNumber matches the error number at the end of an osascript error, EG: "User canceled. (-128)"
+var osascriptErrorNumber = regexp.MustCompile(`\((-?\d+)\)\s*$`)
+
+const userCancelled = -128
+
+func command(name string, args []string) Result {
+	script := fmt.Sprintf(`do shell script "%s 2>&1" with administrator privileges`, appleScriptEscape(shellCommand(name, args)))
+	stdout, stderr, err := shell.RunCommand("/tmp", "osascript", "-e", script)
+	result := Result{
+		Output: stdout,
+	}
+	if err != nil {
+		result.ExitCode = exitCode(err)
+		if match := osascriptErrorNumber.FindStringSubmatch(stderr); match != nil {
+			// The error number is the exit code of the shell script
+			result.ExitCode, _ = strconv.Atoi(match[1])
+		}
+		if result.ExitCode == userCancelled {
+			result.Error = ErrCancelled
+		} else {
+			result.Error = fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+		}
+	}
+	return result
+}
+
+func relaunch(exe string, args []string) error {
+	script := fmt.Sprintf(`do shell script "%s > /dev/null 2>&1 &" with administrator privileges`, appleScriptEscape(shellCommand(exe, args)))
+	_, stderr, err := shell.RunCommand("/tmp", "osascript", "-e", script)
+	if err != nil {
+		if strings.Contains(stderr, "(-128)") {
+			return ErrCancelled
+		}
+		return fmt.Errorf("%s: %w", strings.TrimSpace(stderr), err)
+	}
+	return nil
+}
+
+func isElevated() bool {
+	return os.Geteuid() == 0
+}
+
+// shellCommand quotes the command and its arguments for /bin/sh
+func shellCommand(name string, args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{name}, args...) {
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	return strings.Join(quoted, " ")
+}
+
+// appleScriptEscape escapes the string to be used inside an AppleScript string literal
+func appleScriptEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
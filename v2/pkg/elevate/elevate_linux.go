@@ -0,0 +1,53 @@
+//go:build linux
+
+package elevate
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// pkexec exits with 126 if the authorization could not be obtained because the dialog was dismissed
+const pkexecDismissed = 126
+
+func command(name string, args []string) Result {
+	cmd := exec.Command("pkexec", append([]string{name}, args...)...)
+	output, err := cmd.CombinedOutput()
+	result := Result{
+		Output: string(output),
+	}
+	if err != nil {
+		result.ExitCode = exitCode(err)
+		if result.ExitCode == pkexecDismissed {
+			result.Error = ErrCancelled
+		} else {
+			result.Error = fmt.Errorf("%s: %w", strings.TrimSpace(result.Output), err)
+		}
+	}
+	return result
+}
+
+func relaunch(exe string, args []string) error {
+	// pkexec clears the environment, pass the variables needed to open a window
+	pkexecArgs := []string{"env"}
+	for _, key := range []string{"DISPLAY", "XAUTHORITY", "WAYLAND_DISPLAY", "XDG_RUNTIME_DIR"} {
+		if value, ok := os.LookupEnv(key); ok {
+			pkexecArgs = append(pkexecArgs, key+"="+value)
+		}
+	}
+	pkexecArgs = append(pkexecArgs, exe)
+	pkexecArgs = append(pkexecArgs, args...)
+
+	cmd := exec.Command("pkexec", pkexecArgs...)
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+func isElevated() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,105 @@
+//go:build windows
+
+package elevate
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modshell32          = windows.NewLazySystemDLL("shell32.dll")
+	procShellExecuteExW = modshell32.NewProc("ShellExecuteExW")
+)
+
+const (
+	seeMaskNoCloseProcess = 0x00000040
+	seeMaskNoAsync        = 0x00000100
+)
+
+// shellExecuteInfo mirrors SHELLEXECUTEINFOW
+type shellExecuteInfo struct {
+	cbSize         uint32
+	fMask          uint32
+	hwnd           windows.Handle
+	lpVerb         *uint16
+	lpFile         *uint16
+	lpParameters   *uint16
+	lpDirectory    *uint16
+	nShow          int32
+	hInstApp       windows.Handle
+	lpIDList       uintptr
+	lpClass        *uint16
+	hkeyClass      windows.Handle
+	dwHotKey       uint32
+	hIconOrMonitor windows.Handle
+	hProcess       windows.Handle
+}
+
+// runAs starts the command with the "runas" verb, which shows the UAC prompt
+func runAs(name string, args []string, show int32, wait bool) (int, error) {
+	parameters := make([]string, 0, len(args))
+	for _, arg := range args {
+		parameters = append(parameters, syscall.EscapeArg(arg))
+	}
+
+	info := shellExecuteInfo{
+		fMask:        seeMaskNoCloseProcess | seeMaskNoAsync,
+		lpVerb:       windows.StringToUTF16Ptr("runas"),
+		lpFile:       windows.StringToUTF16Ptr(name),
+		lpParameters: windows.StringToUTF16Ptr(strings.Join(parameters, " ")),
+		nShow:        show,
+	}
+	info.cbSize = uint32(unsafe.Sizeof(info))
+
+	ret, _, err := procShellExecuteExW.Call(uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		if err == windows.ERROR_CANCELLED {
+			return -1, ErrCancelled
+		}
+		return -1, err
+	}
+	if info.hProcess == 0 {
+		return 0, nil
+	}
+	defer windows.CloseHandle(info.hProcess)
+
+	if !wait {
+		return 0, nil
+	}
+
+	_, err = windows.WaitForSingleObject(info.hProcess, windows.INFINITE)
+	if err != nil {
+		return -1, err
+	}
+	var exitCode uint32
+	err = windows.GetExitCodeProcess(info.hProcess, &exitCode)
+	if err != nil {
+		return -1, err
+	}
+	return int(exitCode), nil
+}
+
+func command(name string, args []string) Result {
+	exitCode, err := runAs(name, args, windows.SW_HIDE, true)
+	if err == nil && exitCode != 0 {
+		err = fmt.Errorf("exit status %d", exitCode)
+	}
+	return Result{
+		ExitCode: exitCode,
+		Error:    err,
+	}
+}
+
+func relaunch(exe string, args []string) error {
+	_, err := runAs(exe, args, windows.SW_SHOWNORMAL, false)
+	return err
+}
+
+func isElevated() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
@@ -0,0 +1,319 @@
+// Package featureflags provides feature flags with local defaults that can be refreshed from a remote JSON document,
+// EG: to enable features for a stage of a rollout without a new build. The remote values override the defaults and
+// are cached, so they also apply offline until the next refresh. Bind the Flags service to read the flags from JS
+// and call EmitEvents to keep a store of the frontend up to date.
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ChangeEvent is emitted by EmitEvents with the names of the changed flags and all flags after a change
+const ChangeEvent = "featureflags:change"
+
+// defaultTimeout limits the requests of Refresh when the client has no timeout
+const defaultTimeout = 30 * time.Second
+
+// ErrNoRemote is returned by Refresh and Start when the flags have no remote
+var ErrNoRemote = errors.New("featureflags: no remote")
+
+// Remote is the JSON document the flags are refreshed from. It is an object of the flags and their values, EG:
+// {"newEditor": true, "maxUploads": 5}.
+type Remote struct {
+	URL string
+	// Header is added to the requests, EG: an Authorization or the channel of the user
+	Header http.Header
+	// Interval refreshes the flags periodically after Start
+	Interval time.Duration
+	// CacheFile stores the last remote flags, so they apply from the start of the next launch
+	CacheFile string
+	// Client is used for the requests. http.DefaultClient is used if it is nil.
+	Client *http.Client
+}
+
+type cache struct {
+	ETag  string                 `json:"etag,omitempty"`
+	Flags map[string]interface{} `json:"flags"`
+}
+
+// Flags is the feature flags service
+type Flags struct {
+	// OnChange is called with the names of the changed flags and all flags after a refresh has changed them
+	OnChange func(changed []string, flags map[string]interface{})
+
+	remote *Remote
+
+	lock     sync.Mutex
+	defaults map[string]interface{}
+	values   map[string]interface{}
+	etag     string
+	stop     chan struct{}
+}
+
+// New creates the flags with the defaults and the remote, which may be nil. The cached remote flags are loaded.
+func New(defaults map[string]interface{}, remote *Remote) *Flags {
+	f := &Flags{remote: remote, defaults: normalise(defaults), values: map[string]interface{}{}}
+	if remote != nil && remote.CacheFile != "" {
+		if data, err := os.ReadFile(remote.CacheFile); err == nil {
+			var cached cache
+			if json.Unmarshal(data, &cached) == nil && cached.Flags != nil {
+				f.values = cached.Flags
+				f.etag = cached.ETag
+			}
+		}
+	}
+	return f
+}
+
+// EmitEvents emits ChangeEvent to the frontend
+func EmitEvents(ctx context.Context, f *Flags) {
+	f.OnChange = func(changed []string, flags map[string]interface{}) {
+		runtime.EventsEmit(ctx, ChangeEvent, changed, flags)
+	}
+}
+
+// normalise converts the values to the types of JSON, so the defaults compare with the remote values
+func normalise(flags map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	data, err := json.Marshal(flags)
+	if err == nil {
+		err = json.Unmarshal(data, &result)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("featureflags: the defaults aren't JSON: %v", err))
+	}
+	return result
+}
+
+// all returns the remote values over the defaults
+func (f *Flags) all() map[string]interface{} {
+	result := map[string]interface{}{}
+	for name, value := range f.defaults {
+		result[name] = value
+	}
+	for name, value := range f.values {
+		result[name] = value
+	}
+	return result
+}
+
+// All returns all flags, the remote values override the defaults
+func (f *Flags) All() map[string]interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.all()
+}
+
+// Value returns the value of the flag, nil if it doesn't exist
+func (f *Flags) Value(name string) interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if value, ok := f.values[name]; ok {
+		return value
+	}
+	return f.defaults[name]
+}
+
+// typed returns the remote value of the flag if it has the type of the parser, otherwise the default
+func typed[T any](f *Flags, name string, parse func(value interface{}) (T, bool)) T {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if value, ok := parse(f.values[name]); ok {
+		return value
+	}
+	value, _ := parse(f.defaults[name])
+	return value
+}
+
+// Bool returns the value of a boolean flag, false if it doesn't exist. Remote values of other types are ignored,
+// as with the other typed accessors.
+func (f *Flags) Bool(name string) bool {
+	return typed(f, name, func(value interface{}) (bool, bool) {
+		result, ok := value.(bool)
+		return result, ok
+	})
+}
+
+// String returns the value of a string flag, "" if it doesn't exist
+func (f *Flags) String(name string) string {
+	return typed(f, name, func(value interface{}) (string, bool) {
+		result, ok := value.(string)
+		return result, ok
+	})
+}
+
+// Int returns the value of an integer flag, 0 if it doesn't exist. Numbers with fractions are ignored.
+func (f *Flags) Int(name string) int {
+	return typed(f, name, func(value interface{}) (int, bool) {
+		number, ok := value.(float64)
+		if !ok || number != float64(int(number)) {
+			return 0, false
+		}
+		return int(number), true
+	})
+}
+
+// Float returns the value of a numeric flag, 0 if it doesn't exist
+func (f *Flags) Float(name string) float64 {
+	return typed(f, name, func(value interface{}) (float64, bool) {
+		result, ok := value.(float64)
+		return result, ok
+	})
+}
+
+// Refresh fetches the remote flags and calls OnChange if they changed any flag. The flags are kept when the remote
+// can't be fetched. An error writing the cache is returned after the flags have been updated.
+func (f *Flags) Refresh() error {
+	if f.remote == nil || f.remote.URL == "" {
+		return ErrNoRemote
+	}
+	client := f.remote.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx := context.Background()
+	if client.Timeout == 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, f.remote.URL, nil)
+	if err != nil {
+		return err
+	}
+	for name, values := range f.remote.Header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Accept", "application/json")
+	f.lock.Lock()
+	if f.etag != "" {
+		request.Header.Set("If-None-Match", f.etag)
+	}
+	f.lock.Unlock()
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("featureflags: GET %s: %s", f.remote.URL, response.Status)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("featureflags: invalid flags: %w", err)
+	}
+	return f.update(values, response.Header.Get("ETag"))
+}
+
+// update replaces the remote flags, calls OnChange with the changed flags and writes the cache
+func (f *Flags) update(values map[string]interface{}, etag string) error {
+	f.lock.Lock()
+	before := f.all()
+	f.values = values
+	f.etag = etag
+	after := f.all()
+	f.lock.Unlock()
+
+	var changed []string
+	for name, value := range after {
+		if previous, ok := before[name]; !ok || !reflect.DeepEqual(previous, value) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) > 0 && f.OnChange != nil {
+		sort.Strings(changed)
+		f.OnChange(changed, after)
+	}
+	if f.remote.CacheFile == "" {
+		return nil
+	}
+	if err := writeCache(f.remote.CacheFile, cache{ETag: etag, Flags: values}); err != nil {
+		return fmt.Errorf("featureflags: unable to write the cache: %w", err)
+	}
+	return nil
+}
+
+func writeCache(path string, cached cache) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// The cache is replaced at once, so a crash can't leave a partial file
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+// Start refreshes the flags now and then at the interval of the remote until Stop is called. The errors of the
+// periodic refreshes are ignored, the flags are kept until a refresh succeeds.
+func (f *Flags) Start() error {
+	if f.remote == nil || f.remote.URL == "" {
+		return ErrNoRemote
+	}
+	f.lock.Lock()
+	if f.stop != nil {
+		f.lock.Unlock()
+		return nil
+	}
+	stop := make(chan struct{})
+	f.stop = stop
+	f.lock.Unlock()
+
+	err := f.Refresh()
+	if f.remote.Interval > 0 {
+		go func() {
+			ticker := time.NewTicker(f.remote.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					_ = f.Refresh()
+				}
+			}
+		}()
+	}
+	return err
+}
+
+// Stop stops the periodic refreshes
+func (f *Flags) Stop() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.stop != nil {
+		close(f.stop)
+		f.stop = nil
+	}
+}
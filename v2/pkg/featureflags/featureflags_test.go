@@ -0,0 +1,106 @@
+package featureflags
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var defaults = map[string]interface{}{
+	"newEditor":  false,
+	"maxUploads": 3,
+	"theme":      "classic",
+	"ratio":      0.5,
+}
+
+func TestDefaults(t *testing.T) {
+	f := New(defaults, nil)
+	if f.Bool("newEditor") || f.Int("maxUploads") != 3 || f.String("theme") != "classic" || f.Float("ratio") != 0.5 {
+		t.Errorf("unexpected flags %v", f.All())
+	}
+	if f.Bool("missing") || f.Value("missing") != nil {
+		t.Error("expected the zero value for a missing flag")
+	}
+	if err := f.Refresh(); !errors.Is(err, ErrNoRemote) {
+		t.Errorf("Refresh() error = %v, want ErrNoRemote", err)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	body := `{"newEditor": true, "maxUploads": "many", "beta": "on"}`
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Channel") != "beta" {
+			t.Errorf("missing header, got %v", r.Header)
+		}
+		if r.Header.Get("If-None-Match") == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"1"`)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "cache", "flags.json")
+	remote := &Remote{URL: server.URL, Header: http.Header{"X-Channel": {"beta"}}, CacheFile: cacheFile}
+	f := New(defaults, remote)
+	var changes [][]string
+	f.OnChange = func(changed []string, flags map[string]interface{}) {
+		changes = append(changes, changed)
+		if flags["newEditor"] != true {
+			t.Errorf("OnChange flags = %v", flags)
+		}
+	}
+	if err := f.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	// Remote values of the wrong type fall back to the defaults
+	if !f.Bool("newEditor") || f.Int("maxUploads") != 3 || f.String("beta") != "on" {
+		t.Errorf("unexpected flags %v", f.All())
+	}
+	if !reflect.DeepEqual(changes, [][]string{{"beta", "maxUploads", "newEditor"}}) {
+		t.Errorf("changes = %v", changes)
+	}
+
+	// The cached flags apply from the start and their ETag is sent
+	cached := New(defaults, remote)
+	if !cached.Bool("newEditor") {
+		t.Errorf("cached flags = %v", cached.All())
+	}
+	if err := cached.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 || !cached.Bool("newEditor") {
+		t.Errorf("requests = %d, flags = %v", requests, cached.All())
+	}
+
+	// Refreshing with the same flags doesn't change anything
+	body = `{"newEditor": true, "maxUploads": "many", "beta": "on"}`
+	remote.CacheFile = ""
+	f.etag = ""
+	if err := f.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("changes = %v", changes)
+	}
+}
+
+func TestRefreshFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	f := New(defaults, &Remote{URL: server.URL})
+	if err := f.Refresh(); err == nil {
+		t.Error("expected an error")
+	}
+	if f.Int("maxUploads") != 3 {
+		t.Errorf("unexpected flags %v", f.All())
+	}
+}
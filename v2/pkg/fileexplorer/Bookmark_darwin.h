@@ -0,0 +1,14 @@
+#ifndef Bookmark_darwin_h
+#define Bookmark_darwin_h
+
+#include <stdbool.h>
+
+bool BookmarkIsSandboxed(void);
+void* BookmarkCreate(const char *path, int *length, char **error);
+char* BookmarkResolve(const void *bookmark, int length, bool *stale, char **error);
+void BookmarkStopAccessing(const char *path);
+char* BookmarkRemember(const char *path);
+void BookmarkForget(const char *path);
+char* BookmarkRestore(void);
+
+#endif /* Bookmark_darwin_h */
@@ -0,0 +1,153 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#include <stdlib.h>
+#include <string.h>
+
+#import "Bookmark_darwin.h"
+
+// The remembered bookmarks are kept in the user defaults, which are stored in the container of sandboxed applications
+static NSString *const rememberedBookmarksKey = @"WailsSecurityScopedBookmarks";
+
+// accessedURLs returns the resolved URLs by their path, the access is given up by calling
+// stopAccessingSecurityScopedResource on the URL that has been resolved
+static NSMutableDictionary<NSString*, NSURL*>* accessedURLs(void) {
+    static NSMutableDictionary *urls;
+    static dispatch_once_t once;
+    dispatch_once(&once, ^{
+        urls = [NSMutableDictionary new];
+    });
+    return urls;
+}
+
+// copyString returns a copy of the string that is freed by Go
+static char* copyString(NSString *value) {
+    return strdup(value.UTF8String);
+}
+
+bool BookmarkIsSandboxed(void) {
+    return [NSProcessInfo processInfo].environment[@"APP_SANDBOX_CONTAINER_ID"] != nil;
+}
+
+static NSData* createBookmark(NSString *path, NSError **error) {
+    NSURLBookmarkCreationOptions options = BookmarkIsSandboxed() ? NSURLBookmarkCreationWithSecurityScope : 0;
+    return [[NSURL fileURLWithPath:path] bookmarkDataWithOptions:options includingResourceValuesForKeys:nil relativeToURL:nil error:error];
+}
+
+// resolveBookmark resolves the bookmark and starts accessing its item, a previous access of the path is given up
+static NSURL* resolveBookmark(NSData *bookmark, BOOL *stale, NSError **error) {
+    NSURLBookmarkResolutionOptions options = NSURLBookmarkResolutionWithoutUI;
+    if (BookmarkIsSandboxed()) {
+        options |= NSURLBookmarkResolutionWithSecurityScope;
+    }
+    NSURL *url = [NSURL URLByResolvingBookmarkData:bookmark options:options relativeToURL:nil bookmarkDataIsStale:stale error:error];
+    if (url == nil) {
+        return nil;
+    }
+    // It returns false outside of the sandbox, where the access isn't restricted
+    [url startAccessingSecurityScopedResource];
+    NSMutableDictionary *urls = accessedURLs();
+    @synchronized (urls) {
+        [urls[url.path] stopAccessingSecurityScopedResource];
+        urls[url.path] = url;
+    }
+    return url;
+}
+
+void* BookmarkCreate(const char *path, int *length, char **error) {
+    NSError *err = nil;
+    NSData *bookmark = createBookmark([NSString stringWithUTF8String:path], &err);
+    if (bookmark == nil) {
+        *error = copyString(err.localizedDescription);
+        return NULL;
+    }
+    void *result = malloc(bookmark.length);
+    memcpy(result, bookmark.bytes, bookmark.length);
+    *length = (int)bookmark.length;
+    return result;
+}
+
+char* BookmarkResolve(const void *bookmark, int length, bool *stale, char **error) {
+    NSError *err = nil;
+    BOOL isStale = NO;
+    NSURL *url = resolveBookmark([NSData dataWithBytes:bookmark length:length], &isStale, &err);
+    if (url == nil) {
+        *error = copyString(err.localizedDescription);
+        return NULL;
+    }
+    *stale = isStale;
+    return copyString(url.path);
+}
+
+void BookmarkStopAccessing(const char *path) {
+    NSString *_path = [NSString stringWithUTF8String:path];
+    NSMutableDictionary *urls = accessedURLs();
+    @synchronized (urls) {
+        [urls[_path] stopAccessingSecurityScopedResource];
+        [urls removeObjectForKey:_path];
+    }
+}
+
+// BookmarkRemember creates the bookmark of the path and remembers it, it returns the error or NULL
+char* BookmarkRemember(const char *path) {
+    NSString *_path = [NSString stringWithUTF8String:path];
+    NSError *err = nil;
+    NSData *bookmark = createBookmark(_path, &err);
+    if (bookmark == nil) {
+        return copyString(err.localizedDescription);
+    }
+    NSUserDefaults *defaults = [NSUserDefaults standardUserDefaults];
+    @synchronized (defaults) {
+        NSMutableDictionary *remembered = [NSMutableDictionary dictionaryWithDictionary:[defaults dictionaryForKey:rememberedBookmarksKey]];
+        remembered[_path] = bookmark;
+        [defaults setObject:remembered forKey:rememberedBookmarksKey];
+    }
+    return NULL;
+}
+
+void BookmarkForget(const char *path) {
+    NSUserDefaults *defaults = [NSUserDefaults standardUserDefaults];
+    @synchronized (defaults) {
+        NSMutableDictionary *remembered = [NSMutableDictionary dictionaryWithDictionary:[defaults dictionaryForKey:rememberedBookmarksKey]];
+        [remembered removeObjectForKey:[NSString stringWithUTF8String:path]];
+        [defaults setObject:remembered forKey:rememberedBookmarksKey];
+    }
+}
+
+// BookmarkRestore resolves the remembered bookmarks and returns the JSON array of the paths of their items. The stale
+// bookmarks are renewed by the paths the items have been moved to, the bookmarks of deleted items are forgotten and
+// the others are kept, EG: of items on volumes that aren't mounted.
+char* BookmarkRestore(void) {
+    NSUserDefaults *defaults = [NSUserDefaults standardUserDefaults];
+    NSMutableArray *paths = [NSMutableArray array];
+    @synchronized (defaults) {
+        NSDictionary *remembered = [defaults dictionaryForKey:rememberedBookmarksKey];
+        NSMutableDictionary *restored = [NSMutableDictionary dictionary];
+        for (NSString *path in remembered) {
+            NSData *bookmark = remembered[path];
+            if (![bookmark isKindOfClass:[NSData class]]) {
+                continue;
+            }
+            NSError *err = nil;
+            BOOL stale = NO;
+            NSURL *url = resolveBookmark(bookmark, &stale, &err);
+            if (url == nil) {
+                if (!([err.domain isEqualToString:NSCocoaErrorDomain] && err.code == NSFileNoSuchFileError)) {
+                    restored[path] = bookmark;
+                }
+                continue;
+            }
+            if (stale) {
+                NSData *renewed = createBookmark(url.path, nil);
+                if (renewed != nil) {
+                    bookmark = renewed;
+                }
+            }
+            restored[url.path] = bookmark;
+            [paths addObject:url.path];
+        }
+        [defaults setObject:restored forKey:rememberedBookmarksKey];
+    }
+    NSData *json = [NSJSONSerialization dataWithJSONObject:paths options:0 error:nil];
+    return copyString([[[NSString alloc] initWithData:json encoding:NSUTF8StringEncoding] autorelease]);
+}
@@ -0,0 +1,89 @@
+package fileexplorer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCancelled is returned when the user closes the Open With dialog without choosing an application
+var ErrCancelled = errors.New("fileexplorer: cancelled by the user")
+
+// ErrNotSupported is returned when the desktop doesn't provide the Open With dialog
+var ErrNotSupported = errors.New("fileexplorer: not supported by the desktop")
+
+// Application is an application that opens files
+type Application struct {
+	Name string `json:"name"`
+	// Path is the executable on Windows, the application bundle on macOS and the desktop file on Linux
+	Path string `json:"path"`
+	// ID is the ProgID on Windows, the bundle identifier on macOS and the desktop file ID on Linux, EG:
+	// org.gnome.Evince.desktop. It is empty for the applications of Applications on Windows.
+	ID string `json:"id"`
+}
+
+// DefaultApplication returns the application that opens the file when the user opens it in the file manager. The
+// file type is determined by the file or by an extension, EG: ".pdf". It returns nil if no application is
+// associated with the file type.
+func DefaultApplication(path string) (*Application, error) {
+	path, err := associationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return defaultApplication(path)
+}
+
+// Applications returns the applications that are registered for the file type of the file or the extension, EG:
+// to offer an "Open With" menu. The default application is the first one.
+func Applications(path string) ([]Application, error) {
+	path, err := associationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return applications(path)
+}
+
+// OpenWith opens the file with the application, which is usually one of Applications
+func OpenWith(path string, app Application) error {
+	path, err := existingPath(path)
+	if err != nil {
+		return err
+	}
+	return openWith(path, app)
+}
+
+// OpenWithDialog prompts the user to choose an application for the file and opens it: with the Open With dialog of
+// Explorer on Windows, an application chooser on macOS and the application chooser of the desktop portal on Linux.
+// It returns ErrCancelled when the user closes the dialog.
+func OpenWithDialog(path string) error {
+	path, err := existingPath(path)
+	if err != nil {
+		return err
+	}
+	return openWithDialog(path)
+}
+
+// isExtension returns true for a file extension, EG: ".pdf"
+func isExtension(path string) bool {
+	return len(path) > 1 && strings.HasPrefix(path, ".") && !strings.ContainsAny(path, `/\`) && filepath.Ext(path) == path
+}
+
+// associationPath returns extensions as they are and the absolute path of files
+func associationPath(path string) (string, error) {
+	if isExtension(path) {
+		return strings.ToLower(path), nil
+	}
+	return existingPath(path)
+}
+
+func existingPath(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
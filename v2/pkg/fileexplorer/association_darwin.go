@@ -0,0 +1,107 @@
+//go:build darwin
+
+package fileexplorer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+// applicationsScript returns the default application or all applications that open the file with NSWorkspace
+const applicationsScript = `ObjC.import("AppKit");
+
+function application(url) {
+	const bundle = $.NSBundle.bundleWithURL(url);
+	return {
+		name: $.NSFileManager.defaultManager.displayNameAtPath(url.path).js,
+		path: url.path.js,
+		id: bundle.isNil() || bundle.bundleIdentifier.isNil() ? "" : bundle.bundleIdentifier.js,
+	};
+}
+
+function run(argv) {
+	const workspace = $.NSWorkspace.sharedWorkspace;
+	const url = $.NSURL.fileURLWithPath(argv[0]);
+	const defaultURL = workspace.URLForApplicationToOpenURL(url);
+	const apps = defaultURL.isNil() ? [] : [application(defaultURL)];
+	// URLsForApplicationsToOpenURL is available since macOS 12
+	if (argv[1] === "all" && workspace.respondsToSelector("URLsForApplicationsToOpenURL:")) {
+		ObjC.unwrap(workspace.URLsForApplicationsToOpenURL(url)).forEach((appURL) => {
+			if (defaultURL.isNil() || !appURL.isEqual(defaultURL)) {
+				apps.push(application(appURL));
+			}
+		});
+	}
+	return JSON.stringify(apps);
+}`
+
+// queryApplications runs the script for the file. Launch Services determine the applications of extensions by the
+// name of the file, so an empty file with the extension is queried for them.
+func queryApplications(path string, all bool) ([]Application, error) {
+	if isExtension(path) {
+		dir, err := os.MkdirTemp("", "wails-association")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		path = filepath.Join(dir, "file"+path)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			return nil, err
+		}
+	}
+	mode := "default"
+	if all {
+		mode = "all"
+	}
+	stdout, stderr, err := shell.RunCommand("/tmp", "osascript", "-l", "JavaScript", "-e", applicationsScript, path, mode)
+	if err != nil {
+		return nil, fmt.Errorf("querying the applications of %s failed: %s", path, strings.TrimSpace(stderr))
+	}
+	var apps []Application
+	if err := json.Unmarshal([]byte(stdout), &apps); err != nil {
+		return nil, err
+	}
+	for index := range apps {
+		apps[index].Name = strings.TrimSuffix(apps[index].Name, ".app")
+	}
+	return apps, nil
+}
+
+func defaultApplication(path string) (*Application, error) {
+	apps, err := queryApplications(path, false)
+	if err != nil || len(apps) == 0 {
+		return nil, err
+	}
+	return &apps[0], nil
+}
+
+func applications(path string) ([]Application, error) {
+	return queryApplications(path, true)
+}
+
+func openWith(path string, app Application) error {
+	_, stderr, err := shell.RunCommand("/tmp", "open", "-a", app.Path, path)
+	if err != nil {
+		return fmt.Errorf("opening %s with %s failed: %s", path, app.Name, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+func openWithDialog(path string) error {
+	script := fmt.Sprintf(`POSIX path of (choose application with title "Open With" with prompt "Choose an application to open “%s”:" as alias)`, appleScriptEscape(filepath.Base(path)))
+	stdout, stderr, err := shell.RunCommand("/tmp", "osascript", "-e", script)
+	if err != nil {
+		// -128 is the error of the Cancel button
+		if strings.Contains(stderr, "(-128)") {
+			return ErrCancelled
+		}
+		return fmt.Errorf("choosing an application failed: %s", strings.TrimSpace(stderr))
+	}
+	app := strings.TrimSpace(stdout)
+	return openWith(path, Application{Name: strings.TrimSuffix(filepath.Base(app), ".app"), Path: app})
+}
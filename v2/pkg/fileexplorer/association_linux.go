@@ -0,0 +1,271 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
+)
+
+// mimeTypeOf returns the MIME type of the file or the extension. The content of files is inspected by the shared
+// MIME database if xdg-mime is installed.
+func mimeTypeOf(path string) string {
+	if !isExtension(path) {
+		if output, err := exec.Command("xdg-mime", "query", "filetype", path).Output(); err == nil {
+			if mimeType := strings.TrimSpace(string(output)); mimeType != "" {
+				return mimeType
+			}
+		}
+		path = filepath.Ext(path)
+	}
+	mimeType, _, _ := mime.ParseMediaType(mime.TypeByExtension(path))
+	return mimeType
+}
+
+func dataHome() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share")
+}
+
+func configHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config")
+}
+
+func splitDirs(value string, defaults string) []string {
+	if value == "" {
+		value = defaults
+	}
+	return filepath.SplitList(value)
+}
+
+// applicationDirs returns the directories of the desktop files in the order of precedence
+func applicationDirs() []string {
+	dirs := []string{filepath.Join(dataHome(), "applications")}
+	for _, dir := range splitDirs(os.Getenv("XDG_DATA_DIRS"), "/usr/local/share:/usr/share") {
+		dirs = append(dirs, filepath.Join(dir, "applications"))
+	}
+	return dirs
+}
+
+// mimeAppsLists returns the mimeapps.list files in the order of precedence
+func mimeAppsLists() []string {
+	lists := []string{filepath.Join(configHome(), "mimeapps.list")}
+	for _, dir := range splitDirs(os.Getenv("XDG_CONFIG_DIRS"), "/etc/xdg") {
+		lists = append(lists, filepath.Join(dir, "mimeapps.list"))
+	}
+	for _, dir := range applicationDirs() {
+		lists = append(lists, filepath.Join(dir, "mimeapps.list"))
+	}
+	return lists
+}
+
+// parseKeyFile returns the values of the groups of a desktop entry file, EG: mimeapps.list
+func parseKeyFile(path string) (map[string]map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	groups := map[string]map[string]string{}
+	var group map[string]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := line[1 : len(line)-1]
+			if groups[name] == nil {
+				groups[name] = map[string]string{}
+			}
+			group = groups[name]
+		case group != nil:
+			if key, value, ok := strings.Cut(line, "="); ok {
+				group[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return groups, scanner.Err()
+}
+
+// splitList splits a list of desktop file IDs, which is terminated by a semicolon
+func splitList(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ";") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// associations returns the desktop file IDs of the MIME type by the Association between MIME types and applications
+// specification. The default application is the first one.
+func associations(mimeType string) []string {
+	var defaults, added []string
+	removed := map[string]bool{}
+	for _, list := range mimeAppsLists() {
+		groups, err := parseKeyFile(list)
+		if err != nil {
+			continue
+		}
+		// Removals of lists with a higher precedence don't apply to the defaults and additions of the same list
+		for _, id := range splitList(groups["Default Applications"][mimeType]) {
+			if !removed[id] {
+				defaults = append(defaults, id)
+			}
+		}
+		for _, id := range splitList(groups["Added Associations"][mimeType]) {
+			if !removed[id] {
+				added = append(added, id)
+			}
+		}
+		for _, id := range splitList(groups["Removed Associations"][mimeType]) {
+			removed[id] = true
+		}
+	}
+	for _, dir := range applicationDirs() {
+		groups, err := parseKeyFile(filepath.Join(dir, "mimeinfo.cache"))
+		if err != nil {
+			continue
+		}
+		for _, id := range splitList(groups["MIME Cache"][mimeType]) {
+			if !removed[id] {
+				added = append(added, id)
+			}
+		}
+	}
+
+	// The first installed default is the default application, otherwise the first association
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range defaults {
+		if findDesktopFile(id) != "" {
+			ids = append(ids, id)
+			seen[id] = true
+			break
+		}
+	}
+	for _, id := range added {
+		if !seen[id] {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+	return ids
+}
+
+// findDesktopFile returns the path of the desktop file with the ID. A dash in the ID can be a subdirectory, EG:
+// kde4-okular.desktop is kde4/okular.desktop.
+func findDesktopFile(id string) string {
+	candidates := []string{id}
+	for index, char := range id {
+		if char == '-' {
+			candidates = append(candidates, id[:index]+"/"+id[index+1:])
+		}
+	}
+	for _, dir := range applicationDirs() {
+		for _, candidate := range candidates {
+			path := filepath.Join(dir, filepath.FromSlash(candidate))
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// loadApplication returns the application of the desktop file. It returns nil if it isn't installed or is hidden.
+func loadApplication(id string) *Application {
+	path := findDesktopFile(id)
+	if path == "" {
+		return nil
+	}
+	groups, err := parseKeyFile(path)
+	if err != nil {
+		return nil
+	}
+	entry := groups["Desktop Entry"]
+	if entry == nil || entry["Hidden"] == "true" {
+		return nil
+	}
+	name := entry["Name"]
+	if name == "" {
+		name = strings.TrimSuffix(id, ".desktop")
+	}
+	return &Application{Name: name, Path: path, ID: id}
+}
+
+func applications(path string) ([]Application, error) {
+	mimeType := mimeTypeOf(path)
+	if mimeType == "" {
+		return nil, nil
+	}
+	var apps []Application
+	for _, id := range associations(mimeType) {
+		if app := loadApplication(id); app != nil {
+			apps = append(apps, *app)
+		}
+	}
+	return apps, nil
+}
+
+func defaultApplication(path string) (*Application, error) {
+	apps, err := applications(path)
+	if err != nil || len(apps) == 0 {
+		return nil, err
+	}
+	return &apps[0], nil
+}
+
+func openWith(path string, app Application) error {
+	var cmd *exec.Cmd
+	switch {
+	case app.Path != "" && commandExists("gio"):
+		cmd = exec.Command("gio", "launch", app.Path, path)
+	case commandExists("gtk-launch"):
+		cmd = exec.Command("gtk-launch", app.ID, path)
+	default:
+		return errors.New("opening files with an application requires gio or gtk-launch")
+	}
+	// The output isn't captured, as the application would inherit the pipes and they would stay open until it exits
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("opening %s with %s failed: %w", path, app.Name, err)
+	}
+	return nil
+}
+
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func openWithDialog(path string) error {
+	opened, err := portal.OpenFileWith("", path, true)
+	if errors.Is(err, portal.ErrUnavailable) {
+		return fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	if err != nil {
+		return err
+	}
+	if !opened {
+		return ErrCancelled
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_CONFIG_DIRS", filepath.Join(dir, "etc"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	t.Setenv("XDG_DATA_DIRS", filepath.Join(dir, "usr"))
+	userApps := filepath.Join(dir, "data", "applications")
+	systemApps := filepath.Join(dir, "usr", "applications")
+
+	writeFile(t, filepath.Join(systemApps, "org.gnome.Evince.desktop"), "[Desktop Entry]\nName=Document Viewer\nName[de]=Dokumentenbetrachter\nExec=evince %U\n")
+	writeFile(t, filepath.Join(systemApps, "kde4", "okular.desktop"), "[Desktop Entry]\nName=Okular\n")
+	writeFile(t, filepath.Join(systemApps, "firefox.desktop"), "[Desktop Entry]\nName=Firefox\n")
+	writeFile(t, filepath.Join(userApps, "gimp.desktop"), "[Desktop Entry]\nName=GIMP\nHidden=true\n")
+	writeFile(t, filepath.Join(systemApps, "gimp.desktop"), "[Desktop Entry]\nName=GIMP\n")
+	writeFile(t, filepath.Join(systemApps, "mimeinfo.cache"), "[MIME Cache]\napplication/pdf=org.gnome.Evince.desktop;firefox.desktop;gimp.desktop;\n")
+	// The user list takes precedence over the list of the system
+	writeFile(t, filepath.Join(dir, "config", "mimeapps.list"), "[Default Applications]\napplication/pdf=missing.desktop;kde4-okular.desktop;\n\n[Removed Associations]\napplication/pdf=firefox.desktop;\n")
+	writeFile(t, filepath.Join(dir, "etc", "mimeapps.list"), "[Default Applications]\napplication/pdf=org.gnome.Evince.desktop\n[Added Associations]\napplication/pdf=firefox.desktop;\n")
+
+	apps, err := Applications(".pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The hidden desktop file of the user hides gimp.desktop of the system
+	want := []Application{
+		{Name: "Okular", Path: filepath.Join(systemApps, "kde4", "okular.desktop"), ID: "kde4-okular.desktop"},
+		{Name: "Document Viewer", Path: filepath.Join(systemApps, "org.gnome.Evince.desktop"), ID: "org.gnome.Evince.desktop"},
+	}
+	if len(apps) != len(want) {
+		t.Fatalf("expected %v, got %v", want, apps)
+	}
+	for index := range want {
+		if apps[index] != want[index] {
+			t.Errorf("expected %v, got %v", want[index], apps[index])
+		}
+	}
+
+	app, err := DefaultApplication(".PDF")
+	if err != nil || app == nil || app.ID != "kde4-okular.desktop" {
+		t.Errorf("unexpected default application %v, %v", app, err)
+	}
+	if app, err := DefaultApplication(".unknown-extension"); err != nil || app != nil {
+		t.Errorf("expected no application, got %v, %v", app, err)
+	}
+}
@@ -0,0 +1,209 @@
+//go:build windows
+
+package fileexplorer
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	assocfNoTruncate        = 0x20
+	assocfInitIgnoreUnknown = 0x400
+
+	assocstrExecutable      = 2
+	assocstrFriendlyAppName = 4
+	assocstrProgID          = 20
+
+	assocFilterRecommended = 1
+
+	oaifAllowRegistration = 0x1
+	oaifExec              = 0x4
+
+	errorNoAssociation = 0x80070483 // HRESULT_FROM_WIN32(ERROR_NO_ASSOCIATION)
+	errorCancelled     = 0x800704C7 // HRESULT_FROM_WIN32(ERROR_CANCELLED)
+	sFalse             = 1
+)
+
+var (
+	procAssocQueryString    = windows.NewLazySystemDLL("shlwapi.dll").NewProc("AssocQueryStringW")
+	procSHAssocEnumHandlers = windows.NewLazySystemDLL("shell32.dll").NewProc("SHAssocEnumHandlers")
+	procSHOpenWithDialog    = windows.NewLazySystemDLL("shell32.dll").NewProc("SHOpenWithDialog")
+)
+
+type openAsInfo struct {
+	pcszFile    *uint16
+	pcszClass   *uint16
+	oaifInFlags uint32
+}
+
+// extensionOf returns the extension that the associations are registered for
+func extensionOf(path string) string {
+	if isExtension(path) {
+		return path
+	}
+	return filepath.Ext(path)
+}
+
+// assocQueryString returns the string of the association of the extension. It returns an empty string if there is
+// no association.
+func assocQueryString(str uintptr, extension string) (string, error) {
+	assoc, err := syscall.UTF16PtrFromString(extension)
+	if err != nil {
+		return "", err
+	}
+	var length uint32
+	result, _, _ := procAssocQueryString.Call(assocfNoTruncate|assocfInitIgnoreUnknown, str, uintptr(unsafe.Pointer(assoc)), 0, 0, uintptr(unsafe.Pointer(&length)))
+	if uint32(result) == errorNoAssociation {
+		return "", nil
+	}
+	if uint32(result) != sFalse || length == 0 {
+		return "", fmt.Errorf("querying the association of %s failed with code 0x%x", extension, result)
+	}
+	buffer := make([]uint16, length)
+	result, _, _ = procAssocQueryString.Call(assocfNoTruncate|assocfInitIgnoreUnknown, str, uintptr(unsafe.Pointer(assoc)), 0, uintptr(unsafe.Pointer(&buffer[0])), uintptr(unsafe.Pointer(&length)))
+	if uint32(result) != 0 {
+		return "", fmt.Errorf("querying the association of %s failed with code 0x%x", extension, result)
+	}
+	return syscall.UTF16ToString(buffer), nil
+}
+
+func defaultApplication(path string) (*Application, error) {
+	extension := extensionOf(path)
+	if extension == "" {
+		return nil, nil
+	}
+	executable, err := assocQueryString(assocstrExecutable, extension)
+	if err != nil || executable == "" {
+		return nil, err
+	}
+	app := &Application{Name: filepath.Base(executable), Path: executable}
+	if name, err := assocQueryString(assocstrFriendlyAppName, extension); err == nil && name != "" {
+		app.Name = name
+	}
+	app.ID, _ = assocQueryString(assocstrProgID, extension)
+	return app, nil
+}
+
+// runOnCOMThread runs the function on a locked thread with an initialised single-threaded apartment, which the shell
+// objects and dialogs require
+func runOnCOMThread(fn func() error) error {
+	result := make(chan error)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		if err := windows.CoInitializeEx(0, windows.COINIT_APARTMENTTHREADED); err != nil {
+			result <- err
+			return
+		}
+		defer windows.CoUninitialize()
+		result <- fn()
+	}()
+	return <-result
+}
+
+// comCall calls the method with the index of the virtual table of the COM object
+func comCall(object unsafe.Pointer, method int, args ...uintptr) uintptr {
+	vtbl := *(**[16]uintptr)(object)
+	result, _, _ := syscall.SyscallN(vtbl[method], append([]uintptr{uintptr(object)}, args...)...)
+	return result
+}
+
+// comString calls a method that returns a string allocated with CoTaskMemAlloc
+func comString(object unsafe.Pointer, method int) string {
+	var value *uint16
+	if uint32(comCall(object, method, uintptr(unsafe.Pointer(&value)))) != 0 || value == nil {
+		return ""
+	}
+	defer windows.CoTaskMemFree(unsafe.Pointer(value))
+	return windows.UTF16PtrToString(value)
+}
+
+// Methods of IUnknown, IEnumAssocHandlers and IAssocHandler
+const (
+	methodRelease   = 2
+	methodNext      = 3
+	methodGetName   = 3
+	methodGetUIName = 4
+)
+
+func applications(path string) ([]Application, error) {
+	extension := extensionOf(path)
+	if extension == "" {
+		return nil, nil
+	}
+	assoc, err := syscall.UTF16PtrFromString(extension)
+	if err != nil {
+		return nil, err
+	}
+	var apps []Application
+	err = runOnCOMThread(func() error {
+		var enum unsafe.Pointer
+		result, _, _ := procSHAssocEnumHandlers.Call(uintptr(unsafe.Pointer(assoc)), assocFilterRecommended, uintptr(unsafe.Pointer(&enum)))
+		if uint32(result) != 0 {
+			return fmt.Errorf("enumerating the applications of %s failed with code 0x%x", extension, result)
+		}
+		defer comCall(enum, methodRelease)
+		for {
+			var handler unsafe.Pointer
+			var fetched uint32
+			if uint32(comCall(enum, methodNext, 1, uintptr(unsafe.Pointer(&handler)), uintptr(unsafe.Pointer(&fetched)))) != 0 || fetched == 0 {
+				return nil
+			}
+			// GetName returns the path of the executable
+			app := Application{Path: comString(handler, methodGetName), Name: comString(handler, methodGetUIName)}
+			comCall(handler, methodRelease)
+			if app.Path != "" {
+				apps = append(apps, app)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The default application is registered as recommended handler too
+	if defaultApp, err := defaultApplication(path); err == nil && defaultApp != nil {
+		for index, app := range apps {
+			if filepath.Clean(app.Path) == filepath.Clean(defaultApp.Path) {
+				apps = append(apps[:index], apps[index+1:]...)
+				break
+			}
+		}
+		apps = append([]Application{*defaultApp}, apps...)
+	}
+	return apps, nil
+}
+
+func openWith(path string, app Application) error {
+	cmd := exec.Command(app.Path, path)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+func openWithDialog(path string) error {
+	file, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	return runOnCOMThread(func() error {
+		info := openAsInfo{pcszFile: file, oaifInFlags: oaifAllowRegistration | oaifExec}
+		result, _, _ := procSHOpenWithDialog.Call(0, uintptr(unsafe.Pointer(&info)))
+		switch uint32(result) {
+		case 0:
+			return nil
+		case errorCancelled:
+			return ErrCancelled
+		default:
+			return fmt.Errorf("the Open With dialog failed with code 0x%x", result)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package fileexplorer
+
+import "fmt"
+
+/*
+Security-scoped bookmarks keep the access of sandboxed applications on macOS, EG: of the Mac App Store, to the files
+and directories that the user has chosen. The App Sandbox only grants the access to the items of the dialogs until the
+application quits, the bookmarks restore it when the application is launched again. The open dialogs of sandboxed
+applications remember the bookmarks of the chosen items and the application restores them when it starts, so the
+paths returned by the dialogs stay accessible. This requires the com.apple.security.files.bookmarks.app-scope
+entitlement.
+
+Outside of the sandbox the bookmarks are regular bookmarks of macOS, which follow the items when they are moved. On
+Windows and Linux the bookmarks are the paths.
+*/
+
+// IsSandboxed returns true if the application runs in the App Sandbox of macOS
+func IsSandboxed() bool {
+	return isSandboxed()
+}
+
+// CreateBookmark returns a bookmark of the file or directory, which has to be accessible, EG: because it has been
+// chosen in a dialog. The bookmark can be stored by the application and resolved with ResolveBookmark after the
+// application has been launched again.
+func CreateBookmark(path string) ([]byte, error) {
+	path, err := existingPath(path)
+	if err != nil {
+		return nil, err
+	}
+	bookmark, err := createBookmark(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating a bookmark of %s failed: %w", path, err)
+	}
+	return bookmark, nil
+}
+
+// ResolveBookmark returns the path of the bookmark and starts accessing the item, the access is held until
+// StopAccessing is called with the path or the application quits. Stale is true if the item has been moved or
+// renamed since the bookmark has been created, then the bookmark should be replaced by a new one of the path.
+func ResolveBookmark(bookmark []byte) (path string, stale bool, err error) {
+	if len(bookmark) == 0 {
+		return "", false, fmt.Errorf("the bookmark is empty")
+	}
+	path, stale, err = resolveBookmark(bookmark)
+	if err != nil {
+		return "", false, fmt.Errorf("resolving the bookmark failed: %w", err)
+	}
+	return path, stale, nil
+}
+
+// StopAccessing gives up the access to the item of a bookmark that has been resolved by ResolveBookmark or
+// RestoreBookmarks
+func StopAccessing(path string) {
+	stopAccessing(path)
+}
+
+// RememberBookmark creates a bookmark of the file or directory and keeps it in the user defaults of the application,
+// so RestoreBookmarks restores the access to it. The open dialogs remember the chosen items of sandboxed
+// applications. The items of the save dialog don't exist yet when it returns, so they are remembered by the
+// application after writing them.
+func RememberBookmark(path string) error {
+	path, err := existingPath(path)
+	if err != nil {
+		return err
+	}
+	err = rememberBookmark(path)
+	if err != nil {
+		return fmt.Errorf("remembering the bookmark of %s failed: %w", path, err)
+	}
+	return nil
+}
+
+// ForgetBookmark removes the remembered bookmark of the path, the current access to the item isn't given up
+func ForgetBookmark(path string) {
+	forgetBookmark(path)
+}
+
+// RestoreBookmarks resolves the remembered bookmarks and starts accessing their items. It returns the paths of the
+// items, which differ from the remembered paths if the items have been moved. The bookmarks of deleted items are
+// forgotten. The bookmarks of sandboxed applications are restored when the application starts.
+func RestoreBookmarks() ([]string, error) {
+	return restoreBookmarks()
+}
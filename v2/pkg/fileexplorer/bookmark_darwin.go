@@ -0,0 +1,81 @@
+//go:build darwin
+
+package fileexplorer
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation
+
+#import "Bookmark_darwin.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"unsafe"
+)
+
+func isSandboxed() bool {
+	return bool(C.BookmarkIsSandboxed())
+}
+
+// bookmarkError returns the error that has been written by the Objective-C functions and frees it
+func bookmarkError(cerror *C.char) error {
+	defer C.free(unsafe.Pointer(cerror))
+	return errors.New(C.GoString(cerror))
+}
+
+func createBookmark(path string) ([]byte, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	var length C.int
+	var cerror *C.char
+	bookmark := C.BookmarkCreate(cpath, &length, &cerror)
+	if bookmark == nil {
+		return nil, bookmarkError(cerror)
+	}
+	defer C.free(bookmark)
+	return C.GoBytes(bookmark, length), nil
+}
+
+func resolveBookmark(bookmark []byte) (string, bool, error) {
+	var stale C.bool
+	var cerror *C.char
+	cpath := C.BookmarkResolve(unsafe.Pointer(&bookmark[0]), C.int(len(bookmark)), &stale, &cerror)
+	if cpath == nil {
+		return "", false, bookmarkError(cerror)
+	}
+	defer C.free(unsafe.Pointer(cpath))
+	return C.GoString(cpath), bool(stale), nil
+}
+
+func stopAccessing(path string) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	C.BookmarkStopAccessing(cpath)
+}
+
+func rememberBookmark(path string) error {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	if cerror := C.BookmarkRemember(cpath); cerror != nil {
+		return bookmarkError(cerror)
+	}
+	return nil
+}
+
+func forgetBookmark(path string) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	C.BookmarkForget(cpath)
+}
+
+func restoreBookmarks() ([]string, error) {
+	cpaths := C.BookmarkRestore()
+	defer C.free(unsafe.Pointer(cpaths))
+	var paths []string
+	err := json.Unmarshal([]byte(C.GoString(cpaths)), &paths)
+	return paths, err
+}
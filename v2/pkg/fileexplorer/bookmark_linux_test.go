@@ -0,0 +1,42 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBookmarks(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if IsSandboxed() {
+		t.Error("expected Linux applications not to be sandboxed")
+	}
+
+	bookmark, err := CreateBookmark(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, stale, err := ResolveBookmark(bookmark)
+	if err != nil || path != file || stale {
+		t.Errorf("ResolveBookmark() = %q, %v, %v", path, stale, err)
+	}
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ResolveBookmark(bookmark); err == nil {
+		t.Error("expected the bookmark of a deleted file to fail")
+	}
+	if _, err := CreateBookmark(file); err == nil {
+		t.Error("expected the bookmark of a missing file to fail")
+	}
+	if _, _, err := ResolveBookmark(nil); err == nil {
+		t.Error("expected an empty bookmark to fail")
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !darwin
+
+package fileexplorer
+
+import "os"
+
+func isSandboxed() bool {
+	return false
+}
+
+func createBookmark(path string) ([]byte, error) {
+	return []byte(path), nil
+}
+
+func resolveBookmark(bookmark []byte) (string, bool, error) {
+	path := string(bookmark)
+	if _, err := os.Stat(path); err != nil {
+		return "", false, err
+	}
+	return path, false, nil
+}
+
+func stopAccessing(path string) {}
+
+func rememberBookmark(path string) error {
+	return nil
+}
+
+func forgetBookmark(path string) {}
+
+func restoreBookmarks() ([]string, error) {
+	return nil, nil
+}
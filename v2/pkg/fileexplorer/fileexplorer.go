@@ -0,0 +1,232 @@
+// Package fileexplorer integrates applications with the file manager of the operating system: Explorer on Windows,
+// Finder on macOS and the file manager of the desktop environment on Linux.
+package fileexplorer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Options override the file manager that is detected, EG: to force the file manager of a kiosk or a managed desktop
+type Options struct {
+	// Binary is the file manager to run, EG: "pcmanfm" or `C:\Program Files\totalcmd\TOTALCMD64.EXE`. The detected
+	// file manager is used if it is empty.
+	Binary string `json:"binary"`
+	// Args are passed before the paths, EG: []string{"--select"}. The paths are the items if they are selected,
+	// otherwise their directories.
+	Args []string `json:"args"`
+}
+
+var (
+	optionsLock    sync.Mutex
+	currentOptions Options
+)
+
+// SetOptions sets the options of the file manager for Open, OpenContext, OpenMultiple and OpenMultipleContext
+func SetOptions(options Options) {
+	optionsLock.Lock()
+	defer optionsLock.Unlock()
+	currentOptions = Options{Binary: options.Binary, Args: append([]string{}, options.Args...)}
+}
+
+func getOptions() Options {
+	optionsLock.Lock()
+	defer optionsLock.Unlock()
+	return currentOptions
+}
+
+// Detection is the file manager that is used to open paths and the reason it has been chosen
+type Detection struct {
+	// FileManager is the name of the file manager or of the interface that is asked to open the paths, EG: "nautilus"
+	// or "org.freedesktop.FileManager1"
+	FileManager string `json:"fileManager"`
+	// Binary is the binary that is run, it is empty if the file manager is asked over D-Bus or the desktop portal
+	Binary string `json:"binary"`
+	// Args are passed to the binary before the paths
+	Args []string `json:"args"`
+	// CanSelect is false if the file manager can't select items, then their directories are opened instead
+	CanSelect bool `json:"canSelect"`
+	// Reason explains why the file manager has been chosen, EG: to log it when revealing files misbehaves
+	Reason string `json:"reason"`
+}
+
+// Detect returns the file manager that opens paths with the current options. On Linux the session bus is asked for
+// the file manager of the session, so the result can change when the file manager is started or quit.
+func Detect() Detection {
+	if options := getOptions(); options.Binary != "" {
+		return Detection{
+			FileManager: filepath.Base(options.Binary),
+			Binary:      options.Binary,
+			Args:        options.Args,
+			CanSelect:   true,
+			Reason:      "the file manager is set by the options",
+		}
+	}
+	return detect()
+}
+
+// Open opens the directory in the file manager. If selectFile is true, the file or directory is selected in the
+// window of its parent directory instead. Files are never opened, without selectFile their directory is opened.
+// Open waits for the file manager to return, use OpenContext to bound the wait.
+func Open(path string, selectFile bool) error {
+	return OpenContext(context.Background(), path, selectFile)
+}
+
+// OpenContext is like Open, but kills the file manager and returns the error of the context if the context is done
+// before the file manager returns, EG: after a timeout of context.WithTimeout.
+func OpenContext(ctx context.Context, path string, selectFile bool) error {
+	return OpenMultipleContext(ctx, []string{path}, selectFile)
+}
+
+// OpenMultiple is like Open for several paths, EG: to reveal the exported files. If selectFiles is true, the items of
+// a directory are selected together in one window of the directory, otherwise every directory is opened once. On
+// macOS several items are revealed by Finder with AppleScript, which asks the user to allow the application to
+// control Finder.
+func OpenMultiple(paths []string, selectFiles bool) error {
+	return OpenMultipleContext(context.Background(), paths, selectFiles)
+}
+
+// OpenMultipleContext is like OpenMultiple with the cancellation of OpenContext
+func OpenMultipleContext(ctx context.Context, paths []string, selectFiles bool) error {
+	if len(paths) == 0 {
+		return errors.New("no paths to open in the file manager")
+	}
+	items := make([]string, 0, len(paths))
+	seen := map[string]bool{}
+	for _, path := range paths {
+		path, err := existingPath(path)
+		if err != nil {
+			return err
+		}
+		if !selectFiles {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				path = filepath.Dir(path)
+			}
+		}
+		if !seen[path] {
+			seen[path] = true
+			items = append(items, path)
+		}
+	}
+	var err error
+	if options := getOptions(); options.Binary != "" {
+		err = run(ctx, exec.CommandContext(ctx, options.Binary, append(append([]string{}, options.Args...), items...)...))
+	} else {
+		err = open(ctx, items, selectFiles)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	}
+	if err != nil {
+		return fmt.Errorf("opening %s in the file manager failed: %w", strings.Join(items, ", "), err)
+	}
+	return nil
+}
+
+// Opening is a file manager that is opened by OpenAsync or OpenMultipleAsync
+type Opening struct {
+	// Process is the first process that has been started to open the paths, EG: to kill it. It is nil if the file
+	// manager has been asked over D-Bus, the desktop portal or the shell API, which return when the paths are shown.
+	Process *os.Process
+
+	done chan struct{}
+	err  error
+}
+
+// Done is closed when the file manager has returned
+func (o *Opening) Done() <-chan struct{} {
+	return o.done
+}
+
+// Wait waits for the file manager to return and returns the error of opening the paths
+func (o *Opening) Wait() error {
+	<-o.done
+	return o.err
+}
+
+// OpenAsync is like Open, but returns as soon as the file manager has been started instead of waiting for it to
+// return, which is the lifetime of the window for some file managers on Linux. Errors of the file manager after it
+// has been started are returned by Wait.
+func OpenAsync(path string, selectFile bool) (*Opening, error) {
+	return OpenMultipleAsync([]string{path}, selectFile)
+}
+
+// OpenMultipleAsync is like OpenMultiple, but returns like OpenAsync
+func OpenMultipleAsync(paths []string, selectFiles bool) (*Opening, error) {
+	opening := &Opening{done: make(chan struct{})}
+	started := make(chan *os.Process, 1)
+	var once sync.Once
+	ctx := context.WithValue(context.Background(), startedKey{}, func(process *os.Process) {
+		once.Do(func() { started <- process })
+	})
+	go func() {
+		opening.err = OpenMultipleContext(ctx, paths, selectFiles)
+		close(opening.done)
+	}()
+	select {
+	case opening.Process = <-started:
+	case <-opening.done:
+		select {
+		case opening.Process = <-started:
+		default:
+			if opening.err != nil {
+				return nil, opening.err
+			}
+		}
+	}
+	return opening, nil
+}
+
+// startedKey is the key of the context value that is called by run with the started process
+type startedKey struct{}
+
+// run runs the command of the file manager and reports the process to OpenMultipleAsync once it has been started
+func run(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if started, ok := ctx.Value(startedKey{}).(func(*os.Process)); ok {
+		started(cmd.Process)
+	}
+	return cmd.Wait()
+}
+
+// parentDirs returns the directories of the items without duplicates, they are opened when the items can't be
+// selected
+func parentDirs(items []string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, item := range items {
+		dir := filepath.Dir(item)
+		if !seen[dir] {
+			seen[dir] = true
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+// MoveToTrash moves the file or directory to the trash: the Recycle Bin on Windows, the Trash of Finder on macOS and
+// the XDG trash on Linux. The trash remembers the original location, so the item can be restored with the file
+// manager.
+func MoveToTrash(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(path); err != nil {
+		return err
+	}
+	return moveToTrash(path)
+}
+
+// OpenTrash opens the trash in the file manager
+func OpenTrash() error {
+	return openTrash()
+}
@@ -0,0 +1,67 @@
+//go:build darwin
+
+package fileexplorer
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/internal/shell"
+)
+
+func moveToTrash(path string) error {
+	// Only items that have been deleted by Finder can be restored with "Put Back"
+	script := fmt.Sprintf(`tell application "Finder" to delete POSIX file "%s"`, appleScriptEscape(path))
+	_, stderr, err := shell.RunCommand("/tmp", "osascript", "-e", script)
+	if err != nil {
+		return fmt.Errorf("moving %s to the Trash failed: %s", path, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+func openTrash() error {
+	_, stderr, err := shell.RunCommand("/tmp", "osascript", "-e", `tell application "Finder"
+	open trash
+	activate
+end tell`)
+	if err != nil {
+		return fmt.Errorf("opening the Trash failed: %s", strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+func open(ctx context.Context, paths []string, selectFile bool) error {
+	if !selectFile {
+		return run(ctx, exec.CommandContext(ctx, "open", paths...))
+	}
+	if len(paths) == 1 {
+		// -R reveals the item in Finder
+		return run(ctx, exec.CommandContext(ctx, "open", "-R", paths[0]))
+	}
+	// Finder selects all the revealed items of a folder in one window
+	items := make([]string, len(paths))
+	for index, path := range paths {
+		items[index] = fmt.Sprintf(`POSIX file "%s"`, appleScriptEscape(path))
+	}
+	script := fmt.Sprintf(`tell application "Finder"
+	reveal {%s}
+	activate
+end tell`, strings.Join(items, ", "))
+	return run(ctx, exec.CommandContext(ctx, "osascript", "-e", script))
+}
+
+func appleScriptEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+func detect() Detection {
+	return Detection{
+		FileManager: "Finder",
+		Binary:      "open",
+		Args:        []string{"-R"},
+		CanSelect:   true,
+		Reason:      "Finder is the file manager of macOS, several items are revealed with AppleScript",
+	}
+}
@@ -0,0 +1,365 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
+)
+
+// moveToTrash implements the FreeDesktop.org Trash specification. Items on the device of the home directory are
+// moved to the home trash, other items to the trash in the top directory of their mount.
+func moveToTrash(path string) error {
+	homeTrash, err := homeTrashDir()
+	if err != nil {
+		return err
+	}
+	err = trashTo(homeTrash, path, path)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	topDir, err := mountPoint(path)
+	if err != nil {
+		return err
+	}
+	trashDir, err := topDirTrashDir(topDir)
+	if err != nil {
+		return err
+	}
+	relativePath, err := filepath.Rel(topDir, path)
+	if err != nil {
+		return err
+	}
+	return trashTo(trashDir, path, relativePath)
+}
+
+func homeTrashDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "Trash"), nil
+}
+
+// topDirTrashDir returns $topdir/.Trash/$uid if the administrator has created a shared trash, or $topdir/.Trash-$uid
+func topDirTrashDir(topDir string) (string, error) {
+	uid := strconv.Itoa(os.Getuid())
+	shared := filepath.Join(topDir, ".Trash")
+	// The shared trash must be a directory with the sticky bit and not a symbolic link
+	if info, err := os.Lstat(shared); err == nil && info.IsDir() && info.Mode()&os.ModeSticky != 0 {
+		trashDir := filepath.Join(shared, uid)
+		if err := os.MkdirAll(trashDir, 0o700); err == nil {
+			return trashDir, nil
+		}
+	}
+	return filepath.Join(topDir, ".Trash-"+uid), nil
+}
+
+// trashTo moves the item to the trash directory. The info file is created first to reserve the name in the trash.
+func trashTo(trashDir string, path string, infoPath string) error {
+	filesDir := filepath.Join(trashDir, "files")
+	infoDir := filepath.Join(trashDir, "info")
+	for _, dir := range []string{filesDir, infoDir} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+
+	info := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n", escapeTrashPath(infoPath), time.Now().Format("2006-01-02T15:04:05"))
+	base := filepath.Base(path)
+	extension := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, extension)
+	for index := 1; ; index++ {
+		name := base
+		if index > 1 {
+			name = stem + "." + strconv.Itoa(index) + extension
+		}
+		infoFile := filepath.Join(infoDir, name+".trashinfo")
+		file, err := os.OpenFile(infoFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		_, err = file.WriteString(info)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(path, filepath.Join(filesDir, name))
+		}
+		if err != nil {
+			_ = os.Remove(infoFile)
+		}
+		return err
+	}
+}
+
+// escapeTrashPath escapes the path like the path of a URL
+func escapeTrashPath(path string) string {
+	segments := strings.Split(path, "/")
+	for index, segment := range segments {
+		segments[index] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// mountPoint returns the top directory of the mount that contains the path
+func mountPoint(path string) (string, error) {
+	device, err := deviceOf(path)
+	if err != nil {
+		return "", err
+	}
+	dir := path
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir, nil
+		}
+		parentDevice, err := deviceOf(parent)
+		if err != nil {
+			return "", err
+		}
+		if parentDevice != device {
+			return dir, nil
+		}
+		dir = parent
+	}
+}
+
+func deviceOf(path string) (uint64, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Lstat(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Dev), nil
+}
+
+func openTrash() error {
+	for _, command := range [][]string{{"gio", "open", "trash:///"}, {"xdg-open", "trash:///"}} {
+		if _, err := exec.LookPath(command[0]); err != nil {
+			continue
+		}
+		return exec.Command(command[0], command[1:]...).Run()
+	}
+	return errors.New("opening the trash requires gio or xdg-open")
+}
+
+// fileManager is the file manager of a desktop environment
+type fileManager struct {
+	binary string
+	// selectArgs are the arguments before the item to select it, nil if the file manager can't select items
+	selectArgs []string
+}
+
+// fileManagers are the file managers of the desktop environments of XDG_CURRENT_DESKTOP
+var fileManagers = map[string]fileManager{
+	"GNOME":      {"nautilus", []string{"--select"}},
+	"Unity":      {"nautilus", []string{"--select"}},
+	"Budgie":     {"nautilus", []string{"--select"}},
+	"KDE":        {"dolphin", []string{"--select"}},
+	"MATE":       {"caja", []string{"--select"}},
+	"X-Cinnamon": {"nemo", []string{}},
+	"XFCE":       {"thunar", nil},
+	"LXQt":       {"pcmanfm-qt", nil},
+	"LXDE":       {"pcmanfm", nil},
+}
+
+// desktopFileManager guesses the file manager from XDG_CURRENT_DESKTOP, it returns the desktop that has been matched
+func desktopFileManager() (fileManager, string, bool) {
+	for _, desktop := range strings.Split(os.Getenv("XDG_CURRENT_DESKTOP"), ":") {
+		manager, known := fileManagers[desktop]
+		if known && commandExists(manager.binary) {
+			return manager, desktop, true
+		}
+	}
+	return fileManager{}, "", false
+}
+
+// errFileManager1Unavailable is returned by showWithFileManager1 when there is no session bus or no file manager
+// that implements org.freedesktop.FileManager1
+var errFileManager1Unavailable = errors.New("org.freedesktop.FileManager1 is not available")
+
+// showWithFileManager1 asks the file manager of the session to show the folders or to select the items with the
+// org.freedesktop.FileManager1 D-Bus interface, which is implemented by Nautilus, Dolphin, Nemo, Caja, Thunar and the
+// file managers of the other desktops, also on Wayland
+func showWithFileManager1(ctx context.Context, paths []string, selectFile bool) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return errFileManager1Unavailable
+	}
+	method := "org.freedesktop.FileManager1.ShowFolders"
+	if selectFile {
+		method = "org.freedesktop.FileManager1.ShowItems"
+	}
+	uris := make([]string, len(paths))
+	for index, path := range paths {
+		uris[index] = (&url.URL{Scheme: "file", Path: path}).String()
+	}
+	err = conn.Object("org.freedesktop.FileManager1", "/org/freedesktop/FileManager1").
+		CallWithContext(ctx, method, 0, uris, "").Err
+	var dbusErr dbus.Error
+	if errors.As(err, &dbusErr) {
+		switch dbusErr.Name {
+		case "org.freedesktop.DBus.Error.ServiceUnknown", "org.freedesktop.DBus.Error.NameHasNoOwner":
+			return errFileManager1Unavailable
+		}
+	}
+	return err
+}
+
+// fileManager1Available reports if a file manager that implements org.freedesktop.FileManager1 is running or can be
+// started by the session bus
+func fileManager1Available() bool {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return false
+	}
+	for _, method := range []string{"org.freedesktop.DBus.ListNames", "org.freedesktop.DBus.ListActivatableNames"} {
+		var names []string
+		if err := conn.BusObject().Call(method, 0).Store(&names); err != nil {
+			continue
+		}
+		for _, name := range names {
+			if name == "org.freedesktop.FileManager1" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sandboxed reports if the application runs in a Flatpak or Snap sandbox, which doesn't have the binaries of the file
+// managers
+func sandboxed() bool {
+	if _, err := os.Stat("/.flatpak-info"); err == nil {
+		return true
+	}
+	return os.Getenv("SNAP") != ""
+}
+
+// openWithPortal opens the items with the OpenURI portal. OpenDirectory selects one item per window, so the first
+// item of every directory is selected. Directories that aren't selected are opened with OpenFile, as OpenDirectory
+// would open their parent.
+func openWithPortal(ctx context.Context, paths []string, selectFile bool) error {
+	if selectFile {
+		seen := map[string]bool{}
+		for _, path := range paths {
+			dir := filepath.Dir(path)
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := portal.OpenDirectory("", path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		opened, err := portal.OpenFileWith("", path, false)
+		if err != nil {
+			return err
+		}
+		if !opened {
+			return errors.New("opening the directory has been cancelled")
+		}
+	}
+	return nil
+}
+
+func open(ctx context.Context, paths []string, selectFile bool) error {
+	err := showWithFileManager1(ctx, paths, selectFile)
+	if !errors.Is(err, errFileManager1Unavailable) {
+		return err
+	}
+	// The sandbox only allows to talk to FileManager1 if the application has been given the permission
+	if sandboxed() {
+		err := openWithPortal(ctx, paths, selectFile)
+		if !errors.Is(err, portal.ErrUnavailable) {
+			return err
+		}
+	}
+
+	manager, _, found := desktopFileManager()
+	if selectFile && found && manager.selectArgs != nil {
+		args := append(append([]string{}, manager.selectArgs...), paths...)
+		return run(ctx, exec.CommandContext(ctx, manager.binary, args...))
+	}
+	// The directories are opened if the items can't be selected
+	if selectFile {
+		paths = parentDirs(paths)
+	}
+	binary := "xdg-open"
+	if found {
+		binary = manager.binary
+	}
+	for _, path := range paths {
+		if err := run(ctx, exec.CommandContext(ctx, binary, path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detect follows the order of open
+func detect() Detection {
+	if fileManager1Available() {
+		return Detection{
+			FileManager: "org.freedesktop.FileManager1",
+			CanSelect:   true,
+			Reason:      "a file manager of the session implements org.freedesktop.FileManager1",
+		}
+	}
+	if sandboxed() {
+		return Detection{
+			FileManager: "org.freedesktop.portal.OpenURI",
+			CanSelect:   true,
+			Reason:      "the application runs in a Flatpak or Snap sandbox",
+		}
+	}
+	manager, desktop, found := desktopFileManager()
+	if !found {
+		return Detection{
+			FileManager: "xdg-open",
+			Binary:      "xdg-open",
+			Args:        []string{},
+			Reason:      fmt.Sprintf("no file manager of a known desktop is installed for XDG_CURRENT_DESKTOP=%q", os.Getenv("XDG_CURRENT_DESKTOP")),
+		}
+	}
+	args := manager.selectArgs
+	if args == nil {
+		args = []string{}
+	}
+	return Detection{
+		FileManager: manager.binary,
+		Binary:      manager.binary,
+		Args:        args,
+		CanSelect:   manager.selectArgs != nil,
+		Reason:      fmt.Sprintf("%s is the file manager of the %s desktop", manager.binary, desktop),
+	}
+}
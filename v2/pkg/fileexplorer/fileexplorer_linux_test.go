@@ -0,0 +1,236 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMoveToTrash(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	trash := filepath.Join(dir, "data", "Trash")
+
+	for i := 0; i < 2; i++ {
+		file := filepath.Join(dir, "my report.txt")
+		if err := os.WriteFile(file, []byte("report"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := MoveToTrash(file); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(file); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be moved, got %v", file, err)
+		}
+	}
+
+	// The second file must not replace the first one
+	for _, name := range []string{"my report.txt", "my report.2.txt"} {
+		content, err := os.ReadFile(filepath.Join(trash, "files", name))
+		if err != nil || string(content) != "report" {
+			t.Errorf("expected %s in the trash, got %q, %v", name, content, err)
+		}
+		info, err := os.ReadFile(filepath.Join(trash, "info", name+".trashinfo"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(string(info), "\n")
+		wantPath := "Path=" + strings.ReplaceAll(filepath.Join(dir, "my report.txt"), " ", "%20")
+		if lines[0] != "[Trash Info]" || lines[1] != wantPath || !strings.HasPrefix(lines[2], "DeletionDate=") || len(lines[2]) != len("DeletionDate=2006-01-02T15:04:05") {
+			t.Errorf("unexpected trash info %q", info)
+		}
+	}
+
+	if err := MoveToTrash(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+}
+
+func TestEscapeTrashPath(t *testing.T) {
+	if got := escapeTrashPath("/home/user/a b/100%/ü.txt"); got != "/home/user/a%20b/100%25/%C3%BC.txt" {
+		t.Errorf("unexpected path %s", got)
+	}
+}
+
+// fakeFileManager puts a nautilus on the PATH that runs the script. There is no session bus, so nautilus is run
+// instead of calling org.freedesktop.FileManager1.
+func fakeFileManager(t *testing.T, script string) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "nautilus"), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("XDG_CURRENT_DESKTOP", "ubuntu:GNOME")
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path="+filepath.Join(bin, "bus"))
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "args")
+	fakeFileManager(t, `echo "$@" > "`+output+`"`)
+	file := filepath.Join(dir, "report.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		selectFile bool
+		want       string
+	}{
+		{selectFile: true, want: "--select " + file},
+		{selectFile: false, want: dir},
+	}
+	for _, tt := range tests {
+		if err := Open(file, tt.selectFile); err != nil {
+			t.Fatal(err)
+		}
+		args, _ := os.ReadFile(output)
+		if got := strings.TrimSpace(string(args)); got != tt.want {
+			t.Errorf("Open(%v) ran nautilus %s, want %s", tt.selectFile, got, tt.want)
+		}
+	}
+}
+
+func TestOpenSandboxed(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "args")
+	fakeFileManager(t, `echo "$@" > "`+output+`"`)
+	// Without a session bus the portal is unavailable, so the file manager is run
+	t.Setenv("SNAP", "/snap/app/1")
+	if !sandboxed() {
+		t.Fatal("sandboxed() = false in a Snap")
+	}
+	if err := Open(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	if args, _ := os.ReadFile(output); strings.TrimSpace(string(args)) != dir {
+		t.Errorf("Open() ran nautilus %q, want %q", args, dir)
+	}
+}
+
+func TestOpenMultiple(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "args")
+	fakeFileManager(t, `echo "$@" >> "`+output+`"`)
+	other := filepath.Join(dir, "other")
+	if err := os.Mkdir(other, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"), filepath.Join(other, "c.txt")}
+	for _, file := range files {
+		if err := os.WriteFile(file, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		selectFiles bool
+		want        string
+	}{
+		{selectFiles: true, want: "--select " + strings.Join(files, " ")},
+		{selectFiles: false, want: dir + "\n" + other},
+	}
+	for _, tt := range tests {
+		_ = os.Remove(output)
+		if err := OpenMultiple(files, tt.selectFiles); err != nil {
+			t.Fatal(err)
+		}
+		args, _ := os.ReadFile(output)
+		if got := strings.TrimSpace(string(args)); got != tt.want {
+			t.Errorf("OpenMultiple(%v) ran nautilus %q, want %q", tt.selectFiles, got, tt.want)
+		}
+	}
+
+	if err := OpenMultiple(nil, true); err == nil {
+		t.Error("OpenMultiple() didn't fail without paths")
+	}
+}
+
+func TestOpenContext(t *testing.T) {
+	fakeFileManager(t, "exec sleep 10")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := OpenContext(ctx, t.TempDir(), false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("OpenContext() = %v, want the deadline to be exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("OpenContext() returned after %v", elapsed)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "args")
+	fakeFileManager(t, "exit 1")
+	binary := filepath.Join(dir, "kiosk-files")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\necho \"$@\" > \""+output+"\""), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	SetOptions(Options{Binary: binary, Args: []string{"--reveal"}})
+	t.Cleanup(func() { SetOptions(Options{}) })
+
+	file := filepath.Join(dir, "report.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Open(file, true); err != nil {
+		t.Fatal(err)
+	}
+	if args, _ := os.ReadFile(output); strings.TrimSpace(string(args)) != "--reveal "+file {
+		t.Errorf("Open() ran %q, want %q", args, "--reveal "+file)
+	}
+	if detection := Detect(); detection.Binary != binary || detection.FileManager != "kiosk-files" {
+		t.Errorf("Detect() = %+v, want the binary of the options", detection)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	fakeFileManager(t, "")
+	detection := Detect()
+	if detection.FileManager != "nautilus" || !detection.CanSelect || detection.Reason != "nautilus is the file manager of the GNOME desktop" {
+		t.Errorf("Detect() = %+v, want nautilus of GNOME", detection)
+	}
+
+	t.Setenv("XDG_CURRENT_DESKTOP", "Unknown")
+	detection = Detect()
+	if detection.Binary != "xdg-open" || detection.CanSelect {
+		t.Errorf("Detect() = %+v, want xdg-open", detection)
+	}
+}
+
+func TestOpenAsync(t *testing.T) {
+	fakeFileManager(t, "sleep 1; exit 3")
+	start := time.Now()
+	opening, err := OpenAsync(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("OpenAsync() returned after %v", elapsed)
+	}
+	if opening.Process == nil {
+		t.Error("OpenAsync() didn't return the process of nautilus")
+	}
+	select {
+	case <-opening.Done():
+		t.Error("Done() is closed while nautilus is running")
+	default:
+	}
+	var exitErr *exec.ExitError
+	if err := opening.Wait(); !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("Wait() = %v, want the exit code of nautilus", err)
+	}
+
+	if _, err := OpenAsync(filepath.Join(t.TempDir(), "missing"), false); !os.IsNotExist(err) {
+		t.Errorf("OpenAsync() = %v, want a not exist error", err)
+	}
+}
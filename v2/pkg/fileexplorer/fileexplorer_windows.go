@@ -0,0 +1,159 @@
+//go:build windows
+
+package fileexplorer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	foDelete          = 0x0003
+	fofSilent         = 0x0004
+	fofNoConfirmation = 0x0010
+	fofAllowUndo      = 0x0040
+	fofNoErrorUI      = 0x0400
+)
+
+var (
+	procSHFileOperation            = windows.NewLazySystemDLL("shell32.dll").NewProc("SHFileOperationW")
+	procSHParseDisplayName         = windows.NewLazySystemDLL("shell32.dll").NewProc("SHParseDisplayName")
+	procSHOpenFolderAndSelectItems = windows.NewLazySystemDLL("shell32.dll").NewProc("SHOpenFolderAndSelectItems")
+)
+
+func moveToTrash(path string) error {
+	// pFrom is a list of paths that is terminated by an empty path
+	from, err := syscall.UTF16FromString(path)
+	if err != nil {
+		return err
+	}
+	from = append(from, 0)
+
+	// FOF_ALLOWUNDO moves the item to the Recycle Bin instead of deleting it
+	operation := shFileOpStruct{
+		wFunc:  foDelete,
+		pFrom:  &from[0],
+		fFlags: fofAllowUndo | fofNoConfirmation | fofNoErrorUI | fofSilent,
+	}
+	result, _, _ := procSHFileOperation.Call(uintptr(unsafe.Pointer(&operation)))
+	if result != 0 {
+		return fmt.Errorf("moving %s to the Recycle Bin failed with code 0x%x", path, result)
+	}
+	if operation.aborted() {
+		return fmt.Errorf("moving %s to the Recycle Bin has been aborted", path)
+	}
+	return nil
+}
+
+func openTrash() error {
+	// explorer.exe exits with 1 even if the folder has been opened, so the exit code is ignored
+	cmd := exec.Command("explorer.exe", "shell:RecycleBinFolder")
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+func open(ctx context.Context, paths []string, selectFile bool) error {
+	if selectFile {
+		return selectItems(paths)
+	}
+	for _, path := range paths {
+		// explorer.exe parses its command line itself and doesn't accept the quoting of exec
+		if err := runExplorer(ctx, `explorer.exe "`+path+`"`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runExplorer(ctx context.Context, commandLine string) error {
+	cmd := exec.CommandContext(ctx, "explorer.exe")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: commandLine}
+	err := run(ctx, cmd)
+	// explorer.exe exits with 1 even if the folder has been opened
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return nil
+	}
+	return err
+}
+
+// selectItems selects the items with SHOpenFolderAndSelectItems, which, unlike explorer.exe /select, selects several
+// items of a folder together and reuses the window of the folder if it is open. A window is opened per folder.
+func selectItems(paths []string) error {
+	var folders []string
+	items := map[string][]string{}
+	for _, path := range paths {
+		folder := filepath.Dir(path)
+		if _, exists := items[folder]; !exists {
+			folders = append(folders, folder)
+		}
+		items[folder] = append(items[folder], path)
+	}
+	return runOnCOMThread(func() error {
+		for _, folder := range folders {
+			if err := openFolderAndSelectItems(folder, items[folder]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// parseDisplayName returns the item ID list of the path, it must be freed with CoTaskMemFree
+func parseDisplayName(path string) (unsafe.Pointer, error) {
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	var idList unsafe.Pointer
+	result, _, _ := procSHParseDisplayName.Call(uintptr(unsafe.Pointer(name)), 0, uintptr(unsafe.Pointer(&idList)), 0, 0)
+	if result != 0 {
+		return nil, fmt.Errorf("parsing %s failed with code 0x%x", path, result)
+	}
+	return idList, nil
+}
+
+func openFolderAndSelectItems(folder string, paths []string) error {
+	folderIDList, err := parseDisplayName(folder)
+	if err != nil {
+		return err
+	}
+	defer windows.CoTaskMemFree(folderIDList)
+	idLists := make([]unsafe.Pointer, 0, len(paths))
+	defer func() {
+		for _, idList := range idLists {
+			windows.CoTaskMemFree(idList)
+		}
+	}()
+	for _, path := range paths {
+		idList, err := parseDisplayName(path)
+		if err != nil {
+			return err
+		}
+		idLists = append(idLists, idList)
+	}
+	result, _, _ := procSHOpenFolderAndSelectItems.Call(uintptr(folderIDList), uintptr(len(idLists)), uintptr(unsafe.Pointer(&idLists[0])), 0)
+	if result != 0 {
+		return fmt.Errorf("selecting the items of %s failed with code 0x%x", folder, result)
+	}
+	return nil
+}
+
+func detect() Detection {
+	return Detection{
+		FileManager: "Explorer",
+		Binary:      "explorer.exe",
+		Args:        []string{},
+		CanSelect:   true,
+		Reason:      "Explorer is the file manager of Windows, items are selected with SHOpenFolderAndSelectItems",
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows && !386
+
+package fileexplorer
+
+// shFileOpStruct is SHFILEOPSTRUCTW
+type shFileOpStruct struct {
+	hwnd                  uintptr
+	wFunc                 uint32
+	pFrom                 *uint16
+	pTo                   *uint16
+	fFlags                uint16
+	fAnyOperationsAborted int32
+	hNameMappings         uintptr
+	lpszProgressTitle     *uint16
+}
+
+func (s *shFileOpStruct) aborted() bool {
+	return s.fAnyOperationsAborted != 0
+}
@@ -0,0 +1,19 @@
+//go:build windows && 386
+
+package fileexplorer
+
+// shFileOpStruct is SHFILEOPSTRUCTW, which is packed on 32-bit Windows. The fields after fFlags are unaligned.
+type shFileOpStruct struct {
+	hwnd                  uintptr
+	wFunc                 uint32
+	pFrom                 *uint16
+	pTo                   *uint16
+	fFlags                uint16
+	fAnyOperationsAborted [4]byte
+	hNameMappings         [4]byte
+	lpszProgressTitle     [4]byte
+}
+
+func (s *shFileOpStruct) aborted() bool {
+	return s.fAnyOperationsAborted != [4]byte{}
+}
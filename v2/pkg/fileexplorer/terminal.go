@@ -0,0 +1,36 @@
+package fileexplorer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// OpenTerminalAt opens the terminal of the platform with the directory as working directory, with the directory of
+// the file if the path is a file: Windows Terminal or the command prompt on Windows, iTerm or Terminal on macOS and the
+// terminal of the desktop environment or x-terminal-emulator on Linux. It doesn't wait for the terminal to be closed.
+func OpenTerminalAt(path string) error {
+	path, err := existingPath(path)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		path = filepath.Dir(path)
+	}
+	if err := openTerminalAt(path); err != nil {
+		return fmt.Errorf("opening a terminal at %s failed: %w", path, err)
+	}
+	return nil
+}
+
+// startTerminal starts the terminal in the directory without waiting for it to be closed
+func startTerminal(dir string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,31 @@
+//go:build darwin
+
+package fileexplorer
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// iTermInstalled reports if iTerm is installed, users who install it usually prefer it to Terminal
+func iTermInstalled() bool {
+	dirs := []string{"/Applications"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Applications"))
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, "iTerm.app")); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func openTerminalAt(dir string) error {
+	application := "Terminal"
+	if iTermInstalled() {
+		application = "iTerm"
+	}
+	// The terminals open a window in the directory that is opened with them
+	return startTerminal(dir, "open", "-a", application, dir)
+}
@@ -0,0 +1,61 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// terminal is the terminal of a desktop environment
+type terminal struct {
+	binary string
+	// args returns the arguments that open a window in the directory
+	args func(dir string) []string
+}
+
+func workingDirectoryArg(dir string) []string {
+	return []string{"--working-directory=" + dir}
+}
+
+// terminals are the terminals of the desktop environments of XDG_CURRENT_DESKTOP, the newer terminal of a desktop first
+var terminals = map[string][]terminal{
+	"GNOME":      {{"ptyxis", workingDirectoryArg}, {"kgx", workingDirectoryArg}, {"gnome-terminal", workingDirectoryArg}},
+	"Unity":      {{"gnome-terminal", workingDirectoryArg}},
+	"Budgie":     {{"gnome-terminal", workingDirectoryArg}, {"tilix", workingDirectoryArg}},
+	"KDE":        {{"konsole", func(dir string) []string { return []string{"--workdir", dir} }}},
+	"MATE":       {{"mate-terminal", workingDirectoryArg}},
+	"X-Cinnamon": {{"gnome-terminal", workingDirectoryArg}},
+	"XFCE":       {{"xfce4-terminal", workingDirectoryArg}},
+	"LXQt":       {{"qterminal", func(dir string) []string { return []string{"-w", dir} }}},
+	"LXDE":       {{"lxterminal", workingDirectoryArg}},
+}
+
+// desktopTerminal guesses the terminal from XDG_CURRENT_DESKTOP like desktopFileManager
+func desktopTerminal() (terminal, bool) {
+	for _, desktop := range strings.Split(os.Getenv("XDG_CURRENT_DESKTOP"), ":") {
+		for _, candidate := range terminals[desktop] {
+			if commandExists(candidate.binary) {
+				return candidate, true
+			}
+		}
+	}
+	return terminal{}, false
+}
+
+func openTerminalAt(dir string) error {
+	if sandboxed() {
+		return fmt.Errorf("%w: the terminals of the host can't be started from the sandbox", ErrNotSupported)
+	}
+	if candidate, found := desktopTerminal(); found {
+		return startTerminal(dir, candidate.binary, candidate.args(dir)...)
+	}
+	// The terminals of the fallbacks are started in the directory, as their arguments differ
+	for _, binary := range []string{"x-terminal-emulator", "xterm"} {
+		if commandExists(binary) {
+			return startTerminal(dir, binary)
+		}
+	}
+	return fmt.Errorf("%w: no terminal is installed", ErrNotSupported)
+}
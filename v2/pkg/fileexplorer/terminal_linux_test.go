@@ -0,0 +1,50 @@
+//go:build linux
+
+package fileexplorer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenTerminalAt(t *testing.T) {
+	bin := t.TempDir()
+	output := filepath.Join(t.TempDir(), "terminal")
+	script := "#!/bin/sh\necho \"$(pwd) $@\" > \"" + output + "\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "konsole"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	t.Setenv("XDG_CURRENT_DESKTOP", "KDE")
+	t.Setenv("SNAP", "")
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := OpenTerminalAt(file); err != nil {
+		t.Fatal(err)
+	}
+	want := dir + " --workdir " + dir
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, _ := os.ReadFile(output)
+		if strings.TrimSpace(string(got)) == want {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("OpenTerminalAt() ran konsole with %q, want %q", got, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := OpenTerminalAt(dir); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("OpenTerminalAt() = %v without a terminal, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package fileexplorer
+
+import (
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+func openTerminalAt(dir string) error {
+	// wt.exe is the execution alias of Windows Terminal, which is on the PATH if it is installed
+	if _, err := exec.LookPath("wt.exe"); err == nil {
+		return startTerminal(dir, "wt.exe", "-d", dir)
+	}
+	cmd := exec.Command("cmd.exe")
+	cmd.Dir = dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.CREATE_NEW_CONSOLE}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package idle reports how long the user hasn't used the computer and notices when the user becomes idle and active
+// again, EG: to lock the application or to show the presence of the user. The idle time is the time since the last
+// keyboard or mouse input of the session: GetLastInputInfo on Windows, CGEventSourceSecondsSinceLastEventType on macOS
+// and the idle monitor of GNOME, the screensaver of KDE or the IdleHint of logind on Linux. Bind the Idle service to
+// use it from JS.
+package idle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// The events that are emitted by EmitEvents
+const (
+	// IdleEvent is emitted with the idle seconds when the user becomes idle
+	IdleEvent = "idle:idle"
+	// ActiveEvent is emitted with the seconds the user has been idle when there is input again
+	ActiveEvent = "idle:active"
+)
+
+// ErrNotSupported is returned when the session doesn't report the idle time, EG: on Linux without a desktop that has
+// an idle monitor or logind
+var ErrNotSupported = errors.New("idle: the idle time is not available")
+
+const (
+	defaultThreshold = 5 * time.Minute
+	defaultInterval  = time.Second
+)
+
+type backend interface {
+	idleTime() (time.Duration, error)
+}
+
+// Idle is the idle service
+type Idle struct {
+	// Threshold is the idle time after which the user is idle. Default: 5 minutes
+	Threshold time.Duration
+	// Interval is how often Watch checks the idle time. Default: 1 second
+	Interval time.Duration
+
+	// OnIdle is called with the idle time when the user becomes idle
+	OnIdle func(idle time.Duration)
+	// OnActive is called with the time the user has been idle when there is input again
+	OnActive func(idle time.Duration)
+
+	backend backend
+	lock    sync.Mutex
+	stop    chan struct{}
+}
+
+// New creates the idle service
+func New() *Idle {
+	return &Idle{backend: newBackend()}
+}
+
+// EmitEvents emits IdleEvent and ActiveEvent to the frontend
+func EmitEvents(ctx context.Context, i *Idle) {
+	i.OnIdle = func(idle time.Duration) {
+		runtime.EventsEmit(ctx, IdleEvent, idle.Seconds())
+	}
+	i.OnActive = func(idle time.Duration) {
+		runtime.EventsEmit(ctx, ActiveEvent, idle.Seconds())
+	}
+}
+
+func (i *Idle) threshold() time.Duration {
+	if i.Threshold <= 0 {
+		return defaultThreshold
+	}
+	return i.Threshold
+}
+
+// IdleTime returns the time since the last input of the user
+func (i *Idle) IdleTime() (time.Duration, error) {
+	return i.backend.idleTime()
+}
+
+// IdleSeconds returns the seconds since the last input of the user
+func (i *Idle) IdleSeconds() (float64, error) {
+	idle, err := i.IdleTime()
+	return idle.Seconds(), err
+}
+
+// IsIdle returns true if the idle time has reached the threshold
+func (i *Idle) IsIdle() (bool, error) {
+	idle, err := i.IdleTime()
+	return idle >= i.threshold(), err
+}
+
+// Watch calls OnIdle and OnActive until Stop is called. It fails if the idle time is not available.
+func (i *Idle) Watch() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.stop != nil {
+		return nil
+	}
+	if _, err := i.IdleTime(); err != nil {
+		return err
+	}
+	interval := i.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	i.stop = make(chan struct{})
+	go i.watch(i.stop, interval, i.threshold())
+	return nil
+}
+
+// Stop stops watching
+func (i *Idle) Stop() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.stop != nil {
+		close(i.stop)
+		i.stop = nil
+	}
+}
+
+func (i *Idle) watch(stop chan struct{}, interval time.Duration, threshold time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	idle := false
+	var last time.Duration
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		idleTime, err := i.IdleTime()
+		if err != nil {
+			continue
+		}
+		switch {
+		case !idle && idleTime >= threshold:
+			idle = true
+			if i.OnIdle != nil {
+				i.OnIdle(idleTime)
+			}
+		// The idle time only goes down when there has been input
+		case idle && idleTime < last:
+			idle = false
+			if i.OnActive != nil {
+				i.OnActive(last)
+			}
+		}
+		last = idleTime
+	}
+}
@@ -0,0 +1,26 @@
+//go:build darwin
+
+package idle
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics
+
+#include <CoreGraphics/CoreGraphics.h>
+
+static double secondsSinceLastInput() {
+	return CGEventSourceSecondsSinceLastEventType(kCGEventSourceStateCombinedSessionState, kCGAnyInputEventType);
+}
+*/
+import "C"
+
+import "time"
+
+type eventSourceBackend struct{}
+
+func newBackend() backend {
+	return eventSourceBackend{}
+}
+
+func (eventSourceBackend) idleTime() (time.Duration, error) {
+	return time.Duration(float64(C.secondsSinceLastInput()) * float64(time.Second)), nil
+}
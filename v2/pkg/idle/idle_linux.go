@@ -0,0 +1,98 @@
+//go:build linux
+
+package idle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"golang.org/x/sys/unix"
+)
+
+// source reads the idle time from one of the services of the session
+type source func() (time.Duration, error)
+
+// linuxBackend uses the first source that reports the idle time
+type linuxBackend struct {
+	lock    sync.Mutex
+	sources []source
+	current source
+}
+
+func newBackend() backend {
+	return &linuxBackend{sources: []source{mutterIdleTime, screenSaverIdleTime, logindIdleTime}}
+}
+
+func (b *linuxBackend) idleTime() (time.Duration, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.current != nil {
+		if idle, err := b.current(); err == nil {
+			return idle, nil
+		}
+		b.current = nil
+	}
+	for _, source := range b.sources {
+		if idle, err := source(); err == nil {
+			b.current = source
+			return idle, nil
+		}
+	}
+	return 0, ErrNotSupported
+}
+
+// mutterIdleTime asks the idle monitor of GNOME
+func mutterIdleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, err
+	}
+	var idle uint64
+	err = conn.Object("org.gnome.Mutter.IdleMonitor", "/org/gnome/Mutter/IdleMonitor/Core").
+		Call("org.gnome.Mutter.IdleMonitor.GetIdletime", 0).Store(&idle)
+	return time.Duration(idle) * time.Millisecond, err
+}
+
+// screenSaverIdleTime asks the screensaver of KDE and the other desktops that implement org.freedesktop.ScreenSaver
+func screenSaverIdleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, err
+	}
+	var idle uint32
+	err = conn.Object("org.freedesktop.ScreenSaver", "/org/freedesktop/ScreenSaver").
+		Call("org.freedesktop.ScreenSaver.GetSessionIdleTime", 0).Store(&idle)
+	return time.Duration(idle) * time.Second, err
+}
+
+// logindIdleTime uses the IdleHint of the logind session. It is only set by the desktops that tell logind, and then
+// only after their own idle timeout, so the idle time is 0 until the session is idle.
+func logindIdleTime() (time.Duration, error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return 0, err
+	}
+	session := conn.Object("org.freedesktop.login1", "/org/freedesktop/login1/session/auto")
+	hint, err := session.GetProperty("org.freedesktop.login1.Session.IdleHint")
+	if err != nil {
+		return 0, err
+	}
+	if idle, _ := hint.Value().(bool); !idle {
+		return 0, nil
+	}
+	since, err := session.GetProperty("org.freedesktop.login1.Session.IdleSinceHintMonotonic")
+	if err != nil {
+		return 0, err
+	}
+	usec, _ := since.Value().(uint64)
+	var now unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &now); err != nil {
+		return 0, err
+	}
+	idle := time.Duration(now.Nano()) - time.Duration(usec)*time.Microsecond
+	if idle < 0 {
+		return 0, nil
+	}
+	return idle, nil
+}
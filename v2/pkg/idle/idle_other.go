@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !windows
+
+package idle
+
+import "time"
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) idleTime() (time.Duration, error) {
+	return 0, ErrNotSupported
+}
@@ -0,0 +1,82 @@
+package idle
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBackend returns the idle times one after another and then the last one
+type fakeBackend struct {
+	lock  sync.Mutex
+	times []time.Duration
+	err   error
+}
+
+func (b *fakeBackend) idleTime() (time.Duration, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.err != nil {
+		return 0, b.err
+	}
+	idle := b.times[0]
+	if len(b.times) > 1 {
+		b.times = b.times[1:]
+	}
+	return idle, nil
+}
+
+func TestWatch(t *testing.T) {
+	backend := &fakeBackend{times: []time.Duration{
+		0, // checked by Watch
+		time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 0,
+		time.Second, 3 * time.Second, 3 * time.Second, 3 * time.Second,
+	}}
+	i := &Idle{Threshold: 3 * time.Second, Interval: time.Millisecond, backend: backend}
+
+	var lock sync.Mutex
+	var events []string
+	done := make(chan struct{})
+	record := func(event string) func(time.Duration) {
+		return func(idle time.Duration) {
+			lock.Lock()
+			defer lock.Unlock()
+			events = append(events, event+" "+idle.String())
+			if len(events) == 3 {
+				close(done)
+			}
+		}
+	}
+	i.OnIdle = record("idle")
+	i.OnActive = record("active")
+
+	if err := i.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	defer i.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	want := []string{"idle 3s", "active 4s", "idle 3s"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestIsIdle(t *testing.T) {
+	i := &Idle{backend: &fakeBackend{times: []time.Duration{4 * time.Minute, 5 * time.Minute}}}
+	for _, want := range []bool{false, true} {
+		if idle, err := i.IsIdle(); err != nil || idle != want {
+			t.Errorf("IsIdle() = %v, %v, want %v", idle, err, want)
+		}
+	}
+
+	i = &Idle{backend: &fakeBackend{err: ErrNotSupported}}
+	if err := i.Watch(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Watch() = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package idle
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procGetLastInputInfo = windows.NewLazySystemDLL("user32.dll").NewProc("GetLastInputInfo")
+	procGetTickCount     = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetTickCount")
+)
+
+// lastInputInfo is LASTINPUTINFO
+type lastInputInfo struct {
+	cbSize uint32
+	dwTime uint32
+}
+
+type lastInputBackend struct{}
+
+func newBackend() backend {
+	return lastInputBackend{}
+}
+
+func (lastInputBackend) idleTime() (time.Duration, error) {
+	info := lastInputInfo{cbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	result, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info)))
+	if result == 0 {
+		return 0, fmt.Errorf("GetLastInputInfo failed: %w", err)
+	}
+	// The time of the last input is a tick count, which wraps after 49.7 days, the difference of the 32 bit values
+	// doesn't
+	now, _, _ := procGetTickCount.Call()
+	return time.Duration(uint32(now)-info.dwTime) * time.Millisecond, nil
+}
@@ -0,0 +1,223 @@
+// Package license provides a stable machine identity and the offline validation of license files, which are signed
+// with ed25519 by the vendor of the application. A license can be bound to machines and expire with a grace period.
+// Bind the Service to activate and check the license from JS.
+package license
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+var (
+	// ErrInvalid is returned when the license file is malformed or its signature doesn't match the public key
+	ErrInvalid = errors.New("license: invalid license")
+	// ErrOtherProduct is returned when the license has been issued for another product
+	ErrOtherProduct = errors.New("license: the license has been issued for another product")
+	// ErrOtherMachine is returned when the license isn't activated on this machine
+	ErrOtherMachine = errors.New("license: the license is not activated on this machine")
+)
+
+// License is the content of a license file
+type License struct {
+	ID       string `json:"id"`
+	Licensee string `json:"licensee"`
+	// Product is compared with the Product of the Service, EG: to issue licenses for several applications with a key
+	Product  string   `json:"product,omitempty"`
+	Features []string `json:"features,omitempty"`
+	// Machines are the machine IDs the license is activated on. The license is valid on any machine if it is empty.
+	Machines []string  `json:"machines,omitempty"`
+	IssuedAt time.Time `json:"issuedAt"`
+	// ExpiresAt is zero for perpetual licenses
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// HasFeature returns true if the feature has been licensed
+func (l *License) HasFeature(feature string) bool {
+	return lo.Contains(l.Features, feature)
+}
+
+// Sign creates the license file of the license, EG: on the license server. The file is the base64 encoded JSON of the
+// license and its signature, separated by a period, so it can be pasted by the user.
+func Sign(license *License, key ed25519.PrivateKey) (string, error) {
+	payload, err := json.Marshal(license)
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(key, payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Parse verifies the signature of the license file and returns its license
+func Parse(file string, key ed25519.PublicKey) (*License, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(strings.TrimSpace(file), ".")
+	if !found {
+		return nil, ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, payload, signature) {
+		return nil, ErrInvalid
+	}
+	var license License
+	if err := json.Unmarshal(payload, &license); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalid, err)
+	}
+	return &license, nil
+}
+
+// ParsePublicKey parses a base64 ed25519 public key, EG: a key that is embedded into the application
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("license: invalid public key: %w", err)
+	}
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("license: invalid public key of %d bytes", len(data))
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+// State is the state of the license of the application
+type State string
+
+const (
+	// Missing is the state without an activated license
+	Missing State = "missing"
+	// Valid is the state of a license that hasn't expired
+	Valid State = "valid"
+	// Grace is the state of an expired license in the grace period
+	Grace State = "grace"
+	// Expired is the state of a license that has expired after the grace period
+	Expired State = "expired"
+)
+
+// Status is the status of the license of the application
+type Status struct {
+	State   State    `json:"state"`
+	License *License `json:"license,omitempty"`
+	// GraceEndsAt is the end of the grace period of expired licenses
+	GraceEndsAt *time.Time `json:"graceEndsAt,omitempty"`
+}
+
+// Active returns true if the licensed features can be used, which is the case in the grace period
+func (s *Status) Active() bool {
+	return s.State == Valid || s.State == Grace
+}
+
+// Service activates and checks the license of the application
+type Service struct {
+	// PublicKey verifies the signatures of the license files
+	PublicKey ed25519.PublicKey
+	// AppID makes the machine ID specific to the application, EG: its bundle identifier
+	AppID string
+	// Product is compared with the product of the licenses if it is set
+	Product string
+	// GracePeriod is the time an expired license stays active, EG: until the renewal has been processed
+	GracePeriod time.Duration
+	// Path is the file the activated license is stored in
+	Path string
+
+	now       func() time.Time
+	machineID func() (string, error)
+}
+
+// New creates the license service, which stores the license in the file at path
+func New(publicKey ed25519.PublicKey, appID string, path string) *Service {
+	return &Service{PublicKey: publicKey, AppID: appID, Path: path}
+}
+
+func (s *Service) currentTime() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// MachineID returns the ID of this machine for the licenses, which the user sends to activate the license on it
+func (s *Service) MachineID() (string, error) {
+	if s.machineID != nil {
+		return s.machineID()
+	}
+	return MachineID(s.AppID)
+}
+
+// Activate validates the license file and stores it, so it is checked by Status. The stored license isn't replaced
+// by an invalid license file.
+func (s *Service) Activate(file string) (*Status, error) {
+	status, err := s.validate(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(s.Path, []byte(strings.TrimSpace(file)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Deactivate removes the stored license
+func (s *Service) Deactivate() error {
+	if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Status checks the stored license. The state is Missing if no license has been activated.
+func (s *Service) Status() (*Status, error) {
+	file, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return &Status{State: Missing}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.validate(string(file))
+}
+
+func (s *Service) validate(file string) (*Status, error) {
+	license, err := Parse(file, s.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if s.Product != "" && license.Product != s.Product {
+		return nil, ErrOtherProduct
+	}
+	if len(license.Machines) > 0 {
+		machineID, err := s.MachineID()
+		if err != nil {
+			return nil, err
+		}
+		if !lo.Contains(license.Machines, machineID) {
+			return nil, ErrOtherMachine
+		}
+	}
+
+	status := &Status{State: Valid, License: license}
+	now := s.currentTime()
+	if license.ExpiresAt.IsZero() || now.Before(license.ExpiresAt) {
+		return status, nil
+	}
+	graceEndsAt := license.ExpiresAt.Add(s.GracePeriod)
+	if now.Before(graceEndsAt) {
+		status.State = Grace
+		status.GraceEndsAt = &graceEndsAt
+		return status, nil
+	}
+	status.State = Expired
+	return status, nil
+}
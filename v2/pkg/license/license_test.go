@@ -0,0 +1,134 @@
+package license
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestService(t *testing.T) (*Service, ed25519.PrivateKey) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := New(public, "com.example.app", filepath.Join(t.TempDir(), "license", "license.key"))
+	service.machineID = func() (string, error) {
+		return "machine-1", nil
+	}
+	return service, private
+}
+
+func sign(t *testing.T, license *License, key ed25519.PrivateKey) string {
+	t.Helper()
+	file, err := Sign(license, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestActivate(t *testing.T) {
+	service, key := newTestService(t)
+	service.Product = "app"
+	status, err := service.Status()
+	if err != nil || status.State != Missing {
+		t.Fatalf("unexpected status: %+v %v", status, err)
+	}
+
+	file := sign(t, &License{ID: "1", Licensee: "Jo", Product: "app", Features: []string{"pro"}, Machines: []string{"machine-1"}}, key)
+	status, err = service.Activate(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != Valid || !status.Active() || !status.License.HasFeature("pro") {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// An invalid license file doesn't replace the activated license
+	if _, err := service.Activate(file[:len(file)-4] + "AAAA"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	status, err = service.Status()
+	if err != nil || status.State != Valid || status.License.Licensee != "Jo" {
+		t.Errorf("unexpected status: %+v %v", status, err)
+	}
+
+	if err := service.Deactivate(); err != nil {
+		t.Fatal(err)
+	}
+	status, err = service.Status()
+	if err != nil || status.State != Missing {
+		t.Errorf("unexpected status: %+v %v", status, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	service, key := newTestService(t)
+	service.Product = "app"
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr error
+	}{
+		{"other key", sign(t, &License{Product: "app"}, otherKey), ErrInvalid},
+		{"malformed", "not a license", ErrInvalid},
+		{"other product", sign(t, &License{Product: "other"}, key), ErrOtherProduct},
+		{"other machine", sign(t, &License{Product: "app", Machines: []string{"machine-2"}}, key), ErrOtherMachine},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.validate(tt.file); !errors.Is(err, tt.wantErr) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	service, key := newTestService(t)
+	service.GracePeriod = 7 * 24 * time.Hour
+	expiresAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	file := sign(t, &License{ExpiresAt: expiresAt}, key)
+
+	tests := []struct {
+		now   time.Time
+		state State
+	}{
+		{expiresAt.Add(-time.Hour), Valid},
+		{expiresAt.Add(time.Hour), Grace},
+		{expiresAt.Add(8 * 24 * time.Hour), Expired},
+	}
+	for _, tt := range tests {
+		service.now = func() time.Time { return tt.now }
+		status, err := service.validate(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.State != tt.state {
+			t.Errorf("%s: unexpected state %s", tt.now, status.State)
+		}
+		if tt.state == Grace && !status.GraceEndsAt.Equal(expiresAt.Add(service.GracePeriod)) {
+			t.Errorf("unexpected end of the grace period: %s", status.GraceEndsAt)
+		}
+	}
+}
+
+func TestMachineID(t *testing.T) {
+	id := hashMachineID("4C4C4544-0042-3510-8052", "com.example.app")
+	if id != hashMachineID("4c4c4544-0042-3510-8052", "com.example.app") {
+		t.Error("the machine ID depends on the case of the ID of the operating system")
+	}
+	if id == hashMachineID("4C4C4544-0042-3510-8052", "com.example.other") {
+		t.Error("the machine IDs of different applications are the same")
+	}
+	if len(id) != 64 || strings.Contains(id, "4c4c4544") {
+		t.Errorf("unexpected machine ID: %s", id)
+	}
+}
@@ -0,0 +1,36 @@
+package license
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrNoMachineID is returned when the platform doesn't provide an ID of the machine
+var ErrNoMachineID = errors.New("license: the machine has no ID")
+
+// MachineID returns a stable ID of the machine for the application. The ID of the operating system is hashed with
+// the appID, so it isn't revealed and the IDs of different applications can't be correlated.
+//
+//   - Windows: the MachineGuid of HKEY_LOCAL_MACHINE\SOFTWARE\Microsoft\Cryptography.
+//   - macOS: the IOPlatformUUID of the hardware.
+//   - Linux: /etc/machine-id or the machine ID of D-Bus.
+func MachineID(appID string) (string, error) {
+	id, err := platformMachineID()
+	if err != nil {
+		return "", err
+	}
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", ErrNoMachineID
+	}
+	return hashMachineID(id, appID), nil
+}
+
+func hashMachineID(id string, appID string) string {
+	mac := hmac.New(sha256.New, []byte(strings.ToLower(id)))
+	mac.Write([]byte(appID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,22 @@
+//go:build darwin
+
+package license
+
+import (
+	"os/exec"
+	"regexp"
+)
+
+var platformUUIDRegex = regexp.MustCompile(`"IOPlatformUUID" = "([^"]+)"`)
+
+func platformMachineID() (string, error) {
+	output, err := exec.Command("/usr/sbin/ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+	if err != nil {
+		return "", err
+	}
+	match := platformUUIDRegex.FindSubmatch(output)
+	if match == nil {
+		return "", ErrNoMachineID
+	}
+	return string(match[1]), nil
+}
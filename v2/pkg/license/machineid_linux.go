@@ -0,0 +1,17 @@
+//go:build linux
+
+package license
+
+import "os"
+
+// machineIDFiles are the files of the machine ID of systemd and of D-Bus on systems without systemd
+var machineIDFiles = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+func platformMachineID() (string, error) {
+	for _, file := range machineIDFiles {
+		if data, err := os.ReadFile(file); err == nil && len(data) > 0 {
+			return string(data), nil
+		}
+	}
+	return "", ErrNoMachineID
+}
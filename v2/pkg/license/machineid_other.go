@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !windows
+
+package license
+
+func platformMachineID() (string, error) {
+	return "", ErrNoMachineID
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package license
+
+import "golang.org/x/sys/windows/registry"
+
+func platformMachineID() (string, error) {
+	// The 64-bit view is read by 32-bit applications too, the MachineGuid isn't reflected to the 32-bit view
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Cryptography`, registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return "", err
+	}
+	defer key.Close()
+	id, _, err := key.GetStringValue("MachineGuid")
+	return id, err
+}
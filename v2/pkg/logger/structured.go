@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StructuredLogger is implemented by the loggers that keep the fields of structured logs, EG: of runtime.Log in the
+// frontend. The fields are appended to the message for the other loggers.
+type StructuredLogger interface {
+	Logger
+	Log(level LogLevel, message string, fields map[string]interface{})
+}
+
+// FormatFields appends the fields to the message as key=value pairs, sorted by key
+func FormatFields(message string, fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return message
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var builder strings.Builder
+	builder.WriteString(message)
+	for _, key := range keys {
+		value := fmt.Sprint(fields[key])
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		builder.WriteString(" " + key + "=" + value)
+	}
+	return builder.String()
+}
+
+// SlogLogger logs to a slog.Logger, so the logs of Wails and of the frontend end up in the sink of the application
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger creates a logger that logs to the slog.Logger, slog.Default() is used if it is nil
+func NewSlogLogger(logger *slog.Logger) StructuredLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
+
+// slogLevels are the slog levels of the log levels, trace is below debug like in slog's own examples
+var slogLevels = map[LogLevel]slog.Level{
+	TRACE:   slog.LevelDebug - 4,
+	DEBUG:   slog.LevelDebug,
+	INFO:    slog.LevelInfo,
+	WARNING: slog.LevelWarn,
+	ERROR:   slog.LevelError,
+}
+
+// Log logs the message with the fields as attributes, sorted by key
+func (l *SlogLogger) Log(level LogLevel, message string, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.Any(key, fields[key]))
+	}
+	l.logger.LogAttrs(context.Background(), slogLevels[level], message, attrs...)
+}
+
+// Print logs the message at the info level
+func (l *SlogLogger) Print(message string) {
+	l.logger.Info(message)
+}
+
+// Trace logs the message below the debug level
+func (l *SlogLogger) Trace(message string) {
+	l.logger.Log(context.Background(), slogLevels[TRACE], message)
+}
+
+// Debug logs the message at the debug level
+func (l *SlogLogger) Debug(message string) {
+	l.logger.Debug(message)
+}
+
+// Info logs the message at the info level
+func (l *SlogLogger) Info(message string) {
+	l.logger.Info(message)
+}
+
+// Warning logs the message at the warn level
+func (l *SlogLogger) Warning(message string) {
+	l.logger.Warn(message)
+}
+
+// Error logs the message at the error level
+func (l *SlogLogger) Error(message string) {
+	l.logger.Error(message)
+}
+
+// Fatal logs the message at the error level and exits
+func (l *SlogLogger) Fatal(message string) {
+	l.logger.Error(message)
+	os.Exit(1)
+}
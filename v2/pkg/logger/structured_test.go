@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFormatFields(t *testing.T) {
+	got := FormatFields("saved", map[string]interface{}{
+		"page":  "/settings",
+		"count": 3,
+		"error": "disk full",
+		"empty": "",
+	})
+	want := `saved count=3 empty="" error="disk full" page=/settings`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := FormatFields("saved", nil); got != "saved" {
+		t.Errorf("got %q, want %q", got, "saved")
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	handler := slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug - 4})
+	logger := NewSlogLogger(slog.New(handler))
+
+	logger.Log(WARNING, "slow request", map[string]interface{}{"page": "/", "correlationId": "abc"})
+	logger.Trace("tracing")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buffer.String())
+	}
+	if !strings.Contains(lines[0], `level=WARN msg="slow request" correlationId=abc page=/`) {
+		t.Errorf("unexpected log %q", lines[0])
+	}
+	if !strings.Contains(lines[1], `level=DEBUG-4 msg=tracing`) {
+		t.Errorf("unexpected log %q", lines[1])
+	}
+}
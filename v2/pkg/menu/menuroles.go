@@ -11,14 +11,16 @@ const (
 	AppMenuRole    Role = 1
 	EditMenuRole        = 2
 	WindowMenuRole      = 3
+	// The text editing roles are executed in the focused element of the webview
+	UndoRole               Role = 4
+	RedoRole               Role = 5
+	CutRole                Role = 6
+	CopyRole               Role = 7
+	PasteRole              Role = 8
+	PasteAndMatchStyleRole Role = 9
+	SelectAllRole          Role = 10
+	FindRole               Role = 11
 	// AboutRole              Role = "about"
-	// UndoRole               Role = "undo"
-	// RedoRole               Role = "redo"
-	// CutRole                Role = "cut"
-	// CopyRole               Role = "copy"
-	// PasteRole              Role = "paste"
-	// PasteAndMatchStyleRole Role = "pasteAndMatchStyle"
-	// SelectAllRole          Role = "selectAll"
 	// DeleteRole             Role = "delete"
 	// MinimizeRole           Role = "minimize"
 	// QuitRole               Role = "quit"
@@ -36,6 +38,10 @@ const (
 	// SeparatorItemRole      Role = "separatorItem"
 )
 
+// FindEvent is emitted to the frontend and the application when an item with the Find role is clicked that has no
+// Click callback. Webviews have no find bar, so the application shows its own.
+const FindEvent = "menu:find"
+
 /*
 // About provides a MenuItem with the About role
 func About() *MenuItem {
@@ -44,6 +50,45 @@ func About() *MenuItem {
 	}
 }
 
+// Delete provides a MenuItem with the Delete role
+func Delete() *MenuItem {
+	return &MenuItem{
+		Role: DeleteRole,
+	}
+}
+
+// Minimize provides a MenuItem with the Minimize role
+func Minimize() *MenuItem {
+	return &MenuItem{
+		Role: MinimizeRole,
+	}
+}
+
+// Quit provides a MenuItem with the Quit role
+func Quit() *MenuItem {
+	return &MenuItem{
+		Role: QuitRole,
+	}
+}
+
+// ToggleFullscreen provides a MenuItem with the ToggleFullscreen role
+func ToggleFullscreen() *MenuItem {
+	return &MenuItem{
+		Role: TogglefullscreenRole,
+	}
+}
+
+// FileMenu provides a MenuItem with the whole default "File" menu (Close / Quit)
+func FileMenu() *MenuItem {
+	return &MenuItem{
+		Role: FileMenuRole,
+	}
+}
+*/
+
+// The text editing roles use the label and the accelerator of the item if they are set, otherwise the standard
+// ones of the platform.
+
 // Undo provides a MenuItem with the Undo role
 func Undo() *MenuItem {
 	return &MenuItem{
@@ -93,42 +138,13 @@ func SelectAll() *MenuItem {
 	}
 }
 
-// Delete provides a MenuItem with the Delete role
-func Delete() *MenuItem {
-	return &MenuItem{
-		Role: DeleteRole,
-	}
-}
-
-// Minimize provides a MenuItem with the Minimize role
-func Minimize() *MenuItem {
+// Find provides a MenuItem with the Find role. It calls the Click callback of the item or emits the FindEvent.
+func Find() *MenuItem {
 	return &MenuItem{
-		Role: MinimizeRole,
+		Role: FindRole,
 	}
 }
 
-// Quit provides a MenuItem with the Quit role
-func Quit() *MenuItem {
-	return &MenuItem{
-		Role: QuitRole,
-	}
-}
-
-// ToggleFullscreen provides a MenuItem with the ToggleFullscreen role
-func ToggleFullscreen() *MenuItem {
-	return &MenuItem{
-		Role: TogglefullscreenRole,
-	}
-}
-
-// FileMenu provides a MenuItem with the whole default "File" menu (Close / Quit)
-func FileMenu() *MenuItem {
-	return &MenuItem{
-		Role: FileMenuRole,
-	}
-}
-*/
-
 // EditMenu provides a MenuItem with the whole default "Edit" menu (Undo, Copy, etc.).
 func EditMenu() *MenuItem {
 	return &MenuItem{
@@ -0,0 +1,20 @@
+// Package obfuscation registers the bound methods of obfuscated builds. The names of the types and packages are
+// obfuscated by garble, so the IDs of the methods that are called by the generated bindings can't be derived from
+// their names. The registration is generated by `wails build -obfuscated` and isn't written by hand.
+package obfuscation
+
+import "reflect"
+
+var methods []uintptr
+
+// RegisterMethods registers the method expressions of the bound methods, EG: (*App).Greet, in the order of their IDs
+func RegisterMethods(expressions ...interface{}) {
+	for _, expression := range expressions {
+		methods = append(methods, reflect.ValueOf(expression).Pointer())
+	}
+}
+
+// Methods returns the code pointers of the registered methods by ID
+func Methods() []uintptr {
+	return methods
+}
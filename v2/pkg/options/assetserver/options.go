@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/fs"
 	"net/http"
+	"path"
 )
 
 // Options defines the configuration of the AssetServer.
@@ -33,13 +34,43 @@ type Options struct {
 	// Multiple Middlewares can be chained together with:
 	//   ChainMiddleware(middleware ...Middleware) Middleware
 	Middleware Middleware
+
+	// WebSockets maps paths to the handlers of WebSocket connections, EG: "/ws/chat". The handlers have to upgrade the
+	// connections themselves, EG: with `golang.org/x/net/websocket`.
+	// The custom schemes of the webviews can't carry WebSocket connections, so the handlers are served on the
+	// loopback interface. The frontend connects to them with `new WebSocket(window.runtime.WebSocketURL("/ws/chat"))`.
+	WebSockets map[string]http.Handler
+
+	// Templates maps paths to pages that are rendered server-side with html/template before the frontend boots,
+	// EG: "/" or "/settings". A GET request for a path in Templates is served from the Template instead of the Assets
+	// and the Handler.
+	Templates map[string]Template
+
+	// Policies strip the source maps, comments and console statements from the Assets in production builds. The
+	// policies of all patterns that match an asset are combined. The assets policies of the build profile given to
+	// `wails build -profile` replace them.
+	Policies []Policy
 }
 
 // Validate the options
 func (o Options) Validate() error {
-	if o.Assets == nil && o.Handler == nil && o.Middleware == nil {
-		return fmt.Errorf("AssetServer options invalid: either Assets, Handler or Middleware must be set")
+	if o.Assets == nil && o.Handler == nil && o.Middleware == nil && len(o.Templates) == 0 {
+		return fmt.Errorf("AssetServer options invalid: either Assets, Handler, Middleware or Templates must be set")
+	}
+	for path, template := range o.Templates {
+		if template.Template == nil {
+			return fmt.Errorf("AssetServer options invalid: the Template of %s must be set", path)
+		}
+	}
+	for _, policy := range o.Policies {
+		if _, err := path.Match(policy.Pattern, ""); err != nil {
+			return fmt.Errorf("AssetServer options invalid: the pattern %s of the Policy is malformed", policy.Pattern)
+		}
 	}
 
 	return nil
 }
+
+// NoticesFile is the name of the notices of the third-party dependencies that are generated by `wails build` next to
+// the index.html of the assets. The AssetServer shows them at /wails/licenses.
+const NoticesFile = "THIRD_PARTY_NOTICES.txt"
@@ -0,0 +1,19 @@
+package assetserver
+
+// Policy strips the development leftovers from the Assets whose paths match the Pattern. The policies only apply to
+// production builds, debug builds, EG: `wails dev` and `wails build -debug`, serve the Assets as they are.
+type Policy struct {
+	// Pattern is matched against the paths of the Assets with path.Match, EG: "assets/*.js". Patterns without a slash
+	// are matched against the file names, EG: "*.js". An empty Pattern matches all Assets.
+	Pattern string `json:"pattern"`
+
+	// StripSourceMaps removes the sourceMappingURL comments and stops serving the .map files
+	StripSourceMaps bool `json:"stripSourceMaps"`
+
+	// StripComments removes the comments of JS, CSS and HTML files. Legal comments, EG: /*! ... */ or the ones
+	// with @license, are kept.
+	StripComments bool `json:"stripComments"`
+
+	// StripConsole replaces the calls of the console methods in JS files and inline scripts with `void 0`
+	StripConsole bool `json:"stripConsole"`
+}
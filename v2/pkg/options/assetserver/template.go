@@ -0,0 +1,20 @@
+package assetserver
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// Template is a page that is rendered server-side with html/template. The runtime scripts are injected into the
+// rendered page, the same as for the index.html of the Assets.
+type Template struct {
+	// Template is the parsed template
+	Template *template.Template
+
+	// Name is the name of the template to execute. If empty, Template itself is executed.
+	Name string
+
+	// Data returns the data the template is rendered with, EG: build info, the locale of the request or the current
+	// user. If an error is returned, the request fails with `http.StatusInternalServerError`.
+	Data func(req *http.Request) (interface{}, error)
+}
@@ -0,0 +1,44 @@
+package options
+
+// AuthenticationScheme is the scheme of an HTTP authentication challenge
+type AuthenticationScheme string
+
+const (
+	AuthenticationSchemeBasic     AuthenticationScheme = "basic"
+	AuthenticationSchemeDigest    AuthenticationScheme = "digest"
+	AuthenticationSchemeNTLM      AuthenticationScheme = "ntlm"
+	AuthenticationSchemeNegotiate AuthenticationScheme = "negotiate"
+)
+
+// Authentication answers the HTTP authentication challenges of the webview, so resources behind basic, digest, NTLM
+// or Negotiate auth load without the prompts of the platform. The callback is called on the main thread and the
+// webview waits for its decision, so it should return quickly, EG: by looking up stored credentials.
+type Authentication struct {
+	// OnChallenge is called when a server or proxy requests credentials. Returning nil continues with the default
+	// handling of the platform, which may prompt the user or fail the request.
+	OnChallenge func(challenge AuthenticationChallenge) *Credentials
+
+	// IntegratedServers are the hosts that NTLM and Negotiate authenticate with the account of the signed in user,
+	// EG: "intranet.example.com" or "*.example.com". OnChallenge isn't called for their NTLM and Negotiate
+	// challenges. On Windows, the webview only uses the account for the servers of the local intranet by default.
+	IntegratedServers []string
+}
+
+// AuthenticationChallenge is the request of a server or proxy for credentials
+type AuthenticationChallenge struct {
+	Host   string
+	Port   int
+	Realm  string
+	Scheme AuthenticationScheme
+	// IsProxy is set for the challenges of proxies. It isn't reported by WebView2.
+	IsProxy bool
+	// PreviousFailures is the number of earlier attempts for the challenge, so wrong credentials aren't retried
+	// forever. It is at most 1 on Linux.
+	PreviousFailures int
+}
+
+// Credentials answer an authentication challenge
+type Credentials struct {
+	Username string
+	Password string
+}
@@ -0,0 +1,20 @@
+package options
+
+// DeepLinkEvent is emitted with the URL when the application is opened with a URL of one of the schemes of DeepLinks
+const DeepLinkEvent = "wails:deeplink"
+
+// DeepLinks contains the options for opening the application with the URLs of custom schemes, EG: for the redirect of
+// an OAuth flow. Use SingleInstanceLock on Windows and Linux, so that the URLs that are opened while the application is
+// running are delivered to the running instance instead of a new one.
+type DeepLinks struct {
+	// Schemes are the custom schemes without "://", EG: "myapp"
+	Schemes []string
+
+	// Register registers the application as the handler of the schemes for the current user at startup if it isn't
+	// the handler yet, see the deeplink package. The schemes must still be declared in the Info.plist on macOS.
+	Register bool
+
+	// OnURL is called with each URL of the schemes the application is opened with, including the URLs it has been
+	// started with
+	OnURL func(url string) `json:"-"`
+}
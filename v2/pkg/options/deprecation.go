@@ -0,0 +1,17 @@
+package options
+
+// Deprecation marks a bound method as deprecated. Calls to the method are logged as warnings in dev and debug
+// builds and the generated bindings carry a @deprecated JSDoc tag.
+type Deprecation struct {
+	// Method is the deprecated bound method, EG: app.GreetUser
+	Method interface{} `json:"-"`
+
+	// Replacement is the bound method that should be used instead, EG: app.Greet (optional)
+	Replacement interface{} `json:"-"`
+
+	// Message explains the deprecation (optional)
+	Message string
+
+	// Since is the API version the method was deprecated in, EG: "1.2.0" (optional)
+	Since string
+}
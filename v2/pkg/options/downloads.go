@@ -0,0 +1,33 @@
+package options
+
+// Downloads routes the downloads of the webview through the application. The progress of the downloads is reported
+// with the runtime.DownloadEvent event to Go and the frontend, and they can be cancelled with runtime.DownloadCancel.
+// Without it, the platform defaults remain: WebView2 saves to the Downloads folder and WebKit ignores the downloads.
+type Downloads struct {
+	// Directory is where the downloads are saved. It defaults to the Downloads folder of the user.
+	Directory string
+
+	// Prompt asks the user where to save each download, unless OnStart has decided the path
+	Prompt bool
+
+	// OnStart is called on the main thread when a download starts and decides its path. Without it, the downloads are
+	// saved to Directory with their suggested filename, numbered if the file exists.
+	OnStart func(request DownloadRequest) DownloadDecision
+}
+
+// DownloadRequest is a download that has been started in the webview
+type DownloadRequest struct {
+	URL string
+	// SuggestedFilename is the filename of the Content-Disposition header, the download attribute or the URL
+	SuggestedFilename string
+	MimeType          string
+}
+
+// DownloadDecision is the decision of OnStart. The zero value saves the download to the default path.
+type DownloadDecision struct {
+	// Path is where the download is saved, paths that aren't absolute are relative to Directory. The file is replaced
+	// if it exists.
+	Path string
+	// Cancel doesn't download the file
+	Cancel bool
+}
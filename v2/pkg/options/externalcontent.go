@@ -0,0 +1,22 @@
+package options
+
+// ExternalContentPolicy defines how documents of third-party origins are treated, EG: external web apps in frames
+type ExternalContentPolicy int
+
+const (
+	// ExternalContentAllow lets documents of all origins use the runtime bridge. This is the default.
+	ExternalContentAllow ExternalContentPolicy = iota
+	// ExternalContentIsolate loads documents of third-party origins, but drops their messages to the runtime bridge,
+	// so they can't call bound methods or emit events
+	ExternalContentIsolate
+	// ExternalContentBlock doesn't load documents of third-party origins at all
+	ExternalContentBlock
+)
+
+// ExternalContent controls the access of documents of third-party origins to the runtime bridge
+type ExternalContent struct {
+	Policy ExternalContentPolicy
+
+	// TrustedOrigins are treated like the origin of the application, EG: "https://auth.example.com"
+	TrustedOrigins []string
+}
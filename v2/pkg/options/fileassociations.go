@@ -0,0 +1,17 @@
+package options
+
+// FileOpenedEvent is emitted with the absolute path when the application is opened with a file of FileAssociations
+const FileOpenedEvent = "wails:fileopened"
+
+// FileAssociations contains the options for opening the application with files, EG: of the file associations of
+// wails.json. The files are the arguments that are paths of existing files on Windows and Linux and the files that
+// are opened by the Finder on macOS. Use SingleInstanceLock on Windows and Linux, so that the files that are opened
+// while the application is running are delivered to the running instance instead of a new one.
+type FileAssociations struct {
+	// Extensions are the extensions of the files without the dot, EG: "md". Default: all files
+	Extensions []string
+
+	// OnFileOpened is called with the absolute path of each file the application is opened with, including the files
+	// it has been started with
+	OnFileOpened func(path string) `json:"-"`
+}
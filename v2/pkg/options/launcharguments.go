@@ -0,0 +1,19 @@
+package options
+
+// LaunchArguments contains the normalized arguments the application has been launched with
+type LaunchArguments struct {
+	// Args are the arguments that are neither files nor URLs
+	Args []string
+
+	// Files are the absolute paths of the files, EG: from a file association
+	Files []string
+
+	// URLs are the URLs, EG: of a custom protocol scheme
+	URLs []string
+
+	// WorkingDirectory is the working directory of the launched instance
+	WorkingDirectory string
+
+	// SecondInstance is true if the arguments have been forwarded by a second instance, see SingleInstanceLock
+	SecondInstance bool
+}
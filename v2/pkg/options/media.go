@@ -0,0 +1,18 @@
+package options
+
+// Media contains the options of the audio and the video playback of the webview and the work of the page while the
+// window is in the background
+type Media struct {
+	// Muted mutes the audio of the webview from the start, runtime.WindowSetAudioMuted unmutes it
+	Muted bool
+
+	// AutoplayAllowed lets the page play audio and video with sound without a gesture of the user, EG: for kiosks
+	// and notification sounds. Videos without sound can autoplay without it. On Linux it requires WebKitGTK 2.30.
+	AutoplayAllowed bool
+
+	// DisableBackgroundThrottling keeps the timers, the animation frames and the rendering of the page at full rate
+	// while the window is minimised, hidden or occluded by other windows, EG: for dashboards that are captured or
+	// shown on another display. It increases the power used in the background. It is ignored on Linux, where
+	// WebKitGTK has no setting for it.
+	DisableBackgroundThrottling bool
+}
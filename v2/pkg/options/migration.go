@@ -0,0 +1,25 @@
+package options
+
+import (
+	"context"
+
+	"github.com/Masterminds/semver"
+)
+
+// Migration contains the options for detecting the first run of the application and for migrating
+// between application versions. The version of the last run is persisted in a version stamp file.
+type Migration struct {
+	// Version is the semantic version of the application, EG: "1.2.0"
+	Version string
+
+	// Directory is the directory the version stamp is persisted in.
+	// Default: the application data directory, see runtime.AppDataDir
+	Directory string
+
+	// OnFirstRun is called when no version stamp has been found
+	OnFirstRun func(ctx context.Context) error `json:"-"`
+
+	// OnUpgrade is called when the version stamp is lower than Version. The version stamp is only updated
+	// when OnUpgrade succeeds, so it is called again on the next start if it returns an error.
+	OnUpgrade func(ctx context.Context, from *semver.Version, to *semver.Version) error `json:"-"`
+}
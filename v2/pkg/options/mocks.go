@@ -0,0 +1,34 @@
+package options
+
+import "time"
+
+// Mock replaces the methods of a bound struct in dev mode, EG: to develop the frontend with fixtures or to test how
+// it handles slow and failing calls. The mocks can be switched on and off at runtime from the debug page of the dev
+// server. They are ignored in production and debug builds.
+type Mock struct {
+	// Name identifies the mock on the debug page. Default: the name of the Implementation or the Service
+	Name string
+
+	// Service is the bound struct that is mocked, EG: app
+	Service interface{} `json:"-"`
+
+	// Implementation is a pointer to a struct with methods of the same names and signatures as the methods of the
+	// Service, which replace them. Methods it doesn't have call the Service. It can be nil to only delay and fail the
+	// calls (optional)
+	Implementation interface{} `json:"-"`
+
+	// Latency delays every call, Jitter adds a random delay of up to its duration
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// FailureRate is the fraction of the calls that fail with FailureMessage, from 0 to 1. Only methods that return
+	// an error can fail. Default FailureMessage: "mock failure"
+	FailureRate    float64
+	FailureMessage string
+
+	// Seed makes the random delays and failures reproducible
+	Seed int64
+
+	// Disabled starts the mock switched off
+	Disabled bool
+}
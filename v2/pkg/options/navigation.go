@@ -0,0 +1,46 @@
+package options
+
+// NavigationAction is what the webview does with a navigation or a new window
+type NavigationAction int
+
+const (
+	// NavigationAllow loads the URL. The URLs of new windows are loaded in the window, as it is the only one.
+	NavigationAllow NavigationAction = iota
+	// NavigationDeny doesn't load the URL
+	NavigationDeny
+	// NavigationOpenInBrowser opens the URL in the default browser instead of the window
+	NavigationOpenInBrowser
+	// NavigationRedirect loads the URL of the decision instead, EG: to rewrite the links to a documentation site
+	NavigationRedirect
+)
+
+// Navigation decides the navigations and the new windows of the webview, EG: to open external links in the browser.
+// The callbacks are called on the main thread and the webview waits for their decisions, so they should return
+// quickly. Documents that are blocked by ExternalContent don't reach the callbacks.
+type Navigation struct {
+	// OnNavigation is called before the window navigates, including to the pages of the application. Without it, all
+	// navigations are allowed. On Linux it is also called for the navigations of frames, which WebKitGTK doesn't tell
+	// apart.
+	OnNavigation func(request NavigationRequest) NavigationDecision
+
+	// OnNewWindow is called when the page opens a new window, EG: with a link to _blank or window.open. Without it,
+	// WebView2 opens a popup window and WebKit ignores the request.
+	OnNewWindow func(request NavigationRequest) NavigationDecision
+}
+
+// NavigationRequest is a navigation or a new window of the webview
+type NavigationRequest struct {
+	URL string
+	// Internal is set for the URLs of the application, EG: wails://wails/ or the dev server
+	Internal bool
+	// UserInitiated is set when the user activated a link or submitted a form. WebKit reports the new windows
+	// that are opened by scripts as user initiated when they react to a click.
+	UserInitiated bool
+}
+
+// NavigationDecision is the decision of a navigation callback. The zero value allows the navigation.
+type NavigationDecision struct {
+	Action NavigationAction
+	// URL is loaded instead of the requested URL with NavigationRedirect
+	URL string
+}
@@ -45,6 +45,8 @@ type App struct {
 	StartHidden       bool
 	HideWindowOnClose bool
 	AlwaysOnTop       bool
+	// SkipTaskbar hides the window from the taskbar and the window switcher, EG: for tray popovers and overlays
+	SkipTaskbar bool
 	// BackgroundColour is the background colour of the window
 	// You can use the options.NewRGB and options.NewRGBA functions to create a new colour
 	BackgroundColour *RGBA
@@ -66,9 +68,49 @@ type App struct {
 	EnumBind           []interface{}
 	WindowStartState   WindowStartState
 
+	// APIVersion is the version of the bound API. It is exported as `APIVersion` from the generated
+	// wailsjs/go/version.js so the frontend can check it.
+	APIVersion string
+
+	// Deprecations marks bound methods as deprecated
+	Deprecations []Deprecation
+
+	// Mocks replaces the methods of bound structs with fixtures, delays and failures in dev mode
+	Mocks []Mock
+
+	// UserScript is injected into every page of the window before any script of the page runs
+	UserScript *UserScript
+
+	// ExternalContent controls if documents of third-party origins can use the runtime bridge
+	ExternalContent *ExternalContent
+
+	// TLS customises the trust of server certificates and provides client certificates to the webview
+	TLS *TLS
+
+	// Authentication answers the HTTP authentication challenges of the webview, EG: basic or NTLM auth of intranet servers
+	Authentication *Authentication
+
+	// Proxy routes the requests of the webview through a proxy server
+	Proxy *Proxy
+
+	// Navigation decides the navigations and the new windows of the webview, EG: to open external links in the browser
+	Navigation *Navigation
+
+	// Permissions decides the camera, microphone, geolocation, notification and clipboard requests of the webview
+	Permissions *Permissions
+
+	// Downloads saves the downloads of the webview to the path decided by the application and reports their progress
+	Downloads *Downloads
+
+	// WebviewProfile stores the cookies, storage and caches of the webview in a named, custom or in-memory profile
+	WebviewProfile *WebviewProfile
+
 	// ErrorFormatter overrides the formatting of errors returned by backend methods
 	ErrorFormatter ErrorFormatter
 
+	// PanicRecovery recovers panics in bound methods instead of crashing the application
+	PanicRecovery *PanicRecovery
+
 	// CSS property to test for draggable elements. Default "--wails-draggable"
 	CSSDragProperty string
 
@@ -84,8 +126,44 @@ type App struct {
 	// services of Apple and Microsoft.
 	EnableFraudulentWebsiteDetection bool
 
+	// EnableMediaCaptureEvents emits the "wails:media-capture" event when the page starts or stops using the camera,
+	// the microphone or screen capture, and allows runtime.MediaCaptureStop to stop the capture
+	EnableMediaCaptureEvents bool
+
+	// PerformanceMetrics emits the "wails:performance" event with the frame timings and the long tasks of the page
+	PerformanceMetrics *PerformanceMetrics
+
 	SingleInstanceLock *SingleInstanceLock
 
+	// InstanceEvents enables exchanging events between the running instances of the application.
+	// It is ignored when SingleInstanceLock is set.
+	InstanceEvents *InstanceEvents
+
+	// OnLaunchArguments is called with the normalized arguments, files and URLs the application has been launched
+	// with. It is called after OnStartup, when a second instance forwards its arguments and when a file or URL is
+	// opened on macOS.
+	OnLaunchArguments func(arguments LaunchArguments) `json:"-"`
+
+	// DeepLinks delivers the URLs of custom schemes to a callback and to the frontend with the DeepLinkEvent event
+	DeepLinks *DeepLinks
+
+	// FileAssociations delivers the files the application is opened with to a callback and to the frontend with the
+	// FileOpenedEvent event
+	FileAssociations *FileAssociations
+
+	// Migration options for detecting the first run and upgrades of the application
+	Migration *Migration
+
+	// Portable stores all application data, including the data of the webview, in a `data` directory next to the
+	// executable. The portable mode can also be enabled with the `portable` build tag or a file named `portable`
+	// next to the executable.
+	Portable bool
+
+	// EnableRuntimeOverrides honours the WAILS_* environment variables and --wails-* command-line flags that can
+	// override the log level, devtools, GPU acceleration and assets of a production build. The consumed flags are
+	// removed from os.Args. Anyone who can start the application can use them, so only enable them for diagnostics.
+	EnableRuntimeOverrides bool
+
 	Windows *windows.Options
 	Mac     *mac.Options
 	Linux   *linux.Options
@@ -98,6 +176,18 @@ type App struct {
 
 	// DragAndDrop options for drag and drop behavior
 	DragAndDrop *DragAndDrop
+
+	// WebviewRecovery options for recovering the webview after a crash or when it exceeds a memory limit
+	WebviewRecovery *WebviewRecovery
+
+	// VideoPlayback options for windows that mainly play videos
+	VideoPlayback *VideoPlayback
+
+	// Media options for muting the audio, autoplay and the throttling of the page in the background
+	Media *Media
+
+	// Zoom options for the keyboard shortcuts, the limits and the persistence of the zoom factor
+	Zoom *Zoom
 }
 
 type ErrorFormatter func(error) any
@@ -179,12 +269,24 @@ func MergeDefaults(appoptions *App) {
 
 	// Process Drag Options
 	processDragOptions(appoptions)
+
+	// Process Webview Recovery Options
+	processWebviewRecovery(appoptions)
+
+	// Process Zoom Options
+	processZoom(appoptions)
+
+	// Process Performance Metrics Options
+	processPerformanceMetrics(appoptions)
 }
 
 type SingleInstanceLock struct {
 	// uniqueId that will be used for setting up messaging between instances
 	UniqueId               string
 	OnSecondInstanceLaunch func(secondInstanceData SecondInstanceData)
+	// FocusWindow unminimises and shows the window of the running instance when a second instance is launched,
+	// before OnSecondInstanceLaunch is called
+	FocusWindow bool
 }
 
 type SecondInstanceData struct {
@@ -192,6 +294,21 @@ type SecondInstanceData struct {
 	WorkingDirectory string
 }
 
+// InstanceEvents contains the options for exchanging events between the running instances of the application
+type InstanceEvents struct {
+	// UniqueId identifies the application. Only instances with the same UniqueId exchange events.
+	UniqueId string
+}
+
+// InstanceEvent is the envelope of an event that has been received from another instance. It is emitted as the
+// data of a regular event with the same name.
+type InstanceEvent struct {
+	// Sender is the identifier of the instance that emitted the event
+	Sender string        `json:"sender"`
+	Name   string        `json:"name"`
+	Data   []interface{} `json:"data"`
+}
+
 type DragAndDrop struct {
 
 	// EnableFileDrop enables wails' drag and drop functionality that returns the dropped in files' absolute paths.
@@ -0,0 +1,58 @@
+package options
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PanicPolicy decides what happens when a bound method panics
+type PanicPolicy int
+
+const (
+	// PanicRecover returns a PanicError to the caller and logs a report of the panic
+	PanicRecover PanicPolicy = iota
+	// PanicCrash lets the panic crash the application
+	PanicCrash
+)
+
+// PanicRecovery contains the options for recovering panics in bound methods. Only panics of the goroutine of the
+// call are recovered, not of goroutines started by the method.
+type PanicRecovery struct {
+	// Policy returns the policy for a call of the method, EG: "main.App.Greet". All panics are recovered if it is nil.
+	Policy func(method string) PanicPolicy `json:"-"`
+
+	// CrashInDev lets all panics crash the application in dev builds, so they can't be missed
+	CrashInDev bool
+
+	// OnPanic is called with the report of every recovered panic, EG: to send it to a crash reporter
+	OnPanic func(report PanicReport) `json:"-"`
+}
+
+// PanicReport describes a recovered panic of a bound method
+type PanicReport struct {
+	// Method is the name of the bound method
+	Method string
+	// Value is the value that has been passed to panic
+	Value any
+	// Stack is the stack trace of the panic
+	Stack string
+}
+
+// PanicError is the error that is returned to the caller of a bound method that panicked. Unless an ErrorFormatter
+// is set, it is passed to JS as object: {"message": "...", "method": "main.App.Greet", "panic": true}
+type PanicError struct {
+	Method string
+	Value  any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Method, e.Value)
+}
+
+func (e *PanicError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Message string `json:"message"`
+		Method  string `json:"method"`
+		Panic   bool   `json:"panic"`
+	}{e.Error(), e.Method, true})
+}
@@ -0,0 +1,28 @@
+package options
+
+import "time"
+
+// PerformanceMetrics reports the frame timings and the long tasks of the page with the "wails:performance" event,
+// EG: to track UI jank regressions across releases. See runtime.OnPerformanceMetrics.
+type PerformanceMetrics struct {
+	// Interval is the time between two reports, at least one second. Default 10 seconds
+	Interval time.Duration
+
+	// SlowFrameThreshold is the duration after which a frame counts as slow. Default 50 milliseconds
+	SlowFrameThreshold time.Duration
+}
+
+func processPerformanceMetrics(appoptions *App) {
+	metrics := appoptions.PerformanceMetrics
+	if metrics == nil {
+		return
+	}
+	if metrics.Interval <= 0 {
+		metrics.Interval = 10 * time.Second
+	} else if metrics.Interval < time.Second {
+		metrics.Interval = time.Second
+	}
+	if metrics.SlowFrameThreshold <= 0 {
+		metrics.SlowFrameThreshold = 50 * time.Millisecond
+	}
+}
@@ -0,0 +1,39 @@
+package options
+
+// Permission is a permission a page of the webview requests
+type Permission string
+
+const (
+	PermissionCamera        Permission = "camera"
+	PermissionMicrophone    Permission = "microphone"
+	PermissionGeolocation   Permission = "geolocation"
+	PermissionNotifications Permission = "notifications"
+	PermissionClipboardRead Permission = "clipboardRead"
+	// PermissionOther is a permission without a kind of its own, EG: the sensors on Windows or the enumeration of the
+	// media devices on Linux
+	PermissionOther Permission = "other"
+)
+
+// PermissionDecision is the decision of a permission request
+type PermissionDecision int
+
+const (
+	// PermissionDefault leaves the request to the Default of the Permissions
+	PermissionDefault PermissionDecision = iota
+	PermissionAllow
+	PermissionDeny
+)
+
+// Permissions decides the permission requests of the webview, EG: for getUserMedia or the Geolocation API, so the
+// pages get the same answer on every platform. The callback is called on the main thread and the webview waits for
+// its decision, so it should return quickly. The permissions of the operating system, EG: the camera access of the
+// application on macOS, are still required.
+type Permissions struct {
+	// OnPermissionRequest is called for every request with the origin of the page, EG: "https://example.com". The
+	// camera and the microphone of a getUserMedia call are requested separately and both have to be allowed.
+	OnPermissionRequest func(origin string, kind Permission) PermissionDecision
+
+	// Default decides the requests that OnPermissionRequest leaves to the default or that arrive without it.
+	// PermissionDefault keeps the handling of the platform: WebView2 and WKWebView prompt the user, WebKitGTK denies.
+	Default PermissionDecision
+}
@@ -0,0 +1,20 @@
+package options
+
+// Proxy routes the requests of the webview through a proxy server instead of the proxy settings of the system, EG: a
+// corporate proxy. Proxies that intercept TLS need their CA in TLS.TrustedCertificates or an OnServerTrust callback.
+// On macOS, the proxy requires macOS 14 and the system settings are used on older versions.
+type Proxy struct {
+	// URL is the proxy server, EG: "http://proxy.example.com:8080" or "socks5://localhost:1080". The schemes are
+	// http, https and socks5.
+	URL string
+
+	// Bypass are the hosts that are connected to directly, EG: "intranet.example.com" or "*.example.com". The loopback
+	// addresses are always connected to directly.
+	Bypass []string
+
+	// Username and Password answer the authentication challenges of the proxy. They can also be the user info of the
+	// URL. WebView2 doesn't tell the challenges of proxies apart, so on Windows they have to be answered with
+	// Authentication.OnChallenge instead.
+	Username string
+	Password string
+}
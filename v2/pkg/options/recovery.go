@@ -0,0 +1,121 @@
+package options
+
+import (
+	"context"
+	"time"
+)
+
+// WebviewCrashReason describes why the webview content has been recycled
+type WebviewCrashReason string
+
+const (
+	// WebviewCrashReasonCrashed is used when the render process of the webview terminated unexpectedly
+	WebviewCrashReasonCrashed WebviewCrashReason = "crashed"
+	// WebviewCrashReasonMemoryLimit is used when the page exceeded the configured memory limit
+	WebviewCrashReasonMemoryLimit WebviewCrashReason = "memorylimit"
+	// WebviewCrashReasonRecycled is used when the content was recycled by calling runtime.WindowRecycle
+	WebviewCrashReasonRecycled WebviewCrashReason = "recycled"
+	// WebviewCrashReasonUnresponsive is used when the page stopped responding, EG: because of an endless loop
+	WebviewCrashReasonUnresponsive WebviewCrashReason = "unresponsive"
+)
+
+// WebviewRecoveryPolicy is how the webview content is recovered
+type WebviewRecoveryPolicy string
+
+const (
+	// WebviewRecoveryReload reloads the content automatically
+	WebviewRecoveryReload WebviewRecoveryPolicy = "reload"
+	// WebviewRecoveryErrorPanel shows a native error panel with a reload and a report button, the content is reloaded
+	// after the user has chosen
+	WebviewRecoveryErrorPanel WebviewRecoveryPolicy = "errorpanel"
+)
+
+// WebviewCrash is the data of the wails:webview:crashed event and of OnReport
+type WebviewCrash struct {
+	Reason WebviewCrashReason `json:"reason"`
+	// Recoveries is the number of times the content has been recovered, including this time
+	Recoveries int `json:"recoveries"`
+	// Fallback is true when the FallbackHTML is shown instead of the content
+	Fallback bool `json:"fallback"`
+}
+
+// WebviewErrorPanel contains the texts of the error panel
+type WebviewErrorPanel struct {
+	Title        string
+	Message      string
+	ReloadButton string
+	ReportButton string
+}
+
+const defaultFallbackHTML = `<!DOCTYPE html><html><head><meta charset="utf-8"><style>body{font-family:sans-serif;display:flex;` +
+	`align-items:center;justify-content:center;height:100vh;margin:0;color:#888;}</style></head>` +
+	`<body><p>The content of this window stopped working and could not be recovered.</p></body></html>`
+
+// WebviewRecovery contains the options for recovering the webview content after the render process crashed or
+// the page exceeded a memory limit.
+type WebviewRecovery struct {
+	// MemoryLimitMB recycles the webview content when the JavaScript heap of the page exceeds the given amount of
+	// megabytes. Sampling the heap is only supported by WebView2 on Windows. 0 disables the memory limit.
+	MemoryLimitMB int
+
+	// MaxRecoveries is the number of times the content is reloaded automatically before the FallbackHTML is shown
+	// instead. The count is reset when the content crashes after it has been loaded successfully and kept running
+	// for a minute. Default 3
+	MaxRecoveries int
+
+	// FallbackHTML is the page that is shown when the content could not be recovered.
+	FallbackHTML string
+
+	// OnContentCrashed is called every time the webview content has been recycled.
+	OnContentCrashed func(ctx context.Context, reason WebviewCrashReason) `json:"-"`
+
+	// Policy is how the content is recovered. Default WebviewRecoveryReload
+	Policy WebviewRecoveryPolicy
+
+	// ReloadBackoff is the delay before the content is reloaded automatically, which is doubled for every further
+	// recovery up to a minute. 0 reloads immediately.
+	ReloadBackoff time.Duration
+
+	// UnresponsiveTimeout is the time after which a page that doesn't respond is recovered, on macOS where the
+	// webview doesn't detect unresponsive pages itself. Default 10 seconds
+	UnresponsiveTimeout time.Duration
+
+	// ErrorPanel contains the texts of the error panel of WebviewRecoveryErrorPanel
+	ErrorPanel WebviewErrorPanel
+
+	// OnReport is called when the user chose to report the crash in the error panel. The report button is only shown
+	// if OnReport is set.
+	OnReport func(ctx context.Context, crash WebviewCrash) `json:"-"`
+}
+
+func processWebviewRecovery(appoptions *App) {
+	recovery := appoptions.WebviewRecovery
+	if recovery == nil {
+		return
+	}
+	if recovery.MaxRecoveries <= 0 {
+		recovery.MaxRecoveries = 3
+	}
+	if recovery.FallbackHTML == "" {
+		recovery.FallbackHTML = defaultFallbackHTML
+	}
+	if recovery.Policy == "" {
+		recovery.Policy = WebviewRecoveryReload
+	}
+	if recovery.UnresponsiveTimeout <= 0 {
+		recovery.UnresponsiveTimeout = 10 * time.Second
+	}
+	panel := &recovery.ErrorPanel
+	if panel.Title == "" {
+		panel.Title = "The window stopped working"
+	}
+	if panel.Message == "" {
+		panel.Message = "The content of this window stopped working and has to be reloaded."
+	}
+	if panel.ReloadButton == "" {
+		panel.ReloadButton = "Reload"
+	}
+	if panel.ReportButton == "" {
+		panel.ReportButton = "Report"
+	}
+}
@@ -0,0 +1,48 @@
+package options
+
+import "crypto/x509"
+
+// TLS customises the TLS decisions of the webview, EG: for intranet servers with self-signed certificates or
+// servers that require client certificates. The callbacks are called on the main thread and the webview waits for
+// their decision, so they should return quickly.
+type TLS struct {
+	// TrustedCertificates are trusted for the hosts in addition to the certificates of the system. The keys are host
+	// names, EG: "intranet.example.com". The certificate of the server has to be one of them or be issued by one of
+	// them, EG: by the CA of the intranet.
+	TrustedCertificates map[string][]*x509.Certificate
+
+	// OnServerTrust is called for the certificates of servers that are neither trusted by the system nor by
+	// TrustedCertificates. Returning true accepts the certificate for the rest of the session.
+	OnServerTrust func(request ServerTrustRequest) bool
+
+	// OnClientCertificate is called when a server requests a client certificate. Returning nil continues with the
+	// default handling of the platform. Client certificates require WebKitGTK 2.34 on Linux.
+	OnClientCertificate func(request ClientCertificateRequest) *ClientCertificate
+}
+
+// ServerTrustRequest is the request to trust the certificate of a server
+type ServerTrustRequest struct {
+	Host string
+	// Chain starts with the certificate of the server
+	Chain []*x509.Certificate
+}
+
+// ClientCertificateRequest is the request of a server for a client certificate
+type ClientCertificateRequest struct {
+	Host string
+	Port int
+	// Installed are the certificates of the certificate store of the user that the server accepts. It is only set on
+	// Windows, where the webview can only use installed certificates.
+	Installed []*x509.Certificate
+}
+
+// ClientCertificate is the certificate that is presented to the server
+type ClientCertificate struct {
+	// PKCS12 contains the certificate, its chain and its private key, EG: the content of a .p12 or .pfx file. On
+	// Windows, the certificate has to be installed in the certificate store of the user as well.
+	PKCS12   []byte
+	Password string
+
+	// Certificate selects one of the Installed certificates of the request on Windows instead of PKCS12
+	Certificate *x509.Certificate
+}
@@ -0,0 +1,28 @@
+package options
+
+// UserContentInjectionTime defines when user content is injected into a page
+type UserContentInjectionTime int
+
+const (
+	// InjectAtDocumentStart injects the content before any script of the page runs
+	InjectAtDocumentStart UserContentInjectionTime = iota
+	// InjectAtDocumentEnd injects the content after the document has been parsed, but before subresources like
+	// images have been loaded
+	InjectAtDocumentEnd
+)
+
+// UserContent is a user script or a stylesheet that is injected into every page loaded by the window, until it is
+// removed again. Exactly one of Script and Stylesheet must be set.
+type UserContent struct {
+	// Script is the source of the JavaScript to inject
+	Script string
+
+	// Stylesheet is the CSS to inject
+	Stylesheet string
+
+	// InjectionTime defines when the content is injected. Defaults to InjectAtDocumentStart
+	InjectionTime UserContentInjectionTime
+
+	// AllFrames injects the content into all frames of the page instead of the main frame only
+	AllFrames bool
+}
@@ -0,0 +1,12 @@
+package options
+
+// UserScript is injected into every page of the window before any script of the page runs, EG: for feature flags,
+// nonce values and polyfills
+type UserScript struct {
+	// Globals are defined as properties of window, EG: "FEATURE_FLAGS" is available as window.FEATURE_FLAGS.
+	// The values are marshalled as JSON.
+	Globals map[string]interface{}
+
+	// Script is executed after the Globals have been defined
+	Script string
+}
@@ -0,0 +1,18 @@
+package options
+
+// VideoPlayback contains the options for windows that mainly play videos, EG: media players. They let the compositor
+// of the operating system present the videos with less work of the GPU, which reduces the power used to play them.
+type VideoPlayback struct {
+	// HardwareOverlays presents the videos with the overlay planes of the GPU, which scan out the video without
+	// composing it with the rest of the window. On Windows, WebView2 uses DirectComposition overlays and underlays
+	// for the videos, which can cause artefacts with some drivers. On macOS the window and the webview are opaque,
+	// as WindowServer only hands opaque layers directly to the display, so WindowIsTranslucent and
+	// WebviewIsTransparent are ignored. It is ignored on Linux.
+	HardwareOverlays bool
+
+	// FullscreenOptimizations lets the fullscreen window bypass the composition of the desktop. On Windows, the
+	// translucent backdrop is removed while the window is fullscreen, so DWM can flip the frames of the window
+	// independently. On macOS, the elements of the page, EG: videos, can go fullscreen with the Fullscreen API
+	// unless Preferences.FullscreenEnabled disables it. It is ignored on Linux.
+	FullscreenOptimizations bool
+}
@@ -0,0 +1,19 @@
+package options
+
+// WebviewProfile selects where the webview stores its cookies, local storage, IndexedDB and caches, EG: to isolate
+// the sessions of several accounts. Without it, the platform defaults remain. The data of the profile can be managed
+// with runtime.CookiesGet, runtime.CookieSet, runtime.CookieDelete and runtime.SiteDataClear.
+type WebviewProfile struct {
+	// Name stores the data in the `profiles/<Name>` directory of the application data directory. It may only contain
+	// letters, digits, '-', '_' and '.'.
+	Name string
+
+	// Directory stores the data in the directory instead (optional). On macOS, WebKit manages the location of the
+	// data and the directory only identifies the profile. Profiles other than InMemory require macOS 14 and use the
+	// default data store on older versions.
+	Directory string
+
+	// InMemory doesn't persist any data, the data is discarded when the application quits. On Windows, the data is
+	// stored in a temporary directory that is removed when the application quits.
+	InMemory bool
+}
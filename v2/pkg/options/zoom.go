@@ -0,0 +1,29 @@
+package options
+
+// Zoom contains the options for zooming the page of the window, see runtime.WindowSetZoom
+type Zoom struct {
+	// Shortcuts zooms in with Ctrl/Cmd and = or +, out with Ctrl/Cmd and - and resets the zoom with Ctrl/Cmd and 0
+	Shortcuts bool
+	// Persist saves the zoom factor in the application data directory when it changes and restores it at the next
+	// launch
+	Persist bool
+	// Minimum and Maximum limit the zoom factor. Default: 0.25 and 5
+	Minimum float64
+	Maximum float64
+}
+
+func processZoom(appoptions *App) {
+	zoom := appoptions.Zoom
+	if zoom == nil {
+		return
+	}
+	if zoom.Minimum <= 0 {
+		zoom.Minimum = 0.25
+	}
+	if zoom.Maximum <= 0 {
+		zoom.Maximum = 5
+	}
+	if zoom.Maximum < zoom.Minimum {
+		zoom.Maximum = zoom.Minimum
+	}
+}
@@ -0,0 +1,72 @@
+// Package pagination lets bound methods return large result sets in pages instead of one large JSON response.
+//
+// A bound method that returns a Page takes the cursor of the page as its last argument, which is empty for the first
+// page. The generated bindings make the cursor optional and return the page with next(), all() and an async
+// iterator, which fetch the following pages lazily:
+//
+//	func (a *App) Customers(filter string, cursor string) (pagination.Page[Customer], error) {
+//		return pagination.Slice(a.findCustomers(filter), cursor, 500)
+//	}
+//
+//	for await (const customer of await Customers("berlin")) { ... }
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+)
+
+// ErrInvalidCursor is returned for cursors that haven't been created by Offset
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+// Page is a page of a result set
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor is the cursor of the next page. It is empty for the last page.
+	NextCursor string `json:"nextCursor"`
+	// Total is the number of items of the result set, or -1 if it is unknown
+	Total int `json:"total"`
+}
+
+// Offset returns an opaque cursor for the offset of the item in the result set
+func Offset(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+// ParseOffset returns the offset of a cursor of Offset. The empty cursor is the offset 0.
+func ParseOffset(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(decoded) < len("offset:") || string(decoded[:len("offset:")]) != "offset:" {
+		return 0, ErrInvalidCursor
+	}
+	offset, err := strconv.Atoi(string(decoded[len("offset:"):]))
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
+
+// Slice returns the page of the items at the cursor with up to size items
+func Slice[T any](items []T, cursor string, size int) (Page[T], error) {
+	if size <= 0 {
+		return Page[T]{}, errors.New("pagination: the size of pages must be positive")
+	}
+	offset, err := ParseOffset(cursor)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	offset = min(offset, len(items))
+	end := min(offset+size, len(items))
+	page := Page[T]{Items: items[offset:end], Total: len(items)}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if end < len(items) {
+		page.NextCursor = Offset(end)
+	}
+	return page, nil
+}
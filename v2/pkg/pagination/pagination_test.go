@@ -0,0 +1,43 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var all []int
+	cursor := ""
+	for pages := 0; ; pages++ {
+		page, err := Slice(items, cursor, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Total != 5 {
+			t.Errorf("expected a total of 5, got %d", page.Total)
+		}
+		all = append(all, page.Items...)
+		if page.NextCursor == "" {
+			if pages != 2 {
+				t.Errorf("expected 3 pages, got %d", pages+1)
+			}
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if len(all) != 5 || all[0] != 1 || all[4] != 5 {
+		t.Errorf("unexpected items %v", all)
+	}
+
+	page, err := Slice(items, Offset(10), 2)
+	if err != nil || len(page.Items) != 0 || page.Items == nil || page.NextCursor != "" {
+		t.Errorf("expected an empty last page, got %+v, %v", page, err)
+	}
+	if _, err := Slice(items, "garbage", 2); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := ParseOffset(Offset(-1)); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor for a negative offset, got %v", err)
+	}
+}
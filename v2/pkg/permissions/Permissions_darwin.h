@@ -0,0 +1,21 @@
+#ifndef Permissions_darwin_h
+#define Permissions_darwin_h
+
+// The kinds and the statuses of the permissions
+#define PermissionNotifications   0
+#define PermissionCamera          1
+#define PermissionMicrophone      2
+#define PermissionScreenRecording 3
+#define PermissionAccessibility   4
+
+#define PermissionUndetermined 0
+#define PermissionGranted      1
+#define PermissionDenied       2
+#define PermissionRestricted   3
+// PermissionNoBundle is returned for notifications when the application isn't an app bundle
+#define PermissionNoBundle     -1
+
+int PermissionsCheck(int kind);
+int PermissionsRequest(int kind);
+
+#endif /* Permissions_darwin_h */
@@ -0,0 +1,118 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AVFoundation/AVFoundation.h>
+#import <ApplicationServices/ApplicationServices.h>
+#import <UserNotifications/UserNotifications.h>
+
+#import "Permissions_darwin.h"
+
+static int captureStatus(AVMediaType mediaType) {
+    switch ([AVCaptureDevice authorizationStatusForMediaType:mediaType]) {
+    case AVAuthorizationStatusAuthorized:
+        return PermissionGranted;
+    case AVAuthorizationStatusDenied:
+        return PermissionDenied;
+    case AVAuthorizationStatusRestricted:
+        return PermissionRestricted;
+    default:
+        return PermissionUndetermined;
+    }
+}
+
+// requestCapture asks with the NSCameraUsageDescription or NSMicrophoneUsageDescription of Info.plist
+static int requestCapture(AVMediaType mediaType) {
+    dispatch_semaphore_t answered = dispatch_semaphore_create(0);
+    [AVCaptureDevice requestAccessForMediaType:mediaType completionHandler:^(BOOL granted) {
+        dispatch_semaphore_signal(answered);
+    }];
+    dispatch_semaphore_wait(answered, DISPATCH_TIME_FOREVER);
+    dispatch_release(answered);
+    return captureStatus(mediaType);
+}
+
+// UNUserNotificationCenter raises an exception in processes without bundle identifier, EG: during wails dev
+static bool hasBundle(void) {
+    return [[NSBundle mainBundle] bundleIdentifier] != nil;
+}
+
+static int notificationStatus(void) {
+    if (!hasBundle()) {
+        return PermissionNoBundle;
+    }
+    dispatch_semaphore_t checked = dispatch_semaphore_create(0);
+    __block UNAuthorizationStatus status;
+    [[UNUserNotificationCenter currentNotificationCenter] getNotificationSettingsWithCompletionHandler:^(UNNotificationSettings *settings) {
+        status = settings.authorizationStatus;
+        dispatch_semaphore_signal(checked);
+    }];
+    dispatch_semaphore_wait(checked, DISPATCH_TIME_FOREVER);
+    dispatch_release(checked);
+    switch (status) {
+    case UNAuthorizationStatusNotDetermined:
+        return PermissionUndetermined;
+    case UNAuthorizationStatusDenied:
+        return PermissionDenied;
+    default:
+        // Provisional notifications are delivered quietly
+        return PermissionGranted;
+    }
+}
+
+static int requestNotifications(void) {
+    if (!hasBundle()) {
+        return PermissionNoBundle;
+    }
+    dispatch_semaphore_t answered = dispatch_semaphore_create(0);
+    UNAuthorizationOptions options = UNAuthorizationOptionAlert | UNAuthorizationOptionSound | UNAuthorizationOptionBadge;
+    [[UNUserNotificationCenter currentNotificationCenter] requestAuthorizationWithOptions:options completionHandler:^(BOOL granted, NSError *error) {
+        dispatch_semaphore_signal(answered);
+    }];
+    dispatch_semaphore_wait(answered, DISPATCH_TIME_FOREVER);
+    dispatch_release(answered);
+    return notificationStatus();
+}
+
+// macOS doesn't report if the user has been asked for screen recording and accessibility, so they are denied until
+// they are granted
+int PermissionsCheck(int kind) {
+    switch (kind) {
+    case PermissionNotifications:
+        return notificationStatus();
+    case PermissionCamera:
+        return captureStatus(AVMediaTypeVideo);
+    case PermissionMicrophone:
+        return captureStatus(AVMediaTypeAudio);
+    case PermissionScreenRecording:
+        if (@available(macOS 10.15, *)) {
+            return CGPreflightScreenCaptureAccess() ? PermissionGranted : PermissionDenied;
+        }
+        return PermissionGranted;
+    case PermissionAccessibility:
+        return AXIsProcessTrusted() ? PermissionGranted : PermissionDenied;
+    }
+    return PermissionGranted;
+}
+
+// PermissionsRequest asks the user. The prompts for screen recording and accessibility are only shown the first time
+// and return before the user answers.
+int PermissionsRequest(int kind) {
+    switch (kind) {
+    case PermissionNotifications:
+        return requestNotifications();
+    case PermissionCamera:
+        return requestCapture(AVMediaTypeVideo);
+    case PermissionMicrophone:
+        return requestCapture(AVMediaTypeAudio);
+    case PermissionScreenRecording:
+        if (@available(macOS 10.15, *)) {
+            return CGRequestScreenCaptureAccess() ? PermissionGranted : PermissionDenied;
+        }
+        return PermissionGranted;
+    case PermissionAccessibility: {
+        NSDictionary *options = @{(id)kAXTrustedCheckOptionPrompt: @YES};
+        return AXIsProcessTrustedWithOptions((CFDictionaryRef)options) ? PermissionGranted : PermissionDenied;
+    }
+    }
+    return PermissionGranted;
+}
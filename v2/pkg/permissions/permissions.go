@@ -0,0 +1,136 @@
+// Package permissions checks and requests the permissions of the operating system that an application needs, EG: for
+// an onboarding screen, and links to their settings panes. The permissions are reported with the same statuses on
+// every platform: the privacy settings of macOS, the privacy settings of Windows and the portals of Flatpak on Linux.
+// Bind the Permissions service to use it from JS.
+package permissions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pkg/browser"
+)
+
+// Kind is a permission of the operating system
+type Kind string
+
+const (
+	Notifications Kind = "notifications"
+	Camera        Kind = "camera"
+	Microphone    Kind = "microphone"
+	// ScreenRecording is needed to capture the screen or other windows
+	ScreenRecording Kind = "screenRecording"
+	// Accessibility is needed to control other applications and to observe input, EG: for global shortcuts. Only
+	// macOS has it.
+	Accessibility Kind = "accessibility"
+)
+
+// Kinds are all permissions
+var Kinds = []Kind{Notifications, Camera, Microphone, ScreenRecording, Accessibility}
+
+// Status is the status of a permission. Permissions that the platform doesn't restrict are granted.
+type Status string
+
+const (
+	Granted Status = "granted"
+	Denied  Status = "denied"
+	// Undetermined means that the user hasn't been asked yet, so Request asks
+	Undetermined Status = "undetermined"
+	// Restricted means that a policy or the administrator denies the permission, which the user can't change
+	Restricted Status = "restricted"
+)
+
+var (
+	// ErrUnknownKind is returned for kinds that aren't in Kinds
+	ErrUnknownKind = errors.New("permissions: unknown kind")
+	// ErrNoSettings is returned by OpenSettings when the platform has no settings pane for the permission
+	ErrNoSettings = errors.New("permissions: no settings for the permission")
+)
+
+// Result is the status of a permission
+type Result struct {
+	Kind   Kind   `json:"kind"`
+	Status Status `json:"status"`
+	// SettingsURL opens the settings pane of the permission, empty if there is none
+	SettingsURL string `json:"settingsUrl"`
+}
+
+type backend interface {
+	check(kind Kind, appID string) (Status, error)
+	// request asks the user for the undetermined permission and returns the answer
+	request(kind Kind, appID string) (Status, error)
+	settingsURL(kind Kind) string
+}
+
+// openURL opens the settings panes
+var openURL = browser.OpenURL
+
+// Permissions is the permissions service
+type Permissions struct {
+	// AppID is the AppUserModelID of the notifications on Windows, EG: "Company.App". The notifications of the
+	// application are only reported as denied if the user turned off all notifications when it is empty.
+	AppID string
+
+	backend backend
+}
+
+// New creates the permissions service
+func New() *Permissions {
+	return &Permissions{backend: newBackend()}
+}
+
+func validKind(kind Kind) error {
+	for _, known := range Kinds {
+		if kind == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+}
+
+// Check returns the status of the permission without asking the user
+func (p *Permissions) Check(kind Kind) (Status, error) {
+	if err := validKind(kind); err != nil {
+		return "", err
+	}
+	return p.backend.check(kind, p.AppID)
+}
+
+// Request asks the user for the permission if it is undetermined and returns its status. Permissions that have been
+// denied can only be granted in the settings.
+func (p *Permissions) Request(kind Kind) (Status, error) {
+	status, err := p.Check(kind)
+	if err != nil || status != Undetermined {
+		return status, err
+	}
+	return p.backend.request(kind, p.AppID)
+}
+
+// Preflight checks the permissions, all of them if kinds is empty, and asks the user for the undetermined ones one
+// after another
+func (p *Permissions) Preflight(kinds []Kind) ([]Result, error) {
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	results := []Result{}
+	for _, kind := range kinds {
+		status, err := p.Request(kind)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, Result{Kind: kind, Status: status, SettingsURL: p.backend.settingsURL(kind)})
+	}
+	return results, nil
+}
+
+// OpenSettings opens the settings pane of the permission, EG: to grant a denied permission
+func (p *Permissions) OpenSettings(kind Kind) error {
+	if err := validKind(kind); err != nil {
+		return err
+	}
+	url := p.backend.settingsURL(kind)
+	if url == "" {
+		return ErrNoSettings
+	}
+	return openURL(url)
+}
@@ -0,0 +1,64 @@
+//go:build darwin
+
+package permissions
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AVFoundation -framework ApplicationServices -framework CoreGraphics -weak_framework UserNotifications
+
+#import "Permissions_darwin.h"
+*/
+import "C"
+
+import "errors"
+
+var kindIDs = map[Kind]C.int{
+	Notifications:   C.PermissionNotifications,
+	Camera:          C.PermissionCamera,
+	Microphone:      C.PermissionMicrophone,
+	ScreenRecording: C.PermissionScreenRecording,
+	Accessibility:   C.PermissionAccessibility,
+}
+
+// settingsURLs are the panes of System Settings
+var settingsURLs = map[Kind]string{
+	Notifications:   "x-apple.systempreferences:com.apple.preference.notifications",
+	Camera:          "x-apple.systempreferences:com.apple.preference.security?Privacy_Camera",
+	Microphone:      "x-apple.systempreferences:com.apple.preference.security?Privacy_Microphone",
+	ScreenRecording: "x-apple.systempreferences:com.apple.preference.security?Privacy_ScreenCapture",
+	Accessibility:   "x-apple.systempreferences:com.apple.preference.security?Privacy_Accessibility",
+}
+
+// tccBackend uses the privacy frameworks, which ask with the usage descriptions of Info.plist. Calls that ask the
+// user block until the user answers.
+type tccBackend struct{}
+
+func newBackend() backend {
+	return tccBackend{}
+}
+
+func status(result C.int) (Status, error) {
+	switch result {
+	case C.PermissionGranted:
+		return Granted, nil
+	case C.PermissionDenied:
+		return Denied, nil
+	case C.PermissionRestricted:
+		return Restricted, nil
+	case C.PermissionNoBundle:
+		return "", errors.New("permissions: notifications require an app bundle")
+	}
+	return Undetermined, nil
+}
+
+func (tccBackend) check(kind Kind, appID string) (Status, error) {
+	return status(C.PermissionsCheck(kindIDs[kind]))
+}
+
+func (tccBackend) request(kind Kind, appID string) (Status, error) {
+	return status(C.PermissionsRequest(kindIDs[kind]))
+}
+
+func (tccBackend) settingsURL(kind Kind) string {
+	return settingsURLs[kind]
+}
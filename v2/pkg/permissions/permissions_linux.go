@@ -0,0 +1,61 @@
+//go:build linux
+
+package permissions
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
+)
+
+// sandboxBackend reports the permissions of the sandbox of Flatpak. Linux doesn't restrict applications outside
+// of sandboxes and Flatpak only restricts the cameras. The portals ask for screen captures every time.
+type sandboxBackend struct {
+	flatpak bool
+
+	lock   sync.Mutex
+	camera Status
+}
+
+func newBackend() backend {
+	_, err := os.Stat("/.flatpak-info")
+	return &sandboxBackend{flatpak: err == nil, camera: Undetermined}
+}
+
+// check reports the cameras as undetermined in Flatpak until they have been requested, as the permission store of
+// the portal can't be read from the sandbox
+func (b *sandboxBackend) check(kind Kind, appID string) (Status, error) {
+	if kind == Camera && b.flatpak {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		return b.camera, nil
+	}
+	return Granted, nil
+}
+
+func (b *sandboxBackend) request(kind Kind, appID string) (Status, error) {
+	if kind != Camera || !b.flatpak {
+		return Granted, nil
+	}
+	granted, err := portal.AccessCamera()
+	if errors.Is(err, portal.ErrUnavailable) {
+		return Undetermined, err
+	}
+	if err != nil {
+		return "", err
+	}
+	status := Denied
+	if granted {
+		status = Granted
+	}
+	b.lock.Lock()
+	b.camera = status
+	b.lock.Unlock()
+	return status, nil
+}
+
+func (b *sandboxBackend) settingsURL(kind Kind) string {
+	return ""
+}
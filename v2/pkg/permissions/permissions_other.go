@@ -0,0 +1,22 @@
+//go:build !linux && !darwin && !windows
+
+package permissions
+
+// unrestrictedBackend grants everything, as the platform doesn't restrict applications
+type unrestrictedBackend struct{}
+
+func newBackend() backend {
+	return unrestrictedBackend{}
+}
+
+func (unrestrictedBackend) check(kind Kind, appID string) (Status, error) {
+	return Granted, nil
+}
+
+func (unrestrictedBackend) request(kind Kind, appID string) (Status, error) {
+	return Granted, nil
+}
+
+func (unrestrictedBackend) settingsURL(kind Kind) string {
+	return ""
+}
@@ -0,0 +1,97 @@
+package permissions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeBackend grants the requested permissions and records the requests
+type fakeBackend struct {
+	statuses  map[Kind]Status
+	requested []Kind
+}
+
+func (f *fakeBackend) check(kind Kind, appID string) (Status, error) {
+	return f.statuses[kind], nil
+}
+
+func (f *fakeBackend) request(kind Kind, appID string) (Status, error) {
+	f.requested = append(f.requested, kind)
+	f.statuses[kind] = Granted
+	return Granted, nil
+}
+
+func (f *fakeBackend) settingsURL(kind Kind) string {
+	if kind == Accessibility {
+		return ""
+	}
+	return "settings:" + string(kind)
+}
+
+func newFake() (*Permissions, *fakeBackend) {
+	fake := &fakeBackend{statuses: map[Kind]Status{
+		Notifications:   Granted,
+		Camera:          Undetermined,
+		Microphone:      Denied,
+		ScreenRecording: Undetermined,
+		Accessibility:   Granted,
+	}}
+	return &Permissions{backend: fake}, fake
+}
+
+func TestRequest(t *testing.T) {
+	p, fake := newFake()
+	if _, err := p.Check("location"); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("Check() error = %v, want ErrUnknownKind", err)
+	}
+	// Denied permissions aren't requested again
+	if status, err := p.Request(Microphone); err != nil || status != Denied {
+		t.Errorf("Request() = %v, %v", status, err)
+	}
+	if status, err := p.Request(Camera); err != nil || status != Granted {
+		t.Errorf("Request() = %v, %v", status, err)
+	}
+	if !reflect.DeepEqual(fake.requested, []Kind{Camera}) {
+		t.Errorf("requested %v", fake.requested)
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	p, fake := newFake()
+	results, err := p.Preflight(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Result{
+		{Kind: Notifications, Status: Granted, SettingsURL: "settings:notifications"},
+		{Kind: Camera, Status: Granted, SettingsURL: "settings:camera"},
+		{Kind: Microphone, Status: Denied, SettingsURL: "settings:microphone"},
+		{Kind: ScreenRecording, Status: Granted, SettingsURL: "settings:screenRecording"},
+		{Kind: Accessibility, Status: Granted},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Preflight() = %v, want %v", results, want)
+	}
+	if !reflect.DeepEqual(fake.requested, []Kind{Camera, ScreenRecording}) {
+		t.Errorf("requested %v", fake.requested)
+	}
+}
+
+func TestOpenSettings(t *testing.T) {
+	p, _ := newFake()
+	var opened []string
+	openURL = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	if err := p.OpenSettings(Microphone); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OpenSettings(Accessibility); !errors.Is(err, ErrNoSettings) {
+		t.Errorf("OpenSettings() error = %v, want ErrNoSettings", err)
+	}
+	if !reflect.DeepEqual(opened, []string{"settings:microphone"}) {
+		t.Errorf("opened %v", opened)
+	}
+}
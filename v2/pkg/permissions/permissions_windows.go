@@ -0,0 +1,100 @@
+//go:build windows
+
+package permissions
+
+import (
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	consentStorePath = `Software\Microsoft\Windows\CurrentVersion\CapabilityAccessManager\ConsentStore\`
+	policyPath       = `Software\Policies\Microsoft\Windows\AppPrivacy`
+
+	// forceDeny is the value of the app privacy policies that denies the capability
+	forceDeny = 2
+)
+
+// capabilities are the capabilities of the consent store and the names of their app privacy policies
+var capabilities = map[Kind][2]string{
+	Camera:          {"webcam", "LetAppsAccessCamera"},
+	Microphone:      {"microphone", "LetAppsAccessMicrophone"},
+	ScreenRecording: {"graphicsCaptureProgrammatic", "LetAppsAccessGraphicsCaptureProgrammatic"},
+}
+
+// settingsURLs are the pages of the Settings app
+var settingsURLs = map[Kind]string{
+	Notifications:   "ms-settings:notifications",
+	Camera:          "ms-settings:privacy-webcam",
+	Microphone:      "ms-settings:privacy-microphone",
+	ScreenRecording: "ms-settings:privacy-graphicsCaptureProgrammatic",
+}
+
+// registryBackend reads the privacy settings from the registry. Windows doesn't ask desktop applications for
+// permissions, so they are never undetermined.
+type registryBackend struct{}
+
+func newBackend() backend {
+	return registryBackend{}
+}
+
+func (registryBackend) check(kind Kind, appID string) (Status, error) {
+	switch kind {
+	case Notifications:
+		if readDWORD(registry.CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\PushNotifications`, "ToastEnabled") == 0 {
+			return Denied, nil
+		}
+		if appID != "" && readDWORD(registry.CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\Notifications\Settings\`+appID, "Enabled") == 0 {
+			return Denied, nil
+		}
+		return Granted, nil
+	case Accessibility:
+		return Granted, nil
+	}
+
+	capability := capabilities[kind]
+	// The toggles for the device and the policies of the administrator can't be changed by the user
+	if readDWORD(registry.LOCAL_MACHINE, policyPath, capability[1]) == forceDeny || readString(registry.LOCAL_MACHINE, consentStorePath+capability[0], "Value") == "Deny" {
+		return Restricted, nil
+	}
+	// The access of desktop applications is a separate toggle
+	if readString(registry.CURRENT_USER, consentStorePath+capability[0], "Value") == "Deny" ||
+		readString(registry.CURRENT_USER, consentStorePath+capability[0]+`\NonPackaged`, "Value") == "Deny" {
+		return Denied, nil
+	}
+	return Granted, nil
+}
+
+func (b registryBackend) request(kind Kind, appID string) (Status, error) {
+	return b.check(kind, appID)
+}
+
+func (registryBackend) settingsURL(kind Kind) string {
+	return settingsURLs[kind]
+}
+
+// readDWORD returns the value or -1 if it doesn't exist
+func readDWORD(root registry.Key, path string, name string) int64 {
+	key, err := registry.OpenKey(root, path, registry.QUERY_VALUE)
+	if err != nil {
+		return -1
+	}
+	defer key.Close()
+	value, _, err := key.GetIntegerValue(name)
+	if err != nil {
+		return -1
+	}
+	return int64(value)
+}
+
+func readString(root registry.Key, path string, name string) string {
+	key, err := registry.OpenKey(root, path, registry.QUERY_VALUE)
+	if err != nil {
+		return ""
+	}
+	defer key.Close()
+	value, _, err := key.GetStringValue(name)
+	if err != nil {
+		return ""
+	}
+	return value
+}
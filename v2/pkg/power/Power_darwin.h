@@ -0,0 +1,12 @@
+#ifndef Power_darwin_h
+#define Power_darwin_h
+
+#include <stdbool.h>
+
+void PowerStatus(bool *onBattery, int *battery);
+void* PowerWatch(int watcher);
+void PowerStopWatching(void *watch);
+bool PowerLock(bool display, const char *reason, unsigned int *assertion);
+void PowerUnlock(unsigned int assertion);
+
+#endif /* Power_darwin_h */
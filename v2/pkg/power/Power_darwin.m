@@ -0,0 +1,110 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AppKit/AppKit.h>
+#import <IOKit/ps/IOPowerSources.h>
+#import <IOKit/ps/IOPSKeys.h>
+#import <IOKit/pwr_mgt/IOPMLib.h>
+#include <math.h>
+#include <stdlib.h>
+
+#import "Power_darwin.h"
+
+extern void processPowerEvent(int watcher, char *event);
+
+void PowerStatus(bool *onBattery, int *battery) {
+    *onBattery = false;
+    *battery = -1;
+    CFTypeRef info = IOPSCopyPowerSourcesInfo();
+    if (info == NULL) {
+        return;
+    }
+    CFStringRef source = IOPSGetProvidingPowerSourceType(info);
+    if (source != NULL) {
+        *onBattery = CFStringCompare(source, CFSTR(kIOPSBatteryPowerValue), 0) == kCFCompareEqualTo;
+    }
+    CFArrayRef sources = IOPSCopyPowerSourcesList(info);
+    if (sources != NULL) {
+        for (CFIndex index = 0; index < CFArrayGetCount(sources); index++) {
+            NSDictionary *description = (NSDictionary*)IOPSGetPowerSourceDescription(info, CFArrayGetValueAtIndex(sources, index));
+            if (description == nil || ![description[@kIOPSTypeKey] isEqualToString:@kIOPSInternalBatteryType]) {
+                continue;
+            }
+            NSNumber *current = description[@kIOPSCurrentCapacityKey];
+            NSNumber *max = description[@kIOPSMaxCapacityKey];
+            if (current != nil && max != nil && [max doubleValue] > 0) {
+                *battery = (int)lround([current doubleValue] * 100.0 / [max doubleValue]);
+            }
+            break;
+        }
+        CFRelease(sources);
+    }
+    CFRelease(info);
+}
+
+typedef struct {
+    NSMutableArray *observers;
+    CFRunLoopSourceRef source;
+} PowerWatchContext;
+
+static void powerSourcesChanged(void *context) {
+    processPowerEvent((int)(intptr_t)context, "status");
+}
+
+// PowerWatch observes the sleep and power off notifications of NSWorkspace and the changes of the power sources for
+// the watcher. It returns the watch, which is removed by PowerStopWatching.
+void* PowerWatch(int watcher) {
+    PowerWatchContext *watch = calloc(1, sizeof(PowerWatchContext));
+    watch->observers = [NSMutableArray new];
+    @autoreleasepool {
+        NSNotificationCenter *center = [[NSWorkspace sharedWorkspace] notificationCenter];
+        NSDictionary *notifications = @{
+            NSWorkspaceWillSleepNotification: @"suspending",
+            NSWorkspaceDidWakeNotification: @"resumed",
+            NSWorkspaceWillPowerOffNotification: @"shutdown",
+        };
+        for (NSString *name in notifications) {
+            NSString *event = notifications[name];
+            [watch->observers addObject:[center addObserverForName:name object:nil queue:nil usingBlock:^(NSNotification *notification) {
+                processPowerEvent(watcher, (char*)[event UTF8String]);
+            }]];
+        }
+    }
+    watch->source = IOPSNotificationCreateRunLoopSource(powerSourcesChanged, (void*)(intptr_t)watcher);
+    if (watch->source != NULL) {
+        CFRunLoopAddSource(CFRunLoopGetMain(), watch->source, kCFRunLoopDefaultMode);
+    }
+    return watch;
+}
+
+void PowerStopWatching(void *context) {
+    PowerWatchContext *watch = (PowerWatchContext*)context;
+    NSNotificationCenter *center = [[NSWorkspace sharedWorkspace] notificationCenter];
+    for (id observer in watch->observers) {
+        [center removeObserver:observer];
+    }
+    [watch->observers release];
+    if (watch->source != NULL) {
+        CFRunLoopRemoveSource(CFRunLoopGetMain(), watch->source, kCFRunLoopDefaultMode);
+        CFRelease(watch->source);
+    }
+    free(watch);
+}
+
+// PowerLock creates an assertion that keeps the computer or the display awake, the reason is shown by `pmset -g assertions`
+bool PowerLock(bool display, const char *reason, unsigned int *assertion) {
+    CFStringRef type = display ? kIOPMAssertionTypePreventUserIdleDisplaySleep : kIOPMAssertionTypePreventUserIdleSystemSleep;
+    CFStringRef name = CFStringCreateWithCString(NULL, reason, kCFStringEncodingUTF8);
+    IOPMAssertionID id;
+    IOReturn result = IOPMAssertionCreateWithName(type, kIOPMAssertionLevelOn, name, &id);
+    CFRelease(name);
+    if (result != kIOReturnSuccess) {
+        return false;
+    }
+    *assertion = id;
+    return true;
+}
+
+void PowerUnlock(unsigned int assertion) {
+    IOPMAssertionRelease(assertion);
+}
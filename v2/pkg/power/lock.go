@@ -0,0 +1,32 @@
+package power
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// LockKind is what a lock keeps awake
+type LockKind int
+
+const (
+	// PreventSleep keeps the computer from sleeping when it is idle, the display may still turn off
+	PreventSleep LockKind = iota
+	// PreventDisplaySleep keeps the display on and the computer from sleeping when it is idle, EG: while playing a video
+	PreventDisplaySleep
+)
+
+// AcquireLock keeps the computer awake until release is called, EG: during a long upload. The reason is shown by the
+// platforms that list the locks, EG: by `systemd-inhibit --list`. The computer still sleeps when the user asks for it,
+// EG: by closing the lid.
+func AcquireLock(kind LockKind, reason string) (release func(), err error) {
+	if reason == "" {
+		reason = "Keeping the computer awake"
+	}
+	unlock, err := acquireLock(kind, filepath.Base(os.Args[0]), reason)
+	if err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func() { once.Do(unlock) }, nil
+}
@@ -0,0 +1,24 @@
+//go:build darwin
+
+package power
+
+/*
+#import "Power_darwin.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+func acquireLock(kind LockKind, name string, reason string) (func(), error) {
+	creason := C.CString(reason)
+	defer C.free(unsafe.Pointer(creason))
+	var assertion C.uint
+	if !C.PowerLock(C.bool(kind == PreventDisplaySleep), creason, &assertion) {
+		return nil, errors.New("creating the power assertion failed")
+	}
+	return func() { C.PowerUnlock(assertion) }, nil
+}
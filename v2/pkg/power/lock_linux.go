@@ -0,0 +1,71 @@
+//go:build linux
+
+package power
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	portalDestination = "org.freedesktop.portal.Desktop"
+	portalPath        = "/org/freedesktop/portal/desktop"
+
+	inhibitSuspend = 4
+	inhibitIdle    = 8
+)
+
+// acquireLock inhibits with the Inhibit portal, which also works in sandboxes and keeps the screen on, and with a
+// block inhibitor lock of logind where there is no portal
+func acquireLock(kind LockKind, name string, reason string) (func(), error) {
+	if release, err := portalInhibit(kind, reason); err == nil {
+		return release, nil
+	}
+	return logindInhibit(kind, name, reason)
+}
+
+// portalInhibit inhibits until the request is closed, the portal ends it when the connection of the session bus closes
+func portalInhibit(kind LockKind, reason string) (func(), error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, err
+	}
+	flags := uint32(inhibitSuspend)
+	if kind == PreventDisplaySleep {
+		flags |= inhibitIdle
+	}
+	var request dbus.ObjectPath
+	err = conn.Object(portalDestination, portalPath).
+		Call("org.freedesktop.portal.Inhibit.Inhibit", 0, "", flags, map[string]dbus.Variant{"reason": dbus.MakeVariant(reason)}).
+		Store(&request)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		conn.Object(portalDestination, request).Call("org.freedesktop.portal.Request.Close", 0)
+	}, nil
+}
+
+// logindInhibit takes a block inhibitor lock that is released when its file is closed. Most desktops don't honour the
+// idle lock of logind, so the display may still turn off.
+func logindInhibit(kind LockKind, name string, reason string) (func(), error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	what := "sleep"
+	if kind == PreventDisplaySleep {
+		what = "sleep:idle"
+	}
+	var fd dbus.UnixFD
+	err = conn.Object(login1Destination, login1Path).
+		Call(managerInterface+".Inhibit", 0, what, name, reason, "block").
+		Store(&fd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	file := os.NewFile(uintptr(fd), "inhibitor")
+	return func() { file.Close() }, nil
+}
@@ -0,0 +1,42 @@
+//go:build windows
+
+package power
+
+import (
+	"fmt"
+	"runtime"
+)
+
+const (
+	esSystemRequired  = 0x00000001
+	esDisplayRequired = 0x00000002
+	esContinuous      = 0x80000000
+)
+
+var procSetThreadExecutionState = kernel32.NewProc("SetThreadExecutionState")
+
+// acquireLock sets the execution state of a thread that is kept until the release, as the state belongs to the thread
+// and ends with it. Windows doesn't show the reason.
+func acquireLock(kind LockKind, name string, reason string) (func(), error) {
+	state := uintptr(esContinuous | esSystemRequired)
+	if kind == PreventDisplaySleep {
+		state |= esDisplayRequired
+	}
+	errs := make(chan error)
+	release := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		if result, _, err := procSetThreadExecutionState.Call(state); result == 0 {
+			errs <- fmt.Errorf("setting the execution state failed: %w", err)
+			return
+		}
+		errs <- nil
+		<-release
+		procSetThreadExecutionState.Call(uintptr(esContinuous))
+	}()
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return func() { close(release) }, nil
+}
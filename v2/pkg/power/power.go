@@ -0,0 +1,210 @@
+// Package power notices the power events of the computer: when it is about to sleep and when it has woken up, when it
+// switches between the AC adapter and the battery, when the battery is low and when the session is about to end, EG:
+// to pause work before the computer sleeps and to reconnect after it has woken up. The changes of the session, EG:
+// when it is locked, are reported by the session package. Bind the Power service to use it from JS. AcquireLock keeps
+// the computer awake, EG: during a long upload.
+package power
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ChangeEvent is emitted by EmitEvents with the Change and the Status after the change
+const ChangeEvent = "power:change"
+
+// Change is a power event of the computer
+type Change string
+
+const (
+	// Suspending is reported before the computer sleeps. Work should be paused before OnChange returns.
+	Suspending Change = "suspending"
+	// Resumed is reported after the computer has woken up
+	Resumed Change = "resumed"
+	// OnBattery is reported when the computer switches to the battery
+	OnBattery Change = "onbattery"
+	// OnAC is reported when the computer switches to the AC adapter
+	OnAC Change = "onac"
+	// BatteryLow is reported when the charge of the battery drops below LowBattery while on battery
+	BatteryLow Change = "batterylow"
+	// ShuttingDown is reported before the session ends, EG: on shutdown or log out, before OnShutdown is called
+	ShuttingDown Change = "shuttingdown"
+)
+
+// ErrNotSupported is returned when the platform doesn't report the power events or doesn't grant the locks, EG: on
+// Linux without logind
+var ErrNotSupported = errors.New("power: not supported")
+
+// defaultLowBattery is the default charge in percent below which the battery is low
+const defaultLowBattery = 10
+
+// Status is the power status of the computer
+type Status struct {
+	// OnBattery is true while the computer runs on battery
+	OnBattery bool `json:"onBattery"`
+	// Battery is the charge of the battery in percent, -1 for computers without battery
+	Battery int `json:"battery"`
+}
+
+// callbacks are the events that are reported by the backends
+type callbacks struct {
+	// sleep is called with true before the computer sleeps and with false after it has woken up
+	sleep func(suspending bool)
+	// statusChanged is called when the power source or the charge of the battery changes
+	statusChanged func()
+	// shutdown is called before the session ends, it returns false to veto the end where it is supported
+	shutdown func() bool
+	// shutdownReason is shown by the platform while the end of the session is vetoed
+	shutdownReason string
+}
+
+type backend interface {
+	status() (Status, error)
+	// watch reports the events until stop is called
+	watch(callbacks callbacks) (stop func(), err error)
+}
+
+// Power is the power service
+type Power struct {
+	// LowBattery is the charge in percent below which BatteryLow is reported. Default: 10
+	LowBattery int
+	// OnChange is called with the change and the status after the change
+	OnChange func(change Change, status Status)
+	// OnShutdown is called before the session ends. Returning false vetoes the end of the session on Windows, where
+	// ShutdownReason is shown to the user. The other platforms don't allow applications to veto.
+	OnShutdown func() bool
+	// ShutdownReason is shown by Windows while the end of the session is vetoed
+	ShutdownReason string
+
+	backend backend
+	lock    sync.Mutex
+	current Status
+	stop    func()
+}
+
+// New creates the power service
+func New() *Power {
+	return &Power{backend: newBackend()}
+}
+
+// EmitEvents emits ChangeEvent to the frontend
+func EmitEvents(ctx context.Context, p *Power) {
+	p.OnChange = func(change Change, status Status) {
+		runtime.EventsEmit(ctx, ChangeEvent, change, status)
+	}
+}
+
+// Status returns the power status of the computer
+func (p *Power) Status() (Status, error) {
+	return p.backend.status()
+}
+
+// Watch calls OnChange and OnShutdown until Stop is called
+func (p *Power) Watch() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.stop != nil {
+		return nil
+	}
+	status, err := p.backend.status()
+	if err != nil {
+		return err
+	}
+	p.current = status
+	reason := p.ShutdownReason
+	if reason == "" {
+		reason = "The application is busy"
+	}
+	stop, err := p.backend.watch(callbacks{
+		sleep:          p.sleep,
+		statusChanged:  p.statusChanged,
+		shutdown:       p.shutdown,
+		shutdownReason: reason,
+	})
+	if err != nil {
+		return err
+	}
+	p.stop = stop
+	return nil
+}
+
+// Stop stops watching
+func (p *Power) Stop() {
+	p.lock.Lock()
+	stop := p.stop
+	p.stop = nil
+	p.lock.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+func (p *Power) lowBattery() int {
+	if p.LowBattery <= 0 {
+		return defaultLowBattery
+	}
+	return p.LowBattery
+}
+
+func (p *Power) changed(change Change) {
+	p.lock.Lock()
+	status := p.current
+	p.lock.Unlock()
+	if p.OnChange != nil {
+		p.OnChange(change, status)
+	}
+}
+
+func (p *Power) sleep(suspending bool) {
+	if suspending {
+		p.changed(Suspending)
+		return
+	}
+	// The power source may have changed while the computer was sleeping
+	p.statusChanged()
+	p.changed(Resumed)
+}
+
+// statusChanged reports the changes of the power source and the low battery, as the platforms report the changes of
+// the status without telling what has changed
+func (p *Power) statusChanged() {
+	status, err := p.backend.status()
+	if err != nil {
+		return
+	}
+	p.lock.Lock()
+	previous := p.current
+	p.current = status
+	p.lock.Unlock()
+
+	var changes []Change
+	if status.OnBattery != previous.OnBattery {
+		if status.OnBattery {
+			changes = append(changes, OnBattery)
+		} else {
+			changes = append(changes, OnAC)
+		}
+	}
+	low := p.lowBattery()
+	isLow := status.OnBattery && status.Battery >= 0 && status.Battery < low
+	wasLow := previous.OnBattery && previous.Battery >= 0 && previous.Battery < low
+	if isLow && !wasLow {
+		changes = append(changes, BatteryLow)
+	}
+	if p.OnChange != nil {
+		for _, change := range changes {
+			p.OnChange(change, status)
+		}
+	}
+}
+
+func (p *Power) shutdown() bool {
+	p.changed(ShuttingDown)
+	if p.OnShutdown == nil {
+		return true
+	}
+	return p.OnShutdown()
+}
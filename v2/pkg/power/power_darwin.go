@@ -0,0 +1,70 @@
+//go:build darwin
+
+package power
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AppKit -framework IOKit
+
+#import "Power_darwin.h"
+*/
+import "C"
+
+import "sync"
+
+// workspaceBackend observes the sleep and power off notifications of NSWorkspace and the power sources of IOKit. The
+// notifications are delivered by the main run loop of the application. macOS doesn't allow applications to veto the
+// power off with the notification, the application is quit by the delegate of the application.
+type workspaceBackend struct{}
+
+var (
+	watchersLock sync.Mutex
+	watchers     = map[int]callbacks{}
+	nextWatcher  int
+)
+
+//export processPowerEvent
+func processPowerEvent(watcher C.int, event *C.char) {
+	watchersLock.Lock()
+	callbacks, watched := watchers[int(watcher)]
+	watchersLock.Unlock()
+	if !watched {
+		return
+	}
+	switch C.GoString(event) {
+	case "suspending":
+		callbacks.sleep(true)
+	case "resumed":
+		callbacks.sleep(false)
+	case "status":
+		callbacks.statusChanged()
+	case "shutdown":
+		callbacks.shutdown()
+	}
+}
+
+func newBackend() backend {
+	return workspaceBackend{}
+}
+
+func (workspaceBackend) status() (Status, error) {
+	var onBattery C.bool
+	var battery C.int
+	C.PowerStatus(&onBattery, &battery)
+	return Status{OnBattery: bool(onBattery), Battery: int(battery)}, nil
+}
+
+func (workspaceBackend) watch(callbacks callbacks) (func(), error) {
+	watchersLock.Lock()
+	id := nextWatcher
+	nextWatcher++
+	watchers[id] = callbacks
+	watchersLock.Unlock()
+	watch := C.PowerWatch(C.int(id))
+	return func() {
+		C.PowerStopWatching(watch)
+		watchersLock.Lock()
+		delete(watchers, id)
+		watchersLock.Unlock()
+	}, nil
+}
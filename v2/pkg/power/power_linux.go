@@ -0,0 +1,157 @@
+//go:build linux
+
+package power
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	login1Destination = "org.freedesktop.login1"
+	login1Path        = "/org/freedesktop/login1"
+	managerInterface  = "org.freedesktop.login1.Manager"
+	upowerDestination = "org.freedesktop.UPower"
+	upowerPath        = "/org/freedesktop/UPower"
+	displayDevicePath = "/org/freedesktop/UPower/devices/DisplayDevice"
+	deviceInterface   = "org.freedesktop.UPower.Device"
+)
+
+// logindBackend receives the sleep and shutdown signals of logind and the power status of UPower. A delay inhibitor
+// lock makes logind wait until the application has been notified, logind doesn't allow applications to veto.
+type logindBackend struct{}
+
+func newBackend() backend {
+	return logindBackend{}
+}
+
+func (logindBackend) status() (Status, error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return Status{}, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	return upowerStatus(conn), nil
+}
+
+// upowerStatus reads the status of UPower, computers without UPower are reported without battery
+func upowerStatus(conn *dbus.Conn) Status {
+	status := Status{Battery: -1}
+	if onBattery, err := conn.Object(upowerDestination, upowerPath).GetProperty(upowerDestination + ".OnBattery"); err == nil {
+		status.OnBattery, _ = onBattery.Value().(bool)
+	}
+	device := conn.Object(upowerDestination, displayDevicePath)
+	present, err := device.GetProperty(deviceInterface + ".IsPresent")
+	if isPresent, _ := present.Value().(bool); err != nil || !isPresent {
+		return status
+	}
+	if percentage, err := device.GetProperty(deviceInterface + ".Percentage"); err == nil {
+		if value, ok := percentage.Value().(float64); ok {
+			status.Battery = int(math.Round(value))
+		}
+	}
+	return status
+}
+
+// inhibitor holds the delay inhibitor lock of logind while the application hasn't been notified
+type inhibitor struct {
+	conn *dbus.Conn
+	lock sync.Mutex
+	file *os.File
+}
+
+// acquire takes the lock, the events are reported without it if logind doesn't grant it
+func (i *inhibitor) acquire() {
+	if i == nil {
+		return
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.file != nil {
+		return
+	}
+	var fd dbus.UnixFD
+	err := i.conn.Object(login1Destination, login1Path).
+		Call(managerInterface+".Inhibit", 0, "sleep:shutdown", filepath.Base(os.Args[0]), "Notifying the application", "delay").
+		Store(&fd)
+	if err == nil {
+		i.file = os.NewFile(uintptr(fd), "inhibitor")
+	}
+}
+
+func (i *inhibitor) release() {
+	if i == nil {
+		return
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.file != nil {
+		i.file.Close()
+		i.file = nil
+	}
+}
+
+func (logindBackend) watch(callbacks callbacks) (func(), error) {
+	// A private connection, so that the signals stop when it is closed
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	for _, match := range [][]dbus.MatchOption{
+		{dbus.WithMatchObjectPath(login1Path), dbus.WithMatchInterface(managerInterface), dbus.WithMatchMember("PrepareForSleep")},
+		{dbus.WithMatchObjectPath(login1Path), dbus.WithMatchInterface(managerInterface), dbus.WithMatchMember("PrepareForShutdown")},
+		{dbus.WithMatchObjectPath(upowerPath), dbus.WithMatchInterface("org.freedesktop.DBus.Properties"), dbus.WithMatchMember("PropertiesChanged")},
+		{dbus.WithMatchObjectPath(displayDevicePath), dbus.WithMatchInterface("org.freedesktop.DBus.Properties"), dbus.WithMatchMember("PropertiesChanged")},
+	} {
+		if err := conn.AddMatchSignal(match...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+		}
+	}
+	lock := &inhibitor{conn: conn}
+	lock.acquire()
+	signals := make(chan *dbus.Signal, 10)
+	conn.Signal(signals)
+	go func() {
+		for signal := range signals {
+			dispatchSignal(signal, callbacks, lock)
+		}
+	}()
+	return func() {
+		lock.release()
+		conn.Close()
+	}, nil
+}
+
+// dispatchSignal reports the event of the signal. The lock is released once the application has been notified of the
+// sleep or the shutdown and acquired again after the computer has woken up.
+func dispatchSignal(signal *dbus.Signal, callbacks callbacks, lock *inhibitor) {
+	switch signal.Name {
+	case managerInterface + ".PrepareForSleep":
+		if len(signal.Body) == 0 {
+			return
+		}
+		start, _ := signal.Body[0].(bool)
+		if !start {
+			lock.acquire()
+		}
+		callbacks.sleep(start)
+		if start {
+			lock.release()
+		}
+	case managerInterface + ".PrepareForShutdown":
+		if len(signal.Body) == 0 {
+			return
+		}
+		if start, _ := signal.Body[0].(bool); start {
+			callbacks.shutdown()
+			lock.release()
+		}
+	case "org.freedesktop.DBus.Properties.PropertiesChanged":
+		callbacks.statusChanged()
+	}
+}
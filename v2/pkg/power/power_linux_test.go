@@ -0,0 +1,40 @@
+package power
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestDispatchSignal(t *testing.T) {
+	var events []string
+	callbacks := callbacks{
+		sleep: func(suspending bool) {
+			if suspending {
+				events = append(events, "suspending")
+			} else {
+				events = append(events, "resumed")
+			}
+		},
+		statusChanged: func() { events = append(events, "status") },
+		shutdown: func() bool {
+			events = append(events, "shutdown")
+			return true
+		},
+	}
+	for _, signal := range []*dbus.Signal{
+		{Name: managerInterface + ".PrepareForSleep", Body: []interface{}{true}},
+		{Name: managerInterface + ".PrepareForSleep", Body: []interface{}{false}},
+		{Name: "org.freedesktop.DBus.Properties.PropertiesChanged", Body: []interface{}{deviceInterface, map[string]dbus.Variant{}}},
+		// The shutdown has been cancelled
+		{Name: managerInterface + ".PrepareForShutdown", Body: []interface{}{false}},
+		{Name: managerInterface + ".PrepareForShutdown", Body: []interface{}{true}},
+		{Name: managerInterface + ".PrepareForSleep"},
+	} {
+		dispatchSignal(signal, callbacks, nil)
+	}
+	if want := []string{"suspending", "resumed", "status", "shutdown"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
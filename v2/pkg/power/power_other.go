@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !windows
+
+package power
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) status() (Status, error) {
+	return Status{}, ErrNotSupported
+}
+
+func (unsupportedBackend) watch(callbacks callbacks) (func(), error) {
+	return nil, ErrNotSupported
+}
+
+func acquireLock(kind LockKind, name string, reason string) (func(), error) {
+	return nil, ErrNotSupported
+}
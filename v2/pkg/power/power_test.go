@@ -0,0 +1,73 @@
+package power
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeBackend struct {
+	current   Status
+	err       error
+	callbacks callbacks
+	stopped   bool
+}
+
+func (b *fakeBackend) status() (Status, error) {
+	return b.current, b.err
+}
+
+func (b *fakeBackend) watch(callbacks callbacks) (func(), error) {
+	b.callbacks = callbacks
+	return func() { b.stopped = true }, nil
+}
+
+func TestWatch(t *testing.T) {
+	backend := &fakeBackend{current: Status{Battery: 50}}
+	p := &Power{backend: backend, LowBattery: 20}
+	var changes []Change
+	var statuses []Status
+	p.OnChange = func(change Change, status Status) {
+		changes = append(changes, change)
+		statuses = append(statuses, status)
+	}
+	p.OnShutdown = func() bool {
+		return false
+	}
+	if err := p.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	backend.callbacks.sleep(true)
+	backend.current = Status{OnBattery: true, Battery: 50}
+	backend.callbacks.sleep(false)
+	// The charge is reported more than once
+	for _, battery := range []int{30, 19, 18, 25, 15} {
+		backend.current.Battery = battery
+		backend.callbacks.statusChanged()
+	}
+	backend.current = Status{Battery: 15}
+	backend.callbacks.statusChanged()
+	if backend.callbacks.shutdown() {
+		t.Error("OnShutdown didn't veto")
+	}
+	p.Stop()
+
+	wantChanges := []Change{Suspending, OnBattery, Resumed, BatteryLow, BatteryLow, OnAC, ShuttingDown}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("changes = %v, want %v", changes, wantChanges)
+	}
+	if want := (Status{OnBattery: true, Battery: 19}); statuses[3] != want {
+		t.Errorf("status after %s = %+v, want %+v", changes[3], statuses[3], want)
+	}
+	if backend.callbacks.shutdownReason == "" {
+		t.Error("no default reason of the veto")
+	}
+	if !backend.stopped {
+		t.Error("Stop() didn't stop the backend")
+	}
+
+	p = &Power{backend: &fakeBackend{err: ErrNotSupported}}
+	if err := p.Watch(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Watch() = %v, want ErrNotSupported", err)
+	}
+}
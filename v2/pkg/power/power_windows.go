@@ -0,0 +1,159 @@
+//go:build windows
+
+package power
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/windows/winc/w32"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	pbtAPMPowerStatusChange = 0xA
+	pbtAPMResumeAutomatic   = 0x12
+	pbtAPMSuspend           = 0x4
+
+	acLineOffline  = 0
+	batteryNone    = 128
+	batteryUnknown = 255
+)
+
+var (
+	kernel32                       = windows.NewLazySystemDLL("kernel32.dll")
+	procGetSystemPowerStatus       = kernel32.NewProc("GetSystemPowerStatus")
+	user32                         = windows.NewLazySystemDLL("user32.dll")
+	procShutdownBlockReasonCreate  = user32.NewProc("ShutdownBlockReasonCreate")
+	procShutdownBlockReasonDestroy = user32.NewProc("ShutdownBlockReasonDestroy")
+)
+
+// systemPowerStatus is SYSTEM_POWER_STATUS
+type systemPowerStatus struct {
+	acLineStatus        byte
+	batteryFlag         byte
+	batteryLifePercent  byte
+	systemStatusFlag    byte
+	batteryLifeTime     uint32
+	batteryFullLifeTime uint32
+}
+
+// windowBackend receives the power broadcasts and the end of the session with a hidden top-level window, as
+// message-only windows don't receive broadcasts
+type windowBackend struct{}
+
+func newBackend() backend {
+	return windowBackend{}
+}
+
+func (windowBackend) status() (Status, error) {
+	var status systemPowerStatus
+	if result, _, err := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status))); result == 0 {
+		return Status{}, fmt.Errorf("querying the power status failed: %w", err)
+	}
+	battery := int(status.batteryLifePercent)
+	if status.batteryFlag&batteryNone != 0 || status.batteryLifePercent == batteryUnknown {
+		battery = -1
+	}
+	return Status{OnBattery: status.acLineStatus == acLineOffline, Battery: battery}, nil
+}
+
+const windowClassName = "wailsPowerNotifications"
+
+var (
+	registerClassOnce sync.Once
+	registerClassErr  error
+
+	watchersLock sync.Mutex
+	watchers     = map[w32.HWND]callbacks{}
+)
+
+func windowProc(hwnd w32.HWND, msg uint32, wparam uintptr, lparam uintptr) uintptr {
+	watchersLock.Lock()
+	callbacks, watched := watchers[hwnd]
+	watchersLock.Unlock()
+	switch msg {
+	case w32.WM_POWERBROADCAST:
+		if watched {
+			switch wparam {
+			case pbtAPMSuspend:
+				callbacks.sleep(true)
+			case pbtAPMResumeAutomatic:
+				callbacks.sleep(false)
+			case pbtAPMPowerStatusChange:
+				callbacks.statusChanged()
+			}
+		}
+		return 1
+	case w32.WM_QUERYENDSESSION:
+		if watched && !callbacks.shutdown() {
+			// Windows shows the reason in the list of the applications that block the shutdown
+			reason, _ := windows.UTF16PtrFromString(callbacks.shutdownReason)
+			procShutdownBlockReasonCreate.Call(uintptr(hwnd), uintptr(unsafe.Pointer(reason)))
+			return 0
+		}
+		return 1
+	case w32.WM_ENDSESSION:
+		procShutdownBlockReasonDestroy.Call(uintptr(hwnd))
+		return 0
+	case w32.WM_DESTROY:
+		watchersLock.Lock()
+		delete(watchers, hwnd)
+		watchersLock.Unlock()
+		w32.PostQuitMessage(0)
+		return 0
+	}
+	return w32.DefWindowProc(hwnd, msg, wparam, lparam)
+}
+
+func registerClass() error {
+	registerClassOnce.Do(func() {
+		class := w32.WNDCLASSEX{
+			WndProc:   syscall.NewCallback(windowProc),
+			Instance:  w32.GetModuleHandle(""),
+			ClassName: windows.StringToUTF16Ptr(windowClassName),
+		}
+		class.Size = uint32(unsafe.Sizeof(class))
+		if w32.RegisterClassEx(&class) == 0 {
+			registerClassErr = fmt.Errorf("registering the window class failed: %w", syscall.GetLastError())
+		}
+	})
+	return registerClassErr
+}
+
+func (windowBackend) watch(callbacks callbacks) (func(), error) {
+	if err := registerClass(); err != nil {
+		return nil, err
+	}
+	created := make(chan w32.HWND)
+	errs := make(chan error, 1)
+	go func() {
+		// The messages of a window are received by the thread that created it
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		hwnd := w32.CreateWindowEx(0, windows.StringToUTF16Ptr(windowClassName), nil, 0, 0, 0, 0, 0, 0, 0, w32.GetModuleHandle(""), nil)
+		if hwnd == 0 {
+			errs <- fmt.Errorf("creating the window failed: %w", syscall.GetLastError())
+			return
+		}
+		watchersLock.Lock()
+		watchers[hwnd] = callbacks
+		watchersLock.Unlock()
+		created <- hwnd
+
+		var msg w32.MSG
+		for w32.GetMessage(&msg, 0, 0, 0) > 0 {
+			w32.TranslateMessage(&msg)
+			w32.DispatchMessage(&msg)
+		}
+	}()
+	select {
+	case hwnd := <-created:
+		return func() { w32.PostMessage(hwnd, w32.WM_CLOSE, 0, 0) }, nil
+	case err := <-errs:
+		return nil, err
+	}
+}
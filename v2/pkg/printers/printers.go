@@ -0,0 +1,163 @@
+// Package printers lists the installed printers and prints to them without the print dialog of the webview, EG: for
+// point-of-sale applications that print receipts with ESC/POS or shipping applications that print labels with ZPL.
+// Raw jobs are passed to the printer as they are, PDFs are rendered by the print system: CUPS on Linux and macOS and
+// the spooler on Windows. Bind the Printers service to use it from JS.
+package printers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// State is the state of a printer
+type State string
+
+const (
+	Idle     State = "idle"
+	Printing State = "printing"
+	// Stopped printers keep the jobs until they are resumed
+	Stopped State = "stopped"
+	Offline State = "offline"
+)
+
+var (
+	// ErrNotSupported is returned when the platform has no print system that the package can use, EG: on Linux
+	// without the command line tools of CUPS
+	ErrNotSupported = errors.New("printers: printing is not supported")
+	// ErrUnknownPrinter is returned for printers that aren't installed
+	ErrUnknownPrinter = errors.New("printers: unknown printer")
+)
+
+// Printer is an installed printer
+type Printer struct {
+	// Name identifies the printer in the other methods
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	State       State  `json:"state"`
+	// Default is true for the default printer of the user
+	Default bool `json:"default"`
+}
+
+// Capabilities are the features of a printer that are reported by its driver. Printers without a driver, EG: raw CUPS
+// queues of label printers, report none.
+type Capabilities struct {
+	Color  bool `json:"color"`
+	Duplex bool `json:"duplex"`
+	// PaperSizes are the names of the paper sizes, EG: "A4" or "w288h432"
+	PaperSizes []string `json:"paperSizes"`
+	// Resolutions are the resolutions in dpi, EG: "203dpi" or "600x300dpi"
+	Resolutions []string `json:"resolutions"`
+}
+
+// PDFOptions are the options of PrintPDF
+type PDFOptions struct {
+	// Title is the name of the job, the name of the file by default. It is ignored on Windows.
+	Title string `json:"title"`
+	// Copies defaults to 1
+	Copies int `json:"copies"`
+	// PaperSize is one of the PaperSizes of the capabilities. It is ignored on Windows.
+	PaperSize string `json:"paperSize"`
+	// Duplex prints on both sides of the paper. It is ignored on Windows.
+	Duplex bool `json:"duplex"`
+}
+
+// Job is a submitted print job
+type Job struct {
+	Printer string `json:"printer"`
+	// ID identifies the job in the queue of the printer. It is empty for PDFs on Windows, which are printed by the
+	// PDF application.
+	ID string `json:"id"`
+}
+
+type backend interface {
+	list() ([]Printer, error)
+	capabilities(printer string) (Capabilities, error)
+	printRaw(printer string, title string, data []byte) (Job, error)
+	printPDF(printer string, path string, options PDFOptions) (Job, error)
+}
+
+// Printers is the printers service
+type Printers struct {
+	backend backend
+}
+
+// New creates the printers service
+func New() *Printers {
+	return &Printers{backend: newBackend()}
+}
+
+// List returns the installed printers
+func (p *Printers) List() ([]Printer, error) {
+	return p.backend.list()
+}
+
+// Default returns the default printer. ErrUnknownPrinter is returned if there is none.
+func (p *Printers) Default() (Printer, error) {
+	printers, err := p.List()
+	if err != nil {
+		return Printer{}, err
+	}
+	for _, printer := range printers {
+		if printer.Default {
+			return printer, nil
+		}
+	}
+	return Printer{}, fmt.Errorf("%w: no default printer", ErrUnknownPrinter)
+}
+
+// resolve returns the name of the printer, or of the default printer if it is empty, if it is installed
+func (p *Printers) resolve(name string) (string, error) {
+	if name == "" {
+		printer, err := p.Default()
+		return printer.Name, err
+	}
+	printers, err := p.List()
+	if err != nil {
+		return "", err
+	}
+	for _, printer := range printers {
+		if printer.Name == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownPrinter, name)
+}
+
+// Capabilities returns the capabilities of the printer, of the default printer if it is empty
+func (p *Printers) Capabilities(printer string) (Capabilities, error) {
+	printer, err := p.resolve(printer)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return p.backend.capabilities(printer)
+}
+
+// PrintRaw sends the data to the printer, to the default printer if it is empty, without converting it, EG: ESC/POS
+// or ZPL commands. The data is passed as a base64 string from JS.
+func (p *Printers) PrintRaw(printer string, title string, data []byte) (Job, error) {
+	if len(data) == 0 {
+		return Job{}, errors.New("printers: no data to print")
+	}
+	printer, err := p.resolve(printer)
+	if err != nil {
+		return Job{}, err
+	}
+	return p.backend.printRaw(printer, title, data)
+}
+
+// PrintPDF prints the PDF file with the printer, with the default printer if it is empty
+func (p *Printers) PrintPDF(printer string, path string, options PDFOptions) (Job, error) {
+	if _, err := os.Stat(path); err != nil {
+		return Job{}, err
+	}
+	printer, err := p.resolve(printer)
+	if err != nil {
+		return Job{}, err
+	}
+	if options.Copies < 1 {
+		options.Copies = 1
+	}
+	return p.backend.printPDF(printer, path, options)
+}
@@ -0,0 +1,192 @@
+//go:build linux || darwin
+
+package printers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cupsBackend uses the command line tools of CUPS, which are installed with CUPS on Linux and part of macOS
+type cupsBackend struct{}
+
+func newBackend() backend {
+	return cupsBackend{}
+}
+
+// runCommand runs the command with the C locale, as the output of lpstat and lp is translated
+var runCommand = func(stdin []byte, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%w: %s is not installed", ErrNotSupported, name)
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return stdout.String(), fmt.Errorf("%s failed: %s", name, message)
+		}
+		return stdout.String(), fmt.Errorf("%s failed: %w", name, err)
+	}
+	return stdout.String(), nil
+}
+
+func (cupsBackend) list() ([]Printer, error) {
+	output, err := runCommand(nil, "lpstat", "-l", "-p")
+	if err != nil {
+		if strings.Contains(err.Error(), "No destinations added") {
+			return []Printer{}, nil
+		}
+		return nil, err
+	}
+	printers := parseLpstat(output)
+	defaultOutput, err := runCommand(nil, "lpstat", "-d")
+	if err != nil {
+		return nil, err
+	}
+	if name, found := strings.CutPrefix(strings.TrimSpace(defaultOutput), "system default destination: "); found {
+		for index := range printers {
+			printers[index].Default = printers[index].Name == name
+		}
+	}
+	return printers, nil
+}
+
+// parseLpstat parses the long status of the printers, the properties of a printer are indented with a tab:
+//
+//	printer Office now printing Office-12.  enabled since Tue 01 Oct 2024 10:00:00 AM CEST
+//		Description: Office Laser
+//		Alerts: none
+//		Location: 2nd floor
+func parseLpstat(output string) []Printer {
+	printers := []Printer{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if status, found := strings.CutPrefix(line, "printer "); found {
+			name, status, _ := strings.Cut(status, " ")
+			printer := Printer{Name: name, State: Idle}
+			switch {
+			case strings.HasPrefix(status, "now printing"):
+				printer.State = Printing
+			case strings.HasPrefix(status, "disabled"):
+				printer.State = Stopped
+			}
+			printers = append(printers, printer)
+			continue
+		}
+		if len(printers) == 0 || !strings.HasPrefix(line, "\t") {
+			continue
+		}
+		printer := &printers[len(printers)-1]
+		key, value, _ := strings.Cut(strings.TrimSpace(line), ":")
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Description":
+			printer.Description = value
+		case "Location":
+			printer.Location = value
+		case "Alerts":
+			if strings.Contains(value, "offline") && printer.State != Stopped {
+				printer.State = Offline
+			}
+		}
+	}
+	return printers
+}
+
+func (cupsBackend) capabilities(printer string) (Capabilities, error) {
+	output, err := runCommand(nil, "lpoptions", "-p", printer, "-l")
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return parseLpoptions(output), nil
+}
+
+// parseLpoptions parses the options of the driver of the printer, the default value is marked with a star:
+//
+//	PageSize/Media Size: *Letter Legal A4
+//	Duplex/2-Sided Printing: *None DuplexNoTumble DuplexTumble
+func parseLpoptions(output string) Capabilities {
+	capabilities := Capabilities{PaperSizes: []string{}, Resolutions: []string{}}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		option, values, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		key, _, _ := strings.Cut(option, "/")
+		for _, value := range strings.Fields(values) {
+			value = strings.TrimPrefix(value, "*")
+			switch key {
+			case "PageSize":
+				capabilities.PaperSizes = append(capabilities.PaperSizes, value)
+			case "Resolution":
+				capabilities.Resolutions = append(capabilities.Resolutions, value)
+			case "Duplex":
+				if value != "None" {
+					capabilities.Duplex = true
+				}
+			case "ColorModel":
+				lower := strings.ToLower(value)
+				if !strings.Contains(lower, "gray") && !strings.Contains(lower, "grey") && !strings.Contains(lower, "mono") && !strings.Contains(lower, "black") {
+					capabilities.Color = true
+				}
+			}
+		}
+	}
+	return capabilities
+}
+
+func (cupsBackend) printRaw(printer string, title string, data []byte) (Job, error) {
+	args := []string{"-d", printer, "-o", "raw"}
+	if title != "" {
+		args = append(args, "-t", title)
+	}
+	output, err := runCommand(data, "lp", args...)
+	if err != nil {
+		return Job{}, err
+	}
+	return parseLp(printer, output)
+}
+
+func (cupsBackend) printPDF(printer string, path string, options PDFOptions) (Job, error) {
+	title := options.Title
+	if title == "" {
+		title = filepath.Base(path)
+	}
+	args := []string{"-d", printer, "-t", title, "-n", strconv.Itoa(options.Copies)}
+	if options.PaperSize != "" {
+		args = append(args, "-o", "media="+options.PaperSize)
+	}
+	if options.Duplex {
+		args = append(args, "-o", "sides=two-sided-long-edge")
+	}
+	output, err := runCommand(nil, "lp", append(args, "--", path)...)
+	if err != nil {
+		return Job{}, err
+	}
+	return parseLp(printer, output)
+}
+
+// parseLp returns the job of the output of lp: "request id is Office-12 (1 file(s))"
+func parseLp(printer string, output string) (Job, error) {
+	id, found := strings.CutPrefix(strings.TrimSpace(output), "request id is ")
+	if !found {
+		return Job{}, errors.New("lp didn't return the request id: " + strings.TrimSpace(output))
+	}
+	id, _, _ = strings.Cut(id, " ")
+	return Job{Printer: printer, ID: id}, nil
+}
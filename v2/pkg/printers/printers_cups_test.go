@@ -0,0 +1,86 @@
+//go:build linux || darwin
+
+package printers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLpstat(t *testing.T) {
+	output := `printer Office now printing Office-12.  enabled since Tue 01 Oct 2024 10:00:00 AM CEST
+	Form mounted:
+	Description: Office Laser
+	Alerts: none
+	Location: 2nd floor
+	Users allowed:
+		(all)
+printer Labels is idle.  enabled since Tue 01 Oct 2024 10:00:00 AM CEST
+	Description: Zebra ZD420
+	Alerts: offline-report
+	Location:
+printer Old disabled since Tue 01 Oct 2024 10:00:00 AM CEST -
+	reason unknown
+	Description: Old
+`
+	want := []Printer{
+		{Name: "Office", Description: "Office Laser", Location: "2nd floor", State: Printing},
+		{Name: "Labels", Description: "Zebra ZD420", State: Offline},
+		{Name: "Old", Description: "Old", State: Stopped},
+	}
+	if got := parseLpstat(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLpstat() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseLpoptions(t *testing.T) {
+	output := `PageSize/Media Size: *Letter Legal A4 w288h432
+Duplex/2-Sided Printing: *None DuplexNoTumble DuplexTumble
+ColorModel/Color Mode: *Gray RGB
+Resolution/Resolution: 300dpi *600x300dpi
+`
+	want := Capabilities{
+		Color:       true,
+		Duplex:      true,
+		PaperSizes:  []string{"Letter", "Legal", "A4", "w288h432"},
+		Resolutions: []string{"300dpi", "600x300dpi"},
+	}
+	if got := parseLpoptions(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLpoptions() = %+v, want %+v", got, want)
+	}
+	if got := parseLpoptions("ColorModel/Color Mode: *Gray\n"); got.Color || got.Duplex {
+		t.Errorf("parseLpoptions() = %+v for a monochrome printer", got)
+	}
+}
+
+func TestCUPSPrint(t *testing.T) {
+	var commands []string
+	var stdins []string
+	original := runCommand
+	defer func() { runCommand = original }()
+	runCommand = func(stdin []byte, name string, args ...string) (string, error) {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		stdins = append(stdins, string(stdin))
+		return "request id is Labels-7 (0 file(s))\n", nil
+	}
+
+	backend := cupsBackend{}
+	if job, err := backend.printRaw("Labels", "Label", []byte("^XA^XZ")); err != nil || job != (Job{Printer: "Labels", ID: "Labels-7"}) {
+		t.Errorf("printRaw() = %v, %v", job, err)
+	}
+	if _, err := backend.printPDF("Labels", "/tmp/invoice.pdf", PDFOptions{Copies: 2, PaperSize: "A4", Duplex: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"lp -d Labels -o raw -t Label",
+		"lp -d Labels -t invoice.pdf -n 2 -o media=A4 -o sides=two-sided-long-edge -- /tmp/invoice.pdf",
+	}
+	if !reflect.DeepEqual(commands, want) || stdins[0] != "^XA^XZ" {
+		t.Errorf("ran %q with %q, want %q", commands, stdins, want)
+	}
+
+	if _, err := parseLp("Labels", "lp: Error - The printer or class does not exist."); err == nil {
+		t.Error("parseLp() didn't fail without a request id")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !linux && !darwin && !windows
+
+package printers
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) list() ([]Printer, error) {
+	return nil, ErrNotSupported
+}
+
+func (unsupportedBackend) capabilities(printer string) (Capabilities, error) {
+	return Capabilities{}, ErrNotSupported
+}
+
+func (unsupportedBackend) printRaw(printer string, title string, data []byte) (Job, error) {
+	return Job{}, ErrNotSupported
+}
+
+func (unsupportedBackend) printPDF(printer string, path string, options PDFOptions) (Job, error) {
+	return Job{}, ErrNotSupported
+}
@@ -0,0 +1,65 @@
+package printers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeBackend has two printers and records the jobs
+type fakeBackend struct {
+	raw     []byte
+	options PDFOptions
+}
+
+func (f *fakeBackend) list() ([]Printer, error) {
+	return []Printer{{Name: "Office", State: Idle}, {Name: "Labels", State: Idle, Default: true}}, nil
+}
+
+func (f *fakeBackend) capabilities(printer string) (Capabilities, error) {
+	return Capabilities{PaperSizes: []string{printer}}, nil
+}
+
+func (f *fakeBackend) printRaw(printer string, title string, data []byte) (Job, error) {
+	f.raw = data
+	return Job{Printer: printer, ID: printer + "-1"}, nil
+}
+
+func (f *fakeBackend) printPDF(printer string, path string, options PDFOptions) (Job, error) {
+	f.options = options
+	return Job{Printer: printer, ID: printer + "-2"}, nil
+}
+
+func TestPrinters(t *testing.T) {
+	fake := &fakeBackend{}
+	p := &Printers{backend: fake}
+
+	if printer, err := p.Default(); err != nil || printer.Name != "Labels" {
+		t.Errorf("Default() = %v, %v, want Labels", printer, err)
+	}
+	if capabilities, err := p.Capabilities("Office"); err != nil || capabilities.PaperSizes[0] != "Office" {
+		t.Errorf("Capabilities(Office) = %v, %v", capabilities, err)
+	}
+	if _, err := p.Capabilities("Missing"); !errors.Is(err, ErrUnknownPrinter) {
+		t.Errorf("Capabilities(Missing) = %v, want ErrUnknownPrinter", err)
+	}
+
+	if job, err := p.PrintRaw("", "Label", []byte("^XA^FDHello^FS^XZ")); err != nil || job.ID != "Labels-1" || string(fake.raw) != "^XA^FDHello^FS^XZ" {
+		t.Errorf("PrintRaw() = %v, %v, sent %q", job, err, fake.raw)
+	}
+	if _, err := p.PrintRaw("Office", "", nil); err == nil {
+		t.Error("PrintRaw() didn't fail without data")
+	}
+
+	pdf := filepath.Join(t.TempDir(), "invoice.pdf")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.7"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if job, err := p.PrintPDF("Office", pdf, PDFOptions{}); err != nil || job.ID != "Office-2" || fake.options.Copies != 1 {
+		t.Errorf("PrintPDF() = %v, %v with %d copies", job, err, fake.options.Copies)
+	}
+	if _, err := p.PrintPDF("Office", pdf+".missing", PDFOptions{}); err == nil {
+		t.Error("PrintPDF() didn't fail for a missing file")
+	}
+}
@@ -0,0 +1,256 @@
+//go:build windows
+
+package printers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	printerEnumLocal       = 0x2
+	printerEnumConnections = 0x4
+
+	printerStatusPaused   = 0x1
+	printerStatusError    = 0x2
+	printerStatusOffline  = 0x80
+	printerStatusPrinting = 0x400
+
+	printerAttributeWorkOffline = 0x400
+
+	dcDuplex          = 7
+	dcEnumResolutions = 13
+	dcPaperNames      = 16
+	dcColorDevice     = 32
+
+	// paperNameLength is the length of the names of DC_PAPERNAMES in characters
+	paperNameLength = 64
+)
+
+var (
+	winspool               = windows.NewLazySystemDLL("winspool.drv")
+	procEnumPrinters       = winspool.NewProc("EnumPrintersW")
+	procGetDefaultPrinter  = winspool.NewProc("GetDefaultPrinterW")
+	procDeviceCapabilities = winspool.NewProc("DeviceCapabilitiesW")
+	procOpenPrinter        = winspool.NewProc("OpenPrinterW")
+	procClosePrinter       = winspool.NewProc("ClosePrinter")
+	procStartDocPrinter    = winspool.NewProc("StartDocPrinterW")
+	procEndDocPrinter      = winspool.NewProc("EndDocPrinter")
+	procStartPagePrinter   = winspool.NewProc("StartPagePrinter")
+	procEndPagePrinter     = winspool.NewProc("EndPagePrinter")
+	procWritePrinter       = winspool.NewProc("WritePrinter")
+)
+
+type printerInfo2 struct {
+	pServerName         *uint16
+	pPrinterName        *uint16
+	pShareName          *uint16
+	pPortName           *uint16
+	pDriverName         *uint16
+	pComment            *uint16
+	pLocation           *uint16
+	pDevMode            uintptr
+	pSepFile            *uint16
+	pPrintProcessor     *uint16
+	pDatatype           *uint16
+	pParameters         *uint16
+	pSecurityDescriptor uintptr
+	attributes          uint32
+	priority            uint32
+	defaultPriority     uint32
+	startTime           uint32
+	untilTime           uint32
+	status              uint32
+	cJobs               uint32
+	averagePPM          uint32
+}
+
+type docInfo1 struct {
+	pDocName    *uint16
+	pOutputFile *uint16
+	pDatatype   *uint16
+}
+
+// spoolerBackend uses the print spooler
+type spoolerBackend struct{}
+
+func newBackend() backend {
+	return spoolerBackend{}
+}
+
+// enumPrinters returns the local printers and the connections to shared printers with their ports
+func enumPrinters() ([]Printer, map[string]string, error) {
+	var needed, returned uint32
+	procEnumPrinters.Call(printerEnumLocal|printerEnumConnections, 0, 2, 0, 0, uintptr(unsafe.Pointer(&needed)), uintptr(unsafe.Pointer(&returned)))
+	if needed == 0 {
+		return []Printer{}, map[string]string{}, nil
+	}
+	buffer := make([]byte, needed)
+	result, _, err := procEnumPrinters.Call(printerEnumLocal|printerEnumConnections, 0, 2, uintptr(unsafe.Pointer(&buffer[0])), uintptr(needed), uintptr(unsafe.Pointer(&needed)), uintptr(unsafe.Pointer(&returned)))
+	if result == 0 {
+		return nil, nil, fmt.Errorf("enumerating the printers failed: %w", err)
+	}
+	defaultPrinter := getDefaultPrinter()
+	printers := make([]Printer, 0, returned)
+	ports := map[string]string{}
+	for _, info := range unsafe.Slice((*printerInfo2)(unsafe.Pointer(&buffer[0])), returned) {
+		printer := Printer{
+			Name:        windows.UTF16PtrToString(info.pPrinterName),
+			Description: windows.UTF16PtrToString(info.pComment),
+			Location:    windows.UTF16PtrToString(info.pLocation),
+			State:       Idle,
+		}
+		switch {
+		case info.status&printerStatusOffline != 0 || info.attributes&printerAttributeWorkOffline != 0:
+			printer.State = Offline
+		case info.status&(printerStatusPaused|printerStatusError) != 0:
+			printer.State = Stopped
+		case info.status&printerStatusPrinting != 0:
+			printer.State = Printing
+		}
+		printer.Default = printer.Name == defaultPrinter
+		printers = append(printers, printer)
+		ports[printer.Name] = windows.UTF16PtrToString(info.pPortName)
+	}
+	return printers, ports, nil
+}
+
+func getDefaultPrinter() string {
+	var length uint32
+	procGetDefaultPrinter.Call(0, uintptr(unsafe.Pointer(&length)))
+	if length == 0 {
+		return ""
+	}
+	buffer := make([]uint16, length)
+	if result, _, _ := procGetDefaultPrinter.Call(uintptr(unsafe.Pointer(&buffer[0])), uintptr(unsafe.Pointer(&length))); result == 0 {
+		return ""
+	}
+	return windows.UTF16ToString(buffer)
+}
+
+func (spoolerBackend) list() ([]Printer, error) {
+	printers, _, err := enumPrinters()
+	return printers, err
+}
+
+func (spoolerBackend) capabilities(printer string) (Capabilities, error) {
+	_, ports, err := enumPrinters()
+	if err != nil {
+		return Capabilities{}, err
+	}
+	device, err := syscall.UTF16PtrFromString(printer)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	port, err := syscall.UTF16PtrFromString(ports[printer])
+	if err != nil {
+		return Capabilities{}, err
+	}
+	deviceCapabilities := func(capability uintptr, output unsafe.Pointer) int32 {
+		result, _, _ := procDeviceCapabilities.Call(uintptr(unsafe.Pointer(device)), uintptr(unsafe.Pointer(port)), capability, uintptr(output), 0)
+		return int32(result)
+	}
+
+	capabilities := Capabilities{
+		Color:       deviceCapabilities(dcColorDevice, nil) == 1,
+		Duplex:      deviceCapabilities(dcDuplex, nil) == 1,
+		PaperSizes:  []string{},
+		Resolutions: []string{},
+	}
+	if count := deviceCapabilities(dcPaperNames, nil); count > 0 {
+		names := make([]uint16, int(count)*paperNameLength)
+		deviceCapabilities(dcPaperNames, unsafe.Pointer(&names[0]))
+		for index := 0; index < int(count); index++ {
+			capabilities.PaperSizes = append(capabilities.PaperSizes, windows.UTF16ToString(names[index*paperNameLength:(index+1)*paperNameLength]))
+		}
+	}
+	if count := deviceCapabilities(dcEnumResolutions, nil); count > 0 {
+		resolutions := make([]int32, int(count)*2)
+		deviceCapabilities(dcEnumResolutions, unsafe.Pointer(&resolutions[0]))
+		for index := 0; index < int(count); index++ {
+			x, y := resolutions[index*2], resolutions[index*2+1]
+			resolution := strconv.Itoa(int(x)) + "dpi"
+			if x != y {
+				resolution = strconv.Itoa(int(x)) + "x" + strconv.Itoa(int(y)) + "dpi"
+			}
+			capabilities.Resolutions = append(capabilities.Resolutions, resolution)
+		}
+	}
+	return capabilities, nil
+}
+
+// printRaw writes the data to a job with the RAW datatype, which the spooler passes to the printer as it is
+func (spoolerBackend) printRaw(printer string, title string, data []byte) (Job, error) {
+	if title == "" {
+		title = "Raw job"
+	}
+	name, err := syscall.UTF16PtrFromString(printer)
+	if err != nil {
+		return Job{}, err
+	}
+	var handle uintptr
+	if result, _, err := procOpenPrinter.Call(uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(&handle)), 0); result == 0 {
+		return Job{}, fmt.Errorf("opening the printer %s failed: %w", printer, err)
+	}
+	defer procClosePrinter.Call(handle)
+
+	documentName, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return Job{}, err
+	}
+	document := docInfo1{
+		pDocName:  documentName,
+		pDatatype: windows.StringToUTF16Ptr("RAW"),
+	}
+	jobID, _, err := procStartDocPrinter.Call(handle, 1, uintptr(unsafe.Pointer(&document)))
+	if jobID == 0 {
+		return Job{}, fmt.Errorf("starting the job failed: %w", err)
+	}
+	if result, _, err := procStartPagePrinter.Call(handle); result == 0 {
+		procEndDocPrinter.Call(handle)
+		return Job{}, fmt.Errorf("starting the page failed: %w", err)
+	}
+	for len(data) > 0 {
+		var written uint32
+		result, _, err := procWritePrinter.Call(handle, uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)), uintptr(unsafe.Pointer(&written)))
+		if result == 0 || written == 0 {
+			procEndPagePrinter.Call(handle)
+			procEndDocPrinter.Call(handle)
+			return Job{}, fmt.Errorf("writing to the printer failed: %w", err)
+		}
+		data = data[written:]
+	}
+	procEndPagePrinter.Call(handle)
+	if result, _, err := procEndDocPrinter.Call(handle); result == 0 {
+		return Job{}, fmt.Errorf("ending the job failed: %w", err)
+	}
+	return Job{Printer: printer, ID: strconv.Itoa(int(jobID))}, nil
+}
+
+// printPDF prints the PDF with the printto verb of the application that opens PDFs, as the spooler can't render them.
+// It doesn't wait for the job to be submitted.
+func (spoolerBackend) printPDF(printer string, path string, options PDFOptions) (Job, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return Job{}, err
+	}
+	file, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return Job{}, err
+	}
+	args, err := syscall.UTF16PtrFromString(`"` + printer + `"`)
+	if err != nil {
+		return Job{}, err
+	}
+	for index := 0; index < options.Copies; index++ {
+		if err := windows.ShellExecute(0, windows.StringToUTF16Ptr("printto"), file, args, nil, windows.SW_HIDE); err != nil {
+			return Job{}, fmt.Errorf("printing %s failed, there may be no PDF application that can print: %w", path, err)
+		}
+	}
+	return Job{Printer: printer}, nil
+}
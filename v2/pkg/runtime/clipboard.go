@@ -1,6 +1,38 @@
 package runtime
 
-import "context"
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// selectionTimeout is the time the webview has to report its selection
+const selectionTimeout = 2 * time.Second
+
+// selectionScript reports the selection of the webview with an event. The selection of inputs and textareas is not
+// part of the document selection, so it is read from the focused element.
+const selectionScript = `(function(){
+var text="",html="",el=document.activeElement,sel=window.getSelection();
+if(el&&(el.tagName==="INPUT"||el.tagName==="TEXTAREA")&&typeof el.selectionStart==="number"){
+text=el.value.substring(el.selectionStart,el.selectionEnd);
+}else if(sel&&sel.rangeCount>0){
+var div=document.createElement("div");
+for(var i=0;i<sel.rangeCount;i++){div.appendChild(sel.getRangeAt(i).cloneContents());}
+text=sel.toString();html=div.innerHTML;
+}
+window.runtime.EventsEmit(%q,{text:text,html:html});
+})();`
+
+// Selection is the selected content of the webview
+type Selection struct {
+	Text string
+	HTML string
+}
 
 func ClipboardGetText(ctx context.Context) (string, error) {
 	appFrontend := getFrontend(ctx)
@@ -11,3 +43,63 @@ func ClipboardSetText(ctx context.Context, text string) error {
 	appFrontend := getFrontend(ctx)
 	return appFrontend.ClipboardSetText(text)
 }
+
+// ClipboardCopy copies the selection of the webview to the clipboard, EG: for the Copy item of a native menu
+func ClipboardCopy(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.ClipboardEditCommand(frontend.EditCommandCopy)
+}
+
+// ClipboardCut moves the selection of the webview to the clipboard
+func ClipboardCut(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.ClipboardEditCommand(frontend.EditCommandCut)
+}
+
+// ClipboardPaste pastes the clipboard into the focused element of the webview
+func ClipboardPaste(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.ClipboardEditCommand(frontend.EditCommandPaste)
+}
+
+// ClipboardSelectAll selects the whole content of the focused element of the webview
+func ClipboardSelectAll(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.ClipboardEditCommand(frontend.EditCommandSelectAll)
+}
+
+// ClipboardGetSelection returns the text and the HTML of the current selection in the webview. The HTML is empty
+// when the selection is in an input or a textarea.
+func ClipboardGetSelection(ctx context.Context) (Selection, error) {
+	appFrontend := getFrontend(ctx)
+	events := getEvents(ctx)
+
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return Selection{}, err
+	}
+	eventName := "wails:selection:" + hex.EncodeToString(id)
+
+	result := make(chan Selection, 1)
+	cancel := events.Once(eventName, func(optionalData ...interface{}) {
+		var selection Selection
+		if len(optionalData) > 0 {
+			if data, ok := optionalData[0].(map[string]interface{}); ok {
+				selection.Text, _ = data["text"].(string)
+				selection.HTML, _ = data["html"].(string)
+			}
+		}
+		result <- selection
+	})
+	defer cancel()
+
+	appFrontend.ExecJS(fmt.Sprintf(selectionScript, eventName))
+
+	select {
+	case selection := <-result:
+		return selection, nil
+	case <-time.After(selectionTimeout):
+		return Selection{}, errors.New("timed out waiting for the selection of the webview")
+	}
+}
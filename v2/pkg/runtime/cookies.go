@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// Cookie is a cookie of the webview profile
+type Cookie = frontend.Cookie
+
+// SiteData are the kinds of data that SiteDataClear removes
+type SiteData = frontend.SiteData
+
+const (
+	SiteDataCookies = frontend.SiteDataCookies
+	SiteDataStorage = frontend.SiteDataStorage
+	SiteDataCache   = frontend.SiteDataCache
+	SiteDataAll     = frontend.SiteDataAll
+)
+
+// CookiesGet returns the cookies of the webview that are sent to the URL, or all cookies if the URL is empty.
+// All cookies require WebKitGTK 2.42 on Linux.
+func CookiesGet(ctx context.Context, url string) ([]Cookie, error) {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.CookiesGet(url)
+}
+
+// CookieSet adds the cookie to the webview or replaces the cookie with the same name, domain and path
+func CookieSet(ctx context.Context, cookie Cookie) error {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.CookieSet(cookie)
+}
+
+// CookieDelete deletes the cookie with the name, domain and path of the cookie
+func CookieDelete(ctx context.Context, cookie Cookie) error {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.CookieDelete(cookie)
+}
+
+// SiteDataClear removes the data of all sites from the webview profile, EG: SiteDataAll to end the sessions on
+// logout
+func SiteDataClear(ctx context.Context, data SiteData) error {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.SiteDataClear(data)
+}
@@ -17,6 +17,17 @@ type OpenDialogOptions = frontend.OpenDialogOptions
 // SaveDialogOptions contains the options for the SaveDialog runtime method
 type SaveDialogOptions = frontend.SaveDialogOptions
 
+// MediaDialogOptions contains the options for the OpenMediaDialog runtime method
+type MediaDialogOptions = frontend.MediaDialogOptions
+
+type MediaType = frontend.MediaType
+
+const (
+	MediaImages = frontend.MediaImages
+	MediaVideos = frontend.MediaVideos
+	MediaAll    = frontend.MediaAll
+)
+
 type DialogType = frontend.DialogType
 
 const (
@@ -78,3 +89,19 @@ func MessageDialog(ctx context.Context, dialogOptions MessageDialogOptions) (str
 	appFrontend := getFrontend(ctx)
 	return appFrontend.MessageDialog(dialogOptions)
 }
+
+// OpenMediaDialog prompts the user to select images or videos with the media picker of the platform: PHPicker on
+// macOS 13+, the file chooser portal on Linux and the file dialog with media filters on Windows. It returns no files
+// when the user cancels.
+func OpenMediaDialog(ctx context.Context, dialogOptions MediaDialogOptions) ([]MediaFile, error) {
+	appFrontend := getFrontend(ctx)
+	paths, err := appFrontend.OpenMediaDialog(dialogOptions)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]MediaFile, 0, len(paths))
+	for _, path := range paths {
+		files = append(files, newMediaFile(path))
+	}
+	return files, nil
+}
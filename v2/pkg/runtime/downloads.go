@@ -0,0 +1,44 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// DownloadEvent is emitted with the Download when a download of the webview starts, progresses or finishes.
+// This requires the Downloads application option.
+const DownloadEvent = frontend.DownloadEvent
+
+// Download is a download of the webview
+type Download = frontend.Download
+
+// DownloadState is the state of a download
+type DownloadState = frontend.DownloadState
+
+const (
+	DownloadStarted   = frontend.DownloadStarted
+	DownloadProgress  = frontend.DownloadProgress
+	DownloadCompleted = frontend.DownloadCompleted
+	DownloadCancelled = frontend.DownloadCancelled
+	DownloadFailed    = frontend.DownloadFailed
+)
+
+// OnDownload registers a callback for the changes of the downloads. It returns a function to unregister the
+// callback.
+func OnDownload(ctx context.Context, callback func(download Download)) func() {
+	return EventsOn(ctx, DownloadEvent, func(optionalData ...interface{}) {
+		if len(optionalData) == 0 {
+			return
+		}
+		if download, ok := optionalData[0].(Download); ok {
+			callback(download)
+		}
+	})
+}
+
+// DownloadCancel cancels the download with the id in flight
+func DownloadCancel(ctx context.Context, id string) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.DownloadCancel(id)
+}
@@ -2,8 +2,27 @@ package runtime
 
 import (
 	"context"
+	"errors"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/internal/instanceevents"
 )
 
+// EventPriority is the priority of the delivery of an event to the frontend
+type EventPriority = frontend.EventPriority
+
+const (
+	// EventPriorityLow is for bulk events, EG: telemetry or progress of background work
+	EventPriorityLow = frontend.EventPriorityLow
+	// EventPriorityNormal is the priority of EventsEmit
+	EventPriorityNormal = frontend.EventPriorityNormal
+	// EventPriorityHigh is for UI-critical events, EG: focus or resize notifications
+	EventPriorityHigh = frontend.EventPriorityHigh
+)
+
+type EventQueueMetrics = frontend.EventQueueMetrics
+type EventQueueStats = frontend.EventQueueStats
+
 // EventsOn registers a listener for the given event name. It returns a function to cancel the listener
 func EventsOn(ctx context.Context, eventName string, callback func(optionalData ...interface{})) func() {
 	events := getEvents(ctx)
@@ -47,3 +66,47 @@ func EventsEmit(ctx context.Context, eventName string, optionalData ...interface
 	events := getEvents(ctx)
 	events.Emit(eventName, optionalData...)
 }
+
+// EventsEmitWithPriority emits the event like EventsEmit. When the frontend can't keep up, the pending events are
+// delivered in the order of their priority. Events that have been passed over too often are delivered ahead of
+// higher priorities, so low priority events aren't starved.
+func EventsEmitWithPriority(ctx context.Context, priority EventPriority, eventName string, optionalData ...interface{}) {
+	events := getEvents(ctx)
+	events.EmitWithPriority(priority, eventName, optionalData...)
+}
+
+// EventsRegisterSchema registers the types of the data of the event, with one sample value per argument of
+// EventsEmit, EG: EventsRegisterSchema(ctx, "user:updated", User{}). In dev builds the data of every emit of the event,
+// also by the frontend, is checked against the types: the data must decode into the types without unknown fields and
+// have the fields of structs that aren't tagged with omitempty. Mismatches are logged as warnings with the stack of
+// the emit. Data isn't checked in production builds.
+func EventsRegisterSchema(ctx context.Context, eventName string, samples ...interface{}) {
+	events := getEvents(ctx)
+	events.RegisterSchema(eventName, samples...)
+}
+
+// EventsQueueStats returns the depths and counters of the queues of the event delivery to the frontend
+func EventsQueueStats(ctx context.Context) EventQueueStats {
+	events := getEvents(ctx)
+	return events.QueueStats()
+}
+
+// InstanceEventsEmit sends the event to all other running instances of the application. The other instances receive
+// it as a regular event with an options.InstanceEvent as data. Requires the InstanceEvents application option.
+func InstanceEventsEmit(ctx context.Context, eventName string, optionalData ...interface{}) error {
+	bus, ok := ctx.Value("instanceevents").(*instanceevents.Bus)
+	if !ok {
+		return errors.New("instance events are not enabled")
+	}
+	return bus.Emit(eventName, optionalData...)
+}
+
+// InstanceID returns the identifier of this instance that is sent with its instance events. It is empty when
+// instance events are not enabled.
+func InstanceID(ctx context.Context) string {
+	bus, ok := ctx.Value("instanceevents").(*instanceevents.Bus)
+	if !ok {
+		return ""
+	}
+	return bus.ID()
+}
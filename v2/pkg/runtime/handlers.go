@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrHandlerNotFound is returned by HandlerCall when no handler has been registered with the name in JS
+var ErrHandlerNotFound = errors.New("no JS handler has been registered with this name")
+
+// HandlerError is returned by HandlerCall when the JS handler throws or its promise is rejected
+type HandlerError struct {
+	Name    string
+	Message string
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("JS handler %s failed: %s", e.Name, e.Message)
+}
+
+// handlerReply is the result of a handler that is reported by the frontend
+type handlerReply struct {
+	Result   json.RawMessage `json:"result"`
+	Error    *string         `json:"error"`
+	NotFound bool            `json:"notFound"`
+}
+
+// HandlerCall calls the handler that has been registered with HandlerRegister in JS with the given arguments and
+// waits for its result, which is unmarshalled into result unless it is nil. The arguments are marshalled to JSON.
+// The call is abandoned when ctx is done, use context.WithTimeout to limit how long to wait for the frontend.
+func HandlerCall(ctx context.Context, name string, result any, args ...any) error {
+	appFrontend := getFrontend(ctx)
+	events := getEvents(ctx)
+
+	if args == nil {
+		args = []any{}
+	}
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("unable to marshal the arguments of JS handler %s: %w", name, err)
+	}
+
+	id := make([]byte, 8)
+	_, err = rand.Read(id)
+	if err != nil {
+		return err
+	}
+	callID := hex.EncodeToString(id)
+
+	replies := make(chan handlerReply, 1)
+	cancel := events.Once("wails:handler:"+callID, func(optionalData ...interface{}) {
+		var reply handlerReply
+		if len(optionalData) > 0 {
+			// The data of the event has been unmarshalled by the dispatcher
+			data, err := json.Marshal(optionalData[0])
+			if err == nil {
+				_ = json.Unmarshal(data, &reply)
+			}
+		}
+		replies <- reply
+	})
+	defer cancel()
+
+	appFrontend.ExecJS(fmt.Sprintf("window.wails && window.wails.CallHandler(%q, %q, %s);", callID, name, arguments))
+
+	select {
+	case reply := <-replies:
+		switch {
+		case reply.NotFound:
+			return fmt.Errorf("%w: %s", ErrHandlerNotFound, name)
+		case reply.Error != nil:
+			return &HandlerError{Name: name, Message: *reply.Error}
+		case result != nil && len(reply.Result) > 0:
+			if err := json.Unmarshal(reply.Result, result); err != nil {
+				return fmt.Errorf("unable to unmarshal the result of JS handler %s: %w", name, err)
+			}
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("calling JS handler %s: %w", name, ctx.Err())
+	}
+}
@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// mediaMIMETypes are the MIME types of the media files that are missing in the MIME tables of some platforms
+var mediaMIMETypes = map[string]string{
+	".heic": "image/heic",
+	".heif": "image/heif",
+	".bmp":  "image/bmp",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".mp4":  "video/mp4",
+	".m4v":  "video/x-m4v",
+	".mov":  "video/quicktime",
+	".avi":  "video/x-msvideo",
+	".mkv":  "video/x-matroska",
+	".webm": "video/webm",
+	".wmv":  "video/x-ms-wmv",
+}
+
+// MediaFile is a file that has been selected with OpenMediaDialog. Files from the photo library of macOS are copies
+// in the temporary directory.
+type MediaFile struct {
+	Path     string `json:"path"`
+	Name     string `json:"name"`
+	MIMEType string `json:"mimeType"`
+}
+
+func newMediaFile(path string) MediaFile {
+	extension := strings.ToLower(filepath.Ext(path))
+	mimeType, ok := mediaMIMETypes[extension]
+	if !ok {
+		mimeType, _, _ = strings.Cut(mime.TypeByExtension(extension), ";")
+	}
+	return MediaFile{
+		Path:     path,
+		Name:     filepath.Base(path),
+		MIMEType: mimeType,
+	}
+}
+
+// IsImage returns true if the file is an image
+func (f MediaFile) IsImage() bool {
+	return strings.HasPrefix(f.MIMEType, "image/")
+}
+
+// IsVideo returns true if the file is a video
+func (f MediaFile) IsVideo() bool {
+	return strings.HasPrefix(f.MIMEType, "video/")
+}
+
+// Open opens the file for reading
+func (f MediaFile) Open() (*os.File, error) {
+	return os.Open(f.Path)
+}
+
+// Thumbnail returns a PNG of the image that fits into a square of the size, EG: to show a preview in the frontend.
+// JPEG, PNG, GIF, BMP, TIFF and WebP images are supported. Images smaller than the size are not enlarged.
+func (f MediaFile) Thumbnail(size int) ([]byte, error) {
+	if size <= 0 {
+		return nil, errors.New("the size of the thumbnail must be positive")
+	}
+	file, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	source, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width > height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), source, bounds, draw.Src, nil)
+
+	var result bytes.Buffer
+	err = png.Encode(&result, thumbnail)
+	return result.Bytes(), err
+}
@@ -0,0 +1,47 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// MediaCaptureEvent is emitted with the MediaCaptureState when the page starts or stops a capture.
+// This requires the EnableMediaCaptureEvents application option.
+const MediaCaptureEvent = "wails:media-capture"
+
+// MediaCaptureState reports which devices the page is capturing
+type MediaCaptureState struct {
+	Camera     bool `json:"camera"`
+	Microphone bool `json:"microphone"`
+	Screen     bool `json:"screen"`
+}
+
+// MediaCaptureKind is a kind of capture
+type MediaCaptureKind string
+
+const (
+	MediaCaptureAll        MediaCaptureKind = ""
+	MediaCaptureCamera     MediaCaptureKind = "camera"
+	MediaCaptureMicrophone MediaCaptureKind = "microphone"
+	MediaCaptureScreen     MediaCaptureKind = "screen"
+)
+
+// OnMediaCaptureChange registers a callback for changes of the capture state. It returns a function to unregister
+// the callback.
+func OnMediaCaptureChange(ctx context.Context, callback func(state MediaCaptureState)) func() {
+	return EventsOn(ctx, MediaCaptureEvent, func(optionalData ...interface{}) {
+		if len(optionalData) == 0 {
+			return
+		}
+		if state, ok := optionalData[0].(MediaCaptureState); ok {
+			callback(state)
+		}
+	})
+}
+
+// MediaCaptureStop stops the capture of the kind, or all captures for MediaCaptureAll
+func MediaCaptureStop(ctx context.Context, kind MediaCaptureKind) {
+	appFrontend := getFrontend(ctx)
+	argument, _ := json.Marshal(string(kind))
+	appFrontend.ExecJS("window._wailsMediaCapture && window._wailsMediaCapture.stop(" + string(argument) + ");")
+}
@@ -0,0 +1,46 @@
+package runtime
+
+import "context"
+
+// PerformanceEvent is emitted with the PerformanceMetrics of the page every interval.
+// This requires the PerformanceMetrics application option.
+const PerformanceEvent = "wails:performance"
+
+// PerformanceMetrics are the frame timings and the long tasks of the page since the previous report. The durations
+// are in milliseconds.
+type PerformanceMetrics struct {
+	URL string `json:"url"`
+	// Duration is the time the metrics have been collected over
+	Duration float64 `json:"duration"`
+
+	// Frames is the number of frames rendered while the page was visible and SlowFrames the number of them that took
+	// longer than the SlowFrameThreshold
+	Frames       int     `json:"frames"`
+	SlowFrames   int     `json:"slowFrames"`
+	FrameTimeP50 float64 `json:"frameTimeP50"`
+	FrameTimeP95 float64 `json:"frameTimeP95"`
+	FrameTimeMax float64 `json:"frameTimeMax"`
+
+	// ScrollFrames are the frames rendered while the page was scrolled
+	ScrollFrames     int `json:"scrollFrames"`
+	ScrollSlowFrames int `json:"scrollSlowFrames"`
+
+	// LongTasks are the tasks that blocked the main thread for more than 50 milliseconds. They are only reported by
+	// WebView2, LongTasksSupported is false on the other webviews.
+	LongTasks          int     `json:"longTasks"`
+	LongTaskTime       float64 `json:"longTaskTime"`
+	LongTasksSupported bool    `json:"longTasksSupported"`
+}
+
+// OnPerformanceMetrics registers a callback for the reports of the performance metrics. It returns a function to
+// unregister the callback.
+func OnPerformanceMetrics(ctx context.Context, callback func(metrics PerformanceMetrics)) func() {
+	return EventsOn(ctx, PerformanceEvent, func(optionalData ...interface{}) {
+		if len(optionalData) == 0 {
+			return
+		}
+		if metrics, ok := optionalData[0].(PerformanceMetrics); ok {
+			callback(metrics)
+		}
+	})
+}
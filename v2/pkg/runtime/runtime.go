@@ -3,6 +3,7 @@ package runtime
 import (
 	"context"
 	"log"
+	"os"
 	goruntime "runtime"
 
 	"github.com/wailsapp/wails/v2/internal/frontend"
@@ -87,6 +88,40 @@ func Show(ctx context.Context) {
 	appFrontend.Show()
 }
 
+// Relaunch shuts down the application and starts a new instance of it with the given arguments
+func Relaunch(ctx context.Context, args ...string) {
+	if ctx == nil {
+		log.Fatalf("Error calling 'runtime.Relaunch': %s", contextError)
+	}
+	relaunch, ok := ctx.Value("relaunch").(func([]string))
+	if !ok {
+		log.Fatalf("Error calling 'runtime.Relaunch': %s", contextError)
+	}
+	relaunch(args)
+}
+
+// Restart shuts down the application and starts a new instance of it with the arguments it has been started with
+func Restart(ctx context.Context) {
+	if ctx == nil {
+		log.Fatalf("Error calling 'runtime.Restart': %s", contextError)
+	}
+	Relaunch(ctx, os.Args[1:]...)
+}
+
+// RelaunchElevated shuts down the application and starts a new instance of it with elevated privileges and the given
+// arguments. The user is asked for permission once the shutdown has completed, wails.Run returns
+// elevate.ErrCancelled if the user declines.
+func RelaunchElevated(ctx context.Context, args ...string) {
+	if ctx == nil {
+		log.Fatalf("Error calling 'runtime.RelaunchElevated': %s", contextError)
+	}
+	relaunch, ok := ctx.Value("relaunchelevated").(func([]string))
+	if !ok {
+		log.Fatalf("Error calling 'runtime.RelaunchElevated': %s", contextError)
+	}
+	relaunch(args)
+}
+
 // EnvironmentInfo contains information about the environment
 type EnvironmentInfo struct {
 	BuildType string `json:"buildType"`
@@ -105,3 +140,48 @@ func Environment(ctx context.Context) EnvironmentInfo {
 	result.Arch = goruntime.GOARCH
 	return result
 }
+
+// IsFirstRun returns true if no version stamp of a previous run has been found.
+// This requires the Migration application option.
+func IsFirstRun(ctx context.Context) bool {
+	firstRun, _ := ctx.Value("firstrun").(bool)
+	return firstRun
+}
+
+// PreviousVersion returns the version of the application that has been used in the previous run.
+// It returns an empty string on the first run or if the Migration application option is not used.
+func PreviousVersion(ctx context.Context) string {
+	previousVersion, _ := ctx.Value("previousversion").(string)
+	return previousVersion
+}
+
+// AppDataDir returns the directory the application should store its data in. In portable mode this is the
+// `data` directory next to the executable, otherwise a directory named after the executable in the user config dir.
+func AppDataDir(ctx context.Context) string {
+	appDataDir, _ := ctx.Value("appdatadir").(string)
+	return appDataDir
+}
+
+// IsPortable returns true if the application is running in portable mode
+func IsPortable(ctx context.Context) bool {
+	portable, _ := ctx.Value("portable").(bool)
+	return portable
+}
+
+// BuildProfile contains the build profile the application has been built with
+type BuildProfile struct {
+	Name  string            `json:"name"`
+	Flags map[string]string `json:"flags"`
+}
+
+// BuildInfo returns the build profile that has been selected with `wails build -profile`. The name is empty if no
+// profile has been used.
+func BuildInfo(ctx context.Context) BuildProfile {
+	var result BuildProfile
+	result.Name, _ = ctx.Value("buildprofile").(string)
+	result.Flags, _ = ctx.Value("buildflags").(map[string]string)
+	if result.Flags == nil {
+		result.Flags = map[string]string{}
+	}
+	return result
+}
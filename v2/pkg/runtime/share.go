@@ -0,0 +1,24 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+)
+
+// ShareOptions contains the options for the Share runtime method
+type ShareOptions = frontend.ShareOptions
+
+// ShareAnchor is the rectangle of the UI element the share sheet is positioned relative to
+type ShareAnchor = frontend.ShareAnchor
+
+// Share shows the share sheet of the platform with the text, the URL and the files of the options: the share UI of
+// Windows, the sharing service picker on macOS and the email portal on Linux, which has no share sheet. It returns
+// when the share sheet is shown, not when the content has been shared.
+func Share(ctx context.Context, options ShareOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	appFrontend := getFrontend(ctx)
+	return appFrontend.Share(options)
+}
@@ -3,9 +3,16 @@ package runtime
 import (
 	"context"
 
+	"github.com/wailsapp/wails/v2/internal/frontend"
 	"github.com/wailsapp/wails/v2/pkg/options"
 )
 
+// PDFOptions contains the options for WindowPrintToPDF
+type PDFOptions = frontend.PDFOptions
+
+// PDFMargins are the margins of the pages in inches
+type PDFMargins = frontend.PDFMargins
+
 // WindowSetTitle sets the title of the window
 func WindowSetTitle(ctx context.Context, title string) {
 	appFrontend := getFrontend(ctx)
@@ -57,6 +64,23 @@ func WindowSetDarkTheme(ctx context.Context) {
 	appFrontend.WindowSetDarkTheme()
 }
 
+// ColorSchemeEvent is emitted by the frontend with "dark" or "light" when the color scheme of the window changes,
+// because the system theme changed or a theme has been forced
+const ColorSchemeEvent = "wails:color-scheme"
+
+// OnColorSchemeChange registers a callback for changes of the color scheme of the window. It returns a function to
+// unregister the callback.
+func OnColorSchemeChange(ctx context.Context, callback func(scheme string)) func() {
+	return EventsOn(ctx, ColorSchemeEvent, func(optionalData ...interface{}) {
+		if len(optionalData) == 0 {
+			return
+		}
+		if scheme, ok := optionalData[0].(string); ok {
+			callback(scheme)
+		}
+	})
+}
+
 // WindowShow shows the window if hidden
 func WindowShow(ctx context.Context) {
 	appFrontend := getFrontend(ctx)
@@ -98,6 +122,12 @@ func WindowSetAlwaysOnTop(ctx context.Context, b bool) {
 	appFrontend.WindowSetAlwaysOnTop(b)
 }
 
+// WindowSetSkipTaskbar hides the window from the taskbar and the window switcher or shows it again
+func WindowSetSkipTaskbar(ctx context.Context, skip bool) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowSetSkipTaskbar(skip)
+}
+
 // WindowSetPosition sets the position of the window
 func WindowSetPosition(ctx context.Context, x int, y int) {
 	appFrontend := getFrontend(ctx)
@@ -184,3 +214,93 @@ func WindowPrint(ctx context.Context) {
 	appFrontend := getFrontend(ctx)
 	appFrontend.WindowPrint()
 }
+
+// WindowPrintToPDF prints the page of the window to a PDF without showing a print dialog and returns the document
+func WindowPrintToPDF(ctx context.Context, options PDFOptions) ([]byte, error) {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.WindowPrintToPDF(options)
+}
+
+// WindowCaptureSnapshot captures the visible content of the webview and returns it as a PNG, which can be decoded with
+// image/png
+func WindowCaptureSnapshot(ctx context.Context) ([]byte, error) {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.WindowCaptureSnapshot()
+}
+
+// WindowSetZoom sets the zoom factor of the page, EG: 1.5 for 150%. The factor is limited by options.Zoom.
+func WindowSetZoom(ctx context.Context, factor float64) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowSetZoom(factor)
+}
+
+// WindowGetZoom returns the zoom factor of the page
+func WindowGetZoom(ctx context.Context) float64 {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.WindowGetZoom()
+}
+
+// WindowZoomIn zooms the page in to the next zoom level
+func WindowZoomIn(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowSetZoom(frontend.ZoomIn(appFrontend.WindowGetZoom()))
+}
+
+// WindowZoomOut zooms the page out to the previous zoom level
+func WindowZoomOut(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowSetZoom(frontend.ZoomOut(appFrontend.WindowGetZoom()))
+}
+
+// WindowZoomReset resets the zoom factor of the page to 1
+func WindowZoomReset(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowSetZoom(1)
+}
+
+// WindowSetAudioMuted mutes or unmutes the audio of the page, the media keeps playing while it is muted
+func WindowSetAudioMuted(ctx context.Context, muted bool) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowSetAudioMuted(muted)
+}
+
+// WindowIsAudioMuted returns true if the audio of the page is muted
+func WindowIsAudioMuted(ctx context.Context) bool {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.WindowIsAudioMuted()
+}
+
+// WindowRecycle loads the application frontend again. On Linux the content process of the webview is terminated
+// first, on macOS and Windows the content process is kept and only the page is loaded again.
+func WindowRecycle(ctx context.Context) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowRecycle()
+}
+
+// WindowAddUserContent injects the user script or stylesheet into every page that is loaded by the window from now
+// on. It returns the id of the content, which is used to remove it again.
+func WindowAddUserContent(ctx context.Context, content options.UserContent) (string, error) {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.WindowAddUserContent(content)
+}
+
+// WindowRemoveUserContent stops injecting the user content with the given id. Pages that have already been loaded
+// are not changed.
+func WindowRemoveUserContent(ctx context.Context, id string) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowRemoveUserContent(id)
+}
+
+// WindowEmbedNativeView hosts a native control in the window, above the webview: a HWND on Windows, a NSView on macOS
+// or a GtkWidget on Linux. The control is hidden until the placeholder element with the same id is tracked with
+// WindowTrackNativeView in JS, then it follows the bounds of the placeholder.
+func WindowEmbedNativeView(ctx context.Context, id string, handle uintptr) error {
+	appFrontend := getFrontend(ctx)
+	return appFrontend.WindowEmbedNativeView(id, handle)
+}
+
+// WindowRemoveNativeView removes the native control with the given id from the window
+func WindowRemoveNativeView(ctx context.Context, id string) {
+	appFrontend := getFrontend(ctx)
+	appFrontend.WindowRemoveNativeView(id)
+}
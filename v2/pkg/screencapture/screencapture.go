@@ -0,0 +1,153 @@
+// Package screencapture takes screenshots and starts screen casts with the consent of the user. On Linux it uses the
+// Screenshot and ScreenCast portals of xdg-desktop-portal with PipeWire, which also work on Wayland and in Flatpak and
+// Snap sandboxes. Bind the ScreenCapture service to use it from JS.
+package screencapture
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// ErrNotSupported is returned when the platform doesn't provide the feature
+var ErrNotSupported = errors.New("screencapture: not supported on this platform")
+
+// ErrCancelled is returned when the user cancels the screenshot or the screen cast
+var ErrCancelled = errors.New("screencapture: cancelled by the user")
+
+// ErrNoScreenCast is returned when no screen cast is running
+var ErrNoScreenCast = errors.New("screencapture: no screen cast is running")
+
+// Source is a type of source of a screen cast
+type Source string
+
+const (
+	SourceMonitor Source = "monitor"
+	SourceWindow  Source = "window"
+	// SourceVirtual is a virtual monitor that is created for the screen cast
+	SourceVirtual Source = "virtual"
+)
+
+// CursorMode is the mode of the cursor in a screen cast
+type CursorMode string
+
+const (
+	CursorHidden   CursorMode = "hidden"
+	CursorEmbedded CursorMode = "embedded"
+	// CursorMetadata provides the cursor as metadata of the stream
+	CursorMetadata CursorMode = "metadata"
+)
+
+// ScreenshotOptions are the options of a screenshot
+type ScreenshotOptions struct {
+	// Interactive lets the user choose the area of the screenshot
+	Interactive bool `json:"interactive"`
+}
+
+// ScreenCastOptions are the options of a screen cast. The zero values use the defaults of the platform.
+type ScreenCastOptions struct {
+	// Sources are the types of sources that the user can choose from. The default is SourceMonitor.
+	Sources []Source `json:"sources"`
+	// Multiple lets the user choose more than one source
+	Multiple bool       `json:"multiple"`
+	Cursor   CursorMode `json:"cursor"`
+}
+
+// Stream is a stream of a screen cast
+type Stream struct {
+	// NodeID is the ID of the PipeWire node of the stream
+	NodeID uint32 `json:"nodeId"`
+	Source Source `json:"source"`
+	// X, Y, Width and Height are the position and the size of the source in the compositor, if known
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// session is a running screen cast of the platform
+type session interface {
+	pipeWireRemote() (*os.File, error)
+	close() error
+}
+
+// ScreenCapture is the screen capture service
+type ScreenCapture struct {
+	// RestoreToken restores the sources of the last screen cast without asking the user again. It is updated after
+	// each screen cast and can be set to the stored token of an earlier run.
+	RestoreToken string
+	// OnRestoreToken is called with the new restore token after a screen cast has started, EG: to store it for the
+	// next run
+	OnRestoreToken func(token string)
+
+	lock    sync.Mutex
+	session session
+	streams []Stream
+}
+
+// New creates the screen capture service
+func New() *ScreenCapture {
+	return &ScreenCapture{}
+}
+
+// Screenshot takes a screenshot and returns the path of the image
+func (s *ScreenCapture) Screenshot(options ScreenshotOptions) (string, error) {
+	return screenshot(options)
+}
+
+// StartScreenCast asks the user for the sources of a screen cast, or restores the sources of the last screen cast,
+// and returns its streams. A running screen cast is stopped.
+func (s *ScreenCapture) StartScreenCast(options ScreenCastOptions) ([]Stream, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.session != nil {
+		_ = s.session.close()
+		s.session, s.streams = nil, nil
+	}
+	if len(options.Sources) == 0 {
+		options.Sources = []Source{SourceMonitor}
+	}
+	started, streams, token, err := startScreenCast(options, s.RestoreToken)
+	if err != nil {
+		return nil, err
+	}
+	s.session, s.streams = started, streams
+	// A restore token can only be used once
+	if token != s.RestoreToken {
+		s.RestoreToken = token
+		if s.OnRestoreToken != nil {
+			s.OnRestoreToken(token)
+		}
+	}
+	return streams, nil
+}
+
+// Streams returns the streams of the running screen cast
+func (s *ScreenCapture) Streams() []Stream {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.streams
+}
+
+// StopScreenCast stops the running screen cast
+func (s *ScreenCapture) StopScreenCast() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.session == nil {
+		return ErrNoScreenCast
+	}
+	err := s.session.close()
+	s.session, s.streams = nil, nil
+	return err
+}
+
+// PipeWireRemote returns the PipeWire connection that provides the streams of the running screen cast, EG: to pass
+// to GStreamer's pipewiresrc. It isn't a method, so it isn't bound to JS. The caller closes the file.
+func PipeWireRemote(s *ScreenCapture) (*os.File, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.session == nil {
+		return nil, ErrNoScreenCast
+	}
+	return s.session.pipeWireRemote()
+}
@@ -0,0 +1,96 @@
+//go:build linux
+
+package screencapture
+
+import (
+	"errors"
+	"os"
+
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
+)
+
+var sourceTypes = map[Source]uint32{
+	SourceMonitor: portal.SourceMonitor,
+	SourceWindow:  portal.SourceWindow,
+	SourceVirtual: portal.SourceVirtual,
+}
+
+var cursorModes = map[CursorMode]uint32{
+	CursorHidden:   portal.CursorHidden,
+	CursorEmbedded: portal.CursorEmbedded,
+	CursorMetadata: portal.CursorMetadata,
+}
+
+func screenshot(options ScreenshotOptions) (string, error) {
+	path, err := portal.Screenshot("", options.Interactive)
+	return path, portalError(err)
+}
+
+// portalOptions converts the options to the options of the ScreenCast portal
+func portalOptions(options ScreenCastOptions, restoreToken string) (portal.ScreenCastOptions, error) {
+	result := portal.ScreenCastOptions{Multiple: options.Multiple, RestoreToken: restoreToken}
+	for _, source := range options.Sources {
+		sourceType, ok := sourceTypes[source]
+		if !ok {
+			return result, errors.New("screencapture: unknown source " + string(source))
+		}
+		result.SourceTypes |= sourceType
+	}
+	if options.Cursor != "" {
+		mode, ok := cursorModes[options.Cursor]
+		if !ok {
+			return result, errors.New("screencapture: unknown cursor mode " + string(options.Cursor))
+		}
+		result.CursorMode = mode
+	}
+	return result, nil
+}
+
+func streams(cast *portal.ScreenCast) []Stream {
+	result := make([]Stream, 0, len(cast.Streams))
+	for _, stream := range cast.Streams {
+		converted := Stream{NodeID: stream.NodeID, X: stream.X, Y: stream.Y, Width: stream.Width, Height: stream.Height}
+		for source, sourceType := range sourceTypes {
+			if stream.SourceType == sourceType {
+				converted.Source = source
+			}
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+func startScreenCast(options ScreenCastOptions, restoreToken string) (session, []Stream, string, error) {
+	castOptions, err := portalOptions(options, restoreToken)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	cast, err := portal.StartScreenCast("", castOptions)
+	if err != nil {
+		return nil, nil, "", portalError(err)
+	}
+	return portalSession{cast}, streams(cast), cast.RestoreToken, nil
+}
+
+// portalError converts the errors of the portal to the errors of the package
+func portalError(err error) error {
+	switch {
+	case errors.Is(err, portal.ErrCancelled):
+		return ErrCancelled
+	case errors.Is(err, portal.ErrUnavailable):
+		return errors.Join(ErrNotSupported, err)
+	}
+	return err
+}
+
+type portalSession struct {
+	cast *portal.ScreenCast
+}
+
+func (s portalSession) pipeWireRemote() (*os.File, error) {
+	return s.cast.OpenPipeWireRemote()
+}
+
+func (s portalSession) close() error {
+	return s.cast.Close()
+}
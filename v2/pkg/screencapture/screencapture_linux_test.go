@@ -0,0 +1,39 @@
+//go:build linux
+
+package screencapture
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/linux/portal"
+)
+
+func TestPortalOptions(t *testing.T) {
+	is2 := is.New(t)
+	options, err := portalOptions(ScreenCastOptions{Sources: []Source{SourceMonitor, SourceWindow}, Multiple: true, Cursor: CursorEmbedded}, "token")
+	is2.NoErr(err)
+	is2.Equal(options, portal.ScreenCastOptions{
+		SourceTypes:  portal.SourceMonitor | portal.SourceWindow,
+		Multiple:     true,
+		CursorMode:   portal.CursorEmbedded,
+		RestoreToken: "token",
+	})
+
+	_, err = portalOptions(ScreenCastOptions{Sources: []Source{"tab"}}, "")
+	is2.True(err != nil)
+	_, err = portalOptions(ScreenCastOptions{Cursor: "blinking"}, "")
+	is2.True(err != nil)
+}
+
+func TestStreams(t *testing.T) {
+	is2 := is.New(t)
+	converted := streams(&portal.ScreenCast{Streams: []portal.Stream{
+		{NodeID: 42, SourceType: portal.SourceWindow, Width: 800, Height: 600},
+		{NodeID: 43},
+	}})
+	is2.Equal(converted, []Stream{
+		{NodeID: 42, Source: SourceWindow, Width: 800, Height: 600},
+		{NodeID: 43},
+	})
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package screencapture
+
+func screenshot(ScreenshotOptions) (string, error) {
+	return "", ErrNotSupported
+}
+
+func startScreenCast(ScreenCastOptions, string) (session, []Stream, string, error) {
+	return nil, nil, "", ErrNotSupported
+}
@@ -0,0 +1,10 @@
+#ifndef Session_darwin_h
+#define Session_darwin_h
+
+#include <stdbool.h>
+
+void SessionState(bool *locked, bool *active);
+void* SessionWatch(int watcher);
+void SessionStopWatching(void *observers);
+
+#endif /* Session_darwin_h */
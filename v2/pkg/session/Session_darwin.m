@@ -0,0 +1,64 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AppKit/AppKit.h>
+#import <CoreGraphics/CoreGraphics.h>
+
+#import "Session_darwin.h"
+
+extern void processSessionChange(int watcher, char *change);
+
+void SessionState(bool *locked, bool *active) {
+    *locked = false;
+    *active = true;
+    CFDictionaryRef session = CGSessionCopyCurrentDictionary();
+    if (session == NULL) {
+        return;
+    }
+    NSDictionary *properties = (NSDictionary*)session;
+    *locked = [properties[@"CGSSessionScreenIsLocked"] boolValue];
+    NSNumber *onConsole = properties[(NSString*)kCGSessionOnConsoleKey];
+    if (onConsole != nil) {
+        *active = [onConsole boolValue];
+    }
+    CFRelease(session);
+}
+
+// SessionWatch observes the notifications of the fast user switching and of the screen lock for the watcher. It
+// returns the observers, which are removed by SessionStopWatching.
+void* SessionWatch(int watcher) {
+    NSMutableArray *observers = [NSMutableArray new];
+    @autoreleasepool {
+        NSNotificationCenter *workspaceCenter = [[NSWorkspace sharedWorkspace] notificationCenter];
+        NSDistributedNotificationCenter *distributedCenter = [NSDistributedNotificationCenter defaultCenter];
+        NSDictionary *workspaceNotifications = @{
+            NSWorkspaceSessionDidBecomeActiveNotification: @"activated",
+            NSWorkspaceSessionDidResignActiveNotification: @"deactivated",
+        };
+        for (NSString *name in workspaceNotifications) {
+            NSString *change = workspaceNotifications[name];
+            [observers addObject:@[workspaceCenter, [workspaceCenter addObserverForName:name object:nil queue:nil usingBlock:^(NSNotification *notification) {
+                processSessionChange(watcher, (char*)[change UTF8String]);
+            }]]];
+        }
+        NSDictionary *distributedNotifications = @{
+            @"com.apple.screenIsLocked": @"locked",
+            @"com.apple.screenIsUnlocked": @"unlocked",
+        };
+        for (NSString *name in distributedNotifications) {
+            NSString *change = distributedNotifications[name];
+            [observers addObject:@[distributedCenter, [distributedCenter addObserverForName:name object:nil queue:nil usingBlock:^(NSNotification *notification) {
+                processSessionChange(watcher, (char*)[change UTF8String]);
+            }]]];
+        }
+    }
+    return observers;
+}
+
+void SessionStopWatching(void *observers) {
+    NSMutableArray *array = (NSMutableArray*)observers;
+    for (NSArray *observer in array) {
+        [(NSNotificationCenter*)observer[0] removeObserver:observer[1]];
+    }
+    [array release];
+}
@@ -0,0 +1,133 @@
+// Package session notices the changes of the desktop session of the user on shared machines: when the session is
+// locked and unlocked, when another user switches to their session and back and when a remote desktop client connects
+// and disconnects, EG: to pause syncing or to lock the UI of the application. The changes are the WTS session
+// notifications on Windows, the notifications of the workspace and of the screen lock on macOS and the properties of
+// the logind session on Linux. Bind the Session service to use it from JS.
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ChangeEvent is emitted by EmitEvents with the Change and the State after the change
+const ChangeEvent = "session:change"
+
+// Change is a change of the session
+type Change string
+
+const (
+	Locked   Change = "locked"
+	Unlocked Change = "unlocked"
+	// Deactivated is reported when the session is no longer shown on the display, EG: when another user switches to
+	// their session
+	Deactivated Change = "deactivated"
+	// Activated is reported when the session is shown on the display again
+	Activated Change = "activated"
+	// RemoteConnected is reported when a remote desktop client connects to the session. It is only reported on
+	// Windows.
+	RemoteConnected Change = "remoteconnected"
+	// RemoteDisconnected is reported when the remote desktop client disconnects. It is only reported on Windows.
+	RemoteDisconnected Change = "remotedisconnected"
+)
+
+// ErrNotSupported is returned when the platform doesn't report the changes of the session, EG: on Linux without
+// logind
+var ErrNotSupported = errors.New("session: session changes are not supported")
+
+// State is the state of the session
+type State struct {
+	Locked bool `json:"locked"`
+	// Active is false while the session isn't shown on the display, EG: while another user uses the computer
+	Active bool `json:"active"`
+	// Remote is true while the session is shown by a remote desktop client
+	Remote bool `json:"remote"`
+}
+
+type backend interface {
+	state() (State, error)
+	// watch calls changed with the changes of the session until stop is called
+	watch(changed func(Change)) (stop func(), err error)
+}
+
+// Session is the session service
+type Session struct {
+	// OnChange is called with the change and the state after the change
+	OnChange func(change Change, state State)
+
+	backend backend
+	lock    sync.Mutex
+	current State
+	stop    func()
+}
+
+// New creates the session service
+func New() *Session {
+	return &Session{backend: newBackend()}
+}
+
+// EmitEvents emits ChangeEvent to the frontend
+func EmitEvents(ctx context.Context, s *Session) {
+	s.OnChange = func(change Change, state State) {
+		runtime.EventsEmit(ctx, ChangeEvent, change, state)
+	}
+}
+
+// State returns the state of the session
+func (s *Session) State() (State, error) {
+	return s.backend.state()
+}
+
+// Watch calls OnChange until Stop is called
+func (s *Session) Watch() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stop != nil {
+		return nil
+	}
+	state, err := s.backend.state()
+	if err != nil {
+		return err
+	}
+	s.current = state
+	stop, err := s.backend.watch(s.changed)
+	if err != nil {
+		return err
+	}
+	s.stop = stop
+	return nil
+}
+
+// Stop stops watching
+func (s *Session) Stop() {
+	s.lock.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.lock.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+// changed calls OnChange if the change changes the state, as some platforms report a change more than once
+func (s *Session) changed(change Change) {
+	s.lock.Lock()
+	state := s.current
+	switch change {
+	case Locked, Unlocked:
+		state.Locked = change == Locked
+	case Activated, Deactivated:
+		state.Active = change == Activated
+	case RemoteConnected, RemoteDisconnected:
+		state.Remote = change == RemoteConnected
+	}
+	updated := state != s.current
+	s.current = state
+	s.lock.Unlock()
+	if updated && s.OnChange != nil {
+		s.OnChange(change, state)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build darwin
+
+package session
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AppKit -framework CoreGraphics
+
+#import "Session_darwin.h"
+*/
+import "C"
+
+import "sync"
+
+// workspaceBackend observes the session notifications of NSWorkspace and the notifications of the screen lock. The
+// notifications are delivered by the main run loop of the application. macOS doesn't notify about Screen Sharing, so
+// the session is never remote.
+type workspaceBackend struct{}
+
+var (
+	watchersLock sync.Mutex
+	watchers     = map[int]func(Change){}
+	nextWatcher  int
+)
+
+//export processSessionChange
+func processSessionChange(watcher C.int, change *C.char) {
+	watchersLock.Lock()
+	changed := watchers[int(watcher)]
+	watchersLock.Unlock()
+	if changed != nil {
+		changed(Change(C.GoString(change)))
+	}
+}
+
+func newBackend() backend {
+	return workspaceBackend{}
+}
+
+func (workspaceBackend) state() (State, error) {
+	var locked, active C.bool
+	C.SessionState(&locked, &active)
+	return State{Locked: bool(locked), Active: bool(active)}, nil
+}
+
+func (workspaceBackend) watch(changed func(Change)) (func(), error) {
+	watchersLock.Lock()
+	id := nextWatcher
+	nextWatcher++
+	watchers[id] = changed
+	watchersLock.Unlock()
+	observers := C.SessionWatch(C.int(id))
+	return func() {
+		C.SessionStopWatching(observers)
+		watchersLock.Lock()
+		delete(watchers, id)
+		watchersLock.Unlock()
+	}, nil
+}
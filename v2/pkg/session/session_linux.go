@@ -0,0 +1,122 @@
+//go:build linux
+
+package session
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	login1Destination = "org.freedesktop.login1"
+	sessionInterface  = "org.freedesktop.login1.Session"
+)
+
+// logindBackend watches the properties and the signals of the logind session of the application. LockedHint is set
+// by the desktops that tell logind about their screen lock, Lock and Unlock are the requests of loginctl.
+type logindBackend struct{}
+
+func newBackend() backend {
+	return logindBackend{}
+}
+
+// sessionPath returns the path of the session, the signals are sent from it and not from the path of the "auto"
+// session
+func sessionPath(conn *dbus.Conn) (dbus.ObjectPath, error) {
+	id, err := conn.Object(login1Destination, "/org/freedesktop/login1/session/auto").GetProperty(sessionInterface + ".Id")
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	var path dbus.ObjectPath
+	err = conn.Object(login1Destination, "/org/freedesktop/login1").
+		Call("org.freedesktop.login1.Manager.GetSession", 0, id.Value()).Store(&path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	return path, nil
+}
+
+func (logindBackend) state() (State, error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return State{}, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	session := conn.Object(login1Destination, "/org/freedesktop/login1/session/auto")
+	var state State
+	for property, value := range map[string]*bool{"LockedHint": &state.Locked, "Active": &state.Active, "Remote": &state.Remote} {
+		variant, err := session.GetProperty(sessionInterface + "." + property)
+		if err != nil {
+			return State{}, fmt.Errorf("%w: %v", ErrNotSupported, err)
+		}
+		*value, _ = variant.Value().(bool)
+	}
+	return state, nil
+}
+
+func (logindBackend) watch(changed func(Change)) (func(), error) {
+	// A private connection, so that the signals stop when it is closed
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	path, err := sessionPath(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for _, match := range [][]dbus.MatchOption{
+		{dbus.WithMatchObjectPath(path), dbus.WithMatchInterface("org.freedesktop.DBus.Properties"), dbus.WithMatchMember("PropertiesChanged")},
+		{dbus.WithMatchObjectPath(path), dbus.WithMatchInterface(sessionInterface)},
+	} {
+		if err := conn.AddMatchSignal(match...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	signals := make(chan *dbus.Signal, 10)
+	conn.Signal(signals)
+	go func() {
+		for signal := range signals {
+			for _, change := range parseSignal(signal) {
+				changed(change)
+			}
+		}
+	}()
+	return func() { conn.Close() }, nil
+}
+
+// parseSignal returns the changes of a signal of the session
+func parseSignal(signal *dbus.Signal) []Change {
+	switch signal.Name {
+	case sessionInterface + ".Lock":
+		return []Change{Locked}
+	case sessionInterface + ".Unlock":
+		return []Change{Unlocked}
+	case "org.freedesktop.DBus.Properties.PropertiesChanged":
+		if len(signal.Body) < 2 {
+			return nil
+		}
+		if iface, _ := signal.Body[0].(string); iface != sessionInterface {
+			return nil
+		}
+		properties, _ := signal.Body[1].(map[string]dbus.Variant)
+		var changes []Change
+		if locked, ok := properties["LockedHint"].Value().(bool); ok {
+			change := Unlocked
+			if locked {
+				change = Locked
+			}
+			changes = append(changes, change)
+		}
+		if active, ok := properties["Active"].Value().(bool); ok {
+			change := Deactivated
+			if active {
+				change = Activated
+			}
+			changes = append(changes, change)
+		}
+		return changes
+	}
+	return nil
+}
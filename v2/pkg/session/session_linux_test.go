@@ -0,0 +1,45 @@
+//go:build linux
+
+package session
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		name   string
+		signal *dbus.Signal
+		want   []Change
+	}{
+		{name: "lock", signal: &dbus.Signal{Name: "org.freedesktop.login1.Session.Lock"}, want: []Change{Locked}},
+		{name: "unlock", signal: &dbus.Signal{Name: "org.freedesktop.login1.Session.Unlock"}, want: []Change{Unlocked}},
+		{
+			name: "properties",
+			signal: &dbus.Signal{Name: "org.freedesktop.DBus.Properties.PropertiesChanged", Body: []interface{}{
+				"org.freedesktop.login1.Session",
+				map[string]dbus.Variant{"LockedHint": dbus.MakeVariant(true), "Active": dbus.MakeVariant(false), "IdleHint": dbus.MakeVariant(true)},
+				[]string{},
+			}},
+			want: []Change{Locked, Deactivated},
+		},
+		{
+			name: "other interface",
+			signal: &dbus.Signal{Name: "org.freedesktop.DBus.Properties.PropertiesChanged", Body: []interface{}{
+				"org.freedesktop.login1.User",
+				map[string]dbus.Variant{"Active": dbus.MakeVariant(true)},
+				[]string{},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSignal(tt.signal); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSignal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !windows
+
+package session
+
+type unsupportedBackend struct{}
+
+func newBackend() backend {
+	return unsupportedBackend{}
+}
+
+func (unsupportedBackend) state() (State, error) {
+	return State{}, ErrNotSupported
+}
+
+func (unsupportedBackend) watch(changed func(Change)) (func(), error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,58 @@
+package session
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeBackend struct {
+	initial State
+	err     error
+	changed func(Change)
+	stopped bool
+}
+
+func (b *fakeBackend) state() (State, error) {
+	return b.initial, b.err
+}
+
+func (b *fakeBackend) watch(changed func(Change)) (func(), error) {
+	b.changed = changed
+	return func() { b.stopped = true }, nil
+}
+
+func TestWatch(t *testing.T) {
+	backend := &fakeBackend{initial: State{Active: true}}
+	s := &Session{backend: backend}
+	var changes []Change
+	var states []State
+	s.OnChange = func(change Change, state State) {
+		changes = append(changes, change)
+		states = append(states, state)
+	}
+	if err := s.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	// Linux reports the lock with the Lock signal and with LockedHint
+	for _, change := range []Change{Locked, Locked, Deactivated, RemoteConnected, Activated, Unlocked} {
+		backend.changed(change)
+	}
+	s.Stop()
+
+	wantChanges := []Change{Locked, Deactivated, RemoteConnected, Activated, Unlocked}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("changes = %v, want %v", changes, wantChanges)
+	}
+	if want := (State{Locked: true, Active: false, Remote: true}); states[2] != want {
+		t.Errorf("state after %s = %+v, want %+v", changes[2], states[2], want)
+	}
+	if !backend.stopped {
+		t.Error("Stop() didn't stop the backend")
+	}
+
+	s = &Session{backend: &fakeBackend{err: ErrNotSupported}}
+	if err := s.Watch(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Watch() = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,171 @@
+//go:build windows
+
+package session
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/wailsapp/wails/v2/internal/frontend/desktop/windows/winc/w32"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	wmWTSSessionChange = 0x02B1
+
+	wtsConsoleConnect    = 0x1
+	wtsConsoleDisconnect = 0x2
+	wtsRemoteConnect     = 0x3
+	wtsRemoteDisconnect  = 0x4
+	wtsSessionLock       = 0x7
+	wtsSessionUnlock     = 0x8
+
+	notifyForThisSession = 0
+	wtsSessionInfoEx     = 25
+	wtsSessionStateLock  = 0
+)
+
+var (
+	wtsapi32                             = windows.NewLazySystemDLL("wtsapi32.dll")
+	procWTSRegisterSessionNotification   = wtsapi32.NewProc("WTSRegisterSessionNotification")
+	procWTSUnRegisterSessionNotification = wtsapi32.NewProc("WTSUnRegisterSessionNotification")
+	procWTSQuerySessionInformation       = wtsapi32.NewProc("WTSQuerySessionInformationW")
+	procWTSFreeMemory                    = wtsapi32.NewProc("WTSFreeMemory")
+	procWTSGetActiveConsoleSessionId     = windows.NewLazySystemDLL("kernel32.dll").NewProc("WTSGetActiveConsoleSessionId")
+)
+
+// sessionChanges are the changes of the WTS notifications
+var sessionChanges = map[uintptr]Change{
+	wtsConsoleConnect:    Activated,
+	wtsConsoleDisconnect: Deactivated,
+	wtsRemoteConnect:     RemoteConnected,
+	wtsRemoteDisconnect:  RemoteDisconnected,
+	wtsSessionLock:       Locked,
+	wtsSessionUnlock:     Unlocked,
+}
+
+// wtsInfoEx is WTSINFOEXW with the beginning of WTSINFOEX_LEVEL1_W, which is aligned to 8 bytes
+type wtsInfoEx struct {
+	level        uint32
+	_            uint32
+	sessionID    uint32
+	sessionState int32
+	sessionFlags int32
+}
+
+// wtsBackend receives the WTS notifications of the session with a message-only window
+type wtsBackend struct{}
+
+func newBackend() backend {
+	return wtsBackend{}
+}
+
+func (wtsBackend) state() (State, error) {
+	var sessionID uint32
+	if err := windows.ProcessIdToSessionId(windows.GetCurrentProcessId(), &sessionID); err != nil {
+		return State{}, err
+	}
+	var info *wtsInfoEx
+	var size uint32
+	result, _, err := procWTSQuerySessionInformation.Call(0, uintptr(sessionID), wtsSessionInfoEx, uintptr(unsafe.Pointer(&info)), uintptr(unsafe.Pointer(&size)))
+	if result == 0 {
+		return State{}, fmt.Errorf("querying the session failed: %w", err)
+	}
+	defer procWTSFreeMemory.Call(uintptr(unsafe.Pointer(info)))
+
+	remote := w32.GetSystemMetrics(w32.SM_REMOTESESSION) != 0
+	consoleSessionID, _, _ := procWTSGetActiveConsoleSessionId.Call()
+	return State{
+		Locked: info.sessionFlags == wtsSessionStateLock,
+		Active: remote || uint32(consoleSessionID) == sessionID,
+		Remote: remote,
+	}, nil
+}
+
+const windowClassName = "wailsSessionNotifications"
+
+var (
+	registerClassOnce sync.Once
+	registerClassErr  error
+
+	watchersLock sync.Mutex
+	watchers     = map[w32.HWND]func(Change){}
+)
+
+func windowProc(hwnd w32.HWND, msg uint32, wparam uintptr, lparam uintptr) uintptr {
+	switch msg {
+	case wmWTSSessionChange:
+		watchersLock.Lock()
+		changed := watchers[hwnd]
+		watchersLock.Unlock()
+		if change, known := sessionChanges[wparam]; known && changed != nil {
+			changed(change)
+		}
+		return 0
+	case w32.WM_DESTROY:
+		procWTSUnRegisterSessionNotification.Call(uintptr(hwnd))
+		watchersLock.Lock()
+		delete(watchers, hwnd)
+		watchersLock.Unlock()
+		w32.PostQuitMessage(0)
+		return 0
+	}
+	return w32.DefWindowProc(hwnd, msg, wparam, lparam)
+}
+
+func registerClass() error {
+	registerClassOnce.Do(func() {
+		class := w32.WNDCLASSEX{
+			WndProc:   syscall.NewCallback(windowProc),
+			Instance:  w32.GetModuleHandle(""),
+			ClassName: windows.StringToUTF16Ptr(windowClassName),
+		}
+		class.Size = uint32(unsafe.Sizeof(class))
+		if w32.RegisterClassEx(&class) == 0 {
+			registerClassErr = fmt.Errorf("registering the window class failed: %w", syscall.GetLastError())
+		}
+	})
+	return registerClassErr
+}
+
+func (wtsBackend) watch(changed func(Change)) (func(), error) {
+	if err := registerClass(); err != nil {
+		return nil, err
+	}
+	created := make(chan w32.HWND)
+	errs := make(chan error, 1)
+	go func() {
+		// The messages of a window are received by the thread that created it
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		hwnd := w32.CreateWindowEx(0, windows.StringToUTF16Ptr(windowClassName), nil, 0, 0, 0, 0, 0, w32.HWND_MESSAGE, 0, w32.GetModuleHandle(""), nil)
+		if hwnd == 0 {
+			errs <- fmt.Errorf("creating the window failed: %w", syscall.GetLastError())
+			return
+		}
+		watchersLock.Lock()
+		watchers[hwnd] = changed
+		watchersLock.Unlock()
+		if result, _, err := procWTSRegisterSessionNotification.Call(uintptr(hwnd), notifyForThisSession); result == 0 {
+			w32.DestroyWindow(hwnd)
+			errs <- fmt.Errorf("registering the session notifications failed: %w", err)
+			return
+		}
+		created <- hwnd
+
+		var msg w32.MSG
+		for w32.GetMessage(&msg, 0, 0, 0) > 0 {
+			w32.TranslateMessage(&msg)
+			w32.DispatchMessage(&msg)
+		}
+	}()
+	select {
+	case hwnd := <-created:
+		return func() { w32.PostMessage(hwnd, w32.WM_CLOSE, 0, 0) }, nil
+	case err := <-errs:
+		return nil, err
+	}
+}
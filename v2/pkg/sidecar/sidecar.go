@@ -0,0 +1,268 @@
+// Package sidecar extracts the helper executables that have been embedded into the application by the Wails CLI.
+// The CLI stages the files of build/sidecars/<os>_<arch>, build/sidecars/<os> and build/sidecars/all for the target
+// of the build into build/sidecars/embedded, which the application embeds:
+//
+//	//go:embed all:build/sidecars/embedded
+//	var sidecars embed.FS
+//
+// Extract writes them to the cache directory of the user on first run and verifies their checksums.
+package sidecar
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/wailsapp/wails/v2/pkg/subprocess"
+)
+
+// ManifestFile is the name of the manifest that is written next to the staged sidecars
+const ManifestFile = "sidecars.json"
+
+// ErrUnknownSidecar is returned for names that are not in the manifest
+var ErrUnknownSidecar = errors.New("sidecar: unknown sidecar")
+
+// Manifest describes the staged sidecars
+type Manifest struct {
+	// Platform is the target of the build, EG: linux/amd64
+	Platform string  `json:"platform"`
+	Sidecars []Entry `json:"sidecars"`
+}
+
+// Entry is a sidecar of the manifest
+type Entry struct {
+	// Name is the filename without the .exe extension on Windows
+	Name string `json:"name"`
+	// File is the filename
+	File   string `json:"file"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Sidecars are the extracted sidecars
+type Sidecars struct {
+	dir   string
+	paths map[string]string
+}
+
+// Extract extracts the sidecars of the embedded directory to the cache directory of the user, EG:
+// ~/.cache/<appName>/sidecars on Linux. Sidecars that have already been extracted are only checked.
+func Extract(fsys fs.FS, appName string) (*Sidecars, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return ExtractTo(fsys, filepath.Join(cacheDir, appName, "sidecars"))
+}
+
+// ExtractTo extracts the sidecars of the embedded directory to the directory. Each version of the sidecars is
+// extracted to its own subdirectory, marked by a copy of the manifest, and the subdirectories of the other versions
+// are removed. Other files and directories in the directory are kept.
+func ExtractTo(fsys fs.FS, dir string) (*Sidecars, error) {
+	manifestPath, err := findManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(fsys, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("sidecar: invalid %s: %w", ManifestFile, err)
+	}
+
+	checksum := sha256.Sum256(data)
+	version := hex.EncodeToString(checksum[:8])
+	target := filepath.Join(dir, version)
+	err = os.MkdirAll(target, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Sidecars{dir: target, paths: make(map[string]string)}
+	root := path.Dir(manifestPath)
+	for _, entry := range manifest.Sidecars {
+		if entry.File != filepath.Base(entry.File) {
+			return nil, fmt.Errorf("sidecar: invalid file %s", entry.File)
+		}
+		filename := filepath.Join(target, entry.File)
+		if verify(filename, entry) != nil {
+			err = extract(fsys, path.Join(root, entry.File), filename, entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result.paths[entry.Name] = filename
+	}
+	marker := filepath.Join(target, ManifestFile)
+	if existing, err := os.ReadFile(marker); err != nil || !bytes.Equal(existing, data) {
+		err = os.WriteFile(marker, data, 0o644)
+		if err != nil {
+			return nil, err
+		}
+	}
+	removeOtherVersions(dir, version)
+	return result, nil
+}
+
+// findManifest returns the path of the manifest, as embed.FS keeps the path of the embedded directory
+func findManifest(fsys fs.FS) (string, error) {
+	var result string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == ManifestFile {
+			result = p
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if result == "" {
+		return "", fmt.Errorf("sidecar: %s not found, the sidecars are staged by wails build", ManifestFile)
+	}
+	return result, nil
+}
+
+// verify checks the size and the checksum of the file
+func verify(filename string, entry Entry) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() != entry.Size {
+		return fmt.Errorf("sidecar: the size of %s is %d instead of %d", filename, info.Size(), entry.Size)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != entry.SHA256 {
+		return fmt.Errorf("sidecar: the checksum of %s is %s instead of %s", filename, checksum, entry.SHA256)
+	}
+	return nil
+}
+
+// extract writes the file atomically, so other instances don't run partially written sidecars
+func extract(fsys fs.FS, source string, filename string, entry Entry) error {
+	reader, err := fsys.Open(source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	temp, err := os.CreateTemp(filepath.Dir(filename), entry.File+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	_, err = io.Copy(temp, reader)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = verify(temp.Name(), entry)
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(temp.Name(), 0o755)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(temp.Name(), filename)
+	if err != nil {
+		// Windows doesn't replace files that are in use, EG: by another instance, which has verified them
+		if verify(filename, entry) == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// removeOtherVersions removes the sidecars of other versions. Only the subdirectories that are named after a version
+// and contain the manifest are removed, as the directory might be shared with other files. Sidecars that are running
+// can't be removed on Windows, so they are removed after a later start.
+func removeOtherVersions(dir string, version string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == version || !isVersion(entry.Name()) {
+			continue
+		}
+		versionDir := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(versionDir, ManifestFile)); err != nil {
+			continue
+		}
+		_ = os.RemoveAll(versionDir)
+	}
+}
+
+// isVersion reports whether the name is a version of the sidecars, the first 8 bytes of the checksum of the manifest
+// in lowercase hex
+func isVersion(name string) bool {
+	if len(name) != 16 {
+		return false
+	}
+	for _, c := range name {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Path returns the path of the extracted sidecar
+func (s *Sidecars) Path(name string) (string, error) {
+	p, exists := s.paths[name]
+	if !exists {
+		return "", fmt.Errorf("%w %s", ErrUnknownSidecar, name)
+	}
+	return p, nil
+}
+
+// Names returns the names of the sidecars
+func (s *Sidecars) Names() []string {
+	names := make([]string, 0, len(s.paths))
+	for name := range s.paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dir returns the directory of the extracted sidecars
+func (s *Sidecars) Dir() string {
+	return s.dir
+}
+
+// Config returns a subprocess.Config that runs the sidecar, to be supervised by a subprocess.Supervisor
+func (s *Sidecars) Config(name string, args ...string) (subprocess.Config, error) {
+	p, err := s.Path(name)
+	if err != nil {
+		return subprocess.Config{}, err
+	}
+	return subprocess.Config{Name: name, Path: p, Args: args}, nil
+}
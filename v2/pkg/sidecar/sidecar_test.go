@@ -0,0 +1,89 @@
+package sidecar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/matryer/is"
+)
+
+func embedded(t *testing.T, files map[string]string) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	manifest := Manifest{Platform: "linux/amd64"}
+	for name, content := range files {
+		sum := sha256.Sum256([]byte(content))
+		fsys["build/sidecars/embedded/"+name] = &fstest.MapFile{Data: []byte(content)}
+		manifest.Sidecars = append(manifest.Sidecars, Entry{Name: name, File: name, SHA256: hex.EncodeToString(sum[:]), Size: int64(len(content))})
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys["build/sidecars/embedded/"+ManifestFile] = &fstest.MapFile{Data: data}
+	return fsys
+}
+
+func TestExtract(t *testing.T) {
+	is2 := is.New(t)
+	dir := t.TempDir()
+	fsys := embedded(t, map[string]string{"ffmpeg": "ffmpeg binary", "server": "server binary"})
+
+	sidecars, err := ExtractTo(fsys, dir)
+	is2.NoErr(err)
+	is2.Equal(sidecars.Names(), []string{"ffmpeg", "server"})
+	path, err := sidecars.Path("ffmpeg")
+	is2.NoErr(err)
+	is2.Equal(filepath.Dir(path), sidecars.Dir())
+	data, err := os.ReadFile(path)
+	is2.NoErr(err)
+	is2.Equal(string(data), "ffmpeg binary")
+	info, err := os.Stat(path)
+	is2.NoErr(err)
+	is2.True(info.Mode().Perm()&0o100 != 0)
+
+	_, err = sidecars.Path("unknown")
+	is2.True(err != nil)
+	config, err := sidecars.Config("server", "--port={port}")
+	is2.NoErr(err)
+	is2.Equal(config.Path, filepath.Join(sidecars.Dir(), "server"))
+	is2.Equal(config.Args, []string{"--port={port}"})
+
+	// Modified sidecars are extracted again
+	is2.NoErr(os.WriteFile(path, []byte("modified binary"), 0o755))
+	_, err = ExtractTo(fsys, dir)
+	is2.NoErr(err)
+	data, _ = os.ReadFile(path)
+	is2.Equal(string(data), "ffmpeg binary")
+
+	// Other versions are removed, other directories are kept
+	kept := []string{filepath.Join(dir, "data"), filepath.Join(dir, "0123456789abcdef")}
+	for _, keep := range kept {
+		is2.NoErr(os.Mkdir(keep, 0o755))
+	}
+	updated, err := ExtractTo(embedded(t, map[string]string{"ffmpeg": "updated binary"}), dir)
+	is2.NoErr(err)
+	is2.True(updated.Dir() != sidecars.Dir())
+	_, err = os.Stat(sidecars.Dir())
+	is2.True(os.IsNotExist(err))
+	for _, keep := range kept {
+		_, err = os.Stat(keep)
+		is2.NoErr(err)
+	}
+}
+
+func TestExtractCorrupted(t *testing.T) {
+	is2 := is.New(t)
+	fsys := embedded(t, map[string]string{"ffmpeg": "ffmpeg binary"})
+	fsys["build/sidecars/embedded/ffmpeg"].Data = []byte("ffmpeg binarx")
+	_, err := ExtractTo(fsys, t.TempDir())
+	is2.True(err != nil)
+
+	_, err = ExtractTo(fstest.MapFS{}, t.TempDir())
+	is2.True(err != nil)
+}
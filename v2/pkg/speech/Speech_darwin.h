@@ -0,0 +1,16 @@
+#ifndef Speech_darwin_h
+#define Speech_darwin_h
+
+#include <stdbool.h>
+
+void* SpeechNew(void);
+const char* SpeechVoices(void);
+bool SpeechSpeak(void *synthesizer, const char *text, const char *voice, float rate, float pitch, float volume, bool interrupt);
+void SpeechStop(void *synthesizer);
+void SpeechPause(void *synthesizer);
+void SpeechResume(void *synthesizer);
+bool SpeechIsSpeaking(void *synthesizer);
+const char* SpeechStartDictation(const char *language);
+void SpeechStopDictation(void);
+
+#endif /* Speech_darwin_h */
@@ -0,0 +1,163 @@
+//go:build darwin
+
+#import <Foundation/Foundation.h>
+#import <AVFoundation/AVFoundation.h>
+#import <Speech/Speech.h>
+#include <string.h>
+
+#import "Speech_darwin.h"
+
+extern void processDictationResult(char *text, bool final);
+
+void* SpeechNew(void) {
+    return [AVSpeechSynthesizer new];
+}
+
+// SpeechVoices returns the voices as JSON. The caller has to free the result.
+const char* SpeechVoices(void) {
+    @autoreleasepool {
+        NSMutableArray *voices = [NSMutableArray new];
+        for (AVSpeechSynthesisVoice *voice in [AVSpeechSynthesisVoice speechVoices]) {
+            [voices addObject:@{@"id": voice.identifier, @"name": voice.name, @"language": voice.language}];
+        }
+        NSData *data = [NSJSONSerialization dataWithJSONObject:voices options:0 error:nil];
+        [voices release];
+        NSString *json = [[[NSString alloc] initWithData:data encoding:NSUTF8StringEncoding] autorelease];
+        return strdup([json UTF8String]);
+    }
+}
+
+// SpeechSpeak returns false if the voice doesn't exist
+bool SpeechSpeak(void *synthesizer, const char *text, const char *voice, float rate, float pitch, float volume, bool interrupt) {
+    AVSpeechSynthesizer *speechSynthesizer = (AVSpeechSynthesizer*)synthesizer;
+    @autoreleasepool {
+        AVSpeechUtterance *utterance = [AVSpeechUtterance speechUtteranceWithString:[NSString stringWithUTF8String:text]];
+        if (strlen(voice) > 0) {
+            AVSpeechSynthesisVoice *speechVoice = [AVSpeechSynthesisVoice voiceWithIdentifier:[NSString stringWithUTF8String:voice]];
+            if (speechVoice == nil) {
+                return false;
+            }
+            utterance.voice = speechVoice;
+        }
+        if (rate > 0) {
+            utterance.rate = MAX(AVSpeechUtteranceMinimumSpeechRate, MIN(AVSpeechUtteranceMaximumSpeechRate, AVSpeechUtteranceDefaultSpeechRate * rate));
+        }
+        if (pitch > 0) {
+            utterance.pitchMultiplier = MAX(0.5, MIN(2.0, pitch));
+        }
+        if (volume > 0) {
+            utterance.volume = volume;
+        }
+        if (interrupt) {
+            [speechSynthesizer stopSpeakingAtBoundary:AVSpeechBoundaryImmediate];
+        }
+        [speechSynthesizer speakUtterance:utterance];
+    }
+    return true;
+}
+
+void SpeechStop(void *synthesizer) {
+    [(AVSpeechSynthesizer*)synthesizer stopSpeakingAtBoundary:AVSpeechBoundaryImmediate];
+}
+
+void SpeechPause(void *synthesizer) {
+    [(AVSpeechSynthesizer*)synthesizer pauseSpeakingAtBoundary:AVSpeechBoundaryWord];
+}
+
+void SpeechResume(void *synthesizer) {
+    [(AVSpeechSynthesizer*)synthesizer continueSpeaking];
+}
+
+bool SpeechIsSpeaking(void *synthesizer) {
+    AVSpeechSynthesizer *speechSynthesizer = (AVSpeechSynthesizer*)synthesizer;
+    return speechSynthesizer.isSpeaking && !speechSynthesizer.isPaused;
+}
+
+static AVAudioEngine *audioEngine = nil;
+static SFSpeechAudioBufferRecognitionRequest *recognitionRequest = nil;
+static SFSpeechRecognitionTask *recognitionTask = nil;
+
+static void cleanupDictation(void) {
+    if (audioEngine != nil) {
+        [audioEngine stop];
+        [audioEngine.inputNode removeTapOnBus:0];
+        [audioEngine release];
+        audioEngine = nil;
+    }
+    [recognitionRequest release];
+    recognitionRequest = nil;
+    [recognitionTask release];
+    recognitionTask = nil;
+}
+
+// SpeechStartDictation returns an error message or NULL. The caller has to free the error.
+const char* SpeechStartDictation(const char *language) {
+    // The authorization is requested once, the user is asked with the NSSpeechRecognitionUsageDescription of Info.plist
+    dispatch_semaphore_t authorized = dispatch_semaphore_create(0);
+    __block SFSpeechRecognizerAuthorizationStatus status;
+    [SFSpeechRecognizer requestAuthorization:^(SFSpeechRecognizerAuthorizationStatus authorizationStatus) {
+        status = authorizationStatus;
+        dispatch_semaphore_signal(authorized);
+    }];
+    dispatch_semaphore_wait(authorized, DISPATCH_TIME_FOREVER);
+    dispatch_release(authorized);
+    if (status != SFSpeechRecognizerAuthorizationStatusAuthorized) {
+        return strdup("speech recognition has not been authorized");
+    }
+
+    @autoreleasepool {
+        SFSpeechRecognizer *recognizer;
+        if (strlen(language) > 0) {
+            NSLocale *locale = [NSLocale localeWithLocaleIdentifier:[NSString stringWithUTF8String:language]];
+            recognizer = [[[SFSpeechRecognizer alloc] initWithLocale:locale] autorelease];
+        } else {
+            recognizer = [[[SFSpeechRecognizer alloc] init] autorelease];
+        }
+        if (recognizer == nil || !recognizer.isAvailable) {
+            return strdup("speech recognition is not available for the language");
+        }
+
+        cleanupDictation();
+        recognitionRequest = [SFSpeechAudioBufferRecognitionRequest new];
+        recognitionRequest.shouldReportPartialResults = YES;
+        if (@available(macOS 10.15, *)) {
+            // Dictation never leaves the device if the language supports it
+            recognitionRequest.requiresOnDeviceRecognition = recognizer.supportsOnDeviceRecognition;
+        }
+
+        audioEngine = [AVAudioEngine new];
+        AVAudioInputNode *inputNode = audioEngine.inputNode;
+        SFSpeechAudioBufferRecognitionRequest *request = recognitionRequest;
+        [inputNode installTapOnBus:0 bufferSize:1024 format:[inputNode outputFormatForBus:0] block:^(AVAudioPCMBuffer *buffer, AVAudioTime *when) {
+            [request appendAudioPCMBuffer:buffer];
+        }];
+
+        recognitionTask = [[recognizer recognitionTaskWithRequest:recognitionRequest resultHandler:^(SFSpeechRecognitionResult *result, NSError *error) {
+            if (result != nil) {
+                processDictationResult((char*)[result.bestTranscription.formattedString UTF8String], result.isFinal);
+            } else if (error != nil) {
+                processDictationResult("", true);
+            }
+        }] retain];
+
+        NSError *error = nil;
+        [audioEngine prepare];
+        if (![audioEngine startAndReturnError:&error]) {
+            [recognitionTask cancel];
+            cleanupDictation();
+            return strdup([error.localizedDescription UTF8String]);
+        }
+    }
+    return NULL;
+}
+
+void SpeechStopDictation(void) {
+    if (audioEngine != nil) {
+        [audioEngine stop];
+        [audioEngine.inputNode removeTapOnBus:0];
+        [audioEngine release];
+        audioEngine = nil;
+    }
+    // The recognizer delivers the final result after the end of the audio
+    [recognitionRequest endAudio];
+}
@@ -0,0 +1,174 @@
+// Package speech provides text-to-speech with the voices of the operating system and on-device dictation where it
+// is available: SAPI on Windows, AVSpeechSynthesizer and the Speech framework on macOS and speech-dispatcher on
+// Linux. Bind the Speech service to use it from JS.
+package speech
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotSupported is returned when the platform doesn't provide the feature, EG: dictation on Linux
+var ErrNotSupported = errors.New("speech: not supported on this platform")
+
+// Voice is a voice of the operating system
+type Voice struct {
+	// ID selects the voice in SpeakOptions
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Language is the BCP 47 code of the language, EG: en-US
+	Language string `json:"language"`
+}
+
+// SpeakOptions are the options of an utterance. The zero values use the defaults of the platform.
+type SpeakOptions struct {
+	// Voice is the ID of the voice
+	Voice string `json:"voice"`
+	// Rate is the speaking rate relative to the default, EG: 2 for twice as fast
+	Rate float64 `json:"rate"`
+	// Pitch is the pitch relative to the default, EG: 1.5. It is not supported by SAPI.
+	Pitch float64 `json:"pitch"`
+	// Volume is between 0 and 1. 0 uses the full volume.
+	Volume float64 `json:"volume"`
+	// Interrupt stops the current and all queued utterances instead of queueing the text
+	Interrupt bool `json:"interrupt"`
+}
+
+// DictationResult is a transcript of the dictation
+type DictationResult struct {
+	Text string `json:"text"`
+	// Final is true for the last result of the dictation
+	Final bool `json:"final"`
+}
+
+type engine interface {
+	voices() ([]Voice, error)
+	speak(text string, options SpeakOptions) error
+	stop() error
+	pause() error
+	resume() error
+	isSpeaking() (bool, error)
+	startDictation(language string, callback func(DictationResult)) error
+	stopDictation() error
+}
+
+// Speech is the speech service
+type Speech struct {
+	// OnDictation is called with the partial and the final results of the dictation, EG: to emit them as event
+	// to the frontend
+	OnDictation func(result DictationResult)
+
+	init    sync.Once
+	engine  engine
+	initErr error
+
+	lock          sync.Mutex
+	dictationText string
+}
+
+// New creates the speech service. The platform service is started on first use.
+func New() *Speech {
+	return &Speech{}
+}
+
+func (s *Speech) getEngine() (engine, error) {
+	s.init.Do(func() {
+		s.engine, s.initErr = newEngine()
+	})
+	return s.engine, s.initErr
+}
+
+// Voices returns the installed voices
+func (s *Speech) Voices() ([]Voice, error) {
+	e, err := s.getEngine()
+	if err != nil {
+		return nil, err
+	}
+	return e.voices()
+}
+
+// Speak speaks the text after the queued utterances, or immediately if Interrupt is set. It returns when the text
+// has been queued.
+func (s *Speech) Speak(text string, options SpeakOptions) error {
+	if options.Rate < 0 || options.Pitch < 0 || options.Volume < 0 || options.Volume > 1 {
+		return errors.New("speech: rate and pitch must be positive and the volume between 0 and 1")
+	}
+	e, err := s.getEngine()
+	if err != nil {
+		return err
+	}
+	return e.speak(text, options)
+}
+
+// Stop stops the current utterance and clears the queue
+func (s *Speech) Stop() error {
+	e, err := s.getEngine()
+	if err != nil {
+		return err
+	}
+	return e.stop()
+}
+
+// Pause pauses speaking
+func (s *Speech) Pause() error {
+	e, err := s.getEngine()
+	if err != nil {
+		return err
+	}
+	return e.pause()
+}
+
+// Resume continues speaking after Pause
+func (s *Speech) Resume() error {
+	e, err := s.getEngine()
+	if err != nil {
+		return err
+	}
+	return e.resume()
+}
+
+// IsSpeaking returns true while an utterance is spoken
+func (s *Speech) IsSpeaking() (bool, error) {
+	e, err := s.getEngine()
+	if err != nil {
+		return false, err
+	}
+	return e.isSpeaking()
+}
+
+// StartDictation starts the on-device recognition of the microphone in the language, EG: en-US. An empty language
+// uses the language of the user. The results are passed to OnDictation and the latest transcript is returned by
+// DictationText. ErrNotSupported is returned on Windows and Linux.
+func (s *Speech) StartDictation(language string) error {
+	e, err := s.getEngine()
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	s.dictationText = ""
+	s.lock.Unlock()
+	return e.startDictation(language, func(result DictationResult) {
+		s.lock.Lock()
+		s.dictationText = result.Text
+		s.lock.Unlock()
+		if s.OnDictation != nil {
+			s.OnDictation(result)
+		}
+	})
+}
+
+// StopDictation stops the dictation. The final result is passed to OnDictation.
+func (s *Speech) StopDictation() error {
+	e, err := s.getEngine()
+	if err != nil {
+		return err
+	}
+	return e.stopDictation()
+}
+
+// DictationText returns the latest transcript of the dictation
+func (s *Speech) DictationText() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.dictationText
+}
@@ -0,0 +1,109 @@
+//go:build darwin
+
+package speech
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework AVFoundation -framework Speech
+
+#include <stdlib.h>
+#import "Speech_darwin.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// avEngine speaks with AVSpeechSynthesizer and recognises speech with SFSpeechRecognizer. Dictation needs the
+// NSSpeechRecognitionUsageDescription and NSMicrophoneUsageDescription keys in Info.plist.
+type avEngine struct {
+	synthesizer unsafe.Pointer
+}
+
+// There can only be one dictation at a time
+var (
+	dictationLock     sync.Mutex
+	dictationCallback func(DictationResult)
+)
+
+//export processDictationResult
+func processDictationResult(text *C.char, final C.bool) {
+	dictationLock.Lock()
+	callback := dictationCallback
+	if final {
+		dictationCallback = nil
+	}
+	dictationLock.Unlock()
+	if callback != nil {
+		callback(DictationResult{Text: C.GoString(text), Final: bool(final)})
+	}
+}
+
+func newEngine() (engine, error) {
+	return &avEngine{synthesizer: C.SpeechNew()}, nil
+}
+
+func (e *avEngine) voices() ([]Voice, error) {
+	voicesJSON := C.SpeechVoices()
+	defer C.free(unsafe.Pointer(voicesJSON))
+	var voices []Voice
+	err := json.Unmarshal([]byte(C.GoString(voicesJSON)), &voices)
+	return voices, err
+}
+
+func (e *avEngine) speak(text string, options SpeakOptions) error {
+	cText := C.CString(text)
+	defer C.free(unsafe.Pointer(cText))
+	cVoice := C.CString(options.Voice)
+	defer C.free(unsafe.Pointer(cVoice))
+	if !C.SpeechSpeak(e.synthesizer, cText, cVoice, C.float(options.Rate), C.float(options.Pitch), C.float(options.Volume), C.bool(options.Interrupt)) {
+		return fmt.Errorf("speech: unknown voice %s", options.Voice)
+	}
+	return nil
+}
+
+func (e *avEngine) stop() error {
+	C.SpeechStop(e.synthesizer)
+	return nil
+}
+
+func (e *avEngine) pause() error {
+	C.SpeechPause(e.synthesizer)
+	return nil
+}
+
+func (e *avEngine) resume() error {
+	C.SpeechResume(e.synthesizer)
+	return nil
+}
+
+func (e *avEngine) isSpeaking() (bool, error) {
+	return bool(C.SpeechIsSpeaking(e.synthesizer)), nil
+}
+
+func (e *avEngine) startDictation(language string, callback func(DictationResult)) error {
+	dictationLock.Lock()
+	dictationCallback = callback
+	dictationLock.Unlock()
+
+	cLanguage := C.CString(language)
+	defer C.free(unsafe.Pointer(cLanguage))
+	if message := C.SpeechStartDictation(cLanguage); message != nil {
+		defer C.free(unsafe.Pointer(message))
+		dictationLock.Lock()
+		dictationCallback = nil
+		dictationLock.Unlock()
+		return errors.New("speech: " + C.GoString(message))
+	}
+	return nil
+}
+
+func (e *avEngine) stopDictation() error {
+	C.SpeechStopDictation()
+	return nil
+}
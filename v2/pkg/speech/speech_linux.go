@@ -0,0 +1,241 @@
+//go:build linux
+
+package speech
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+ssipClient speaks the Speech Synthesis Interface Protocol of speech-dispatcher, see
+https://htmlpreview.github.io/?https://github.com/brailcom/speechd/blob/master/doc/ssip.html
+Replies are lines of a 3 digit code followed by '-' for continued lines or ' ' for the last line. Notifications of
+the 7xx codes are sent asynchronously on the same connection.
+*/
+type ssipClient struct {
+	conn net.Conn
+	// lock serialises the commands, the replies are in the order of the commands
+	lock     sync.Mutex
+	replies  chan ssipReply
+	speaking atomic.Bool
+}
+
+type ssipReply struct {
+	code  int
+	lines []string
+	err   error
+}
+
+func newEngine() (engine, error) {
+	conn, err := dialSSIP()
+	if err != nil {
+		return nil, fmt.Errorf("speech: unable to connect to speech-dispatcher: %w", err)
+	}
+	return newSSIPClient(conn)
+}
+
+func dialSSIP() (net.Conn, error) {
+	address := os.Getenv("SPEECHD_ADDRESS")
+	if strings.HasPrefix(address, "inet_socket:") {
+		return net.Dial("tcp", strings.TrimPrefix(address, "inet_socket:"))
+	}
+	path := strings.TrimPrefix(address, "unix_socket:")
+	if path == "" {
+		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+		if runtimeDir == "" {
+			runtimeDir = os.TempDir()
+		}
+		path = filepath.Join(runtimeDir, "speech-dispatcher", "speechd.sock")
+	}
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		return conn, nil
+	}
+	// speech-dispatcher is started on demand, the same as by libspeechd
+	if spawnErr := exec.Command("speech-dispatcher", "--spawn").Run(); spawnErr != nil {
+		return nil, err
+	}
+	return net.Dial("unix", path)
+}
+
+func newSSIPClient(conn net.Conn) (*ssipClient, error) {
+	c := &ssipClient{
+		conn:    conn,
+		replies: make(chan ssipReply),
+	}
+	go c.read()
+
+	userName := "unknown"
+	if current, err := user.Current(); err == nil {
+		userName = current.Username
+	}
+	application := strings.ReplaceAll(filepath.Base(os.Args[0]), ":", "_")
+	for _, command := range []string{
+		"SET self CLIENT_NAME " + userName + ":" + application + ":main",
+		"SET self NOTIFICATION ALL on",
+	} {
+		if _, err := c.command(command); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *ssipClient) read() {
+	reader := bufio.NewReader(c.conn)
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// Fail the pending and all future commands
+			for {
+				c.replies <- ssipReply{err: err}
+			}
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) < 4 {
+			continue
+		}
+		code, _ := strconv.Atoi(line[:3])
+		lines = append(lines, line[4:])
+		if line[3] == '-' {
+			continue
+		}
+		if code >= 700 {
+			c.notify(code)
+		} else {
+			c.replies <- ssipReply{code: code, lines: lines}
+		}
+		lines = nil
+	}
+}
+
+func (c *ssipClient) notify(code int) {
+	switch code {
+	case 701, 705: // BEGIN, RESUME
+		c.speaking.Store(true)
+	case 702, 703, 704: // END, CANCEL, PAUSE
+		c.speaking.Store(false)
+	}
+}
+
+// command sends the lines and returns the reply to the last line
+func (c *ssipClient) command(lines ...string) (ssipReply, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var reply ssipReply
+	for _, line := range lines {
+		_, err := c.conn.Write([]byte(line + "\r\n"))
+		if err != nil {
+			return reply, err
+		}
+		reply = <-c.replies
+		if reply.err != nil {
+			return reply, reply.err
+		}
+		if reply.code >= 300 {
+			return reply, errors.New("speech: " + strings.Join(reply.lines, " "))
+		}
+	}
+	return reply, nil
+}
+
+func (c *ssipClient) voices() ([]Voice, error) {
+	reply, err := c.command("LIST SYNTHESIS_VOICES")
+	if err != nil {
+		return nil, err
+	}
+	var result []Voice
+	// The last line is the status
+	for _, line := range reply.lines[:len(reply.lines)-1] {
+		fields := strings.Split(line, "\t")
+		voice := Voice{ID: fields[0], Name: fields[0]}
+		if len(fields) > 1 && fields[1] != "none" {
+			voice.Language = fields[1]
+		}
+		result = append(result, voice)
+	}
+	return result, nil
+}
+
+func (c *ssipClient) speak(text string, options SpeakOptions) error {
+	var commands []string
+	if options.Interrupt {
+		commands = append(commands, "CANCEL self")
+	}
+	if options.Voice != "" {
+		commands = append(commands, "SET self SYNTHESIS_VOICE "+options.Voice)
+	}
+	volume := 100
+	if options.Volume != 0 {
+		volume = int(math.Round(200*options.Volume - 100))
+	}
+	commands = append(commands,
+		"SET self RATE "+strconv.Itoa(ssipScale(options.Rate)),
+		"SET self PITCH "+strconv.Itoa(ssipScale(options.Pitch)),
+		"SET self VOLUME "+strconv.Itoa(volume),
+		"SPEAK",
+	)
+
+	// The text ends with a line of a single dot, dots at the start of lines are doubled
+	var data strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, ".") {
+			data.WriteString(".")
+		}
+		data.WriteString(line + "\r\n")
+	}
+	data.WriteString(".")
+	commands = append(commands, data.String())
+
+	_, err := c.command(commands...)
+	return err
+}
+
+// ssipScale maps a factor of the default to -100..100, 100 is twice and -100 half the default
+func ssipScale(factor float64) int {
+	if factor == 0 {
+		return 0
+	}
+	return int(math.Max(-100, math.Min(100, math.Round(100*math.Log2(factor)))))
+}
+
+func (c *ssipClient) stop() error {
+	_, err := c.command("CANCEL self")
+	return err
+}
+
+func (c *ssipClient) pause() error {
+	_, err := c.command("PAUSE self")
+	return err
+}
+
+func (c *ssipClient) resume() error {
+	_, err := c.command("RESUME self")
+	return err
+}
+
+func (c *ssipClient) isSpeaking() (bool, error) {
+	return c.speaking.Load(), nil
+}
+
+func (c *ssipClient) startDictation(string, func(DictationResult)) error {
+	return ErrNotSupported
+}
+
+func (c *ssipClient) stopDictation() error {
+	return ErrNotSupported
+}
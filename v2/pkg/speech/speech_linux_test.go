@@ -0,0 +1,101 @@
+//go:build linux
+
+package speech
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSpeechd answers the commands of the client and records them
+func fakeSpeechd(t *testing.T, server net.Conn, received chan<- string) {
+	reader := bufio.NewReader(server)
+	data := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		received <- line
+		switch {
+		case data && line == ".":
+			data = false
+			server.Write([]byte("225-21\r\n225 OK MESSAGE QUEUED\r\n701-21\r\n701-1\r\n701 BEGIN\r\n"))
+		case data:
+		case line == "SPEAK":
+			data = true
+			server.Write([]byte("230 OK RECEIVING DATA\r\n"))
+		case line == "LIST SYNTHESIS_VOICES":
+			server.Write([]byte("249-Alice\ten-US\tnone\r\n249-Bob\tnone\tnone\r\n249 OK VOICE LIST SENT\r\n"))
+		case line == "SET self SYNTHESIS_VOICE Unknown":
+			server.Write([]byte("409 ERR RATE TOO HIGH\r\n"))
+		default:
+			server.Write([]byte("208 OK\r\n"))
+		}
+	}
+}
+
+func TestSSIPClient(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	received := make(chan string, 100)
+	go fakeSpeechd(t, server, received)
+
+	c, err := newSSIPClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line := <-received; !strings.HasPrefix(line, "SET self CLIENT_NAME ") || !strings.HasSuffix(line, ":main") {
+		t.Errorf("unexpected client name %q", line)
+	}
+	if line := <-received; line != "SET self NOTIFICATION ALL on" {
+		t.Errorf("unexpected command %q", line)
+	}
+
+	voices, err := c.voices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(voices) != 2 || voices[0] != (Voice{ID: "Alice", Name: "Alice", Language: "en-US"}) || voices[1].Language != "" {
+		t.Errorf("unexpected voices %+v", voices)
+	}
+	<-received
+
+	err = c.speak("Hello\n.hidden", SpeakOptions{Interrupt: true, Voice: "Alice", Rate: 2, Volume: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commands []string
+	for len(commands) < 9 {
+		commands = append(commands, <-received)
+	}
+	want := []string{"CANCEL self", "SET self SYNTHESIS_VOICE Alice", "SET self RATE 100", "SET self PITCH 0", "SET self VOLUME 0", "SPEAK", "Hello", "..hidden", "."}
+	if strings.Join(commands, "|") != strings.Join(want, "|") {
+		t.Errorf("got %q, want %q", commands, want)
+	}
+
+	// The BEGIN notification is received after the reply
+	deadline := time.Now().Add(time.Second)
+	for speaking, _ := c.isSpeaking(); !speaking; speaking, _ = c.isSpeaking() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the client to be speaking")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := c.speak("x", SpeakOptions{Voice: "Unknown"}); err == nil || !strings.Contains(err.Error(), "409") && !strings.Contains(err.Error(), "ERR") {
+		t.Errorf("expected the error of speech-dispatcher, got %v", err)
+	}
+}
+
+func TestSSIPScale(t *testing.T) {
+	for factor, want := range map[float64]int{0: 0, 1: 0, 2: 100, 0.5: -100, 4: 100, 1.5: 58} {
+		if got := ssipScale(factor); got != want {
+			t.Errorf("ssipScale(%v) = %d, want %d", factor, got, want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+//go:build windows
+
+package speech
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+	"golang.org/x/sys/windows"
+)
+
+// SpeechVoiceSpeakFlags and SpeechRunState of SAPI
+const (
+	svsfAsync          = 1
+	svsfPurgeBeforeSpk = 2
+	svsfIsNotXML       = 16
+	srseIsSpeaking     = 2
+)
+
+var procLCIDToLocaleName = windows.NewLazySystemDLL("kernel32.dll").NewProc("LCIDToLocaleName")
+
+// sapiEngine owns a SAPI SpVoice. COM objects are bound to the thread that created them, so all calls are run on a
+// dedicated thread.
+type sapiEngine struct {
+	calls chan func()
+
+	voice        *ole.IDispatch
+	defaultVoice *ole.IDispatch
+}
+
+func newEngine() (engine, error) {
+	e := &sapiEngine{calls: make(chan func())}
+	initErr := make(chan error)
+	go func() {
+		runtime.LockOSThread()
+		err := ole.CoInitializeEx(0, ole.COINIT_MULTITHREADED)
+		if err != nil {
+			initErr <- fmt.Errorf("speech: CoInitializeEx failed: %w", err)
+			return
+		}
+		unknown, err := oleutil.CreateObject("SAPI.SpVoice")
+		if err != nil {
+			ole.CoUninitialize()
+			initErr <- fmt.Errorf("speech: creating SAPI.SpVoice failed: %w", err)
+			return
+		}
+		e.voice, err = unknown.QueryInterface(ole.IID_IDispatch)
+		unknown.Release()
+		if err == nil {
+			var defaultVoice *ole.VARIANT
+			defaultVoice, err = oleutil.GetProperty(e.voice, "Voice")
+			if err == nil {
+				e.defaultVoice = defaultVoice.ToIDispatch()
+			}
+		}
+		initErr <- err
+		if err != nil {
+			return
+		}
+		// The voice lives as long as the application
+		for call := range e.calls {
+			call()
+		}
+	}()
+	if err := <-initErr; err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// run runs the function on the COM thread
+func (e *sapiEngine) run(fn func() error) error {
+	result := make(chan error)
+	e.calls <- func() {
+		result <- fn()
+	}
+	return <-result
+}
+
+func (e *sapiEngine) voices() ([]Voice, error) {
+	var voices []Voice
+	err := e.run(func() error {
+		tokens, err := oleutil.CallMethod(e.voice, "GetVoices")
+		if err != nil {
+			return err
+		}
+		defer tokens.Clear()
+		return oleutil.ForEach(tokens.ToIDispatch(), func(v *ole.VARIANT) error {
+			token := v.ToIDispatch()
+			id, err := oleutil.GetProperty(token, "Id")
+			if err != nil {
+				return err
+			}
+			voice := Voice{ID: id.ToString(), Name: id.ToString()}
+			if description, err := oleutil.CallMethod(token, "GetDescription"); err == nil {
+				voice.Name = description.ToString()
+			}
+			if language, err := oleutil.CallMethod(token, "GetAttribute", "Language"); err == nil {
+				voice.Language = localeName(language.ToString())
+			}
+			voices = append(voices, voice)
+			return nil
+		})
+	})
+	return voices, err
+}
+
+// localeName converts the hexadecimal LCIDs of the Language attribute of a voice token, EG: "409;9", to the
+// language code of the first LCID
+func localeName(lcids string) string {
+	first, _, _ := strings.Cut(lcids, ";")
+	lcid, err := strconv.ParseUint(first, 16, 32)
+	if err != nil {
+		return ""
+	}
+	buffer := make([]uint16, 85) // LOCALE_NAME_MAX_LENGTH
+	length, _, _ := procLCIDToLocaleName.Call(uintptr(lcid), uintptr(unsafe.Pointer(&buffer[0])), uintptr(len(buffer)), 0)
+	if length == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buffer)
+}
+
+// findVoice returns the voice token with the ID
+func (e *sapiEngine) findVoice(id string) (*ole.IDispatch, error) {
+	tokens, err := oleutil.CallMethod(e.voice, "GetVoices")
+	if err != nil {
+		return nil, err
+	}
+	defer tokens.Clear()
+	count, err := oleutil.GetProperty(tokens.ToIDispatch(), "Count")
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(count.Val); i++ {
+		token, err := oleutil.CallMethod(tokens.ToIDispatch(), "Item", i)
+		if err != nil {
+			return nil, err
+		}
+		tokenID, err := oleutil.GetProperty(token.ToIDispatch(), "Id")
+		if err == nil && strings.EqualFold(tokenID.ToString(), id) {
+			return token.ToIDispatch(), nil
+		}
+		token.Clear()
+	}
+	return nil, fmt.Errorf("speech: unknown voice %s", id)
+}
+
+// sapiRate converts the factor to the rate of SAPI between -10 and 10, where 10 is about three times as fast
+func sapiRate(factor float64) int {
+	if factor == 0 {
+		return 0
+	}
+	return int(math.Max(-10, math.Min(10, math.Round(10*math.Log(factor)/math.Log(3)))))
+}
+
+func (e *sapiEngine) speak(text string, options SpeakOptions) error {
+	return e.run(func() error {
+		// The settings of the voice persist, so the defaults have to be restored
+		voice := e.defaultVoice
+		if options.Voice != "" {
+			token, err := e.findVoice(options.Voice)
+			if err != nil {
+				return err
+			}
+			defer token.Release()
+			voice = token
+		}
+		if _, err := oleutil.PutPropertyRef(e.voice, "Voice", voice); err != nil {
+			return err
+		}
+		if _, err := oleutil.PutProperty(e.voice, "Rate", sapiRate(options.Rate)); err != nil {
+			return err
+		}
+		volume := 100
+		if options.Volume > 0 {
+			volume = int(math.Round(options.Volume * 100))
+		}
+		if _, err := oleutil.PutProperty(e.voice, "Volume", volume); err != nil {
+			return err
+		}
+		flags := svsfAsync | svsfIsNotXML
+		if options.Interrupt {
+			flags |= svsfPurgeBeforeSpk
+		}
+		_, err := oleutil.CallMethod(e.voice, "Speak", text, flags)
+		return err
+	})
+}
+
+func (e *sapiEngine) stop() error {
+	return e.run(func() error {
+		_, err := oleutil.CallMethod(e.voice, "Speak", "", svsfAsync|svsfPurgeBeforeSpk)
+		return err
+	})
+}
+
+func (e *sapiEngine) pause() error {
+	return e.run(func() error {
+		_, err := oleutil.CallMethod(e.voice, "Pause")
+		return err
+	})
+}
+
+func (e *sapiEngine) resume() error {
+	return e.run(func() error {
+		_, err := oleutil.CallMethod(e.voice, "Resume")
+		return err
+	})
+}
+
+func (e *sapiEngine) isSpeaking() (bool, error) {
+	var speaking bool
+	err := e.run(func() error {
+		status, err := oleutil.GetProperty(e.voice, "Status")
+		if err != nil {
+			return err
+		}
+		defer status.Clear()
+		state, err := oleutil.GetProperty(status.ToIDispatch(), "RunningState")
+		if err != nil {
+			return err
+		}
+		speaking = state.Val == srseIsSpeaking
+		return nil
+	})
+	return speaking, err
+}
+
+func (e *sapiEngine) startDictation(string, func(DictationResult)) error {
+	return ErrNotSupported
+}
+
+func (e *sapiEngine) stopDictation() error {
+	return ErrNotSupported
+}
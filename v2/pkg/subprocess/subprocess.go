@@ -0,0 +1,588 @@
+// Package subprocess supervises the helper executables of an application, EG: language servers, ffmpeg or local
+// inference servers. The processes are restarted by a watchdog according to their restart policy, their output is
+// streamed line by line and they are stopped gracefully when the application quits. Bind the Supervisor to control
+// the processes from JS and use EmitEvents to stream their output to the frontend.
+package subprocess
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// The events that are emitted by EmitEvents
+const (
+	// OutputEvent is emitted with an Output for every line of stdout and stderr
+	OutputEvent = "subprocess:output"
+	// StateEvent is emitted with the Status of a process when its state changes
+	StateEvent = "subprocess:state"
+)
+
+// FilesEnv is the environment variable with the file descriptors, or the handles on Windows, of the Files that are
+// passed to a process, EG: "3,4"
+const FilesEnv = "WAILS_SUBPROCESS_FILES"
+
+const (
+	defaultRestartDelay    = time.Second
+	maxRestartDelay        = 30 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+	// A process that ran for stableTime is restarted without delay and its restarts are not counted for MaxRestarts
+	stableTime = time.Minute
+	// outputDelay is how long the output is read after the process has exited
+	outputDelay = time.Second
+	// maxLineLength is the maximum length of a line of output. Longer lines are split.
+	maxLineLength = 1 << 20
+)
+
+// ErrUnknownProcess is returned for names that haven't been configured
+var ErrUnknownProcess = errors.New("subprocess: unknown process")
+
+// ErrNotRunning is returned by Write when the process is not running
+var ErrNotRunning = errors.New("subprocess: the process is not running")
+
+// RestartPolicy defines when a process is restarted after it has exited
+type RestartPolicy int
+
+const (
+	// RestartNever doesn't restart the process
+	RestartNever RestartPolicy = iota
+	// RestartOnFailure restarts the process when it exited with an error or could not be started
+	RestartOnFailure
+	// RestartAlways restarts the process whenever it exited without having been stopped
+	RestartAlways
+)
+
+// State is the state of a process
+type State string
+
+const (
+	StateStopped    State = "stopped"
+	StateRunning    State = "running"
+	StateRestarting State = "restarting"
+	// StateExited is the state of a process that exited and won't be restarted
+	StateExited State = "exited"
+	// StateFailed is the state of a process that could not be started or exceeded MaxRestarts
+	StateFailed State = "failed"
+)
+
+// Config is the configuration of a process
+type Config struct {
+	// Name identifies the process
+	Name string
+	// Path is the path of the executable
+	Path string
+	// Args are the arguments. "{port}" is replaced with the port of PortEnv.
+	Args []string
+	// Env contains additional environment variables, EG: "MODEL=small". The environment of the application is
+	// inherited.
+	Env []string
+	// Dir is the working directory. Defaults to the working directory of the application.
+	Dir string
+
+	// Restart defines when the process is restarted
+	Restart RestartPolicy
+	// MaxRestarts is the number of consecutive restarts after which the process is given up. 0 is unlimited.
+	MaxRestarts int
+	// RestartDelay is the initial delay before a restart. It is doubled for consecutive restarts up to 30 seconds.
+	// Defaults to 1 second.
+	RestartDelay time.Duration
+	// ShutdownTimeout is the time the process is given to exit after stdin has been closed and SIGTERM has been sent,
+	// before it is killed. Windows processes only see stdin being closed. Defaults to 5 seconds.
+	ShutdownTimeout time.Duration
+
+	// PortEnv is the name of an environment variable, EG: "PORT", that passes a free port of the loopback interface
+	// to the process. A new port is chosen for every start.
+	PortEnv string
+	// Files are inherited by the process, EG: the file of a listener created by ListenerFile. Their descriptors are
+	// passed in WAILS_SUBPROCESS_FILES.
+	Files []*os.File
+}
+
+// Status is the status of a process
+type Status struct {
+	Name  string `json:"name"`
+	State State  `json:"state"`
+	PID   int    `json:"pid"`
+	// Port is the port that was passed in PortEnv
+	Port int `json:"port"`
+	// Restarts is the number of times the process has been restarted
+	Restarts int `json:"restarts"`
+	// ExitCode is the exit code of the last run
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Output is a line of output of a process
+type Output struct {
+	Name string `json:"name"`
+	// Stream is stdout or stderr
+	Stream string `json:"stream"`
+	Line   string `json:"line"`
+}
+
+type process struct {
+	config Config
+	status Status
+
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// stop is closed when the process is stopped
+	stop chan struct{}
+	// done is closed when the watchdog of the process has returned
+	done chan struct{}
+}
+
+// Supervisor starts, restarts and stops the configured processes
+type Supervisor struct {
+	// OnOutput is called with every line of output. It has to be set before the processes are started.
+	OnOutput func(output Output)
+	// OnStateChange is called when the state of a process changes. It has to be set before the processes are
+	// started.
+	OnStateChange func(status Status)
+
+	lock      sync.Mutex
+	processes map[string]*process
+	platform  platform
+}
+
+// New creates a supervisor for the processes. They are started with Start.
+func New(configs ...Config) *Supervisor {
+	s := &Supervisor{processes: make(map[string]*process)}
+	for _, config := range configs {
+		s.processes[config.Name] = &process{
+			config: config,
+			status: Status{Name: config.Name, State: StateStopped},
+		}
+	}
+	return s
+}
+
+// EmitEvents streams the output and the state changes of the processes to the frontend as OutputEvent and
+// StateEvent. The context is the one given to OnStartup.
+func EmitEvents(ctx context.Context, s *Supervisor) {
+	s.OnOutput = func(output Output) {
+		runtime.EventsEmit(ctx, OutputEvent, output)
+	}
+	s.OnStateChange = func(status Status) {
+		runtime.EventsEmit(ctx, StateEvent, status)
+	}
+}
+
+func (s *Supervisor) get(name string) (*process, error) {
+	p, exists := s.processes[name]
+	if !exists {
+		return nil, fmt.Errorf("%w %s", ErrUnknownProcess, name)
+	}
+	return p, nil
+}
+
+// Start starts the process and its watchdog. Starting a running process does nothing.
+func (s *Supervisor) Start(name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p, err := s.get(name)
+	if err != nil {
+		return err
+	}
+	if p.done != nil {
+		return nil
+	}
+	if p.config.Path == "" {
+		return fmt.Errorf("subprocess: the path of %s is empty", name)
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	p.status = Status{Name: name}
+	go s.watch(p, p.stop, p.done)
+	return nil
+}
+
+// Stop stops the process gracefully and waits until it has exited
+func (s *Supervisor) Stop(name string) error {
+	s.lock.Lock()
+	p, err := s.get(name)
+	if err != nil {
+		s.lock.Unlock()
+		return err
+	}
+	done := s.stopProcess(p)
+	s.lock.Unlock()
+	if done != nil {
+		<-done
+	}
+	return nil
+}
+
+// stopProcess signals the watchdog of the process to stop it. It returns the channel that is closed when it has
+// stopped, or nil if it isn't running.
+func (s *Supervisor) stopProcess(p *process) chan struct{} {
+	if p.done == nil {
+		return nil
+	}
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+	return p.done
+}
+
+// Restart stops the process and starts it again
+func (s *Supervisor) Restart(name string) error {
+	err := s.Stop(name)
+	if err != nil {
+		return err
+	}
+	return s.Start(name)
+}
+
+// Shutdown stops all processes gracefully and waits until they have exited. Call it in OnShutdown.
+func (s *Supervisor) Shutdown() {
+	s.lock.Lock()
+	var stopping []chan struct{}
+	for _, p := range s.processes {
+		if done := s.stopProcess(p); done != nil {
+			stopping = append(stopping, done)
+		}
+	}
+	s.lock.Unlock()
+	for _, done := range stopping {
+		<-done
+	}
+}
+
+// Status returns the status of the process
+func (s *Supervisor) Status(name string) (Status, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p, err := s.get(name)
+	if err != nil {
+		return Status{}, err
+	}
+	return p.status, nil
+}
+
+// List returns the status of all processes
+func (s *Supervisor) List() []Status {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	result := make([]Status, 0, len(s.processes))
+	for _, p := range s.processes {
+		result = append(result, p.status)
+	}
+	return result
+}
+
+// Write writes the data to stdin of the process, EG: the messages of a language server
+func (s *Supervisor) Write(name string, data string) error {
+	s.lock.Lock()
+	p, err := s.get(name)
+	var stdin io.WriteCloser
+	if err == nil {
+		stdin = p.stdin
+	}
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if stdin == nil {
+		return fmt.Errorf("%w: %s", ErrNotRunning, name)
+	}
+	_, err = io.WriteString(stdin, data)
+	return err
+}
+
+// setStatus updates the status of the process and notifies OnStateChange
+func (s *Supervisor) setStatus(p *process, update func(status *Status)) {
+	s.lock.Lock()
+	update(&p.status)
+	status := p.status
+	s.lock.Unlock()
+	if s.OnStateChange != nil {
+		s.OnStateChange(status)
+	}
+}
+
+// watch runs the process until it is stopped or won't be restarted
+func (s *Supervisor) watch(p *process, stop chan struct{}, done chan struct{}) {
+	defer func() {
+		s.lock.Lock()
+		p.done = nil
+		p.stdin = nil
+		s.lock.Unlock()
+		close(done)
+	}()
+
+	delay := p.config.RestartDelay
+	if delay <= 0 {
+		delay = defaultRestartDelay
+	}
+	consecutiveRestarts := 0
+	for {
+		started := time.Now()
+		exitCode, err := s.run(p, stop)
+
+		select {
+		case <-stop:
+			s.setStatus(p, func(status *Status) {
+				status.State = StateStopped
+				status.PID = 0
+				status.ExitCode = exitCode
+				status.Error = ""
+			})
+			return
+		default:
+		}
+
+		failed := err != nil || exitCode != 0
+		restart := p.config.Restart == RestartAlways || (p.config.Restart == RestartOnFailure && failed)
+		if time.Since(started) >= stableTime {
+			consecutiveRestarts = 0
+		}
+		if restart && p.config.MaxRestarts > 0 && consecutiveRestarts >= p.config.MaxRestarts {
+			if err == nil {
+				err = fmt.Errorf("exited %d times in a row", consecutiveRestarts+1)
+			}
+			s.setStatus(p, func(status *Status) {
+				status.State = StateFailed
+				status.PID = 0
+				status.ExitCode = exitCode
+				status.Error = err.Error()
+			})
+			return
+		}
+		if !restart {
+			s.setStatus(p, func(status *Status) {
+				status.State = StateExited
+				if err != nil {
+					status.State = StateFailed
+					status.Error = err.Error()
+				}
+				status.PID = 0
+				status.ExitCode = exitCode
+			})
+			return
+		}
+
+		wait := restartDelay(delay, consecutiveRestarts)
+		if time.Since(started) >= stableTime {
+			wait = 0
+		}
+		consecutiveRestarts++
+		s.setStatus(p, func(status *Status) {
+			status.State = StateRestarting
+			status.PID = 0
+			status.ExitCode = exitCode
+			status.Error = ""
+			if err != nil {
+				status.Error = err.Error()
+			}
+		})
+		select {
+		case <-stop:
+			s.setStatus(p, func(status *Status) {
+				status.State = StateStopped
+			})
+			return
+		case <-time.After(wait):
+		}
+		s.lock.Lock()
+		p.status.Restarts++
+		s.lock.Unlock()
+	}
+}
+
+// restartDelay doubles the delay for every consecutive restart
+func restartDelay(delay time.Duration, restarts int) time.Duration {
+	for i := 0; i < restarts && delay < maxRestartDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRestartDelay)
+}
+
+// run starts the process and waits until it has exited or has been stopped
+func (s *Supervisor) run(p *process, stop chan struct{}) (int, error) {
+	config := p.config
+	cmd := exec.Command(config.Path)
+	cmd.Dir = config.Dir
+	cmd.Env = append(os.Environ(), config.Env...)
+
+	port := 0
+	if config.PortEnv != "" {
+		var err error
+		port, err = FreePort()
+		if err != nil {
+			return -1, err
+		}
+		cmd.Env = append(cmd.Env, config.PortEnv+"="+strconv.Itoa(port))
+	}
+	for _, arg := range config.Args {
+		cmd.Args = append(cmd.Args, strings.ReplaceAll(arg, "{port}", strconv.Itoa(port)))
+	}
+
+	descriptors, err := s.platform.passFiles(cmd, config.Files)
+	if err != nil {
+		return -1, err
+	}
+	if len(descriptors) > 0 {
+		cmd.Env = append(cmd.Env, FilesEnv+"="+strings.Join(descriptors, ","))
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return -1, err
+	}
+	// Processes that are started by the process might inherit its output and keep it open after it has exited, so
+	// Wait only waits for the output up to the outputDelay once the process has exited
+	stdout, stdoutWriter := io.Pipe()
+	stderr, stderrWriter := io.Pipe()
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+	cmd.WaitDelay = outputDelay
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go s.readOutput(&readers, config.Name, "stdout", stdout)
+	go s.readOutput(&readers, config.Name, "stderr", stderr)
+	wait := func() error {
+		err := cmd.Wait()
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		readers.Wait()
+		if errors.Is(err, exec.ErrWaitDelay) {
+			// The process has exited successfully
+			return nil
+		}
+		return err
+	}
+
+	s.platform.prepare(cmd)
+	err = cmd.Start()
+	if err != nil {
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		return -1, err
+	}
+	err = s.platform.started(cmd)
+	if err != nil {
+		cmd.Process.Kill()
+		wait()
+		return -1, err
+	}
+
+	s.lock.Lock()
+	p.cmd = cmd
+	p.stdin = stdin
+	s.lock.Unlock()
+	s.setStatus(p, func(status *Status) {
+		status.State = StateRunning
+		status.PID = cmd.Process.Pid
+		status.Port = port
+		status.Error = ""
+	})
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- wait()
+	}()
+
+	select {
+	case err = <-exited:
+	case <-stop:
+		err = s.terminate(cmd, stdin, config.ShutdownTimeout, exited)
+	}
+
+	s.lock.Lock()
+	p.cmd = nil
+	p.stdin = nil
+	s.lock.Unlock()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// terminate closes stdin and asks the process to exit. It is killed if it hasn't exited within the timeout.
+func (s *Supervisor) terminate(cmd *exec.Cmd, stdin io.Closer, timeout time.Duration, exited chan error) error {
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	stdin.Close()
+	s.platform.interrupt(cmd)
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		return <-exited
+	}
+}
+
+func (s *Supervisor) readOutput(readers *sync.WaitGroup, name string, stream string, reader io.Reader) {
+	defer readers.Done()
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		if s.OnOutput != nil {
+			s.OnOutput(Output{Name: name, Stream: stream, Line: scanner.Text()})
+		}
+	}
+	// The rest of the output is discarded after an error to not block the process
+	io.Copy(io.Discard, reader)
+}
+
+// FreePort returns a free TCP port of the loopback interface
+func FreePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// ListenerFile returns a file of the listener that can be passed to a process in Config.Files, so it accepts the
+// connections on the socket of the application. It is not supported on Windows.
+func ListenerFile(listener net.Listener) (*os.File, error) {
+	switch l := listener.(type) {
+	case *net.TCPListener:
+		return l.File()
+	case *net.UnixListener:
+		return l.File()
+	default:
+		return nil, fmt.Errorf("subprocess: unsupported listener %T", listener)
+	}
+}
+
+// InheritedFiles returns the files that have been passed to this process by a Supervisor, in the order of
+// Config.Files. It is called by helpers that are written in Go.
+func InheritedFiles() ([]*os.File, error) {
+	value := os.Getenv(FilesEnv)
+	if value == "" {
+		return nil, nil
+	}
+	var files []*os.File
+	for index, descriptor := range strings.Split(value, ",") {
+		fd, err := strconv.ParseUint(descriptor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("subprocess: invalid %s: %s", FilesEnv, value)
+		}
+		files = append(files, os.NewFile(uintptr(fd), "inherited-"+strconv.Itoa(index)))
+	}
+	return files, nil
+}
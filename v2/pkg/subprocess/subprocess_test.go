@@ -0,0 +1,193 @@
+package subprocess
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// The test binary is the helper process when helperEnv is set
+const helperEnv = "SUBPROCESS_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "echo":
+		// Echoes stdin until it is closed
+		fmt.Println("ready " + os.Getenv("PORT") + " " + os.Args[len(os.Args)-1])
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			fmt.Println("echo " + scanner.Text())
+		}
+		os.Exit(0)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "failing")
+		os.Exit(3)
+	case "orphan":
+		// Exits while a child process keeps the output open
+		child := exec.Command(os.Args[0])
+		child.Env = append(os.Environ(), helperEnv+"=sleep")
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		if err := child.Start(); err != nil {
+			os.Exit(1)
+		}
+		fmt.Println("child " + strconv.Itoa(child.Process.Pid))
+		os.Exit(0)
+	case "sleep":
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+}
+
+type recorder struct {
+	lock   sync.Mutex
+	output []Output
+	states []State
+}
+
+func (r *recorder) attach(s *Supervisor) {
+	s.OnOutput = func(output Output) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.output = append(r.output, output)
+	}
+	s.OnStateChange = func(status Status) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.states = append(r.states, status.State)
+	}
+}
+
+func (r *recorder) waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		r.lock.Lock()
+		done := condition()
+		r.lock.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out")
+}
+
+func helper(name string, mode string) Config {
+	return Config{
+		Name: name,
+		Path: os.Args[0],
+		Env:  []string{helperEnv + "=" + mode},
+	}
+}
+
+func TestOutputAndStop(t *testing.T) {
+	is2 := is.New(t)
+	config := helper("echo", "echo")
+	config.PortEnv = "PORT"
+	config.Args = []string{"--port={port}"}
+	s := New(config)
+	r := &recorder{}
+	r.attach(s)
+
+	is2.NoErr(s.Start("echo"))
+	r.waitFor(t, func() bool { return len(r.output) == 1 })
+	status, err := s.Status("echo")
+	is2.NoErr(err)
+	is2.Equal(status.State, StateRunning)
+	is2.True(status.PID > 0)
+	is2.True(status.Port > 0)
+	port := strconv.Itoa(status.Port)
+	is2.Equal(r.output[0], Output{Name: "echo", Stream: "stdout", Line: "ready " + port + " --port=" + port})
+
+	is2.NoErr(s.Write("echo", "hello\n"))
+	r.waitFor(t, func() bool { return len(r.output) == 2 })
+	is2.Equal(r.output[1].Line, "echo hello")
+
+	is2.NoErr(s.Stop("echo"))
+	status, _ = s.Status("echo")
+	is2.Equal(status.State, StateStopped)
+	is2.Equal(status.PID, 0)
+	is2.Equal(r.states, []State{StateRunning, StateStopped})
+	is2.True(s.Write("echo", "hello\n") != nil)
+}
+
+func TestRestartOnFailure(t *testing.T) {
+	is2 := is.New(t)
+	config := helper("fail", "fail")
+	config.Restart = RestartOnFailure
+	config.MaxRestarts = 2
+	config.RestartDelay = time.Millisecond
+	s := New(config)
+	r := &recorder{}
+	r.attach(s)
+
+	is2.NoErr(s.Start("fail"))
+	r.waitFor(t, func() bool { return len(r.states) > 0 && r.states[len(r.states)-1] == StateFailed })
+	status, _ := s.Status("fail")
+	is2.Equal(status.Restarts, 2)
+	is2.Equal(status.ExitCode, 3)
+	is2.Equal(r.states, []State{StateRunning, StateRestarting, StateRunning, StateRestarting, StateRunning, StateFailed})
+	is2.Equal(r.output[0], Output{Name: "fail", Stream: "stderr", Line: "failing"})
+
+	// A failed process can be started again
+	is2.NoErr(s.Start("fail"))
+	s.Shutdown()
+}
+
+func TestNoRestart(t *testing.T) {
+	is2 := is.New(t)
+	s := New(helper("fail", "fail"))
+	r := &recorder{}
+	r.attach(s)
+	is2.NoErr(s.Start("fail"))
+	r.waitFor(t, func() bool { return len(r.states) == 2 })
+	is2.Equal(r.states, []State{StateRunning, StateExited})
+
+	is2.True(s.Start("unknown") != nil)
+	s.processes["missing"] = &process{config: Config{Name: "missing", Path: "/does/not/exist"}}
+	is2.NoErr(s.Start("missing"))
+	r.waitFor(t, func() bool { return len(r.states) == 3 })
+	status, _ := s.Status("missing")
+	is2.Equal(status.State, StateFailed)
+	is2.True(status.Error != "")
+}
+
+func TestExitWithOpenOutput(t *testing.T) {
+	is2 := is.New(t)
+	s := New(helper("orphan", "orphan"))
+	r := &recorder{}
+	r.attach(s)
+	is2.NoErr(s.Start("orphan"))
+	r.waitFor(t, func() bool { return len(r.output) == 1 })
+	r.lock.Lock()
+	pid, err := strconv.Atoi(strings.TrimPrefix(r.output[0].Line, "child "))
+	r.lock.Unlock()
+	is2.NoErr(err)
+	if child, err := os.FindProcess(pid); err == nil {
+		defer child.Kill()
+	}
+
+	// The exit is reported although the child still holds the output
+	r.waitFor(t, func() bool { return len(r.states) == 2 })
+	is2.Equal(r.states, []State{StateRunning, StateExited})
+	status, _ := s.Status("orphan")
+	is2.Equal(status.ExitCode, 0)
+}
+
+func TestRestartDelay(t *testing.T) {
+	is2 := is.New(t)
+	is2.Equal(restartDelay(time.Second, 0), time.Second)
+	is2.Equal(restartDelay(time.Second, 3), 8*time.Second)
+	is2.Equal(restartDelay(time.Second, 100), maxRestartDelay)
+}
@@ -0,0 +1,32 @@
+//go:build !windows
+
+package subprocess
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+type platform struct{}
+
+// passFiles passes the files as the descriptors after stdin, stdout and stderr
+func (p *platform) passFiles(cmd *exec.Cmd, files []*os.File) ([]string, error) {
+	cmd.ExtraFiles = files
+	descriptors := make([]string, len(files))
+	for index := range files {
+		descriptors[index] = strconv.Itoa(3 + index)
+	}
+	return descriptors, nil
+}
+
+func (p *platform) prepare(*exec.Cmd) {}
+
+func (p *platform) started(*exec.Cmd) error {
+	return nil
+}
+
+func (p *platform) interrupt(cmd *exec.Cmd) {
+	cmd.Process.Signal(syscall.SIGTERM)
+}
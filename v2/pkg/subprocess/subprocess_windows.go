@@ -0,0 +1,80 @@
+//go:build windows
+
+package subprocess
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// platform assigns the processes to a job object that kills them when the application exits, even if it crashed
+type platform struct {
+	init    sync.Once
+	job     windows.Handle
+	initErr error
+}
+
+func (p *platform) getJob() (windows.Handle, error) {
+	p.init.Do(func() {
+		p.job, p.initErr = windows.CreateJobObject(nil, nil)
+		if p.initErr != nil {
+			return
+		}
+		info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+		info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+		_, p.initErr = windows.SetInformationJobObject(p.job, windows.JobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	})
+	return p.job, p.initErr
+}
+
+func sysProcAttr(cmd *exec.Cmd) *syscall.SysProcAttr {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	return cmd.SysProcAttr
+}
+
+// passFiles makes the handles of the files inheritable
+func (p *platform) passFiles(cmd *exec.Cmd, files []*os.File) ([]string, error) {
+	descriptors := make([]string, len(files))
+	for index, file := range files {
+		handle := windows.Handle(file.Fd())
+		err := windows.SetHandleInformation(handle, windows.HANDLE_FLAG_INHERIT, windows.HANDLE_FLAG_INHERIT)
+		if err != nil {
+			return nil, fmt.Errorf("subprocess: unable to pass %s: %w", file.Name(), err)
+		}
+		attr := sysProcAttr(cmd)
+		attr.AdditionalInheritedHandles = append(attr.AdditionalInheritedHandles, syscall.Handle(handle))
+		descriptors[index] = strconv.FormatUint(uint64(handle), 10)
+	}
+	return descriptors, nil
+}
+
+// prepare hides the console window of console applications
+func (p *platform) prepare(cmd *exec.Cmd) {
+	sysProcAttr(cmd).CreationFlags |= windows.CREATE_NO_WINDOW
+}
+
+func (p *platform) started(cmd *exec.Cmd) error {
+	job, err := p.getJob()
+	if err != nil {
+		return fmt.Errorf("subprocess: unable to create the job object: %w", err)
+	}
+	process, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(process)
+	return windows.AssignProcessToJobObject(job, process)
+}
+
+// interrupt does nothing, as Windows has no signal for GUI applications to ask a process to exit. The process sees
+// stdin being closed.
+func (p *platform) interrupt(*exec.Cmd) {}
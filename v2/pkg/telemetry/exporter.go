@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HTTPExporter posts the batches as JSON to a URL
+type HTTPExporter struct {
+	URL string
+	// Header is added to the requests, EG: the API key of the backend
+	Header http.Header
+	// Client is used for the requests. http.DefaultClient is used if it is nil.
+	Client *http.Client
+}
+
+// Export posts the batch, the export fails unless the backend responds with a 2xx status
+func (e *HTTPExporter) Export(ctx context.Context, batch Batch) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range e.Header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("telemetry: exporting to %s failed with status %s", e.URL, response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,378 @@
+// Package telemetry collects usage events of an application once the user has opted in. Nothing is collected or sent
+// until SetEnabled(true) has been called, the consent is stored in the application data directory. The events are
+// batched locally and passed to an Exporter, EG: the HTTPExporter. Batches that can't be exported, EG: while the
+// computer is offline, are queued on disk and exported with the next flush. Every batch carries the platform, the
+// build type and the build profile of runtime.BuildInfo and an anonymous installation ID, which is discarded when the
+// user opts out. Bind the Telemetry service to ask for the consent and to track events from JS.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// The files in the application data directory
+const (
+	ConsentFilename = "telemetry.json"
+	QueueFilename   = "telemetry-queue.json"
+)
+
+const (
+	defaultBatchSize     = 50
+	defaultFlushInterval = time.Minute
+	defaultMaxQueued     = 1000
+	exportTimeout        = 30 * time.Second
+)
+
+// ErrNotStarted is returned when the consent is changed before Start has been called
+var ErrNotStarted = errors.New("telemetry: the service has not been started")
+
+// Event is a tracked event
+type Event struct {
+	Name       string                 `json:"name"`
+	Time       time.Time              `json:"time"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Context describes the installation that has sent a batch
+type Context struct {
+	// InstallationID is a random ID that is created when the user opts in
+	InstallationID string `json:"installationId"`
+	// Version is the version of the application, see Telemetry.Version
+	Version      string            `json:"version,omitempty"`
+	Platform     string            `json:"platform"`
+	Arch         string            `json:"arch"`
+	BuildType    string            `json:"buildType"`
+	BuildProfile string            `json:"buildProfile,omitempty"`
+	BuildFlags   map[string]string `json:"buildFlags,omitempty"`
+}
+
+// Batch is a number of events that are exported together
+type Batch struct {
+	Context Context `json:"context"`
+	Events  []Event `json:"events"`
+}
+
+// Exporter sends the batches to a telemetry backend
+type Exporter interface {
+	Export(ctx context.Context, batch Batch) error
+}
+
+// ExporterFunc is a function that is an Exporter
+type ExporterFunc func(ctx context.Context, batch Batch) error
+
+// Export calls f
+func (f ExporterFunc) Export(ctx context.Context, batch Batch) error {
+	return f(ctx, batch)
+}
+
+// consent is the content of the ConsentFilename
+type consent struct {
+	Decided        bool      `json:"decided"`
+	Enabled        bool      `json:"enabled"`
+	InstallationID string    `json:"installationId,omitempty"`
+	Time           time.Time `json:"time"`
+}
+
+// Telemetry is the telemetry service
+type Telemetry struct {
+	// Version is the version of the application that is sent with the batches
+	Version string
+	// Dir is the directory of the consent and the queue. Default: the application data directory
+	Dir string
+	// BatchSize is the number of events after which the events are flushed. Default: 50
+	BatchSize int
+	// FlushInterval is how often the events are flushed. Default: 1 minute
+	FlushInterval time.Duration
+	// MaxQueued is the maximum number of events that are queued while they can't be exported, the oldest batches
+	// are dropped first. Default: 1000
+	MaxQueued int
+
+	exporter Exporter
+	ctx      context.Context
+	consent  consent
+	pending  []Event
+	queue    []Batch
+	lock     sync.Mutex
+	// flushLock serialises the flushes, the exports of a flush run without lock
+	flushLock sync.Mutex
+	flush     chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// New creates the telemetry service that exports the batches with the exporter
+func New(exporter Exporter) *Telemetry {
+	return &Telemetry{exporter: exporter}
+}
+
+func (t *Telemetry) dir() string {
+	if t.Dir != "" {
+		return t.Dir
+	}
+	return runtime.AppDataDir(t.ctx)
+}
+
+func (t *Telemetry) batchSize() int {
+	if t.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return t.BatchSize
+}
+
+func (t *Telemetry) maxQueued() int {
+	if t.MaxQueued <= 0 {
+		return defaultMaxQueued
+	}
+	return t.MaxQueued
+}
+
+// Start reads the consent and the queue and flushes the events every FlushInterval until Stop is called, EG: in
+// OnStartup
+func (t *Telemetry) Start(ctx context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.stop != nil {
+		return nil
+	}
+	t.ctx = ctx
+	if err := readJSON(filepath.Join(t.dir(), ConsentFilename), &t.consent); err != nil {
+		return err
+	}
+	if t.consent.Enabled {
+		if err := readJSON(filepath.Join(t.dir(), QueueFilename), &t.queue); err != nil {
+			return err
+		}
+	}
+	interval := t.FlushInterval
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	t.flush = make(chan struct{}, 1)
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+	go t.run(t.flush, t.stop, t.done, interval)
+	return nil
+}
+
+// Stop stops flushing and flushes the pending events, the events that can't be exported are queued for the next
+// start, EG: in OnShutdown
+func (t *Telemetry) Stop() error {
+	t.lock.Lock()
+	stop, done := t.stop, t.done
+	t.stop = nil
+	t.lock.Unlock()
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+	<-done
+	return t.Flush()
+}
+
+func (t *Telemetry) run(flush chan struct{}, stop chan struct{}, done chan struct{}, interval time.Duration) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-flush:
+		}
+		_ = t.Flush()
+	}
+}
+
+// Decided returns true if the user has opted in or out
+func (t *Telemetry) Decided() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.consent.Decided
+}
+
+// Enabled returns true if the user has opted in
+func (t *Telemetry) Enabled() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.consent.Enabled
+}
+
+// SetEnabled stores the consent of the user. Opting out discards the installation ID and the pending and queued
+// events.
+func (t *Telemetry) SetEnabled(enabled bool) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.ctx == nil {
+		return ErrNotStarted
+	}
+	updated := consent{Decided: true, Enabled: enabled, Time: time.Now().UTC()}
+	if enabled {
+		updated.InstallationID = t.consent.InstallationID
+		if updated.InstallationID == "" {
+			id, err := newInstallationID()
+			if err != nil {
+				return err
+			}
+			updated.InstallationID = id
+		}
+	}
+	if err := writeJSON(filepath.Join(t.dir(), ConsentFilename), updated); err != nil {
+		return err
+	}
+	t.consent = updated
+	if !enabled {
+		t.pending = nil
+		t.queue = nil
+		if err := os.Remove(filepath.Join(t.dir(), QueueFilename)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Track records an event with optional properties. It is dropped unless the user has opted in. The properties have
+// to be serialisable to JSON.
+func (t *Telemetry) Track(name string, properties map[string]interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.consent.Enabled || t.stop == nil {
+		return
+	}
+	t.pending = append(t.pending, Event{Name: name, Time: time.Now().UTC(), Properties: properties})
+	if len(t.pending) >= t.batchSize() {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush exports the queued batches and the pending events. The batches that fail to export are queued.
+func (t *Telemetry) Flush() error {
+	t.flushLock.Lock()
+	defer t.flushLock.Unlock()
+
+	t.lock.Lock()
+	if !t.consent.Enabled {
+		t.lock.Unlock()
+		return nil
+	}
+	batches := t.queue
+	if len(t.pending) > 0 {
+		batches = append(batches, Batch{Context: t.context(), Events: t.pending})
+	}
+	t.queue = nil
+	t.pending = nil
+	ctx := t.ctx
+	t.lock.Unlock()
+	if len(batches) == 0 {
+		return nil
+	}
+
+	var failed []Batch
+	var exportErr error
+	for i, batch := range batches {
+		exportCtx, cancel := context.WithTimeout(ctx, exportTimeout)
+		err := t.exporter.Export(exportCtx, batch)
+		cancel()
+		if err != nil {
+			// Keep the order of the events, the following batches are queued as well
+			failed = batches[i:]
+			exportErr = err
+			break
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.consent.Enabled {
+		return exportErr
+	}
+	t.queue = limitQueue(append(failed, t.queue...), t.maxQueued())
+	if err := t.saveQueue(); err != nil {
+		return err
+	}
+	return exportErr
+}
+
+func (t *Telemetry) saveQueue() error {
+	path := filepath.Join(t.dir(), QueueFilename)
+	if len(t.queue) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSON(path, t.queue)
+}
+
+func (t *Telemetry) context() Context {
+	environment := runtime.Environment(t.ctx)
+	buildInfo := runtime.BuildInfo(t.ctx)
+	result := Context{
+		InstallationID: t.consent.InstallationID,
+		Version:        t.Version,
+		Platform:       environment.Platform,
+		Arch:           environment.Arch,
+		BuildType:      environment.BuildType,
+		BuildProfile:   buildInfo.Name,
+	}
+	if len(buildInfo.Flags) > 0 {
+		result.BuildFlags = buildInfo.Flags
+	}
+	return result
+}
+
+// limitQueue drops the oldest batches until at most maximum events are queued
+func limitQueue(queue []Batch, maximum int) []Batch {
+	count := 0
+	for i := len(queue) - 1; i >= 0; i-- {
+		count += len(queue[i].Events)
+		if count > maximum {
+			return queue[i+1:]
+		}
+	}
+	return queue
+}
+
+func newInstallationID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// readJSON reads the file into value, a missing file is not an error
+func readJSON(path string, value interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+func writeJSON(path string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,180 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recordingExporter records the exported batches and fails while err is set
+type recordingExporter struct {
+	lock    sync.Mutex
+	batches []Batch
+	err     error
+}
+
+func (e *recordingExporter) Export(ctx context.Context, batch Batch) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.batches = append(e.batches, batch)
+	return nil
+}
+
+func testContext() context.Context {
+	ctx := context.WithValue(context.Background(), "buildtype", "production")
+	ctx = context.WithValue(ctx, "buildprofile", "beta")
+	return context.WithValue(ctx, "buildflags", map[string]string{"channel": "beta"})
+}
+
+func names(events []Event) []string {
+	var result []string
+	for _, event := range events {
+		result = append(result, event.Name)
+	}
+	return result
+}
+
+func TestOptIn(t *testing.T) {
+	exporter := &recordingExporter{}
+	telemetry := &Telemetry{Dir: t.TempDir(), Version: "1.2.0", exporter: exporter}
+	if err := telemetry.SetEnabled(true); !errors.Is(err, ErrNotStarted) {
+		t.Errorf("SetEnabled() before Start = %v, want ErrNotStarted", err)
+	}
+	if err := telemetry.Start(testContext()); err != nil {
+		t.Fatal(err)
+	}
+	defer telemetry.Stop()
+
+	telemetry.Track("dropped", nil)
+	if err := telemetry.Flush(); err != nil || len(exporter.batches) != 0 || telemetry.Decided() {
+		t.Fatalf("events have been exported without consent: %v %+v", err, exporter.batches)
+	}
+
+	if err := telemetry.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	telemetry.Track("export", map[string]interface{}{"format": "png"})
+	if err := telemetry.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(exporter.batches) != 1 {
+		t.Fatalf("exported %d batches, want 1", len(exporter.batches))
+	}
+	batch := exporter.batches[0]
+	if batch.Context.InstallationID == "" || batch.Context.Version != "1.2.0" || batch.Context.BuildType != "production" ||
+		batch.Context.BuildProfile != "beta" || batch.Context.BuildFlags["channel"] != "beta" {
+		t.Errorf("unexpected context %+v", batch.Context)
+	}
+	if len(batch.Events) != 1 || batch.Events[0].Name != "export" || batch.Events[0].Properties["format"] != "png" {
+		t.Errorf("unexpected events %+v", batch.Events)
+	}
+}
+
+func TestOfflineQueue(t *testing.T) {
+	dir := t.TempDir()
+	exporter := &recordingExporter{err: errors.New("offline")}
+	telemetry := &Telemetry{Dir: dir, MaxQueued: 3, exporter: exporter}
+	if err := telemetry.Start(testContext()); err != nil {
+		t.Fatal(err)
+	}
+	if err := telemetry.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	for _, batch := range [][]string{{"a", "b"}, {"c"}, {"d"}} {
+		for _, name := range batch {
+			telemetry.Track(name, nil)
+		}
+		if err := telemetry.Flush(); err == nil {
+			t.Fatal("the failed export has not been reported")
+		}
+	}
+	telemetry.Track("e", nil)
+	if err := telemetry.Stop(); err == nil {
+		t.Fatal("the failed export has not been reported")
+	}
+
+	// The queue is exported after the restart, the oldest batches have been dropped
+	exporter.err = nil
+	telemetry = &Telemetry{Dir: dir, MaxQueued: 3, exporter: exporter}
+	if err := telemetry.Start(testContext()); err != nil {
+		t.Fatal(err)
+	}
+	defer telemetry.Stop()
+	if err := telemetry.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	var exported []string
+	for _, batch := range exporter.batches {
+		exported = append(exported, names(batch.Events)...)
+	}
+	if want := []string{"c", "d", "e"}; !reflect.DeepEqual(exported, want) {
+		t.Errorf("exported %v, want %v", exported, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, QueueFilename)); !os.IsNotExist(err) {
+		t.Errorf("the queue has not been removed: %v", err)
+	}
+}
+
+func TestOptOut(t *testing.T) {
+	dir := t.TempDir()
+	exporter := &recordingExporter{err: errors.New("offline")}
+	telemetry := &Telemetry{Dir: dir, exporter: exporter}
+	if err := telemetry.Start(testContext()); err != nil {
+		t.Fatal(err)
+	}
+	defer telemetry.Stop()
+	if err := telemetry.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	telemetry.Track("queued", nil)
+	_ = telemetry.Flush()
+
+	if err := telemetry.SetEnabled(false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, QueueFilename)); !os.IsNotExist(err) {
+		t.Errorf("the queue has not been removed: %v", err)
+	}
+	var stored consent
+	if err := readJSON(filepath.Join(dir, ConsentFilename), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Decided || stored.Enabled || stored.InstallationID != "" {
+		t.Errorf("unexpected consent %+v", stored)
+	}
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var received Batch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	exporter := &HTTPExporter{URL: server.URL, Header: http.Header{"X-Api-Key": {"secret"}}}
+	if err := exporter.Export(context.Background(), Batch{Events: []Event{{Name: "start"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(received.Events) != 1 || received.Events[0].Name != "start" {
+		t.Errorf("received %+v", received)
+	}
+
+	exporter.Header = nil
+	if err := exporter.Export(context.Background(), Batch{}); err == nil {
+		t.Error("the status of the backend has not been reported")
+	}
+}
@@ -0,0 +1,204 @@
+// Package toast shows Windows toast notifications and delivers their activations to the application, also when the
+// notification is clicked after the application has exited. Windows starts the application through its COM
+// activator in that case, so the Notifier has to listen before wails.Run:
+//
+//	notifier := toast.New(toast.Config{
+//		AppID:          "Company.App",
+//		DisplayName:    "App",
+//		ActivatorCLSID: "{1AA6A8C6-52B2-4B9C-8D6B-84F1D2E5A6C1}",
+//	})
+//	notifier.OnActivated = func(activation toast.Activation) { ... }
+//	err := notifier.Register()
+//	err = notifier.Listen()
+//	defer notifier.Close()
+//
+// The other platforms return ErrNotSupported.
+package toast
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"sync"
+)
+
+// ErrNotSupported is returned on platforms other than Windows
+var ErrNotSupported = errors.New("toast: not supported on this platform")
+
+// ActivatedArg is the argument of the command line that Windows starts the application with to deliver an
+// activation
+const ActivatedArg = "-ToastActivated"
+
+// Config identifies the application to Windows
+type Config struct {
+	// AppID is the AppUserModelID of the application, EG: "Company.App". Installers that create the Start menu
+	// shortcut have to set the same ID.
+	AppID string
+	// DisplayName is the name of the Start menu shortcut, which Windows shows as the sender of the notifications
+	DisplayName string
+	// ActivatorCLSID is the CLSID of the COM activator, EG: "{1AA6A8C6-52B2-4B9C-8D6B-84F1D2E5A6C1}". It has to be
+	// unique to the application and mustn't change between versions.
+	ActivatorCLSID string
+	// IconPath is the icon of the Start menu shortcut. The icon of the executable is used by default.
+	IconPath string
+}
+
+// Activation is delivered when the user clicks a notification or one of its actions
+type Activation struct {
+	AppID string `json:"appId"`
+	// Arguments are the arguments of the clicked action or the notification
+	Arguments string `json:"arguments"`
+	// Inputs are the values of the inputs of the notification by their ID
+	Inputs map[string]string `json:"inputs"`
+}
+
+// Notification is a toast notification
+type Notification struct {
+	Title   string
+	Message string
+	// Arguments are delivered when the notification itself is clicked
+	Arguments string
+	Inputs    []Input
+	Actions   []Action
+}
+
+// Input is a text input of a notification, EG: to reply to a message
+type Input struct {
+	ID          string
+	Placeholder string
+}
+
+// Action is a button of a notification
+type Action struct {
+	Content string
+	// Arguments are delivered when the action is clicked
+	Arguments string
+}
+
+// Notifier shows the notifications of the application and receives their activations
+type Notifier struct {
+	// OnActivated is called on a COM thread when the user clicks a notification or one of its actions, EG: to emit
+	// the activation as event to the frontend
+	OnActivated func(activation Activation)
+
+	config Config
+	lock   sync.Mutex
+	stop   func()
+}
+
+// New creates the notifier of the application
+func New(config Config) *Notifier {
+	return &Notifier{config: config}
+}
+
+// Register sets the AppUserModelID of the process and registers the COM activator and the Start menu shortcut for
+// the current user, which Windows requires for the notifications of unpackaged applications. It updates the
+// registration when the executable has moved.
+func (n *Notifier) Register() error {
+	return register(n.config)
+}
+
+// Listen registers the COM activator with Windows, which delivers the activations to OnActivated until Close is
+// called. Activations that started the application are delivered after Listen.
+func (n *Notifier) Listen() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.stop != nil {
+		return nil
+	}
+	stop, err := listen(n.config, n.activated)
+	if err != nil {
+		return err
+	}
+	n.stop = stop
+	return nil
+}
+
+// Show shows the notification
+func (n *Notifier) Show(notification Notification) error {
+	content, err := notification.content()
+	if err != nil {
+		return err
+	}
+	return show(n.config, content)
+}
+
+// Close revokes the COM activator
+func (n *Notifier) Close() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.stop != nil {
+		n.stop()
+		n.stop = nil
+	}
+}
+
+func (n *Notifier) activated(activation Activation) {
+	if n.OnActivated != nil {
+		n.OnActivated(activation)
+	}
+}
+
+// LaunchedByActivation reports if Windows started the application to deliver an activation, EG: to start it
+// hidden
+func LaunchedByActivation() bool {
+	for _, arg := range os.Args[1:] {
+		if arg == ActivatedArg || arg == "-Embedding" {
+			return true
+		}
+	}
+	return false
+}
+
+type toastXML struct {
+	XMLName        xml.Name    `xml:"toast"`
+	Launch         string      `xml:"launch,attr,omitempty"`
+	ActivationType string      `xml:"activationType,attr"`
+	Binding        bindingXML  `xml:"visual>binding"`
+	Actions        *actionsXML `xml:"actions,omitempty"`
+}
+
+type bindingXML struct {
+	Template string   `xml:"template,attr"`
+	Texts    []string `xml:"text"`
+}
+
+type actionsXML struct {
+	Inputs  []inputXML  `xml:"input"`
+	Actions []actionXML `xml:"action"`
+}
+
+type inputXML struct {
+	ID          string `xml:"id,attr"`
+	Type        string `xml:"type,attr"`
+	Placeholder string `xml:"placeHolderContent,attr,omitempty"`
+}
+
+type actionXML struct {
+	Content        string `xml:"content,attr"`
+	Arguments      string `xml:"arguments,attr"`
+	ActivationType string `xml:"activationType,attr"`
+}
+
+// content returns the XML of the notification. The foreground activations are delivered to the COM activator.
+func (n Notification) content() (string, error) {
+	toast := toastXML{
+		Launch:         n.Arguments,
+		ActivationType: "foreground",
+		Binding:        bindingXML{Template: "ToastGeneric", Texts: []string{n.Title, n.Message}},
+	}
+	if len(n.Inputs) > 0 || len(n.Actions) > 0 {
+		toast.Actions = &actionsXML{}
+		for _, input := range n.Inputs {
+			toast.Actions.Inputs = append(toast.Actions.Inputs, inputXML{ID: input.ID, Type: "text", Placeholder: input.Placeholder})
+		}
+		for _, action := range n.Actions {
+			toast.Actions.Actions = append(toast.Actions.Actions, actionXML{Content: action.Content, Arguments: action.Arguments, ActivationType: "foreground"})
+		}
+	}
+	data, err := xml.Marshal(toast)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
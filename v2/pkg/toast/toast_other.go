@@ -0,0 +1,15 @@
+//go:build !windows
+
+package toast
+
+func register(Config) error {
+	return ErrNotSupported
+}
+
+func listen(Config, func(Activation)) (func(), error) {
+	return nil, ErrNotSupported
+}
+
+func show(Config, string) error {
+	return ErrNotSupported
+}
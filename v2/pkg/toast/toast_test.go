@@ -0,0 +1,36 @@
+package toast
+
+import (
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestContent(t *testing.T) {
+	is2 := is.New(t)
+	content, err := Notification{Title: "Build", Message: "Done <3"}.content()
+	is2.NoErr(err)
+	is2.Equal(content, `<toast activationType="foreground"><visual><binding template="ToastGeneric"><text>Build</text><text>Done &lt;3</text></binding></visual></toast>`)
+
+	content, err = Notification{
+		Title:     "Message",
+		Message:   "Hello",
+		Arguments: "open=1",
+		Inputs:    []Input{{ID: "reply", Placeholder: "Reply"}},
+		Actions:   []Action{{Content: "Send", Arguments: "send=1"}},
+	}.content()
+	is2.NoErr(err)
+	is2.Equal(content, `<toast launch="open=1" activationType="foreground"><visual><binding template="ToastGeneric"><text>Message</text><text>Hello</text></binding></visual>`+
+		`<actions><input id="reply" type="text" placeHolderContent="Reply"></input><action content="Send" arguments="send=1" activationType="foreground"></action></actions></toast>`)
+}
+
+func TestLaunchedByActivation(t *testing.T) {
+	is2 := is.New(t)
+	args := os.Args
+	defer func() { os.Args = args }()
+	os.Args = []string{"app"}
+	is2.True(!LaunchedByActivation())
+	os.Args = []string{"app", ActivatedArg}
+	is2.True(LaunchedByActivation())
+}
@@ -0,0 +1,484 @@
+//go:build windows
+
+package toast
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/go-ole/go-ole"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	clsctxLocalServer      = 4
+	regclsMultipleUse      = 1
+	roInitMultithreaded    = 1
+	eNoInterface           = 0x80004002
+	classENoAggregation    = 0x80040110
+	vtLPWStr               = 31
+	vtCLSID                = 72
+	pidAppUserModelID      = 5
+	pidToastActivatorCLSID = 26
+)
+
+var (
+	procSetCurrentProcessExplicitAppUserModelID = windows.NewLazySystemDLL("shell32.dll").NewProc("SetCurrentProcessExplicitAppUserModelID")
+	procCoRegisterClassObject                   = windows.NewLazySystemDLL("ole32.dll").NewProc("CoRegisterClassObject")
+	procCoRevokeClassObject                     = windows.NewLazySystemDLL("ole32.dll").NewProc("CoRevokeClassObject")
+	procWindowsCreateString                     = windows.NewLazySystemDLL("combase.dll").NewProc("WindowsCreateString")
+)
+
+var (
+	iidIClassFactory                    = ole.NewGUID("{00000001-0000-0000-C000-000000000046}")
+	iidINotificationActivationCallback  = ole.NewGUID("{53E31837-6600-4A81-9395-75CFFE746F94}")
+	clsidShellLink                      = ole.NewGUID("{00021401-0000-0000-C000-000000000046}")
+	iidIShellLinkW                      = ole.NewGUID("{000214F9-0000-0000-C000-000000000046}")
+	iidIPropertyStore                   = ole.NewGUID("{886D8EEB-8CF2-4446-8D02-CDBA1DBDCF99}")
+	iidIPersistFile                     = ole.NewGUID("{0000010B-0000-0000-C000-000000000046}")
+	iidIXmlDocument                     = ole.NewGUID("{F7F3A506-1E87-42D6-BCFB-B8C809FA5494}")
+	iidIXmlDocumentIO                   = ole.NewGUID("{6CD0E74E-EE65-4489-9EBF-CA43E87BA637}")
+	iidIToastNotificationFactory        = ole.NewGUID("{04124B20-82C6-4229-B109-FD9ED4662B53}")
+	iidIToastNotificationManagerStatics = ole.NewGUID("{50AC103F-D235-4598-BBEF-98FE4D1A3AD4}")
+	fmtidAppUserModel                   = ole.NewGUID("{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}")
+)
+
+// COM objects are bound to the apartment of the thread that created them, so all calls are run on a dedicated
+// thread of the multithreaded apartment. The activations are delivered on the RPC threads of COM.
+var (
+	calls     = make(chan func())
+	startOnce sync.Once
+	startErr  error
+)
+
+func run(fn func() error) error {
+	startOnce.Do(func() {
+		initErr := make(chan error)
+		go func() {
+			runtime.LockOSThread()
+			if err := ole.RoInitialize(roInitMultithreaded); err != nil {
+				initErr <- fmt.Errorf("toast: RoInitialize failed: %w", err)
+				return
+			}
+			initErr <- nil
+			for call := range calls {
+				call()
+			}
+		}()
+		startErr = <-initErr
+	})
+	if startErr != nil {
+		return startErr
+	}
+	result := make(chan error)
+	calls <- func() {
+		result <- fn()
+	}
+	return <-result
+}
+
+// hresult returns the error of a failed HRESULT. S_FALSE is a success.
+func hresult(hr uintptr) error {
+	if int32(hr) < 0 {
+		return ole.NewError(hr)
+	}
+	return nil
+}
+
+func register(config Config) error {
+	if config.AppID == "" || config.ActivatorCLSID == "" {
+		return errors.New("toast: AppID and ActivatorCLSID are required")
+	}
+	clsid, err := ole.CLSIDFromString(config.ActivatorCLSID)
+	if err != nil {
+		return fmt.Errorf("toast: invalid ActivatorCLSID %s: %w", config.ActivatorCLSID, err)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	appID, err := windows.UTF16PtrFromString(config.AppID)
+	if err != nil {
+		return err
+	}
+	hr, _, _ := procSetCurrentProcessExplicitAppUserModelID.Call(uintptr(unsafe.Pointer(appID)))
+	if err := hresult(hr); err != nil {
+		return fmt.Errorf("toast: unable to set the AppUserModelID: %w", err)
+	}
+
+	// COM starts the executable with ActivatedArg when the application isn't running
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\CLSID\`+clsid.String()+`\LocalServer32`, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("toast: unable to register the activator: %w", err)
+	}
+	err = key.SetStringValue("", fmt.Sprintf(`"%s" %s`, exe, ActivatedArg))
+	key.Close()
+	if err != nil {
+		return fmt.Errorf("toast: unable to register the activator: %w", err)
+	}
+
+	return run(func() error {
+		return createShortcut(config, exe, clsid)
+	})
+}
+
+type shellLinkVtbl struct {
+	ole.IUnknownVtbl
+	GetPath             uintptr
+	GetIDList           uintptr
+	SetIDList           uintptr
+	GetDescription      uintptr
+	SetDescription      uintptr
+	GetWorkingDirectory uintptr
+	SetWorkingDirectory uintptr
+	GetArguments        uintptr
+	SetArguments        uintptr
+	GetHotkey           uintptr
+	SetHotkey           uintptr
+	GetShowCmd          uintptr
+	SetShowCmd          uintptr
+	GetIconLocation     uintptr
+	SetIconLocation     uintptr
+	SetRelativePath     uintptr
+	Resolve             uintptr
+	SetPath             uintptr
+}
+
+type propertyStoreVtbl struct {
+	ole.IUnknownVtbl
+	GetCount uintptr
+	GetAt    uintptr
+	GetValue uintptr
+	SetValue uintptr
+	Commit   uintptr
+}
+
+type persistFileVtbl struct {
+	ole.IUnknownVtbl
+	GetClassID    uintptr
+	IsDirty       uintptr
+	Load          uintptr
+	Save          uintptr
+	SaveCompleted uintptr
+	GetCurFile    uintptr
+}
+
+type propertyKey struct {
+	fmtid ole.GUID
+	pid   uint32
+}
+
+// propVariant mirrors the PROPVARIANT of a pointer value
+type propVariant struct {
+	vt    uint16
+	_     [3]uint16
+	value unsafe.Pointer
+	_     uintptr
+}
+
+// createShortcut creates the Start menu shortcut with the AppUserModelID and the CLSID of the activator, which
+// Windows uses to identify the sender of the notifications and to start the application
+func createShortcut(config Config, exe string, clsid *ole.GUID) error {
+	programs, err := windows.KnownFolderPath(windows.FOLDERID_Programs, 0)
+	if err != nil {
+		return err
+	}
+	name := config.DisplayName
+	if name == "" {
+		name = config.AppID
+	}
+	filename, err := windows.UTF16PtrFromString(filepath.Join(programs, name+".lnk"))
+	if err != nil {
+		return err
+	}
+	target, err := windows.UTF16PtrFromString(exe)
+	if err != nil {
+		return err
+	}
+	dir, err := windows.UTF16PtrFromString(filepath.Dir(exe))
+	if err != nil {
+		return err
+	}
+	appID, err := windows.UTF16PtrFromString(config.AppID)
+	if err != nil {
+		return err
+	}
+
+	link, err := ole.CreateInstance(clsidShellLink, iidIShellLinkW)
+	if err != nil {
+		return fmt.Errorf("toast: unable to create the shortcut: %w", err)
+	}
+	defer link.Release()
+	linkVtbl := (*shellLinkVtbl)(unsafe.Pointer(link.RawVTable))
+	hr, _, _ := syscall.SyscallN(linkVtbl.SetPath, uintptr(unsafe.Pointer(link)), uintptr(unsafe.Pointer(target)))
+	if err := hresult(hr); err != nil {
+		return err
+	}
+	hr, _, _ = syscall.SyscallN(linkVtbl.SetWorkingDirectory, uintptr(unsafe.Pointer(link)), uintptr(unsafe.Pointer(dir)))
+	if err := hresult(hr); err != nil {
+		return err
+	}
+	if config.IconPath != "" {
+		icon, err := windows.UTF16PtrFromString(config.IconPath)
+		if err != nil {
+			return err
+		}
+		hr, _, _ = syscall.SyscallN(linkVtbl.SetIconLocation, uintptr(unsafe.Pointer(link)), uintptr(unsafe.Pointer(icon)), 0)
+		if err := hresult(hr); err != nil {
+			return err
+		}
+	}
+
+	store, err := link.QueryInterface(iidIPropertyStore)
+	if err != nil {
+		return err
+	}
+	defer store.Release()
+	storeVtbl := (*propertyStoreVtbl)(unsafe.Pointer(store.RawVTable))
+	properties := []struct {
+		key   propertyKey
+		value propVariant
+	}{
+		{propertyKey{*fmtidAppUserModel, pidAppUserModelID}, propVariant{vt: vtLPWStr, value: unsafe.Pointer(appID)}},
+		{propertyKey{*fmtidAppUserModel, pidToastActivatorCLSID}, propVariant{vt: vtCLSID, value: unsafe.Pointer(clsid)}},
+	}
+	for index := range properties {
+		hr, _, _ = syscall.SyscallN(storeVtbl.SetValue, uintptr(unsafe.Pointer(store)), uintptr(unsafe.Pointer(&properties[index].key)), uintptr(unsafe.Pointer(&properties[index].value)))
+		if err := hresult(hr); err != nil {
+			return fmt.Errorf("toast: unable to set the properties of the shortcut: %w", err)
+		}
+	}
+	hr, _, _ = syscall.SyscallN(storeVtbl.Commit, uintptr(unsafe.Pointer(store)))
+	if err := hresult(hr); err != nil {
+		return err
+	}
+
+	persist, err := link.QueryInterface(iidIPersistFile)
+	if err != nil {
+		return err
+	}
+	defer persist.Release()
+	persistVtbl := (*persistFileVtbl)(unsafe.Pointer(persist.RawVTable))
+	hr, _, _ = syscall.SyscallN(persistVtbl.Save, uintptr(unsafe.Pointer(persist)), uintptr(unsafe.Pointer(filename)), 1)
+	if err := hresult(hr); err != nil {
+		return fmt.Errorf("toast: unable to save the shortcut: %w", err)
+	}
+	return nil
+}
+
+// activator implements INotificationActivationCallback. It and its class factory live until the activator is
+// revoked, so AddRef and Release don't count.
+type activator struct {
+	vtbl      *activatorVtbl
+	activated func(Activation)
+}
+
+type activatorVtbl struct {
+	ole.IUnknownVtbl
+	Activate uintptr
+}
+
+// inputData mirrors NOTIFICATION_USER_INPUT_DATA
+type inputData struct {
+	key   *uint16
+	value *uint16
+}
+
+var activatorFn = activatorVtbl{
+	ole.IUnknownVtbl{
+		QueryInterface: windows.NewCallback(func(this *activator, iid *ole.GUID, object *unsafe.Pointer) uintptr {
+			if ole.IsEqualGUID(iid, ole.IID_IUnknown) || ole.IsEqualGUID(iid, iidINotificationActivationCallback) {
+				*object = unsafe.Pointer(this)
+				return 0
+			}
+			*object = nil
+			return eNoInterface
+		}),
+		AddRef:  windows.NewCallback(func(this *activator) uintptr { return 1 }),
+		Release: windows.NewCallback(func(this *activator) uintptr { return 1 }),
+	},
+	windows.NewCallback(func(this *activator, appID *uint16, arguments *uint16, data unsafe.Pointer, count uintptr) uintptr {
+		activation := Activation{
+			AppID:     windows.UTF16PtrToString(appID),
+			Arguments: windows.UTF16PtrToString(arguments),
+			Inputs:    map[string]string{},
+		}
+		if data != nil {
+			for _, input := range unsafe.Slice((*inputData)(data), count) {
+				activation.Inputs[windows.UTF16PtrToString(input.key)] = windows.UTF16PtrToString(input.value)
+			}
+		}
+		// Windows waits for the activator to return
+		go this.activated(activation)
+		return 0
+	}),
+}
+
+type classFactory struct {
+	vtbl      *classFactoryVtbl
+	activator *activator
+}
+
+type classFactoryVtbl struct {
+	ole.IUnknownVtbl
+	CreateInstance uintptr
+	LockServer     uintptr
+}
+
+var classFactoryFn = classFactoryVtbl{
+	ole.IUnknownVtbl{
+		QueryInterface: windows.NewCallback(func(this *classFactory, iid *ole.GUID, object *unsafe.Pointer) uintptr {
+			if ole.IsEqualGUID(iid, ole.IID_IUnknown) || ole.IsEqualGUID(iid, iidIClassFactory) {
+				*object = unsafe.Pointer(this)
+				return 0
+			}
+			*object = nil
+			return eNoInterface
+		}),
+		AddRef:  windows.NewCallback(func(this *classFactory) uintptr { return 1 }),
+		Release: windows.NewCallback(func(this *classFactory) uintptr { return 1 }),
+	},
+	windows.NewCallback(func(this *classFactory, outer unsafe.Pointer, iid *ole.GUID, object *unsafe.Pointer) uintptr {
+		if outer != nil {
+			*object = nil
+			return classENoAggregation
+		}
+		if ole.IsEqualGUID(iid, ole.IID_IUnknown) || ole.IsEqualGUID(iid, iidINotificationActivationCallback) {
+			*object = unsafe.Pointer(this.activator)
+			return 0
+		}
+		*object = nil
+		return eNoInterface
+	}),
+	windows.NewCallback(func(this *classFactory, lock uintptr) uintptr { return 0 }),
+}
+
+func listen(config Config, activated func(Activation)) (func(), error) {
+	clsid, err := ole.CLSIDFromString(config.ActivatorCLSID)
+	if err != nil {
+		return nil, fmt.Errorf("toast: invalid ActivatorCLSID %s: %w", config.ActivatorCLSID, err)
+	}
+	factory := &classFactory{
+		vtbl:      &classFactoryFn,
+		activator: &activator{vtbl: &activatorFn, activated: activated},
+	}
+	var cookie uint32
+	err = run(func() error {
+		hr, _, _ := procCoRegisterClassObject.Call(uintptr(unsafe.Pointer(clsid)), uintptr(unsafe.Pointer(factory)), clsctxLocalServer, regclsMultipleUse, uintptr(unsafe.Pointer(&cookie)))
+		return hresult(hr)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("toast: unable to register the activator: %w", err)
+	}
+	return func() {
+		_ = run(func() error {
+			procCoRevokeClassObject.Call(uintptr(cookie))
+			// The factory is used by COM until it is revoked
+			runtime.KeepAlive(factory)
+			return nil
+		})
+	}, nil
+}
+
+type xmlDocumentIOVtbl struct {
+	ole.IInspectableVtbl
+	LoadXml uintptr
+}
+
+type toastNotificationFactoryVtbl struct {
+	ole.IInspectableVtbl
+	CreateToastNotification uintptr
+}
+
+type toastNotificationManagerStaticsVtbl struct {
+	ole.IInspectableVtbl
+	CreateToastNotifier       uintptr
+	CreateToastNotifierWithId uintptr
+}
+
+type toastNotifierVtbl struct {
+	ole.IInspectableVtbl
+	Show uintptr
+}
+
+// newHString creates an HSTRING with the length of the UTF-16 string
+func newHString(s string) (ole.HString, error) {
+	value, err := windows.UTF16FromString(s)
+	if err != nil {
+		return 0, err
+	}
+	var result ole.HString
+	hr, _, _ := procWindowsCreateString.Call(uintptr(unsafe.Pointer(&value[0])), uintptr(len(value)-1), uintptr(unsafe.Pointer(&result)))
+	return result, hresult(hr)
+}
+
+func show(config Config, content string) error {
+	if config.AppID == "" {
+		return errors.New("toast: AppID is required")
+	}
+	return run(func() error {
+		document, err := ole.RoActivateInstance("Windows.Data.Xml.Dom.XmlDocument")
+		if err != nil {
+			return fmt.Errorf("toast: unable to create the XML document: %w", err)
+		}
+		defer document.Release()
+		documentIO, err := document.QueryInterface(iidIXmlDocumentIO)
+		if err != nil {
+			return err
+		}
+		defer documentIO.Release()
+		xmlContent, err := newHString(content)
+		if err != nil {
+			return err
+		}
+		defer ole.DeleteHString(xmlContent)
+		hr, _, _ := syscall.SyscallN((*xmlDocumentIOVtbl)(unsafe.Pointer(documentIO.RawVTable)).LoadXml, uintptr(unsafe.Pointer(documentIO)), uintptr(xmlContent))
+		if err := hresult(hr); err != nil {
+			return fmt.Errorf("toast: invalid notification: %w", err)
+		}
+		xmlDocument, err := document.QueryInterface(iidIXmlDocument)
+		if err != nil {
+			return err
+		}
+		defer xmlDocument.Release()
+
+		factory, err := ole.RoGetActivationFactory("Windows.UI.Notifications.ToastNotification", iidIToastNotificationFactory)
+		if err != nil {
+			return err
+		}
+		defer factory.Release()
+		var notification *ole.IInspectable
+		hr, _, _ = syscall.SyscallN((*toastNotificationFactoryVtbl)(unsafe.Pointer(factory.RawVTable)).CreateToastNotification, uintptr(unsafe.Pointer(factory)), uintptr(unsafe.Pointer(xmlDocument)), uintptr(unsafe.Pointer(&notification)))
+		if err := hresult(hr); err != nil {
+			return fmt.Errorf("toast: unable to create the notification: %w", err)
+		}
+		defer notification.Release()
+
+		manager, err := ole.RoGetActivationFactory("Windows.UI.Notifications.ToastNotificationManager", iidIToastNotificationManagerStatics)
+		if err != nil {
+			return err
+		}
+		defer manager.Release()
+		appID, err := newHString(config.AppID)
+		if err != nil {
+			return err
+		}
+		defer ole.DeleteHString(appID)
+		var notifier *ole.IInspectable
+		hr, _, _ = syscall.SyscallN((*toastNotificationManagerStaticsVtbl)(unsafe.Pointer(manager.RawVTable)).CreateToastNotifierWithId, uintptr(unsafe.Pointer(manager)), uintptr(appID), uintptr(unsafe.Pointer(&notifier)))
+		if err := hresult(hr); err != nil {
+			return fmt.Errorf("toast: unable to create the notifier, is the application registered? %w", err)
+		}
+		defer notifier.Release()
+		hr, _, _ = syscall.SyscallN((*toastNotifierVtbl)(unsafe.Pointer(notifier.RawVTable)).Show, uintptr(unsafe.Pointer(notifier)), uintptr(unsafe.Pointer(notification)))
+		return hresult(hr)
+	})
+}
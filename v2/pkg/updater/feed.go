@@ -0,0 +1,173 @@
+package updater
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// feed is the update.json of wails release
+type feed struct {
+	Version   string `json:"version"`
+	Date      string `json:"date"`
+	Notes     string `json:"notes"`
+	Artifacts []struct {
+		Platform string `json:"platform"`
+		URL      string `json:"url"`
+		Size     int64  `json:"size"`
+		SHA256   string `json:"sha256"`
+	} `json:"artifacts"`
+}
+
+func parseFeed(data []byte, platform string) ([]Release, error) {
+	var f feed
+	err := json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, fmt.Errorf("updater: invalid feed: %w", err)
+	}
+	for _, artifact := range f.Artifacts {
+		if !matchesPlatform(artifact.Platform, platform) || artifact.URL == "" {
+			continue
+		}
+		release := Release{
+			Version: f.Version,
+			Notes:   f.Notes,
+			Artifact: Artifact{
+				Platform: artifact.Platform,
+				URL:      artifact.URL,
+				Size:     artifact.Size,
+				SHA256:   artifact.SHA256,
+			},
+		}
+		release.Date, _ = time.Parse(time.RFC3339, f.Date)
+		return []Release{release}, nil
+	}
+	return nil, nil
+}
+
+type appcast struct {
+	Items []appcastItem `xml:"channel>item"`
+}
+
+type appcastItem struct {
+	PubDate              string `xml:"pubDate"`
+	Description          string `xml:"description"`
+	Version              string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle version"`
+	ShortVersion         string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle shortVersionString"`
+	ReleaseNotesLink     string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle releaseNotesLink"`
+	MinimumSystemVersion string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle minimumSystemVersion"`
+	Channel              string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle channel"`
+	CriticalUpdate       *struct {
+		Version string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle version,attr"`
+	} `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle criticalUpdate"`
+	Enclosures []enclosure `xml:"enclosure"`
+	Deltas     *struct {
+		Enclosures []enclosure `xml:"enclosure"`
+	} `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle deltas"`
+}
+
+type enclosure struct {
+	URL          string `xml:"url,attr"`
+	Length       int64  `xml:"length,attr"`
+	Version      string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle version,attr"`
+	ShortVersion string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle shortVersionString,attr"`
+	OS           string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle os,attr"`
+	DeltaFrom    string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle deltaFrom,attr"`
+	EdSignature  string `xml:"http://www.andymatuschak.org/xml-namespaces/sparkle edSignature,attr"`
+}
+
+// platform returns the operating system of the enclosure. Enclosures without sparkle:os are for macOS.
+func (e enclosure) platform() string {
+	switch strings.ToLower(e.OS) {
+	case "", "macos":
+		return "darwin"
+	default:
+		return strings.ToLower(e.OS)
+	}
+}
+
+func (e enclosure) artifact() Artifact {
+	return Artifact{
+		Platform:    e.platform(),
+		URL:         e.URL,
+		Size:        e.Length,
+		EdSignature: e.EdSignature,
+	}
+}
+
+// parseAppcast returns the releases of the items of the appcast for the platform. Items of other channels are
+// skipped.
+func parseAppcast(data []byte, platform string, channels []string) ([]Release, error) {
+	var cast appcast
+	err := xml.Unmarshal(data, &cast)
+	if err != nil {
+		return nil, fmt.Errorf("updater: invalid appcast: %w", err)
+	}
+	var result []Release
+	for _, item := range cast.Items {
+		if item.Channel != "" && !contains(channels, item.Channel) {
+			continue
+		}
+		var selected *enclosure
+		for index := range item.Enclosures {
+			if matchesPlatform(item.Enclosures[index].platform(), platform) {
+				selected = &item.Enclosures[index]
+				break
+			}
+		}
+		if selected == nil {
+			continue
+		}
+
+		// Sparkle 2 moved the versions from the enclosure to the item
+		build := firstNonEmpty(item.Version, selected.Version)
+		release := Release{
+			Version:              firstNonEmpty(item.ShortVersion, selected.ShortVersion, build),
+			Build:                build,
+			Notes:                strings.TrimSpace(item.Description),
+			NotesURL:             strings.TrimSpace(item.ReleaseNotesLink),
+			MinimumSystemVersion: item.MinimumSystemVersion,
+			Artifact:             selected.artifact(),
+		}
+		if item.CriticalUpdate != nil {
+			// A version limits the critical update to the builds before it
+			release.Critical = true
+			release.criticalBefore = item.CriticalUpdate.Version
+		}
+		if date, err := time.Parse(time.RFC1123Z, strings.TrimSpace(item.PubDate)); err == nil {
+			release.Date = date
+		} else if date, err := time.Parse(time.RFC1123, strings.TrimSpace(item.PubDate)); err == nil {
+			release.Date = date
+		}
+		if item.Deltas != nil {
+			for _, delta := range item.Deltas.Enclosures {
+				if delta.DeltaFrom == "" || !matchesPlatform(delta.platform(), platform) {
+					continue
+				}
+				release.Deltas = append(release.Deltas, Delta{From: delta.DeltaFrom, Artifact: delta.artifact()})
+			}
+		}
+		result = append(result, release)
+	}
+	return result, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,352 @@
+// Package updater checks the update feed of an application and downloads verified updates. It reads the update.json
+// feeds written by wails release as well as Sparkle appcasts, including their EdDSA signatures and delta items, so
+// applications that migrate from native macOS apps can keep their release infrastructure. Installing the update is
+// left to the application.
+package updater
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnverifiable is returned for artifacts that have neither a checksum nor a signature that can be verified
+var ErrUnverifiable = errors.New("updater: the artifact can't be verified")
+
+// Release is a release of the feed
+type Release struct {
+	// Version is compared with the current version, EG: the sparkle:shortVersionString of appcasts
+	Version string
+	// Build is the sparkle:version of appcasts, EG: the CFBundleVersion, which the deltas refer to
+	Build string
+	Date  time.Time
+	Notes string
+	// NotesURL is the sparkle:releaseNotesLink of appcasts
+	NotesURL             string
+	MinimumSystemVersion string
+	Critical             bool
+	// Artifact is the update for the platform
+	Artifact Artifact
+	// Deltas are the delta updates from earlier builds of appcasts. They are in the format of Sparkle's
+	// BinaryDelta, which has to be applied by the application.
+	Deltas []Delta
+
+	criticalBefore string
+}
+
+// Artifact is a file of a release
+type Artifact struct {
+	// Platform is the target, EG: windows/amd64, or the operating system of appcasts, EG: darwin
+	Platform string
+	URL      string
+	Size     int64
+	SHA256   string
+	// EdSignature is the base64 EdDSA signature of the file of appcasts
+	EdSignature string
+}
+
+// Delta is a delta update of an appcast
+type Delta struct {
+	// From is the build that the delta applies to
+	From string
+	Artifact
+}
+
+// Delta returns the delta update from the build or nil
+func (r *Release) Delta(from string) *Delta {
+	for index := range r.Deltas {
+		if r.Deltas[index].From == from {
+			return &r.Deltas[index]
+		}
+	}
+	return nil
+}
+
+// Updater checks a feed for newer releases
+type Updater struct {
+	// FeedURL is the URL of update.json or of the appcast
+	FeedURL        string
+	CurrentVersion string
+	// CurrentBuild is compared with the builds of appcasts, EG: the CFBundleVersion. CurrentVersion is used if it
+	// is empty.
+	CurrentBuild string
+	// Platform selects the artifacts, EG: darwin/arm64. It defaults to the platform of the application.
+	Platform string
+	// Channels are the channels of the appcast to include in addition to the items without channel, EG: "beta"
+	Channels []string
+	// PublicKey verifies the EdDSA signatures of the artifacts. Artifacts without a valid signature are rejected
+	// when it is set.
+	PublicKey ed25519.PublicKey
+	// Client is used for the requests. http.DefaultClient is used if it is nil.
+	Client *http.Client
+}
+
+// ParsePublicKey parses a base64 EdDSA public key, EG: the SUPublicEDKey of the Info.plist of a Sparkle application
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("updater: invalid public key: %w", err)
+	}
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("updater: invalid public key of %d bytes", len(data))
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+func (u *Updater) client() *http.Client {
+	if u.Client != nil {
+		return u.Client
+	}
+	return http.DefaultClient
+}
+
+func (u *Updater) platform() string {
+	if u.Platform != "" {
+		return u.Platform
+	}
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+func (u *Updater) get(ctx context.Context, uri string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := u.client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("updater: GET %s: %s", uri, response.Status)
+	}
+	return response, nil
+}
+
+// Check returns the newest release for the platform that is newer than the current version, or nil if the
+// application is up to date
+func (u *Updater) Check(ctx context.Context) (*Release, error) {
+	response, err := u.get(ctx, u.FeedURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []Release
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		releases, err = parseFeed(data, u.platform())
+	} else {
+		releases, err = parseAppcast(data, u.platform(), u.Channels)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result *Release
+	for index := range releases {
+		release := &releases[index]
+		if CompareVersions(release.Version, u.CurrentVersion) <= 0 {
+			continue
+		}
+		if result == nil || CompareVersions(release.Version, result.Version) > 0 {
+			result = release
+		}
+	}
+	if result != nil && result.criticalBefore != "" {
+		result.Critical = CompareVersions(u.currentBuild(), result.criticalBefore) < 0
+	}
+	return result, nil
+}
+
+func (u *Updater) currentBuild() string {
+	if u.CurrentBuild != "" {
+		return u.CurrentBuild
+	}
+	return u.CurrentVersion
+}
+
+// CurrentDelta returns the delta update of the release from the current build or nil
+func (u *Updater) CurrentDelta(release *Release) *Delta {
+	return release.Delta(u.currentBuild())
+}
+
+// Download downloads the artifact to the directory and verifies its size, checksum and signature. It returns the
+// path of the file.
+func (u *Updater) Download(ctx context.Context, artifact Artifact, dir string) (string, error) {
+	if artifact.SHA256 == "" && (u.PublicKey == nil || artifact.EdSignature == "") {
+		return "", ErrUnverifiable
+	}
+	if u.PublicKey != nil && artifact.EdSignature == "" {
+		return "", fmt.Errorf("updater: %s isn't signed", artifact.URL)
+	}
+	name := "update"
+	if parsed, err := url.Parse(artifact.URL); err == nil && path.Base(parsed.Path) != "." && path.Base(parsed.Path) != "/" {
+		name = path.Base(parsed.Path)
+	}
+
+	response, err := u.get(ctx, artifact.URL)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	temp, err := os.CreateTemp(dir, name+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(temp.Name())
+	var reader io.Reader = response.Body
+	if artifact.Size > 0 {
+		reader = io.LimitReader(reader, artifact.Size+1)
+	}
+	_, err = io.Copy(temp, reader)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	err = u.verify(temp.Name(), artifact)
+	if err != nil {
+		return "", err
+	}
+	filename := filepath.Join(dir, name)
+	err = os.Rename(temp.Name(), filename)
+	if err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+func (u *Updater) verify(filename string, artifact Artifact) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if artifact.Size > 0 && int64(len(data)) != artifact.Size {
+		return fmt.Errorf("updater: the size of %s is %d instead of %d", artifact.URL, len(data), artifact.Size)
+	}
+	if artifact.SHA256 != "" {
+		checksum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(checksum[:]); !strings.EqualFold(actual, artifact.SHA256) {
+			return fmt.Errorf("updater: the checksum of %s is %s instead of %s", artifact.URL, actual, artifact.SHA256)
+		}
+	}
+	if u.PublicKey != nil {
+		signature, err := base64.StdEncoding.DecodeString(artifact.EdSignature)
+		if err != nil || !ed25519.Verify(u.PublicKey, data, signature) {
+			return fmt.Errorf("updater: invalid signature of %s", artifact.URL)
+		}
+	}
+	return nil
+}
+
+// matchesPlatform reports if the artifact is for the platform. Artifacts without architecture or for darwin/universal
+// match all architectures of the operating system.
+func matchesPlatform(artifact string, platform string) bool {
+	if artifact == platform {
+		return true
+	}
+	goos, _, _ := strings.Cut(platform, "/")
+	return artifact == goos || (goos == "darwin" && artifact == "darwin/universal")
+}
+
+// CompareVersions compares versions like Sparkle, EG: "1.10" is newer than "1.9" and "1.0" is newer than
+// "1.0-beta1". It returns -1, 0 or 1.
+func CompareVersions(a string, b string) int {
+	left := versionParts(a)
+	right := versionParts(b)
+	for index := 0; index < len(left) || index < len(right); index++ {
+		switch {
+		case index >= len(left):
+			return -partOrder(right[index])
+		case index >= len(right):
+			return partOrder(left[index])
+		}
+		l, r := left[index], right[index]
+		ln, lerr := strconv.Atoi(l)
+		rn, rerr := strconv.Atoi(r)
+		switch {
+		case lerr == nil && rerr == nil:
+			if ln != rn {
+				return compareInts(ln, rn)
+			}
+		case lerr == nil:
+			// Numbers are newer than pre-release labels
+			return 1
+		case rerr == nil:
+			return -1
+		default:
+			if result := strings.Compare(l, r); result != 0 {
+				return result
+			}
+		}
+	}
+	return 0
+}
+
+// partOrder is the order of a version with an additional part: "1.0.1" is newer and "1.0beta" is older than "1.0"
+func partOrder(part string) int {
+	if _, err := strconv.Atoi(part); err == nil {
+		return 1
+	}
+	return -1
+}
+
+func compareInts(a int, b int) int {
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// versionParts splits the version into its numbers and labels, EG: "v1.0b2" into 1, 0, b, 2
+func versionParts(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	var parts []string
+	var current strings.Builder
+	digits := false
+	flush := func() {
+		if current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range version {
+		switch {
+		case r == '.' || r == '-' || r == '+' || r == ' ':
+			flush()
+		case r >= '0' && r <= '9':
+			if !digits {
+				flush()
+			}
+			digits = true
+			current.WriteRune(r)
+		default:
+			if digits {
+				flush()
+			}
+			digits = false
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return parts
+}
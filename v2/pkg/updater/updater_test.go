@@ -0,0 +1,172 @@
+package updater
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+const testAppcast = `<?xml version="1.0" encoding="utf-8"?>
+<rss version="2.0" xmlns:sparkle="http://www.andymatuschak.org/xml-namespaces/sparkle">
+  <channel>
+    <title>App</title>
+    <item>
+      <title>Version 1.2.0</title>
+      <pubDate>Wed, 01 May 2024 10:00:00 +0000</pubDate>
+      <sparkle:version>120</sparkle:version>
+      <sparkle:shortVersionString>1.2.0</sparkle:shortVersionString>
+      <sparkle:releaseNotesLink>https://example.com/1.2.0.html</sparkle:releaseNotesLink>
+      <sparkle:minimumSystemVersion>10.15</sparkle:minimumSystemVersion>
+      <sparkle:criticalUpdate sparkle:version="110"></sparkle:criticalUpdate>
+      <enclosure url="%[1]s/App-1.2.0.zip" length="%[2]d" type="application/octet-stream" sparkle:edSignature="%[3]s"/>
+      <enclosure url="%[1]s/App-1.2.0.exe" length="10" sparkle:os="windows" sparkle:edSignature="c2ln"/>
+      <sparkle:deltas>
+        <enclosure url="%[1]s/App120-110.delta" sparkle:deltaFrom="110" length="5" sparkle:edSignature="c2ln"/>
+      </sparkle:deltas>
+    </item>
+    <item>
+      <title>Version 1.1.0</title>
+      <enclosure url="%[1]s/App-1.1.0.zip" sparkle:version="110" sparkle:shortVersionString="1.1.0" length="10" sparkle:edSignature="c2ln"/>
+    </item>
+    <item>
+      <title>Version 1.3.0 beta</title>
+      <sparkle:channel>beta</sparkle:channel>
+      <sparkle:version>130</sparkle:version>
+      <sparkle:shortVersionString>1.3.0-beta1</sparkle:shortVersionString>
+      <enclosure url="%[1]s/App-1.3.0.zip" length="10" sparkle:edSignature="c2ln"/>
+    </item>
+  </channel>
+</rss>`
+
+func TestCompareVersions(t *testing.T) {
+	is2 := is.New(t)
+	is2.Equal(CompareVersions("1.10", "1.9"), 1)
+	is2.Equal(CompareVersions("v1.2.0", "1.2"), 1)
+	is2.Equal(CompareVersions("1.2", "1.2"), 0)
+	is2.Equal(CompareVersions("1.0", "1.0-beta1"), 1)
+	is2.Equal(CompareVersions("1.0b2", "1.0b10"), -1)
+	is2.Equal(CompareVersions("1.0beta", "1.0rc"), -1)
+	is2.Equal(CompareVersions("120", "110"), 1)
+}
+
+func TestParseAppcast(t *testing.T) {
+	is2 := is.New(t)
+	data := []byte(fmt.Sprintf(testAppcast, "https://example.com", 4, "c2ln"))
+
+	releases, err := parseAppcast(data, "darwin/arm64", nil)
+	is2.NoErr(err)
+	is2.Equal(len(releases), 2)
+	release := releases[0]
+	is2.Equal(release.Version, "1.2.0")
+	is2.Equal(release.Build, "120")
+	is2.Equal(release.NotesURL, "https://example.com/1.2.0.html")
+	is2.Equal(release.MinimumSystemVersion, "10.15")
+	is2.Equal(release.Date.Year(), 2024)
+	is2.True(release.Critical)
+	is2.Equal(release.Artifact, Artifact{Platform: "darwin", URL: "https://example.com/App-1.2.0.zip", Size: 4, EdSignature: "c2ln"})
+	is2.Equal(release.Delta("110").URL, "https://example.com/App120-110.delta")
+	is2.True(release.Delta("100") == nil)
+	is2.Equal(releases[1].Version, "1.1.0")
+	is2.Equal(releases[1].Build, "110")
+
+	releases, err = parseAppcast(data, "windows/amd64", []string{"beta"})
+	is2.NoErr(err)
+	is2.Equal(len(releases), 1)
+	is2.Equal(releases[0].Artifact.URL, "https://example.com/App-1.2.0.exe")
+	is2.Equal(len(releases[0].Deltas), 0)
+
+	releases, err = parseAppcast(data, "darwin/amd64", []string{"beta"})
+	is2.NoErr(err)
+	is2.Equal(len(releases), 3)
+}
+
+func TestParseFeed(t *testing.T) {
+	is2 := is.New(t)
+	data := []byte(`{"version":"1.3.0","date":"2024-05-01T10:00:00Z","notes":"notes","artifacts":[
+		{"platform":"darwin/universal","name":"app.zip","url":"https://example.com/app.zip","size":3,"sha256":"abc"},
+		{"platform":"windows/amd64","name":"app.exe","url":"https://example.com/app.exe","size":4,"sha256":"def"}]}`)
+	releases, err := parseFeed(data, "darwin/arm64")
+	is2.NoErr(err)
+	is2.Equal(len(releases), 1)
+	is2.Equal(releases[0].Version, "1.3.0")
+	is2.Equal(releases[0].Artifact.URL, "https://example.com/app.zip")
+	is2.Equal(releases[0].Artifact.SHA256, "abc")
+
+	releases, err = parseFeed(data, "linux/amd64")
+	is2.NoErr(err)
+	is2.Equal(len(releases), 0)
+}
+
+func TestCheckAndDownload(t *testing.T) {
+	is2 := is.New(t)
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	is2.NoErr(err)
+	update := []byte("new!")
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, update))
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/appcast.xml":
+			fmt.Fprintf(w, testAppcast, server.URL, len(update), signature)
+		case "/update.json":
+			checksum := sha256.Sum256(update)
+			fmt.Fprintf(w, `{"version":"2.0.0","artifacts":[{"platform":"linux/amd64","url":"%s/app","size":%d,"sha256":"%s"}]}`, server.URL, len(update), hex.EncodeToString(checksum[:]))
+		default:
+			w.Write(update)
+		}
+	}))
+	defer server.Close()
+
+	key, err := ParsePublicKey(base64.StdEncoding.EncodeToString(public))
+	is2.NoErr(err)
+	u := &Updater{FeedURL: server.URL + "/appcast.xml", CurrentVersion: "1.1.0", CurrentBuild: "110", Platform: "darwin/arm64", PublicKey: key}
+	release, err := u.Check(context.Background())
+	is2.NoErr(err)
+	is2.Equal(release.Version, "1.2.0")
+	is2.True(!release.Critical)
+	is2.Equal(u.CurrentDelta(release).From, "110")
+
+	dir := t.TempDir()
+	filename, err := u.Download(context.Background(), release.Artifact, dir)
+	is2.NoErr(err)
+	is2.Equal(filename, filepath.Join(dir, "App-1.2.0.zip"))
+	data, err := os.ReadFile(filename)
+	is2.NoErr(err)
+	is2.Equal(data, update)
+
+	// The delta has an invalid signature
+	_, err = u.Download(context.Background(), u.CurrentDelta(release).Artifact, dir)
+	is2.True(err != nil)
+	_, err = (&Updater{}).Download(context.Background(), release.Artifact, dir)
+	is2.Equal(err, ErrUnverifiable)
+
+	u.CurrentVersion = "1.2.0"
+	release, err = u.Check(context.Background())
+	is2.NoErr(err)
+	is2.True(release == nil)
+
+	u = &Updater{FeedURL: server.URL + "/update.json", CurrentVersion: "1.0.0", Platform: "linux/amd64"}
+	release, err = u.Check(context.Background())
+	is2.NoErr(err)
+	is2.Equal(release.Version, "2.0.0")
+	filename, err = u.Download(context.Background(), release.Artifact, dir)
+	is2.NoErr(err)
+	is2.True(strings.HasSuffix(filename, "app"))
+	release.Artifact.SHA256 = strconv.Itoa(0)
+	_, err = u.Download(context.Background(), release.Artifact, dir)
+	is2.True(err != nil)
+}
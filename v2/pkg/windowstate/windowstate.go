@@ -0,0 +1,243 @@
+// Package windowstate saves the position, the size and the maximised and fullscreen state of a window when it is closed
+// and restores them at the next launch. The monitor the window was on is saved with the state: the position is only
+// restored when the window opens on the same monitor with the same size, otherwise the window is centred. The restored
+// size never exceeds the monitor, EG: after the resolution has been lowered. The states of the windows are stored by
+// their name in a JSON file in the application data directory.
+package windowstate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// DefaultName is the name of the window if none has been given
+const DefaultName = "main"
+
+// Filename is the name of the file in the application data directory the states are stored in
+const Filename = "windowstate.json"
+
+// Monitor identifies the monitor a window was on
+type Monitor struct {
+	// Index is the index of the monitor in runtime.ScreenGetAll
+	Index   int  `json:"index"`
+	Width   int  `json:"width"`
+	Height  int  `json:"height"`
+	Primary bool `json:"primary"`
+}
+
+// State is the saved state of a window. The position and the size are the ones of the normal window, they are kept
+// while the window is maximised, fullscreen or minimised.
+type State struct {
+	X          int     `json:"x"`
+	Y          int     `json:"y"`
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+	Maximised  bool    `json:"maximised"`
+	Fullscreen bool    `json:"fullscreen"`
+	Monitor    Monitor `json:"monitor"`
+}
+
+// WindowState saves and restores the state of a window
+type WindowState struct {
+	// Name is the name the state of the window is stored by. Default: DefaultName
+	Name string
+	// Path is the file the states are stored in. Default: Filename in the application data directory
+	Path string
+
+	state       *State
+	startHidden bool
+	lock        sync.Mutex
+}
+
+// New creates the service for the window with the name
+func New(name string) *WindowState {
+	return &WindowState{Name: name}
+}
+
+// Manage restores the state of the window at startup and saves it before the window closes. It wraps OnStartup and
+// OnBeforeClose of the options and starts the window hidden until the state has been restored, so it has to be called
+// before wails.Run. Call Save when the application quits with runtime.Quit, as OnBeforeClose isn't called then.
+func (w *WindowState) Manage(appoptions *options.App) {
+	w.startHidden = appoptions.StartHidden
+	appoptions.StartHidden = true
+
+	onStartup := appoptions.OnStartup
+	appoptions.OnStartup = func(ctx context.Context) {
+		_ = w.Restore(ctx)
+		if !w.startHidden {
+			runtime.WindowShow(ctx)
+		}
+		if onStartup != nil {
+			onStartup(ctx)
+		}
+	}
+
+	onBeforeClose := appoptions.OnBeforeClose
+	appoptions.OnBeforeClose = func(ctx context.Context) bool {
+		if onBeforeClose != nil && onBeforeClose(ctx) {
+			return true
+		}
+		_ = w.Save(ctx)
+		return false
+	}
+}
+
+func (w *WindowState) name() string {
+	if w.Name == "" {
+		return DefaultName
+	}
+	return w.Name
+}
+
+func (w *WindowState) path(ctx context.Context) string {
+	if w.Path != "" {
+		return w.Path
+	}
+	return filepath.Join(runtime.AppDataDir(ctx), Filename)
+}
+
+// Restore applies the saved state to the window. Nothing is changed if no state has been saved.
+func (w *WindowState) Restore(ctx context.Context) error {
+	states, err := readStates(w.path(ctx))
+	if err != nil {
+		return err
+	}
+	saved, ok := states[w.name()]
+	if !ok {
+		return nil
+	}
+	w.lock.Lock()
+	w.state = &saved
+	w.lock.Unlock()
+
+	screens, err := runtime.ScreenGetAll(ctx)
+	if err != nil {
+		return err
+	}
+	state, positioned := sanitise(saved, screens)
+	if state.Width > 0 && state.Height > 0 {
+		runtime.WindowSetSize(ctx, state.Width, state.Height)
+	}
+	if positioned {
+		runtime.WindowSetPosition(ctx, state.X, state.Y)
+	} else {
+		runtime.WindowCenter(ctx)
+	}
+	switch {
+	case state.Fullscreen:
+		runtime.WindowFullscreen(ctx)
+	case state.Maximised:
+		runtime.WindowMaximise(ctx)
+	}
+	return nil
+}
+
+// Save stores the current state of the window
+func (w *WindowState) Save(ctx context.Context) error {
+	w.lock.Lock()
+	state := State{}
+	if w.state != nil {
+		state = *w.state
+	}
+	w.lock.Unlock()
+
+	state.Maximised = runtime.WindowIsMaximised(ctx)
+	state.Fullscreen = runtime.WindowIsFullscreen(ctx)
+	if !state.Maximised && !state.Fullscreen && !runtime.WindowIsMinimised(ctx) {
+		state.X, state.Y = runtime.WindowGetPosition(ctx)
+		state.Width, state.Height = runtime.WindowGetSize(ctx)
+		if screens, err := runtime.ScreenGetAll(ctx); err == nil {
+			state.Monitor = currentMonitor(screens)
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.state = &state
+	path := w.path(ctx)
+	states, err := readStates(path)
+	if err != nil {
+		states = map[string]State{}
+	}
+	states[w.name()] = state
+	return writeStates(path, states)
+}
+
+// currentMonitor returns the monitor of the screen the window is on
+func currentMonitor(screens []runtime.Screen) Monitor {
+	for i, screen := range screens {
+		if screen.IsCurrent {
+			return Monitor{Index: i, Width: screen.Size.Width, Height: screen.Size.Height, Primary: screen.IsPrimary}
+		}
+	}
+	return Monitor{Index: -1}
+}
+
+// sanitise fits the saved state into the screen the window is on. The position is only kept when the window is on the
+// saved monitor, as the positions of the runtime are relative to the monitor of the window.
+func sanitise(state State, screens []runtime.Screen) (State, bool) {
+	monitor := currentMonitor(screens)
+	if monitor.Index < 0 {
+		return state, false
+	}
+	screen := screens[monitor.Index]
+	if state.Width > screen.Size.Width {
+		state.Width = screen.Size.Width
+	}
+	if state.Height > screen.Size.Height {
+		state.Height = screen.Size.Height
+	}
+	if monitor != state.Monitor {
+		return state, false
+	}
+	state.X = clamp(state.X, 0, screen.Size.Width-state.Width)
+	state.Y = clamp(state.Y, 0, screen.Size.Height-state.Height)
+	return state, true
+}
+
+func clamp(value, minimum, maximum int) int {
+	if value > maximum {
+		value = maximum
+	}
+	if value < minimum {
+		value = minimum
+	}
+	return value
+}
+
+func readStates(path string) (map[string]State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	states := map[string]State{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// writeStates replaces the file with a renamed temporary file, so a crash while saving doesn't corrupt it
+func writeStates(path string, states map[string]State) error {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
@@ -0,0 +1,87 @@
+package windowstate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wailsapp/wails/v2/internal/frontend"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+func screen(width, height int, current, primary bool) runtime.Screen {
+	return runtime.Screen{IsCurrent: current, IsPrimary: primary, Size: frontend.ScreenSize{Width: width, Height: height}}
+}
+
+func TestSanitise(t *testing.T) {
+	laptop := Monitor{Index: 0, Width: 1440, Height: 900, Primary: true}
+	tests := []struct {
+		name           string
+		state          State
+		screens        []runtime.Screen
+		want           State
+		wantPositioned bool
+	}{
+		{
+			name:           "same monitor",
+			state:          State{X: 100, Y: 50, Width: 800, Height: 600, Monitor: laptop},
+			screens:        []runtime.Screen{screen(1440, 900, true, true)},
+			want:           State{X: 100, Y: 50, Width: 800, Height: 600, Monitor: laptop},
+			wantPositioned: true,
+		},
+		{
+			name:           "off the monitor",
+			state:          State{X: 1200, Y: -20, Width: 800, Height: 600, Monitor: laptop},
+			screens:        []runtime.Screen{screen(1440, 900, true, true)},
+			want:           State{X: 640, Y: 0, Width: 800, Height: 600, Monitor: laptop},
+			wantPositioned: true,
+		},
+		{
+			name:    "disconnected monitor",
+			state:   State{X: 100, Y: 50, Width: 2400, Height: 1300, Monitor: Monitor{Index: 1, Width: 2560, Height: 1440}},
+			screens: []runtime.Screen{screen(1440, 900, true, true)},
+			want:    State{X: 100, Y: 50, Width: 1440, Height: 900, Monitor: Monitor{Index: 1, Width: 2560, Height: 1440}},
+		},
+		{
+			name:    "lowered resolution",
+			state:   State{X: 100, Y: 50, Width: 800, Height: 600, Monitor: laptop},
+			screens: []runtime.Screen{screen(1280, 800, true, true)},
+			want:    State{X: 100, Y: 50, Width: 800, Height: 600, Monitor: laptop},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, positioned := sanitise(tt.state, tt.screens)
+			if !reflect.DeepEqual(state, tt.want) || positioned != tt.wantPositioned {
+				t.Errorf("sanitise() = %+v, %v, want %+v, %v", state, positioned, tt.want, tt.wantPositioned)
+			}
+		})
+	}
+}
+
+func TestStates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app", Filename)
+	states, err := readStates(path)
+	if err != nil || len(states) != 0 {
+		t.Fatalf("readStates() = %v, %v, want no states", states, err)
+	}
+
+	want := map[string]State{
+		"main":     {X: 10, Y: 20, Width: 800, Height: 600, Maximised: true, Monitor: Monitor{Width: 1440, Height: 900, Primary: true}},
+		"settings": {Width: 400, Height: 300, Monitor: Monitor{Index: 1, Width: 2560, Height: 1440}},
+	}
+	if err := writeStates(path, want); err != nil {
+		t.Fatal(err)
+	}
+	states, err = readStates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("readStates() = %+v, want %+v", states, want)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file has not been renamed: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package zeroconf
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// maxQueryInterval is the longest interval between the queries of a browse, which double from a second
+const maxQueryInterval = time.Minute
+
+type browse struct {
+	serviceType string
+	stop        chan struct{}
+	instances   map[string]*instance
+}
+
+// instance is a browsed instance that is reported when its SRV record has been received
+type instance struct {
+	service  Service
+	reported bool
+	ttl      uint32
+	expires  time.Time
+	queried  time.Time
+}
+
+// query queries for the instances of the browse until it is stopped. The resolved instances are sent as known
+// answers, which their responders don't repeat.
+func (z *Zeroconf) query(b *browse) {
+	interval := time.Second
+	for {
+		query := dnsmessage.Message{
+			Questions: []dnsmessage.Question{{
+				Name:  dnsmessage.MustNewName(typeName(b.serviceType)),
+				Type:  dnsmessage.TypePTR,
+				Class: dnsmessage.ClassINET,
+			}},
+		}
+		now := z.now()
+		z.lock.Lock()
+		for _, instance := range b.instances {
+			remaining := instance.expires.Sub(now)
+			if instance.reported && remaining > time.Duration(instance.ttl)*time.Second/2 {
+				query.Answers = append(query.Answers, resource(typeName(b.serviceType), dnsmessage.ClassINET, uint32(remaining/time.Second),
+					&dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(instance.service.instanceName())}))
+			}
+		}
+		z.lock.Unlock()
+		z.sendMessage(query, nil)
+
+		select {
+		case <-b.stop:
+			return
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxQueryInterval)
+	}
+}
+
+// findInstance returns the browsed instance with the name
+func (z *Zeroconf) findInstance(name string) *instance {
+	for _, b := range z.browses {
+		if instance, ok := b.instances[name]; ok {
+			return instance
+		}
+	}
+	return nil
+}
+
+// resolve caches the records of the response for the browses, reports the resolved and changed instances and queries
+// for the records that are missing
+func (z *Zeroconf) resolve(message dnsmessage.Message) {
+	records := append(append([]dnsmessage.Resource{}, message.Answers...), message.Additionals...)
+	now := z.now()
+	var found []Service
+	var questions []dnsmessage.Question
+
+	z.lock.Lock()
+	if len(z.browses) == 0 {
+		z.lock.Unlock()
+		return
+	}
+	for _, record := range records {
+		name := strings.ToLower(record.Header.Name.String())
+		switch body := record.Body.(type) {
+		case *dnsmessage.AResource:
+			z.updateHost(name, net.IP(body.A[:]).String(), record.Header.TTL == 0)
+		case *dnsmessage.AAAAResource:
+			z.updateHost(name, net.IP(body.AAAA[:]).String(), record.Header.TTL == 0)
+		}
+	}
+	for _, record := range records {
+		name := strings.ToLower(record.Header.Name.String())
+		body, ok := record.Body.(*dnsmessage.PTRResource)
+		if !ok {
+			continue
+		}
+		b := z.browses[name]
+		if b == nil {
+			continue
+		}
+		target := body.PTR.String()
+		key := strings.ToLower(target)
+		if !strings.HasSuffix(key, "."+name) {
+			continue
+		}
+		inst := b.instances[key]
+		if record.Header.TTL == 0 {
+			// Goodbyes expire after a second, as recommended by RFC 6762
+			if inst != nil {
+				inst.expires = now.Add(time.Second)
+			}
+			continue
+		}
+		if inst == nil {
+			inst = &instance{service: Service{Instance: target[:len(target)-len(name)-1], Type: b.serviceType}}
+			b.instances[key] = inst
+		}
+		inst.ttl = record.Header.TTL
+		inst.expires = now.Add(time.Duration(record.Header.TTL) * time.Second)
+	}
+	for _, record := range records {
+		inst := z.findInstance(strings.ToLower(record.Header.Name.String()))
+		if inst == nil {
+			continue
+		}
+		switch body := record.Body.(type) {
+		case *dnsmessage.SRVResource:
+			if record.Header.TTL == 0 {
+				continue
+			}
+			inst.service.Host = strings.TrimSuffix(body.Target.String(), ".")
+			inst.service.Port = int(body.Port)
+		case *dnsmessage.TXTResource:
+			inst.service.Text = parseText(body.TXT)
+		}
+	}
+
+	for _, b := range z.browses {
+		for key, inst := range b.instances {
+			if inst.service.Port == 0 {
+				// The SRV and TXT records weren't in the response
+				if now.Sub(inst.queried) > time.Second {
+					inst.queried = now
+					questions = append(questions,
+						dnsmessage.Question{Name: dnsmessage.MustNewName(key), Type: dnsmessage.TypeSRV, Class: dnsmessage.ClassINET},
+						dnsmessage.Question{Name: dnsmessage.MustNewName(key), Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET},
+					)
+				}
+				continue
+			}
+			service := inst.service
+			service.IPs = append([]string{}, z.hosts[strings.ToLower(service.Host)+"."]...)
+			if len(service.IPs) == 0 && now.Sub(inst.queried) > time.Second {
+				inst.queried = now
+				host := dnsmessage.MustNewName(service.Host + ".")
+				questions = append(questions,
+					dnsmessage.Question{Name: host, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+					dnsmessage.Question{Name: host, Type: dnsmessage.TypeAAAA, Class: dnsmessage.ClassINET},
+				)
+			}
+			if !inst.reported || !reflect.DeepEqual(service, inst.service) {
+				inst.reported = true
+				inst.service = service
+				found = append(found, service)
+			}
+		}
+	}
+	z.lock.Unlock()
+
+	if len(questions) > 0 {
+		z.sendMessage(dnsmessage.Message{Questions: questions}, nil)
+	}
+	if z.OnFound != nil {
+		for _, service := range found {
+			z.OnFound(service)
+		}
+	}
+}
+
+// updateHost adds the address to the host or removes it
+func (z *Zeroconf) updateHost(name string, ip string, remove bool) {
+	ips := z.hosts[name]
+	for index, existing := range ips {
+		if existing == ip {
+			if remove {
+				z.hosts[name] = append(ips[:index:index], ips[index+1:]...)
+			}
+			return
+		}
+	}
+	if !remove {
+		ips = append(ips, ip)
+		sort.Strings(ips)
+		z.hosts[name] = ips
+	}
+}
+
+// expire removes the expired instances and reports the resolved ones as lost
+func (z *Zeroconf) expire() {
+	now := z.now()
+	var lost []Service
+	z.lock.Lock()
+	for _, b := range z.browses {
+		for key, inst := range b.instances {
+			if now.Before(inst.expires) {
+				continue
+			}
+			delete(b.instances, key)
+			if inst.reported {
+				lost = append(lost, inst.service)
+			}
+		}
+	}
+	z.lock.Unlock()
+	if z.OnLost != nil {
+		for _, service := range lost {
+			z.OnLost(service)
+		}
+	}
+}
+
+// parseText parses the key value pairs of a TXT record. Keys without value have an empty value.
+func parseText(txt []string) map[string]string {
+	result := map[string]string{}
+	for _, entry := range txt {
+		if entry == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(entry, "=")
+		if _, ok := result[key]; !ok {
+			result[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,301 @@
+package zeroconf
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// hostTTL is the TTL of the records with a host name and serviceTTL of the others, as recommended by RFC 6762
+	hostTTL    = 120
+	serviceTTL = 4500
+
+	// servicesName enumerates the service types of the network
+	servicesName = "_services._dns-sd._udp.local."
+
+	// cacheFlush is the class bit of the unique records of responses and unicastResponse the class bit of the
+	// questions that ask for unicast responses
+	cacheFlush      = 0x8000
+	unicastResponse = 0x8000
+
+	maxProbeAttempts = 20
+)
+
+var (
+	probeInterval    = 250 * time.Millisecond
+	announceInterval = time.Second
+)
+
+func resource(name string, class dnsmessage.Class, ttl uint32, body dnsmessage.ResourceBody) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: class, TTL: ttl},
+		Body:   body,
+	}
+}
+
+func (s Service) ptr() dnsmessage.Resource {
+	return resource(typeName(s.Type), dnsmessage.ClassINET, serviceTTL, &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(s.instanceName())})
+}
+
+func (s Service) srv() dnsmessage.Resource {
+	return resource(s.instanceName(), dnsmessage.ClassINET|cacheFlush, hostTTL, &dnsmessage.SRVResource{
+		Port:   uint16(s.Port),
+		Target: dnsmessage.MustNewName(s.hostName()),
+	})
+}
+
+// txt returns the TXT record of the text, which is sorted by key. Services without text have an empty string.
+func (s Service) txt() dnsmessage.Resource {
+	txt := []string{}
+	for key, value := range s.Text {
+		txt = append(txt, key+"="+value)
+	}
+	if len(txt) == 0 {
+		txt = append(txt, "")
+	}
+	sort.Strings(txt)
+	return resource(s.instanceName(), dnsmessage.ClassINET|cacheFlush, serviceTTL, &dnsmessage.TXTResource{TXT: txt})
+}
+
+func (s Service) addresses(ipv4 bool, ipv6 bool) []dnsmessage.Resource {
+	var result []dnsmessage.Resource
+	for _, value := range s.IPs {
+		ip := net.ParseIP(value)
+		if v4 := ip.To4(); v4 != nil {
+			if ipv4 {
+				a := &dnsmessage.AResource{}
+				copy(a.A[:], v4)
+				result = append(result, resource(s.hostName(), dnsmessage.ClassINET|cacheFlush, hostTTL, a))
+			}
+		} else if ip != nil && ipv6 {
+			aaaa := &dnsmessage.AAAAResource{}
+			copy(aaaa.AAAA[:], ip.To16())
+			result = append(result, resource(s.hostName(), dnsmessage.ClassINET|cacheFlush, hostTTL, aaaa))
+		}
+	}
+	return result
+}
+
+// answer returns the answers and additional records of the advertised services for the questions of the query
+func (z *Zeroconf) answer(query dnsmessage.Message) ([]dnsmessage.Resource, []dnsmessage.Resource) {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	var answers, additionals []dnsmessage.Resource
+	added := map[string]bool{}
+	add := func(records *[]dnsmessage.Resource, record dnsmessage.Resource) {
+		key := record.Header.Name.String() + "/" + record.Body.GoString()
+		if !added[key] {
+			added[key] = true
+			*records = append(*records, record)
+		}
+	}
+	for _, question := range query.Questions {
+		name := strings.ToLower(question.Name.String())
+		any := question.Type == dnsmessage.TypeALL
+		for _, service := range z.advertised {
+			switch {
+			case name == servicesName && (any || question.Type == dnsmessage.TypePTR):
+				add(&answers, resource(servicesName, dnsmessage.ClassINET, serviceTTL, &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(typeName(service.Type))}))
+			case name == typeName(service.Type) && (any || question.Type == dnsmessage.TypePTR):
+				if knownAnswer(query, service.ptr()) {
+					continue
+				}
+				add(&answers, service.ptr())
+				add(&additionals, service.srv())
+				add(&additionals, service.txt())
+				for _, address := range service.addresses(true, true) {
+					add(&additionals, address)
+				}
+			case name == strings.ToLower(service.instanceName()):
+				if any || question.Type == dnsmessage.TypeSRV {
+					add(&answers, service.srv())
+					for _, address := range service.addresses(true, true) {
+						add(&additionals, address)
+					}
+				}
+				if any || question.Type == dnsmessage.TypeTXT {
+					add(&answers, service.txt())
+				}
+			case name == strings.ToLower(service.hostName()):
+				for _, address := range service.addresses(any || question.Type == dnsmessage.TypeA, any || question.Type == dnsmessage.TypeAAAA) {
+					add(&answers, address)
+				}
+			}
+		}
+	}
+	// The answers aren't repeated as additional records
+	result := additionals[:0]
+	for _, record := range additionals {
+		duplicate := false
+		for _, answer := range answers {
+			if answer.Header.Name == record.Header.Name && answer.Body.GoString() == record.Body.GoString() {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, record)
+		}
+	}
+	return answers, result
+}
+
+// knownAnswer reports if the query lists the record with at least half of its TTL, so the answer can be suppressed
+func knownAnswer(query dnsmessage.Message, record dnsmessage.Resource) bool {
+	for _, known := range query.Answers {
+		if strings.EqualFold(known.Header.Name.String(), record.Header.Name.String()) &&
+			known.Body.GoString() == record.Body.GoString() && known.Header.TTL >= record.Header.TTL/2 {
+			return true
+		}
+	}
+	return false
+}
+
+// handle handles a received message
+func (z *Zeroconf) handle(data []byte, from *net.UDPAddr) {
+	var message dnsmessage.Message
+	if message.Unpack(data) != nil {
+		return
+	}
+	if message.Header.Response {
+		z.conflicts(message)
+		z.resolve(message)
+		return
+	}
+	if message.Header.OpCode != 0 {
+		return
+	}
+	answers, additionals := z.answer(message)
+	if len(answers) == 0 {
+		return
+	}
+	response := dnsmessage.Message{
+		Header:      dnsmessage.Header{Response: true, Authoritative: true},
+		Answers:     answers,
+		Additionals: additionals,
+	}
+	var to *net.UDPAddr
+	switch {
+	case from != nil && from.Port != group.Port:
+		// Legacy unicast queries of resolvers get a conventional DNS response
+		response.Header.ID = message.Header.ID
+		response.Questions = message.Questions
+		for _, records := range [][]dnsmessage.Resource{response.Answers, response.Additionals} {
+			for index := range records {
+				records[index].Header.Class &^= cacheFlush
+				records[index].Header.TTL = min(records[index].Header.TTL, 10)
+			}
+		}
+		to = from
+	case from != nil && unicastQuestions(message):
+		to = from
+	}
+	z.sendMessage(response, to)
+}
+
+// unicastQuestions reports if all questions ask for unicast responses
+func unicastQuestions(message dnsmessage.Message) bool {
+	for _, question := range message.Questions {
+		if question.Class&unicastResponse == 0 {
+			return false
+		}
+	}
+	return len(message.Questions) > 0
+}
+
+func (z *Zeroconf) sendMessage(message dnsmessage.Message, to *net.UDPAddr) error {
+	data, err := message.Pack()
+	if err != nil {
+		return err
+	}
+	z.lock.Lock()
+	send := z.send
+	z.lock.Unlock()
+	if send == nil {
+		return ErrClosed
+	}
+	return send(data, to)
+}
+
+// conflicts signals the probes of names that the response has records for
+func (z *Zeroconf) conflicts(message dnsmessage.Message) {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	if len(z.probing) == 0 {
+		return
+	}
+	for _, records := range [][]dnsmessage.Resource{message.Answers, message.Additionals} {
+		for _, record := range records {
+			if record.Header.TTL == 0 {
+				continue
+			}
+			if conflict, ok := z.probing[strings.ToLower(record.Header.Name.String())]; ok {
+				select {
+				case conflict <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// probe queries for the name of the instance three times and reports if another host answers. Simultaneous probes
+// aren't tie broken.
+func (z *Zeroconf) probe(service Service) (bool, error) {
+	name := strings.ToLower(service.instanceName())
+	conflict := make(chan struct{}, 1)
+	z.lock.Lock()
+	if _, ok := z.advertised[name]; ok {
+		z.lock.Unlock()
+		return true, nil
+	}
+	z.probing[name] = conflict
+	z.lock.Unlock()
+	defer func() {
+		z.lock.Lock()
+		delete(z.probing, name)
+		z.lock.Unlock()
+	}()
+
+	query := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(service.instanceName()),
+			Type:  dnsmessage.TypeALL,
+			Class: dnsmessage.ClassINET | unicastResponse,
+		}},
+		Authorities: []dnsmessage.Resource{service.srv()},
+	}
+	for index := 0; index < 3; index++ {
+		err := z.sendMessage(query, nil)
+		if err != nil {
+			return false, err
+		}
+		select {
+		case <-conflict:
+			return true, nil
+		case <-time.After(probeInterval):
+		}
+	}
+	return false, nil
+}
+
+// announce announces the records of the service or says goodbye with a TTL of 0. Goodbyes keep the addresses of the
+// host, which other services can share.
+func (z *Zeroconf) announce(service Service, goodbye bool) error {
+	records := []dnsmessage.Resource{service.ptr(), service.srv(), service.txt()}
+	if !goodbye {
+		records = append(records, service.addresses(true, true)...)
+	} else {
+		for index := range records {
+			records[index].Header.TTL = 0
+		}
+	}
+	return z.sendMessage(dnsmessage.Message{
+		Header:  dnsmessage.Header{Response: true, Authoritative: true},
+		Answers: records,
+	}, nil)
+}
@@ -0,0 +1,91 @@
+package zeroconf
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"golang.org/x/net/ipv4"
+)
+
+// group is the IPv4 multicast group of mDNS. The AAAA records are announced over IPv4.
+var group = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// transport is the multicast socket, which joins the group on every interface
+type transport struct {
+	conn       *net.UDPConn
+	packet     *ipv4.PacketConn
+	interfaces []net.Interface
+
+	// lock serialises the sends, which set the interface of the socket
+	lock sync.Mutex
+}
+
+func listen() (*transport, error) {
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		return nil, fmt.Errorf("zeroconf: %w", err)
+	}
+	packet := ipv4.NewPacketConn(conn)
+	_ = packet.SetMulticastTTL(255)
+	_ = packet.SetMulticastLoopback(true)
+
+	t := &transport{conn: conn, packet: packet}
+	interfaces, _ := net.Interfaces()
+	for _, ifi := range interfaces {
+		if ifi.Flags&net.FlagUp == 0 || ifi.Flags&net.FlagMulticast == 0 || ifi.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		// Joining fails for the interface that ListenMulticastUDP has joined
+		_ = packet.JoinGroup(&ifi, group)
+		t.interfaces = append(t.interfaces, ifi)
+	}
+	return t, nil
+}
+
+// send sends the message to the address or to the group on every interface if it is nil
+func (t *transport) send(data []byte, to *net.UDPAddr) error {
+	if to != nil {
+		_, err := t.conn.WriteToUDP(data, to)
+		return err
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var lastErr error
+	sent := false
+	for index := range t.interfaces {
+		if t.packet.SetMulticastInterface(&t.interfaces[index]) != nil {
+			continue
+		}
+		if _, err := t.conn.WriteToUDP(data, group); err != nil {
+			lastErr = err
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		if _, err := t.conn.WriteToUDP(data, group); err != nil {
+			if lastErr == nil {
+				lastErr = err
+			}
+			return fmt.Errorf("zeroconf: %w", lastErr)
+		}
+	}
+	return nil
+}
+
+// receive passes the messages to the handler until the socket is closed
+func (t *transport) receive(handle func(data []byte, from *net.UDPAddr)) {
+	buffer := make([]byte, 9000)
+	for {
+		n, from, err := t.conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		handle(buffer[:n], from)
+	}
+}
+
+func (t *transport) close() error {
+	return t.conn.Close()
+}
@@ -0,0 +1,365 @@
+// Package zeroconf advertises the application and discovers its peers on the local network with multicast DNS and
+// DNS service discovery, also known as Bonjour, EG: to pair devices or to sync locally. It is implemented in Go
+// without cgo and shares the mDNS port with Avahi and mDNSResponder. Bind the Zeroconf service to use it from JS.
+package zeroconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// The events that are emitted by EmitEvents
+const (
+	// FoundEvent is emitted with a Service when a browsed service is resolved or changes
+	FoundEvent = "zeroconf:found"
+	// LostEvent is emitted with a Service when a browsed service goes away
+	LostEvent = "zeroconf:lost"
+)
+
+// ErrClosed is returned after Close
+var ErrClosed = errors.New("zeroconf: closed")
+
+// Service is a service instance in the local domain
+type Service struct {
+	// Instance is the name of the instance that is shown to users, EG: "Alice's MacBook". It can't contain dots.
+	Instance string `json:"instance"`
+	// Type is the service type, EG: "_myapp._tcp"
+	Type string `json:"type"`
+	// Host is the host name of the instance, EG: "alice.local". It defaults to the host name of the computer.
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// IPs are the addresses of the host. They default to the addresses of the network interfaces.
+	IPs []string `json:"ips"`
+	// Text are the key value pairs of the TXT record, EG: the version of the protocol
+	Text map[string]string `json:"text"`
+}
+
+// Zeroconf is the mDNS service. The socket is opened on first use.
+type Zeroconf struct {
+	// OnFound is called when a browsed service is resolved or changes, including the services of the application
+	OnFound func(service Service)
+	// OnLost is called when a browsed service says goodbye or expires
+	OnLost func(service Service)
+
+	lock       sync.Mutex
+	closed     bool
+	transport  *transport
+	send       func(data []byte, to *net.UDPAddr) error
+	stop       chan struct{}
+	advertised map[string]*Service
+	probing    map[string]chan struct{}
+	browses    map[string]*browse
+	hosts      map[string][]string
+	now        func() time.Time
+}
+
+// New creates the Zeroconf service
+func New() *Zeroconf {
+	return &Zeroconf{
+		stop:       make(chan struct{}),
+		advertised: map[string]*Service{},
+		probing:    map[string]chan struct{}{},
+		browses:    map[string]*browse{},
+		hosts:      map[string][]string{},
+		now:        time.Now,
+	}
+}
+
+// EmitEvents emits the found and lost services as events to the frontend
+func EmitEvents(ctx context.Context, z *Zeroconf) {
+	z.OnFound = func(service Service) {
+		runtime.EventsEmit(ctx, FoundEvent, service)
+	}
+	z.OnLost = func(service Service) {
+		runtime.EventsEmit(ctx, LostEvent, service)
+	}
+}
+
+// start opens the socket unless it is open
+func (z *Zeroconf) start() error {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	if z.closed {
+		return ErrClosed
+	}
+	if z.send != nil {
+		return nil
+	}
+	t, err := listen()
+	if err != nil {
+		return err
+	}
+	z.transport = t
+	z.send = t.send
+	go t.receive(z.handle)
+	go z.maintain()
+	return nil
+}
+
+// maintain expires the browsed services until Close
+func (z *Zeroconf) maintain() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-z.stop:
+			return
+		case <-ticker.C:
+			z.expire()
+		}
+	}
+}
+
+// Close says goodbye for the advertised services, stops browsing and closes the socket
+func (z *Zeroconf) Close() error {
+	z.lock.Lock()
+	if z.closed {
+		z.lock.Unlock()
+		return nil
+	}
+	z.closed = true
+	var services []Service
+	for _, service := range z.advertised {
+		services = append(services, *service)
+	}
+	z.advertised = map[string]*Service{}
+	for name, b := range z.browses {
+		close(b.stop)
+		delete(z.browses, name)
+	}
+	close(z.stop)
+	z.lock.Unlock()
+
+	for _, service := range services {
+		z.announce(service, true)
+	}
+	if z.transport != nil {
+		return z.transport.close()
+	}
+	return nil
+}
+
+// typeName returns the name of the service type, EG: _myapp._tcp.local.
+func typeName(serviceType string) string {
+	return strings.ToLower(serviceType) + ".local."
+}
+
+func (s Service) instanceName() string {
+	return s.Instance + "." + typeName(s.Type)
+}
+
+func (s Service) hostName() string {
+	return strings.TrimSuffix(s.Host, ".") + "."
+}
+
+// validType reports if the service type is valid, EG: _myapp._tcp
+func validType(serviceType string) error {
+	name, protocol, ok := strings.Cut(strings.ToLower(serviceType), ".")
+	if !ok || (protocol != "_tcp" && protocol != "_udp") || len(name) < 2 || len(name) > 16 || name[0] != '_' {
+		return fmt.Errorf("zeroconf: invalid service type %s", serviceType)
+	}
+	for _, r := range name[1:] {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return fmt.Errorf("zeroconf: invalid service type %s", serviceType)
+		}
+	}
+	return nil
+}
+
+// complete validates the service and sets the defaults
+func complete(service Service) (Service, error) {
+	if err := validType(service.Type); err != nil {
+		return service, err
+	}
+	service.Type = strings.ToLower(service.Type)
+	if service.Instance == "" || len(service.Instance) > 63 || strings.Contains(service.Instance, ".") {
+		return service, fmt.Errorf("zeroconf: invalid instance name %q", service.Instance)
+	}
+	if service.Port <= 0 || service.Port > 65535 {
+		return service, fmt.Errorf("zeroconf: invalid port %d", service.Port)
+	}
+	if service.Host == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return service, fmt.Errorf("zeroconf: %w", err)
+		}
+		hostname, _, _ = strings.Cut(hostname, ".")
+		service.Host = hostname + ".local"
+	}
+	if !strings.Contains(strings.TrimSuffix(service.Host, "."), ".") {
+		service.Host += ".local"
+	}
+	if len(service.IPs) == 0 {
+		service.IPs = interfaceIPs()
+	}
+	for _, ip := range service.IPs {
+		if net.ParseIP(ip) == nil {
+			return service, fmt.Errorf("zeroconf: invalid IP %s", ip)
+		}
+	}
+	for key, value := range service.Text {
+		if key == "" || strings.Contains(key, "=") || len(key)+len(value) >= 255 {
+			return service, fmt.Errorf("zeroconf: invalid text key %q", key)
+		}
+	}
+	return service, nil
+}
+
+// interfaceIPs returns the addresses of the network interfaces that are up, without loopback and link local addresses
+func interfaceIPs() []string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var result []string
+	for _, ifi := range interfaces {
+		if ifi.Flags&net.FlagUp == 0 || ifi.Flags&net.FlagLoopback != 0 || ifi.Flags&net.FlagMulticast == 0 {
+			continue
+		}
+		addresses, err := ifi.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			network, ok := address.(*net.IPNet)
+			if !ok || network.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			result = append(result, network.IP.String())
+		}
+	}
+	return result
+}
+
+// Advertise probes for the name of the instance and announces the service. It returns the advertised service, which
+// is renamed, EG: to "Alice's MacBook (2)", when another instance has the name.
+func (z *Zeroconf) Advertise(service Service) (Service, error) {
+	service, err := complete(service)
+	if err != nil {
+		return service, err
+	}
+	err = z.start()
+	if err != nil {
+		return service, err
+	}
+	base := service.Instance
+	for attempt := 2; ; attempt++ {
+		conflict, err := z.probe(service)
+		if err != nil {
+			return service, err
+		}
+		if !conflict {
+			break
+		}
+		if attempt > maxProbeAttempts {
+			return service, fmt.Errorf("zeroconf: the name %s is taken", base)
+		}
+		service.Instance = fmt.Sprintf("%s (%d)", base, attempt)
+		if len(service.Instance) > 63 {
+			return service, fmt.Errorf("zeroconf: the name %s is taken", base)
+		}
+	}
+
+	advertised := service
+	z.lock.Lock()
+	z.advertised[strings.ToLower(service.instanceName())] = &advertised
+	z.lock.Unlock()
+	err = z.announce(service, false)
+	if err != nil {
+		return service, err
+	}
+	// Announce again as multicast isn't reliable
+	go func() {
+		select {
+		case <-z.stop:
+		case <-time.After(announceInterval):
+			z.lock.Lock()
+			current := z.advertised[strings.ToLower(service.instanceName())] == &advertised
+			z.lock.Unlock()
+			if current {
+				z.announce(service, false)
+			}
+		}
+	}()
+	return service, nil
+}
+
+// Unadvertise says goodbye for the advertised instance of the service type
+func (z *Zeroconf) Unadvertise(instance string, serviceType string) error {
+	name := strings.ToLower(instance + "." + typeName(serviceType))
+	z.lock.Lock()
+	service := z.advertised[name]
+	delete(z.advertised, name)
+	z.lock.Unlock()
+	if service == nil {
+		return fmt.Errorf("zeroconf: %s isn't advertised", instance)
+	}
+	return z.announce(*service, true)
+}
+
+// Browse queries for the instances of the service type, which are passed to OnFound and OnLost until StopBrowsing
+func (z *Zeroconf) Browse(serviceType string) error {
+	if err := validType(serviceType); err != nil {
+		return err
+	}
+	err := z.start()
+	if err != nil {
+		return err
+	}
+	name := typeName(serviceType)
+	z.lock.Lock()
+	if _, ok := z.browses[name]; ok {
+		z.lock.Unlock()
+		return nil
+	}
+	b := &browse{
+		serviceType: strings.ToLower(serviceType),
+		stop:        make(chan struct{}),
+		instances:   map[string]*instance{},
+	}
+	z.browses[name] = b
+	z.lock.Unlock()
+	go z.query(b)
+	return nil
+}
+
+// StopBrowsing stops browsing the service type
+func (z *Zeroconf) StopBrowsing(serviceType string) error {
+	name := typeName(serviceType)
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	if b, ok := z.browses[name]; ok {
+		close(b.stop)
+		delete(z.browses, name)
+	}
+	return nil
+}
+
+// Services returns the resolved instances of the browsed service type, sorted by name
+func (z *Zeroconf) Services(serviceType string) []Service {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	result := []Service{}
+	b, ok := z.browses[typeName(serviceType)]
+	if !ok {
+		return result
+	}
+	for _, instance := range b.instances {
+		if instance.reported {
+			result = append(result, instance.service)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Instance < result[j].Instance
+	})
+	return result
+}
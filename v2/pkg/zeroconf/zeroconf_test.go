@@ -0,0 +1,264 @@
+package zeroconf
+
+import (
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type sent struct {
+	message dnsmessage.Message
+	to      *net.UDPAddr
+}
+
+// newTest returns a Zeroconf that records the sent messages instead of opening the socket
+func newTest(t *testing.T) (*Zeroconf, func() []sent) {
+	probeInterval = time.Millisecond
+	z := New()
+	var lock sync.Mutex
+	var messages []sent
+	z.send = func(data []byte, to *net.UDPAddr) error {
+		var message dnsmessage.Message
+		if err := message.Unpack(data); err != nil {
+			t.Errorf("invalid message: %v", err)
+		}
+		lock.Lock()
+		messages = append(messages, sent{message: message, to: to})
+		lock.Unlock()
+		return nil
+	}
+	t.Cleanup(func() { z.Close() })
+	return z, func() []sent {
+		lock.Lock()
+		defer lock.Unlock()
+		result := messages
+		messages = nil
+		return result
+	}
+}
+
+func pack(t *testing.T, message dnsmessage.Message) []byte {
+	data, err := message.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func question(name string, questionType dnsmessage.Type) dnsmessage.Question {
+	return dnsmessage.Question{Name: dnsmessage.MustNewName(name), Type: questionType, Class: dnsmessage.ClassINET}
+}
+
+var peer = &net.UDPAddr{IP: net.IPv4(192, 168, 1, 20), Port: 5353}
+
+var testService = Service{
+	Instance: "Alice's MacBook",
+	Type:     "_myapp._tcp",
+	Host:     "alice.local",
+	Port:     8080,
+	IPs:      []string{"192.168.1.10", "fd00::10"},
+	Text:     map[string]string{"version": "2"},
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name    string
+		service Service
+		wantErr bool
+	}{
+		{name: "valid", service: testService},
+		{name: "type", service: Service{Instance: "App", Type: "myapp._tcp", Port: 1, IPs: []string{"10.0.0.1"}}, wantErr: true},
+		{name: "protocol", service: Service{Instance: "App", Type: "_myapp._sctp", Port: 1, IPs: []string{"10.0.0.1"}}, wantErr: true},
+		{name: "dot", service: Service{Instance: "App 1.0", Type: "_myapp._tcp", Port: 1, IPs: []string{"10.0.0.1"}}, wantErr: true},
+		{name: "port", service: Service{Instance: "App", Type: "_myapp._tcp", IPs: []string{"10.0.0.1"}}, wantErr: true},
+		{name: "ip", service: Service{Instance: "App", Type: "_myapp._tcp", Port: 1, IPs: []string{"alice"}}, wantErr: true},
+		{name: "text", service: Service{Instance: "App", Type: "_myapp._tcp", Port: 1, IPs: []string{"10.0.0.1"}, Text: map[string]string{"a=b": ""}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := complete(tt.service)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	service, err := complete(Service{Instance: "App", Type: "_MyApp._tcp", Host: "alice", Port: 1, IPs: []string{"10.0.0.1"}})
+	if err != nil || service.Host != "alice.local" || service.Type != "_myapp._tcp" {
+		t.Errorf("complete() = %v, %v", service, err)
+	}
+}
+
+func TestAdvertise(t *testing.T) {
+	z, messages := newTest(t)
+	service, err := z.Advertise(testService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Instance != testService.Instance {
+		t.Errorf("Advertise() renamed the instance to %s", service.Instance)
+	}
+	sent := messages()
+	if len(sent) != 4 {
+		t.Fatalf("sent %d messages, want 3 probes and an announcement", len(sent))
+	}
+	probe := sent[0].message
+	if probe.Header.Response || probe.Questions[0].Name.String() != "Alice's MacBook._myapp._tcp.local." || probe.Questions[0].Type != dnsmessage.TypeALL || len(probe.Authorities) != 1 {
+		t.Errorf("invalid probe %v", probe)
+	}
+	announcement := sent[3].message
+	if !announcement.Header.Response || len(announcement.Answers) != 5 {
+		t.Fatalf("invalid announcement %v", announcement)
+	}
+	if txt := announcement.Answers[2].Body.(*dnsmessage.TXTResource); !reflect.DeepEqual(txt.TXT, []string{"version=2"}) {
+		t.Errorf("TXT = %v", txt.TXT)
+	}
+
+	// Browsing for the type answers with the PTR record and the records to resolve it
+	z.handle(pack(t, dnsmessage.Message{Questions: []dnsmessage.Question{question("_myapp._tcp.local.", dnsmessage.TypePTR)}}), peer)
+	sent = messages()
+	if len(sent) != 1 || sent[0].to != nil {
+		t.Fatalf("sent %v, want a multicast response", sent)
+	}
+	response := sent[0].message
+	if len(response.Answers) != 1 || response.Answers[0].Body.(*dnsmessage.PTRResource).PTR.String() != "Alice's MacBook._myapp._tcp.local." {
+		t.Errorf("answers = %v", response.Answers)
+	}
+	if len(response.Additionals) != 4 {
+		t.Errorf("additionals = %v, want SRV, TXT, A and AAAA", response.Additionals)
+	}
+
+	// Known answers are suppressed
+	z.handle(pack(t, dnsmessage.Message{
+		Questions: []dnsmessage.Question{question("_myapp._tcp.local.", dnsmessage.TypePTR)},
+		Answers:   []dnsmessage.Resource{service.ptr()},
+	}), peer)
+	if sent := messages(); len(sent) != 0 {
+		t.Errorf("sent %v for a known answer", sent)
+	}
+
+	// Resolvers get unicast responses
+	resolver := &net.UDPAddr{IP: net.IPv4(192, 168, 1, 20), Port: 40000}
+	z.handle(pack(t, dnsmessage.Message{Header: dnsmessage.Header{ID: 42}, Questions: []dnsmessage.Question{question("alice.local.", dnsmessage.TypeA)}}), resolver)
+	sent = messages()
+	if len(sent) != 1 || sent[0].to != resolver {
+		t.Fatalf("sent %v, want a unicast response", sent)
+	}
+	response = sent[0].message
+	if response.Header.ID != 42 || len(response.Questions) != 1 || len(response.Answers) != 1 || response.Answers[0].Header.TTL != 10 || response.Answers[0].Header.Class != dnsmessage.ClassINET {
+		t.Errorf("invalid legacy response %v", response)
+	}
+
+	if err := z.Unadvertise(service.Instance, service.Type); err != nil {
+		t.Fatal(err)
+	}
+	sent = messages()
+	if len(sent) != 1 || len(sent[0].message.Answers) != 3 || sent[0].message.Answers[0].Header.TTL != 0 {
+		t.Errorf("sent %v, want a goodbye", sent)
+	}
+}
+
+func TestAdvertiseConflict(t *testing.T) {
+	z, messages := newTest(t)
+	// Another host has the name
+	other := testService
+	other.Host = "bob.local"
+	z.send = func(data []byte, to *net.UDPAddr) error {
+		var message dnsmessage.Message
+		message.Unpack(data)
+		if len(message.Questions) > 0 && message.Questions[0].Name.String() == other.instanceName() {
+			go z.handle(pack(t, dnsmessage.Message{Header: dnsmessage.Header{Response: true}, Answers: []dnsmessage.Resource{other.srv()}}), peer)
+		}
+		return nil
+	}
+	probeInterval = 50 * time.Millisecond
+	service, err := z.Advertise(testService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Instance != "Alice's MacBook (2)" {
+		t.Errorf("Advertise() = %s, want Alice's MacBook (2)", service.Instance)
+	}
+	messages()
+}
+
+func TestBrowse(t *testing.T) {
+	z, messages := newTest(t)
+	var lock sync.Mutex
+	now := time.Now()
+	z.now = func() time.Time {
+		lock.Lock()
+		defer lock.Unlock()
+		return now
+	}
+	var found, lost []Service
+	z.OnFound = func(service Service) {
+		lock.Lock()
+		found = append(found, service)
+		lock.Unlock()
+	}
+	z.OnLost = func(service Service) {
+		lock.Lock()
+		lost = append(lost, service)
+		lock.Unlock()
+	}
+	if err := z.Browse("_myapp._tcp"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The PTR record alone queries for the other records
+	z.handle(pack(t, dnsmessage.Message{Header: dnsmessage.Header{Response: true}, Answers: []dnsmessage.Resource{testService.ptr()}}), peer)
+	var resolving bool
+	for _, sent := range messages() {
+		if len(sent.message.Questions) == 2 && sent.message.Questions[0].Type == dnsmessage.TypeSRV {
+			resolving = true
+		}
+	}
+	if !resolving {
+		t.Error("the SRV and TXT records weren't queried")
+	}
+
+	z.handle(pack(t, dnsmessage.Message{
+		Header:      dnsmessage.Header{Response: true},
+		Answers:     []dnsmessage.Resource{testService.srv(), testService.txt()},
+		Additionals: testService.addresses(true, true),
+	}), peer)
+	lock.Lock()
+	if len(found) != 1 || !reflect.DeepEqual(found[0], testService) {
+		t.Fatalf("found %v, want %v", found, testService)
+	}
+	lock.Unlock()
+	if services := z.Services("_myapp._tcp"); len(services) != 1 {
+		t.Errorf("Services() = %v", services)
+	}
+
+	// Goodbyes expire after a second
+	goodbye := testService.ptr()
+	goodbye.Header.TTL = 0
+	z.handle(pack(t, dnsmessage.Message{Header: dnsmessage.Header{Response: true}, Answers: []dnsmessage.Resource{goodbye}}), peer)
+	z.expire()
+	if len(lost) != 0 {
+		t.Fatalf("lost %v before the goodbye expired", lost)
+	}
+	lock.Lock()
+	now = now.Add(2 * time.Second)
+	lock.Unlock()
+	z.expire()
+	if len(lost) != 1 || lost[0].Instance != testService.Instance {
+		t.Errorf("lost %v", lost)
+	}
+	if services := z.Services("_myapp._tcp"); len(services) != 0 {
+		t.Errorf("Services() = %v", services)
+	}
+}
+
+func TestParseText(t *testing.T) {
+	got := parseText([]string{"", "version=2", "flag", "version=3", "path=/a=b"})
+	want := map[string]string{"version": "2", "flag": "", "path": "/a=b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseText() = %v, want %v", got, want)
+	}
+}